@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/usecase"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strings"
+)
+
+const apiKeyMetadata = "x-api-key"
+
+type AuthInterceptor struct {
+	apiKeyUsecase usecase.ApiKey
+}
+
+type AuthInterceptorDeps struct {
+	ApiKeyUsecase usecase.ApiKey
+}
+
+func NewAuthInterceptor(deps AuthInterceptorDeps) *AuthInterceptor {
+	return &AuthInterceptor{
+		apiKeyUsecase: deps.ApiKeyUsecase,
+	}
+}
+
+// Unary reject call without valid api key, what the caller can do is checked by the authorization usecases
+func (ai AuthInterceptor) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := ai.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (ai AuthInterceptor) Stream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := ai.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, authenticatedStream{ServerStream: stream, ctx: ctx})
+}
+
+// authenticate read the key from "authorization: Bearer <key>" or x-api-key metadata, same as the REST api headers.
+// The caller identity is attached to the returned context
+func (ai AuthInterceptor) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	key := firstMetadata(md, apiKeyMetadata)
+
+	scheme, token, found := strings.Cut(firstMetadata(md, "authorization"), " ")
+	if key == "" && found && strings.EqualFold(scheme, "Bearer") {
+		key = strings.TrimSpace(token)
+	}
+
+	if key == "" {
+		return nil, toStatus(internal_error.ErrUnauthorized)
+	}
+
+	caller, err := ai.apiKeyUsecase.Authenticate(ctx, key)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return domain.ContextWithCaller(ctx, caller), nil
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// authenticatedStream replace the context of the stream with the one carrying the caller
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream authenticatedStream) Context() context.Context {
+	return stream.ctx
+}
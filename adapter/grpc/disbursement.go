@@ -0,0 +1,244 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/nobbyphala/Brick/adapter/grpc/pb"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/pii"
+	"github.com/nobbyphala/Brick/external/validator"
+	"github.com/nobbyphala/Brick/usecase"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net/http"
+	"strconv"
+)
+
+const defaultDisbursementPageSize = 100
+
+type DisbursementServer struct {
+	pb.UnimplementedDisbursementServiceServer
+	disbursementUsecase       usecase.Disbursement
+	disbursementStreamUsecase usecase.DisbursementStream
+	validator                 validator.Validator
+	maskingPolicy             pii.Policy
+}
+
+type DisbursementServerDeps struct {
+	DisbursementUsecase       usecase.Disbursement
+	DisbursementStreamUsecase usecase.DisbursementStream
+	// decide which caller role can see unmasked recipient data
+	MaskingPolicy pii.Policy
+}
+
+func NewDisbursementServer(deps DisbursementServerDeps) *DisbursementServer {
+	return &DisbursementServer{
+		disbursementUsecase:       deps.DisbursementUsecase,
+		disbursementStreamUsecase: deps.DisbursementStreamUsecase,
+		validator:                 validator.NewValidator(),
+		maskingPolicy:             deps.MaskingPolicy,
+	}
+}
+
+func (srv DisbursementServer) VerifyDisbursement(ctx context.Context, req *pb.VerifyDisbursementRequest) (*pb.VerifyDisbursementResponse, error) {
+	request := verifyDisbursementRequest{
+		RecipientName:          req.GetRecipientName(),
+		RecipientAccountNumber: req.GetRecipientAccountNumber(),
+		RecipientBankCode:      req.GetRecipientBankCode(),
+		Amount:                 req.GetAmount(),
+	}
+
+	validationErrors := srv.validator.ValidateStruct(request)
+	if validationErrors != nil {
+		return nil, validationStatus(validationErrors)
+	}
+
+	verification, err := srv.disbursementUsecase.VerifyDisbursement(ctx, domain.Disbursement{
+		RecipientName:          request.RecipientName,
+		RecipientAccountNumber: request.RecipientAccountNumber,
+		RecipientBankCode:      request.RecipientBankCode,
+		Amount:                 request.Amount,
+	})
+	if err != nil {
+		// the REST api answer 200 with the reason when the account cannot receive money
+		if internal_error.ErrorStatusCodeMap[err.Error()] == http.StatusOK {
+			return &pb.VerifyDisbursementResponse{Verified: false, Message: err.Error()}, nil
+		}
+
+		return nil, toStatus(err)
+	}
+
+	response := &pb.VerifyDisbursementResponse{
+		Verified:          true,
+		Message:           "disbursement successfully verified",
+		AccountHolderName: verification.AccountHolderName,
+		VerificationToken: verification.Token,
+		NameMatchScore:    int32(verification.NameMatchScore),
+		NameMatchDecision: string(verification.NameMatchDecision),
+	}
+
+	if verification.Token != "" {
+		response.VerificationTokenExpiresAt = timestamppb.New(verification.ExpiresAt)
+	}
+
+	return response, nil
+}
+
+func (srv DisbursementServer) Disburse(ctx context.Context, req *pb.DisburseRequest) (*pb.Disbursement, error) {
+	request := disburseRequest{
+		RecipientName:          req.GetRecipientName(),
+		RecipientAccountNumber: req.GetRecipientAccountNumber(),
+		RecipientBankCode:      req.GetRecipientBankCode(),
+		Amount:                 req.GetAmount(),
+	}
+
+	validationErrors := srv.validator.ValidateStruct(request)
+	if validationErrors != nil {
+		return nil, validationStatus(validationErrors)
+	}
+
+	disbursement, err := srv.disbursementUsecase.Disburse(ctx, domain.Disbursement{
+		RecipientName:          request.RecipientName,
+		RecipientAccountNumber: request.RecipientAccountNumber,
+		RecipientBankCode:      request.RecipientBankCode,
+		Amount:                 request.Amount,
+	}, usecase.DisburseOptions{
+		VerificationToken: req.GetVerificationToken(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return srv.toDisbursement(ctx, disbursement), nil
+}
+
+func (srv DisbursementServer) GetDisbursement(ctx context.Context, req *pb.GetDisbursementRequest) (*pb.Disbursement, error) {
+	disbursement, err := srv.disbursementUsecase.GetDisbursement(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return srv.toDisbursement(ctx, disbursement), nil
+}
+
+func (srv DisbursementServer) ListDisbursements(ctx context.Context, req *pb.ListDisbursementsRequest) (*pb.ListDisbursementsResponse, error) {
+	request := listDisbursementsRequest{
+		PageSize:          int(req.GetPageSize()),
+		NameMatchDecision: req.GetNameMatchDecision(),
+	}
+
+	validationErrors := srv.validator.ValidateStruct(request)
+	if validationErrors != nil {
+		return nil, validationStatus(validationErrors)
+	}
+
+	pageSize := request.PageSize
+	if pageSize == 0 {
+		pageSize = defaultDisbursementPageSize
+	}
+
+	offset, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, validationStatus([]validator.ValidatorError{{Field: "PageToken", Error: "PageToken is invalid"}})
+	}
+
+	disbursements, err := srv.disbursementUsecase.ListDisbursements(ctx, domain.DisbursementFilter{
+		Status:            domain.DisbursementStatus(req.GetStatus()),
+		RecipientBankCode: req.GetRecipientBankCode(),
+		NameMatchDecision: domain.NameMatchDecision(request.NameMatchDecision),
+		Limit:             pageSize,
+		Offset:            offset,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &pb.ListDisbursementsResponse{
+		Disbursements: make([]*pb.Disbursement, 0, len(disbursements)),
+	}
+
+	for _, disbursement := range disbursements {
+		response.Disbursements = append(response.Disbursements, srv.toDisbursement(ctx, disbursement))
+	}
+
+	// a full page may be followed by an empty one
+	if len(disbursements) == pageSize {
+		response.NextPageToken = encodePageToken(offset + pageSize)
+	}
+
+	return response, nil
+}
+
+// WatchDisbursement send the status changes until the client cancel, the usecase closed when the server shutting
+// down or reading the events failed. A client resume by calling again with the last event id it received
+func (srv DisbursementServer) WatchDisbursement(req *pb.WatchDisbursementRequest, stream pb.DisbursementService_WatchDisbursementServer) error {
+	events, err := srv.disbursementStreamUsecase.Subscribe(stream.Context(), usecase.DisbursementStreamFilter{
+		DisbursementId: req.GetDisbursementId(),
+		AfterEventId:   req.GetAfterEventId(),
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+
+	for event := range events {
+		err = stream.Send(&pb.DisbursementStatusEvent{
+			EventId:        event.Id,
+			DisbursementId: event.DisbursementId,
+			Status:         pb.DisbursementStatus(event.Status),
+			PreviousStatus: pb.DisbursementStatus(event.PreviousStatus),
+			Version:        event.Version,
+			CreatedAt:      timestamppb.New(event.CreatedAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// toDisbursement mask the recipient data unless the caller role is allowed to see it
+func (srv DisbursementServer) toDisbursement(ctx context.Context, disbursement domain.Disbursement) *pb.Disbursement {
+	response := &pb.Disbursement{
+		Id:                     disbursement.Id,
+		RecipientName:          disbursement.RecipientName,
+		RecipientAccountNumber: disbursement.RecipientAccountNumber,
+		RecipientBankCode:      disbursement.RecipientBankCode,
+		Amount:                 disbursement.Amount,
+		Status:                 pb.DisbursementStatus(disbursement.Status),
+		BankEvidenceReference:  disbursement.BankEvidenceReference,
+		NameMatchScore:         int32(disbursement.NameMatchScore),
+		NameMatchDecision:      string(disbursement.NameMatchDecision),
+	}
+
+	caller, _ := domain.CallerFromContext(ctx)
+	if srv.maskingPolicy.ShouldMask(string(caller.Role)) {
+		response.RecipientName = pii.MaskName(response.RecipientName)
+		response.RecipientAccountNumber = pii.MaskAccountNumber(response.RecipientAccountNumber)
+	}
+
+	return response
+}
+
+// page token is the opaque offset of the next page
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+
+	offset, err := strconv.Atoi(string(decoded))
+	if err != nil || offset < 0 {
+		return 0, internal_error.ErrInvalidRequest
+	}
+
+	return offset, nil
+}
@@ -0,0 +1,297 @@
+package grpc
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/adapter/grpc/pb"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/pii"
+	mock_usecase "github.com/nobbyphala/Brick/mock/usecase"
+	"github.com/nobbyphala/Brick/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// newTestClient serve the services over an in-memory connection
+func newTestClient(t *testing.T, services Services) pb.DisbursementServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(services)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewDisbursementServiceClient(conn)
+}
+
+func TestDisbursementServer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApiKeyUsecase := mock_usecase.NewMockApiKey(ctrl)
+	mockDisbursementUsecase := mock_usecase.NewMockDisbursement(ctrl)
+	mockDisbursementStreamUsecase := mock_usecase.NewMockDisbursementStream(ctrl)
+
+	client := newTestClient(t, Services{
+		DisbursementServer: NewDisbursementServer(DisbursementServerDeps{
+			DisbursementUsecase:       mockDisbursementUsecase,
+			DisbursementStreamUsecase: mockDisbursementStreamUsecase,
+			MaskingPolicy:             pii.NewPolicy("admin"),
+		}),
+		AuthInterceptor: NewAuthInterceptor(AuthInterceptorDeps{
+			ApiKeyUsecase: mockApiKeyUsecase,
+		}),
+	})
+
+	caller := domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: "merchant-1"}
+	authenticated := metadata.AppendToOutgoingContext(context.TODO(), "authorization", "Bearer brk_key")
+	authenticate := func() {
+		mockApiKeyUsecase.EXPECT().Authenticate(gomock.Any(), "brk_key").Return(caller, nil)
+	}
+	// the usecase receive the caller attached by the interceptor
+	withCaller := gomock.Cond(func(x any) bool {
+		got, _ := domain.CallerFromContext(x.(context.Context))
+		return got == caller
+	})
+	validDisburse := &pb.DisburseRequest{
+		RecipientName:          "Nobby Phala",
+		RecipientAccountNumber: "6789567",
+		RecipientBankCode:      "014",
+		Amount:                 60000,
+	}
+
+	t.Run("disburse", func(t *testing.T) {
+		authenticate()
+		mockDisbursementUsecase.EXPECT().Disburse(withCaller, domain.Disbursement{
+			RecipientName:          "Nobby Phala",
+			RecipientAccountNumber: "6789567",
+			RecipientBankCode:      "014",
+			Amount:                 60000,
+		}, usecase.DisburseOptions{}).Return(domain.Disbursement{
+			Id:                     "disb-id-1",
+			RecipientName:          "Nobby Phala",
+			RecipientAccountNumber: "6789567",
+			RecipientBankCode:      "014",
+			Amount:                 60000,
+			Status:                 domain.DisbursementStatusPending,
+		}, nil)
+
+		got, err := client.Disburse(authenticated, validDisburse)
+		assert.NoError(t, err)
+		assert.Equal(t, "disb-id-1", got.Id)
+		assert.Equal(t, "N**** P****", got.RecipientName)
+		assert.Equal(t, pb.DisbursementStatus_DISBURSEMENT_STATUS_PENDING, got.Status)
+	})
+
+	t.Run("missing api key", func(t *testing.T) {
+		_, err := client.Disburse(context.TODO(), validDisburse)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Equal(t, internal_error.ErrUnauthorized.Error(), status.Convert(err).Message())
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		authenticate()
+
+		_, err := client.Disburse(authenticated, &pb.DisburseRequest{RecipientName: "Nobby Phala", RecipientAccountNumber: "abc", RecipientBankCode: "014"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		details := status.Convert(err).Details()
+		assert.Len(t, details, 1)
+		assert.Equal(t, "RecipientAccountNumber", details[0].(*errdetails.BadRequest).FieldViolations[0].Field)
+	})
+
+	t.Run("amount not positive", func(t *testing.T) {
+		authenticate()
+
+		_, err := client.Disburse(authenticated, &pb.DisburseRequest{RecipientName: "Nobby Phala", RecipientAccountNumber: "6789567", RecipientBankCode: "014", Amount: -60000})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		details := status.Convert(err).Details()
+		assert.Equal(t, "Amount", details[0].(*errdetails.BadRequest).FieldViolations[0].Field)
+	})
+
+	t.Run("domain errors are mapped to status codes", func(t *testing.T) {
+		for err, want := range map[error]codes.Code{
+			internal_error.ErrForbidden:                  codes.PermissionDenied,
+			internal_error.ErrMerchantDailyLimitExceeded: codes.InvalidArgument,
+			internal_error.ErrDisburseBankError:          codes.Unavailable,
+			internal_error.ErrDisburseDisbursement:       codes.Internal,
+		} {
+			authenticate()
+			mockDisbursementUsecase.EXPECT().Disburse(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.Disbursement{}, err)
+
+			_, got := client.Disburse(authenticated, validDisburse)
+			assert.Equal(t, want, status.Code(got), err.Error())
+			assert.Equal(t, err.Error(), status.Convert(got).Message())
+		}
+	})
+
+	t.Run("verify and disburse with the verification token", func(t *testing.T) {
+		expiresAt := time.Date(2024, 1, 1, 0, 5, 0, 0, time.UTC)
+
+		authenticate()
+		mockDisbursementUsecase.EXPECT().VerifyDisbursement(withCaller, gomock.Any()).Return(usecase.DisbursementVerification{
+			AccountHolderName: "Nobby Phala Putra",
+			Token:             "v1.1704067500.abcd",
+			ExpiresAt:         expiresAt,
+		}, nil)
+
+		verified, err := client.VerifyDisbursement(authenticated, &pb.VerifyDisbursementRequest{
+			RecipientName:          "Nobby Phala",
+			RecipientAccountNumber: "6789567",
+			RecipientBankCode:      "014",
+			Amount:                 60000,
+		})
+		assert.NoError(t, err)
+		assert.True(t, verified.Verified)
+		assert.Equal(t, "Nobby Phala Putra", verified.AccountHolderName)
+		assert.Equal(t, expiresAt, verified.VerificationTokenExpiresAt.AsTime())
+
+		authenticate()
+		mockDisbursementUsecase.EXPECT().Disburse(withCaller, gomock.Any(), usecase.DisburseOptions{VerificationToken: "v1.1704067500.abcd"}).Return(domain.Disbursement{Id: "disb-id-1"}, nil)
+
+		disburse := &pb.DisburseRequest{
+			RecipientName:          "Nobby Phala",
+			RecipientAccountNumber: "6789567",
+			RecipientBankCode:      "014",
+			Amount:                 60000,
+			VerificationToken:      verified.VerificationToken,
+		}
+		_, err = client.Disburse(authenticated, disburse)
+		assert.NoError(t, err)
+	})
+
+	t.Run("verify account not found is not an error", func(t *testing.T) {
+		authenticate()
+		mockDisbursementUsecase.EXPECT().VerifyDisbursement(withCaller, gomock.Any()).Return(usecase.DisbursementVerification{}, internal_error.ErrVerifyAccountNotFound)
+
+		got, err := client.VerifyDisbursement(authenticated, &pb.VerifyDisbursementRequest{
+			RecipientName:          "Nobby Phala",
+			RecipientAccountNumber: "6789567",
+			RecipientBankCode:      "014",
+			Amount:                 60000,
+		})
+		assert.NoError(t, err)
+		assert.False(t, got.Verified)
+		assert.Equal(t, internal_error.ErrVerifyAccountNotFound.Error(), got.Message)
+	})
+
+	t.Run("get disbursement not found", func(t *testing.T) {
+		authenticate()
+		mockDisbursementUsecase.EXPECT().GetDisbursement(withCaller, "disb-id-2").Return(domain.Disbursement{}, internal_error.ErrDisbursementNotFound)
+
+		_, err := client.GetDisbursement(authenticated, &pb.GetDisbursementRequest{Id: "disb-id-2"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("list disbursements by page", func(t *testing.T) {
+		authenticate()
+		mockDisbursementUsecase.EXPECT().ListDisbursements(withCaller, domain.DisbursementFilter{
+			Status: domain.DisbursementStatusCompleted,
+			Limit:  2,
+		}).Return([]domain.Disbursement{{Id: "disb-id-3"}, {Id: "disb-id-2"}}, nil)
+
+		got, err := client.ListDisbursements(authenticated, &pb.ListDisbursementsRequest{
+			Status:   pb.DisbursementStatus_DISBURSEMENT_STATUS_COMPLETED,
+			PageSize: 2,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, got.Disbursements, 2)
+		assert.NotEmpty(t, got.NextPageToken)
+
+		authenticate()
+		mockDisbursementUsecase.EXPECT().ListDisbursements(withCaller, domain.DisbursementFilter{
+			Status: domain.DisbursementStatusCompleted,
+			Limit:  2,
+			Offset: 2,
+		}).Return([]domain.Disbursement{{Id: "disb-id-1"}}, nil)
+
+		got, err = client.ListDisbursements(authenticated, &pb.ListDisbursementsRequest{
+			Status:    pb.DisbursementStatus_DISBURSEMENT_STATUS_COMPLETED,
+			PageSize:  2,
+			PageToken: got.NextPageToken,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "disb-id-1", got.Disbursements[0].Id)
+		assert.Empty(t, got.NextPageToken)
+	})
+
+	t.Run("list disbursements flagged for review", func(t *testing.T) {
+		authenticate()
+		mockDisbursementUsecase.EXPECT().ListDisbursements(withCaller, domain.DisbursementFilter{
+			NameMatchDecision: domain.NameMatchReview,
+			Limit:             100,
+		}).Return([]domain.Disbursement{{Id: "disb-id-4", NameMatchScore: 72, NameMatchDecision: domain.NameMatchReview}}, nil)
+
+		got, err := client.ListDisbursements(authenticated, &pb.ListDisbursementsRequest{NameMatchDecision: "REVIEW"})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(72), got.Disbursements[0].NameMatchScore)
+		assert.Equal(t, "REVIEW", got.Disbursements[0].NameMatchDecision)
+	})
+
+	t.Run("list disbursements invalid name match decision", func(t *testing.T) {
+		authenticate()
+
+		_, err := client.ListDisbursements(authenticated, &pb.ListDisbursementsRequest{NameMatchDecision: "MAYBE"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("list disbursements invalid page token", func(t *testing.T) {
+		authenticate()
+
+		_, err := client.ListDisbursements(authenticated, &pb.ListDisbursementsRequest{PageToken: "not a token"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("watch disbursement", func(t *testing.T) {
+		createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		events := make(chan domain.DisbursementStatusEvent, 1)
+		events <- domain.DisbursementStatusEvent{Id: 11, DisbursementId: "disb-id-1", Status: domain.DisbursementStatusCompleted, PreviousStatus: domain.DisbursementStatusPending, Version: 2, CreatedAt: createdAt}
+		close(events)
+
+		authenticate()
+		mockDisbursementStreamUsecase.EXPECT().Subscribe(withCaller, usecase.DisbursementStreamFilter{DisbursementId: "disb-id-1", AfterEventId: 10}).Return(events, nil)
+
+		stream, err := client.WatchDisbursement(authenticated, &pb.WatchDisbursementRequest{DisbursementId: "disb-id-1", AfterEventId: 10})
+		assert.NoError(t, err)
+
+		got, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(11), got.EventId)
+		assert.Equal(t, pb.DisbursementStatus_DISBURSEMENT_STATUS_COMPLETED, got.Status)
+		assert.Equal(t, pb.DisbursementStatus_DISBURSEMENT_STATUS_PENDING, got.PreviousStatus)
+		assert.Equal(t, createdAt, got.CreatedAt.AsTime())
+
+		_, err = stream.Recv()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("watch unknown disbursement", func(t *testing.T) {
+		authenticate()
+		mockDisbursementStreamUsecase.EXPECT().Subscribe(gomock.Any(), usecase.DisbursementStreamFilter{DisbursementId: "disb-id-2"}).Return(nil, internal_error.ErrDisbursementNotFound)
+
+		stream, err := client.WatchDisbursement(authenticated, &pb.WatchDisbursementRequest{DisbursementId: "disb-id-2"})
+		assert.NoError(t, err)
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+)
+
+// codeByHttpStatus follow internal_error.ErrorStatusCodeMap so an error get the same meaning on both transports
+var codeByHttpStatus = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.FailedPrecondition,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// codeByError is used when the http status is too coarse for the client to decide whether to retry
+var codeByError = map[string]codes.Code{
+	internal_error.ErrDisburseBankError.Error(): codes.Unavailable,
+	internal_error.ErrVersionConflict.Error():   codes.Aborted,
+}
+
+// toStatus return the status of the error returned by a usecase, the message is the same as the REST api
+func toStatus(err error) error {
+	code, exists := codeByError[err.Error()]
+	if !exists {
+		code = codes.Internal
+
+		httpStatus, exists := internal_error.ErrorStatusCodeMap[err.Error()]
+		if exists {
+			if mapped, ok := codeByHttpStatus[httpStatus]; ok {
+				code = mapped
+			}
+		}
+	}
+
+	return status.Error(code, err.Error())
+}
+
+// validationStatus attach every invalid field as BadRequest detail
+func validationStatus(errors []validator.ValidatorError) error {
+	badRequest := &errdetails.BadRequest{}
+
+	for _, err := range errors {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       err.Field,
+			Description: err.Error,
+		})
+	}
+
+	st, err := status.New(codes.InvalidArgument, internal_error.ErrInvalidRequest.Error()).WithDetails(badRequest)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, internal_error.ErrInvalidRequest.Error())
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,1161 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: adapter/grpc/pb/disbursement.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DisbursementStatus int32
+
+const (
+	DisbursementStatus_DISBURSEMENT_STATUS_UNSPECIFIED DisbursementStatus = 0
+	DisbursementStatus_DISBURSEMENT_STATUS_PENDING     DisbursementStatus = 1
+	DisbursementStatus_DISBURSEMENT_STATUS_COMPLETED   DisbursementStatus = 2
+	DisbursementStatus_DISBURSEMENT_STATUS_FAILED      DisbursementStatus = 3
+	DisbursementStatus_DISBURSEMENT_STATUS_REJECTED    DisbursementStatus = 4
+	// held by the sanctions screening until compliance release or reject it
+	DisbursementStatus_DISBURSEMENT_STATUS_ON_HOLD DisbursementStatus = 5
+)
+
+// Enum value maps for DisbursementStatus.
+var (
+	DisbursementStatus_name = map[int32]string{
+		0: "DISBURSEMENT_STATUS_UNSPECIFIED",
+		1: "DISBURSEMENT_STATUS_PENDING",
+		2: "DISBURSEMENT_STATUS_COMPLETED",
+		3: "DISBURSEMENT_STATUS_FAILED",
+		4: "DISBURSEMENT_STATUS_REJECTED",
+		5: "DISBURSEMENT_STATUS_ON_HOLD",
+	}
+	DisbursementStatus_value = map[string]int32{
+		"DISBURSEMENT_STATUS_UNSPECIFIED": 0,
+		"DISBURSEMENT_STATUS_PENDING":     1,
+		"DISBURSEMENT_STATUS_COMPLETED":   2,
+		"DISBURSEMENT_STATUS_FAILED":      3,
+		"DISBURSEMENT_STATUS_REJECTED":    4,
+		"DISBURSEMENT_STATUS_ON_HOLD":     5,
+	}
+)
+
+func (x DisbursementStatus) Enum() *DisbursementStatus {
+	p := new(DisbursementStatus)
+	*p = x
+	return p
+}
+
+func (x DisbursementStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DisbursementStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_adapter_grpc_pb_disbursement_proto_enumTypes[0].Descriptor()
+}
+
+func (DisbursementStatus) Type() protoreflect.EnumType {
+	return &file_adapter_grpc_pb_disbursement_proto_enumTypes[0]
+}
+
+func (x DisbursementStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DisbursementStatus.Descriptor instead.
+func (DisbursementStatus) EnumDescriptor() ([]byte, []int) {
+	return file_adapter_grpc_pb_disbursement_proto_rawDescGZIP(), []int{0}
+}
+
+type Disbursement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// recipient name and account number are masked unless the caller role is allowed to see them
+	RecipientName          string             `protobuf:"bytes,2,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	RecipientAccountNumber string             `protobuf:"bytes,3,opt,name=recipient_account_number,json=recipientAccountNumber,proto3" json:"recipient_account_number,omitempty"`
+	RecipientBankCode      string             `protobuf:"bytes,4,opt,name=recipient_bank_code,json=recipientBankCode,proto3" json:"recipient_bank_code,omitempty"`
+	Amount                 int64              `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status                 DisbursementStatus `protobuf:"varint,6,opt,name=status,proto3,enum=brick.disbursement.v1.DisbursementStatus" json:"status,omitempty"`
+	BankEvidenceReference  string             `protobuf:"bytes,7,opt,name=bank_evidence_reference,json=bankEvidenceReference,proto3" json:"bank_evidence_reference,omitempty"`
+	// similarity of the recipient name to the bank account holder name, 0 to 100
+	NameMatchScore int32 `protobuf:"varint,8,opt,name=name_match_score,json=nameMatchScore,proto3" json:"name_match_score,omitempty"`
+	// ACCEPTED or REVIEW, empty for the disbursement created before the name matching
+	NameMatchDecision string `protobuf:"bytes,9,opt,name=name_match_decision,json=nameMatchDecision,proto3" json:"name_match_decision,omitempty"`
+}
+
+func (x *Disbursement) Reset() {
+	*x = Disbursement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Disbursement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Disbursement) ProtoMessage() {}
+
+func (x *Disbursement) ProtoReflect() protoreflect.Message {
+	mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Disbursement.ProtoReflect.Descriptor instead.
+func (*Disbursement) Descriptor() ([]byte, []int) {
+	return file_adapter_grpc_pb_disbursement_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Disbursement) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Disbursement) GetRecipientName() string {
+	if x != nil {
+		return x.RecipientName
+	}
+	return ""
+}
+
+func (x *Disbursement) GetRecipientAccountNumber() string {
+	if x != nil {
+		return x.RecipientAccountNumber
+	}
+	return ""
+}
+
+func (x *Disbursement) GetRecipientBankCode() string {
+	if x != nil {
+		return x.RecipientBankCode
+	}
+	return ""
+}
+
+func (x *Disbursement) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Disbursement) GetStatus() DisbursementStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DisbursementStatus_DISBURSEMENT_STATUS_UNSPECIFIED
+}
+
+func (x *Disbursement) GetBankEvidenceReference() string {
+	if x != nil {
+		return x.BankEvidenceReference
+	}
+	return ""
+}
+
+func (x *Disbursement) GetNameMatchScore() int32 {
+	if x != nil {
+		return x.NameMatchScore
+	}
+	return 0
+}
+
+func (x *Disbursement) GetNameMatchDecision() string {
+	if x != nil {
+		return x.NameMatchDecision
+	}
+	return ""
+}
+
+type VerifyDisbursementRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecipientName          string `protobuf:"bytes,1,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	RecipientAccountNumber string `protobuf:"bytes,2,opt,name=recipient_account_number,json=recipientAccountNumber,proto3" json:"recipient_account_number,omitempty"`
+	RecipientBankCode      string `protobuf:"bytes,3,opt,name=recipient_bank_code,json=recipientBankCode,proto3" json:"recipient_bank_code,omitempty"`
+	Amount                 int64  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *VerifyDisbursementRequest) Reset() {
+	*x = VerifyDisbursementRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyDisbursementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDisbursementRequest) ProtoMessage() {}
+
+func (x *VerifyDisbursementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDisbursementRequest.ProtoReflect.Descriptor instead.
+func (*VerifyDisbursementRequest) Descriptor() ([]byte, []int) {
+	return file_adapter_grpc_pb_disbursement_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VerifyDisbursementRequest) GetRecipientName() string {
+	if x != nil {
+		return x.RecipientName
+	}
+	return ""
+}
+
+func (x *VerifyDisbursementRequest) GetRecipientAccountNumber() string {
+	if x != nil {
+		return x.RecipientAccountNumber
+	}
+	return ""
+}
+
+func (x *VerifyDisbursementRequest) GetRecipientBankCode() string {
+	if x != nil {
+		return x.RecipientBankCode
+	}
+	return ""
+}
+
+func (x *VerifyDisbursementRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// account not found or blocked is not an error, verified is false and message tell the reason
+type VerifyDisbursementResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Verified bool   `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
+	Message  string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// name of the account owner reported by the bank, not masked
+	AccountHolderName string `protobuf:"bytes,3,opt,name=account_holder_name,json=accountHolderName,proto3" json:"account_holder_name,omitempty"`
+	// sent with the disbursement of the same recipient and amount to skip verifying it again,
+	// empty when verification token is disabled
+	VerificationToken          string                 `protobuf:"bytes,4,opt,name=verification_token,json=verificationToken,proto3" json:"verification_token,omitempty"`
+	VerificationTokenExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=verification_token_expires_at,json=verificationTokenExpiresAt,proto3" json:"verification_token_expires_at,omitempty"`
+	NameMatchScore             int32                  `protobuf:"varint,6,opt,name=name_match_score,json=nameMatchScore,proto3" json:"name_match_score,omitempty"`
+	// ACCEPTED or REVIEW, a REJECTED name is not verified
+	NameMatchDecision string `protobuf:"bytes,7,opt,name=name_match_decision,json=nameMatchDecision,proto3" json:"name_match_decision,omitempty"`
+}
+
+func (x *VerifyDisbursementResponse) Reset() {
+	*x = VerifyDisbursementResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyDisbursementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDisbursementResponse) ProtoMessage() {}
+
+func (x *VerifyDisbursementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDisbursementResponse.ProtoReflect.Descriptor instead.
+func (*VerifyDisbursementResponse) Descriptor() ([]byte, []int) {
+	return file_adapter_grpc_pb_disbursement_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *VerifyDisbursementResponse) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *VerifyDisbursementResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *VerifyDisbursementResponse) GetAccountHolderName() string {
+	if x != nil {
+		return x.AccountHolderName
+	}
+	return ""
+}
+
+func (x *VerifyDisbursementResponse) GetVerificationToken() string {
+	if x != nil {
+		return x.VerificationToken
+	}
+	return ""
+}
+
+func (x *VerifyDisbursementResponse) GetVerificationTokenExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.VerificationTokenExpiresAt
+	}
+	return nil
+}
+
+func (x *VerifyDisbursementResponse) GetNameMatchScore() int32 {
+	if x != nil {
+		return x.NameMatchScore
+	}
+	return 0
+}
+
+func (x *VerifyDisbursementResponse) GetNameMatchDecision() string {
+	if x != nil {
+		return x.NameMatchDecision
+	}
+	return ""
+}
+
+type DisburseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecipientName          string `protobuf:"bytes,1,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	RecipientAccountNumber string `protobuf:"bytes,2,opt,name=recipient_account_number,json=recipientAccountNumber,proto3" json:"recipient_account_number,omitempty"`
+	RecipientBankCode      string `protobuf:"bytes,3,opt,name=recipient_bank_code,json=recipientBankCode,proto3" json:"recipient_bank_code,omitempty"`
+	Amount                 int64  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	// returned by VerifyDisbursement, the recipient is verified again when empty, expired or not matching
+	VerificationToken string `protobuf:"bytes,5,opt,name=verification_token,json=verificationToken,proto3" json:"verification_token,omitempty"`
+}
+
+func (x *DisburseRequest) Reset() {
+	*x = DisburseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisburseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisburseRequest) ProtoMessage() {}
+
+func (x *DisburseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisburseRequest.ProtoReflect.Descriptor instead.
+func (*DisburseRequest) Descriptor() ([]byte, []int) {
+	return file_adapter_grpc_pb_disbursement_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DisburseRequest) GetRecipientName() string {
+	if x != nil {
+		return x.RecipientName
+	}
+	return ""
+}
+
+func (x *DisburseRequest) GetRecipientAccountNumber() string {
+	if x != nil {
+		return x.RecipientAccountNumber
+	}
+	return ""
+}
+
+func (x *DisburseRequest) GetRecipientBankCode() string {
+	if x != nil {
+		return x.RecipientBankCode
+	}
+	return ""
+}
+
+func (x *DisburseRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *DisburseRequest) GetVerificationToken() string {
+	if x != nil {
+		return x.VerificationToken
+	}
+	return ""
+}
+
+type GetDisbursementRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetDisbursementRequest) Reset() {
+	*x = GetDisbursementRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDisbursementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDisbursementRequest) ProtoMessage() {}
+
+func (x *GetDisbursementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDisbursementRequest.ProtoReflect.Descriptor instead.
+func (*GetDisbursementRequest) Descriptor() ([]byte, []int) {
+	return file_adapter_grpc_pb_disbursement_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetDisbursementRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListDisbursementsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// unspecified for every status
+	Status            DisbursementStatus `protobuf:"varint,1,opt,name=status,proto3,enum=brick.disbursement.v1.DisbursementStatus" json:"status,omitempty"`
+	RecipientBankCode string             `protobuf:"bytes,2,opt,name=recipient_bank_code,json=recipientBankCode,proto3" json:"recipient_bank_code,omitempty"`
+	// at most 100, default 100
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page, empty for the first page
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// ACCEPTED, REVIEW or REJECTED, empty for every decision
+	NameMatchDecision string `protobuf:"bytes,5,opt,name=name_match_decision,json=nameMatchDecision,proto3" json:"name_match_decision,omitempty"`
+}
+
+func (x *ListDisbursementsRequest) Reset() {
+	*x = ListDisbursementsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDisbursementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDisbursementsRequest) ProtoMessage() {}
+
+func (x *ListDisbursementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDisbursementsRequest.ProtoReflect.Descriptor instead.
+func (*ListDisbursementsRequest) Descriptor() ([]byte, []int) {
+	return file_adapter_grpc_pb_disbursement_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListDisbursementsRequest) GetStatus() DisbursementStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DisbursementStatus_DISBURSEMENT_STATUS_UNSPECIFIED
+}
+
+func (x *ListDisbursementsRequest) GetRecipientBankCode() string {
+	if x != nil {
+		return x.RecipientBankCode
+	}
+	return ""
+}
+
+func (x *ListDisbursementsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDisbursementsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListDisbursementsRequest) GetNameMatchDecision() string {
+	if x != nil {
+		return x.NameMatchDecision
+	}
+	return ""
+}
+
+type ListDisbursementsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// newest first
+	Disbursements []*Disbursement `protobuf:"bytes,1,rep,name=disbursements,proto3" json:"disbursements,omitempty"`
+	// empty when there is no more page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListDisbursementsResponse) Reset() {
+	*x = ListDisbursementsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDisbursementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDisbursementsResponse) ProtoMessage() {}
+
+func (x *ListDisbursementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDisbursementsResponse.ProtoReflect.Descriptor instead.
+func (*ListDisbursementsResponse) Descriptor() ([]byte, []int) {
+	return file_adapter_grpc_pb_disbursement_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListDisbursementsResponse) GetDisbursements() []*Disbursement {
+	if x != nil {
+		return x.Disbursements
+	}
+	return nil
+}
+
+func (x *ListDisbursementsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type WatchDisbursementRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// empty to watch every disbursement the caller can read
+	DisbursementId string `protobuf:"bytes,1,opt,name=disbursement_id,json=disbursementId,proto3" json:"disbursement_id,omitempty"`
+	// resume after the event, 0 to watch only the changes committed after the call
+	AfterEventId int64 `protobuf:"varint,2,opt,name=after_event_id,json=afterEventId,proto3" json:"after_event_id,omitempty"`
+}
+
+func (x *WatchDisbursementRequest) Reset() {
+	*x = WatchDisbursementRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchDisbursementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDisbursementRequest) ProtoMessage() {}
+
+func (x *WatchDisbursementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDisbursementRequest.ProtoReflect.Descriptor instead.
+func (*WatchDisbursementRequest) Descriptor() ([]byte, []int) {
+	return file_adapter_grpc_pb_disbursement_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WatchDisbursementRequest) GetDisbursementId() string {
+	if x != nil {
+		return x.DisbursementId
+	}
+	return ""
+}
+
+func (x *WatchDisbursementRequest) GetAfterEventId() int64 {
+	if x != nil {
+		return x.AfterEventId
+	}
+	return 0
+}
+
+type DisbursementStatusEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventId        int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	DisbursementId string                 `protobuf:"bytes,2,opt,name=disbursement_id,json=disbursementId,proto3" json:"disbursement_id,omitempty"`
+	Status         DisbursementStatus     `protobuf:"varint,3,opt,name=status,proto3,enum=brick.disbursement.v1.DisbursementStatus" json:"status,omitempty"`
+	PreviousStatus DisbursementStatus     `protobuf:"varint,4,opt,name=previous_status,json=previousStatus,proto3,enum=brick.disbursement.v1.DisbursementStatus" json:"previous_status,omitempty"`
+	Version        int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *DisbursementStatusEvent) Reset() {
+	*x = DisbursementStatusEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisbursementStatusEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisbursementStatusEvent) ProtoMessage() {}
+
+func (x *DisbursementStatusEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_adapter_grpc_pb_disbursement_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisbursementStatusEvent.ProtoReflect.Descriptor instead.
+func (*DisbursementStatusEvent) Descriptor() ([]byte, []int) {
+	return file_adapter_grpc_pb_disbursement_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DisbursementStatusEvent) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *DisbursementStatusEvent) GetDisbursementId() string {
+	if x != nil {
+		return x.DisbursementId
+	}
+	return ""
+}
+
+func (x *DisbursementStatusEvent) GetStatus() DisbursementStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DisbursementStatus_DISBURSEMENT_STATUS_UNSPECIFIED
+}
+
+func (x *DisbursementStatusEvent) GetPreviousStatus() DisbursementStatus {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return DisbursementStatus_DISBURSEMENT_STATUS_UNSPECIFIED
+}
+
+func (x *DisbursementStatusEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DisbursementStatusEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_adapter_grpc_pb_disbursement_proto protoreflect.FileDescriptor
+
+var file_adapter_grpc_pb_disbursement_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x61, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70,
+	0x62, 0x2f, 0x64, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x62, 0x72, 0x69, 0x63, 0x6b, 0x2e, 0x64, 0x69, 0x73, 0x62,
+	0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9c, 0x03, 0x0a,
+	0x0c, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x25, 0x0a,
+	0x0e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x18, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e,
+	0x74, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e,
+	0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2e,
+	0x0a, 0x13, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x61, 0x6e, 0x6b,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x6e, 0x6b, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x41, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x62, 0x72, 0x69, 0x63, 0x6b, 0x2e, 0x64,
+	0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x62, 0x61, 0x6e,
+	0x6b, 0x5f, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x62, 0x61, 0x6e, 0x6b,
+	0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x12, 0x28, 0x0a, 0x10, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6e, 0x61, 0x6d,
+	0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x6e,
+	0x61, 0x6d, 0x65, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6e, 0x61, 0x6d, 0x65, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xc4, 0x01, 0x0a, 0x19,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x38, 0x0a, 0x18, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x16, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x61, 0x6e, 0x6b, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65,
+	0x6e, 0x74, 0x42, 0x61, 0x6e, 0x6b, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0xea, 0x02, 0x0a, 0x1a, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x44, 0x69, 0x73,
+	0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x5f, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x6f, 0x6c,
+	0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x5d, 0x0a, 0x1d, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x1a, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x45, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0e, 0x6e, 0x61, 0x6d, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x2e, 0x0a, 0x13, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x64, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6e, 0x61,
+	0x6d, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+	0xe9, 0x01, 0x0a, 0x0f, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x18, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e,
+	0x74, 0x5f, 0x62, 0x61, 0x6e, 0x6b, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x6e, 0x6b,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x12,
+	0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x28, 0x0a, 0x16, 0x47,
+	0x65, 0x74, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xf9, 0x01, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69,
+	0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x41, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x29, 0x2e, 0x62, 0x72, 0x69, 0x63, 0x6b, 0x2e, 0x64, 0x69, 0x73, 0x62, 0x75,
+	0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x62, 0x75,
+	0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65,
+	0x6e, 0x74, 0x5f, 0x62, 0x61, 0x6e, 0x6b, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x6e,
+	0x6b, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x6e, 0x61, 0x6d, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x22, 0x8e, 0x01, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72,
+	0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x49, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x62, 0x72, 0x69, 0x63, 0x6b, 0x2e, 0x64,
+	0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0d, 0x64, 0x69, 0x73,
+	0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65,
+	0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x22, 0x69, 0x0a, 0x18, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x69, 0x73, 0x62, 0x75,
+	0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27,
+	0x0a, 0x0f, 0x64, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x66, 0x74, 0x65, 0x72,
+	0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x61, 0x66, 0x74, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0xc9, 0x02,
+	0x0a, 0x17, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64,
+	0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x41, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e,
+	0x62, 0x72, 0x69, 0x63, 0x6b, 0x2e, 0x64, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x52, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x62, 0x72, 0x69, 0x63,
+	0x6b, 0x2e, 0x64, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x0e, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39,
+	0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x2a, 0xe0, 0x01, 0x0a, 0x12, 0x44, 0x69,
+	0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x23, 0x0a, 0x1f, 0x44, 0x49, 0x53, 0x42, 0x55, 0x52, 0x53, 0x45, 0x4d, 0x45, 0x4e, 0x54,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1f, 0x0a, 0x1b, 0x44, 0x49, 0x53, 0x42, 0x55, 0x52, 0x53,
+	0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e,
+	0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x44, 0x49, 0x53, 0x42, 0x55, 0x52,
+	0x53, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x4f,
+	0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x44, 0x49, 0x53,
+	0x42, 0x55, 0x52, 0x53, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x20, 0x0a, 0x1c, 0x44, 0x49, 0x53,
+	0x42, 0x55, 0x52, 0x53, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1f, 0x0a, 0x1b, 0x44,
+	0x49, 0x53, 0x42, 0x55, 0x52, 0x53, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x55, 0x53, 0x5f, 0x4f, 0x4e, 0x5f, 0x48, 0x4f, 0x4c, 0x44, 0x10, 0x05, 0x32, 0xc0, 0x04, 0x0a,
+	0x13, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x79, 0x0a, 0x12, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x44, 0x69,
+	0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x2e, 0x62, 0x72, 0x69,
+	0x63, 0x6b, 0x2e, 0x64, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x62,
+	0x72, 0x69, 0x63, 0x6b, 0x2e, 0x64, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x44, 0x69, 0x73, 0x62, 0x75,
+	0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x57, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x12, 0x26, 0x2e, 0x62, 0x72,
+	0x69, 0x63, 0x6b, 0x2e, 0x64, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x62, 0x72, 0x69, 0x63, 0x6b, 0x2e, 0x64, 0x69, 0x73, 0x62,
+	0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x62,
+	0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x65, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x44,
+	0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2d, 0x2e, 0x62, 0x72,
+	0x69, 0x63, 0x6b, 0x2e, 0x64, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x62, 0x72, 0x69,
+	0x63, 0x6b, 0x2e, 0x64, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x76, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x2f, 0x2e, 0x62, 0x72, 0x69, 0x63, 0x6b, 0x2e, 0x64, 0x69, 0x73,
+	0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x62, 0x72, 0x69, 0x63, 0x6b, 0x2e, 0x64, 0x69,
+	0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x76, 0x0a, 0x11, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2f, 0x2e, 0x62,
+	0x72, 0x69, 0x63, 0x6b, 0x2e, 0x64, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72,
+	0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e,
+	0x62, 0x72, 0x69, 0x63, 0x6b, 0x2e, 0x64, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x62, 0x75, 0x72, 0x73, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42,
+	0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x6f,
+	0x62, 0x62, 0x79, 0x70, 0x68, 0x61, 0x6c, 0x61, 0x2f, 0x42, 0x72, 0x69, 0x63, 0x6b, 0x2f, 0x61,
+	0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_adapter_grpc_pb_disbursement_proto_rawDescOnce sync.Once
+	file_adapter_grpc_pb_disbursement_proto_rawDescData = file_adapter_grpc_pb_disbursement_proto_rawDesc
+)
+
+func file_adapter_grpc_pb_disbursement_proto_rawDescGZIP() []byte {
+	file_adapter_grpc_pb_disbursement_proto_rawDescOnce.Do(func() {
+		file_adapter_grpc_pb_disbursement_proto_rawDescData = protoimpl.X.CompressGZIP(file_adapter_grpc_pb_disbursement_proto_rawDescData)
+	})
+	return file_adapter_grpc_pb_disbursement_proto_rawDescData
+}
+
+var file_adapter_grpc_pb_disbursement_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_adapter_grpc_pb_disbursement_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_adapter_grpc_pb_disbursement_proto_goTypes = []interface{}{
+	(DisbursementStatus)(0),            // 0: brick.disbursement.v1.DisbursementStatus
+	(*Disbursement)(nil),               // 1: brick.disbursement.v1.Disbursement
+	(*VerifyDisbursementRequest)(nil),  // 2: brick.disbursement.v1.VerifyDisbursementRequest
+	(*VerifyDisbursementResponse)(nil), // 3: brick.disbursement.v1.VerifyDisbursementResponse
+	(*DisburseRequest)(nil),            // 4: brick.disbursement.v1.DisburseRequest
+	(*GetDisbursementRequest)(nil),     // 5: brick.disbursement.v1.GetDisbursementRequest
+	(*ListDisbursementsRequest)(nil),   // 6: brick.disbursement.v1.ListDisbursementsRequest
+	(*ListDisbursementsResponse)(nil),  // 7: brick.disbursement.v1.ListDisbursementsResponse
+	(*WatchDisbursementRequest)(nil),   // 8: brick.disbursement.v1.WatchDisbursementRequest
+	(*DisbursementStatusEvent)(nil),    // 9: brick.disbursement.v1.DisbursementStatusEvent
+	(*timestamppb.Timestamp)(nil),      // 10: google.protobuf.Timestamp
+}
+var file_adapter_grpc_pb_disbursement_proto_depIdxs = []int32{
+	0,  // 0: brick.disbursement.v1.Disbursement.status:type_name -> brick.disbursement.v1.DisbursementStatus
+	10, // 1: brick.disbursement.v1.VerifyDisbursementResponse.verification_token_expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: brick.disbursement.v1.ListDisbursementsRequest.status:type_name -> brick.disbursement.v1.DisbursementStatus
+	1,  // 3: brick.disbursement.v1.ListDisbursementsResponse.disbursements:type_name -> brick.disbursement.v1.Disbursement
+	0,  // 4: brick.disbursement.v1.DisbursementStatusEvent.status:type_name -> brick.disbursement.v1.DisbursementStatus
+	0,  // 5: brick.disbursement.v1.DisbursementStatusEvent.previous_status:type_name -> brick.disbursement.v1.DisbursementStatus
+	10, // 6: brick.disbursement.v1.DisbursementStatusEvent.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 7: brick.disbursement.v1.DisbursementService.VerifyDisbursement:input_type -> brick.disbursement.v1.VerifyDisbursementRequest
+	4,  // 8: brick.disbursement.v1.DisbursementService.Disburse:input_type -> brick.disbursement.v1.DisburseRequest
+	5,  // 9: brick.disbursement.v1.DisbursementService.GetDisbursement:input_type -> brick.disbursement.v1.GetDisbursementRequest
+	6,  // 10: brick.disbursement.v1.DisbursementService.ListDisbursements:input_type -> brick.disbursement.v1.ListDisbursementsRequest
+	8,  // 11: brick.disbursement.v1.DisbursementService.WatchDisbursement:input_type -> brick.disbursement.v1.WatchDisbursementRequest
+	3,  // 12: brick.disbursement.v1.DisbursementService.VerifyDisbursement:output_type -> brick.disbursement.v1.VerifyDisbursementResponse
+	1,  // 13: brick.disbursement.v1.DisbursementService.Disburse:output_type -> brick.disbursement.v1.Disbursement
+	1,  // 14: brick.disbursement.v1.DisbursementService.GetDisbursement:output_type -> brick.disbursement.v1.Disbursement
+	7,  // 15: brick.disbursement.v1.DisbursementService.ListDisbursements:output_type -> brick.disbursement.v1.ListDisbursementsResponse
+	9,  // 16: brick.disbursement.v1.DisbursementService.WatchDisbursement:output_type -> brick.disbursement.v1.DisbursementStatusEvent
+	12, // [12:17] is the sub-list for method output_type
+	7,  // [7:12] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_adapter_grpc_pb_disbursement_proto_init() }
+func file_adapter_grpc_pb_disbursement_proto_init() {
+	if File_adapter_grpc_pb_disbursement_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_adapter_grpc_pb_disbursement_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Disbursement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adapter_grpc_pb_disbursement_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyDisbursementRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adapter_grpc_pb_disbursement_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyDisbursementResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adapter_grpc_pb_disbursement_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisburseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adapter_grpc_pb_disbursement_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDisbursementRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adapter_grpc_pb_disbursement_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDisbursementsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adapter_grpc_pb_disbursement_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDisbursementsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adapter_grpc_pb_disbursement_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchDisbursementRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adapter_grpc_pb_disbursement_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisbursementStatusEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_adapter_grpc_pb_disbursement_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_adapter_grpc_pb_disbursement_proto_goTypes,
+		DependencyIndexes: file_adapter_grpc_pb_disbursement_proto_depIdxs,
+		EnumInfos:         file_adapter_grpc_pb_disbursement_proto_enumTypes,
+		MessageInfos:      file_adapter_grpc_pb_disbursement_proto_msgTypes,
+	}.Build()
+	File_adapter_grpc_pb_disbursement_proto = out.File
+	file_adapter_grpc_pb_disbursement_proto_rawDesc = nil
+	file_adapter_grpc_pb_disbursement_proto_goTypes = nil
+	file_adapter_grpc_pb_disbursement_proto_depIdxs = nil
+}
@@ -0,0 +1,287 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: adapter/grpc/pb/disbursement.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	DisbursementService_VerifyDisbursement_FullMethodName = "/brick.disbursement.v1.DisbursementService/VerifyDisbursement"
+	DisbursementService_Disburse_FullMethodName           = "/brick.disbursement.v1.DisbursementService/Disburse"
+	DisbursementService_GetDisbursement_FullMethodName    = "/brick.disbursement.v1.DisbursementService/GetDisbursement"
+	DisbursementService_ListDisbursements_FullMethodName  = "/brick.disbursement.v1.DisbursementService/ListDisbursements"
+	DisbursementService_WatchDisbursement_FullMethodName  = "/brick.disbursement.v1.DisbursementService/WatchDisbursement"
+)
+
+// DisbursementServiceClient is the client API for DisbursementService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DisbursementServiceClient interface {
+	VerifyDisbursement(ctx context.Context, in *VerifyDisbursementRequest, opts ...grpc.CallOption) (*VerifyDisbursementResponse, error)
+	Disburse(ctx context.Context, in *DisburseRequest, opts ...grpc.CallOption) (*Disbursement, error)
+	GetDisbursement(ctx context.Context, in *GetDisbursementRequest, opts ...grpc.CallOption) (*Disbursement, error)
+	ListDisbursements(ctx context.Context, in *ListDisbursementsRequest, opts ...grpc.CallOption) (*ListDisbursementsResponse, error)
+	// WatchDisbursement stream the status changes until the client cancel or the server shutting down
+	WatchDisbursement(ctx context.Context, in *WatchDisbursementRequest, opts ...grpc.CallOption) (DisbursementService_WatchDisbursementClient, error)
+}
+
+type disbursementServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDisbursementServiceClient(cc grpc.ClientConnInterface) DisbursementServiceClient {
+	return &disbursementServiceClient{cc}
+}
+
+func (c *disbursementServiceClient) VerifyDisbursement(ctx context.Context, in *VerifyDisbursementRequest, opts ...grpc.CallOption) (*VerifyDisbursementResponse, error) {
+	out := new(VerifyDisbursementResponse)
+	err := c.cc.Invoke(ctx, DisbursementService_VerifyDisbursement_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) Disburse(ctx context.Context, in *DisburseRequest, opts ...grpc.CallOption) (*Disbursement, error) {
+	out := new(Disbursement)
+	err := c.cc.Invoke(ctx, DisbursementService_Disburse_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) GetDisbursement(ctx context.Context, in *GetDisbursementRequest, opts ...grpc.CallOption) (*Disbursement, error) {
+	out := new(Disbursement)
+	err := c.cc.Invoke(ctx, DisbursementService_GetDisbursement_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) ListDisbursements(ctx context.Context, in *ListDisbursementsRequest, opts ...grpc.CallOption) (*ListDisbursementsResponse, error) {
+	out := new(ListDisbursementsResponse)
+	err := c.cc.Invoke(ctx, DisbursementService_ListDisbursements_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) WatchDisbursement(ctx context.Context, in *WatchDisbursementRequest, opts ...grpc.CallOption) (DisbursementService_WatchDisbursementClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DisbursementService_ServiceDesc.Streams[0], DisbursementService_WatchDisbursement_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disbursementServiceWatchDisbursementClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DisbursementService_WatchDisbursementClient interface {
+	Recv() (*DisbursementStatusEvent, error)
+	grpc.ClientStream
+}
+
+type disbursementServiceWatchDisbursementClient struct {
+	grpc.ClientStream
+}
+
+func (x *disbursementServiceWatchDisbursementClient) Recv() (*DisbursementStatusEvent, error) {
+	m := new(DisbursementStatusEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DisbursementServiceServer is the server API for DisbursementService service.
+// All implementations must embed UnimplementedDisbursementServiceServer
+// for forward compatibility
+type DisbursementServiceServer interface {
+	VerifyDisbursement(context.Context, *VerifyDisbursementRequest) (*VerifyDisbursementResponse, error)
+	Disburse(context.Context, *DisburseRequest) (*Disbursement, error)
+	GetDisbursement(context.Context, *GetDisbursementRequest) (*Disbursement, error)
+	ListDisbursements(context.Context, *ListDisbursementsRequest) (*ListDisbursementsResponse, error)
+	// WatchDisbursement stream the status changes until the client cancel or the server shutting down
+	WatchDisbursement(*WatchDisbursementRequest, DisbursementService_WatchDisbursementServer) error
+	mustEmbedUnimplementedDisbursementServiceServer()
+}
+
+// UnimplementedDisbursementServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDisbursementServiceServer struct {
+}
+
+func (UnimplementedDisbursementServiceServer) VerifyDisbursement(context.Context, *VerifyDisbursementRequest) (*VerifyDisbursementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyDisbursement not implemented")
+}
+func (UnimplementedDisbursementServiceServer) Disburse(context.Context, *DisburseRequest) (*Disbursement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Disburse not implemented")
+}
+func (UnimplementedDisbursementServiceServer) GetDisbursement(context.Context, *GetDisbursementRequest) (*Disbursement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDisbursement not implemented")
+}
+func (UnimplementedDisbursementServiceServer) ListDisbursements(context.Context, *ListDisbursementsRequest) (*ListDisbursementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDisbursements not implemented")
+}
+func (UnimplementedDisbursementServiceServer) WatchDisbursement(*WatchDisbursementRequest, DisbursementService_WatchDisbursementServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDisbursement not implemented")
+}
+func (UnimplementedDisbursementServiceServer) mustEmbedUnimplementedDisbursementServiceServer() {}
+
+// UnsafeDisbursementServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DisbursementServiceServer will
+// result in compilation errors.
+type UnsafeDisbursementServiceServer interface {
+	mustEmbedUnimplementedDisbursementServiceServer()
+}
+
+func RegisterDisbursementServiceServer(s grpc.ServiceRegistrar, srv DisbursementServiceServer) {
+	s.RegisterService(&DisbursementService_ServiceDesc, srv)
+}
+
+func _DisbursementService_VerifyDisbursement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyDisbursementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).VerifyDisbursement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_VerifyDisbursement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).VerifyDisbursement(ctx, req.(*VerifyDisbursementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_Disburse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisburseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).Disburse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_Disburse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).Disburse(ctx, req.(*DisburseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_GetDisbursement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDisbursementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).GetDisbursement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_GetDisbursement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).GetDisbursement(ctx, req.(*GetDisbursementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_ListDisbursements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDisbursementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).ListDisbursements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_ListDisbursements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).ListDisbursements(ctx, req.(*ListDisbursementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_WatchDisbursement_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDisbursementRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DisbursementServiceServer).WatchDisbursement(m, &disbursementServiceWatchDisbursementServer{stream})
+}
+
+type DisbursementService_WatchDisbursementServer interface {
+	Send(*DisbursementStatusEvent) error
+	grpc.ServerStream
+}
+
+type disbursementServiceWatchDisbursementServer struct {
+	grpc.ServerStream
+}
+
+func (x *disbursementServiceWatchDisbursementServer) Send(m *DisbursementStatusEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// DisbursementService_ServiceDesc is the grpc.ServiceDesc for DisbursementService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DisbursementService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "brick.disbursement.v1.DisbursementService",
+	HandlerType: (*DisbursementServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "VerifyDisbursement",
+			Handler:    _DisbursementService_VerifyDisbursement_Handler,
+		},
+		{
+			MethodName: "Disburse",
+			Handler:    _DisbursementService_Disburse_Handler,
+		},
+		{
+			MethodName: "GetDisbursement",
+			Handler:    _DisbursementService_GetDisbursement_Handler,
+		},
+		{
+			MethodName: "ListDisbursements",
+			Handler:    _DisbursementService_ListDisbursements_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchDisbursement",
+			Handler:       _DisbursementService_WatchDisbursement_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "adapter/grpc/pb/disbursement.proto",
+}
@@ -0,0 +1,24 @@
+package grpc
+
+import (
+	"github.com/nobbyphala/Brick/adapter/grpc/pb"
+	"google.golang.org/grpc"
+)
+
+type Services struct {
+	DisbursementServer *DisbursementServer
+	AuthInterceptor    *AuthInterceptor
+}
+
+// NewServer every call require an api key, same as the REST api
+func NewServer(services Services, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(services.AuthInterceptor.Unary),
+		grpc.ChainStreamInterceptor(services.AuthInterceptor.Stream),
+	)
+
+	server := grpc.NewServer(opts...)
+	pb.RegisterDisbursementServiceServer(server, services.DisbursementServer)
+
+	return server
+}
@@ -0,0 +1,22 @@
+package grpc
+
+// requests are copied to these structs to be validated by the same rules as the REST api
+
+type verifyDisbursementRequest struct {
+	RecipientName          string `validate:"gt=1,required"`
+	RecipientAccountNumber string `validate:"gte=1,numeric"`
+	RecipientBankCode      string `validate:"gte=1"`
+	Amount                 int64  `validate:"gt=0"`
+}
+
+type disburseRequest struct {
+	RecipientName          string `validate:"gt=1,required"`
+	RecipientAccountNumber string `validate:"gte=1,numeric"`
+	RecipientBankCode      string `validate:"gte=1"`
+	Amount                 int64  `validate:"gt=0"`
+}
+
+type listDisbursementsRequest struct {
+	PageSize          int    `validate:"gte=0,lte=100"`
+	NameMatchDecision string `validate:"omitempty,oneof=ACCEPTED REVIEW REJECTED"`
+}
@@ -0,0 +1,134 @@
+package rest_api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/validator"
+	"github.com/nobbyphala/Brick/usecase"
+	"net/http"
+	"time"
+)
+
+type ApiKeyController struct {
+	apiKeyUsecase usecase.ApiKey
+	validator     validator.Validator
+}
+
+type ApiKeyControllerDeps struct {
+	ApiKeyUsecase usecase.ApiKey
+}
+
+func NewApiKeyController(deps ApiKeyControllerDeps) *ApiKeyController {
+	return &ApiKeyController{
+		apiKeyUsecase: deps.ApiKeyUsecase,
+		validator:     validator.NewValidator(),
+	}
+}
+
+func (ctrl ApiKeyController) IssueApiKey(ctx *gin.Context) {
+	var requestBody IssueApiKeyRequest
+
+	err := ctx.BindJSON(&requestBody)
+	if err != nil {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return
+	}
+
+	validationErrors := ctrl.validator.ValidateStruct(requestBody)
+	if validationErrors != nil {
+		SendValidationErrorResponse(ctx, "invalid request", validationErrors)
+		return
+	}
+
+	issued, err := ctrl.apiKeyUsecase.Issue(ctx.Request.Context(), usecase.IssueApiKeyData{
+		ClientId:   requestBody.ClientId,
+		Name:       requestBody.Name,
+		Role:       domain.Role(requestBody.Role),
+		MerchantId: requestBody.MerchantId,
+		ExpiresAt:  requestBody.ExpiresAt,
+	})
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toIssuedApiKeyResponse(issued))
+}
+
+func (ctrl ApiKeyController) ListApiKeys(ctx *gin.Context) {
+	clientId := ctx.Query("client_id")
+	if clientId == "" {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return
+	}
+
+	apiKeys, err := ctrl.apiKeyUsecase.List(ctx.Request.Context(), clientId)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	response := make([]ApiKeyResponse, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		response = append(response, toApiKeyResponse(apiKey))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (ctrl ApiKeyController) RotateApiKey(ctx *gin.Context) {
+	var requestBody RotateApiKeyRequest
+
+	err := ctx.BindJSON(&requestBody)
+	if err != nil {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return
+	}
+
+	validationErrors := ctrl.validator.ValidateStruct(requestBody)
+	if validationErrors != nil {
+		SendValidationErrorResponse(ctx, "invalid request", validationErrors)
+		return
+	}
+
+	issued, err := ctrl.apiKeyUsecase.Rotate(ctx.Request.Context(), ctx.Param("id"), time.Duration(requestBody.OverlapSeconds)*time.Second)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toIssuedApiKeyResponse(issued))
+}
+
+func (ctrl ApiKeyController) RevokeApiKey(ctx *gin.Context) {
+	err := ctrl.apiKeyUsecase.Revoke(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func toApiKeyResponse(apiKey domain.ApiKey) ApiKeyResponse {
+	return ApiKeyResponse{
+		Id:         apiKey.Id,
+		ClientId:   apiKey.ClientId,
+		Name:       apiKey.Name,
+		Role:       string(apiKey.Role),
+		MerchantId: apiKey.MerchantId,
+		Prefix:     apiKey.Prefix,
+		ExpiresAt:  apiKey.ExpiresAt,
+		RevokedAt:  apiKey.RevokedAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
+
+func toIssuedApiKeyResponse(issued usecase.IssuedApiKey) IssuedApiKeyResponse {
+	return IssuedApiKeyResponse{
+		ApiKeyResponse: toApiKeyResponse(issued.ApiKey),
+		Key:            issued.Key,
+	}
+}
@@ -0,0 +1,162 @@
+package rest_api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	mock_usecase "github.com/nobbyphala/Brick/mock/usecase"
+	"github.com/nobbyphala/Brick/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestApiKeyController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApiKeyUsecase := mock_usecase.NewMockApiKey(ctrl)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	issued := usecase.IssuedApiKey{
+		ApiKey: domain.ApiKey{
+			Id:        "key-id-2",
+			ClientId:  "client-1",
+			Name:      "payroll",
+			Role:      domain.RoleClient,
+			Prefix:    "abc",
+			CreatedAt: createdAt,
+		},
+		Key: "brk_abc.secret",
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		req        interface{}
+		wantStatus int
+		want       string
+		mock       func()
+	}{
+		{
+			name:       "issue api key",
+			method:     "POST",
+			path:       "/admin/api-keys",
+			req:        IssueApiKeyRequest{ClientId: "client-1", Name: "payroll", Role: "client"},
+			wantStatus: http.StatusCreated,
+			want: func() string {
+				jsonByte, _ := json.Marshal(toIssuedApiKeyResponse(issued))
+				return string(jsonByte)
+			}(),
+			mock: func() {
+				mockApiKeyUsecase.EXPECT().Issue(gomock.Any(), usecase.IssueApiKeyData{
+					ClientId: "client-1",
+					Name:     "payroll",
+					Role:     domain.RoleClient,
+				}).Return(issued, nil)
+			},
+		},
+		{
+			name:       "issue api key with invalid role",
+			method:     "POST",
+			path:       "/admin/api-keys",
+			req:        IssueApiKeyRequest{ClientId: "client-1", Name: "payroll", Role: "superuser"},
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"error invalid api key role"}`,
+			mock: func() {
+				mockApiKeyUsecase.EXPECT().Issue(gomock.Any(), gomock.Any()).Return(usecase.IssuedApiKey{}, internal_error.ErrApiKeyInvalidRole)
+			},
+		},
+		{
+			name:       "list api keys without client id",
+			method:     "GET",
+			path:       "/admin/api-keys",
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"invalid request"}`,
+			mock:       func() {},
+		},
+		{
+			name:       "list api keys",
+			method:     "GET",
+			path:       "/admin/api-keys?client_id=client-1",
+			wantStatus: http.StatusOK,
+			want: func() string {
+				jsonByte, _ := json.Marshal([]ApiKeyResponse{toApiKeyResponse(issued.ApiKey)})
+				return string(jsonByte)
+			}(),
+			mock: func() {
+				mockApiKeyUsecase.EXPECT().List(gomock.Any(), "client-1").Return([]domain.ApiKey{issued.ApiKey}, nil)
+			},
+		},
+		{
+			name:       "rotate api key",
+			method:     "POST",
+			path:       "/admin/api-keys/key-id-1/rotate",
+			req:        RotateApiKeyRequest{OverlapSeconds: 3600},
+			wantStatus: http.StatusCreated,
+			want: func() string {
+				jsonByte, _ := json.Marshal(toIssuedApiKeyResponse(issued))
+				return string(jsonByte)
+			}(),
+			mock: func() {
+				mockApiKeyUsecase.EXPECT().Rotate(gomock.Any(), "key-id-1", time.Hour).Return(issued, nil)
+			},
+		},
+		{
+			name:       "revoke unknown api key",
+			method:     "DELETE",
+			path:       "/admin/api-keys/key-id-1",
+			wantStatus: http.StatusNotFound,
+			want:       `{"message":"error api key not found"}`,
+			mock: func() {
+				mockApiKeyUsecase.EXPECT().Revoke(gomock.Any(), "key-id-1").Return(internal_error.ErrApiKeyNotFound)
+			},
+		},
+		{
+			name:       "revoke api key",
+			method:     "DELETE",
+			path:       "/admin/api-keys/key-id-1",
+			wantStatus: http.StatusNoContent,
+			want:       "",
+			mock: func() {
+				mockApiKeyUsecase.EXPECT().Revoke(gomock.Any(), "key-id-1").Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			controller := NewApiKeyController(ApiKeyControllerDeps{ApiKeyUsecase: mockApiKeyUsecase})
+
+			router := gin.New()
+			router.GET("/admin/api-keys", controller.ListApiKeys)
+			router.POST("/admin/api-keys", controller.IssueApiKey)
+			router.POST("/admin/api-keys/:id/rotate", controller.RotateApiKey)
+			router.DELETE("/admin/api-keys/:id", controller.RevokeApiKey)
+
+			body := bytes.NewBuffer(nil)
+			if tt.req != nil {
+				requestBody, _ := json.Marshal(tt.req)
+				body = bytes.NewBuffer(requestBody)
+			}
+
+			req, err := http.NewRequest(tt.method, tt.path, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			respRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.wantStatus, respRecorder.Code)
+			assert.Equal(t, tt.want, respRecorder.Body.String())
+		})
+	}
+}
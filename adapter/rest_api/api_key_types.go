@@ -0,0 +1,37 @@
+package rest_api
+
+import "time"
+
+type IssueApiKeyRequest struct {
+	ClientId string `json:"client_id" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+	Role     string `json:"role" validate:"required"`
+	// required for client role
+	MerchantId string `json:"merchant_id"`
+	// optional, the key never expire when empty
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type RotateApiKeyRequest struct {
+	// how long the old key keep working after rotation
+	OverlapSeconds int64 `json:"overlap_seconds" validate:"gte=0"`
+}
+
+type ApiKeyResponse struct {
+	Id         string     `json:"id"`
+	ClientId   string     `json:"client_id"`
+	Name       string     `json:"name"`
+	Role       string     `json:"role"`
+	MerchantId string     `json:"merchant_id,omitempty"`
+	Prefix     string     `json:"prefix"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type IssuedApiKeyResponse struct {
+	ApiKeyResponse
+	// only returned once, it cannot be retrieved again
+	Key string `json:"key"`
+}
@@ -0,0 +1,67 @@
+package rest_api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/usecase"
+	"net/http"
+	"strconv"
+)
+
+type AuditLogController struct {
+	auditUsecase usecase.Audit
+}
+
+type AuditLogControllerDeps struct {
+	AuditUsecase usecase.Audit
+}
+
+func NewAuditLogController(deps AuditLogControllerDeps) *AuditLogController {
+	return &AuditLogController{
+		auditUsecase: deps.AuditUsecase,
+	}
+}
+
+// ListAuditLogs filter by actor_id, action and outcome query, the newest logs come first
+func (ctrl AuditLogController) ListAuditLogs(ctx *gin.Context) {
+	filter := domain.AuditLogFilter{
+		ActorId: ctx.Query("actor_id"),
+		Action:  ctx.Query("action"),
+		Outcome: domain.AuditOutcome(ctx.Query("outcome")),
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		var err error
+
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil || filter.Limit < 0 {
+			SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+			return
+		}
+	}
+
+	auditLogs, err := ctrl.auditUsecase.List(ctx.Request.Context(), filter)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	response := make([]AuditLogResponse, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		response = append(response, AuditLogResponse{
+			Id:         auditLog.Id,
+			ActorId:    auditLog.ActorId,
+			ActorRole:  string(auditLog.ActorRole),
+			ApiKeyId:   auditLog.ApiKeyId,
+			MerchantId: auditLog.MerchantId,
+			Action:     auditLog.Action,
+			ResourceId: auditLog.ResourceId,
+			Outcome:    string(auditLog.Outcome),
+			Reason:     auditLog.Reason,
+			CreatedAt:  auditLog.CreatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,78 @@
+package rest_api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	mock_usecase "github.com/nobbyphala/Brick/mock/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAuditLogController_ListAuditLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuditUsecase := mock_usecase.NewMockAudit(ctrl)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		want       string
+		mock       func()
+	}{
+		{
+			name:       "list denied attempts",
+			path:       "/admin/audit-logs?outcome=denied&limit=10",
+			wantStatus: http.StatusOK,
+			want:       `[{"id":"audit-id-1","actor_id":"client-1","actor_role":"client","merchant_id":"merchant-1","action":"bank_callback.process","resource_id":"PUT /disbursement","outcome":"denied","reason":"role is not granted the permission","created_at":"2024-01-01T00:00:00Z"}]`,
+			mock: func() {
+				mockAuditUsecase.EXPECT().List(gomock.Any(), domain.AuditLogFilter{Outcome: domain.AuditOutcomeDenied, Limit: 10}).Return([]domain.AuditLog{
+					{
+						Id:         "audit-id-1",
+						ActorId:    "client-1",
+						ActorRole:  domain.RoleClient,
+						MerchantId: "merchant-1",
+						Action:     "bank_callback.process",
+						ResourceId: "PUT /disbursement",
+						Outcome:    domain.AuditOutcomeDenied,
+						Reason:     "role is not granted the permission",
+						CreatedAt:  createdAt,
+					},
+				}, nil)
+			},
+		},
+		{
+			name:       "invalid limit",
+			path:       "/admin/audit-logs?limit=ten",
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"invalid request"}`,
+			mock:       func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			controller := NewAuditLogController(AuditLogControllerDeps{AuditUsecase: mockAuditUsecase})
+
+			router := gin.New()
+			router.GET("/admin/audit-logs", controller.ListAuditLogs)
+
+			req, err := http.NewRequest("GET", tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			respRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.wantStatus, respRecorder.Code)
+			assert.Equal(t, tt.want, respRecorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package rest_api
+
+import "time"
+
+type AuditLogResponse struct {
+	Id         string    `json:"id"`
+	ActorId    string    `json:"actor_id"`
+	ActorRole  string    `json:"actor_role"`
+	ApiKeyId   string    `json:"api_key_id,omitempty"`
+	MerchantId string    `json:"merchant_id,omitempty"`
+	Action     string    `json:"action"`
+	ResourceId string    `json:"resource_id"`
+	Outcome    string    `json:"outcome"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,177 @@
+package rest_api
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/pii"
+	"github.com/nobbyphala/Brick/external/validator"
+	"github.com/nobbyphala/Brick/usecase"
+	"net/http"
+	"strconv"
+)
+
+// BeneficiaryController manage the saved recipients of the merchant of the caller
+type BeneficiaryController struct {
+	beneficiaryUsecase usecase.Beneficiary
+	validator          validator.Validator
+	maskingPolicy      pii.Policy
+}
+
+type BeneficiaryControllerDeps struct {
+	BeneficiaryUsecase usecase.Beneficiary
+	// decide which caller role can see unmasked beneficiary data
+	MaskingPolicy pii.Policy
+}
+
+func NewBeneficiaryController(deps BeneficiaryControllerDeps) *BeneficiaryController {
+	return &BeneficiaryController{
+		beneficiaryUsecase: deps.BeneficiaryUsecase,
+		validator:          validator.NewValidator(),
+		maskingPolicy:      deps.MaskingPolicy,
+	}
+}
+
+func (ctrl BeneficiaryController) CreateBeneficiary(ctx *gin.Context) {
+	beneficiary, ok := ctrl.bindBeneficiary(ctx)
+	if !ok {
+		return
+	}
+
+	created, err := ctrl.beneficiaryUsecase.CreateBeneficiary(ctx.Request.Context(), beneficiary)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, ctrl.toBeneficiaryResponse(ctx.Request.Context(), created))
+}
+
+// ListBeneficiaries filter by bank_code query and page with limit and offset, the newest beneficiaries come first
+func (ctrl BeneficiaryController) ListBeneficiaries(ctx *gin.Context) {
+	filter := domain.BeneficiaryFilter{
+		BankCode: ctx.Query("bank_code"),
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		var err error
+
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil || filter.Limit < 0 {
+			SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+			return
+		}
+	}
+
+	if offset := ctx.Query("offset"); offset != "" {
+		var err error
+
+		filter.Offset, err = strconv.Atoi(offset)
+		if err != nil || filter.Offset < 0 {
+			SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+			return
+		}
+	}
+
+	beneficiaries, err := ctrl.beneficiaryUsecase.ListBeneficiaries(ctx.Request.Context(), filter)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	response := make([]BeneficiaryResponse, 0, len(beneficiaries))
+	for _, beneficiary := range beneficiaries {
+		response = append(response, ctrl.toBeneficiaryResponse(ctx.Request.Context(), beneficiary))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (ctrl BeneficiaryController) GetBeneficiary(ctx *gin.Context) {
+	beneficiary, err := ctrl.beneficiaryUsecase.GetBeneficiary(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ctrl.toBeneficiaryResponse(ctx.Request.Context(), beneficiary))
+}
+
+// UpdateBeneficiary replace the recipient, the account is verified again
+func (ctrl BeneficiaryController) UpdateBeneficiary(ctx *gin.Context) {
+	beneficiary, ok := ctrl.bindBeneficiary(ctx)
+	if !ok {
+		return
+	}
+
+	beneficiary.Id = ctx.Param("id")
+
+	updated, err := ctrl.beneficiaryUsecase.UpdateBeneficiary(ctx.Request.Context(), beneficiary)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ctrl.toBeneficiaryResponse(ctx.Request.Context(), updated))
+}
+
+func (ctrl BeneficiaryController) DeleteBeneficiary(ctx *gin.Context) {
+	err := ctrl.beneficiaryUsecase.DeleteBeneficiary(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (ctrl BeneficiaryController) bindBeneficiary(ctx *gin.Context) (domain.Beneficiary, bool) {
+	var requestBody BeneficiaryRequest
+
+	err := ctx.BindJSON(&requestBody)
+	if err != nil {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return domain.Beneficiary{}, false
+	}
+
+	validationErrors := ctrl.validator.ValidateStruct(requestBody)
+	if validationErrors != nil {
+		SendValidationErrorResponse(ctx, "invalid request", validationErrors)
+		return domain.Beneficiary{}, false
+	}
+
+	return domain.Beneficiary{
+		Name:          requestBody.Name,
+		AccountNumber: requestBody.AccountNumber,
+		BankCode:      requestBody.BankCode,
+	}, true
+}
+
+// toBeneficiaryResponse mask the beneficiary unless the caller role is allowed to see it
+func (ctrl BeneficiaryController) toBeneficiaryResponse(ctx context.Context, beneficiary domain.Beneficiary) BeneficiaryResponse {
+	response := BeneficiaryResponse{
+		Id:            beneficiary.Id,
+		MerchantId:    beneficiary.MerchantId,
+		Name:          beneficiary.Name,
+		AccountNumber: beneficiary.AccountNumber,
+		BankCode:      beneficiary.BankCode,
+		Verification: BeneficiaryVerificationResponse{
+			Status:            string(beneficiary.Verification.Status),
+			AccountHolderName: beneficiary.Verification.AccountHolderName,
+			NameMatchScore:    beneficiary.Verification.NameMatchScore,
+			VerifiedAt:        beneficiary.Verification.VerifiedAt,
+		},
+		CreatedAt: beneficiary.CreatedAt,
+		UpdatedAt: beneficiary.UpdatedAt,
+	}
+
+	caller, _ := domain.CallerFromContext(ctx)
+	if ctrl.maskingPolicy.ShouldMask(string(caller.Role)) {
+		response.Name = pii.MaskName(response.Name)
+		response.AccountNumber = pii.MaskAccountNumber(response.AccountNumber)
+		response.Verification.AccountHolderName = pii.MaskName(response.Verification.AccountHolderName)
+	}
+
+	return response
+}
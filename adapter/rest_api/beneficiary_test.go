@@ -0,0 +1,178 @@
+package rest_api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/pii"
+	mock_usecase "github.com/nobbyphala/Brick/mock/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBeneficiaryController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBeneficiaryUsecase := mock_usecase.NewMockBeneficiary(ctrl)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	beneficiary := domain.Beneficiary{
+		Id:            "beneficiary-1",
+		MerchantId:    "merchant-1",
+		Name:          "Nobby Phala",
+		AccountNumber: "6789567",
+		BankCode:      "014",
+		Verification: domain.BeneficiaryVerification{
+			Status:            domain.BeneficiaryVerified,
+			AccountHolderName: "Nobby Phala Putra",
+			NameMatchScore:    80,
+			VerifiedAt:        createdAt,
+		},
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	beneficiaryJSON := `{"id":"beneficiary-1","merchant_id":"merchant-1","name":"N**** P****","account_number":"***9567","bank_code":"014","verification":{"status":"VERIFIED","account_holder_name":"N**** P**** P****","name_match_score":80,"verified_at":"2024-01-01T00:00:00Z"},"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}`
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		req        interface{}
+		wantStatus int
+		want       string
+		mock       func()
+	}{
+		{
+			name:       "create beneficiary",
+			method:     "POST",
+			path:       "/beneficiaries",
+			req:        BeneficiaryRequest{Name: "Nobby Phala", AccountNumber: "6789567", BankCode: "014"},
+			wantStatus: http.StatusCreated,
+			want:       beneficiaryJSON,
+			mock: func() {
+				mockBeneficiaryUsecase.EXPECT().CreateBeneficiary(gomock.Any(), domain.Beneficiary{
+					Name:          "Nobby Phala",
+					AccountNumber: "6789567",
+					BankCode:      "014",
+				}).Return(beneficiary, nil)
+			},
+		},
+		{
+			name:       "create beneficiary with invalid account number",
+			method:     "POST",
+			path:       "/beneficiaries",
+			req:        BeneficiaryRequest{Name: "Nobby Phala", AccountNumber: "abc", BankCode: "014"},
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"invalid request","errors":[{"field":"AccountNumber","error":"AccountNumber must be a valid numeric value"}]}`,
+			mock:       func() {},
+		},
+		{
+			name:       "list beneficiaries of a bank",
+			method:     "GET",
+			path:       "/beneficiaries?bank_code=014&limit=10&offset=20",
+			wantStatus: http.StatusOK,
+			want:       "[" + beneficiaryJSON + "]",
+			mock: func() {
+				mockBeneficiaryUsecase.EXPECT().ListBeneficiaries(gomock.Any(), domain.BeneficiaryFilter{
+					BankCode: "014",
+					Limit:    10,
+					Offset:   20,
+				}).Return([]domain.Beneficiary{beneficiary}, nil)
+			},
+		},
+		{
+			name:       "list beneficiaries with invalid offset",
+			method:     "GET",
+			path:       "/beneficiaries?offset=-1",
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"invalid request"}`,
+			mock:       func() {},
+		},
+		{
+			name:       "get beneficiary",
+			method:     "GET",
+			path:       "/beneficiaries/beneficiary-1",
+			wantStatus: http.StatusOK,
+			want:       beneficiaryJSON,
+			mock: func() {
+				mockBeneficiaryUsecase.EXPECT().GetBeneficiary(gomock.Any(), "beneficiary-1").Return(beneficiary, nil)
+			},
+		},
+		{
+			name:       "update beneficiary",
+			method:     "PUT",
+			path:       "/beneficiaries/beneficiary-1",
+			req:        BeneficiaryRequest{Name: "Nobby Phala", AccountNumber: "6789567", BankCode: "014"},
+			wantStatus: http.StatusOK,
+			want:       beneficiaryJSON,
+			mock: func() {
+				mockBeneficiaryUsecase.EXPECT().UpdateBeneficiary(gomock.Any(), domain.Beneficiary{
+					Id:            "beneficiary-1",
+					Name:          "Nobby Phala",
+					AccountNumber: "6789567",
+					BankCode:      "014",
+				}).Return(beneficiary, nil)
+			},
+		},
+		{
+			name:       "delete beneficiary",
+			method:     "DELETE",
+			path:       "/beneficiaries/beneficiary-1",
+			wantStatus: http.StatusNoContent,
+			mock: func() {
+				mockBeneficiaryUsecase.EXPECT().DeleteBeneficiary(gomock.Any(), "beneficiary-1").Return(nil)
+			},
+		},
+		{
+			name:       "delete unknown beneficiary",
+			method:     "DELETE",
+			path:       "/beneficiaries/beneficiary-2",
+			wantStatus: http.StatusNotFound,
+			want:       `{"message":"error beneficiary not found"}`,
+			mock: func() {
+				mockBeneficiaryUsecase.EXPECT().DeleteBeneficiary(gomock.Any(), "beneficiary-2").Return(internal_error.ErrBeneficiaryNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			controller := NewBeneficiaryController(BeneficiaryControllerDeps{
+				BeneficiaryUsecase: mockBeneficiaryUsecase,
+				MaskingPolicy:      pii.NewPolicy("admin"),
+			})
+
+			router := gin.New()
+			router.GET("/beneficiaries", controller.ListBeneficiaries)
+			router.POST("/beneficiaries", controller.CreateBeneficiary)
+			router.GET("/beneficiaries/:id", controller.GetBeneficiary)
+			router.PUT("/beneficiaries/:id", controller.UpdateBeneficiary)
+			router.DELETE("/beneficiaries/:id", controller.DeleteBeneficiary)
+
+			body := bytes.NewBuffer(nil)
+			if tt.req != nil {
+				requestBody, _ := json.Marshal(tt.req)
+				body = bytes.NewBuffer(requestBody)
+			}
+
+			req, err := http.NewRequest(tt.method, tt.path, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			respRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.wantStatus, respRecorder.Code)
+			assert.Equal(t, tt.want, respRecorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package rest_api
+
+import "time"
+
+type BeneficiaryRequest struct {
+	Name          string `json:"name" validate:"gt=1,required"`
+	AccountNumber string `json:"account_number" validate:"gte=1,numeric"`
+	BankCode      string `json:"bank_code" validate:"gte=1"`
+}
+
+type BeneficiaryResponse struct {
+	Id            string `json:"id"`
+	MerchantId    string `json:"merchant_id"`
+	Name          string `json:"name"`
+	AccountNumber string `json:"account_number"`
+	BankCode      string `json:"bank_code"`
+	// last verification of the account by the bank
+	Verification BeneficiaryVerificationResponse `json:"verification"`
+	CreatedAt    time.Time                       `json:"created_at"`
+	UpdatedAt    time.Time                       `json:"updated_at"`
+}
+
+type BeneficiaryVerificationResponse struct {
+	Status            string    `json:"status"`
+	AccountHolderName string    `json:"account_holder_name,omitempty"`
+	NameMatchScore    int       `json:"name_match_score"`
+	VerifiedAt        time.Time `json:"verified_at"`
+}
@@ -0,0 +1,171 @@
+package rest_api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/validator"
+	"github.com/nobbyphala/Brick/usecase"
+	"net/http"
+	"strconv"
+)
+
+// CallbackReviewController expose the queue of bank callbacks parked for manual review
+type CallbackReviewController struct {
+	callbackReviewUsecase usecase.CallbackReview
+	validator             validator.Validator
+}
+
+type CallbackReviewControllerDeps struct {
+	CallbackReviewUsecase usecase.CallbackReview
+}
+
+func NewCallbackReviewController(deps CallbackReviewControllerDeps) *CallbackReviewController {
+	return &CallbackReviewController{
+		callbackReviewUsecase: deps.CallbackReviewUsecase,
+		validator:             validator.NewValidator(),
+	}
+}
+
+// ListCallbackReviews filter by status, reason and assignee_id query, the oldest reviews come first
+func (ctrl CallbackReviewController) ListCallbackReviews(ctx *gin.Context) {
+	filter := domain.CallbackReviewFilter{
+		Status:     domain.CallbackReviewStatus(ctx.Query("status")),
+		Reason:     domain.CallbackReviewReason(ctx.Query("reason")),
+		AssigneeId: ctx.Query("assignee_id"),
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		var err error
+
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil || filter.Limit < 0 {
+			SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+			return
+		}
+	}
+
+	reviews, err := ctrl.callbackReviewUsecase.ListCallbackReviews(ctx.Request.Context(), filter)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	response := make([]CallbackReviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		response = append(response, toCallbackReviewResponse(review))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (ctrl CallbackReviewController) GetCallbackReview(ctx *gin.Context) {
+	detail, err := ctrl.callbackReviewUsecase.GetCallbackReview(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	response := CallbackReviewDetailResponse{
+		CallbackReviewResponse: toCallbackReviewResponse(detail.Review),
+		Comments:               make([]CallbackReviewCommentResponse, 0, len(detail.Comments)),
+	}
+	for _, comment := range detail.Comments {
+		response.Comments = append(response.Comments, toCallbackReviewCommentResponse(comment))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (ctrl CallbackReviewController) AssignCallbackReview(ctx *gin.Context) {
+	var requestBody AssignCallbackReviewRequest
+
+	err := ctx.BindJSON(&requestBody)
+	if err != nil {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return
+	}
+
+	review, err := ctrl.callbackReviewUsecase.AssignCallbackReview(ctx.Request.Context(), ctx.Param("id"), requestBody.AssigneeId)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toCallbackReviewResponse(review))
+}
+
+func (ctrl CallbackReviewController) ResolveCallbackReview(ctx *gin.Context) {
+	var requestBody ResolveCallbackReviewRequest
+
+	err := ctx.BindJSON(&requestBody)
+	if err != nil {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return
+	}
+
+	validationErrors := ctrl.validator.ValidateStruct(requestBody)
+	if validationErrors != nil {
+		SendValidationErrorResponse(ctx, "invalid request", validationErrors)
+		return
+	}
+
+	review, err := ctrl.callbackReviewUsecase.ResolveCallbackReview(ctx.Request.Context(), ctx.Param("id"), domain.CallbackReviewResolution(requestBody.Resolution), requestBody.Note)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toCallbackReviewResponse(review))
+}
+
+func (ctrl CallbackReviewController) CommentCallbackReview(ctx *gin.Context) {
+	var requestBody CommentCallbackReviewRequest
+
+	err := ctx.BindJSON(&requestBody)
+	if err != nil {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return
+	}
+
+	validationErrors := ctrl.validator.ValidateStruct(requestBody)
+	if validationErrors != nil {
+		SendValidationErrorResponse(ctx, "invalid request", validationErrors)
+		return
+	}
+
+	comment, err := ctrl.callbackReviewUsecase.CommentCallbackReview(ctx.Request.Context(), ctx.Param("id"), requestBody.Body)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toCallbackReviewCommentResponse(comment))
+}
+
+func toCallbackReviewResponse(review domain.CallbackReview) CallbackReviewResponse {
+	return CallbackReviewResponse{
+		Id:             review.Id,
+		TransactionId:  review.TransactionId,
+		DisbursementId: review.DisbursementId,
+		Reason:         string(review.Reason),
+		Detail:         review.Detail,
+		Payload:        review.Payload,
+		Status:         string(review.Status),
+		AssigneeId:     review.AssigneeId,
+		ResolvedBy:     review.ResolvedBy,
+		ResolutionNote: review.ResolutionNote,
+		ResolvedAt:     review.ResolvedAt,
+		CreatedAt:      review.CreatedAt,
+		UpdatedAt:      review.UpdatedAt,
+	}
+}
+
+func toCallbackReviewCommentResponse(comment domain.CallbackReviewComment) CallbackReviewCommentResponse {
+	return CallbackReviewCommentResponse{
+		Id:        comment.Id,
+		AuthorId:  comment.AuthorId,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+	}
+}
@@ -0,0 +1,191 @@
+package rest_api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/metrics"
+	mock_usecase "github.com/nobbyphala/Brick/mock/usecase"
+	"github.com/nobbyphala/Brick/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCallbackReviewController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCallbackReviewUsecase := mock_usecase.NewMockCallbackReview(ctrl)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	review := domain.CallbackReview{
+		Id:            "review-1",
+		TransactionId: "txn-id-1",
+		Reason:        domain.CallbackReviewReasonDisbursementNotFound,
+		Detail:        "no disbursement has the transaction id",
+		Payload:       []byte(`{"transaction_id":"txn-id-1","status":"COMPLETED"}`),
+		Status:        domain.CallbackReviewStatusOpen,
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	}
+	reviewJSON := `{"id":"review-1","transaction_id":"txn-id-1","reason":"DISBURSEMENT_NOT_FOUND","detail":"no disbursement has the transaction id","payload":{"transaction_id":"txn-id-1","status":"COMPLETED"},"status":"OPEN","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}`
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		req        interface{}
+		wantStatus int
+		want       string
+		mock       func()
+	}{
+		{
+			name:       "list open reviews",
+			method:     "GET",
+			path:       "/admin/callback-reviews?status=OPEN&limit=10",
+			wantStatus: http.StatusOK,
+			want:       "[" + reviewJSON + "]",
+			mock: func() {
+				mockCallbackReviewUsecase.EXPECT().ListCallbackReviews(gomock.Any(), domain.CallbackReviewFilter{
+					Status: domain.CallbackReviewStatusOpen,
+					Limit:  10,
+				}).Return([]domain.CallbackReview{review}, nil)
+			},
+		},
+		{
+			name:       "get review with comments",
+			method:     "GET",
+			path:       "/admin/callback-reviews/review-1",
+			wantStatus: http.StatusOK,
+			want:       reviewJSON[:len(reviewJSON)-1] + `,"comments":[{"id":"comment-1","author_id":"ops-1","body":"asked the bank","created_at":"2024-01-01T00:00:00Z"}]}`,
+			mock: func() {
+				mockCallbackReviewUsecase.EXPECT().GetCallbackReview(gomock.Any(), "review-1").Return(usecase.CallbackReviewDetail{
+					Review: review,
+					Comments: []domain.CallbackReviewComment{
+						{Id: "comment-1", ReviewId: "review-1", AuthorId: "ops-1", Body: "asked the bank", CreatedAt: createdAt},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:       "assign review to the caller",
+			method:     "POST",
+			path:       "/admin/callback-reviews/review-1/assign",
+			req:        AssignCallbackReviewRequest{},
+			wantStatus: http.StatusOK,
+			want:       reviewJSON,
+			mock: func() {
+				mockCallbackReviewUsecase.EXPECT().AssignCallbackReview(gomock.Any(), "review-1", "").Return(review, nil)
+			},
+		},
+		{
+			name:       "resolve with unknown resolution",
+			method:     "POST",
+			path:       "/admin/callback-reviews/review-1/resolve",
+			req:        ResolveCallbackReviewRequest{Resolution: "ignore", Note: "duplicate"},
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"invalid request","errors":[{"field":"Resolution","error":"Resolution must be one of [apply discard]"}]}`,
+			mock:       func() {},
+		},
+		{
+			name:       "resolve resolved review",
+			method:     "POST",
+			path:       "/admin/callback-reviews/review-1/resolve",
+			req:        ResolveCallbackReviewRequest{Resolution: "discard", Note: "duplicate"},
+			wantStatus: http.StatusConflict,
+			want:       `{"message":"error callback review is already resolved"}`,
+			mock: func() {
+				mockCallbackReviewUsecase.EXPECT().ResolveCallbackReview(gomock.Any(), "review-1", domain.CallbackReviewResolutionDiscard, "duplicate").Return(domain.CallbackReview{}, internal_error.ErrCallbackReviewResolved)
+			},
+		},
+		{
+			name:       "comment review",
+			method:     "POST",
+			path:       "/admin/callback-reviews/review-1/comments",
+			req:        CommentCallbackReviewRequest{Body: "asked the bank"},
+			wantStatus: http.StatusCreated,
+			want:       `{"id":"comment-1","author_id":"ops-1","body":"asked the bank","created_at":"2024-01-01T00:00:00Z"}`,
+			mock: func() {
+				mockCallbackReviewUsecase.EXPECT().CommentCallbackReview(gomock.Any(), "review-1", "asked the bank").Return(domain.CallbackReviewComment{
+					Id:        "comment-1",
+					ReviewId:  "review-1",
+					AuthorId:  "ops-1",
+					Body:      "asked the bank",
+					CreatedAt: createdAt,
+				}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			controller := NewCallbackReviewController(CallbackReviewControllerDeps{CallbackReviewUsecase: mockCallbackReviewUsecase})
+
+			router := gin.New()
+			router.GET("/admin/callback-reviews", controller.ListCallbackReviews)
+			router.GET("/admin/callback-reviews/:id", controller.GetCallbackReview)
+			router.POST("/admin/callback-reviews/:id/assign", controller.AssignCallbackReview)
+			router.POST("/admin/callback-reviews/:id/resolve", controller.ResolveCallbackReview)
+			router.POST("/admin/callback-reviews/:id/comments", controller.CommentCallbackReview)
+
+			body := bytes.NewBuffer(nil)
+			if tt.req != nil {
+				requestBody, _ := json.Marshal(tt.req)
+				body = bytes.NewBuffer(requestBody)
+			}
+
+			req, err := http.NewRequest(tt.method, tt.path, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			respRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.wantStatus, respRecorder.Code)
+			assert.Equal(t, tt.want, respRecorder.Body.String())
+		})
+	}
+}
+
+func TestMetricsController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCallbackReviewUsecase := mock_usecase.NewMockCallbackReview(ctrl)
+	mockCallbackReviewUsecase.EXPECT().CountOpenCallbackReviews(gomock.Any()).Return([]domain.CallbackReviewCount{
+		{Reason: domain.CallbackReviewReasonDisbursementNotFound, Open: 3, Unassigned: 1},
+	}, nil).Times(2)
+
+	controller := NewMetricsController(MetricsControllerDeps{
+		Registry:              metrics.NewRegistry(),
+		CallbackReviewUsecase: mockCallbackReviewUsecase,
+	})
+
+	router := gin.New()
+	router.GET("/metrics", controller.Metrics)
+
+	req, err := http.NewRequest("GET", "/metrics", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	respRecorder := httptest.NewRecorder()
+
+	router.ServeHTTP(respRecorder, req)
+
+	assert.Equal(t, http.StatusOK, respRecorder.Code)
+	assert.Equal(t, `# HELP brick_callback_review_open number of open bank callback reviews
+# TYPE brick_callback_review_open gauge
+brick_callback_review_open{reason="DISBURSEMENT_NOT_FOUND"} 3
+# HELP brick_callback_review_unassigned number of open bank callback reviews without assignee
+# TYPE brick_callback_review_unassigned gauge
+brick_callback_review_unassigned{reason="DISBURSEMENT_NOT_FOUND"} 1
+`, respRecorder.Body.String())
+}
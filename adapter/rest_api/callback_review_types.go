@@ -0,0 +1,48 @@
+package rest_api
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AssignCallbackReviewRequest struct {
+	// empty assign the review to the caller
+	AssigneeId string `json:"assignee_id"`
+}
+
+type ResolveCallbackReviewRequest struct {
+	Resolution string `json:"resolution" validate:"oneof=apply discard"`
+	Note       string `json:"note" validate:"required"`
+}
+
+type CommentCallbackReviewRequest struct {
+	Body string `json:"body" validate:"required"`
+}
+
+type CallbackReviewResponse struct {
+	Id             string          `json:"id"`
+	TransactionId  string          `json:"transaction_id"`
+	DisbursementId string          `json:"disbursement_id,omitempty"`
+	Reason         string          `json:"reason"`
+	Detail         string          `json:"detail"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	AssigneeId     string          `json:"assignee_id,omitempty"`
+	ResolvedBy     string          `json:"resolved_by,omitempty"`
+	ResolutionNote string          `json:"resolution_note,omitempty"`
+	ResolvedAt     *time.Time      `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+type CallbackReviewCommentResponse struct {
+	Id        string    `json:"id"`
+	AuthorId  string    `json:"author_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CallbackReviewDetailResponse struct {
+	CallbackReviewResponse
+	Comments []CallbackReviewCommentResponse `json:"comments"`
+}
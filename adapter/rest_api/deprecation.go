@@ -0,0 +1,54 @@
+package rest_api
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/metrics"
+	"net/http"
+	"time"
+)
+
+type DeprecationMiddleware struct {
+	deprecatedAt time.Time
+	sunset       time.Time
+	requests     *metrics.Counter
+}
+
+type DeprecationMiddlewareDeps struct {
+	// sent in the Deprecation header (RFC 9745)
+	DeprecatedAt time.Time
+	// sent in the Sunset header (RFC 8594), the routes are removed after this time
+	Sunset time.Time
+	// deprecated requests are counted by route and merchant to know who still has to migrate. /metrics is not
+	// authenticated, so the label is the merchant and role of the caller instead of the client id of its api key
+	Registry *metrics.Registry
+}
+
+func NewDeprecationMiddleware(deps DeprecationMiddlewareDeps) *DeprecationMiddleware {
+	return &DeprecationMiddleware{
+		deprecatedAt: deps.DeprecatedAt,
+		sunset:       deps.Sunset,
+		requests:     deps.Registry.Counter("brick_deprecated_requests_total", "number of requests to deprecated routes by route, merchant and role of the caller"),
+	}
+}
+
+// Deprecated mark the routes as replaced by the same path under successorPrefix, for example /v1
+func (mw DeprecationMiddleware) Deprecated(successorPrefix string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Header("Deprecation", fmt.Sprintf("@%d", mw.deprecatedAt.Unix()))
+		ctx.Header("Sunset", mw.sunset.UTC().Format(http.TimeFormat))
+		ctx.Header("Link", fmt.Sprintf(`<%s%s>; rel="successor-version"`, successorPrefix, ctx.Request.URL.Path))
+
+		ctx.Next()
+
+		// counted after the request so the caller authenticated by the next handlers is known
+		caller, _ := domain.CallerFromContext(ctx.Request.Context())
+		mw.requests.Inc(metrics.Labels{
+			"method":      ctx.Request.Method,
+			"route":       ctx.FullPath(),
+			"merchant_id": caller.MerchantId,
+			"role":        string(caller.Role),
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package rest_api
+
+import (
+	"bytes"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/metrics"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeprecationMiddleware_Deprecated(t *testing.T) {
+	registry := metrics.NewRegistry()
+	mw := NewDeprecationMiddleware(DeprecationMiddlewareDeps{
+		DeprecatedAt: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		Sunset:       time.Date(2027, 4, 19, 0, 0, 0, 0, time.UTC),
+		Registry:     registry,
+	})
+
+	router := gin.New()
+	legacy := router.Group("/", mw.Deprecated("/v1"))
+	legacy.GET("/disbursement/:id", func(ctx *gin.Context) {
+		// stand in for the auth middleware
+		ctx.Request = ctx.Request.WithContext(domain.ContextWithCaller(ctx.Request.Context(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: "merchant-1"}))
+		ctx.Status(http.StatusOK)
+	})
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodGet, "/disbursement/disb-id-1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		respRecorder := httptest.NewRecorder()
+
+		router.ServeHTTP(respRecorder, req)
+
+		assert.Equal(t, http.StatusOK, respRecorder.Code)
+		assert.Equal(t, "@1792368000", respRecorder.Header().Get("Deprecation"))
+		assert.Equal(t, "Mon, 19 Apr 2027 00:00:00 GMT", respRecorder.Header().Get("Sunset"))
+		assert.Equal(t, `</v1/disbursement/disb-id-1>; rel="successor-version"`, respRecorder.Header().Get("Link"))
+	}
+
+	var out bytes.Buffer
+	err := registry.Write(context.TODO(), &out)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), `brick_deprecated_requests_total{merchant_id="merchant-1",method="GET",role="client",route="/disbursement/:id"} 2`)
+	assert.NotContains(t, out.String(), "client-1")
+}
@@ -1,9 +1,11 @@
 package rest_api
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/nobbyphala/Brick/domain"
 	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/pii"
 	"github.com/nobbyphala/Brick/external/validator"
 	"github.com/nobbyphala/Brick/usecase"
 	"github.com/nobbyphala/Brick/usecase/api"
@@ -13,16 +15,20 @@ import (
 type DisbursementController struct {
 	disbursementUsecase usecase.Disbursement
 	validator           validator.Validator
+	maskingPolicy       pii.Policy
 }
 
 type DisbursementControllerDeps struct {
 	DisbursementUsecase usecase.Disbursement
+	// decide which caller role can see unmasked recipient data
+	MaskingPolicy pii.Policy
 }
 
 func NewDisbursementController(deps DisbursementControllerDeps) *DisbursementController {
 	return &DisbursementController{
 		disbursementUsecase: deps.DisbursementUsecase,
 		validator:           validator.NewValidator(),
+		maskingPolicy:       deps.MaskingPolicy,
 	}
 }
 
@@ -41,7 +47,7 @@ func (ctrl DisbursementController) VerifyDisbursement(ctx *gin.Context) {
 		return
 	}
 
-	err = ctrl.disbursementUsecase.VerifyDisbursement(ctx.Request.Context(), domain.Disbursement{
+	verification, err := ctrl.disbursementUsecase.VerifyDisbursement(ctx.Request.Context(), domain.Disbursement{
 		RecipientName:          requestBody.RecipientName,
 		RecipientAccountNumber: requestBody.RecipientAccountNumber,
 		RecipientBankCode:      requestBody.RecipientBankCode,
@@ -52,7 +58,19 @@ func (ctrl DisbursementController) VerifyDisbursement(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "disbursement successfully verified"})
+	response := VerifyDisbursementResponse{
+		Message:           "disbursement successfully verified",
+		AccountHolderName: verification.AccountHolderName,
+		NameMatchScore:    verification.NameMatchScore,
+		NameMatchDecision: string(verification.NameMatchDecision),
+		VerificationToken: verification.Token,
+	}
+
+	if verification.Token != "" {
+		response.VerificationTokenExpiresAt = &verification.ExpiresAt
+	}
+
+	ctx.JSON(http.StatusOK, response)
 }
 
 func (ctrl DisbursementController) Disburse(ctx *gin.Context) {
@@ -75,22 +93,26 @@ func (ctrl DisbursementController) Disburse(ctx *gin.Context) {
 		RecipientAccountNumber: requestBody.RecipientAccountNumber,
 		RecipientBankCode:      requestBody.RecipientBankCode,
 		Amount:                 requestBody.Amount,
+	}, usecase.DisburseOptions{
+		VerificationToken: requestBody.VerificationToken,
+		BeneficiaryId:     requestBody.BeneficiaryId,
 	})
 	if err != nil {
 		SendErrorResponse(ctx, err)
 		return
 	}
 
-	response := DisbursementResponse{
-		Id:                     disbursement.Id,
-		RecipientName:          disbursement.RecipientName,
-		RecipientAccountNumber: disbursement.RecipientAccountNumber,
-		RecipientBankCode:      disbursement.RecipientBankCode,
-		Amount:                 disbursement.Amount,
-		Status:                 disbursement.Status.ToString(),
+	ctx.JSON(http.StatusOK, toDisbursementResponse(ctx.Request.Context(), ctrl.maskingPolicy, disbursement))
+}
+
+func (ctrl DisbursementController) GetDisbursement(ctx *gin.Context) {
+	disbursement, err := ctrl.disbursementUsecase.GetDisbursement(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
 	}
 
-	ctx.JSON(http.StatusOK, response)
+	ctx.JSON(http.StatusOK, toDisbursementResponse(ctx.Request.Context(), ctrl.maskingPolicy, disbursement))
 }
 
 func (ctrl DisbursementController) HandleBankCallback(ctx *gin.Context) {
@@ -108,7 +130,7 @@ func (ctrl DisbursementController) HandleBankCallback(ctx *gin.Context) {
 		return
 	}
 
-	err = ctrl.disbursementUsecase.ProcessBankCallback(ctx, usecase.BankCallbackData{
+	err = ctrl.disbursementUsecase.ProcessBankCallback(ctx.Request.Context(), usecase.BankCallbackData{
 		TransactionId: requestBody.TransactionId,
 		Status:        api.TransferStatus(requestBody.Status),
 	})
@@ -119,3 +141,29 @@ func (ctrl DisbursementController) HandleBankCallback(ctx *gin.Context) {
 
 	ctx.Status(http.StatusOK)
 }
+
+// toDisbursementResponse mask the recipient data unless the caller role is allowed to see it
+func toDisbursementResponse(ctx context.Context, maskingPolicy pii.Policy, disbursement domain.Disbursement) DisbursementResponse {
+	response := DisbursementResponse{
+		Id:                     disbursement.Id,
+		RecipientName:          disbursement.RecipientName,
+		RecipientAccountNumber: disbursement.RecipientAccountNumber,
+		RecipientBankCode:      disbursement.RecipientBankCode,
+		Amount:                 disbursement.Amount,
+		Status:                 disbursement.Status.ToString(),
+		BankEvidenceReference:  disbursement.BankEvidenceReference,
+		NameMatchDecision:      string(disbursement.NameMatchDecision),
+	}
+
+	if disbursement.NameMatchDecision != "" {
+		response.NameMatchScore = &disbursement.NameMatchScore
+	}
+
+	caller, _ := domain.CallerFromContext(ctx)
+	if maskingPolicy.ShouldMask(string(caller.Role)) {
+		response.RecipientName = pii.MaskName(response.RecipientName)
+		response.RecipientAccountNumber = pii.MaskAccountNumber(response.RecipientAccountNumber)
+	}
+
+	return response
+}
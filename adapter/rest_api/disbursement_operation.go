@@ -0,0 +1,99 @@
+package rest_api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/pii"
+	"github.com/nobbyphala/Brick/external/validator"
+	"github.com/nobbyphala/Brick/usecase"
+	"net/http"
+)
+
+// DisbursementOperationController expose the manual intervention of the operators
+type DisbursementOperationController struct {
+	disbursementOperationUsecase usecase.DisbursementOperation
+	validator                    validator.Validator
+	maskingPolicy                pii.Policy
+}
+
+type DisbursementOperationControllerDeps struct {
+	DisbursementOperationUsecase usecase.DisbursementOperation
+	MaskingPolicy                pii.Policy
+}
+
+func NewDisbursementOperationController(deps DisbursementOperationControllerDeps) *DisbursementOperationController {
+	return &DisbursementOperationController{
+		disbursementOperationUsecase: deps.DisbursementOperationUsecase,
+		validator:                    validator.NewValidator(),
+		maskingPolicy:                deps.MaskingPolicy,
+	}
+}
+
+func (ctrl DisbursementOperationController) ForceStatus(ctx *gin.Context) {
+	var requestBody ForceStatusRequest
+
+	err := ctx.BindJSON(&requestBody)
+	if err != nil {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return
+	}
+
+	validationErrors := ctrl.validator.ValidateStruct(requestBody)
+	if validationErrors != nil {
+		SendValidationErrorResponse(ctx, "invalid request", validationErrors)
+		return
+	}
+
+	status, ok := domain.DisbursementStatusFromString(requestBody.Status)
+	if !ok {
+		SendErrorResponse(ctx, internal_error.ErrForceStatusInvalid)
+		return
+	}
+
+	disbursement, err := ctrl.disbursementOperationUsecase.ForceStatus(ctx.Request.Context(), ctx.Param("id"), status, requestBody.Reason)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toDisbursementResponse(ctx.Request.Context(), ctrl.maskingPolicy, disbursement))
+}
+
+func (ctrl DisbursementOperationController) AttachBankEvidence(ctx *gin.Context) {
+	var requestBody AttachBankEvidenceRequest
+
+	err := ctx.BindJSON(&requestBody)
+	if err != nil {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return
+	}
+
+	validationErrors := ctrl.validator.ValidateStruct(requestBody)
+	if validationErrors != nil {
+		SendValidationErrorResponse(ctx, "invalid request", validationErrors)
+		return
+	}
+
+	disbursement, err := ctrl.disbursementOperationUsecase.AttachBankEvidence(ctx.Request.Context(), ctx.Param("id"), requestBody.Reference)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toDisbursementResponse(ctx.Request.Context(), ctrl.maskingPolicy, disbursement))
+}
+
+func (ctrl DisbursementOperationController) RecheckBankStatus(ctx *gin.Context) {
+	result, err := ctrl.disbursementOperationUsecase.RecheckBankStatus(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, BankStatusCheckResponse{
+		Disbursement: toDisbursementResponse(ctx.Request.Context(), ctrl.maskingPolicy, result.Disbursement),
+		BankStatus:   string(result.BankStatus),
+		Updated:      result.Updated,
+	})
+}
@@ -0,0 +1,138 @@
+package rest_api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/pii"
+	mock_usecase "github.com/nobbyphala/Brick/mock/usecase"
+	"github.com/nobbyphala/Brick/usecase"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDisbursementOperationController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOperationUsecase := mock_usecase.NewMockDisbursementOperation(ctrl)
+
+	disbursement := domain.Disbursement{
+		Id:                     "disb-id-1",
+		RecipientName:          "Nobby Phala",
+		RecipientAccountNumber: "1234567890",
+		RecipientBankCode:      "BCA",
+		Amount:                 60000,
+		Status:                 domain.DisbursementStatusCompleted,
+		BankEvidenceReference:  "BCA/2024/0001",
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		req        interface{}
+		wantStatus int
+		want       string
+		mock       func()
+	}{
+		{
+			name:       "force status",
+			path:       "/admin/disbursements/disb-id-1/force-status",
+			req:        ForceStatusRequest{Status: "COMPLETED", Reason: "bank confirmed by email"},
+			wantStatus: http.StatusOK,
+			want:       `{"id":"disb-id-1","recipient_name":"Nobby Phala","recipient_account_number":"1234567890","recipient_bank_code":"BCA","amount":60000,"status":"COMPLETED","bank_evidence_reference":"BCA/2024/0001"}`,
+			mock: func() {
+				mockOperationUsecase.EXPECT().ForceStatus(gomock.Any(), "disb-id-1", domain.DisbursementStatusCompleted, "bank confirmed by email").Return(disbursement, nil)
+			},
+		},
+		{
+			name:       "force to unknown status",
+			path:       "/admin/disbursements/disb-id-1/force-status",
+			req:        ForceStatusRequest{Status: "DONE", Reason: "bank confirmed by email"},
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"error disbursement cannot be forced to the status"}`,
+			mock:       func() {},
+		},
+		{
+			name:       "force without reason",
+			path:       "/admin/disbursements/disb-id-1/force-status",
+			req:        ForceStatusRequest{Status: "COMPLETED"},
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"invalid request","errors":[{"field":"Reason","error":"Reason is a required field"}]}`,
+			mock:       func() {},
+		},
+		{
+			name:       "attach bank evidence",
+			path:       "/admin/disbursements/disb-id-1/evidence",
+			req:        AttachBankEvidenceRequest{Reference: "BCA/2024/0001"},
+			wantStatus: http.StatusOK,
+			want:       `{"id":"disb-id-1","recipient_name":"Nobby Phala","recipient_account_number":"1234567890","recipient_bank_code":"BCA","amount":60000,"status":"COMPLETED","bank_evidence_reference":"BCA/2024/0001"}`,
+			mock: func() {
+				mockOperationUsecase.EXPECT().AttachBankEvidence(gomock.Any(), "disb-id-1", "BCA/2024/0001").Return(disbursement, nil)
+			},
+		},
+		{
+			name:       "recheck bank status",
+			path:       "/admin/disbursements/disb-id-1/recheck",
+			wantStatus: http.StatusOK,
+			want:       `{"disbursement":{"id":"disb-id-1","recipient_name":"Nobby Phala","recipient_account_number":"1234567890","recipient_bank_code":"BCA","amount":60000,"status":"COMPLETED","bank_evidence_reference":"BCA/2024/0001"},"bank_status":"COMPLETED","updated":true}`,
+			mock: func() {
+				mockOperationUsecase.EXPECT().RecheckBankStatus(gomock.Any(), "disb-id-1").Return(usecase.BankStatusCheckResult{
+					Disbursement: disbursement,
+					BankStatus:   api.TransferStatusCompleted,
+					Updated:      true,
+				}, nil)
+			},
+		},
+		{
+			name:       "recheck failed to reach the bank",
+			path:       "/admin/disbursements/disb-id-1/recheck",
+			wantStatus: http.StatusInternalServerError,
+			want:       `{"message":"error when checking transfer status to bank"}`,
+			mock: func() {
+				mockOperationUsecase.EXPECT().RecheckBankStatus(gomock.Any(), "disb-id-1").Return(usecase.BankStatusCheckResult{}, internal_error.ErrBankStatusCheck)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			controller := NewDisbursementOperationController(DisbursementOperationControllerDeps{
+				DisbursementOperationUsecase: mockOperationUsecase,
+				MaskingPolicy:                pii.NewPolicy("ops_operator"),
+			})
+
+			router := gin.New()
+			router.Use(func(ctx *gin.Context) {
+				ctx.Request = ctx.Request.WithContext(domain.ContextWithCaller(ctx.Request.Context(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsOperator}))
+			})
+			router.POST("/admin/disbursements/:id/force-status", controller.ForceStatus)
+			router.POST("/admin/disbursements/:id/evidence", controller.AttachBankEvidence)
+			router.POST("/admin/disbursements/:id/recheck", controller.RecheckBankStatus)
+
+			body := bytes.NewBuffer(nil)
+			if tt.req != nil {
+				requestBody, _ := json.Marshal(tt.req)
+				body = bytes.NewBuffer(requestBody)
+			}
+
+			req, err := http.NewRequest("POST", tt.path, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			respRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.wantStatus, respRecorder.Code)
+			assert.Equal(t, tt.want, respRecorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package rest_api
+
+type ForceStatusRequest struct {
+	Status string `json:"status" validate:"required"`
+	Reason string `json:"reason" validate:"required"`
+}
+
+type AttachBankEvidenceRequest struct {
+	Reference string `json:"reference" validate:"required"`
+}
+
+type BankStatusCheckResponse struct {
+	Disbursement DisbursementResponse `json:"disbursement"`
+	BankStatus   string               `json:"bank_status"`
+	Updated      bool                 `json:"updated"`
+}
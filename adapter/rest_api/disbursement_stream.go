@@ -0,0 +1,120 @@
+package rest_api
+
+import (
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/usecase"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	lastEventIdHeader              = "Last-Event-ID"
+	disbursementStatusEventName    = "status"
+	defaultStreamHeartbeatInterval = 15 * time.Second
+)
+
+type DisbursementStreamController struct {
+	disbursementStreamUsecase usecase.DisbursementStream
+	heartbeatInterval         time.Duration
+}
+
+type DisbursementStreamControllerDeps struct {
+	DisbursementStreamUsecase usecase.DisbursementStream
+	// comment sent on idle stream so proxies do not close the connection
+	HeartbeatInterval time.Duration
+}
+
+func NewDisbursementStreamController(deps DisbursementStreamControllerDeps) *DisbursementStreamController {
+	heartbeatInterval := deps.HeartbeatInterval
+	if heartbeatInterval <= 0 {
+		heartbeatInterval = defaultStreamHeartbeatInterval
+	}
+
+	return &DisbursementStreamController{
+		disbursementStreamUsecase: deps.DisbursementStreamUsecase,
+		heartbeatInterval:         heartbeatInterval,
+	}
+}
+
+// StreamDisbursements push the status changes of every disbursement visible to the caller
+func (ctrl DisbursementStreamController) StreamDisbursements(ctx *gin.Context) {
+	ctrl.stream(ctx, "")
+}
+
+// StreamDisbursement push the status changes of a single disbursement
+func (ctrl DisbursementStreamController) StreamDisbursement(ctx *gin.Context) {
+	ctrl.stream(ctx, ctx.Param("id"))
+}
+
+// stream send every status change as server-sent event with the event sequence as id, a reconnecting client
+// resume after the last event it received by sending the id in Last-Event-ID
+func (ctrl DisbursementStreamController) stream(ctx *gin.Context, disbursementId string) {
+	var afterEventId int64
+
+	if lastEventId := ctx.GetHeader(lastEventIdHeader); lastEventId != "" {
+		var err error
+
+		afterEventId, err = strconv.ParseInt(lastEventId, 10, 64)
+		if err != nil || afterEventId < 0 {
+			SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+			return
+		}
+	}
+
+	events, err := ctrl.disbursementStreamUsecase.Subscribe(ctx.Request.Context(), usecase.DisbursementStreamFilter{
+		DisbursementId: disbursementId,
+		AfterEventId:   afterEventId,
+	})
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	// the stream outlive the server write timeout, not supported by the test recorder
+	_ = http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+
+	ctx.Header("Content-Type", sse.ContentType)
+	ctx.Header("Cache-Control", "no-cache")
+	// disable nginx response buffering
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.WriteHeaderNow()
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(ctrl.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	// events is closed when the client disconnected, the request context is cancelled
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			ctx.Render(-1, sse.Event{
+				Id:    strconv.FormatInt(event.Id, 10),
+				Event: disbursementStatusEventName,
+				Data: DisbursementStatusEventResponse{
+					EventId:        event.Id,
+					DisbursementId: event.DisbursementId,
+					Status:         event.Status.ToString(),
+					PreviousStatus: event.PreviousStatus.ToString(),
+					Version:        event.Version,
+					CreatedAt:      event.CreatedAt,
+				},
+			})
+		case <-heartbeat.C:
+			_, err := io.WriteString(ctx.Writer, ": heartbeat\n\n")
+			if err != nil {
+				return
+			}
+		}
+
+		ctx.Writer.Flush()
+	}
+}
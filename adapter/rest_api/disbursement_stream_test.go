@@ -0,0 +1,110 @@
+package rest_api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	mock_usecase "github.com/nobbyphala/Brick/mock/usecase"
+	"github.com/nobbyphala/Brick/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDisbursementStreamController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDisbursementStreamUsecase := mock_usecase.NewMockDisbursementStream(ctrl)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// the stream end when the usecase close the channel
+	streamOf := func(events ...domain.DisbursementStatusEvent) <-chan domain.DisbursementStatusEvent {
+		ch := make(chan domain.DisbursementStatusEvent, len(events))
+		for _, event := range events {
+			ch <- event
+		}
+		close(ch)
+		return ch
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		lastEventId string
+		wantStatus  int
+		want        string
+		mock        func()
+	}{
+		{
+			name:       "stream every disbursement",
+			path:       "/disbursements/stream",
+			wantStatus: http.StatusOK,
+			want: "id:11\nevent:status\ndata:{\"event_id\":11,\"disbursement_id\":\"disb-1\",\"status\":\"COMPLETED\",\"previous_status\":\"PENDING\",\"version\":2,\"created_at\":\"2024-01-01T00:00:00Z\"}\n\n" +
+				"id:12\nevent:status\ndata:{\"event_id\":12,\"disbursement_id\":\"disb-2\",\"status\":\"FAILED\",\"previous_status\":\"PENDING\",\"version\":3,\"created_at\":\"2024-01-01T00:00:00Z\"}\n\n",
+			mock: func() {
+				mockDisbursementStreamUsecase.EXPECT().Subscribe(gomock.Any(), usecase.DisbursementStreamFilter{}).Return(streamOf(
+					domain.DisbursementStatusEvent{Id: 11, DisbursementId: "disb-1", Status: domain.DisbursementStatusCompleted, PreviousStatus: domain.DisbursementStatusPending, Version: 2, CreatedAt: createdAt},
+					domain.DisbursementStatusEvent{Id: 12, DisbursementId: "disb-2", Status: domain.DisbursementStatusFailed, PreviousStatus: domain.DisbursementStatusPending, Version: 3, CreatedAt: createdAt},
+				), nil)
+			},
+		},
+		{
+			name:        "resume a disbursement after last event id",
+			path:        "/disbursements/disb-1/stream",
+			lastEventId: "10",
+			wantStatus:  http.StatusOK,
+			want:        "",
+			mock: func() {
+				mockDisbursementStreamUsecase.EXPECT().Subscribe(gomock.Any(), usecase.DisbursementStreamFilter{DisbursementId: "disb-1", AfterEventId: 10}).Return(streamOf(), nil)
+			},
+		},
+		{
+			name:        "invalid last event id",
+			path:        "/disbursements/stream",
+			lastEventId: "abc",
+			wantStatus:  http.StatusBadRequest,
+			want:        `{"message":"invalid request"}`,
+			mock:        func() {},
+		},
+		{
+			name:       "disbursement not found",
+			path:       "/disbursements/disb-2/stream",
+			wantStatus: http.StatusNotFound,
+			want:       `{"message":"error disbursement not found"}`,
+			mock: func() {
+				mockDisbursementStreamUsecase.EXPECT().Subscribe(gomock.Any(), usecase.DisbursementStreamFilter{DisbursementId: "disb-2"}).Return(nil, internal_error.ErrDisbursementNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			controller := NewDisbursementStreamController(DisbursementStreamControllerDeps{DisbursementStreamUsecase: mockDisbursementStreamUsecase})
+
+			router := gin.New()
+			router.GET("/disbursements/stream", controller.StreamDisbursements)
+			router.GET("/disbursements/:id/stream", controller.StreamDisbursement)
+
+			req, err := http.NewRequest("GET", tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.lastEventId != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventId)
+			}
+			respRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.wantStatus, respRecorder.Code)
+			assert.Equal(t, tt.want, respRecorder.Body.String())
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "text/event-stream", respRecorder.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
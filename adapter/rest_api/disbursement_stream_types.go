@@ -0,0 +1,13 @@
+package rest_api
+
+import "time"
+
+// DisbursementStatusEventResponse is the data of the "status" server-sent event, the event id is EventId
+type DisbursementStatusEventResponse struct {
+	EventId        int64     `json:"event_id"`
+	DisbursementId string    `json:"disbursement_id"`
+	Status         string    `json:"status"`
+	PreviousStatus string    `json:"previous_status"`
+	Version        int64     `json:"version"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/pii"
 	"github.com/nobbyphala/Brick/external/validator"
 	mock_usecase "github.com/nobbyphala/Brick/mock/usecase"
 	"github.com/nobbyphala/Brick/usecase"
@@ -14,6 +16,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestDisbursementController_VerifyDisbursement(t *testing.T) {
@@ -52,14 +55,20 @@ func TestDisbursementController_VerifyDisbursement(t *testing.T) {
 				},
 			},
 			wantStatus: http.StatusOK,
-			want:       `{"message":"disbursement successfully verified"}`,
+			want:       `{"message":"disbursement successfully verified","account_holder_name":"Nobby Phala Putra","name_match_score":80,"name_match_decision":"ACCEPTED","verification_token":"v1.1704067500.abcd","verification_token_expires_at":"2024-01-01T00:05:00Z"}`,
 			mock: func() {
 				mockDisbursementUsecase.EXPECT().VerifyDisbursement(gomock.Any(), domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "94578",
 					RecipientBankCode:      "BANK A",
 					Amount:                 90000,
-				}).Return(nil)
+				}).Return(usecase.DisbursementVerification{
+					AccountHolderName: "Nobby Phala Putra",
+					NameMatchScore:    80,
+					NameMatchDecision: domain.NameMatchAccepted,
+					Token:             "v1.1704067500.abcd",
+					ExpiresAt:         time.Date(2024, 1, 1, 0, 5, 0, 0, time.UTC),
+				}, nil)
 			},
 		},
 		{
@@ -91,7 +100,7 @@ func TestDisbursementController_VerifyDisbursement(t *testing.T) {
 					RecipientAccountNumber: "94578",
 					RecipientBankCode:      "BANK A",
 					Amount:                 90000,
-				}).Return(errors.New("error bank account not found"))
+				}).Return(usecase.DisbursementVerification{}, errors.New("error bank account not found"))
 			},
 		},
 		{
@@ -123,7 +132,7 @@ func TestDisbursementController_VerifyDisbursement(t *testing.T) {
 					RecipientAccountNumber: "94578",
 					RecipientBankCode:      "BANK A",
 					Amount:                 90000,
-				}).Return(errors.New("error bank account is blocked"))
+				}).Return(usecase.DisbursementVerification{}, errors.New("error bank account is blocked"))
 			},
 		},
 		{
@@ -155,7 +164,7 @@ func TestDisbursementController_VerifyDisbursement(t *testing.T) {
 					RecipientAccountNumber: "94578",
 					RecipientBankCode:      "BANK A",
 					Amount:                 90000,
-				}).Return(errors.New("error when trying verify disbursement"))
+				}).Return(usecase.DisbursementVerification{}, errors.New("error when trying verify disbursement"))
 			},
 		},
 		{
@@ -260,9 +269,11 @@ func TestDisbursementController_Disburse(t *testing.T) {
 	type fields struct {
 		disbursementUsecase usecase.Disbursement
 		validator           validator.Validator
+		maskingPolicy       pii.Policy
 	}
 	type args struct {
-		req interface{}
+		req    interface{}
+		caller *domain.Caller
 	}
 	tests := []struct {
 		name       string
@@ -273,7 +284,7 @@ func TestDisbursementController_Disburse(t *testing.T) {
 		mock       func()
 	}{
 		{
-			name: "successfully Disburse",
+			name: "successfully Disburse with masked recipient",
 			fields: fields{
 				disbursementUsecase: mockDisbursementUsecase,
 				validator:           validator.NewValidator(),
@@ -284,9 +295,56 @@ func TestDisbursementController_Disburse(t *testing.T) {
 					RecipientAccountNumber: "94578",
 					RecipientBankCode:      "BANK A",
 					Amount:                 90000,
+					VerificationToken:      "v1.1704067500.abcd",
 				},
 			},
 			wantStatus: http.StatusOK,
+			want: func() string {
+				res := DisbursementResponse{
+					Id:                     "disb-id-1",
+					RecipientName:          "N**** P****",
+					RecipientAccountNumber: "*4578",
+					RecipientBankCode:      "BANK A",
+					Amount:                 90000,
+					Status:                 "PENDING",
+				}
+
+				jsonByte, _ := json.Marshal(res)
+				return string(jsonByte)
+			}(),
+			mock: func() {
+				mockDisbursementUsecase.EXPECT().Disburse(gomock.Any(), domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "94578",
+					RecipientBankCode:      "BANK A",
+					Amount:                 90000,
+				}, usecase.DisburseOptions{VerificationToken: "v1.1704067500.abcd"}).Return(domain.Disbursement{
+					Id:                     "disb-id-1",
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "94578",
+					RecipientBankCode:      "BANK A",
+					Amount:                 90000,
+					Status:                 1,
+				}, nil)
+			},
+		},
+		{
+			name: "successfully Disburse with unmasked recipient for allowed role",
+			fields: fields{
+				disbursementUsecase: mockDisbursementUsecase,
+				validator:           validator.NewValidator(),
+				maskingPolicy:       pii.NewPolicy("admin"),
+			},
+			args: args{
+				req: DisburseRequest{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "94578",
+					RecipientBankCode:      "BANK A",
+					Amount:                 90000,
+				},
+				caller: &domain.Caller{Id: "admin-1", Role: "admin"},
+			},
+			wantStatus: http.StatusOK,
 			want: func() string {
 				res := DisbursementResponse{
 					Id:                     "disb-id-1",
@@ -306,7 +364,7 @@ func TestDisbursementController_Disburse(t *testing.T) {
 					RecipientAccountNumber: "94578",
 					RecipientBankCode:      "BANK A",
 					Amount:                 90000,
-				}).Return(domain.Disbursement{
+				}, usecase.DisburseOptions{}).Return(domain.Disbursement{
 					Id:                     "disb-id-1",
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "94578",
@@ -343,7 +401,7 @@ func TestDisbursementController_Disburse(t *testing.T) {
 					RecipientAccountNumber: "94578",
 					RecipientBankCode:      "BANK A",
 					Amount:                 90000,
-				}).Return(domain.Disbursement{}, errors.New("error when try to disburse"))
+				}, usecase.DisburseOptions{}).Return(domain.Disbursement{}, errors.New("error when try to disburse"))
 			},
 		},
 		{
@@ -357,7 +415,7 @@ func TestDisbursementController_Disburse(t *testing.T) {
 					RecipientName:          "",
 					RecipientAccountNumber: "094578A",
 					RecipientBankCode:      "",
-					Amount:                 90000,
+					Amount:                 -90000,
 				},
 			},
 			wantStatus: http.StatusBadRequest,
@@ -367,7 +425,7 @@ func TestDisbursementController_Disburse(t *testing.T) {
 					Errors: []ValidationErrorItem{
 						{
 							Field: "RecipientName",
-							Error: "RecipientName must be greater than 1 character in length",
+							Error: "RecipientName is a required field",
 						},
 						{
 							Field: "RecipientAccountNumber",
@@ -375,7 +433,84 @@ func TestDisbursementController_Disburse(t *testing.T) {
 						},
 						{
 							Field: "RecipientBankCode",
-							Error: "RecipientBankCode must be at least 1 character in length",
+							Error: "RecipientBankCode is a required field",
+						},
+						{
+							Field: "Amount",
+							Error: "Amount must be greater than 0",
+						},
+					},
+				}
+
+				jsonByte, _ := json.Marshal(res)
+				return string(jsonByte)
+			}(),
+			mock: func() {
+
+			},
+		},
+		{
+			name: "successfully Disburse to beneficiary",
+			fields: fields{
+				disbursementUsecase: mockDisbursementUsecase,
+				validator:           validator.NewValidator(),
+				maskingPolicy:       pii.NewPolicy("admin"),
+			},
+			args: args{
+				req: DisburseRequest{
+					BeneficiaryId: "beneficiary-1",
+					Amount:        90000,
+				},
+				caller: &domain.Caller{Id: "admin-1", Role: "admin"},
+			},
+			wantStatus: http.StatusOK,
+			want: func() string {
+				res := DisbursementResponse{
+					Id:                     "disb-id-1",
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "94578",
+					RecipientBankCode:      "BANK A",
+					Amount:                 90000,
+					Status:                 "PENDING",
+				}
+
+				jsonByte, _ := json.Marshal(res)
+				return string(jsonByte)
+			}(),
+			mock: func() {
+				mockDisbursementUsecase.EXPECT().Disburse(gomock.Any(), domain.Disbursement{
+					Amount: 90000,
+				}, usecase.DisburseOptions{BeneficiaryId: "beneficiary-1"}).Return(domain.Disbursement{
+					Id:                     "disb-id-1",
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "94578",
+					RecipientBankCode:      "BANK A",
+					Amount:                 90000,
+					Status:                 1,
+				}, nil)
+			},
+		},
+		{
+			name: "beneficiary sent with recipient",
+			fields: fields{
+				disbursementUsecase: mockDisbursementUsecase,
+				validator:           validator.NewValidator(),
+			},
+			args: args{
+				req: DisburseRequest{
+					BeneficiaryId:     "beneficiary-1",
+					RecipientBankCode: "BANK A",
+					Amount:            90000,
+				},
+			},
+			wantStatus: http.StatusBadRequest,
+			want: func() string {
+				res := ValidationErrorResponse{
+					Message: "invalid request",
+					Errors: []ValidationErrorItem{
+						{
+							Field: "RecipientBankCode",
+							Error: "RecipientBankCode is an excluded field",
 						},
 					},
 				}
@@ -416,9 +551,15 @@ func TestDisbursementController_Disburse(t *testing.T) {
 			controller := DisbursementController{
 				disbursementUsecase: tt.fields.disbursementUsecase,
 				validator:           tt.fields.validator,
+				maskingPolicy:       tt.fields.maskingPolicy,
 			}
 
 			router := gin.New()
+			if tt.args.caller != nil {
+				router.Use(func(ctx *gin.Context) {
+					ctx.Request = ctx.Request.WithContext(domain.ContextWithCaller(ctx.Request.Context(), *tt.args.caller))
+				})
+			}
 			router.POST("/test", controller.Disburse)
 
 			requestBody, _ := json.Marshal(tt.args.req)
@@ -593,3 +734,67 @@ func TestDisbursementController_HandleBankCallback(t *testing.T) {
 		})
 	}
 }
+
+func TestDisbursementController_GetDisbursement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDisbursementUsecase := mock_usecase.NewMockDisbursement(ctrl)
+
+	tests := []struct {
+		name       string
+		id         string
+		want       string
+		wantStatus int
+		mock       func()
+	}{
+		{
+			name:       "get disbursement with masked recipient",
+			id:         "disb-id-1",
+			wantStatus: http.StatusOK,
+			want:       `{"id":"disb-id-1","recipient_name":"N**** P****","recipient_account_number":"*4578","recipient_bank_code":"BANK A","amount":90000,"status":"PENDING"}`,
+			mock: func() {
+				mockDisbursementUsecase.EXPECT().GetDisbursement(gomock.Any(), "disb-id-1").Return(domain.Disbursement{
+					Id:                     "disb-id-1",
+					MerchantId:             "merchant-1",
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "94578",
+					RecipientBankCode:      "BANK A",
+					Amount:                 90000,
+					Status:                 domain.DisbursementStatusPending,
+				}, nil)
+			},
+		},
+		{
+			name:       "disbursement of other merchant",
+			id:         "disb-id-2",
+			wantStatus: http.StatusNotFound,
+			want:       `{"message":"error disbursement not found"}`,
+			mock: func() {
+				mockDisbursementUsecase.EXPECT().GetDisbursement(gomock.Any(), "disb-id-2").Return(domain.Disbursement{}, internal_error.ErrDisbursementNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			controller := NewDisbursementController(DisbursementControllerDeps{
+				DisbursementUsecase: mockDisbursementUsecase,
+			})
+
+			router := gin.New()
+			router.GET("/test/:id", controller.GetDisbursement)
+
+			req, err := http.NewRequest("GET", "/test/"+tt.id, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			respRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.wantStatus, respRecorder.Code)
+			assert.Equal(t, tt.want, respRecorder.Body.String())
+		})
+	}
+}
@@ -1,17 +1,35 @@
 package rest_api
 
+import "time"
+
 type VerifyDisbursementRequest struct {
 	RecipientName          string `json:"recipient_name" validate:"gt=1,required"`
 	RecipientAccountNumber string `json:"recipient_account_number" validate:"gte=1,numeric"`
 	RecipientBankCode      string `json:"recipient_bank_code" validate:"gte=1"`
-	Amount                 int64  `json:"amount"`
+	Amount                 int64  `json:"amount" validate:"gt=0"`
+}
+
+// VerifyDisbursementResponse the account holder name is not masked, the merchant need it to confirm the recipient
+type VerifyDisbursementResponse struct {
+	Message           string `json:"message"`
+	AccountHolderName string `json:"account_holder_name"`
+	// similarity of the recipient name to the account holder name from 0 to 100
+	NameMatchScore    int    `json:"name_match_score"`
+	NameMatchDecision string `json:"name_match_decision"`
+	// sent with the disbursement of the same recipient and amount to skip verifying it again
+	VerificationToken          string     `json:"verification_token,omitempty"`
+	VerificationTokenExpiresAt *time.Time `json:"verification_token_expires_at,omitempty"`
 }
 
+// DisburseRequest the recipient is either sent or taken from the saved beneficiary of BeneficiaryId
 type DisburseRequest struct {
-	RecipientName          string `json:"recipient_name" validate:"gt=1,required"`
-	RecipientAccountNumber string `json:"recipient_account_number" validate:"gte=1,numeric"`
-	RecipientBankCode      string `json:"recipient_bank_code" validate:"gte=1"`
-	Amount                 int64  `json:"amount"`
+	RecipientName          string `json:"recipient_name" validate:"required_without=BeneficiaryId,excluded_with=BeneficiaryId,omitempty,gt=1"`
+	RecipientAccountNumber string `json:"recipient_account_number" validate:"required_without=BeneficiaryId,excluded_with=BeneficiaryId,omitempty,numeric"`
+	RecipientBankCode      string `json:"recipient_bank_code" validate:"required_without=BeneficiaryId,excluded_with=BeneficiaryId"`
+	BeneficiaryId          string `json:"beneficiary_id"`
+	Amount                 int64  `json:"amount" validate:"gt=0"`
+	// returned by disbursement verify, the recipient is verified again when empty, expired or not matching
+	VerificationToken string `json:"verification_token"`
 }
 
 type DisbursementResponse struct {
@@ -21,6 +39,10 @@ type DisbursementResponse struct {
 	RecipientBankCode      string `json:"recipient_bank_code"`
 	Amount                 int64  `json:"amount"`
 	Status                 string `json:"status"`
+	BankEvidenceReference  string `json:"bank_evidence_reference,omitempty"`
+	// absent for disbursement created before name matching
+	NameMatchScore    *int   `json:"name_match_score,omitempty"`
+	NameMatchDecision string `json:"name_match_decision,omitempty"`
 }
 
 type BankTransferCallbackRequest struct {
@@ -0,0 +1,129 @@
+package rest_api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/validator"
+	"github.com/nobbyphala/Brick/usecase"
+	"net/http"
+)
+
+type MerchantController struct {
+	merchantUsecase usecase.Merchant
+	validator       validator.Validator
+}
+
+type MerchantControllerDeps struct {
+	MerchantUsecase usecase.Merchant
+}
+
+func NewMerchantController(deps MerchantControllerDeps) *MerchantController {
+	return &MerchantController{
+		merchantUsecase: deps.MerchantUsecase,
+		validator:       validator.NewValidator(),
+	}
+}
+
+func (ctrl MerchantController) CreateMerchant(ctx *gin.Context) {
+	merchant, ok := ctrl.bindMerchant(ctx)
+	if !ok {
+		return
+	}
+
+	merchant, err := ctrl.merchantUsecase.CreateMerchant(ctx.Request.Context(), merchant)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toMerchantResponse(merchant))
+}
+
+func (ctrl MerchantController) GetMerchant(ctx *gin.Context) {
+	merchant, err := ctrl.merchantUsecase.GetMerchant(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toMerchantResponse(merchant))
+}
+
+func (ctrl MerchantController) ListMerchants(ctx *gin.Context) {
+	merchants, err := ctrl.merchantUsecase.ListMerchants(ctx.Request.Context())
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	response := make([]MerchantResponse, 0, len(merchants))
+	for _, merchant := range merchants {
+		response = append(response, toMerchantResponse(merchant))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (ctrl MerchantController) UpdateMerchant(ctx *gin.Context) {
+	merchant, ok := ctrl.bindMerchant(ctx)
+	if !ok {
+		return
+	}
+
+	merchant.Id = ctx.Param("id")
+	merchant, err := ctrl.merchantUsecase.UpdateMerchant(ctx.Request.Context(), merchant)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toMerchantResponse(merchant))
+}
+
+func (ctrl MerchantController) bindMerchant(ctx *gin.Context) (domain.Merchant, bool) {
+	var requestBody MerchantRequest
+
+	err := ctx.BindJSON(&requestBody)
+	if err != nil {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return domain.Merchant{}, false
+	}
+
+	validationErrors := ctrl.validator.ValidateStruct(requestBody)
+	if validationErrors != nil {
+		SendValidationErrorResponse(ctx, "invalid request", validationErrors)
+		return domain.Merchant{}, false
+	}
+
+	return domain.Merchant{
+		Name: requestBody.Name,
+		Settings: domain.MerchantSettings{
+			AllowedBankCodes:     requestBody.Settings.AllowedBankCodes,
+			MaxAmount:            requestBody.Settings.MaxAmount,
+			DailyLimit:           requestBody.Settings.DailyLimit,
+			NameMatchRejectBelow: requestBody.Settings.NameMatchRejectBelow,
+			NameMatchReviewBelow: requestBody.Settings.NameMatchReviewBelow,
+		},
+	}, true
+}
+
+func toMerchantResponse(merchant domain.Merchant) MerchantResponse {
+	allowedBankCodes := merchant.Settings.AllowedBankCodes
+	if allowedBankCodes == nil {
+		allowedBankCodes = []string{}
+	}
+
+	return MerchantResponse{
+		Id:   merchant.Id,
+		Name: merchant.Name,
+		Settings: MerchantSettingsResponse{
+			AllowedBankCodes:     allowedBankCodes,
+			MaxAmount:            merchant.Settings.MaxAmount,
+			DailyLimit:           merchant.Settings.DailyLimit,
+			NameMatchRejectBelow: merchant.Settings.NameMatchRejectBelow,
+			NameMatchReviewBelow: merchant.Settings.NameMatchReviewBelow,
+		},
+		CreatedAt: merchant.CreatedAt,
+	}
+}
@@ -0,0 +1,151 @@
+package rest_api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	mock_usecase "github.com/nobbyphala/Brick/mock/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMerchantController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMerchantUsecase := mock_usecase.NewMockMerchant(ctrl)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	merchant := domain.Merchant{
+		Id:   "merchant-1",
+		Name: "Toko Nobby",
+		Settings: domain.MerchantSettings{
+			AllowedBankCodes:     []string{"BCA"},
+			DailyLimit:           1000000,
+			NameMatchRejectBelow: 50,
+			NameMatchReviewBelow: 80,
+		},
+		CreatedAt: createdAt,
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		req        interface{}
+		wantStatus int
+		want       string
+		mock       func()
+	}{
+		{
+			name:   "create merchant",
+			method: "POST",
+			path:   "/admin/merchants",
+			req: MerchantRequest{
+				Name: "Toko Nobby",
+				Settings: MerchantSettingsRequest{
+					AllowedBankCodes:     []string{"BCA"},
+					DailyLimit:           1000000,
+					NameMatchRejectBelow: 50,
+					NameMatchReviewBelow: 80,
+				},
+			},
+			wantStatus: http.StatusCreated,
+			want:       `{"id":"merchant-1","name":"Toko Nobby","settings":{"allowed_bank_codes":["BCA"],"max_amount":0,"daily_limit":1000000,"name_match_reject_below":50,"name_match_review_below":80},"created_at":"2024-01-01T00:00:00Z"}`,
+			mock: func() {
+				mockMerchantUsecase.EXPECT().CreateMerchant(gomock.Any(), domain.Merchant{
+					Name:     merchant.Name,
+					Settings: merchant.Settings,
+				}).Return(merchant, nil)
+			},
+		},
+		{
+			name:       "create merchant with invalid limit and name match threshold",
+			method:     "POST",
+			path:       "/admin/merchants",
+			req:        MerchantRequest{Name: "Toko Nobby", Settings: MerchantSettingsRequest{DailyLimit: -1, NameMatchReviewBelow: 101}},
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"invalid request","errors":[{"field":"DailyLimit","error":"DailyLimit must be 0 or greater"},{"field":"NameMatchReviewBelow","error":"NameMatchReviewBelow must be 100 or less"}]}`,
+			mock:       func() {},
+		},
+		{
+			name:       "update merchant",
+			method:     "PUT",
+			path:       "/admin/merchants/merchant-1",
+			req:        MerchantRequest{Name: "Toko Nobby", Settings: MerchantSettingsRequest{MaxAmount: 500000}},
+			wantStatus: http.StatusOK,
+			want:       `{"id":"merchant-1","name":"Toko Nobby","settings":{"allowed_bank_codes":[],"max_amount":500000,"daily_limit":0,"name_match_reject_below":0,"name_match_review_below":0},"created_at":"2024-01-01T00:00:00Z"}`,
+			mock: func() {
+				mockMerchantUsecase.EXPECT().UpdateMerchant(gomock.Any(), domain.Merchant{
+					Id:       "merchant-1",
+					Name:     "Toko Nobby",
+					Settings: domain.MerchantSettings{MaxAmount: 500000},
+				}).Return(domain.Merchant{
+					Id:        "merchant-1",
+					Name:      "Toko Nobby",
+					Settings:  domain.MerchantSettings{MaxAmount: 500000},
+					CreatedAt: createdAt,
+				}, nil)
+			},
+		},
+		{
+			name:       "get unknown merchant",
+			method:     "GET",
+			path:       "/admin/merchants/merchant-2",
+			wantStatus: http.StatusNotFound,
+			want:       `{"message":"error merchant not found"}`,
+			mock: func() {
+				mockMerchantUsecase.EXPECT().GetMerchant(gomock.Any(), "merchant-2").Return(domain.Merchant{}, internal_error.ErrMerchantNotFound)
+			},
+		},
+		{
+			name:       "list merchants",
+			method:     "GET",
+			path:       "/admin/merchants",
+			wantStatus: http.StatusOK,
+			want: func() string {
+				jsonByte, _ := json.Marshal([]MerchantResponse{toMerchantResponse(merchant)})
+				return string(jsonByte)
+			}(),
+			mock: func() {
+				mockMerchantUsecase.EXPECT().ListMerchants(gomock.Any()).Return([]domain.Merchant{merchant}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			controller := NewMerchantController(MerchantControllerDeps{MerchantUsecase: mockMerchantUsecase})
+
+			router := gin.New()
+			router.GET("/admin/merchants", controller.ListMerchants)
+			router.POST("/admin/merchants", controller.CreateMerchant)
+			router.GET("/admin/merchants/:id", controller.GetMerchant)
+			router.PUT("/admin/merchants/:id", controller.UpdateMerchant)
+
+			body := bytes.NewBuffer(nil)
+			if tt.req != nil {
+				requestBody, _ := json.Marshal(tt.req)
+				body = bytes.NewBuffer(requestBody)
+			}
+
+			req, err := http.NewRequest(tt.method, tt.path, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			respRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.wantStatus, respRecorder.Code)
+			assert.Equal(t, tt.want, respRecorder.Body.String())
+		})
+	}
+}
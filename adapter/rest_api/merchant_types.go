@@ -0,0 +1,36 @@
+package rest_api
+
+import "time"
+
+type MerchantSettingsRequest struct {
+	// empty means every bank code is allowed
+	AllowedBankCodes []string `json:"allowed_bank_codes"`
+	// 0 means no limit
+	MaxAmount int64 `json:"max_amount" validate:"gte=0"`
+	// 0 means no limit
+	DailyLimit int64 `json:"daily_limit" validate:"gte=0"`
+	// recipient name scoring below these against the bank account holder name is rejected or flagged for review,
+	// from 0 to 100. 0 disable the threshold
+	NameMatchRejectBelow int `json:"name_match_reject_below" validate:"gte=0,lte=100"`
+	NameMatchReviewBelow int `json:"name_match_review_below" validate:"gte=0,lte=100"`
+}
+
+type MerchantRequest struct {
+	Name     string                  `json:"name" validate:"required"`
+	Settings MerchantSettingsRequest `json:"settings"`
+}
+
+type MerchantSettingsResponse struct {
+	AllowedBankCodes     []string `json:"allowed_bank_codes"`
+	MaxAmount            int64    `json:"max_amount"`
+	DailyLimit           int64    `json:"daily_limit"`
+	NameMatchRejectBelow int      `json:"name_match_reject_below"`
+	NameMatchReviewBelow int      `json:"name_match_review_below"`
+}
+
+type MerchantResponse struct {
+	Id        string                   `json:"id"`
+	Name      string                   `json:"name"`
+	Settings  MerchantSettingsResponse `json:"settings"`
+	CreatedAt time.Time                `json:"created_at"`
+}
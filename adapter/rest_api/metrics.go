@@ -0,0 +1,75 @@
+package rest_api
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/external/metrics"
+	"github.com/nobbyphala/Brick/usecase"
+	"log"
+	"net/http"
+)
+
+type MetricsController struct {
+	registry *metrics.Registry
+}
+
+type MetricsControllerDeps struct {
+	Registry              *metrics.Registry
+	CallbackReviewUsecase usecase.CallbackReview
+}
+
+func NewMetricsController(deps MetricsControllerDeps) *MetricsController {
+	registerCallbackReviewMetrics(deps.Registry, deps.CallbackReviewUsecase)
+
+	return &MetricsController{
+		registry: deps.Registry,
+	}
+}
+
+// Metrics is scraped by prometheus
+func (ctrl MetricsController) Metrics(ctx *gin.Context) {
+	ctx.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	ctx.Status(http.StatusOK)
+
+	err := ctrl.registry.Write(ctx.Request.Context(), ctx.Writer)
+	if err != nil {
+		log.Println("error writing metrics", err)
+	}
+}
+
+// registerCallbackReviewMetrics expose the size of the review queue so ops can be alerted when it grows
+func registerCallbackReviewMetrics(registry *metrics.Registry, callbackReviewUsecase usecase.CallbackReview) {
+	registry.GaugeFunc("brick_callback_review_open", "number of open bank callback reviews", func(ctx context.Context) ([]metrics.Sample, error) {
+		counts, err := callbackReviewUsecase.CountOpenCallbackReviews(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		samples := make([]metrics.Sample, 0, len(counts))
+		for _, count := range counts {
+			samples = append(samples, metrics.Sample{
+				Labels: metrics.Labels{"reason": string(count.Reason)},
+				Value:  float64(count.Open),
+			})
+		}
+
+		return samples, nil
+	})
+
+	registry.GaugeFunc("brick_callback_review_unassigned", "number of open bank callback reviews without assignee", func(ctx context.Context) ([]metrics.Sample, error) {
+		counts, err := callbackReviewUsecase.CountOpenCallbackReviews(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		samples := make([]metrics.Sample, 0, len(counts))
+		for _, count := range counts {
+			samples = append(samples, metrics.Sample{
+				Labels: metrics.Labels{"reason": string(count.Reason)},
+				Value:  float64(count.Unassigned),
+			})
+		}
+
+		return samples, nil
+	})
+}
@@ -0,0 +1,81 @@
+package rest_api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/usecase"
+	"log"
+	"strings"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+type AuthMiddleware struct {
+	apiKeyUsecase usecase.ApiKey
+	auditUsecase  usecase.Audit
+}
+
+type AuthMiddlewareDeps struct {
+	ApiKeyUsecase usecase.ApiKey
+	// denied attempts are recorded to audit log
+	AuditUsecase usecase.Audit
+}
+
+func NewAuthMiddleware(deps AuthMiddlewareDeps) *AuthMiddleware {
+	return &AuthMiddleware{
+		apiKeyUsecase: deps.ApiKeyUsecase,
+		auditUsecase:  deps.AuditUsecase,
+	}
+}
+
+// Authenticate reject request without valid api key, the key is read from "Authorization: Bearer <key>" or
+// X-API-Key header. The caller identity is attached to the request context
+func (mw AuthMiddleware) Authenticate(ctx *gin.Context) {
+	key := ctx.GetHeader(apiKeyHeader)
+
+	scheme, token, found := strings.Cut(ctx.GetHeader("Authorization"), " ")
+	if key == "" && found && strings.EqualFold(scheme, "Bearer") {
+		key = strings.TrimSpace(token)
+	}
+
+	if key == "" {
+		SendErrorResponse(ctx, internal_error.ErrUnauthorized)
+		ctx.Abort()
+		return
+	}
+
+	caller, err := mw.apiKeyUsecase.Authenticate(ctx.Request.Context(), key)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Request = ctx.Request.WithContext(domain.ContextWithCaller(ctx.Request.Context(), caller))
+	ctx.Next()
+}
+
+// RequirePermission reject authenticated caller which role is not granted the permission, see domain.Role.Can
+func (mw AuthMiddleware) RequirePermission(permission domain.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		caller, _ := domain.CallerFromContext(ctx.Request.Context())
+		if caller.Role.Can(permission) {
+			ctx.Next()
+			return
+		}
+
+		err := mw.auditUsecase.Record(ctx.Request.Context(), domain.AuditLog{
+			Action:     string(permission),
+			ResourceId: ctx.Request.Method + " " + ctx.FullPath(),
+			Outcome:    domain.AuditOutcomeDenied,
+			Reason:     "role is not granted the permission",
+		})
+		if err != nil {
+			log.Println("error recording denied access:", err)
+		}
+
+		SendErrorResponse(ctx, internal_error.ErrForbidden)
+		ctx.Abort()
+	}
+}
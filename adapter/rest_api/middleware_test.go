@@ -0,0 +1,140 @@
+package rest_api
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	mock_usecase "github.com/nobbyphala/Brick/mock/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApiKeyUsecase := mock_usecase.NewMockApiKey(ctrl)
+	mockAuditUsecase := mock_usecase.NewMockAudit(ctrl)
+
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		permission domain.Permission
+		wantStatus int
+		wantCaller string
+		mock       func()
+	}{
+		{
+			name:       "bearer token",
+			headers:    map[string]string{"Authorization": "Bearer brk_abc.secret"},
+			permission: domain.PermissionDisbursementCreate,
+			wantStatus: http.StatusOK,
+			wantCaller: "client-1",
+			mock: func() {
+				mockApiKeyUsecase.EXPECT().Authenticate(gomock.Any(), "brk_abc.secret").Return(domain.Caller{Id: "client-1", Role: domain.RoleClient}, nil)
+			},
+		},
+		{
+			name:       "api key header",
+			headers:    map[string]string{"X-API-Key": "brk_abc.secret"},
+			permission: domain.PermissionDisbursementCreate,
+			wantStatus: http.StatusOK,
+			wantCaller: "client-1",
+			mock: func() {
+				mockApiKeyUsecase.EXPECT().Authenticate(gomock.Any(), "brk_abc.secret").Return(domain.Caller{Id: "client-1", Role: domain.RoleClient}, nil)
+			},
+		},
+		{
+			name:       "missing key",
+			headers:    map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+			permission: domain.PermissionDisbursementCreate,
+			wantStatus: http.StatusUnauthorized,
+			mock:       func() {},
+		},
+		{
+			name:       "invalid key",
+			headers:    map[string]string{"X-API-Key": "brk_abc.wrong"},
+			permission: domain.PermissionDisbursementCreate,
+			wantStatus: http.StatusUnauthorized,
+			mock: func() {
+				mockApiKeyUsecase.EXPECT().Authenticate(gomock.Any(), "brk_abc.wrong").Return(domain.Caller{}, internal_error.ErrUnauthorized)
+			},
+		},
+		{
+			name:       "error authenticate",
+			headers:    map[string]string{"X-API-Key": "brk_abc.secret"},
+			permission: domain.PermissionDisbursementCreate,
+			wantStatus: http.StatusInternalServerError,
+			mock: func() {
+				mockApiKeyUsecase.EXPECT().Authenticate(gomock.Any(), "brk_abc.secret").Return(domain.Caller{}, errors.New("sql error"))
+			},
+		},
+		{
+			name:       "merchant key call bank callback",
+			headers:    map[string]string{"X-API-Key": "brk_abc.secret"},
+			permission: domain.PermissionBankCallback,
+			wantStatus: http.StatusForbidden,
+			mock: func() {
+				mockApiKeyUsecase.EXPECT().Authenticate(gomock.Any(), "brk_abc.secret").Return(domain.Caller{Id: "client-1", Role: domain.RoleClient}, nil)
+				mockAuditUsecase.EXPECT().Record(gomock.Any(), domain.AuditLog{
+					Action:     "bank_callback.process",
+					ResourceId: "GET /test",
+					Outcome:    domain.AuditOutcomeDenied,
+					Reason:     "role is not granted the permission",
+				}).Return(nil)
+			},
+		},
+		{
+			name:       "merchant key call admin route",
+			headers:    map[string]string{"X-API-Key": "brk_abc.secret"},
+			permission: domain.PermissionApiKeyManage,
+			wantStatus: http.StatusForbidden,
+			mock: func() {
+				mockApiKeyUsecase.EXPECT().Authenticate(gomock.Any(), "brk_abc.secret").Return(domain.Caller{Id: "client-1", Role: domain.RoleClient}, nil)
+				mockAuditUsecase.EXPECT().Record(gomock.Any(), gomock.Any()).Return(errors.New("sql error"))
+			},
+		},
+		{
+			name:       "operator read disbursement",
+			headers:    map[string]string{"X-API-Key": "brk_abc.secret"},
+			permission: domain.PermissionDisbursementRead,
+			wantStatus: http.StatusOK,
+			wantCaller: "ops-1",
+			mock: func() {
+				mockApiKeyUsecase.EXPECT().Authenticate(gomock.Any(), "brk_abc.secret").Return(domain.Caller{Id: "ops-1", Role: domain.RoleOpsViewer}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			mw := NewAuthMiddleware(AuthMiddlewareDeps{ApiKeyUsecase: mockApiKeyUsecase, AuditUsecase: mockAuditUsecase})
+
+			router := gin.New()
+			router.GET("/test", mw.Authenticate, mw.RequirePermission(tt.permission), func(ctx *gin.Context) {
+				caller, _ := domain.CallerFromContext(ctx.Request.Context())
+				ctx.String(http.StatusOK, caller.Id)
+			})
+
+			req, err := http.NewRequest("GET", "/test", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			respRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.wantStatus, respRecorder.Code)
+			if tt.wantCaller != "" {
+				assert.Equal(t, tt.wantCaller, respRecorder.Body.String())
+			}
+		})
+	}
+}
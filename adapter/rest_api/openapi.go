@@ -0,0 +1,73 @@
+package rest_api
+
+import (
+	_ "embed"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/external/openapi"
+	"net/http"
+)
+
+// openApiSpec describe every route of RegisterRouter, it is checked against the request and response types by test
+//
+//go:embed openapi/openapi.json
+var openApiSpec []byte
+
+//go:embed openapi/swagger.html
+var swaggerPage []byte
+
+type OpenApiController struct {
+	requestValidator openapi.RequestValidator
+	validateRequests bool
+	swaggerAssets    http.Handler
+}
+
+type OpenApiControllerDeps struct {
+	// reject request that does not match the document before it reach the controllers
+	ValidateRequests bool
+}
+
+func NewOpenApiController(deps OpenApiControllerDeps) (*OpenApiController, error) {
+	doc, err := openapi.Load(openApiSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	requestValidator, err := openapi.NewRequestValidator(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenApiController{
+		requestValidator: requestValidator,
+		validateRequests: deps.ValidateRequests,
+		swaggerAssets:    http.StripPrefix("/docs", openapi.SwaggerUIAssets()),
+	}, nil
+}
+
+func (ctrl OpenApiController) Spec(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "application/json", openApiSpec)
+}
+
+// SwaggerUI serve the embedded Swagger UI under /docs/ showing the document of Spec
+func (ctrl OpenApiController) SwaggerUI(ctx *gin.Context) {
+	switch ctx.Param("filepath") {
+	case "/", "/index.html":
+		ctx.Data(http.StatusOK, "text/html; charset=utf-8", swaggerPage)
+	default:
+		ctrl.swaggerAssets.ServeHTTP(ctx.Writer, ctx.Request)
+	}
+}
+
+// ValidateRequest reject request whose parameters or body does not match the document with the same response as
+// the controllers validation. Request of undocumented route is passed to the next handler
+func (ctrl OpenApiController) ValidateRequest(ctx *gin.Context) {
+	if !ctrl.validateRequests {
+		return
+	}
+
+	validationErrors := ctrl.requestValidator.Validate(ctx.Request)
+	if validationErrors != nil {
+		SendValidationErrorResponse(ctx, "invalid request", validationErrors)
+		ctx.Abort()
+	}
+}
@@ -0,0 +1,429 @@
+package rest_api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/external/metrics"
+	"github.com/nobbyphala/Brick/external/openapi"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// documentedTypes is every request and response type of the api by its schema name in openapi.json
+var documentedTypes = map[string]reflect.Type{
+	"ErrorResponse":                   reflect.TypeOf(ErrorResponse{}),
+	"ValidationErrorItem":             reflect.TypeOf(ValidationErrorItem{}),
+	"ValidationErrorResponse":         reflect.TypeOf(ValidationErrorResponse{}),
+	"VerifyDisbursementRequest":       reflect.TypeOf(VerifyDisbursementRequest{}),
+	"VerifyDisbursementResponse":      reflect.TypeOf(VerifyDisbursementResponse{}),
+	"DisburseRequest":                 reflect.TypeOf(DisburseRequest{}),
+	"DisbursementResponse":            reflect.TypeOf(DisbursementResponse{}),
+	"BankTransferCallbackRequest":     reflect.TypeOf(BankTransferCallbackRequest{}),
+	"DisbursementStatusEventResponse": reflect.TypeOf(DisbursementStatusEventResponse{}),
+	"ForceStatusRequest":              reflect.TypeOf(ForceStatusRequest{}),
+	"AttachBankEvidenceRequest":       reflect.TypeOf(AttachBankEvidenceRequest{}),
+	"BankStatusCheckResponse":         reflect.TypeOf(BankStatusCheckResponse{}),
+	"IssueApiKeyRequest":              reflect.TypeOf(IssueApiKeyRequest{}),
+	"RotateApiKeyRequest":             reflect.TypeOf(RotateApiKeyRequest{}),
+	"ApiKeyResponse":                  reflect.TypeOf(ApiKeyResponse{}),
+	"IssuedApiKeyResponse":            reflect.TypeOf(IssuedApiKeyResponse{}),
+	"MerchantSettingsRequest":         reflect.TypeOf(MerchantSettingsRequest{}),
+	"MerchantRequest":                 reflect.TypeOf(MerchantRequest{}),
+	"MerchantSettingsResponse":        reflect.TypeOf(MerchantSettingsResponse{}),
+	"MerchantResponse":                reflect.TypeOf(MerchantResponse{}),
+	"AssignCallbackReviewRequest":     reflect.TypeOf(AssignCallbackReviewRequest{}),
+	"ResolveCallbackReviewRequest":    reflect.TypeOf(ResolveCallbackReviewRequest{}),
+	"CommentCallbackReviewRequest":    reflect.TypeOf(CommentCallbackReviewRequest{}),
+	"CallbackReviewResponse":          reflect.TypeOf(CallbackReviewResponse{}),
+	"CallbackReviewCommentResponse":   reflect.TypeOf(CallbackReviewCommentResponse{}),
+	"CallbackReviewDetailResponse":    reflect.TypeOf(CallbackReviewDetailResponse{}),
+	"WebhookEndpointRequest":          reflect.TypeOf(WebhookEndpointRequest{}),
+	"WebhookEndpointResponse":         reflect.TypeOf(WebhookEndpointResponse{}),
+	"WebhookDeliveryResponse":         reflect.TypeOf(WebhookDeliveryResponse{}),
+	"WebhookDeliveryAttemptResponse":  reflect.TypeOf(WebhookDeliveryAttemptResponse{}),
+	"WebhookDeliveryDetailResponse":   reflect.TypeOf(WebhookDeliveryDetailResponse{}),
+	"BeneficiaryRequest":              reflect.TypeOf(BeneficiaryRequest{}),
+	"BeneficiaryResponse":             reflect.TypeOf(BeneficiaryResponse{}),
+	"BeneficiaryVerificationResponse": reflect.TypeOf(BeneficiaryVerificationResponse{}),
+	"AuditLogResponse":                reflect.TypeOf(AuditLogResponse{}),
+	"EvictVerificationRequest":        reflect.TypeOf(EvictVerificationRequest{}),
+	"ResolveScreeningRequest":         reflect.TypeOf(ResolveScreeningRequest{}),
+	"BlocklistEntryRequest":           reflect.TypeOf(BlocklistEntryRequest{}),
+	"DeleteBlocklistEntryRequest":     reflect.TypeOf(DeleteBlocklistEntryRequest{}),
+	"ScreeningMatchResponse":          reflect.TypeOf(ScreeningMatchResponse{}),
+	"ScreeningResponse":               reflect.TypeOf(ScreeningResponse{}),
+	"ScreeningDetailResponse":         reflect.TypeOf(ScreeningDetailResponse{}),
+	"ImportScreeningListResponse":     reflect.TypeOf(ImportScreeningListResponse{}),
+	"ScreeningEntryResponse":          reflect.TypeOf(ScreeningEntryResponse{}),
+}
+
+// routes that are not part of the api
+var undocumentedRoutes = map[string]bool{
+	"GET /docs/*filepath": true,
+}
+
+var ginPathParam = regexp.MustCompile(`:([a-z_]+)`)
+
+func TestOpenApiSpec_Routes(t *testing.T) {
+	doc, err := openapi.Load(openApiSpec)
+	assert.NoError(t, err)
+
+	openApiController, err := NewOpenApiController(OpenApiControllerDeps{})
+	assert.NoError(t, err)
+
+	r := gin.New()
+	RegisterRouter(r, RouteController{
+		DisbursementController:          &DisbursementController{},
+		DisbursementStreamController:    &DisbursementStreamController{},
+		DisbursementOperationController: &DisbursementOperationController{},
+		CallbackReviewController:        &CallbackReviewController{},
+		WebhookController:               &WebhookController{},
+		VerificationCacheController:     &VerificationCacheController{},
+		BeneficiaryController:           &BeneficiaryController{},
+		ScreeningController:             &ScreeningController{},
+		MetricsController:               &MetricsController{},
+		ApiKeyController:                &ApiKeyController{},
+		MerchantController:              &MerchantController{},
+		AuditLogController:              &AuditLogController{},
+		OpenApiController:               openApiController,
+		AuthMiddleware:                  &AuthMiddleware{},
+		DeprecationMiddleware:           NewDeprecationMiddleware(DeprecationMiddlewareDeps{Registry: metrics.NewRegistry()}),
+		LegacyRoutes:                    true,
+	})
+
+	var registered []string
+	for _, route := range r.Routes() {
+		if undocumentedRoutes[route.Method+" "+route.Path] {
+			continue
+		}
+
+		registered = append(registered, route.Method+" "+ginPathParam.ReplaceAllString(route.Path, "{$1}"))
+	}
+
+	// every operation is served under each server of the document unless it has its own servers
+	var documented []string
+	for path, pathItem := range doc.Paths {
+		for method, operation := range pathItem.Operations() {
+			servers := doc.Servers
+			if operation.Servers != nil {
+				servers = *operation.Servers
+			}
+
+			for _, server := range servers {
+				documented = append(documented, method+" "+strings.TrimSuffix(server.URL, "/")+path)
+			}
+		}
+	}
+
+	assert.ElementsMatch(t, registered, documented)
+}
+
+func TestOpenApiSpec_Schemas(t *testing.T) {
+	doc, err := openapi.Load(openApiSpec)
+	assert.NoError(t, err)
+
+	var schemaNames []string
+	for name := range doc.Components.Schemas {
+		schemaNames = append(schemaNames, name)
+	}
+
+	var typeNames []string
+	for name := range documentedTypes {
+		typeNames = append(typeNames, name)
+	}
+
+	assert.ElementsMatch(t, typeNames, schemaNames)
+
+	for name, typ := range documentedTypes {
+		schemaRef, exists := doc.Components.Schemas[name]
+		if !exists {
+			continue
+		}
+
+		t.Run(name, func(t *testing.T) {
+			assertSchemaMatchType(t, schemaRef.Value, typ)
+		})
+	}
+}
+
+// assertSchemaMatchType compare the properties, their type and the required properties with the json and validate
+// tags of the struct fields. Embedded structs are flattened like encoding/json does
+func assertSchemaMatchType(t *testing.T, schema *openapi3.Schema, typ reflect.Type) {
+	fields := jsonFields(typ)
+
+	var fieldNames, propertyNames, required []string
+	for name, field := range fields {
+		fieldNames = append(fieldNames, name)
+
+		rules := strings.Split(field.Tag.Get("validate"), ",")
+		for _, rule := range rules {
+			if rule == "required" {
+				required = append(required, name)
+			}
+
+			if values, found := strings.CutPrefix(rule, "oneof="); found {
+				property, exists := schema.Properties[name]
+				if assert.True(t, exists, name) {
+					var enum []string
+					for _, value := range property.Value.Enum {
+						enum = append(enum, value.(string))
+					}
+					assert.ElementsMatch(t, strings.Fields(values), enum, "enum of %s", name)
+				}
+			}
+		}
+	}
+
+	for name := range schema.Properties {
+		propertyNames = append(propertyNames, name)
+	}
+
+	assert.ElementsMatch(t, fieldNames, propertyNames, "properties")
+	assert.ElementsMatch(t, required, schema.Required, "required properties")
+
+	for name, field := range fields {
+		property, exists := schema.Properties[name]
+		if !exists {
+			continue
+		}
+
+		assertPropertyMatchType(t, name, property, field.Type)
+	}
+}
+
+func assertPropertyMatchType(t *testing.T, name string, property *openapi3.SchemaRef, typ reflect.Type) {
+	if typ.Kind() == reflect.Pointer {
+		assert.True(t, property.Value.Nullable, "%s is nullable", name)
+		typ = typ.Elem()
+	}
+
+	switch {
+	case typ == reflect.TypeOf(json.RawMessage{}):
+		assert.Empty(t, property.Value.Type, "%s accept any json", name)
+	case typ == reflect.TypeOf(time.Time{}):
+		assert.Equal(t, "string", property.Value.Type, name)
+		assert.Equal(t, "date-time", property.Value.Format, name)
+	case typ.Kind() == reflect.Struct:
+		assert.Equal(t, "#/components/schemas/"+typ.Name(), property.Ref, name)
+	case typ.Kind() == reflect.Slice:
+		if assert.Equal(t, "array", property.Value.Type, name) {
+			assertPropertyMatchType(t, name+"[]", property.Value.Items, typ.Elem())
+		}
+	case typ.Kind() == reflect.String:
+		assert.Equal(t, "string", property.Value.Type, name)
+	case typ.Kind() == reflect.Int || typ.Kind() == reflect.Int64:
+		assert.Equal(t, "integer", property.Value.Type, name)
+	case typ.Kind() == reflect.Bool:
+		assert.Equal(t, "boolean", property.Value.Type, name)
+	default:
+		t.Errorf("%s has unsupported type %s", name, typ)
+	}
+}
+
+func jsonFields(typ reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		if field.Anonymous {
+			for name, embedded := range jsonFields(field.Type) {
+				fields[name] = embedded
+			}
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		fields[name] = field
+	}
+
+	return fields
+}
+
+func TestOpenApiController_ValidateRequest(t *testing.T) {
+	type args struct {
+		method      string
+		path        string
+		contentType string
+		body        string
+	}
+	tests := []struct {
+		name             string
+		validateRequests bool
+		args             args
+		wantStatus       int
+		wantFields       []string
+	}{
+		{
+			name:             "valid request",
+			validateRequests: true,
+			args: args{
+				method:      http.MethodPost,
+				path:        "/v1/disbursement",
+				contentType: "application/json",
+				body:        `{"recipient_name":"Nobby Phala","recipient_account_number":"6789567","recipient_bank_code":"014","amount":60000}`,
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:             "invalid body",
+			validateRequests: true,
+			args: args{
+				method:      http.MethodPost,
+				path:        "/v1/disbursement",
+				contentType: "application/json",
+				body:        `{"recipient_account_number":"abc","recipient_bank_code":"014","amount":"60000"}`,
+			},
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{"amount", "recipient_account_number"},
+		},
+		{
+			name:             "invalid body of deprecated alias",
+			validateRequests: true,
+			args: args{
+				method:      http.MethodPost,
+				path:        "/disbursement",
+				contentType: "application/json",
+				body:        `{"recipient_account_number":"abc","recipient_bank_code":"014","amount":0}`,
+			},
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{"amount", "recipient_account_number"},
+		},
+		{
+			name:             "invalid query parameter",
+			validateRequests: true,
+			args: args{
+				method: http.MethodGet,
+				path:   "/v1/admin/audit-logs?limit=ten",
+			},
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{"limit"},
+		},
+		{
+			name:             "invalid enum",
+			validateRequests: true,
+			args: args{
+				method:      http.MethodPost,
+				path:        "/v1/admin/callback-reviews/review-1/resolve",
+				contentType: "application/json",
+				body:        `{"resolution":"ignore","note":"duplicate"}`,
+			},
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{"resolution"},
+		},
+		{
+			name:             "undocumented route",
+			validateRequests: true,
+			args: args{
+				method: http.MethodGet,
+				path:   "/undocumented",
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:             "validation disabled",
+			validateRequests: false,
+			args: args{
+				method:      http.MethodPost,
+				path:        "/disbursement",
+				contentType: "application/json",
+				body:        `{"amount":"60000"}`,
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, err := NewOpenApiController(OpenApiControllerDeps{ValidateRequests: tt.validateRequests})
+			assert.NoError(t, err)
+
+			var received string
+
+			router := gin.New()
+			router.Use(ctrl.ValidateRequest)
+			router.NoRoute(func(ctx *gin.Context) {
+				// the body can still be read by the controller
+				buf := new(bytes.Buffer)
+				_, _ = buf.ReadFrom(ctx.Request.Body)
+				received = buf.String()
+
+				ctx.Status(http.StatusOK)
+			})
+
+			req, err := http.NewRequest(tt.args.method, tt.args.path, strings.NewReader(tt.args.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.args.contentType != "" {
+				req.Header.Set("Content-Type", tt.args.contentType)
+			}
+			respRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.wantStatus, respRecorder.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, tt.args.body, received)
+				return
+			}
+
+			var response ValidationErrorResponse
+			assert.NoError(t, json.Unmarshal(respRecorder.Body.Bytes(), &response))
+			assert.Equal(t, "invalid request", response.Message)
+
+			var fields []string
+			for _, item := range response.Errors {
+				fields = append(fields, item.Field)
+			}
+			sort.Strings(fields)
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestOpenApiController_Docs(t *testing.T) {
+	ctrl, err := NewOpenApiController(OpenApiControllerDeps{})
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/openapi.json", ctrl.Spec)
+	router.GET("/docs/*filepath", ctrl.SwaggerUI)
+
+	tests := []struct {
+		path            string
+		wantContentType string
+		wantContains    string
+	}{
+		{path: "/openapi.json", wantContentType: "application/json", wantContains: `"openapi": "3.0.3"`},
+		{path: "/docs/", wantContentType: "text/html; charset=utf-8", wantContains: `url: "/openapi.json"`},
+		{path: "/docs/swagger-ui-bundle.js", wantContentType: "text/javascript; charset=utf-8", wantContains: "SwaggerUIBundle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			respRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, http.StatusOK, respRecorder.Code)
+			assert.Equal(t, tt.wantContentType, respRecorder.Header().Get("Content-Type"))
+			assert.Contains(t, respRecorder.Body.String(), tt.wantContains)
+		})
+	}
+}
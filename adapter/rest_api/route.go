@@ -1,13 +1,123 @@
 package rest_api
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+)
 
 type RouteController struct {
-	DisbursementController *DisbursementController
+	DisbursementController          *DisbursementController
+	DisbursementStreamController    *DisbursementStreamController
+	DisbursementOperationController *DisbursementOperationController
+	CallbackReviewController        *CallbackReviewController
+	WebhookController               *WebhookController
+	BeneficiaryController           *BeneficiaryController
+	VerificationCacheController     *VerificationCacheController
+	ScreeningController             *ScreeningController
+	MetricsController               *MetricsController
+	ApiKeyController                *ApiKeyController
+	MerchantController              *MerchantController
+	AuditLogController              *AuditLogController
+	OpenApiController               *OpenApiController
+	AuthMiddleware                  *AuthMiddleware
+	DeprecationMiddleware           *DeprecationMiddleware
+	// serve the routes of before versioning without version prefix as deprecated aliases of /v1
+	LegacyRoutes bool
 }
 
+type apiVersion struct {
+	prefix   string
+	register func(group *gin.RouterGroup, ctrl RouteController)
+}
+
+// apiVersions are served side by side. A breaking change is made in a new version, for example {"/v2", registerV2},
+// registering the changed handlers and reusing the v1 handlers of the routes that did not change
+var apiVersions = []apiVersion{
+	{prefix: "/v1", register: registerV1},
+}
+
+// RegisterRouter every route except metrics and the api documentation require an api key, what the caller can do is decided by the permission matrix of its role
 func RegisterRouter(r *gin.Engine, ctrl RouteController) {
-	r.POST("/disbursement/verify", ctrl.DisbursementController.VerifyDisbursement)
-	r.POST("/disbursement", ctrl.DisbursementController.Disburse)
-	r.PUT("/disbursement", ctrl.DisbursementController.HandleBankCallback)
+	// not versioned, scraped by prometheus inside the private network
+	r.GET("/metrics", ctrl.MetricsController.Metrics)
+
+	r.GET("/openapi.json", ctrl.OpenApiController.Spec)
+	r.GET("/docs/*filepath", ctrl.OpenApiController.SwaggerUI)
+
+	for _, version := range apiVersions {
+		version.register(r.Group(version.prefix), ctrl)
+	}
+
+	// the routes served before versioning keep working until their sunset
+	if ctrl.LegacyRoutes {
+		registerLegacy(r.Group("/", ctrl.DeprecationMiddleware.Deprecated("/v1")), ctrl)
+	}
+}
+
+// registerLegacy only register the routes that existed before versioning, the routes added since are served under
+// a version prefix only
+func registerLegacy(group *gin.RouterGroup, ctrl RouteController) {
+	auth := ctrl.AuthMiddleware
+
+	authenticated := group.Group("/", auth.Authenticate, ctrl.OpenApiController.ValidateRequest)
+
+	authenticated.POST("/disbursement/verify", auth.RequirePermission(domain.PermissionDisbursementVerify), ctrl.DisbursementController.VerifyDisbursement)
+	authenticated.POST("/disbursement", auth.RequirePermission(domain.PermissionDisbursementCreate), ctrl.DisbursementController.Disburse)
+
+	// called by the bank
+	authenticated.PUT("/disbursement", auth.RequirePermission(domain.PermissionBankCallback), ctrl.DisbursementController.HandleBankCallback)
+}
+
+func registerV1(group *gin.RouterGroup, ctrl RouteController) {
+	auth := ctrl.AuthMiddleware
+
+	authenticated := group.Group("/", auth.Authenticate, ctrl.OpenApiController.ValidateRequest)
+
+	authenticated.POST("/disbursement/verify", auth.RequirePermission(domain.PermissionDisbursementVerify), ctrl.DisbursementController.VerifyDisbursement)
+	authenticated.POST("/disbursement", auth.RequirePermission(domain.PermissionDisbursementCreate), ctrl.DisbursementController.Disburse)
+	authenticated.GET("/disbursement/:id", auth.RequirePermission(domain.PermissionDisbursementRead), ctrl.DisbursementController.GetDisbursement)
+	authenticated.GET("/disbursements/stream", auth.RequirePermission(domain.PermissionDisbursementRead), ctrl.DisbursementStreamController.StreamDisbursements)
+	authenticated.GET("/disbursements/:id/stream", auth.RequirePermission(domain.PermissionDisbursementRead), ctrl.DisbursementStreamController.StreamDisbursement)
+	authenticated.GET("/beneficiaries", auth.RequirePermission(domain.PermissionBeneficiaryRead), ctrl.BeneficiaryController.ListBeneficiaries)
+	authenticated.POST("/beneficiaries", auth.RequirePermission(domain.PermissionBeneficiaryManage), ctrl.BeneficiaryController.CreateBeneficiary)
+	authenticated.GET("/beneficiaries/:id", auth.RequirePermission(domain.PermissionBeneficiaryRead), ctrl.BeneficiaryController.GetBeneficiary)
+	authenticated.PUT("/beneficiaries/:id", auth.RequirePermission(domain.PermissionBeneficiaryManage), ctrl.BeneficiaryController.UpdateBeneficiary)
+	authenticated.DELETE("/beneficiaries/:id", auth.RequirePermission(domain.PermissionBeneficiaryManage), ctrl.BeneficiaryController.DeleteBeneficiary)
+
+	// called by the bank
+	authenticated.PUT("/disbursement", auth.RequirePermission(domain.PermissionBankCallback), ctrl.DisbursementController.HandleBankCallback)
+
+	admin := authenticated.Group("/admin")
+	admin.GET("/api-keys", auth.RequirePermission(domain.PermissionApiKeyManage), ctrl.ApiKeyController.ListApiKeys)
+	admin.POST("/api-keys", auth.RequirePermission(domain.PermissionApiKeyManage), ctrl.ApiKeyController.IssueApiKey)
+	admin.POST("/api-keys/:id/rotate", auth.RequirePermission(domain.PermissionApiKeyManage), ctrl.ApiKeyController.RotateApiKey)
+	admin.DELETE("/api-keys/:id", auth.RequirePermission(domain.PermissionApiKeyManage), ctrl.ApiKeyController.RevokeApiKey)
+	admin.GET("/merchants", auth.RequirePermission(domain.PermissionMerchantRead), ctrl.MerchantController.ListMerchants)
+	admin.POST("/merchants", auth.RequirePermission(domain.PermissionMerchantManage), ctrl.MerchantController.CreateMerchant)
+	admin.GET("/merchants/:id", auth.RequirePermission(domain.PermissionMerchantRead), ctrl.MerchantController.GetMerchant)
+	admin.PUT("/merchants/:id", auth.RequirePermission(domain.PermissionMerchantManage), ctrl.MerchantController.UpdateMerchant)
+	admin.POST("/disbursements/:id/force-status", auth.RequirePermission(domain.PermissionDisbursementOperate), ctrl.DisbursementOperationController.ForceStatus)
+	admin.POST("/disbursements/:id/evidence", auth.RequirePermission(domain.PermissionDisbursementOperate), ctrl.DisbursementOperationController.AttachBankEvidence)
+	admin.POST("/disbursements/:id/recheck", auth.RequirePermission(domain.PermissionDisbursementOperate), ctrl.DisbursementOperationController.RecheckBankStatus)
+	admin.GET("/callback-reviews", auth.RequirePermission(domain.PermissionCallbackReviewRead), ctrl.CallbackReviewController.ListCallbackReviews)
+	admin.GET("/callback-reviews/:id", auth.RequirePermission(domain.PermissionCallbackReviewRead), ctrl.CallbackReviewController.GetCallbackReview)
+	admin.POST("/callback-reviews/:id/assign", auth.RequirePermission(domain.PermissionCallbackReviewManage), ctrl.CallbackReviewController.AssignCallbackReview)
+	admin.POST("/callback-reviews/:id/resolve", auth.RequirePermission(domain.PermissionCallbackReviewManage), ctrl.CallbackReviewController.ResolveCallbackReview)
+	admin.POST("/callback-reviews/:id/comments", auth.RequirePermission(domain.PermissionCallbackReviewManage), ctrl.CallbackReviewController.CommentCallbackReview)
+	admin.GET("/merchants/:id/webhooks", auth.RequirePermission(domain.PermissionWebhookRead), ctrl.WebhookController.ListWebhookEndpoints)
+	admin.POST("/merchants/:id/webhooks", auth.RequirePermission(domain.PermissionWebhookManage), ctrl.WebhookController.CreateWebhookEndpoint)
+	admin.PUT("/merchants/:id/webhooks/:endpoint_id", auth.RequirePermission(domain.PermissionWebhookManage), ctrl.WebhookController.UpdateWebhookEndpoint)
+	admin.DELETE("/merchants/:id/webhooks/:endpoint_id", auth.RequirePermission(domain.PermissionWebhookManage), ctrl.WebhookController.DeleteWebhookEndpoint)
+	admin.GET("/webhook-deliveries", auth.RequirePermission(domain.PermissionWebhookRead), ctrl.WebhookController.ListWebhookDeliveries)
+	admin.GET("/webhook-deliveries/:id", auth.RequirePermission(domain.PermissionWebhookRead), ctrl.WebhookController.GetWebhookDelivery)
+	admin.POST("/webhook-deliveries/:id/replay", auth.RequirePermission(domain.PermissionWebhookReplay), ctrl.WebhookController.ReplayWebhookDelivery)
+	admin.POST("/verification-cache/evict", auth.RequirePermission(domain.PermissionVerificationCacheManage), ctrl.VerificationCacheController.EvictVerification)
+	admin.GET("/screenings", auth.RequirePermission(domain.PermissionScreeningRead), ctrl.ScreeningController.ListScreenings)
+	admin.GET("/screenings/:id", auth.RequirePermission(domain.PermissionScreeningRead), ctrl.ScreeningController.GetScreening)
+	admin.POST("/screenings/:id/resolve", auth.RequirePermission(domain.PermissionScreeningResolve), ctrl.ScreeningController.ResolveScreening)
+	admin.GET("/screening-entries", auth.RequirePermission(domain.PermissionScreeningRead), ctrl.ScreeningController.ListScreeningEntries)
+	admin.PUT("/screening-lists/:name", auth.RequirePermission(domain.PermissionScreeningListManage), ctrl.ScreeningController.ImportScreeningList)
+	admin.POST("/blocklist", auth.RequirePermission(domain.PermissionScreeningListManage), ctrl.ScreeningController.AddBlocklistEntry)
+	admin.DELETE("/blocklist/:id", auth.RequirePermission(domain.PermissionScreeningListManage), ctrl.ScreeningController.DeleteBlocklistEntry)
+	admin.GET("/audit-logs", auth.RequirePermission(domain.PermissionAuditRead), ctrl.AuditLogController.ListAuditLogs)
 }
@@ -0,0 +1,303 @@
+package rest_api
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/pii"
+	"github.com/nobbyphala/Brick/external/validator"
+	"github.com/nobbyphala/Brick/usecase"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// maxScreeningListSize is the largest sanctions list file accepted by the import
+const maxScreeningListSize = 32 << 20
+
+// ScreeningController expose the disbursements held by the sanctions screening for compliance review, and the
+// management of the sanctions lists and the internal blocklist
+type ScreeningController struct {
+	screeningUsecase usecase.Screening
+	validator        validator.Validator
+	maskingPolicy    pii.Policy
+}
+
+type ScreeningControllerDeps struct {
+	ScreeningUsecase usecase.Screening
+	// decide which caller role can see unmasked recipient and blocklist data
+	MaskingPolicy pii.Policy
+}
+
+func NewScreeningController(deps ScreeningControllerDeps) *ScreeningController {
+	return &ScreeningController{
+		screeningUsecase: deps.ScreeningUsecase,
+		validator:        validator.NewValidator(),
+		maskingPolicy:    deps.MaskingPolicy,
+	}
+}
+
+// ListScreenings filter by status and disbursement_id query and page with limit and offset, the oldest screenings
+// come first
+func (ctrl ScreeningController) ListScreenings(ctx *gin.Context) {
+	filter := domain.ScreeningFilter{
+		Status:         domain.ScreeningStatus(ctx.Query("status")),
+		DisbursementId: ctx.Query("disbursement_id"),
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		var err error
+
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil || filter.Limit < 0 {
+			SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+			return
+		}
+	}
+
+	if offset := ctx.Query("offset"); offset != "" {
+		var err error
+
+		filter.Offset, err = strconv.Atoi(offset)
+		if err != nil || filter.Offset < 0 {
+			SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+			return
+		}
+	}
+
+	screenings, err := ctrl.screeningUsecase.ListScreenings(ctx.Request.Context(), filter)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	response := make([]ScreeningResponse, 0, len(screenings))
+	for _, screening := range screenings {
+		response = append(response, ctrl.toScreeningResponse(ctx.Request.Context(), screening))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (ctrl ScreeningController) GetScreening(ctx *gin.Context) {
+	detail, err := ctrl.screeningUsecase.GetScreening(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ctrl.toScreeningDetailResponse(ctx.Request.Context(), detail))
+}
+
+// ResolveScreening release send the held disbursement to the bank, reject reject it without transfer
+func (ctrl ScreeningController) ResolveScreening(ctx *gin.Context) {
+	var requestBody ResolveScreeningRequest
+
+	err := ctx.BindJSON(&requestBody)
+	if err != nil {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return
+	}
+
+	validationErrors := ctrl.validator.ValidateStruct(requestBody)
+	if validationErrors != nil {
+		SendValidationErrorResponse(ctx, "invalid request", validationErrors)
+		return
+	}
+
+	detail, err := ctrl.screeningUsecase.ResolveScreening(ctx.Request.Context(), ctx.Param("id"), domain.ScreeningResolution(requestBody.Resolution), requestBody.Note)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ctrl.toScreeningDetailResponse(ctx.Request.Context(), detail))
+}
+
+// ImportScreeningList replace the entries of the list with the file in the body, the format is taken from the
+// content type, text/csv or application/xml
+func (ctrl ScreeningController) ImportScreeningList(ctx *gin.Context) {
+	var format domain.ScreeningListFormat
+
+	mediaType, _, _ := mime.ParseMediaType(ctx.ContentType())
+	switch mediaType {
+	case "text/csv":
+		format = domain.ScreeningListFormatCSV
+	case "application/xml":
+		format = domain.ScreeningListFormatXML
+	default:
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return
+	}
+
+	file := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxScreeningListSize)
+
+	imported, err := ctrl.screeningUsecase.ImportScreeningList(ctx.Request.Context(), ctx.Param("name"), format, file)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ImportScreeningListResponse{Imported: imported})
+}
+
+// ListScreeningEntries filter by list_name query and page with limit and offset, the newest entries come first
+func (ctrl ScreeningController) ListScreeningEntries(ctx *gin.Context) {
+	filter := domain.ScreeningEntryFilter{
+		ListName: ctx.Query("list_name"),
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		var err error
+
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil || filter.Limit < 0 {
+			SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+			return
+		}
+	}
+
+	if offset := ctx.Query("offset"); offset != "" {
+		var err error
+
+		filter.Offset, err = strconv.Atoi(offset)
+		if err != nil || filter.Offset < 0 {
+			SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+			return
+		}
+	}
+
+	entries, err := ctrl.screeningUsecase.ListScreeningEntries(ctx.Request.Context(), filter)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	response := make([]ScreeningEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		response = append(response, ctrl.toScreeningEntryResponse(ctx.Request.Context(), entry))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (ctrl ScreeningController) AddBlocklistEntry(ctx *gin.Context) {
+	var requestBody BlocklistEntryRequest
+
+	err := ctx.BindJSON(&requestBody)
+	if err != nil {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return
+	}
+
+	validationErrors := ctrl.validator.ValidateStruct(requestBody)
+	if validationErrors != nil {
+		SendValidationErrorResponse(ctx, "invalid request", validationErrors)
+		return
+	}
+
+	entry, err := ctrl.screeningUsecase.AddBlocklistEntry(ctx.Request.Context(), domain.ScreeningEntry{
+		Name:          requestBody.Name,
+		AccountNumber: requestBody.AccountNumber,
+		BankCode:      requestBody.BankCode,
+		Reference:     requestBody.Reason,
+	})
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, ctrl.toScreeningEntryResponse(ctx.Request.Context(), entry))
+}
+
+// DeleteBlocklistEntry take the reason in the body, like the other operations recorded in the audit log
+func (ctrl ScreeningController) DeleteBlocklistEntry(ctx *gin.Context) {
+	var requestBody DeleteBlocklistEntryRequest
+
+	err := ctx.BindJSON(&requestBody)
+	if err != nil {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return
+	}
+
+	validationErrors := ctrl.validator.ValidateStruct(requestBody)
+	if validationErrors != nil {
+		SendValidationErrorResponse(ctx, "invalid request", validationErrors)
+		return
+	}
+
+	err = ctrl.screeningUsecase.DeleteBlocklistEntry(ctx.Request.Context(), ctx.Param("id"), requestBody.Reason)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (ctrl ScreeningController) toScreeningDetailResponse(ctx context.Context, detail usecase.ScreeningDetail) ScreeningDetailResponse {
+	return ScreeningDetailResponse{
+		ScreeningResponse: ctrl.toScreeningResponse(ctx, detail.Screening),
+		Disbursement:      toDisbursementResponse(ctx, ctrl.maskingPolicy, detail.Disbursement),
+	}
+}
+
+// toScreeningResponse mask the matched names unless the caller role is allowed to see it, they can be the name of
+// the recipient
+func (ctrl ScreeningController) toScreeningResponse(ctx context.Context, screening domain.Screening) ScreeningResponse {
+	caller, _ := domain.CallerFromContext(ctx)
+	shouldMask := ctrl.maskingPolicy.ShouldMask(string(caller.Role))
+
+	response := ScreeningResponse{
+		Id:             screening.Id,
+		DisbursementId: screening.DisbursementId,
+		MerchantId:     screening.MerchantId,
+		Status:         string(screening.Status),
+		Matches:        make([]ScreeningMatchResponse, 0, len(screening.Matches)),
+		ResolvedBy:     screening.ResolvedBy,
+		ResolutionNote: screening.ResolutionNote,
+		ResolvedAt:     screening.ResolvedAt,
+		CreatedAt:      screening.CreatedAt,
+		UpdatedAt:      screening.UpdatedAt,
+	}
+
+	for _, match := range screening.Matches {
+		entryName := match.EntryName
+		if shouldMask {
+			entryName = pii.MaskName(entryName)
+		}
+
+		response.Matches = append(response.Matches, ScreeningMatchResponse{
+			EntryId:   match.EntryId,
+			ListName:  match.ListName,
+			EntryName: entryName,
+			Reference: match.Reference,
+			Field:     string(match.Field),
+			Score:     match.Score,
+		})
+	}
+
+	return response
+}
+
+// toScreeningEntryResponse mask the entry unless the caller role is allowed to see it
+func (ctrl ScreeningController) toScreeningEntryResponse(ctx context.Context, entry domain.ScreeningEntry) ScreeningEntryResponse {
+	response := ScreeningEntryResponse{
+		Id:            entry.Id,
+		ListName:      entry.ListName,
+		Name:          entry.Name,
+		AccountNumber: entry.AccountNumber,
+		BankCode:      entry.BankCode,
+		Reference:     entry.Reference,
+		CreatedAt:     entry.CreatedAt,
+	}
+
+	caller, _ := domain.CallerFromContext(ctx)
+	if ctrl.maskingPolicy.ShouldMask(string(caller.Role)) {
+		response.Name = pii.MaskName(response.Name)
+		response.AccountNumber = pii.MaskAccountNumber(response.AccountNumber)
+	}
+
+	return response
+}
@@ -0,0 +1,251 @@
+package rest_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/pii"
+	mock_usecase "github.com/nobbyphala/Brick/mock/usecase"
+	"github.com/nobbyphala/Brick/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestScreeningController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockScreeningUsecase := mock_usecase.NewMockScreening(ctrl)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	screening := domain.Screening{
+		Id:             "screening-1",
+		DisbursementId: "disb-id-1",
+		MerchantId:     "merchant-1",
+		Status:         domain.ScreeningStatusHeld,
+		Matches: []domain.ScreeningMatch{
+			{EntryId: "entry-1", ListName: "OFAC", EntryName: "Nobby Phala", Reference: "SDN-1", Field: domain.ScreeningMatchFieldName, Score: 100},
+		},
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	screeningJSON := `{"id":"screening-1","disbursement_id":"disb-id-1","merchant_id":"merchant-1","status":"HELD","matches":[{"entry_id":"entry-1","list_name":"OFAC","entry_name":"N**** P****","reference":"SDN-1","field":"NAME","score":100}],"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}`
+	released := screening
+	released.Status = domain.ScreeningStatusReleased
+	released.ResolvedBy = "compliance-1"
+	released.ResolutionNote = "different date of birth"
+	released.ResolvedAt = &createdAt
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		req         interface{}
+		wantStatus  int
+		want        string
+		mock        func()
+	}{
+		{
+			name:       "list held screenings",
+			method:     "GET",
+			path:       "/screenings?status=HELD&limit=10",
+			wantStatus: http.StatusOK,
+			want:       "[" + screeningJSON + "]",
+			mock: func() {
+				mockScreeningUsecase.EXPECT().ListScreenings(gomock.Any(), domain.ScreeningFilter{
+					Status: domain.ScreeningStatusHeld,
+					Limit:  10,
+				}).Return([]domain.Screening{screening}, nil)
+			},
+		},
+		{
+			name:       "list screenings with invalid limit",
+			method:     "GET",
+			path:       "/screenings?limit=abc",
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"invalid request"}`,
+			mock:       func() {},
+		},
+		{
+			name:       "release screening",
+			method:     "POST",
+			path:       "/screenings/screening-1/resolve",
+			req:        ResolveScreeningRequest{Resolution: "release", Note: "different date of birth"},
+			wantStatus: http.StatusOK,
+			want:       `{"id":"screening-1","disbursement_id":"disb-id-1","merchant_id":"merchant-1","status":"RELEASED","matches":[{"entry_id":"entry-1","list_name":"OFAC","entry_name":"N**** P****","reference":"SDN-1","field":"NAME","score":100}],"resolved_by":"compliance-1","resolution_note":"different date of birth","resolved_at":"2024-01-01T00:00:00Z","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","disbursement":{"id":"disb-id-1","recipient_name":"N**** P****","recipient_account_number":"***9567","recipient_bank_code":"014","amount":60000,"status":"PENDING"}}`,
+			mock: func() {
+				mockScreeningUsecase.EXPECT().ResolveScreening(gomock.Any(), "screening-1", domain.ScreeningResolutionRelease, "different date of birth").Return(usecase.ScreeningDetail{
+					Screening: released,
+					Disbursement: domain.Disbursement{
+						Id:                     "disb-id-1",
+						RecipientName:          "Nobby Phala",
+						RecipientAccountNumber: "6789567",
+						RecipientBankCode:      "014",
+						Amount:                 60000,
+						Status:                 domain.DisbursementStatusPending,
+					},
+				}, nil)
+			},
+		},
+		{
+			name:       "resolve screening with unknown resolution",
+			method:     "POST",
+			path:       "/screenings/screening-1/resolve",
+			req:        ResolveScreeningRequest{Resolution: "escalate", Note: "need a second opinion"},
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"invalid request","errors":[{"field":"Resolution","error":"Resolution must be one of [release reject]"}]}`,
+			mock:       func() {},
+		},
+		{
+			name:       "resolve screening already resolved",
+			method:     "POST",
+			path:       "/screenings/screening-1/resolve",
+			req:        ResolveScreeningRequest{Resolution: "reject", Note: "confirmed sanctioned party"},
+			wantStatus: http.StatusConflict,
+			want:       `{"message":"` + internal_error.ErrScreeningResolved.Error() + `"}`,
+			mock: func() {
+				mockScreeningUsecase.EXPECT().ResolveScreening(gomock.Any(), "screening-1", domain.ScreeningResolutionReject, "confirmed sanctioned party").Return(usecase.ScreeningDetail{}, internal_error.ErrScreeningResolved)
+			},
+		},
+		{
+			name:        "import csv sanctions list",
+			method:      "PUT",
+			path:        "/screening-lists/ofac",
+			contentType: "text/csv; charset=utf-8",
+			req:         "name,reference\nNobby Phala,SDN-1\n",
+			wantStatus:  http.StatusOK,
+			want:        `{"imported":1}`,
+			mock: func() {
+				mockScreeningUsecase.EXPECT().ImportScreeningList(gomock.Any(), "ofac", domain.ScreeningListFormatCSV, gomock.Any()).DoAndReturn(func(ctx context.Context, listName string, format domain.ScreeningListFormat, file io.Reader) (int, error) {
+					content, err := io.ReadAll(file)
+					assert.NoError(t, err)
+					assert.Equal(t, "name,reference\nNobby Phala,SDN-1\n", string(content))
+					return 1, nil
+				})
+			},
+		},
+		{
+			name:        "import sanctions list of unsupported format",
+			method:      "PUT",
+			path:        "/screening-lists/ofac",
+			contentType: "application/json",
+			req:         `{"name":"Nobby Phala"}`,
+			wantStatus:  http.StatusBadRequest,
+			want:        `{"message":"invalid request"}`,
+			mock:        func() {},
+		},
+		{
+			name:       "list blocklist entries",
+			method:     "GET",
+			path:       "/screening-entries?list_name=internal&offset=5",
+			wantStatus: http.StatusOK,
+			want:       `[{"id":"entry-2","list_name":"INTERNAL","account_number":"***1111","bank_code":"014","reference":"fraud report","created_at":"2024-01-01T00:00:00Z"}]`,
+			mock: func() {
+				mockScreeningUsecase.EXPECT().ListScreeningEntries(gomock.Any(), domain.ScreeningEntryFilter{
+					ListName: "internal",
+					Offset:   5,
+				}).Return([]domain.ScreeningEntry{
+					{Id: "entry-2", ListName: domain.ScreeningListInternal, AccountNumber: "1111111", BankCode: "014", Reference: "fraud report", CreatedAt: createdAt},
+				}, nil)
+			},
+		},
+		{
+			name:       "add blocklist entry",
+			method:     "POST",
+			path:       "/blocklist",
+			req:        BlocklistEntryRequest{AccountNumber: "1111111", BankCode: "014", Reason: "fraud report"},
+			wantStatus: http.StatusCreated,
+			want:       `{"id":"entry-2","list_name":"INTERNAL","account_number":"***1111","bank_code":"014","reference":"fraud report","created_at":"2024-01-01T00:00:00Z"}`,
+			mock: func() {
+				mockScreeningUsecase.EXPECT().AddBlocklistEntry(gomock.Any(), domain.ScreeningEntry{
+					AccountNumber: "1111111",
+					BankCode:      "014",
+					Reference:     "fraud report",
+				}).Return(domain.ScreeningEntry{Id: "entry-2", ListName: domain.ScreeningListInternal, AccountNumber: "1111111", BankCode: "014", Reference: "fraud report", CreatedAt: createdAt}, nil)
+			},
+		},
+		{
+			name:       "add blocklist entry without name and account number",
+			method:     "POST",
+			path:       "/blocklist",
+			req:        BlocklistEntryRequest{BankCode: "014", Reason: "fraud report"},
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"invalid request","errors":[{"field":"Name","error":"Name is a required field"},{"field":"AccountNumber","error":"AccountNumber is a required field"}]}`,
+			mock:       func() {},
+		},
+		{
+			name:       "delete unknown blocklist entry",
+			method:     "DELETE",
+			path:       "/blocklist/entry-3",
+			req:        DeleteBlocklistEntryRequest{Reason: "reported by mistake"},
+			wantStatus: http.StatusNotFound,
+			want:       `{"message":"` + internal_error.ErrBlocklistEntryNotFound.Error() + `"}`,
+			mock: func() {
+				mockScreeningUsecase.EXPECT().DeleteBlocklistEntry(gomock.Any(), "entry-3", "reported by mistake").Return(internal_error.ErrBlocklistEntryNotFound)
+			},
+		},
+		{
+			name:       "delete blocklist entry",
+			method:     "DELETE",
+			path:       "/blocklist/entry-2",
+			req:        DeleteBlocklistEntryRequest{Reason: "reported by mistake"},
+			wantStatus: http.StatusNoContent,
+			mock: func() {
+				mockScreeningUsecase.EXPECT().DeleteBlocklistEntry(gomock.Any(), "entry-2", "reported by mistake").Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			controller := NewScreeningController(ScreeningControllerDeps{
+				ScreeningUsecase: mockScreeningUsecase,
+				MaskingPolicy:    pii.NewPolicy("admin"),
+			})
+
+			router := gin.New()
+			router.GET("/screenings", controller.ListScreenings)
+			router.GET("/screenings/:id", controller.GetScreening)
+			router.POST("/screenings/:id/resolve", controller.ResolveScreening)
+			router.PUT("/screening-lists/:name", controller.ImportScreeningList)
+			router.GET("/screening-entries", controller.ListScreeningEntries)
+			router.POST("/blocklist", controller.AddBlocklistEntry)
+			router.DELETE("/blocklist/:id", controller.DeleteBlocklistEntry)
+
+			body := bytes.NewBuffer(nil)
+			switch req := tt.req.(type) {
+			case nil:
+			case string:
+				body = bytes.NewBufferString(req)
+			default:
+				requestBody, _ := json.Marshal(req)
+				body = bytes.NewBuffer(requestBody)
+			}
+
+			req, err := http.NewRequest(tt.method, tt.path, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			contentType := tt.contentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			req.Header.Set("Content-Type", contentType)
+			respRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.wantStatus, respRecorder.Code)
+			assert.Equal(t, tt.want, respRecorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package rest_api
+
+import "time"
+
+type ResolveScreeningRequest struct {
+	Resolution string `json:"resolution" validate:"oneof=release reject"`
+	Note       string `json:"note" validate:"required"`
+}
+
+// BlocklistEntryRequest block the recipient name, the account number or both, reason is kept as the entry reference
+type BlocklistEntryRequest struct {
+	Name          string `json:"name" validate:"required_without=AccountNumber"`
+	AccountNumber string `json:"account_number" validate:"required_without=Name,omitempty,numeric"`
+	// empty block the account number in every bank
+	BankCode string `json:"bank_code"`
+	Reason   string `json:"reason" validate:"required"`
+}
+
+type DeleteBlocklistEntryRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+type ScreeningMatchResponse struct {
+	EntryId   string `json:"entry_id"`
+	ListName  string `json:"list_name"`
+	EntryName string `json:"entry_name,omitempty"`
+	Reference string `json:"reference,omitempty"`
+	Field     string `json:"field"`
+	Score     int    `json:"score"`
+}
+
+type ScreeningResponse struct {
+	Id             string                   `json:"id"`
+	DisbursementId string                   `json:"disbursement_id"`
+	MerchantId     string                   `json:"merchant_id,omitempty"`
+	Status         string                   `json:"status"`
+	Matches        []ScreeningMatchResponse `json:"matches"`
+	ResolvedBy     string                   `json:"resolved_by,omitempty"`
+	ResolutionNote string                   `json:"resolution_note,omitempty"`
+	ResolvedAt     *time.Time               `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+}
+
+type ScreeningDetailResponse struct {
+	ScreeningResponse
+	Disbursement DisbursementResponse `json:"disbursement"`
+}
+
+type ImportScreeningListResponse struct {
+	Imported int `json:"imported"`
+}
+
+type ScreeningEntryResponse struct {
+	Id            string    `json:"id"`
+	ListName      string    `json:"list_name"`
+	Name          string    `json:"name,omitempty"`
+	AccountNumber string    `json:"account_number,omitempty"`
+	BankCode      string    `json:"bank_code,omitempty"`
+	Reference     string    `json:"reference,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
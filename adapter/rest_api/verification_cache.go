@@ -0,0 +1,52 @@
+package rest_api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/validator"
+	"github.com/nobbyphala/Brick/usecase"
+	"net/http"
+)
+
+// VerificationCacheController let the operators drop a cached bank account verification, for example after the
+// holder closed the account
+type VerificationCacheController struct {
+	verificationCacheUsecase usecase.VerificationCache
+	validator                validator.Validator
+}
+
+type VerificationCacheControllerDeps struct {
+	VerificationCacheUsecase usecase.VerificationCache
+}
+
+func NewVerificationCacheController(deps VerificationCacheControllerDeps) *VerificationCacheController {
+	return &VerificationCacheController{
+		verificationCacheUsecase: deps.VerificationCacheUsecase,
+		validator:                validator.NewValidator(),
+	}
+}
+
+// EvictVerification take the account in the body instead of the path, so the account number is not in access logs
+func (ctrl VerificationCacheController) EvictVerification(ctx *gin.Context) {
+	var requestBody EvictVerificationRequest
+
+	err := ctx.BindJSON(&requestBody)
+	if err != nil {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return
+	}
+
+	validationErrors := ctrl.validator.ValidateStruct(requestBody)
+	if validationErrors != nil {
+		SendValidationErrorResponse(ctx, "invalid request", validationErrors)
+		return
+	}
+
+	err = ctrl.verificationCacheUsecase.EvictVerification(ctx.Request.Context(), requestBody.BankCode, requestBody.AccountNumber, requestBody.Reason)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,78 @@
+package rest_api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	mock_usecase "github.com/nobbyphala/Brick/mock/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerificationCacheController_EvictVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVerificationCacheUsecase := mock_usecase.NewMockVerificationCache(ctrl)
+
+	tests := []struct {
+		name       string
+		req        EvictVerificationRequest
+		wantStatus int
+		want       string
+		mock       func()
+	}{
+		{
+			name:       "evict cached verification",
+			req:        EvictVerificationRequest{BankCode: "BCA", AccountNumber: "1234567890", Reason: "account closed by the holder"},
+			wantStatus: http.StatusNoContent,
+			mock: func() {
+				mockVerificationCacheUsecase.EXPECT().EvictVerification(gomock.Any(), "BCA", "1234567890", "account closed by the holder").Return(nil)
+			},
+		},
+		{
+			name:       "account number not numeric",
+			req:        EvictVerificationRequest{BankCode: "BCA", AccountNumber: "12-34", Reason: "account closed by the holder"},
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"invalid request","errors":[{"field":"AccountNumber","error":"AccountNumber must be a valid numeric value"}]}`,
+			mock:       func() {},
+		},
+		{
+			name:       "verification cache disabled",
+			req:        EvictVerificationRequest{BankCode: "BCA", AccountNumber: "1234567890", Reason: "account closed by the holder"},
+			wantStatus: http.StatusNotFound,
+			want:       `{"message":"error verification cache is not enabled"}`,
+			mock: func() {
+				mockVerificationCacheUsecase.EXPECT().EvictVerification(gomock.Any(), "BCA", "1234567890", "account closed by the holder").Return(internal_error.ErrVerificationCacheDisabled)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			controller := NewVerificationCacheController(VerificationCacheControllerDeps{
+				VerificationCacheUsecase: mockVerificationCacheUsecase,
+			})
+
+			router := gin.New()
+			router.POST("/admin/verification-cache/evict", controller.EvictVerification)
+
+			requestBody, _ := json.Marshal(tt.req)
+			req, err := http.NewRequest("POST", "/admin/verification-cache/evict", bytes.NewBuffer(requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			respRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.wantStatus, respRecorder.Code)
+			assert.Equal(t, tt.want, respRecorder.Body.String())
+		})
+	}
+}
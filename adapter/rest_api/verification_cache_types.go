@@ -0,0 +1,7 @@
+package rest_api
+
+type EvictVerificationRequest struct {
+	BankCode      string `json:"bank_code" validate:"required"`
+	AccountNumber string `json:"account_number" validate:"required,numeric"`
+	Reason        string `json:"reason" validate:"required"`
+}
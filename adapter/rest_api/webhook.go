@@ -0,0 +1,218 @@
+package rest_api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/validator"
+	"github.com/nobbyphala/Brick/usecase"
+	"net/http"
+	"strconv"
+)
+
+// WebhookController manage the webhook endpoints of merchants and expose the delivery logs
+type WebhookController struct {
+	webhookUsecase usecase.Webhook
+	validator      validator.Validator
+}
+
+type WebhookControllerDeps struct {
+	WebhookUsecase usecase.Webhook
+}
+
+func NewWebhookController(deps WebhookControllerDeps) *WebhookController {
+	return &WebhookController{
+		webhookUsecase: deps.WebhookUsecase,
+		validator:      validator.NewValidator(),
+	}
+}
+
+// CreateWebhookEndpoint return the signing secret, it cannot be read again later
+func (ctrl WebhookController) CreateWebhookEndpoint(ctx *gin.Context) {
+	endpoint, ok := ctrl.bindEndpoint(ctx)
+	if !ok {
+		return
+	}
+
+	created, err := ctrl.webhookUsecase.CreateWebhookEndpoint(ctx.Request.Context(), endpoint)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	response := toWebhookEndpointResponse(created)
+	response.Secret = created.Secret
+
+	ctx.JSON(http.StatusCreated, response)
+}
+
+func (ctrl WebhookController) ListWebhookEndpoints(ctx *gin.Context) {
+	endpoints, err := ctrl.webhookUsecase.ListWebhookEndpoints(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	response := make([]WebhookEndpointResponse, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		response = append(response, toWebhookEndpointResponse(endpoint))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (ctrl WebhookController) UpdateWebhookEndpoint(ctx *gin.Context) {
+	endpoint, ok := ctrl.bindEndpoint(ctx)
+	if !ok {
+		return
+	}
+
+	endpoint.Id = ctx.Param("endpoint_id")
+
+	updated, err := ctrl.webhookUsecase.UpdateWebhookEndpoint(ctx.Request.Context(), endpoint)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toWebhookEndpointResponse(updated))
+}
+
+func (ctrl WebhookController) DeleteWebhookEndpoint(ctx *gin.Context) {
+	err := ctrl.webhookUsecase.DeleteWebhookEndpoint(ctx.Request.Context(), ctx.Param("id"), ctx.Param("endpoint_id"))
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (ctrl WebhookController) bindEndpoint(ctx *gin.Context) (domain.WebhookEndpoint, bool) {
+	var requestBody WebhookEndpointRequest
+
+	err := ctx.BindJSON(&requestBody)
+	if err != nil {
+		SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+		return domain.WebhookEndpoint{}, false
+	}
+
+	validationErrors := ctrl.validator.ValidateStruct(requestBody)
+	if validationErrors != nil {
+		SendValidationErrorResponse(ctx, "invalid request", validationErrors)
+		return domain.WebhookEndpoint{}, false
+	}
+
+	endpoint := domain.WebhookEndpoint{
+		MerchantId: ctx.Param("id"),
+		Url:        requestBody.Url,
+	}
+	for _, eventType := range requestBody.EventTypes {
+		endpoint.EventTypes = append(endpoint.EventTypes, domain.WebhookEventType(eventType))
+	}
+
+	return endpoint, true
+}
+
+// ListWebhookDeliveries filter by merchant_id, endpoint_id, disbursement_id and status query, the newest deliveries come first
+func (ctrl WebhookController) ListWebhookDeliveries(ctx *gin.Context) {
+	filter := domain.WebhookDeliveryFilter{
+		MerchantId:     ctx.Query("merchant_id"),
+		EndpointId:     ctx.Query("endpoint_id"),
+		DisbursementId: ctx.Query("disbursement_id"),
+		Status:         domain.WebhookDeliveryStatus(ctx.Query("status")),
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		var err error
+
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil || filter.Limit < 0 {
+			SendErrorResponse(ctx, internal_error.ErrInvalidRequest)
+			return
+		}
+	}
+
+	deliveries, err := ctrl.webhookUsecase.ListWebhookDeliveries(ctx.Request.Context(), filter)
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	response := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, toWebhookDeliveryResponse(delivery))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (ctrl WebhookController) GetWebhookDelivery(ctx *gin.Context) {
+	detail, err := ctrl.webhookUsecase.GetWebhookDelivery(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	response := WebhookDeliveryDetailResponse{
+		WebhookDeliveryResponse: toWebhookDeliveryResponse(detail.Delivery),
+		AttemptLogs:             make([]WebhookDeliveryAttemptResponse, 0, len(detail.Attempts)),
+	}
+	for _, attempt := range detail.Attempts {
+		response.AttemptLogs = append(response.AttemptLogs, WebhookDeliveryAttemptResponse{
+			Attempt:    attempt.Attempt,
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			DurationMs: attempt.Duration.Milliseconds(),
+			CreatedAt:  attempt.CreatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (ctrl WebhookController) ReplayWebhookDelivery(ctx *gin.Context) {
+	delivery, err := ctrl.webhookUsecase.ReplayWebhookDelivery(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		SendErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, toWebhookDeliveryResponse(delivery))
+}
+
+func toWebhookEndpointResponse(endpoint domain.WebhookEndpoint) WebhookEndpointResponse {
+	eventTypes := make([]string, 0, len(endpoint.EventTypes))
+	for _, eventType := range endpoint.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	return WebhookEndpointResponse{
+		Id:         endpoint.Id,
+		MerchantId: endpoint.MerchantId,
+		Url:        endpoint.Url,
+		EventTypes: eventTypes,
+		CreatedAt:  endpoint.CreatedAt,
+	}
+}
+
+func toWebhookDeliveryResponse(delivery domain.WebhookDelivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		Id:             delivery.Id,
+		EndpointId:     delivery.EndpointId,
+		MerchantId:     delivery.MerchantId,
+		EventId:        delivery.EventId,
+		EventType:      string(delivery.EventType),
+		DisbursementId: delivery.DisbursementId,
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		ReplayOf:       delivery.ReplayOf,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
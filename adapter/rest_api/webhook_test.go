@@ -0,0 +1,176 @@
+package rest_api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	mock_usecase "github.com/nobbyphala/Brick/mock/usecase"
+	"github.com/nobbyphala/Brick/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookUsecase := mock_usecase.NewMockWebhook(ctrl)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	endpoint := domain.WebhookEndpoint{
+		Id:         "endpoint-1",
+		MerchantId: "merchant-1",
+		Url:        "https://merchant.test/webhook",
+		Secret:     "whsec_secret",
+		EventTypes: []domain.WebhookEventType{domain.WebhookEventDisbursementCompleted},
+		CreatedAt:  createdAt,
+	}
+	delivery := domain.WebhookDelivery{
+		Id:             "delivery-1",
+		EndpointId:     "endpoint-1",
+		MerchantId:     "merchant-1",
+		EventId:        "disb-1:v2",
+		EventType:      domain.WebhookEventDisbursementCompleted,
+		DisbursementId: "disb-1",
+		Payload:        []byte(`{"id":"disb-1:v2"}`),
+		Status:         domain.WebhookDeliveryStatusFailed,
+		Attempts:       1,
+		NextAttemptAt:  createdAt,
+		LastStatusCode: 500,
+		LastError:      "unexpected status code 500",
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt,
+	}
+	deliveryJSON := `{"id":"delivery-1","endpoint_id":"endpoint-1","merchant_id":"merchant-1","event_id":"disb-1:v2","event_type":"disbursement.completed","disbursement_id":"disb-1","payload":{"id":"disb-1:v2"},"status":"FAILED","attempts":1,"next_attempt_at":"2024-01-01T00:00:00Z","last_status_code":500,"last_error":"unexpected status code 500","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}`
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		req        interface{}
+		wantStatus int
+		want       string
+		mock       func()
+	}{
+		{
+			name:       "create endpoint return the secret",
+			method:     "POST",
+			path:       "/admin/merchants/merchant-1/webhooks",
+			req:        WebhookEndpointRequest{Url: "https://merchant.test/webhook", EventTypes: []string{"disbursement.completed"}},
+			wantStatus: http.StatusCreated,
+			want:       `{"id":"endpoint-1","merchant_id":"merchant-1","url":"https://merchant.test/webhook","event_types":["disbursement.completed"],"secret":"whsec_secret","created_at":"2024-01-01T00:00:00Z"}`,
+			mock: func() {
+				mockWebhookUsecase.EXPECT().CreateWebhookEndpoint(gomock.Any(), domain.WebhookEndpoint{
+					MerchantId: "merchant-1",
+					Url:        "https://merchant.test/webhook",
+					EventTypes: []domain.WebhookEventType{domain.WebhookEventDisbursementCompleted},
+				}).Return(endpoint, nil)
+			},
+		},
+		{
+			name:       "create endpoint without url",
+			method:     "POST",
+			path:       "/admin/merchants/merchant-1/webhooks",
+			req:        WebhookEndpointRequest{},
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"invalid request","errors":[{"field":"Url","error":"Url is a required field"}]}`,
+			mock:       func() {},
+		},
+		{
+			name:       "list endpoints hide the secret",
+			method:     "GET",
+			path:       "/admin/merchants/merchant-1/webhooks",
+			wantStatus: http.StatusOK,
+			want:       `[{"id":"endpoint-1","merchant_id":"merchant-1","url":"https://merchant.test/webhook","event_types":["disbursement.completed"],"created_at":"2024-01-01T00:00:00Z"}]`,
+			mock: func() {
+				mockWebhookUsecase.EXPECT().ListWebhookEndpoints(gomock.Any(), "merchant-1").Return([]domain.WebhookEndpoint{endpoint}, nil)
+			},
+		},
+		{
+			name:       "delete unknown endpoint",
+			method:     "DELETE",
+			path:       "/admin/merchants/merchant-1/webhooks/endpoint-2",
+			wantStatus: http.StatusNotFound,
+			want:       `{"message":"error webhook endpoint not found"}`,
+			mock: func() {
+				mockWebhookUsecase.EXPECT().DeleteWebhookEndpoint(gomock.Any(), "merchant-1", "endpoint-2").Return(internal_error.ErrWebhookEndpointNotFound)
+			},
+		},
+		{
+			name:       "list failed deliveries",
+			method:     "GET",
+			path:       "/admin/webhook-deliveries?status=FAILED&merchant_id=merchant-1",
+			wantStatus: http.StatusOK,
+			want:       "[" + deliveryJSON + "]",
+			mock: func() {
+				mockWebhookUsecase.EXPECT().ListWebhookDeliveries(gomock.Any(), domain.WebhookDeliveryFilter{
+					MerchantId: "merchant-1",
+					Status:     domain.WebhookDeliveryStatusFailed,
+				}).Return([]domain.WebhookDelivery{delivery}, nil)
+			},
+		},
+		{
+			name:       "get delivery with attempts",
+			method:     "GET",
+			path:       "/admin/webhook-deliveries/delivery-1",
+			wantStatus: http.StatusOK,
+			want:       deliveryJSON[:len(deliveryJSON)-1] + `,"attempt_logs":[{"attempt":1,"status_code":500,"error":"unexpected status code 500","duration_ms":120,"created_at":"2024-01-01T00:00:00Z"}]}`,
+			mock: func() {
+				mockWebhookUsecase.EXPECT().GetWebhookDelivery(gomock.Any(), "delivery-1").Return(usecase.WebhookDeliveryDetail{
+					Delivery: delivery,
+					Attempts: []domain.WebhookDeliveryAttempt{
+						{Id: "attempt-1", DeliveryId: "delivery-1", Attempt: 1, StatusCode: 500, Error: "unexpected status code 500", Duration: 120 * time.Millisecond, CreatedAt: createdAt},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:       "replay delivery",
+			method:     "POST",
+			path:       "/admin/webhook-deliveries/delivery-1/replay",
+			wantStatus: http.StatusAccepted,
+			want:       deliveryJSON,
+			mock: func() {
+				mockWebhookUsecase.EXPECT().ReplayWebhookDelivery(gomock.Any(), "delivery-1").Return(delivery, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			controller := NewWebhookController(WebhookControllerDeps{WebhookUsecase: mockWebhookUsecase})
+
+			router := gin.New()
+			router.GET("/admin/merchants/:id/webhooks", controller.ListWebhookEndpoints)
+			router.POST("/admin/merchants/:id/webhooks", controller.CreateWebhookEndpoint)
+			router.DELETE("/admin/merchants/:id/webhooks/:endpoint_id", controller.DeleteWebhookEndpoint)
+			router.GET("/admin/webhook-deliveries", controller.ListWebhookDeliveries)
+			router.GET("/admin/webhook-deliveries/:id", controller.GetWebhookDelivery)
+			router.POST("/admin/webhook-deliveries/:id/replay", controller.ReplayWebhookDelivery)
+
+			body := bytes.NewBuffer(nil)
+			if tt.req != nil {
+				requestBody, _ := json.Marshal(tt.req)
+				body = bytes.NewBuffer(requestBody)
+			}
+
+			req, err := http.NewRequest(tt.method, tt.path, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			respRecorder := httptest.NewRecorder()
+
+			router.ServeHTTP(respRecorder, req)
+
+			assert.Equal(t, tt.wantStatus, respRecorder.Code)
+			assert.Equal(t, tt.want, respRecorder.Body.String())
+		})
+	}
+}
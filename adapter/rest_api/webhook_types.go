@@ -0,0 +1,54 @@
+package rest_api
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookEndpointRequest struct {
+	Url string `json:"url" validate:"required,url"`
+	// empty subscribe every event type
+	EventTypes []string `json:"event_types"`
+}
+
+type WebhookEndpointResponse struct {
+	Id         string   `json:"id"`
+	MerchantId string   `json:"merchant_id"`
+	Url        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	// only returned when the endpoint is created
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	Id             string          `json:"id"`
+	EndpointId     string          `json:"endpoint_id"`
+	MerchantId     string          `json:"merchant_id"`
+	EventId        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	DisbursementId string          `json:"disbursement_id"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	ReplayOf       string          `json:"replay_of,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+type WebhookDeliveryAttemptResponse struct {
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type WebhookDeliveryDetailResponse struct {
+	WebhookDeliveryResponse
+	AttemptLogs []WebhookDeliveryAttemptResponse `json:"attempt_logs"`
+}
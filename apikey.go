@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/nobbyphala/Brick/config"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/crypto"
+	"github.com/nobbyphala/Brick/usecase"
+)
+
+// runApiKeyCommand handle `apikey issue -client-id <id> -name <name> -role <role> [-merchant-id <id>] [-expires-in <duration>]`,
+// `apikey list -client-id <id>` and `apikey revoke <id>` subcommands
+func runApiKeyCommand(ctx context.Context, cfg config.DatabaseConfig, encryptor crypto.FieldEncryptor, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: apikey issue | list | revoke")
+	}
+
+	if cfg.Driver != config.DatabaseDriverPostgres {
+		return fmt.Errorf("apikey is only supported by %s driver", config.DatabaseDriverPostgres)
+	}
+
+	repos, err := newPostgresRepositories(cfg, encryptor)
+	if err != nil {
+		return err
+	}
+	defer repos.closer.Close()
+
+	apiKeyUsecase := usecase.NewApiKey(usecase.ApiKeyDeps{
+		ApiKeyRepository:   repos.apiKey,
+		MerchantRepository: repos.merchant,
+		UtilsRepository:    repos.utils,
+	})
+
+	fs := flag.NewFlagSet("apikey "+args[0], flag.ContinueOnError)
+	clientId := fs.String("client-id", "", "client owning the key")
+	name := fs.String("name", "", "name of the key")
+	role := fs.String("role", string(domain.RoleClient), "role of the key")
+	merchantId := fs.String("merchant-id", "", "merchant the key belongs to, required for client role")
+	expiresIn := fs.Duration("expires-in", 0, "key lifetime, never expire when 0")
+
+	err = fs.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "issue":
+		var expiresAt *time.Time
+		if *expiresIn > 0 {
+			expiry := time.Now().UTC().Add(*expiresIn)
+			expiresAt = &expiry
+		}
+
+		issued, err := apiKeyUsecase.Issue(ctx, usecase.IssueApiKeyData{
+			ClientId:   *clientId,
+			Name:       *name,
+			Role:       domain.Role(*role),
+			MerchantId: *merchantId,
+			ExpiresAt:  expiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("id: %s\nkey: %s\n", issued.ApiKey.Id, issued.Key)
+	case "list":
+		apiKeys, err := apiKeyUsecase.List(ctx, *clientId)
+		if err != nil {
+			return err
+		}
+
+		for _, apiKey := range apiKeys {
+			fmt.Printf("%s\t%s\t%s\t%s\tactive: %t\n", apiKey.Id, apiKey.Prefix, apiKey.Name, apiKey.Role, apiKey.IsActive(time.Now()))
+		}
+	case "revoke":
+		if fs.NArg() != 1 {
+			return fmt.Errorf("usage: apikey revoke <id>")
+		}
+
+		return apiKeyUsecase.Revoke(ctx, fs.Arg(0))
+	default:
+		return fmt.Errorf("unknown apikey command %q", args[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"time"
+)
+
+type ApiConfig struct {
+	// serve the routes of before versioning without version prefix as deprecated aliases of /v1
+	LegacyRoutes bool `json:"legacy_routes" yaml:"legacy_routes"`
+	// when the legacy routes were deprecated, sent in the Deprecation header
+	LegacyDeprecatedAt Time `json:"legacy_deprecated_at" yaml:"legacy_deprecated_at"`
+	// when the legacy routes will be removed, sent in the Sunset header
+	LegacySunset Time `json:"legacy_sunset" yaml:"legacy_sunset"`
+}
+
+func defaultApiConfig() ApiConfig {
+	return ApiConfig{
+		LegacyRoutes:       true,
+		LegacyDeprecatedAt: Time(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)),
+		LegacySunset:       Time(time.Date(2027, 4, 19, 0, 0, 0, 0, time.UTC)),
+	}
+}
+
+func (cfg *ApiConfig) registerFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&cfg.LegacyRoutes, "api-legacy-routes", cfg.LegacyRoutes, "serve the routes of before versioning without version prefix as deprecated aliases of /v1")
+	fs.Var(&cfg.LegacyDeprecatedAt, "api-legacy-deprecated-at", "RFC 3339 time the legacy routes were deprecated")
+	fs.Var(&cfg.LegacySunset, "api-legacy-sunset", "RFC 3339 time the legacy routes will be removed")
+}
+
+func (cfg ApiConfig) validate() []error {
+	var errs []error
+
+	if !cfg.LegacyRoutes {
+		return errs
+	}
+
+	if cfg.LegacyDeprecatedAt.Time().IsZero() || cfg.LegacySunset.Time().IsZero() {
+		errs = append(errs, errors.New("api.legacy_deprecated_at and api.legacy_sunset are required when api.legacy_routes is enabled"))
+	} else if !cfg.LegacySunset.Time().After(cfg.LegacyDeprecatedAt.Time()) {
+		errs = append(errs, errors.New("api.legacy_sunset must be after api.legacy_deprecated_at"))
+	}
+
+	return errs
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"strings"
+)
+
+type AuthConfig struct {
+	// api key registered with admin role on startup, used to issue the first keys. Leave empty in production
+	// and issue keys with `apikey issue` instead
+	BootstrapKey      string `json:"bootstrap_key" yaml:"bootstrap_key"`
+	BootstrapClientId string `json:"bootstrap_client_id" yaml:"bootstrap_client_id"`
+}
+
+func defaultAuthConfig() AuthConfig {
+	return AuthConfig{
+		BootstrapClientId: "bootstrap",
+	}
+}
+
+func (cfg *AuthConfig) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&cfg.BootstrapKey, "auth-bootstrap-key", cfg.BootstrapKey, "admin api key registered on startup, in brk_<prefix>.<secret> format")
+	fs.StringVar(&cfg.BootstrapClientId, "auth-bootstrap-client-id", cfg.BootstrapClientId, "client id of the bootstrap api key")
+}
+
+func (cfg AuthConfig) validate() []error {
+	var errs []error
+
+	if cfg.BootstrapKey != "" && (!strings.HasPrefix(cfg.BootstrapKey, "brk_") || !strings.Contains(cfg.BootstrapKey, ".")) {
+		errs = append(errs, errors.New("auth.bootstrap_key must be in brk_<prefix>.<secret> format"))
+	}
+
+	if cfg.BootstrapKey != "" && len(cfg.BootstrapKey) < 32 {
+		errs = append(errs, errors.New("auth.bootstrap_key must be at least 32 characters"))
+	}
+
+	if cfg.BootstrapClientId == "" {
+		errs = append(errs, errors.New("auth.bootstrap_client_id is required"))
+	}
+
+	return errs
+}
+
+func (cfg AuthConfig) redacted() AuthConfig {
+	cfg.BootstrapKey = redact(cfg.BootstrapKey)
+	return cfg
+}
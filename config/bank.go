@@ -1,5 +1,35 @@
 package config
 
-var (
-	BankBaseURL = "http://localhost:3000"
+import (
+	"errors"
+	"flag"
+	"net/url"
 )
+
+type BankConfig struct {
+	BaseURL string `json:"base_url" yaml:"base_url"`
+	// log every request and response body sent to the bank, personal data is redacted
+	DebugLog bool `json:"debug_log" yaml:"debug_log"`
+}
+
+func defaultBankConfig() BankConfig {
+	return BankConfig{
+		BaseURL: "http://localhost:3000",
+	}
+}
+
+func (cfg *BankConfig) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&cfg.BaseURL, "bank-base-url", cfg.BaseURL, "base url of the bank partner api")
+	fs.BoolVar(&cfg.DebugLog, "bank-debug-log", cfg.DebugLog, "log bank request and response body with personal data redacted")
+}
+
+func (cfg BankConfig) validate() []error {
+	var errs []error
+
+	baseUrl, err := url.Parse(cfg.BaseURL)
+	if cfg.BaseURL == "" || err != nil || baseUrl.Scheme == "" || baseUrl.Host == "" {
+		errs = append(errs, errors.New("bank.base_url must be an absolute url"))
+	}
+
+	return errs
+}
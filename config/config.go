@@ -0,0 +1,249 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the effective application configuration. It is resolved in the following order where the later
+// source override the earlier one: defaults, config file (yaml or json), environment variables, flags.
+//
+// every flag can be set from environment variable by upper casing the flag name and replacing "-" with "_",
+// for example -db-host can be set through DB_HOST. Secret value can also be read from a file by appending
+// _FILE to the environment variable name, for example DB_PASSWORD_FILE.
+type Config struct {
+	Server       ServerConfig       `json:"server" yaml:"server"`
+	Grpc         GrpcConfig         `json:"grpc" yaml:"grpc"`
+	Api          ApiConfig          `json:"api" yaml:"api"`
+	Database     DatabaseConfig     `json:"database" yaml:"database"`
+	Bank         BankConfig         `json:"bank" yaml:"bank"`
+	Encryption   EncryptionConfig   `json:"encryption" yaml:"encryption"`
+	Masking      MaskingConfig      `json:"masking" yaml:"masking"`
+	Auth         AuthConfig         `json:"auth" yaml:"auth"`
+	Webhook      WebhookConfig      `json:"webhook" yaml:"webhook"`
+	Stream       StreamConfig       `json:"stream" yaml:"stream"`
+	Events       EventsConfig       `json:"events" yaml:"events"`
+	Verification VerificationConfig `json:"verification" yaml:"verification"`
+	Screening    ScreeningConfig    `json:"screening" yaml:"screening"`
+}
+
+const (
+	configFileFlag = "config"
+	configFileEnv  = "CONFIG_FILE"
+	redactedValue  = "******"
+)
+
+// flags holding secret value. These can be read from file and will be redacted when printed
+var secretFlags = map[string]bool{
+	"db-password":               true,
+	"auth-bootstrap-key":        true,
+	"verification-token-secret": true,
+}
+
+func Default() Config {
+	return Config{
+		Server:       defaultServerConfig(),
+		Grpc:         defaultGrpcConfig(),
+		Api:          defaultApiConfig(),
+		Database:     defaultDatabaseConfig(),
+		Bank:         defaultBankConfig(),
+		Encryption:   defaultEncryptionConfig(),
+		Masking:      defaultMaskingConfig(),
+		Auth:         defaultAuthConfig(),
+		Webhook:      defaultWebhookConfig(),
+		Stream:       defaultStreamConfig(),
+		Events:       defaultEventsConfig(),
+		Verification: defaultVerificationConfig(),
+		Screening:    defaultScreeningConfig(),
+	}
+}
+
+// Load resolve the configuration from all sources and validate it.
+// args is the command line arguments without the program name. Non flag arguments are returned as is
+func Load(args []string) (Config, []string, error) {
+	cfg := Default()
+
+	configFile := findConfigFile(args)
+	if configFile != "" {
+		err := loadFile(configFile, &cfg)
+		if err != nil {
+			return Config{}, nil, err
+		}
+	}
+
+	fs := flag.NewFlagSet("brick", flag.ContinueOnError)
+	fs.String(configFileFlag, configFile, "path to yaml or json config file, can also be set through "+configFileEnv)
+	cfg.registerFlags(fs)
+
+	err := loadEnv(fs)
+	if err != nil {
+		return Config{}, nil, err
+	}
+
+	err = fs.Parse(args)
+	if err != nil {
+		return Config{}, nil, err
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		return Config{}, nil, err
+	}
+
+	return cfg, fs.Args(), nil
+}
+
+func (cfg Config) Validate() error {
+	var errs []error
+
+	errs = append(errs, cfg.Server.validate()...)
+	errs = append(errs, cfg.Grpc.validate()...)
+	errs = append(errs, cfg.Api.validate()...)
+	errs = append(errs, cfg.Database.validate()...)
+	errs = append(errs, cfg.Bank.validate()...)
+	errs = append(errs, cfg.Encryption.validate()...)
+	errs = append(errs, cfg.Auth.validate()...)
+	errs = append(errs, cfg.Webhook.validate()...)
+	errs = append(errs, cfg.Stream.validate()...)
+	errs = append(errs, cfg.Events.validate()...)
+	errs = append(errs, cfg.Verification.validate()...)
+	errs = append(errs, cfg.Screening.validate()...)
+
+	if cfg.Verification.Cache == VerificationCachePostgres && cfg.Database.Driver != DatabaseDriverPostgres {
+		errs = append(errs, errors.New("verification.cache postgres require database.driver postgres"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// String return the config as json with all secret redacted so it is safe to be logged
+func (cfg Config) String() string {
+	cfg.Database = cfg.Database.redacted()
+	cfg.Auth = cfg.Auth.redacted()
+	cfg.Verification = cfg.Verification.redacted()
+
+	res, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+
+	return string(res)
+}
+
+func (cfg *Config) registerFlags(fs *flag.FlagSet) {
+	cfg.Server.registerFlags(fs)
+	cfg.Grpc.registerFlags(fs)
+	cfg.Api.registerFlags(fs)
+	cfg.Database.registerFlags(fs)
+	cfg.Bank.registerFlags(fs)
+	cfg.Encryption.registerFlags(fs)
+	cfg.Masking.registerFlags(fs)
+	cfg.Auth.registerFlags(fs)
+	cfg.Webhook.registerFlags(fs)
+	cfg.Stream.registerFlags(fs)
+	cfg.Events.registerFlags(fs)
+	cfg.Verification.registerFlags(fs)
+	cfg.Screening.registerFlags(fs)
+}
+
+func findConfigFile(args []string) string {
+	for i, arg := range args {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != configFileFlag {
+			continue
+		}
+
+		if hasValue {
+			return value
+		}
+
+		if i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+
+	return os.Getenv(configFileEnv)
+}
+
+func loadFile(path string, cfg *Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, cfg)
+	case ".json":
+		err = json.Unmarshal(content, cfg)
+	default:
+		return fmt.Errorf("unsupported config file extension %q", filepath.Ext(path))
+	}
+
+	if err != nil {
+		return fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func loadEnv(fs *flag.FlagSet) error {
+	var err error
+
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || f.Name == configFileFlag {
+			return
+		}
+
+		envName := strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+
+		value, exists := os.LookupEnv(envName)
+		if !exists && secretFlags[f.Name] {
+			value, exists, err = readSecretFile(envName + "_FILE")
+		}
+
+		if err != nil || !exists {
+			return
+		}
+
+		setErr := f.Value.Set(value)
+		if setErr != nil {
+			err = fmt.Errorf("invalid value %q for %s: %w", value, envName, setErr)
+		}
+	})
+
+	return err
+}
+
+func readSecretFile(envName string) (string, bool, error) {
+	path, exists := os.LookupEnv(envName)
+	if !exists || path == "" {
+		return "", false, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("error reading %s: %w", envName, err)
+	}
+
+	return strings.TrimSpace(string(content)), true, nil
+}
+
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return redactedValue
+}
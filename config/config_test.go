@@ -0,0 +1,255 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlFile := filepath.Join(dir, "config.yaml")
+	os.WriteFile(yamlFile, []byte(`
+server:
+  address: 0.0.0.0:9000
+  read_timeout: 5s
+database:
+  host: db.internal
+  port: 6543
+`), 0600)
+
+	listFile := filepath.Join(dir, "list.yaml")
+	os.WriteFile(listFile, []byte(`
+masking:
+  unmasked_roles: [admin]
+`), 0600)
+
+	jsonFile := filepath.Join(dir, "config.json")
+	os.WriteFile(jsonFile, []byte(`{"bank": {"base_url": "https://bank.example.com"}}`), 0600)
+
+	passwordFile := filepath.Join(dir, "db_password")
+	os.WriteFile(passwordFile, []byte("from-file\n"), 0600)
+
+	type args struct {
+		args []string
+		env  map[string]string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		want     func() Config
+		wantArgs []string
+		wantErr  bool
+	}{
+		{
+			name: "defaults",
+			args: args{},
+			want: Default,
+		},
+		{
+			name: "yaml file override defaults",
+			args: args{
+				args: []string{"-config", yamlFile},
+			},
+			want: func() Config {
+				cfg := Default()
+				cfg.Server.Address = "0.0.0.0:9000"
+				cfg.Server.ReadTimeout = Duration(5 * time.Second)
+				cfg.Database.Host = "db.internal"
+				cfg.Database.Port = 6543
+				return cfg
+			},
+		},
+		{
+			name: "json file from env",
+			args: args{
+				env: map[string]string{"CONFIG_FILE": jsonFile},
+			},
+			want: func() Config {
+				cfg := Default()
+				cfg.Bank.BaseURL = "https://bank.example.com"
+				return cfg
+			},
+		},
+		{
+			name: "env override file and flag override env",
+			args: args{
+				args: []string{"--config=" + yamlFile, "-db-host", "flag-host", "migrate", "up"},
+				env: map[string]string{
+					"DB_HOST":             "env-host",
+					"DB_PORT":             "7000",
+					"SERVER_READ_TIMEOUT": "1m",
+				},
+			},
+			want: func() Config {
+				cfg := Default()
+				cfg.Server.Address = "0.0.0.0:9000"
+				cfg.Server.ReadTimeout = Duration(time.Minute)
+				cfg.Database.Host = "flag-host"
+				cfg.Database.Port = 7000
+				return cfg
+			},
+			wantArgs: []string{"migrate", "up"},
+		},
+		{
+			name: "secret from file",
+			args: args{
+				env: map[string]string{"DB_PASSWORD_FILE": passwordFile},
+			},
+			want: func() Config {
+				cfg := Default()
+				cfg.Database.Password = "from-file"
+				return cfg
+			},
+		},
+		{
+			name: "list from file",
+			args: args{
+				args: []string{"-config", listFile},
+			},
+			want: func() Config {
+				cfg := Default()
+				cfg.Masking.UnmaskedRoles = StringList{"admin"}
+				return cfg
+			},
+		},
+		{
+			name: "comma separated list from env",
+			args: args{
+				env: map[string]string{"MASKING_UNMASKED_ROLES": "admin, ops_viewer,"},
+			},
+			want: func() Config {
+				cfg := Default()
+				cfg.Masking.UnmaskedRoles = StringList{"admin", "ops_viewer"}
+				return cfg
+			},
+		},
+		{
+			name: "invalid env value",
+			args: args{
+				env: map[string]string{"DB_PORT": "not a number"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid port range",
+			args: args{
+				args: []string{"-db-port", "70000"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing required field",
+			args: args{
+				args: []string{"-db-host", ""},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid bootstrap key format",
+			args: args{
+				env: map[string]string{"AUTH_BOOTSTRAP_KEY": "not-an-api-key-but-long-enough-to-pass"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "tls cert without key",
+			args: args{
+				args: []string{"-server-tls-cert-file", "cert.pem"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "grpc tls key without cert",
+			args: args{
+				args: []string{"-grpc-tls-key-file", "key.pem"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "legacy routes sunset before deprecation",
+			args: args{
+				args: []string{"-api-legacy-sunset", "2026-01-01T00:00:00Z"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "verification token secret too short",
+			args: args{
+				args: []string{"-verification-token-secret", "short"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "postgres verification cache without postgres",
+			args: args{
+				args: []string{"-verification-cache", "postgres", "-db-driver", "memory"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative beneficiary max age",
+			args: args{
+				args: []string{"-verification-beneficiary-max-age", "-1h"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "screening name match threshold above 100",
+			args: args{
+				args: []string{"-screening-name-match-threshold", "101"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "file event publisher without path",
+			args: args{
+				args: []string{"-events-publisher", "file"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "config file not found",
+			args: args{
+				args: []string{"-config", filepath.Join(dir, "missing.yaml")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.args.env {
+				t.Setenv(key, value)
+			}
+
+			got, gotArgs, err := Load(tt.args.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want(), got)
+			assert.ElementsMatch(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestConfig_String(t *testing.T) {
+	cfg := Default()
+	cfg.Database.Password = "super-secret"
+	cfg.Auth.BootstrapKey = "brk_abcdef.bootstrap-secret"
+	cfg.Verification.TokenSecret = "verification-token-secret-32-chars"
+
+	got := cfg.String()
+
+	assert.NotContains(t, got, "super-secret")
+	assert.NotContains(t, got, "bootstrap-secret")
+	assert.NotContains(t, got, "verification-token-secret")
+	assert.Contains(t, got, `"password": "******"`)
+	assert.Contains(t, got, `"read_timeout": "15s"`)
+}
@@ -1,10 +1,80 @@
 package config
 
-// harcoded
+import (
+	"errors"
+	"flag"
+)
+
 const (
-	DB_HOST     = "localhost"
-	DB_PORT     = 5432
-	DB_USER     = "test"
-	DB_PASSWORD = "test"
-	DB_DATABASE = "test"
+	DatabaseDriverPostgres = "postgres"
+	// keep all data in memory, for local development without postgres. Data is lost when the application stopped
+	DatabaseDriverMemory = "memory"
 )
+
+type DatabaseConfig struct {
+	Driver   string `json:"driver" yaml:"driver"`
+	Host     string `json:"host" yaml:"host"`
+	Port     int    `json:"port" yaml:"port"`
+	User     string `json:"user" yaml:"user"`
+	Password string `json:"password" yaml:"password"`
+	Database string `json:"database" yaml:"database"`
+
+	// apply pending schema migrations when the application start
+	AutoMigrate bool `json:"auto_migrate" yaml:"auto_migrate"`
+}
+
+func defaultDatabaseConfig() DatabaseConfig {
+	return DatabaseConfig{
+		Driver:   DatabaseDriverPostgres,
+		Host:     "localhost",
+		Port:     5432,
+		User:     "test",
+		Password: "test",
+		Database: "test",
+	}
+}
+
+func (cfg *DatabaseConfig) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&cfg.Driver, "db-driver", cfg.Driver, "database driver, postgres or memory")
+	fs.StringVar(&cfg.Host, "db-host", cfg.Host, "database host")
+	fs.IntVar(&cfg.Port, "db-port", cfg.Port, "database port")
+	fs.StringVar(&cfg.User, "db-user", cfg.User, "database user")
+	fs.StringVar(&cfg.Password, "db-password", cfg.Password, "database password")
+	fs.StringVar(&cfg.Database, "db-database", cfg.Database, "database name")
+	fs.BoolVar(&cfg.AutoMigrate, "db-auto-migrate", cfg.AutoMigrate, "apply pending schema migrations on startup")
+}
+
+func (cfg DatabaseConfig) validate() []error {
+	var errs []error
+
+	if cfg.Driver == DatabaseDriverMemory {
+		return nil
+	}
+
+	if cfg.Driver != DatabaseDriverPostgres {
+		errs = append(errs, errors.New("database.driver must be postgres or memory"))
+	}
+
+	if cfg.Host == "" {
+		errs = append(errs, errors.New("database.host is required"))
+	}
+
+	if cfg.Port < 1 || cfg.Port > 65535 {
+		errs = append(errs, errors.New("database.port must be between 1 and 65535"))
+	}
+
+	if cfg.User == "" {
+		errs = append(errs, errors.New("database.user is required"))
+	}
+
+	if cfg.Database == "" {
+		errs = append(errs, errors.New("database.database is required"))
+	}
+
+	return errs
+}
+
+func (cfg DatabaseConfig) redacted() DatabaseConfig {
+	cfg.Password = redact(cfg.Password)
+	return cfg
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"time"
+)
+
+type EncryptionConfig struct {
+	// json key file holding the master keys, recipient data is stored unencrypted when empty
+	KeyFile string `json:"key_file" yaml:"key_file"`
+
+	// how often rows encrypted with an old key are re-encrypted with the current key
+	ReEncryptInterval  Duration `json:"reencrypt_interval" yaml:"reencrypt_interval"`
+	ReEncryptBatchSize int      `json:"reencrypt_batch_size" yaml:"reencrypt_batch_size"`
+}
+
+func defaultEncryptionConfig() EncryptionConfig {
+	return EncryptionConfig{
+		ReEncryptInterval:  Duration(10 * time.Minute),
+		ReEncryptBatchSize: 100,
+	}
+}
+
+func (cfg *EncryptionConfig) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&cfg.KeyFile, "encryption-key-file", cfg.KeyFile, "json key file for recipient data encryption, disabled when empty")
+	fs.Var(&cfg.ReEncryptInterval, "encryption-reencrypt-interval", "interval of re-encrypting data with the current key")
+	fs.IntVar(&cfg.ReEncryptBatchSize, "encryption-reencrypt-batch-size", cfg.ReEncryptBatchSize, "number of rows re-encrypted per transaction")
+}
+
+func (cfg EncryptionConfig) validate() []error {
+	var errs []error
+
+	if cfg.ReEncryptInterval <= 0 {
+		errs = append(errs, errors.New("encryption.reencrypt_interval must be greater than 0"))
+	}
+
+	if cfg.ReEncryptBatchSize < 1 || cfg.ReEncryptBatchSize > 10000 {
+		errs = append(errs, errors.New("encryption.reencrypt_batch_size must be between 1 and 10000"))
+	}
+
+	return errs
+}
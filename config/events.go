@@ -0,0 +1,96 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"time"
+)
+
+const (
+	// domain events stay in the outbox, nothing is published
+	EventPublisherNone = "none"
+	// append every event as a json line to FilePath
+	EventPublisherFile = "file"
+	// post every event to HttpUrl, for a broker http bridge
+	EventPublisherHttp = "http"
+)
+
+type EventsConfig struct {
+	Publisher string `json:"publisher" yaml:"publisher"`
+	FilePath  string `json:"file_path" yaml:"file_path"`
+	HttpUrl   string `json:"http_url" yaml:"http_url"`
+	// timeout of a single request to HttpUrl
+	RequestTimeout Duration `json:"request_timeout" yaml:"request_timeout"`
+	// how often recorded events are published
+	PublishInterval  Duration `json:"publish_interval" yaml:"publish_interval"`
+	PublishBatchSize int      `json:"publish_batch_size" yaml:"publish_batch_size"`
+	// the delay between attempts of a failing event double from RetryBaseDelay up to RetryMaxDelay
+	RetryBaseDelay Duration `json:"retry_base_delay" yaml:"retry_base_delay"`
+	RetryMaxDelay  Duration `json:"retry_max_delay" yaml:"retry_max_delay"`
+	// published events are deleted from the outbox after Retention
+	Retention Duration `json:"retention" yaml:"retention"`
+}
+
+func defaultEventsConfig() EventsConfig {
+	return EventsConfig{
+		Publisher:        EventPublisherNone,
+		RequestTimeout:   Duration(10 * time.Second),
+		PublishInterval:  Duration(time.Second),
+		PublishBatchSize: 100,
+		RetryBaseDelay:   Duration(5 * time.Second),
+		RetryMaxDelay:    Duration(10 * time.Minute),
+		Retention:        Duration(7 * 24 * time.Hour),
+	}
+}
+
+func (cfg *EventsConfig) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&cfg.Publisher, "events-publisher", cfg.Publisher, "domain event publisher, none, file or http")
+	fs.StringVar(&cfg.FilePath, "events-file-path", cfg.FilePath, "file the domain events are appended to")
+	fs.StringVar(&cfg.HttpUrl, "events-http-url", cfg.HttpUrl, "url the domain events are posted to")
+	fs.Var(&cfg.RequestTimeout, "events-request-timeout", "timeout of a domain event request")
+	fs.Var(&cfg.PublishInterval, "events-publish-interval", "interval of publishing recorded domain events")
+	fs.IntVar(&cfg.PublishBatchSize, "events-publish-batch-size", cfg.PublishBatchSize, "number of domain events claimed per batch")
+	fs.Var(&cfg.RetryBaseDelay, "events-retry-base-delay", "delay before the first domain event retry, doubled on every retry")
+	fs.Var(&cfg.RetryMaxDelay, "events-retry-max-delay", "maximum delay between domain event retries")
+	fs.Var(&cfg.Retention, "events-retention", "how long published domain events are kept in the outbox")
+}
+
+func (cfg EventsConfig) validate() []error {
+	var errs []error
+
+	switch cfg.Publisher {
+	case EventPublisherNone:
+	case EventPublisherFile:
+		if cfg.FilePath == "" {
+			errs = append(errs, errors.New("events.file_path is required when events.publisher is file"))
+		}
+	case EventPublisherHttp:
+		if cfg.HttpUrl == "" {
+			errs = append(errs, errors.New("events.http_url is required when events.publisher is http"))
+		}
+	default:
+		errs = append(errs, errors.New("events.publisher must be none, file or http"))
+	}
+
+	if cfg.RequestTimeout <= 0 {
+		errs = append(errs, errors.New("events.request_timeout must be greater than 0"))
+	}
+
+	if cfg.PublishInterval <= 0 {
+		errs = append(errs, errors.New("events.publish_interval must be greater than 0"))
+	}
+
+	if cfg.PublishBatchSize < 1 || cfg.PublishBatchSize > 1000 {
+		errs = append(errs, errors.New("events.publish_batch_size must be between 1 and 1000"))
+	}
+
+	if cfg.RetryBaseDelay <= 0 || cfg.RetryMaxDelay < cfg.RetryBaseDelay {
+		errs = append(errs, errors.New("events.retry_base_delay must be greater than 0 and not greater than events.retry_max_delay"))
+	}
+
+	if cfg.Retention <= 0 {
+		errs = append(errs, errors.New("events.retention must be greater than 0"))
+	}
+
+	return errs
+}
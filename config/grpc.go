@@ -0,0 +1,37 @@
+package config
+
+import (
+	"errors"
+	"flag"
+)
+
+type GrpcConfig struct {
+	// leave empty to disable the grpc api
+	Address string `json:"address" yaml:"address"`
+
+	// leave both empty to serve without tls
+	TLSCertFile string `json:"tls_cert_file" yaml:"tls_cert_file"`
+	TLSKeyFile  string `json:"tls_key_file" yaml:"tls_key_file"`
+}
+
+func defaultGrpcConfig() GrpcConfig {
+	return GrpcConfig{
+		Address: "127.0.0.1:9090",
+	}
+}
+
+func (cfg *GrpcConfig) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&cfg.Address, "grpc-address", cfg.Address, "grpc server listen address, grpc api is disabled when empty")
+	fs.StringVar(&cfg.TLSCertFile, "grpc-tls-cert-file", cfg.TLSCertFile, "grpc tls certificate file, serve without tls when empty")
+	fs.StringVar(&cfg.TLSKeyFile, "grpc-tls-key-file", cfg.TLSKeyFile, "grpc tls private key file, serve without tls when empty")
+}
+
+func (cfg GrpcConfig) validate() []error {
+	var errs []error
+
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		errs = append(errs, errors.New("grpc.tls_cert_file and grpc.tls_key_file must be set together"))
+	}
+
+	return errs
+}
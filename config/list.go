@@ -0,0 +1,23 @@
+package config
+
+import "strings"
+
+// StringList is list of string that can be set from comma separated env or flag value, for example "admin,ops"
+type StringList []string
+
+func (l StringList) String() string {
+	return strings.Join(l, ",")
+}
+
+func (l *StringList) Set(value string) error {
+	*l = StringList{}
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			*l = append(*l, item)
+		}
+	}
+
+	return nil
+}
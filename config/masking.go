@@ -0,0 +1,18 @@
+package config
+
+import "flag"
+
+type MaskingConfig struct {
+	// roles that can see full recipient name and account number in api response, everyone else get masked value
+	UnmaskedRoles StringList `json:"unmasked_roles" yaml:"unmasked_roles"`
+}
+
+func defaultMaskingConfig() MaskingConfig {
+	return MaskingConfig{
+		UnmaskedRoles: StringList{},
+	}
+}
+
+func (cfg *MaskingConfig) registerFlags(fs *flag.FlagSet) {
+	fs.Var(&cfg.UnmaskedRoles, "masking-unmasked-roles", "comma separated roles allowed to see unmasked recipient data")
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"time"
+)
+
+type ScreeningConfig struct {
+	// recipient name scoring at least NameMatchThreshold against a name of the sanctions lists or the internal
+	// blocklist is held for compliance review, from 1 to 100
+	NameMatchThreshold int `json:"name_match_threshold" yaml:"name_match_threshold"`
+	// how often the screening entries are read again from the database, so an instance sees the lists changed
+	// through another instance
+	ReloadInterval Duration `json:"reload_interval" yaml:"reload_interval"`
+}
+
+func defaultScreeningConfig() ScreeningConfig {
+	return ScreeningConfig{
+		NameMatchThreshold: 80,
+		ReloadInterval:     Duration(time.Minute),
+	}
+}
+
+func (cfg *ScreeningConfig) registerFlags(fs *flag.FlagSet) {
+	fs.IntVar(&cfg.NameMatchThreshold, "screening-name-match-threshold", cfg.NameMatchThreshold, "name match score from which a recipient is held for compliance review")
+	fs.Var(&cfg.ReloadInterval, "screening-reload-interval", "interval of reading the sanctions lists and the internal blocklist again")
+}
+
+func (cfg ScreeningConfig) validate() []error {
+	var errs []error
+
+	if cfg.NameMatchThreshold < 1 || cfg.NameMatchThreshold > 100 {
+		errs = append(errs, errors.New("screening.name_match_threshold must be between 1 and 100"))
+	}
+
+	if cfg.ReloadInterval <= 0 {
+		errs = append(errs, errors.New("screening.reload_interval must be greater than 0"))
+	}
+
+	return errs
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"time"
+)
+
+type ServerConfig struct {
+	Address        string   `json:"address" yaml:"address"`
+	ReadTimeout    Duration `json:"read_timeout" yaml:"read_timeout"`
+	WriteTimeout   Duration `json:"write_timeout" yaml:"write_timeout"`
+	IdleTimeout    Duration `json:"idle_timeout" yaml:"idle_timeout"`
+	MaxHeaderBytes int      `json:"max_header_bytes" yaml:"max_header_bytes"`
+
+	// leave both empty to serve plain http
+	TLSCertFile string `json:"tls_cert_file" yaml:"tls_cert_file"`
+	TLSKeyFile  string `json:"tls_key_file" yaml:"tls_key_file"`
+
+	// reject request that does not match the OpenAPI document before it reach the controllers
+	ValidateRequests bool `json:"validate_requests" yaml:"validate_requests"`
+
+	// maximum time to wait for in-flight request and background worker when shutting down
+	ShutdownTimeout Duration `json:"shutdown_timeout" yaml:"shutdown_timeout"`
+}
+
+func defaultServerConfig() ServerConfig {
+	return ServerConfig{
+		Address:         "127.0.0.1:8080",
+		ReadTimeout:     Duration(15 * time.Second),
+		WriteTimeout:    Duration(30 * time.Second),
+		IdleTimeout:     Duration(60 * time.Second),
+		MaxHeaderBytes:  1 << 20,
+		ShutdownTimeout: Duration(30 * time.Second),
+	}
+}
+
+func (cfg *ServerConfig) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&cfg.Address, "server-address", cfg.Address, "http server listen address")
+	fs.Var(&cfg.ReadTimeout, "server-read-timeout", "http server read timeout")
+	fs.Var(&cfg.WriteTimeout, "server-write-timeout", "http server write timeout")
+	fs.Var(&cfg.IdleTimeout, "server-idle-timeout", "http server keep-alive idle timeout")
+	fs.IntVar(&cfg.MaxHeaderBytes, "server-max-header-bytes", cfg.MaxHeaderBytes, "maximum size of request headers")
+	fs.StringVar(&cfg.TLSCertFile, "server-tls-cert-file", cfg.TLSCertFile, "tls certificate file, serve plain http when empty")
+	fs.StringVar(&cfg.TLSKeyFile, "server-tls-key-file", cfg.TLSKeyFile, "tls private key file, serve plain http when empty")
+	fs.BoolVar(&cfg.ValidateRequests, "server-validate-requests", cfg.ValidateRequests, "validate requests against the OpenAPI document")
+	fs.Var(&cfg.ShutdownTimeout, "server-shutdown-timeout", "maximum time to wait in-flight work when shutting down")
+}
+
+func (cfg ServerConfig) validate() []error {
+	var errs []error
+
+	if cfg.Address == "" {
+		errs = append(errs, errors.New("server.address is required"))
+	}
+
+	if cfg.ReadTimeout <= 0 || cfg.WriteTimeout <= 0 || cfg.IdleTimeout <= 0 {
+		errs = append(errs, errors.New("server read, write and idle timeout must be greater than 0"))
+	}
+
+	if cfg.MaxHeaderBytes < 1024 {
+		errs = append(errs, errors.New("server.max_header_bytes must be at least 1024"))
+	}
+
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		errs = append(errs, errors.New("server.tls_cert_file and server.tls_key_file must be set together"))
+	}
+
+	if cfg.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout must be greater than 0"))
+	}
+
+	return errs
+}
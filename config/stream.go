@@ -0,0 +1,40 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"time"
+)
+
+type StreamConfig struct {
+	// comment sent on idle stream so proxies and load balancers do not close the connection
+	HeartbeatInterval Duration `json:"heartbeat_interval" yaml:"heartbeat_interval"`
+	// subscribers read new events at this interval when a notification from the database is missed
+	PollInterval Duration `json:"poll_interval" yaml:"poll_interval"`
+}
+
+func defaultStreamConfig() StreamConfig {
+	return StreamConfig{
+		HeartbeatInterval: Duration(15 * time.Second),
+		PollInterval:      Duration(5 * time.Second),
+	}
+}
+
+func (cfg *StreamConfig) registerFlags(fs *flag.FlagSet) {
+	fs.Var(&cfg.HeartbeatInterval, "stream-heartbeat-interval", "interval of heartbeat sent on idle disbursement stream")
+	fs.Var(&cfg.PollInterval, "stream-poll-interval", "interval of reading new disbursement status events without notification")
+}
+
+func (cfg StreamConfig) validate() []error {
+	var errs []error
+
+	if cfg.HeartbeatInterval <= 0 {
+		errs = append(errs, errors.New("stream.heartbeat_interval must be greater than 0"))
+	}
+
+	if cfg.PollInterval <= 0 {
+		errs = append(errs, errors.New("stream.poll_interval must be greater than 0"))
+	}
+
+	return errs
+}
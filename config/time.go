@@ -0,0 +1,41 @@
+package config
+
+import "time"
+
+// Time is time.Time that can be read from RFC 3339 text such as "2026-11-01T00:00:00Z" in config file, env and flag
+type Time time.Time
+
+func (t Time) Time() time.Time {
+	return time.Time(t)
+}
+
+func (t Time) String() string {
+	if time.Time(t).IsZero() {
+		return ""
+	}
+
+	return time.Time(t).Format(time.RFC3339)
+}
+
+func (t *Time) Set(value string) error {
+	if value == "" {
+		*t = Time{}
+		return nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return err
+	}
+
+	*t = Time(parsed)
+	return nil
+}
+
+func (t Time) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *Time) UnmarshalText(text []byte) error {
+	return t.Set(string(text))
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"time"
+)
+
+const (
+	// every verification is sent to the bank
+	VerificationCacheNone = "none"
+	// results are cached in the process, up to CacheSize accounts
+	VerificationCacheLRU = "lru"
+	// results are cached in the database and shared by every instance
+	VerificationCachePostgres = "postgres"
+)
+
+type VerificationConfig struct {
+	// key signing the verification token returned by disbursement verify. A random key is generated on startup when
+	// empty, set it when running more than one instance so the token is accepted by every instance
+	TokenSecret string `json:"token_secret" yaml:"token_secret"`
+	// how long a verified recipient can be disbursed without asking the bank again
+	TokenTTL Duration `json:"token_ttl" yaml:"token_ttl"`
+	// cache of the bank account verification results, by bank code and account number
+	Cache     string `json:"cache" yaml:"cache"`
+	CacheSize int    `json:"cache_size" yaml:"cache_size"`
+	// how long a verified account is cached
+	CachePositiveTTL Duration `json:"cache_positive_ttl" yaml:"cache_positive_ttl"`
+	// how long a not found or blocked account is cached, 0 to not cache them
+	CacheNegativeTTL Duration `json:"cache_negative_ttl" yaml:"cache_negative_ttl"`
+	// saved beneficiary verified longer ago is verified again before disbursed to, 0 to verify it every time
+	BeneficiaryMaxAge Duration `json:"beneficiary_max_age" yaml:"beneficiary_max_age"`
+}
+
+func defaultVerificationConfig() VerificationConfig {
+	return VerificationConfig{
+		TokenTTL:          Duration(5 * time.Minute),
+		Cache:             VerificationCacheNone,
+		CacheSize:         10000,
+		CachePositiveTTL:  Duration(time.Hour),
+		CacheNegativeTTL:  Duration(5 * time.Minute),
+		BeneficiaryMaxAge: Duration(24 * time.Hour),
+	}
+}
+
+func (cfg *VerificationConfig) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&cfg.TokenSecret, "verification-token-secret", cfg.TokenSecret, "key signing the verification tokens, random on startup when empty")
+	fs.Var(&cfg.TokenTTL, "verification-token-ttl", "lifetime of a verification token")
+	fs.StringVar(&cfg.Cache, "verification-cache", cfg.Cache, "bank account verification cache, none, lru or postgres")
+	fs.IntVar(&cfg.CacheSize, "verification-cache-size", cfg.CacheSize, "number of accounts kept by the lru verification cache")
+	fs.Var(&cfg.CachePositiveTTL, "verification-cache-positive-ttl", "how long a verified account is cached")
+	fs.Var(&cfg.CacheNegativeTTL, "verification-cache-negative-ttl", "how long a not found or blocked account is cached, 0 to not cache them")
+	fs.Var(&cfg.BeneficiaryMaxAge, "verification-beneficiary-max-age", "how long a saved beneficiary is disbursed to without verifying it again, 0 to verify every time")
+}
+
+func (cfg VerificationConfig) validate() []error {
+	var errs []error
+
+	if cfg.TokenSecret != "" && len(cfg.TokenSecret) < 32 {
+		errs = append(errs, errors.New("verification.token_secret must be at least 32 characters"))
+	}
+
+	if cfg.TokenTTL <= 0 || cfg.TokenTTL.Duration() > time.Hour {
+		errs = append(errs, errors.New("verification.token_ttl must be greater than 0 and at most 1h"))
+	}
+
+	switch cfg.Cache {
+	case VerificationCacheNone, VerificationCachePostgres:
+	case VerificationCacheLRU:
+		if cfg.CacheSize <= 0 {
+			errs = append(errs, errors.New("verification.cache_size must be greater than 0 when verification.cache is lru"))
+		}
+	default:
+		errs = append(errs, errors.New("verification.cache must be none, lru or postgres"))
+	}
+
+	if cfg.CachePositiveTTL <= 0 {
+		errs = append(errs, errors.New("verification.cache_positive_ttl must be greater than 0"))
+	}
+
+	if cfg.CacheNegativeTTL < 0 {
+		errs = append(errs, errors.New("verification.cache_negative_ttl must not be negative"))
+	}
+
+	if cfg.BeneficiaryMaxAge < 0 {
+		errs = append(errs, errors.New("verification.beneficiary_max_age must not be negative"))
+	}
+
+	return errs
+}
+
+func (cfg VerificationConfig) redacted() VerificationConfig {
+	cfg.TokenSecret = redact(cfg.TokenSecret)
+	return cfg
+}
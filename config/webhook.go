@@ -0,0 +1,65 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"time"
+)
+
+type WebhookConfig struct {
+	// how often pending deliveries are sent to the merchant endpoints
+	DeliveryInterval  Duration `json:"delivery_interval" yaml:"delivery_interval"`
+	DeliveryBatchSize int      `json:"delivery_batch_size" yaml:"delivery_batch_size"`
+	// timeout of a single request to the merchant endpoint
+	RequestTimeout Duration `json:"request_timeout" yaml:"request_timeout"`
+	// delivery is given up after MaxAttempts, the delay between attempts double from RetryBaseDelay up to RetryMaxDelay
+	MaxAttempts    int      `json:"max_attempts" yaml:"max_attempts"`
+	RetryBaseDelay Duration `json:"retry_base_delay" yaml:"retry_base_delay"`
+	RetryMaxDelay  Duration `json:"retry_max_delay" yaml:"retry_max_delay"`
+}
+
+func defaultWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		DeliveryInterval:  Duration(5 * time.Second),
+		DeliveryBatchSize: 50,
+		RequestTimeout:    Duration(10 * time.Second),
+		MaxAttempts:       10,
+		RetryBaseDelay:    Duration(30 * time.Second),
+		RetryMaxDelay:     Duration(6 * time.Hour),
+	}
+}
+
+func (cfg *WebhookConfig) registerFlags(fs *flag.FlagSet) {
+	fs.Var(&cfg.DeliveryInterval, "webhook-delivery-interval", "interval of sending pending webhook deliveries")
+	fs.IntVar(&cfg.DeliveryBatchSize, "webhook-delivery-batch-size", cfg.DeliveryBatchSize, "number of webhook deliveries sent per interval")
+	fs.Var(&cfg.RequestTimeout, "webhook-request-timeout", "timeout of a webhook request to the merchant endpoint")
+	fs.IntVar(&cfg.MaxAttempts, "webhook-max-attempts", cfg.MaxAttempts, "number of attempts before a webhook delivery is given up")
+	fs.Var(&cfg.RetryBaseDelay, "webhook-retry-base-delay", "delay before the first webhook retry, doubled on every retry")
+	fs.Var(&cfg.RetryMaxDelay, "webhook-retry-max-delay", "maximum delay between webhook retries")
+}
+
+func (cfg WebhookConfig) validate() []error {
+	var errs []error
+
+	if cfg.DeliveryInterval <= 0 {
+		errs = append(errs, errors.New("webhook.delivery_interval must be greater than 0"))
+	}
+
+	if cfg.DeliveryBatchSize < 1 || cfg.DeliveryBatchSize > 1000 {
+		errs = append(errs, errors.New("webhook.delivery_batch_size must be between 1 and 1000"))
+	}
+
+	if cfg.RequestTimeout <= 0 {
+		errs = append(errs, errors.New("webhook.request_timeout must be greater than 0"))
+	}
+
+	if cfg.MaxAttempts < 1 {
+		errs = append(errs, errors.New("webhook.max_attempts must be greater than 0"))
+	}
+
+	if cfg.RetryBaseDelay <= 0 || cfg.RetryMaxDelay < cfg.RetryBaseDelay {
+		errs = append(errs, errors.New("webhook.retry_base_delay must be greater than 0 and not greater than webhook.retry_max_delay"))
+	}
+
+	return errs
+}
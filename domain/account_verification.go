@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// AccountVerification is the bank answer to the inquiry of an account, kept until ExpiresAt so the same account is
+// not asked again
+type AccountVerification struct {
+	BankCode          string
+	AccountNumber     string
+	AccountHolderName string
+	// one of the api.VerifyAccountStatus values
+	AccountStatus string
+	ExpiresAt     time.Time
+}
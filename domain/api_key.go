@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// ApiKey is a credential issued to a client. Only the hash of the key is stored, the key itself is shown once when issued
+type ApiKey struct {
+	Id       string
+	ClientId string
+	// MerchantId is required for client key, the key can only access the merchant data
+	MerchantId string
+	Name       string
+	Role       Role
+	// Prefix is the public part of the key used to find it without knowing the secret
+	Prefix     string
+	Hash       string
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// IsActive return true when the key is not revoked and not expired at the given time
+func (key ApiKey) IsActive(now time.Time) bool {
+	if key.RevokedAt != nil {
+		return false
+	}
+
+	return key.ExpiresAt == nil || now.Before(*key.ExpiresAt)
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+type AuditOutcome string
+
+const (
+	AuditOutcomeAllowed AuditOutcome = "allowed"
+	AuditOutcomeDenied  AuditOutcome = "denied"
+)
+
+// actions done by operator, denied attempts use the permission as action
+const (
+	AuditActionForceStatus        = "disbursement.force_status"
+	AuditActionAttachBankEvidence = "disbursement.attach_bank_evidence"
+	AuditActionRecheckBankStatus  = "disbursement.recheck_bank_status"
+	AuditActionResolveCallback    = "callback_review.resolve"
+	AuditActionReplayWebhook      = "webhook_delivery.replay"
+	AuditActionEvictVerification  = "verification_cache.evict"
+	AuditActionResolveScreening   = "screening.resolve"
+	AuditActionImportScreening    = "screening_list.import"
+	AuditActionAddBlocklist       = "blocklist.add"
+	AuditActionDeleteBlocklist    = "blocklist.delete"
+)
+
+// AuditLog record an action done by a caller, the actor fields are copied from the caller at the time of the action
+type AuditLog struct {
+	Id         string
+	ActorId    string
+	ActorRole  Role
+	ApiKeyId   string
+	MerchantId string
+	// Action is the permission or operation attempted
+	Action string
+	// ResourceId is the id or route of what the action is done to
+	ResourceId string
+	Outcome    AuditOutcome
+	Reason     string
+	CreatedAt  time.Time
+}
+
+type AuditLogFilter struct {
+	ActorId string
+	Action  string
+	Outcome AuditOutcome
+	// newest logs are returned first, default limit is used when 0
+	Limit int
+}
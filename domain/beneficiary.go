@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+type BeneficiaryVerificationStatus string
+
+const (
+	BeneficiaryVerified        BeneficiaryVerificationStatus = "VERIFIED"
+	BeneficiaryAccountNotFound BeneficiaryVerificationStatus = "ACCOUNT_NOT_FOUND"
+	BeneficiaryAccountBlocked  BeneficiaryVerificationStatus = "ACCOUNT_BLOCKED"
+)
+
+// Beneficiary is a recipient saved by the merchant, disbursed to by id instead of sending the recipient again
+type Beneficiary struct {
+	Id            string
+	MerchantId    string
+	Name          string
+	AccountNumber string
+	BankCode      string
+	// last time the account was asked to the bank
+	Verification BeneficiaryVerification
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type BeneficiaryVerification struct {
+	Status BeneficiaryVerificationStatus
+	// empty unless verified
+	AccountHolderName string
+	// similarity of the beneficiary name to AccountHolderName from 0 to 100
+	NameMatchScore int
+	VerifiedAt     time.Time
+}
+
+// VerifiedSince return true when the account was verified successfully at or after since
+func (beneficiary Beneficiary) VerifiedSince(since time.Time) bool {
+	return beneficiary.Verification.Status == BeneficiaryVerified && !beneficiary.Verification.VerifiedAt.Before(since)
+}
+
+// Recipient return the disbursement fields of the beneficiary
+func (beneficiary Beneficiary) Recipient(disbursement Disbursement) Disbursement {
+	disbursement.RecipientName = beneficiary.Name
+	disbursement.RecipientAccountNumber = beneficiary.AccountNumber
+	disbursement.RecipientBankCode = beneficiary.BankCode
+
+	return disbursement
+}
+
+type BeneficiaryFilter struct {
+	BankCode string
+	// newest beneficiaries are returned first, default limit is used when 0
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+type CallbackReviewReason string
+
+const (
+	// the callback transaction id does not match any disbursement
+	CallbackReviewReasonDisbursementNotFound CallbackReviewReason = "DISBURSEMENT_NOT_FOUND"
+	// the disbursement is not pending anymore so the callback cannot be applied automatically
+	CallbackReviewReasonInvalidTransition CallbackReviewReason = "INVALID_TRANSITION"
+)
+
+type CallbackReviewStatus string
+
+const (
+	CallbackReviewStatusOpen CallbackReviewStatus = "OPEN"
+	// the callback status was applied to the disbursement by an operator
+	CallbackReviewStatusApplied CallbackReviewStatus = "APPLIED"
+	// the callback was ignored by an operator
+	CallbackReviewStatusDiscarded CallbackReviewStatus = "DISCARDED"
+)
+
+type CallbackReviewResolution string
+
+const (
+	CallbackReviewResolutionApply   CallbackReviewResolution = "apply"
+	CallbackReviewResolutionDiscard CallbackReviewResolution = "discard"
+)
+
+// CallbackReview is a bank callback that cannot be processed automatically, parked until an operator resolve it
+type CallbackReview struct {
+	Id            string
+	TransactionId string
+	// empty when the disbursement is not found
+	DisbursementId string
+	Reason         CallbackReviewReason
+	// Detail explain the reason, for example the disbursement status when the callback received
+	Detail string
+	// Payload is the callback as received from the bank
+	Payload        []byte
+	Status         CallbackReviewStatus
+	AssigneeId     string
+	ResolvedBy     string
+	ResolutionNote string
+	ResolvedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (review CallbackReview) IsOpen() bool {
+	return review.Status == CallbackReviewStatusOpen
+}
+
+type CallbackReviewComment struct {
+	Id        string
+	ReviewId  string
+	AuthorId  string
+	Body      string
+	CreatedAt time.Time
+}
+
+type CallbackReviewFilter struct {
+	Status     CallbackReviewStatus
+	Reason     CallbackReviewReason
+	AssigneeId string
+	// oldest reviews are returned first, default limit is used when 0
+	Limit int
+}
+
+// CallbackReviewCount is the number of open reviews having the reason
+type CallbackReviewCount struct {
+	Reason     CallbackReviewReason
+	Open       int64
+	Unassigned int64
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "context"
+
+type Role string
+
+// Caller is the identity of whoever make the current request
+type Caller struct {
+	Id   string
+	Role Role
+	// ApiKeyId is the key used to authenticate the request
+	ApiKeyId string
+	// MerchantId is set when the caller act on behalf of a merchant, the caller can only access the merchant data
+	MerchantId string
+}
+
+type callerContextKey struct{}
+
+func ContextWithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+// CallerFromContext return the caller of the request, false when the request is not authenticated
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerContextKey{}).(Caller)
+	return caller, ok
+}
@@ -8,6 +8,8 @@ const (
 	DisbursementStatusCompleted DisbursementStatus = 2
 	DisbursementStatusFailed    DisbursementStatus = 3
 	DisbursementStatusRejected  DisbursementStatus = 4
+	// the recipient matched a screening list, the money is not transferred until compliance release it
+	DisbursementStatusOnHold DisbursementStatus = 5
 )
 
 const (
@@ -16,6 +18,7 @@ const (
 	DisbursementStatusCompletedStr = "COMPLETED"
 	DisbursementStatusFailedStr    = "FAILED"
 	DisbursementStatusRejectedStr  = "REJECTED"
+	DisbursementStatusOnHoldStr    = "ON_HOLD"
 )
 
 func (disb DisbursementStatus) ToString() string {
@@ -30,21 +33,58 @@ func (disb DisbursementStatus) ToString() string {
 		return DisbursementStatusFailedStr
 	case DisbursementStatusRejected:
 		return DisbursementStatusRejectedStr
+	case DisbursementStatusOnHold:
+		return DisbursementStatusOnHoldStr
 	default:
 		return DisbursementStatusUnknownStr
 	}
 }
 
+// DisbursementStatusFromString is the reverse of ToString, false when the string is not a known status
+func DisbursementStatusFromString(status string) (DisbursementStatus, bool) {
+	for _, disbursementStatus := range []DisbursementStatus{
+		DisbursementStatusPending,
+		DisbursementStatusCompleted,
+		DisbursementStatusFailed,
+		DisbursementStatusRejected,
+		DisbursementStatusOnHold,
+	} {
+		if disbursementStatus.ToString() == status {
+			return disbursementStatus, true
+		}
+	}
+
+	return DisbursementStatusUnknown, false
+}
+
 func (disb DisbursementStatus) ToInt() int {
 	return int(disb)
 }
 
 type Disbursement struct {
 	Id                     string
+	MerchantId             string // merchant owning the disbursement
 	RecipientName          string
 	RecipientAccountNumber string
 	RecipientBankCode      string
-	BankTransactionId      string // reference id to bank partner
+	BankTransactionId      string // reference id to bank partner, empty while the disbursement is on hold
 	Amount                 int64
 	Status                 DisbursementStatus // status of the disbursement
+	BankEvidenceReference  string             // bank document proving the transfer result, attached by operator
+	Version                int64              // incremented on every update, used to detect concurrent modification
+	// similarity of the recipient name to the account holder name reported by the bank from 0 to 100,
+	// empty decision when the name was not matched
+	NameMatchScore    int
+	NameMatchDecision NameMatchDecision
+}
+
+type DisbursementFilter struct {
+	// DisbursementStatusUnknown for every status
+	Status            DisbursementStatus
+	RecipientBankCode string
+	// empty for every decision
+	NameMatchDecision NameMatchDecision
+	// newest disbursements are returned first, default limit is used when 0
+	Limit  int
+	Offset int
 }
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// DisbursementStatusEvent is recorded when the status of a disbursement changed. Events are read in commit order,
+// not always in Id order, and a reader resume after the Id of the last event it has seen
+type DisbursementStatusEvent struct {
+	Id             int64
+	DisbursementId string
+	MerchantId     string
+	Status         DisbursementStatus
+	PreviousStatus DisbursementStatus
+	Version        int64 // version of the disbursement after the change
+	CreatedAt      time.Time
+}
+
+// DisbursementStatusEventFilter empty DisbursementId match every disbursement
+type DisbursementStatusEventFilter struct {
+	DisbursementId string
+	AfterId        int64
+	Limit          int
+}
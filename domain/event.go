@@ -0,0 +1,69 @@
+package domain
+
+import "time"
+
+type EventType string
+
+const (
+	EventTypeDisbursementCreated       EventType = "DisbursementCreated"
+	EventTypeDisbursementStatusChanged EventType = "DisbursementStatusChanged"
+)
+
+// Event is a domain event published to other services. Events of the same aggregate are published in the order
+// they were recorded
+type Event interface {
+	EventType() EventType
+	AggregateId() string
+}
+
+// DisbursementCreated is recorded when the disbursement is stored, before the transfer or on hold for compliance
+// review, so BankTransactionId is empty. A transfer refused by the bank is followed with DisbursementStatusChanged
+type DisbursementCreated struct {
+	DisbursementId    string    `json:"disbursement_id"`
+	MerchantId        string    `json:"merchant_id,omitempty"`
+	Amount            int64     `json:"amount"`
+	RecipientBankCode string    `json:"recipient_bank_code"`
+	BankTransactionId string    `json:"bank_transaction_id"`
+	Status            string    `json:"status"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func (event DisbursementCreated) EventType() EventType {
+	return EventTypeDisbursementCreated
+}
+
+func (event DisbursementCreated) AggregateId() string {
+	return event.DisbursementId
+}
+
+// DisbursementStatusChanged is recorded by every status change, from bank callback or operator
+type DisbursementStatusChanged struct {
+	DisbursementId string    `json:"disbursement_id"`
+	MerchantId     string    `json:"merchant_id,omitempty"`
+	Status         string    `json:"status"`
+	PreviousStatus string    `json:"previous_status"`
+	Version        int64     `json:"version"`
+	ChangedAt      time.Time `json:"changed_at"`
+}
+
+func (event DisbursementStatusChanged) EventType() EventType {
+	return EventTypeDisbursementStatusChanged
+}
+
+func (event DisbursementStatusChanged) AggregateId() string {
+	return event.DisbursementId
+}
+
+// OutboxEvent is a domain event recorded in the transaction of the change, waiting to be published by the relay
+type OutboxEvent struct {
+	Id          int64 // increasing in commit order for the same aggregate, consumers use it to ignore duplicates
+	Type        EventType
+	AggregateId string
+	Payload     []byte // the event as json
+	OccurredAt  time.Time
+	// failed publish is retried at NextAttemptAt
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	PublishedAt   *time.Time
+}
@@ -0,0 +1,13 @@
+package internal_error
+
+import "errors"
+
+var (
+	ErrUnauthorized = errors.New("error invalid or missing api key")
+	ErrForbidden    = errors.New("error caller is not allowed to access this resource")
+
+	ErrApiKeyNotFound    = errors.New("error api key not found")
+	ErrApiKeyInvalidRole = errors.New("error invalid api key role")
+	ErrApiKeyRevoked     = errors.New("error api key already revoked")
+	ErrApiKeyNoMerchant  = errors.New("error client api key requires a merchant")
+)
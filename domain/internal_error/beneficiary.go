@@ -0,0 +1,7 @@
+package internal_error
+
+import "errors"
+
+var (
+	ErrBeneficiaryNotFound = errors.New("error beneficiary not found")
+)
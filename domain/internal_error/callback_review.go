@@ -0,0 +1,10 @@
+package internal_error
+
+import "errors"
+
+var (
+	ErrCallbackReviewNotFound          = errors.New("error callback review not found")
+	ErrCallbackReviewResolved          = errors.New("error callback review is already resolved")
+	ErrCallbackReviewInvalidResolution = errors.New("error resolution should be apply or discard")
+	ErrCallbackReviewCommentRequired   = errors.New("error comment is required")
+)
@@ -6,6 +6,8 @@ var (
 	ErrVerifyDisbursement    = errors.New("error when trying verify disbursement")
 	ErrVerifyAccountNotFound = errors.New("error bank account not found")
 	ErrVerifyAccountBlocked  = errors.New("error bank account is blocked")
+	ErrRecipientNameMismatch = errors.New("error recipient name does not match the bank account holder name")
+	ErrAmountInvalid         = errors.New("error amount should be greater than 0")
 
 	ErrDisburseDisbursement = errors.New("error when try to disburse")
 	ErrDisburseBankError    = errors.New("temporary bank network error")
@@ -14,6 +16,4 @@ var (
 	ErrUpdateDisbursementStatus = errors.New("error when updated disbursement status")
 
 	ErrDisbursementNotFound = errors.New("error disbursement not found")
-
-	ErrDisbursementInvalidStatus = errors.New("error invalid disbursement status")
 )
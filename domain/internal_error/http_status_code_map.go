@@ -3,14 +3,49 @@ package internal_error
 import "net/http"
 
 var ErrorStatusCodeMap = map[string]int{
-	ErrInvalidRequest.Error():            http.StatusBadRequest,
-	ErrVerifyDisbursement.Error():        http.StatusInternalServerError,
-	ErrVerifyAccountNotFound.Error():     http.StatusOK,
-	ErrVerifyAccountBlocked.Error():      http.StatusOK,
-	ErrDisburseBankError.Error():         http.StatusInternalServerError,
-	ErrDisburseDisbursement.Error():      http.StatusInternalServerError,
-	ErrHandleBankCallback.Error():        http.StatusInternalServerError,
-	ErrDisbursementNotFound.Error():      http.StatusNotFound,
-	ErrDisbursementInvalidStatus.Error(): http.StatusNotFound,
-	ErrUpdateDisbursementStatus.Error():  http.StatusInternalServerError,
+	ErrInvalidRequest.Error():                  http.StatusBadRequest,
+	ErrVerifyDisbursement.Error():              http.StatusInternalServerError,
+	ErrVerifyAccountNotFound.Error():           http.StatusOK,
+	ErrVerifyAccountBlocked.Error():            http.StatusOK,
+	ErrRecipientNameMismatch.Error():           http.StatusOK,
+	ErrAmountInvalid.Error():                   http.StatusBadRequest,
+	ErrDisburseBankError.Error():               http.StatusInternalServerError,
+	ErrDisburseDisbursement.Error():            http.StatusInternalServerError,
+	ErrHandleBankCallback.Error():              http.StatusInternalServerError,
+	ErrDisbursementNotFound.Error():            http.StatusNotFound,
+	ErrUpdateDisbursementStatus.Error():        http.StatusInternalServerError,
+	ErrVersionConflict.Error():                 http.StatusConflict,
+	ErrUnauthorized.Error():                    http.StatusUnauthorized,
+	ErrForbidden.Error():                       http.StatusForbidden,
+	ErrApiKeyNotFound.Error():                  http.StatusNotFound,
+	ErrApiKeyInvalidRole.Error():               http.StatusBadRequest,
+	ErrApiKeyRevoked.Error():                   http.StatusConflict,
+	ErrApiKeyNoMerchant.Error():                http.StatusBadRequest,
+	ErrMerchantNotFound.Error():                http.StatusNotFound,
+	ErrMerchantRequired.Error():                http.StatusForbidden,
+	ErrBankCodeNotAllowed.Error():              http.StatusBadRequest,
+	ErrAmountExceedLimit.Error():               http.StatusBadRequest,
+	ErrMerchantDailyLimitExceeded.Error():      http.StatusBadRequest,
+	ErrOperationReasonRequired.Error():         http.StatusBadRequest,
+	ErrForceStatusInvalid.Error():              http.StatusBadRequest,
+	ErrBankEvidenceRequired.Error():            http.StatusBadRequest,
+	ErrBankStatusCheck.Error():                 http.StatusInternalServerError,
+	ErrCallbackReviewNotFound.Error():          http.StatusNotFound,
+	ErrCallbackReviewResolved.Error():          http.StatusConflict,
+	ErrCallbackReviewInvalidResolution.Error(): http.StatusBadRequest,
+	ErrCallbackReviewCommentRequired.Error():   http.StatusBadRequest,
+	ErrWebhookEndpointNotFound.Error():         http.StatusNotFound,
+	ErrWebhookEndpointInvalid.Error():          http.StatusBadRequest,
+	ErrWebhookDeliveryNotFound.Error():         http.StatusNotFound,
+	ErrVerificationCacheDisabled.Error():       http.StatusNotFound,
+	ErrEvictVerification.Error():               http.StatusInternalServerError,
+	ErrBeneficiaryNotFound.Error():             http.StatusNotFound,
+	ErrScreeningNotFound.Error():               http.StatusNotFound,
+	ErrScreeningResolved.Error():               http.StatusConflict,
+	ErrScreeningInvalidResolution.Error():      http.StatusBadRequest,
+	ErrScreeningListInvalid.Error():            http.StatusBadRequest,
+	ErrScreeningListReserved.Error():           http.StatusBadRequest,
+	ErrBlocklistEntryNotFound.Error():          http.StatusNotFound,
+	ErrBlocklistEntryInvalid.Error():           http.StatusBadRequest,
+	ErrDisbursementOnHold.Error():              http.StatusConflict,
 }
@@ -0,0 +1,14 @@
+package internal_error
+
+import "errors"
+
+var (
+	ErrMerchantNotFound = errors.New("error merchant not found")
+	ErrMerchantRequired = errors.New("error caller does not belong to a merchant")
+
+	ErrMerchantInvalidSettings = errors.New("error invalid merchant settings")
+
+	ErrBankCodeNotAllowed         = errors.New("error bank code is not allowed for the merchant")
+	ErrAmountExceedLimit          = errors.New("error amount exceed the merchant limit")
+	ErrMerchantDailyLimitExceeded = errors.New("error merchant daily disbursement limit exceeded")
+)
@@ -0,0 +1,10 @@
+package internal_error
+
+import "errors"
+
+var (
+	ErrOperationReasonRequired = errors.New("error reason is required for manual intervention")
+	ErrForceStatusInvalid      = errors.New("error disbursement cannot be forced to the status")
+	ErrBankEvidenceRequired    = errors.New("error bank evidence reference is required")
+	ErrBankStatusCheck         = errors.New("error when checking transfer status to bank")
+)
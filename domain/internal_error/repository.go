@@ -3,5 +3,6 @@ package internal_error
 import "errors"
 
 var (
-	ErrNoRowsAffected = errors.New("error expected there row be affected but got none")
+	ErrNoRowsAffected  = errors.New("error expected there row be affected but got none")
+	ErrVersionConflict = errors.New("error record has been modified by another process")
 )
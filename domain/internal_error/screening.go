@@ -0,0 +1,14 @@
+package internal_error
+
+import "errors"
+
+var (
+	ErrScreeningNotFound          = errors.New("error screening not found")
+	ErrScreeningResolved          = errors.New("error screening is already resolved")
+	ErrScreeningInvalidResolution = errors.New("error resolution should be release or reject")
+	ErrScreeningListInvalid       = errors.New("error screening list is invalid")
+	ErrScreeningListReserved      = errors.New("error internal blocklist cannot be imported")
+	ErrBlocklistEntryNotFound     = errors.New("error blocklist entry not found")
+	ErrBlocklistEntryInvalid      = errors.New("error blocklist entry should have a name or an account number")
+	ErrDisbursementOnHold         = errors.New("error disbursement is on hold for compliance review")
+)
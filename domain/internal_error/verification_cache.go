@@ -0,0 +1,8 @@
+package internal_error
+
+import "errors"
+
+var (
+	ErrVerificationCacheDisabled = errors.New("error verification cache is not enabled")
+	ErrEvictVerification         = errors.New("error when evicting cached verification")
+)
@@ -0,0 +1,9 @@
+package internal_error
+
+import "errors"
+
+var (
+	ErrWebhookEndpointNotFound = errors.New("error webhook endpoint not found")
+	ErrWebhookEndpointInvalid  = errors.New("error invalid webhook endpoint")
+	ErrWebhookDeliveryNotFound = errors.New("error webhook delivery not found")
+)
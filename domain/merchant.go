@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Merchant is a tenant owning disbursements. Client api keys belong to a merchant
+type Merchant struct {
+	Id        string
+	Name      string
+	Settings  MerchantSettings
+	CreatedAt time.Time
+}
+
+type MerchantSettings struct {
+	// empty means every bank code is allowed
+	AllowedBankCodes []string
+	// maximum amount of a single disbursement, 0 means no limit
+	MaxAmount int64
+	// maximum total amount disbursed in a day, failed and rejected disbursements are not counted. 0 means no limit
+	DailyLimit int64
+	// recipient name scoring below NameMatchRejectBelow against the account holder name is rejected, below
+	// NameMatchReviewBelow is flagged for review. Score is from 0 to 100, 0 disable the threshold
+	NameMatchRejectBelow int
+	NameMatchReviewBelow int
+}
+
+func (settings MerchantSettings) IsBankCodeAllowed(bankCode string) bool {
+	if len(settings.AllowedBankCodes) == 0 {
+		return true
+	}
+
+	for _, allowed := range settings.AllowedBankCodes {
+		if allowed == bankCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MerchantScopeFromContext return the merchant the request is restricted to. Caller without merchant such as
+// operator or internal process is not restricted
+func MerchantScopeFromContext(ctx context.Context) (string, bool) {
+	caller, ok := CallerFromContext(ctx)
+	if !ok || caller.MerchantId == "" {
+		return "", false
+	}
+
+	return caller.MerchantId, true
+}
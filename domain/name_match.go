@@ -0,0 +1,23 @@
+package domain
+
+// NameMatchDecision is the outcome of comparing the recipient name with the account holder name reported by the bank
+type NameMatchDecision string
+
+const (
+	NameMatchAccepted NameMatchDecision = "ACCEPTED"
+	// the disbursement is sent but flagged for an operator to review
+	NameMatchReview   NameMatchDecision = "REVIEW"
+	NameMatchRejected NameMatchDecision = "REJECTED"
+)
+
+// NameMatchDecision decide by the score of the recipient name from 0 to 100
+func (settings MerchantSettings) NameMatchDecision(score int) NameMatchDecision {
+	switch {
+	case score < settings.NameMatchRejectBelow:
+		return NameMatchRejected
+	case score < settings.NameMatchReviewBelow:
+		return NameMatchReview
+	default:
+		return NameMatchAccepted
+	}
+}
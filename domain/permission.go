@@ -0,0 +1,114 @@
+package domain
+
+const (
+	// RoleClient is the role of merchant application calling the disbursement api
+	RoleClient Role = "client"
+	// RoleOpsViewer is an operation staff who can look into disbursements, merchants and audit logs
+	RoleOpsViewer Role = "ops_viewer"
+	// RoleOpsOperator is an operation staff who can act on disbursements in addition to what viewer can do
+	RoleOpsOperator Role = "ops_operator"
+	// RoleAdmin can manage api keys and merchants
+	RoleAdmin Role = "admin"
+	// RoleCompliance is a compliance staff who review the disbursements held by the screening and manage the lists
+	RoleCompliance Role = "compliance"
+	// RoleBank is the bank partner sending transfer status callback
+	RoleBank Role = "bank"
+)
+
+type Permission string
+
+const (
+	PermissionDisbursementVerify Permission = "disbursement.verify"
+	PermissionDisbursementCreate Permission = "disbursement.create"
+	PermissionDisbursementRead   Permission = "disbursement.read"
+	// PermissionDisbursementOperate allow manual intervention such as forcing status
+	PermissionDisbursementOperate Permission = "disbursement.operate"
+	PermissionBankCallback        Permission = "bank_callback.process"
+	PermissionMerchantRead        Permission = "merchant.read"
+	PermissionMerchantManage      Permission = "merchant.manage"
+	PermissionApiKeyManage        Permission = "api_key.manage"
+	PermissionAuditRead           Permission = "audit.read"
+	PermissionCallbackReviewRead  Permission = "callback_review.read"
+	// PermissionCallbackReviewManage allow assigning, commenting and resolving parked bank callbacks
+	PermissionCallbackReviewManage Permission = "callback_review.manage"
+	PermissionWebhookRead          Permission = "webhook.read"
+	// PermissionWebhookManage allow managing the webhook endpoints of merchants
+	PermissionWebhookManage Permission = "webhook.manage"
+	// PermissionWebhookReplay allow sending a webhook delivery again
+	PermissionWebhookReplay Permission = "webhook.replay"
+	// PermissionVerificationCacheManage allow evicting cached bank account verification results
+	PermissionVerificationCacheManage Permission = "verification_cache.manage"
+	PermissionBeneficiaryRead         Permission = "beneficiary.read"
+	// PermissionBeneficiaryManage allow saving, changing and deleting the beneficiaries of the merchant
+	PermissionBeneficiaryManage Permission = "beneficiary.manage"
+	PermissionScreeningRead     Permission = "screening.read"
+	// PermissionScreeningResolve allow releasing or rejecting the disbursements held by the screening
+	PermissionScreeningResolve Permission = "screening.resolve"
+	// PermissionScreeningListManage allow importing the sanctions lists and changing the internal blocklist
+	PermissionScreeningListManage Permission = "screening_list.manage"
+)
+
+var opsViewerPermissions = []Permission{
+	PermissionDisbursementRead,
+	PermissionMerchantRead,
+	PermissionAuditRead,
+	PermissionCallbackReviewRead,
+	PermissionWebhookRead,
+	PermissionBeneficiaryRead,
+}
+
+// rolePermissions is the permission matrix, permission not listed for a role is denied
+var rolePermissions = map[Role][]Permission{
+	RoleClient: {
+		PermissionDisbursementVerify,
+		PermissionDisbursementCreate,
+		PermissionDisbursementRead,
+		PermissionBeneficiaryRead,
+		PermissionBeneficiaryManage,
+	},
+	RoleOpsViewer: opsViewerPermissions,
+	RoleOpsOperator: append([]Permission{
+		PermissionDisbursementOperate,
+		PermissionCallbackReviewManage,
+		PermissionWebhookReplay,
+		PermissionVerificationCacheManage,
+	}, opsViewerPermissions...),
+	RoleAdmin: append([]Permission{
+		PermissionDisbursementOperate,
+		PermissionCallbackReviewManage,
+		PermissionWebhookReplay,
+		PermissionVerificationCacheManage,
+		PermissionWebhookManage,
+		PermissionMerchantManage,
+		PermissionApiKeyManage,
+		PermissionScreeningRead,
+		PermissionScreeningResolve,
+		PermissionScreeningListManage,
+	}, opsViewerPermissions...),
+	RoleCompliance: {
+		PermissionDisbursementRead,
+		PermissionAuditRead,
+		PermissionScreeningRead,
+		PermissionScreeningResolve,
+		PermissionScreeningListManage,
+	},
+	RoleBank: {
+		PermissionBankCallback,
+	},
+}
+
+func (role Role) IsValid() bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// Can return true when the role is granted the permission
+func (role Role) Can(permission Permission) bool {
+	for _, granted := range rolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+
+	return false
+}
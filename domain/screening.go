@@ -0,0 +1,100 @@
+package domain
+
+import "time"
+
+// ScreeningListInternal is the blocklist maintained by compliance, it cannot be replaced by an import
+const ScreeningListInternal = "INTERNAL"
+
+type ScreeningListFormat string
+
+const (
+	ScreeningListFormatCSV ScreeningListFormat = "csv"
+	ScreeningListFormatXML ScreeningListFormat = "xml"
+)
+
+// ScreeningEntry is a sanctioned or blocked party, matched by name, account number or both
+type ScreeningEntry struct {
+	Id       string
+	ListName string
+	// empty when only the account number is listed
+	Name string
+	// empty when only the name is listed
+	AccountNumber string
+	// the account number is blocked in any bank when empty
+	BankCode string
+	// Reference is the id of the entry in the source list, or the reason of an internal blocklist entry
+	Reference string
+	CreatedAt time.Time
+}
+
+type ScreeningMatchField string
+
+const (
+	ScreeningMatchFieldName          ScreeningMatchField = "NAME"
+	ScreeningMatchFieldAccountNumber ScreeningMatchField = "ACCOUNT_NUMBER"
+)
+
+// ScreeningMatch is a screening entry hit by the recipient of a disbursement
+type ScreeningMatch struct {
+	EntryId   string
+	ListName  string
+	EntryName string
+	Reference string
+	Field     ScreeningMatchField
+	// similarity of the recipient name to the entry name from 0 to 100, always 100 for the account number
+	Score int
+}
+
+type ScreeningStatus string
+
+const (
+	// no entry matched the recipient, the disbursement was sent to the bank
+	ScreeningStatusCleared ScreeningStatus = "CLEARED"
+	// the disbursement is on hold until compliance resolve the screening
+	ScreeningStatusHeld ScreeningStatus = "HELD"
+	// compliance judged the matches as false positive and the disbursement was sent to the bank
+	ScreeningStatusReleased ScreeningStatus = "RELEASED"
+	// compliance confirmed the matches and the disbursement was rejected
+	ScreeningStatusRejected ScreeningStatus = "REJECTED"
+)
+
+type ScreeningResolution string
+
+const (
+	ScreeningResolutionRelease ScreeningResolution = "release"
+	ScreeningResolutionReject  ScreeningResolution = "reject"
+)
+
+// Screening is the decision taken on the recipient of a disbursement before the transfer
+type Screening struct {
+	Id             string
+	DisbursementId string
+	MerchantId     string
+	Status         ScreeningStatus
+	// empty when cleared
+	Matches        []ScreeningMatch
+	ResolvedBy     string
+	ResolutionNote string
+	ResolvedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (screening Screening) IsHeld() bool {
+	return screening.Status == ScreeningStatusHeld
+}
+
+type ScreeningFilter struct {
+	Status         ScreeningStatus
+	DisbursementId string
+	// oldest screenings are returned first, default limit is used when 0
+	Limit  int
+	Offset int
+}
+
+type ScreeningEntryFilter struct {
+	ListName string
+	// newest entries are returned first, default limit is used when 0
+	Limit  int
+	Offset int
+}
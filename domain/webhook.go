@@ -0,0 +1,116 @@
+package domain
+
+import "time"
+
+type WebhookEventType string
+
+const (
+	WebhookEventDisbursementPending   WebhookEventType = "disbursement.pending"
+	WebhookEventDisbursementCompleted WebhookEventType = "disbursement.completed"
+	WebhookEventDisbursementFailed    WebhookEventType = "disbursement.failed"
+	WebhookEventDisbursementRejected  WebhookEventType = "disbursement.rejected"
+)
+
+// WebhookEventTypeOf return the event sent when a disbursement change to the status
+func WebhookEventTypeOf(status DisbursementStatus) (WebhookEventType, bool) {
+	switch status {
+	case DisbursementStatusPending:
+		return WebhookEventDisbursementPending, true
+	case DisbursementStatusCompleted:
+		return WebhookEventDisbursementCompleted, true
+	case DisbursementStatusFailed:
+		return WebhookEventDisbursementFailed, true
+	case DisbursementStatusRejected:
+		return WebhookEventDisbursementRejected, true
+	default:
+		return "", false
+	}
+}
+
+func (eventType WebhookEventType) IsValid() bool {
+	for _, status := range []DisbursementStatus{DisbursementStatusPending, DisbursementStatusCompleted, DisbursementStatusFailed, DisbursementStatusRejected} {
+		if valid, _ := WebhookEventTypeOf(status); valid == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebhookEndpoint is an url of the merchant receiving the events, signed with the endpoint secret
+type WebhookEndpoint struct {
+	Id         string
+	MerchantId string
+	Url        string
+	Secret     string
+	// empty means every event type
+	EventTypes []WebhookEventType
+	CreatedAt  time.Time
+}
+
+func (endpoint WebhookEndpoint) Subscribes(eventType WebhookEventType) bool {
+	if len(endpoint.EventTypes) == 0 {
+		return true
+	}
+
+	for _, subscribed := range endpoint.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "DELIVERED"
+	// every attempt failed, the delivery can still be replayed manually
+	WebhookDeliveryStatusFailed WebhookDeliveryStatus = "FAILED"
+)
+
+// WebhookDelivery is an event to be sent to an endpoint. It is written in the same transaction as the change
+// causing the event so the event is never lost
+type WebhookDelivery struct {
+	Id         string
+	EndpointId string
+	MerchantId string
+	// EventId is the same for every delivery of the event, merchant use it to ignore duplicates
+	EventId        string
+	EventType      WebhookEventType
+	DisbursementId string
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    *time.Time
+	// ReplayOf is the id of the delivery replayed by this delivery
+	ReplayOf  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WebhookDeliveryAttempt is the log of a request sent to the endpoint
+type WebhookDeliveryAttempt struct {
+	Id         string
+	DeliveryId string
+	Attempt    int
+	// 0 when no response received
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+	CreatedAt  time.Time
+}
+
+type WebhookDeliveryFilter struct {
+	MerchantId     string
+	EndpointId     string
+	DisbursementId string
+	Status         WebhookDeliveryStatus
+	// newest deliveries are returned first, default limit is used when 0
+	Limit int
+}
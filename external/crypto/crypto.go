@@ -0,0 +1,36 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrKeyNotFound       = errors.New("encryption key not found")
+	ErrEncryptionMissing = errors.New("encrypted value cannot be read because encryption is not configured")
+)
+
+// KeyProvider provide the master keys used to wrap the per record data key
+type KeyProvider interface {
+	// CurrentKeyId return id of the key used to encrypt new data
+	CurrentKeyId(ctx context.Context) (string, error)
+	Key(ctx context.Context, keyId string) ([]byte, error)
+	// BlindIndexKey return the key used to calculate blind index, it is not rotated since the index need to stay stable
+	BlindIndexKey(ctx context.Context) ([]byte, error)
+}
+
+// EncryptedFields is the encrypted form of some fields of a record. KeyId empty means the values are not encrypted
+type EncryptedFields struct {
+	KeyId   string
+	DataKey string // data key wrapped by the master key
+	Values  []string
+}
+
+type FieldEncryptor interface {
+	// CurrentKeyId return id of the key used to encrypt new data, empty when encryption not enabled
+	CurrentKeyId(ctx context.Context) (string, error)
+	Encrypt(ctx context.Context, plaintexts ...string) (EncryptedFields, error)
+	Decrypt(ctx context.Context, fields EncryptedFields) ([]string, error)
+	// BlindIndex return keyed hash of value so the encrypted field can be searched by exact match
+	BlindIndex(ctx context.Context, value string) (string, error)
+}
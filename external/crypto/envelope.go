@@ -0,0 +1,159 @@
+package crypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+)
+
+const dataKeySize = 32
+
+var errInvalidCiphertext = errors.New("invalid ciphertext")
+
+// envelopeEncryptor encrypt every record with its own random data key using AES-GCM, then wrap the data key
+// with the master key. Rotating the master key only need the data key to be re-wrapped
+type envelopeEncryptor struct {
+	keyProvider KeyProvider
+}
+
+type EnvelopeEncryptorOpts struct {
+	KeyProvider KeyProvider
+}
+
+func NewEnvelopeEncryptor(opts EnvelopeEncryptorOpts) *envelopeEncryptor {
+	return &envelopeEncryptor{
+		keyProvider: opts.KeyProvider,
+	}
+}
+
+func (env envelopeEncryptor) CurrentKeyId(ctx context.Context) (string, error) {
+	return env.keyProvider.CurrentKeyId(ctx)
+}
+
+func (env envelopeEncryptor) Encrypt(ctx context.Context, plaintexts ...string) (EncryptedFields, error) {
+	keyId, err := env.keyProvider.CurrentKeyId(ctx)
+	if err != nil {
+		return EncryptedFields{}, err
+	}
+
+	masterKey, err := env.keyProvider.Key(ctx, keyId)
+	if err != nil {
+		return EncryptedFields{}, err
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	_, err = rand.Read(dataKey)
+	if err != nil {
+		return EncryptedFields{}, err
+	}
+
+	wrappedDataKey, err := seal(masterKey, dataKey, []byte(keyId))
+	if err != nil {
+		return EncryptedFields{}, err
+	}
+
+	res := EncryptedFields{
+		KeyId:   keyId,
+		DataKey: wrappedDataKey,
+		Values:  make([]string, 0, len(plaintexts)),
+	}
+
+	for i, plaintext := range plaintexts {
+		// bind the ciphertext to its position so the fields cannot be swapped
+		ciphertext, err := seal(dataKey, []byte(plaintext), []byte(strconv.Itoa(i)))
+		if err != nil {
+			return EncryptedFields{}, err
+		}
+
+		res.Values = append(res.Values, ciphertext)
+	}
+
+	return res, nil
+}
+
+func (env envelopeEncryptor) Decrypt(ctx context.Context, fields EncryptedFields) ([]string, error) {
+	if fields.KeyId == "" {
+		// written before encryption enabled
+		return fields.Values, nil
+	}
+
+	masterKey, err := env.keyProvider.Key(ctx, fields.KeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := open(masterKey, fields.DataKey, []byte(fields.KeyId))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(fields.Values))
+	for i, ciphertext := range fields.Values {
+		plaintext, err := open(dataKey, ciphertext, []byte(strconv.Itoa(i)))
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, string(plaintext))
+	}
+
+	return res, nil
+}
+
+func (env envelopeEncryptor) BlindIndex(ctx context.Context, value string) (string, error) {
+	key, err := env.keyProvider.BlindIndexKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// seal encrypt plaintext with AES-GCM and return base64 of nonce followed by the ciphertext
+func seal(key []byte, plaintext []byte, additionalData []byte) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, plaintext, additionalData)), nil
+}
+
+func open(key []byte, encoded string, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(ciphertext) < aead.NonceSize() {
+		return nil, errInvalidCiphertext
+	}
+
+	return aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], additionalData)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
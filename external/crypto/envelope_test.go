@@ -0,0 +1,142 @@
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type staticKeyProvider struct {
+	currentKeyId  string
+	keys          map[string][]byte
+	blindIndexKey []byte
+}
+
+func (st staticKeyProvider) CurrentKeyId(ctx context.Context) (string, error) {
+	return st.currentKeyId, nil
+}
+
+func (st staticKeyProvider) Key(ctx context.Context, keyId string) ([]byte, error) {
+	key, exists := st.keys[keyId]
+	if !exists {
+		return nil, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (st staticKeyProvider) BlindIndexKey(ctx context.Context) ([]byte, error) {
+	return st.blindIndexKey, nil
+}
+
+func newStaticKeyProvider(currentKeyId string) staticKeyProvider {
+	return staticKeyProvider{
+		currentKeyId: currentKeyId,
+		keys: map[string][]byte{
+			"key-1": bytes.Repeat([]byte{1}, 32),
+			"key-2": bytes.Repeat([]byte{2}, 32),
+		},
+		blindIndexKey: bytes.Repeat([]byte{9}, 32),
+	}
+}
+
+func Test_envelopeEncryptor_EncryptDecrypt(t *testing.T) {
+	ctx := context.TODO()
+	encryptor := NewEnvelopeEncryptor(EnvelopeEncryptorOpts{KeyProvider: newStaticKeyProvider("key-1")})
+
+	fields, err := encryptor.Encrypt(ctx, "Nobby Phala", "79823469")
+	assert.Nil(t, err)
+	assert.Equal(t, "key-1", fields.KeyId)
+	assert.NotEmpty(t, fields.DataKey)
+	assert.NotContains(t, fields.Values, "Nobby Phala")
+	assert.NotContains(t, fields.Values, "79823469")
+
+	got, err := encryptor.Decrypt(ctx, fields)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Nobby Phala", "79823469"}, got)
+}
+
+func Test_envelopeEncryptor_Decrypt(t *testing.T) {
+	ctx := context.TODO()
+	oldEncryptor := NewEnvelopeEncryptor(EnvelopeEncryptorOpts{KeyProvider: newStaticKeyProvider("key-1")})
+	encrypted, err := oldEncryptor.Encrypt(ctx, "Nobby Phala", "79823469")
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name    string
+		fields  EncryptedFields
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "encrypted with previous key",
+			fields: encrypted,
+			want:   []string{"Nobby Phala", "79823469"},
+		},
+		{
+			name:   "not encrypted",
+			fields: EncryptedFields{Values: []string{"Nobby Phala", "79823469"}},
+			want:   []string{"Nobby Phala", "79823469"},
+		},
+		{
+			name: "swapped fields",
+			fields: EncryptedFields{
+				KeyId:   encrypted.KeyId,
+				DataKey: encrypted.DataKey,
+				Values:  []string{encrypted.Values[1], encrypted.Values[0]},
+			},
+			wantErr: true,
+		},
+		{
+			name: "tampered data key",
+			fields: EncryptedFields{
+				KeyId:   "key-2",
+				DataKey: encrypted.DataKey,
+				Values:  encrypted.Values,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown key",
+			fields: EncryptedFields{
+				KeyId:   "key-3",
+				DataKey: encrypted.DataKey,
+				Values:  encrypted.Values,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encryptor := NewEnvelopeEncryptor(EnvelopeEncryptorOpts{KeyProvider: newStaticKeyProvider("key-2")})
+			got, err := encryptor.Decrypt(ctx, tt.fields)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_envelopeEncryptor_BlindIndex(t *testing.T) {
+	ctx := context.TODO()
+	encryptor := NewEnvelopeEncryptor(EnvelopeEncryptorOpts{KeyProvider: newStaticKeyProvider("key-1")})
+	rotated := NewEnvelopeEncryptor(EnvelopeEncryptorOpts{KeyProvider: newStaticKeyProvider("key-2")})
+
+	first, err := encryptor.BlindIndex(ctx, "79823469")
+	assert.Nil(t, err)
+	second, err := rotated.BlindIndex(ctx, "79823469")
+	assert.Nil(t, err)
+	other, err := encryptor.BlindIndex(ctx, "79823468")
+	assert.Nil(t, err)
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+	assert.NotContains(t, first, "79823469")
+}
+
+func Test_plaintextEncryptor_Decrypt(t *testing.T) {
+	_, err := NewPlaintextEncryptor().Decrypt(context.TODO(), EncryptedFields{KeyId: "key-1"})
+	assert.True(t, errors.Is(err, ErrEncryptionMissing))
+}
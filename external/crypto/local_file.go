@@ -0,0 +1,101 @@
+package crypto
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// key file format, every key is base64 encoded 32 bytes:
+//
+//	{
+//	  "current_key_id": "2024-02",
+//	  "keys": {"2024-01": "<base64>", "2024-02": "<base64>"},
+//	  "blind_index_key": "<base64>"
+//	}
+//
+// to rotate add a new key, point current_key_id to it and keep the old keys until re-encryption finished
+type keyFile struct {
+	CurrentKeyId  string            `json:"current_key_id"`
+	Keys          map[string]string `json:"keys"`
+	BlindIndexKey string            `json:"blind_index_key"`
+}
+
+type localFileKeyProvider struct {
+	currentKeyId  string
+	keys          map[string][]byte
+	blindIndexKey []byte
+}
+
+type LocalFileKeyProviderOpts struct {
+	Path string
+}
+
+func NewLocalFileKeyProvider(opts LocalFileKeyProviderOpts) (*localFileKeyProvider, error) {
+	content, err := os.ReadFile(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file keyFile
+	err = json.Unmarshal(content, &file)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing key file: %w", err)
+	}
+
+	provider := &localFileKeyProvider{
+		currentKeyId: file.CurrentKeyId,
+		keys:         make(map[string][]byte, len(file.Keys)),
+	}
+
+	for keyId, encoded := range file.Keys {
+		provider.keys[keyId], err = decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s: %w", keyId, err)
+		}
+	}
+
+	if _, exists := provider.keys[provider.currentKeyId]; !exists {
+		return nil, fmt.Errorf("current key %q is not in the key file", provider.currentKeyId)
+	}
+
+	provider.blindIndexKey, err = decodeKey(file.BlindIndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blind index key: %w", err)
+	}
+
+	return provider, nil
+}
+
+func (lf localFileKeyProvider) CurrentKeyId(ctx context.Context) (string, error) {
+	return lf.currentKeyId, nil
+}
+
+func (lf localFileKeyProvider) Key(ctx context.Context, keyId string) ([]byte, error) {
+	key, exists := lf.keys[keyId]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyId)
+	}
+
+	return key, nil
+}
+
+func (lf localFileKeyProvider) BlindIndexKey(ctx context.Context) ([]byte, error) {
+	return lf.blindIndexKey, nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(key) != 32 {
+		return nil, errors.New("key must be 32 bytes")
+	}
+
+	return key, nil
+}
@@ -0,0 +1,68 @@
+package crypto
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testKey1 = "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="
+	testKey2 = "AgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgI="
+)
+
+func TestNewLocalFileKeyProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "valid key file",
+			content: `{"current_key_id": "key-2", "keys": {"key-1": "` + testKey1 + `", "key-2": "` + testKey2 + `"}, "blind_index_key": "` + testKey1 + `"}`,
+		},
+		{
+			name:    "current key missing",
+			content: `{"current_key_id": "key-3", "keys": {"key-1": "` + testKey1 + `"}, "blind_index_key": "` + testKey1 + `"}`,
+			wantErr: true,
+		},
+		{
+			name:    "short key",
+			content: `{"current_key_id": "key-1", "keys": {"key-1": "AQEB"}, "blind_index_key": "` + testKey1 + `"}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing blind index key",
+			content: `{"current_key_id": "key-1", "keys": {"key-1": "` + testKey1 + `"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			content: `{`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "keys.json")
+			assert.Nil(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			got, err := NewLocalFileKeyProvider(LocalFileKeyProviderOpts{Path: path})
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErr {
+				return
+			}
+
+			keyId, _ := got.CurrentKeyId(context.TODO())
+			assert.Equal(t, "key-2", keyId)
+
+			_, err = got.Key(context.TODO(), "key-1")
+			assert.Nil(t, err)
+			_, err = got.Key(context.TODO(), "key-3")
+			assert.ErrorIs(t, err, ErrKeyNotFound)
+		})
+	}
+}
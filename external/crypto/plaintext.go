@@ -0,0 +1,30 @@
+package crypto
+
+import "context"
+
+// plaintextEncryptor is used when encryption is not configured, the values are stored as is
+type plaintextEncryptor struct{}
+
+func NewPlaintextEncryptor() *plaintextEncryptor {
+	return &plaintextEncryptor{}
+}
+
+func (pl plaintextEncryptor) CurrentKeyId(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+func (pl plaintextEncryptor) Encrypt(ctx context.Context, plaintexts ...string) (EncryptedFields, error) {
+	return EncryptedFields{Values: plaintexts}, nil
+}
+
+func (pl plaintextEncryptor) Decrypt(ctx context.Context, fields EncryptedFields) ([]string, error) {
+	if fields.KeyId != "" {
+		return nil, ErrEncryptionMissing
+	}
+
+	return fields.Values, nil
+}
+
+func (pl plaintextEncryptor) BlindIndex(ctx context.Context, value string) (string, error) {
+	return value, nil
+}
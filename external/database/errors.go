@@ -0,0 +1,48 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+	pgUniqueViolation      = "23505"
+)
+
+// driver agnostic errors, driver that not return postgres error (such as in-memory database) should use these
+var (
+	ErrSerializationFailure = errors.New("could not serialize access due to concurrent update")
+	ErrUniqueViolation      = errors.New("duplicate key value violates unique constraint")
+)
+
+// IsRetryableError report whether err is caused by transaction conflict with another transaction,
+// so the whole transaction can be safely retried
+func IsRetryableError(err error) bool {
+	if errors.Is(err, ErrSerializationFailure) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+}
+
+// IsUniqueViolation report whether err is caused by inserting or updating duplicate value of unique constraint
+func IsUniqueViolation(err error) bool {
+	if errors.Is(err, ErrUniqueViolation) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == pgUniqueViolation
+}
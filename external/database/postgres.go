@@ -2,14 +2,13 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 )
 
-// for now the sqlx not wrapped
-
 type PostgresSQLOpts struct {
 	DB *sqlx.DB
 }
@@ -51,6 +50,10 @@ func (pgs *postgresSql) Get(ctx context.Context, dest interface{}, query string,
 	return pgs.db.GetContext(ctx, dest, query, args...)
 }
 
+func (pgs *postgresSql) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return pgs.db.SelectContext(ctx, dest, query, args...)
+}
+
 func (pgs *postgresSql) Exec(ctx context.Context, query string, args ...interface{}) (Result, error) {
 	return pgs.db.ExecContext(ctx, query, args...)
 }
@@ -59,6 +62,45 @@ func (pgs *postgresSql) Query(ctx context.Context, query string, args ...interfa
 	return pgs.db.QueryRowContext(ctx, query, args...)
 }
 
+func (pgs *postgresSql) QueryRows(ctx context.Context, query string, args ...interface{}) (Rows, error) {
+	return pgs.db.QueryxContext(ctx, query, args...)
+}
+
+func (pgs *postgresSql) NamedExec(ctx context.Context, query string, arg interface{}) (Result, error) {
+	return pgs.db.NamedExecContext(ctx, query, arg)
+}
+
+func (pgs *postgresSql) NamedQuery(ctx context.Context, query string, arg interface{}) (Rows, error) {
+	return pgs.db.NamedQueryContext(ctx, query, arg)
+}
+
+func (pgs *postgresSql) Prepare(ctx context.Context, query string) (Stmt, error) {
+	stmt, err := pgs.db.PreparexContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &postgresStmt{stmt: stmt}, nil
+}
+
+func (pgs *postgresSql) BeginTx(ctx context.Context, opts TxOptions) (Tx, error) {
+	tx, err := pgs.db.BeginTxx(ctx, &sql.TxOptions{
+		Isolation: sql.IsolationLevel(opts.Isolation),
+		ReadOnly:  opts.ReadOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPostgresSqlTx(PostgresSqlTxOpts{
+		Tx: tx,
+	}), nil
+}
+
+func (pgs *postgresSql) Close() error {
+	return pgs.db.Close()
+}
+
 type PostgresSqlTxOpts struct {
 	Tx *sqlx.Tx
 }
@@ -77,6 +119,10 @@ func (pgsx *postgresSqlTx) Get(ctx context.Context, dest interface{}, query stri
 	return pgsx.tx.GetContext(ctx, dest, query, args...)
 }
 
+func (pgsx *postgresSqlTx) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return pgsx.tx.SelectContext(ctx, dest, query, args...)
+}
+
 func (pgsx *postgresSqlTx) Exec(ctx context.Context, query string, args ...interface{}) (Result, error) {
 	return pgsx.tx.ExecContext(ctx, query, args...)
 }
@@ -84,3 +130,62 @@ func (pgsx *postgresSqlTx) Exec(ctx context.Context, query string, args ...inter
 func (pgsx *postgresSqlTx) Query(ctx context.Context, query string, args ...interface{}) Row {
 	return pgsx.tx.QueryRowContext(ctx, query, args...)
 }
+
+func (pgsx *postgresSqlTx) QueryRows(ctx context.Context, query string, args ...interface{}) (Rows, error) {
+	return pgsx.tx.QueryxContext(ctx, query, args...)
+}
+
+func (pgsx *postgresSqlTx) NamedExec(ctx context.Context, query string, arg interface{}) (Result, error) {
+	return pgsx.tx.NamedExecContext(ctx, query, arg)
+}
+
+func (pgsx *postgresSqlTx) NamedQuery(ctx context.Context, query string, arg interface{}) (Rows, error) {
+	// sqlx.Tx has no NamedQueryContext so bind the named parameter manually
+	boundQuery, args, err := pgsx.tx.BindNamed(query, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgsx.tx.QueryxContext(ctx, boundQuery, args...)
+}
+
+func (pgsx *postgresSqlTx) Prepare(ctx context.Context, query string) (Stmt, error) {
+	stmt, err := pgsx.tx.PreparexContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &postgresStmt{stmt: stmt}, nil
+}
+
+func (pgsx *postgresSqlTx) Commit() error {
+	return pgsx.tx.Commit()
+}
+
+func (pgsx *postgresSqlTx) Rollback() error {
+	return pgsx.tx.Rollback()
+}
+
+type postgresStmt struct {
+	stmt *sqlx.Stmt
+}
+
+func (pgst *postgresStmt) Get(ctx context.Context, dest interface{}, args ...interface{}) error {
+	return pgst.stmt.GetContext(ctx, dest, args...)
+}
+
+func (pgst *postgresStmt) Select(ctx context.Context, dest interface{}, args ...interface{}) error {
+	return pgst.stmt.SelectContext(ctx, dest, args...)
+}
+
+func (pgst *postgresStmt) Exec(ctx context.Context, args ...interface{}) (Result, error) {
+	return pgst.stmt.ExecContext(ctx, args...)
+}
+
+func (pgst *postgresStmt) Query(ctx context.Context, args ...interface{}) Row {
+	return pgst.stmt.QueryRowContext(ctx, args...)
+}
+
+func (pgst *postgresStmt) Close() error {
+	return pgst.stmt.Close()
+}
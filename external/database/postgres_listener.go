@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+)
+
+type PostgresListenerOpts struct {
+	DB *sqlx.DB
+	// Channel is the postgres NOTIFY channel
+	Channel string
+}
+
+type postgresListener struct {
+	db      *sqlx.DB
+	channel string
+}
+
+func NewPostgresListener(opts PostgresListenerOpts) *postgresListener {
+	return &postgresListener{
+		db:      opts.DB,
+		channel: opts.Channel,
+	}
+}
+
+// Listen hold a dedicated connection executing LISTEN on the channel. The returned channel receive a value after
+// notifications arrived, several notifications may be merged. It is closed when ctx done or the connection lost
+func (pl *postgresListener) Listen(ctx context.Context) (<-chan struct{}, error) {
+	conn, err := pl.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := make(chan struct{}, 1)
+	listening := make(chan error, 1)
+
+	go func() {
+		defer close(notifications)
+		defer conn.Close()
+
+		// returning driver.ErrBadConn discard the connection instead of putting it back to the pool still listening
+		_ = conn.Raw(func(driverConn any) error {
+			stdlibConn, ok := driverConn.(*stdlib.Conn)
+			if !ok {
+				listening <- fmt.Errorf("listen require pgx connection, got %T", driverConn)
+				return driver.ErrBadConn
+			}
+
+			pgxConn := stdlibConn.Conn()
+
+			_, err := pgxConn.Exec(ctx, "LISTEN "+pgx.Identifier{pl.channel}.Sanitize())
+			listening <- err
+			if err != nil {
+				return driver.ErrBadConn
+			}
+
+			for {
+				_, err = pgxConn.WaitForNotification(ctx)
+				if err != nil {
+					return driver.ErrBadConn
+				}
+
+				select {
+				case notifications <- struct{}{}:
+				default:
+				}
+			}
+		})
+	}()
+
+	err = <-listening
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
@@ -2,22 +2,81 @@ package database
 
 import (
 	"context"
+	"database/sql"
 )
 
 // wrap external depedencies and driver.
-// for now we will define only the function needed for this project
+// the application should only depend on these interfaces so the driver can be replaced
+var (
+	ErrNoRows = sql.ErrNoRows
+	ErrTxDone = sql.ErrTxDone
+)
+
+type IsolationLevel int
+
+const (
+	LevelDefault        = IsolationLevel(sql.LevelDefault)
+	LevelReadCommitted  = IsolationLevel(sql.LevelReadCommitted)
+	LevelRepeatableRead = IsolationLevel(sql.LevelRepeatableRead)
+	LevelSerializable   = IsolationLevel(sql.LevelSerializable)
+)
+
+type TxOptions struct {
+	Isolation IsolationLevel
+	ReadOnly  bool
+}
+
 type Row interface {
 	Scan(dest ...any) error
 	Err() error
 }
 
+type Rows interface {
+	Next() bool
+	Scan(dest ...any) error
+	// StructScan scan the current row to struct using the db tag
+	StructScan(dest interface{}) error
+	Close() error
+	Err() error
+}
+
 type Result interface {
 	LastInsertId() (int64, error)
 	RowsAffected() (int64, error)
 }
 
+type Stmt interface {
+	Get(ctx context.Context, dest interface{}, args ...interface{}) error
+	Select(ctx context.Context, dest interface{}, args ...interface{}) error
+	Exec(ctx context.Context, args ...interface{}) (Result, error)
+	Query(ctx context.Context, args ...interface{}) Row
+	Close() error
+}
+
 type SQLDatabase interface {
 	Get(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error
 	Exec(ctx context.Context, query string, args ...interface{}) (Result, error)
 	Query(ctx context.Context, query string, args ...interface{}) Row
+	QueryRows(ctx context.Context, query string, args ...interface{}) (Rows, error)
+	// NamedExec and NamedQuery bind :name parameter in the query from arg struct db tag or map key
+	NamedExec(ctx context.Context, query string, arg interface{}) (Result, error)
+	NamedQuery(ctx context.Context, query string, arg interface{}) (Rows, error)
+	Prepare(ctx context.Context, query string) (Stmt, error)
+}
+
+type Tx interface {
+	SQLDatabase
+	Commit() error
+	Rollback() error
+}
+
+type Transactor interface {
+	BeginTx(ctx context.Context, opts TxOptions) (Tx, error)
+}
+
+type DB interface {
+	SQLDatabase
+	Transactor
+	Close() error
 }
@@ -0,0 +1,57 @@
+package grpc_server
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+)
+
+type ServerOpts struct {
+	Address string
+	Server  *grpc.Server
+}
+
+type grpcServer struct {
+	address string
+	server  *grpc.Server
+}
+
+func NewGrpcServer(opts ServerOpts) *grpcServer {
+	return &grpcServer{
+		address: opts.Address,
+		server:  opts.Server,
+	}
+}
+
+func (srv *grpcServer) Start() error {
+	listener, err := net.Listen("tcp", srv.address)
+	if err != nil {
+		return err
+	}
+
+	err = srv.server.Serve(listener)
+	if err == grpc.ErrServerStopped {
+		return nil
+	}
+
+	return err
+}
+
+func (srv *grpcServer) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+
+	go func() {
+		srv.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		// long running streams never finish by themselves
+		srv.server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,13 @@
+package grpc_server
+
+import "context"
+
+// simple wrapper for grpc server so the application not depend on the grpc server lifecycle directly
+
+type GRPCServer interface {
+	// Start block until the server stopped. Return nil when the server stopped by Shutdown
+	Start() error
+	// Shutdown stop accepting new call and wait all in-flight call until done or ctx expired,
+	// remaining calls are cancelled after that
+	Shutdown(ctx context.Context) error
+}
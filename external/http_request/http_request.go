@@ -9,4 +9,6 @@ import (
 
 type HTTPRequest interface {
 	Post(ctx context.Context, url string, header map[string]string, body interface{}, response interface{}) error
+	// PostRaw send the body as is and return the response status code, the response body is discarded
+	PostRaw(ctx context.Context, url string, header map[string]string, body []byte) (int, error)
 }
@@ -9,6 +9,8 @@ import (
 	"net/http"
 )
 
+const maxDiscardedBodySize = 64 << 10
+
 type httpRequest struct {
 }
 
@@ -60,3 +62,39 @@ func (ht httpRequest) Post(ctx context.Context, url string, headers map[string]s
 
 	return nil
 }
+
+func (ht httpRequest) PostRaw(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	client := &http.Client{}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		log.Println("Error creating request:", err)
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println("errror closing body", err)
+		}
+	}(resp.Body)
+
+	// drain so the connection can be reused
+	_, err = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDiscardedBodySize))
+	if err != nil {
+		log.Println("error reading response body:", err)
+	}
+
+	return resp.StatusCode, nil
+}
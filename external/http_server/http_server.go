@@ -0,0 +1,60 @@
+package http_server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+type ServerOpts struct {
+	Address        string
+	Handler        http.Handler
+	ReadTimeout    time.Duration
+	WriteTimeout   time.Duration
+	IdleTimeout    time.Duration
+	MaxHeaderBytes int
+	TLSCertFile    string
+	TLSKeyFile     string
+}
+
+type httpServer struct {
+	server      *http.Server
+	tlsCertFile string
+	tlsKeyFile  string
+}
+
+func NewHttpServer(opts ServerOpts) *httpServer {
+	return &httpServer{
+		server: &http.Server{
+			Addr:           opts.Address,
+			Handler:        opts.Handler,
+			ReadTimeout:    opts.ReadTimeout,
+			WriteTimeout:   opts.WriteTimeout,
+			IdleTimeout:    opts.IdleTimeout,
+			MaxHeaderBytes: opts.MaxHeaderBytes,
+		},
+		tlsCertFile: opts.TLSCertFile,
+		tlsKeyFile:  opts.TLSKeyFile,
+	}
+}
+
+func (srv *httpServer) Start() error {
+	var err error
+
+	if srv.tlsCertFile != "" && srv.tlsKeyFile != "" {
+		err = srv.server.ListenAndServeTLS(srv.tlsCertFile, srv.tlsKeyFile)
+	} else {
+		err = srv.server.ListenAndServe()
+	}
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+func (srv *httpServer) Shutdown(ctx context.Context) error {
+	return srv.server.Shutdown(ctx)
+}
@@ -0,0 +1,16 @@
+package http_server
+
+import "context"
+
+// simple wrapper for http server so the application not depend on net/http server directly
+
+type HTTPServer interface {
+	// Start block until the server stopped. Return nil when the server stopped by Shutdown
+	Start() error
+	// Shutdown stop accepting new request and wait all in-flight request until done or ctx expired
+	Shutdown(ctx context.Context) error
+}
+
+func (srv *httpServer) RegisterOnShutdown(fn func()) {
+	srv.server.RegisterOnShutdown(fn)
+}
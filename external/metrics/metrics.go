@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Registry hold the metrics of the application and write them in the prometheus text exposition format,
+// so they can be scraped without pulling the prometheus client library
+
+type Labels map[string]string
+
+type Sample struct {
+	Labels Labels
+	Value  float64
+}
+
+type metricType string
+
+const (
+	typeGauge   metricType = "gauge"
+	typeCounter metricType = "counter"
+)
+
+type metric struct {
+	name       string
+	help       string
+	metricType metricType
+	collect    func(ctx context.Context) ([]Sample, error)
+}
+
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// GaugeFunc register a gauge whose samples are read by collect on every scrape
+func (r *Registry) GaugeFunc(name string, help string, collect func(ctx context.Context) ([]Sample, error)) {
+	r.register(metric{
+		name:       name,
+		help:       help,
+		metricType: typeGauge,
+		collect:    collect,
+	})
+}
+
+// Counter register a counter whose samples are increased by the application
+func (r *Registry) Counter(name string, help string) *Counter {
+	counter := &Counter{
+		samples: map[string]*Sample{},
+	}
+
+	r.register(metric{
+		name:       name,
+		help:       help,
+		metricType: typeCounter,
+		collect:    counter.collect,
+	})
+
+	return counter
+}
+
+func (r *Registry) register(m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.metrics {
+		if existing.name == m.name {
+			panic(fmt.Sprintf("metric %q registered twice", m.name))
+		}
+	}
+
+	r.metrics = append(r.metrics, m)
+}
+
+// Write collect every metric and write them to w. Metric failed to be collected is skipped so the other
+// metrics are still exposed
+func (r *Registry) Write(ctx context.Context, w io.Writer) error {
+	r.mu.Lock()
+	metrics := append([]metric(nil), r.metrics...)
+	r.mu.Unlock()
+
+	for _, m := range metrics {
+		samples, err := m.collect(ctx)
+		if err != nil {
+			log.Println("error collecting metric", m.name, err)
+			continue
+		}
+
+		_, err = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.metricType)
+		if err != nil {
+			return err
+		}
+
+		for _, sample := range samples {
+			_, err = fmt.Fprintf(w, "%s%s %s\n", m.name, formatLabels(sample.Labels), strconv.FormatFloat(sample.Value, 'g', -1, 64))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// formatLabels render labels sorted by name so the output is stable
+func formatLabels(labels Labels) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", name, labels[name]))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Counter only go up, it has one sample per distinct labels
+type Counter struct {
+	mu      sync.Mutex
+	samples map[string]*Sample
+}
+
+// Inc add 1 to the sample of the labels, the sample is created on the first call
+func (c *Counter) Inc(labels Labels) {
+	key := formatLabels(labels)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sample, exists := c.samples[key]
+	if !exists {
+		sample = &Sample{Labels: make(Labels, len(labels))}
+		for name, value := range labels {
+			sample.Labels[name] = value
+		}
+		c.samples[key] = sample
+	}
+
+	sample.Value++
+}
+
+// collect return the samples sorted by labels so the output is stable
+func (c *Counter) collect(ctx context.Context) ([]Sample, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]string, 0, len(c.samples))
+	for key := range c.samples {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	samples := make([]Sample, 0, len(keys))
+	for _, key := range keys {
+		samples = append(samples, *c.samples[key])
+	}
+
+	return samples, nil
+}
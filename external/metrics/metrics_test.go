@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Write(t *testing.T) {
+	registry := NewRegistry()
+
+	registry.GaugeFunc("brick_queue_size", "number of items in the queue", func(ctx context.Context) ([]Sample, error) {
+		return []Sample{
+			{Labels: Labels{"reason": "NOT_FOUND", "kind": "a"}, Value: 3},
+			{Labels: Labels{"reason": "INVALID"}, Value: 0.5},
+		}, nil
+	})
+	registry.GaugeFunc("brick_broken", "failed to be collected", func(ctx context.Context) ([]Sample, error) {
+		return nil, errors.New("database is down")
+	})
+	registry.GaugeFunc("brick_up", "application is running", func(ctx context.Context) ([]Sample, error) {
+		return []Sample{{Value: 1}}, nil
+	})
+
+	var out bytes.Buffer
+	err := registry.Write(context.TODO(), &out)
+	assert.NoError(t, err)
+	assert.Equal(t, `# HELP brick_queue_size number of items in the queue
+# TYPE brick_queue_size gauge
+brick_queue_size{kind="a",reason="NOT_FOUND"} 3
+brick_queue_size{reason="INVALID"} 0.5
+# HELP brick_up application is running
+# TYPE brick_up gauge
+brick_up 1
+`, out.String())
+
+	assert.Panics(t, func() {
+		registry.GaugeFunc("brick_up", "registered twice", nil)
+	})
+}
+
+func TestRegistry_Counter(t *testing.T) {
+	registry := NewRegistry()
+
+	counter := registry.Counter("brick_requests_total", "number of requests")
+	counter.Inc(Labels{"route": "/disbursement", "client_id": "client-2"})
+	counter.Inc(Labels{"route": "/disbursement", "client_id": "client-1"})
+	counter.Inc(Labels{"client_id": "client-2", "route": "/disbursement"})
+
+	var out bytes.Buffer
+	err := registry.Write(context.TODO(), &out)
+	assert.NoError(t, err)
+	assert.Equal(t, `# HELP brick_requests_total number of requests
+# TYPE brick_requests_total counter
+brick_requests_total{client_id="client-1",route="/disbursement"} 1
+brick_requests_total{client_id="client-2",route="/disbursement"} 2
+`, out.String())
+}
@@ -0,0 +1,29 @@
+package migration
+
+import (
+	"context"
+	"time"
+)
+
+// Migration is a single versioned schema change
+type Migration struct {
+	Version int64
+	Name    string
+	UpSQL   string
+	DownSQL string
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+type Migrator interface {
+	// Up apply all pending migrations and return the applied ones
+	Up(ctx context.Context) ([]Migration, error)
+	// Down revert the latest applied migrations up to steps and return the reverted ones
+	Down(ctx context.Context, steps int) ([]Migration, error)
+	Status(ctx context.Context) ([]MigrationStatus, error)
+}
@@ -0,0 +1,226 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// arbitrary key so only one instance run the migrations at the same time
+const advisoryLockKey int64 = 7316528011
+
+const (
+	queryCreateMigrationTable = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint NOT NULL,
+		name varchar NOT NULL,
+		applied_at timestamp NOT NULL,
+		CONSTRAINT schema_migrations_pk PRIMARY KEY (version)
+	)`
+
+	querySelectAppliedMigrations = `
+	SELECT
+		version,
+		applied_at
+	FROM
+		schema_migrations`
+
+	queryInsertMigration = `
+	INSERT INTO
+		schema_migrations
+		(version, name, applied_at)
+	VALUES
+		($1, $2, CURRENT_TIMESTAMP)`
+
+	queryDeleteMigration = `
+	DELETE FROM
+		schema_migrations
+	WHERE
+		version = $1`
+)
+
+type MigratorOpts struct {
+	DB     *sqlx.DB
+	Source fs.FS
+}
+
+type postgresMigrator struct {
+	db     *sqlx.DB
+	source fs.FS
+}
+
+func NewPostgresMigrator(opts MigratorOpts) *postgresMigrator {
+	return &postgresMigrator{
+		db:     opts.DB,
+		source: opts.Source,
+	}
+}
+
+func (pm postgresMigrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+
+	err := pm.withLock(ctx, func(conn *sqlx.Conn, migrations []Migration, appliedAt map[int64]time.Time) error {
+		for _, migration := range migrations {
+			if _, exists := appliedAt[migration.Version]; exists {
+				continue
+			}
+
+			log.Printf("applying migration %d_%s", migration.Version, migration.Name)
+
+			err := runInTx(ctx, conn, migration.UpSQL, queryInsertMigration, migration.Version, migration.Name)
+			if err != nil {
+				return fmt.Errorf("error applying migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+func (pm postgresMigrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+
+	err := pm.withLock(ctx, func(conn *sqlx.Conn, migrations []Migration, appliedAt map[int64]time.Time) error {
+		for i := len(migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := migrations[i]
+			if _, exists := appliedAt[migration.Version]; !exists {
+				continue
+			}
+
+			if migration.DownSQL == "" {
+				return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+
+			log.Printf("reverting migration %d_%s", migration.Version, migration.Name)
+
+			err := runInTx(ctx, conn, migration.DownSQL, queryDeleteMigration, migration.Version)
+			if err != nil {
+				return fmt.Errorf("error reverting migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+func (pm postgresMigrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var res []MigrationStatus
+
+	err := pm.withLock(ctx, func(conn *sqlx.Conn, migrations []Migration, appliedAt map[int64]time.Time) error {
+		for _, migration := range migrations {
+			status := MigrationStatus{
+				Version: migration.Version,
+				Name:    migration.Name,
+			}
+
+			if at, exists := appliedAt[migration.Version]; exists {
+				status.Applied = true
+				status.AppliedAt = &at
+			}
+
+			res = append(res, status)
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+// withLock hold postgres advisory lock on a dedicated connection while running handler
+func (pm postgresMigrator) withLock(
+	ctx context.Context,
+	handler func(conn *sqlx.Conn, migrations []Migration, appliedAt map[int64]time.Time) error,
+) error {
+	migrations, err := loadMigrations(pm.source)
+	if err != nil {
+		return err
+	}
+
+	conn, err := pm.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockKey)
+	if err != nil {
+		return fmt.Errorf("error acquiring migration lock: %w", err)
+	}
+
+	defer func() {
+		// use new context so the lock still released when ctx cancelled
+		_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", advisoryLockKey)
+		if err != nil {
+			log.Println("error releasing migration lock:", err)
+		}
+	}()
+
+	_, err = conn.ExecContext(ctx, queryCreateMigrationTable)
+	if err != nil {
+		return err
+	}
+
+	rows, err := conn.QueryContext(ctx, querySelectAppliedMigrations)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	appliedAt := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var at time.Time
+
+		err = rows.Scan(&version, &at)
+		if err != nil {
+			return err
+		}
+
+		appliedAt[version] = at
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	// release the connection before running the migrations on it
+	rows.Close()
+
+	return handler(conn, migrations, appliedAt)
+}
+
+func runInTx(ctx context.Context, conn *sqlx.Conn, migrationSQL string, bookkeepingQuery string, args ...interface{}) error {
+	tx, err := conn.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, migrationSQL)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, bookkeepingQuery, args...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
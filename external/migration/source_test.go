@@ -0,0 +1,71 @@
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/nobbyphala/Brick/migrations"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loadMigrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  fstest.MapFS
+		want    []Migration
+		wantErr bool
+	}{
+		{
+			name: "ordered by version",
+			source: fstest.MapFS{
+				"0002_add_column.up.sql":   {Data: []byte("ALTER 2")},
+				"0002_add_column.down.sql": {Data: []byte("REVERT 2")},
+				"0001_create_table.up.sql": {Data: []byte("CREATE 1")},
+				"0010_add_index.up.sql":    {Data: []byte("CREATE INDEX 10")},
+				"README.md":                {Data: []byte("ignored")},
+			},
+			want: []Migration{
+				{Version: 1, Name: "create_table", UpSQL: "CREATE 1"},
+				{Version: 2, Name: "add_column", UpSQL: "ALTER 2", DownSQL: "REVERT 2"},
+				{Version: 10, Name: "add_index", UpSQL: "CREATE INDEX 10"},
+			},
+		},
+		{
+			name: "missing up file",
+			source: fstest.MapFS{
+				"0001_create_table.down.sql": {Data: []byte("DROP")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "same version different name",
+			source: fstest.MapFS{
+				"0001_create_table.up.sql": {Data: []byte("CREATE")},
+				"0001_other_name.down.sql": {Data: []byte("DROP")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadMigrations(tt.source)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_loadMigrations_embedded(t *testing.T) {
+	got, err := loadMigrations(migrations.FS)
+	assert.NoError(t, err)
+
+	for i, migration := range got {
+		assert.Equal(t, int64(i+1), migration.Version, "migration version should be sequential")
+		assert.NotEmpty(t, migration.DownSQL, "migration %d should have down file", migration.Version)
+	}
+}
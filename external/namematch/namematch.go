@@ -0,0 +1,134 @@
+package namematch
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	// words less similar than this are different words, one typo in a word of four letters is still the same word
+	minWordSimilarity = 0.75
+	// an initial such as the M of M. Rizki match the word it abbreviate
+	initialSimilarity = 0.8
+)
+
+type Matcher struct{}
+
+func NewMatcher() Matcher {
+	return Matcher{}
+}
+
+func (Matcher) Score(name string, other string) int {
+	return Score(name, other)
+}
+
+// Score return how similar two names are, from 0 for unrelated names to 100 for the same name after Normalize.
+// Words are compared regardless of their order, so a missing word lower the score less than a different one:
+// "Budi Santoso" score 80 against "Budi Santoso Wibowo" but 50 against "Budi Hartono"
+func Score(name string, other string) int {
+	words, otherWords := strings.Fields(Normalize(name)), strings.Fields(Normalize(other))
+	if len(words) == 0 || len(otherWords) == 0 {
+		return 0
+	}
+
+	score := wordsSimilarity(words, otherWords)
+
+	// the same name with or without space, Nur Aini and Nuraini
+	joined := similarity(strings.Join(words, ""), strings.Join(otherWords, ""))
+	if joined >= minWordSimilarity && joined > score {
+		score = joined
+	}
+
+	return int(math.Round(score * 100))
+}
+
+// wordsSimilarity pair every word with its most similar word of the other name, a word is paired at most once.
+// Return the dice coefficient of the paired words so unpaired words of both names lower the score
+func wordsSimilarity(words []string, otherWords []string) float64 {
+	type pair struct {
+		word, otherWord int
+		similarity      float64
+	}
+
+	var pairs []pair
+	for i, word := range words {
+		for j, otherWord := range otherWords {
+			wordSimilarity := similarity(word, otherWord)
+			if wordSimilarity >= minWordSimilarity {
+				pairs = append(pairs, pair{word: i, otherWord: j, similarity: wordSimilarity})
+			}
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].similarity > pairs[j].similarity
+	})
+
+	paired := make(map[int]bool)
+	otherPaired := make(map[int]bool)
+	var total float64
+
+	for _, p := range pairs {
+		if paired[p.word] || otherPaired[p.otherWord] {
+			continue
+		}
+
+		paired[p.word] = true
+		otherPaired[p.otherWord] = true
+		total += p.similarity
+	}
+
+	return 2 * total / float64(len(words)+len(otherWords))
+}
+
+// similarity is 1 for equal words down to 0 based on the number of edits between them
+func similarity(word string, other string) float64 {
+	if word == other {
+		return 1
+	}
+
+	runes, otherRunes := []rune(word), []rune(other)
+	if (len(runes) == 1 || len(otherRunes) == 1) && runes[0] == otherRunes[0] {
+		return initialSimilarity
+	}
+
+	longest := len(runes)
+	if len(otherRunes) > longest {
+		longest = len(otherRunes)
+	}
+
+	return 1 - float64(levenshtein(runes, otherRunes))/float64(longest)
+}
+
+func levenshtein(runes []rune, other []rune) int {
+	previous := make([]int, len(other)+1)
+	current := make([]int, len(other)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(runes); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(other); j++ {
+			cost := 1
+			if runes[i-1] == other[j-1] {
+				cost = 0
+			}
+
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(other)]
+}
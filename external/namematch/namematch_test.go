@@ -0,0 +1,61 @@
+package namematch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "BUDI SANTOSO", want: "budi santoso"},
+		{name: "H. Moch. Djoko Santoso, S.Kom., M.M.", want: "muhamad joko santoso"},
+		{name: "Santoso, Djoko", want: "santoso joko"},
+		{name: "Santoso, Djoko, S.E. M.M.", want: "santoso joko"},
+		{name: "Wibowo, Dr. Budi", want: "wibowo budi"},
+		{name: "Budi Santoso, S. Kom", want: "budi santoso"},
+		{name: "Bpk. Ir. Soekarno", want: "sukarno"},
+		{name: "Tjahjo Kumolo", want: "cahjo kumolo"},
+		{name: "Achmad Taufiq Ramadhan", want: "ahmad taufik ramadan"},
+		{name: "Ma'ruf Amin", want: "maruf amin"},
+		{name: "Fenny Yanty", want: "feni yanti"},
+		{name: "Hassan-Basri", want: "hasan basri"},
+		{name: "H. Budi", want: "h budi"},
+		{name: "Ibu", want: "ibu"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Normalize(tt.name))
+		})
+	}
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		other string
+		want  int
+	}{
+		{name: "Budi Santoso", other: "BUDI SANTOSO", want: 100},
+		{name: "Santoso Budi", other: "Budi Santoso", want: 100},
+		{name: "H. Moch. Djoko Santoso, S.Kom", other: "Muhammad Joko Santoso", want: 100},
+		{name: "Santoso, Djoko", other: "Djoko Santoso", want: 100},
+		{name: "Nur Aini", other: "Nuraini", want: 100},
+		{name: "Budi Santosa", other: "Budi Santoso", want: 93},
+		{name: "M. Rizki", other: "Muhammad Rizki", want: 90},
+		{name: "Budi Santoso", other: "Budi Santoso Wibowo", want: 80},
+		{name: "Budi", other: "Budi Santoso", want: 67},
+		{name: "Budi Santoso", other: "Budi Hartono", want: 50},
+		{name: "Sri Wahyuni", other: "Siti Rahayu", want: 0},
+		{name: "Budi Santoso", other: "", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.other, func(t *testing.T) {
+			assert.Equal(t, tt.want, Score(tt.name, tt.other))
+			assert.Equal(t, tt.want, Score(tt.other, tt.name), "score is symmetric")
+		})
+	}
+}
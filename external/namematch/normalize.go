@@ -0,0 +1,169 @@
+package namematch
+
+import (
+	"strings"
+	"unicode"
+)
+
+// honorifics, religious and academic titles are written in front of the name but are not part of the name
+// registered at the bank
+var titles = map[string]bool{
+	"bapak": true, "bpk": true, "pak": true, "ibu": true, "bu": true, "tuan": true, "tn": true, "nyonya": true,
+	"ny": true, "nona": true, "nn": true, "saudara": true, "sdr": true, "saudari": true, "sdri": true, "mr": true,
+	"mrs": true, "ms": true, "haji": true, "hj": true, "hajah": true, "hajjah": true, "kh": true, "ustadz": true,
+	"dr": true, "drs": true, "dra": true, "ir": true, "prof": true, "alm": true, "almh": true,
+}
+
+// academic degrees written after the name following a comma, compared without dots
+var degrees = map[string]bool{
+	"se": true, "sh": true, "st": true, "sp": true, "ssi": true, "skom": true, "spd": true, "ssos": true, "sked": true,
+	"sip": true, "sag": true, "sfarm": true, "skm": true, "spsi": true, "sth": true, "ak": true, "amd": true,
+	"mm": true, "mh": true, "mt": true, "msi": true, "mkom": true, "mpd": true, "mba": true, "msc": true, "mag": true,
+	"dr": true, "drs": true, "dra": true, "ir": true, "phd": true, "bsc": true, "ba": true, "ma": true, "md": true,
+}
+
+// old spelling still used in names (Soekarno, Djoko, Tjahjo) and letters written differently for the same sound
+// (Achmad, Khairul, Taufiq, Ramadhan) are written the same way
+var spelling = strings.NewReplacer(
+	"oe", "u",
+	"dj", "j",
+	"tj", "c",
+	"sj", "sy",
+	"nj", "ny",
+	"ch", "h",
+	"kh", "h",
+	"dh", "d",
+	"th", "t",
+	"ph", "f",
+	"q", "k",
+)
+
+// common abbreviations and spellings of the same word, after the spelling and double letters are normalized
+var variants = map[string]string{
+	"mohamad": "muhamad",
+	"muhamat": "muhamad",
+	"mohamat": "muhamad",
+	"mohd":    "muhamad",
+	"moh":     "muhamad",
+	"muh":     "muhamad",
+	"mhd":     "muhamad",
+	"md":      "muhamad",
+	"abd":     "abdul",
+	"nurul":   "nur",
+}
+
+// Normalize lower case the name and remove titles, punctuation and spelling differences so the same name written
+// in different ways become equal. Academic degrees after a comma are removed, for example "H. Moch. Djoko Santoso,
+// S.Kom" become "muhamad joko santoso", the rest of the name is kept so "Santoso, Djoko" become "santoso joko"
+func Normalize(name string) string {
+	name = removeDegrees(name)
+
+	words := strings.Fields(strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r):
+			return unicode.ToLower(r)
+		case r == '\'' || r == '`' || r == '’':
+			// Ma'ruf and Maruf are the same name
+			return -1
+		default:
+			return ' '
+		}
+	}, name))
+
+	return strings.Join(normalizeWords(words), " ")
+}
+
+// removeDegrees remove the parts after a comma that are only academic degrees
+func removeDegrees(name string) string {
+	parts := strings.Split(name, ",")
+	kept := parts[:1]
+
+	for _, part := range parts[1:] {
+		if !isDegree(part) {
+			kept = append(kept, part)
+		}
+	}
+
+	return strings.Join(kept, " ")
+}
+
+// isDegree report whether the part is a degree, written with or without dots and spaces like "S.Kom" or "S. Kom",
+// or several degrees like "S.E. M.M"
+func isDegree(part string) bool {
+	letters := func(word string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		}, word)
+	}
+
+	if compact := letters(part); compact == "" || degrees[compact] {
+		return true
+	}
+
+	for _, word := range strings.Fields(part) {
+		if compact := letters(word); compact != "" && !degrees[compact] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func normalizeWords(words []string) []string {
+	res := make([]string, 0, len(words))
+
+	for i, word := range words {
+		if titles[word] {
+			continue
+		}
+
+		// H. in front of the name is haji, keep it when it may be the initial of a short name
+		if i == 0 && word == "h" && len(words) > 2 {
+			continue
+		}
+
+		res = append(res, normalizeWord(word))
+	}
+
+	// the whole name is a title, compare it as written
+	if len(res) == 0 {
+		for _, word := range words {
+			res = append(res, normalizeWord(word))
+		}
+	}
+
+	return res
+}
+
+func normalizeWord(word string) string {
+	word = squeeze(spelling.Replace(word))
+
+	// Yanty and Yanti, Fenny and Feni
+	if len(word) > 2 && strings.HasSuffix(word, "y") && !strings.ContainsRune("aiueo", rune(word[len(word)-2])) {
+		word = word[:len(word)-1] + "i"
+	}
+
+	if variant, found := variants[word]; found {
+		return variant
+	}
+
+	return word
+}
+
+// squeeze remove repeated letters, Hassan and Hasan, Muhammad and Muhamad
+func squeeze(word string) string {
+	var builder strings.Builder
+	var previous rune
+
+	for _, r := range word {
+		if r != previous {
+			builder.WriteRune(r)
+		}
+		previous = r
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,152 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/nobbyphala/Brick/external/validator"
+)
+
+// field of the errors that are not about a single parameter or body property
+const bodyField = "body"
+
+// RequestValidator check requests against an OpenAPI 3 document
+type RequestValidator interface {
+	// Validate return nil when the request match its operation or the document has no operation for the request
+	Validate(req *http.Request) []validator.ValidatorError
+}
+
+type requestValidator struct {
+	router routers.Router
+}
+
+// the sanctions list import take the file as the request body, it is checked against a string schema
+func init() {
+	openapi3filter.RegisterBodyDecoder("text/csv", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("application/xml", openapi3filter.FileBodyDecoder)
+}
+
+// Load parse the document and check it is a valid OpenAPI 3 document
+func Load(spec []byte) (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing openapi document: %w", err)
+	}
+
+	err = doc.Validate(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("invalid openapi document: %w", err)
+	}
+
+	return doc, nil
+}
+
+func NewRequestValidator(doc *openapi3.T) (*requestValidator, error) {
+	router, err := gorillamux.NewRouter(withOperationServers(doc))
+	if err != nil {
+		return nil, err
+	}
+
+	return &requestValidator{
+		router: router,
+	}, nil
+}
+
+// withOperationServers return a copy of the document also listing the servers of the operations, gorillamux only
+// route the requests under the servers of the document
+func withOperationServers(doc *openapi3.T) *openapi3.T {
+	routed := *doc
+	routed.Servers = append(openapi3.Servers(nil), doc.Servers...)
+
+	for _, pathItem := range doc.Paths {
+		for _, operation := range pathItem.Operations() {
+			if operation.Servers == nil {
+				continue
+			}
+
+			for _, server := range *operation.Servers {
+				if !hasServer(routed.Servers, server.URL) {
+					routed.Servers = append(routed.Servers, server)
+				}
+			}
+		}
+	}
+
+	return &routed
+}
+
+func hasServer(servers openapi3.Servers, url string) bool {
+	for _, server := range servers {
+		if server.URL == url {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate does not check security requirement, authentication is done by the application.
+// The request body is restored so it can be read again
+func (rv requestValidator) Validate(req *http.Request) []validator.ValidatorError {
+	route, pathParams, err := rv.router.FindRoute(req)
+	if err != nil {
+		return nil
+	}
+
+	err = openapi3filter.ValidateRequest(req.Context(), &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			MultiError:         true,
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		},
+	})
+	if err != nil {
+		return toValidatorErrors(err, bodyField)
+	}
+
+	return nil
+}
+
+// toValidatorErrors flatten the nested validation errors, field is the dot separated path of the invalid property
+func toValidatorErrors(err error, field string) []validator.ValidatorError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var res []validator.ValidatorError
+		for _, item := range e {
+			res = append(res, toValidatorErrors(item, field)...)
+		}
+		return res
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			field = e.Parameter.Name
+		}
+
+		switch e.Err.(type) {
+		case openapi3.MultiError, *openapi3.SchemaError:
+			return toValidatorErrors(e.Err, field)
+		}
+
+		return []validator.ValidatorError{{Field: field, Error: e.Error()}}
+	case *openapi3.SchemaError:
+		if path := e.JSONPointer(); len(path) > 0 {
+			field = strings.Join(path, ".")
+		}
+
+		reason := e.Reason
+		if reason == "" {
+			reason = fmt.Sprintf("doesn't match schema %q", e.SchemaField)
+		}
+
+		return []validator.ValidatorError{{Field: field, Error: reason}}
+	default:
+		return []validator.ValidatorError{{Field: field, Error: err.Error()}}
+	}
+}
@@ -0,0 +1,13 @@
+package openapi
+
+import (
+	"net/http"
+
+	swaggerFiles "github.com/swaggo/files"
+)
+
+// SwaggerUIAssets serve the Swagger UI scripts and styles, for example /swagger-ui-bundle.js.
+// The index page is not included since it has to point to the document of the application
+func SwaggerUIAssets() http.Handler {
+	return http.FileServer(swaggerFiles.HTTP)
+}
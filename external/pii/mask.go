@@ -0,0 +1,34 @@
+package pii
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maskChar          = "*"
+	visibleLastDigits = 4
+)
+
+// MaskAccountNumber keep only the last 4 characters, for example 1234567890 become ******7890
+func MaskAccountNumber(accountNumber string) string {
+	length := utf8.RuneCountInString(accountNumber)
+	if length <= visibleLastDigits {
+		return strings.Repeat(maskChar, length)
+	}
+
+	runes := []rune(accountNumber)
+	return strings.Repeat(maskChar, length-visibleLastDigits) + string(runes[length-visibleLastDigits:])
+}
+
+// MaskName keep only the first letter of every word, for example Nobby Phala become N**** P****
+func MaskName(name string) string {
+	words := strings.Fields(name)
+
+	for i, word := range words {
+		runes := []rune(word)
+		words[i] = string(runes[0]) + strings.Repeat(maskChar, len(runes)-1)
+	}
+
+	return strings.Join(words, " ")
+}
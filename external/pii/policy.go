@@ -0,0 +1,24 @@
+package pii
+
+// Policy decide whether personal data is shown in full or masked based on the caller role
+type Policy struct {
+	unmaskedRoles map[string]bool
+}
+
+// NewPolicy create policy where only the given roles can see unmasked data, everyone else including
+// unauthenticated caller get masked data
+func NewPolicy(unmaskedRoles ...string) Policy {
+	policy := Policy{
+		unmaskedRoles: make(map[string]bool, len(unmaskedRoles)),
+	}
+
+	for _, role := range unmaskedRoles {
+		policy.unmaskedRoles[role] = true
+	}
+
+	return policy
+}
+
+func (p Policy) ShouldMask(role string) bool {
+	return !p.unmaskedRoles[role]
+}
@@ -0,0 +1,61 @@
+package pii
+
+import (
+	"io"
+	"regexp"
+)
+
+// fields that contain account number or name, both in json (snake case) and in go formatted struct (camel case)
+var (
+	jsonAccountNumberPattern   = regexp.MustCompile(`("(?:recipient_account_number|account_holder_number|account_number)"\s*:\s*")([^"]*)(")`)
+	jsonNamePattern            = regexp.MustCompile(`("(?:recipient_name|account_holder_name)"\s*:\s*")([^"]*)(")`)
+	structAccountNumberPattern = regexp.MustCompile(`((?:RecipientAccountNumber|AccountHolderNumber|AccountNumber):"?)([^\s",}]*)`)
+	structQuotedNamePattern    = regexp.MustCompile(`((?:RecipientName|AccountHolderName):")([^"]*)(")`)
+	// unquoted name may contain spaces, it ends at the next field or the end of the struct
+	structNamePattern = regexp.MustCompile(`((?:RecipientName|AccountHolderName):)([^"].*?)(\s\w+:|}|$)`)
+)
+
+// Redact mask account numbers and names found in text such as log message
+func Redact(text string) string {
+	text = replaceGroup(jsonAccountNumberPattern, text, MaskAccountNumber)
+	text = replaceGroup(jsonNamePattern, text, MaskName)
+	text = replaceGroup(structAccountNumberPattern, text, MaskAccountNumber)
+	text = replaceGroup(structQuotedNamePattern, text, MaskName)
+	text = replaceGroup(structNamePattern, text, MaskName)
+
+	return text
+}
+
+// replaceGroup apply mask to the second capture group of every match
+func replaceGroup(pattern *regexp.Regexp, text string, mask func(string) string) string {
+	return pattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := pattern.FindStringSubmatch(match)
+
+		res := groups[1] + mask(groups[2])
+		if len(groups) > 3 {
+			res += groups[3]
+		}
+
+		return res
+	})
+}
+
+type redactingWriter struct {
+	writer io.Writer
+}
+
+// NewRedactingWriter return writer that redact personal data before writing to w, use it as log output
+func NewRedactingWriter(w io.Writer) io.Writer {
+	return &redactingWriter{
+		writer: w,
+	}
+}
+
+func (rw redactingWriter) Write(p []byte) (int, error) {
+	_, err := rw.writer.Write([]byte(Redact(string(p))))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
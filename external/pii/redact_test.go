@@ -0,0 +1,77 @@
+package pii
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	type account struct {
+		RecipientName          string
+		RecipientAccountNumber string
+		Amount                 int64
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "json body",
+			text: `{"account_holder_name":"Nobby Phala","account_holder_number": "1234567890","amount":1000}`,
+			want: `{"account_holder_name":"N**** P****","account_holder_number": "******7890","amount":1000}`,
+		},
+		{
+			name: "go struct",
+			text: fmt.Sprintf("%+v", account{RecipientName: "Nobby Phala", RecipientAccountNumber: "1234567890", Amount: 1000}),
+			want: "{RecipientName:N**** P**** RecipientAccountNumber:******7890 Amount:1000}",
+		},
+		{
+			name: "go struct with quoted value",
+			text: fmt.Sprintf("%#v", account{RecipientName: "Nobby Phala", RecipientAccountNumber: "1234567890"}),
+			want: `pii.account{RecipientName:"N**** P****", RecipientAccountNumber:"******7890", Amount:0}`,
+		},
+		{
+			name: "name at the end of struct",
+			text: "{AccountHolderNumber:987654 AccountHolderName:Nobby Phala}",
+			want: "{AccountHolderNumber:**7654 AccountHolderName:N**** P****}",
+		},
+		{
+			name: "no personal data",
+			text: "error disbursement not found txn-id-1",
+			want: "error disbursement not found txn-id-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Redact(tt.text))
+		})
+	}
+}
+
+func TestNewRedactingWriter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(NewRedactingWriter(&buf), "", 0)
+
+	logger.Println(`bank request: {"account_holder_number":"1234567890"}`)
+
+	assert.Equal(t, `bank request: {"account_holder_number":"******7890"}`+"\n", buf.String())
+}
+
+func TestMask(t *testing.T) {
+	assert.Equal(t, "******7890", MaskAccountNumber("1234567890"))
+	assert.Equal(t, "***", MaskAccountNumber("123"))
+	assert.Equal(t, "", MaskAccountNumber(""))
+	assert.Equal(t, "N**** P****", MaskName("Nobby  Phala"))
+	assert.Equal(t, "", MaskName(""))
+
+	policy := NewPolicy("admin")
+	assert.False(t, policy.ShouldMask("admin"))
+	assert.True(t, policy.ShouldMask("client"))
+	assert.True(t, policy.ShouldMask(""))
+}
@@ -0,0 +1,24 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// Periodic return worker function that call fn every interval until ctx cancelled
+func Periodic(interval time.Duration, fn func(ctx context.Context)) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			fn(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// Group run background workers and stop them together when the application shutting down
+
+type Group interface {
+	// Go run fn in background. ctx passed to fn will be cancelled when Shutdown called
+	Go(name string, fn func(ctx context.Context))
+	// Shutdown cancel all workers and wait until all of them return or ctx expired
+	Shutdown(ctx context.Context) error
+}
+
+type group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewGroup() *group {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &group{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (g *group) Go(name string, fn func(ctx context.Context)) {
+	g.wg.Add(1)
+
+	go func() {
+		defer g.wg.Done()
+
+		log.Println("worker started:", name)
+		fn(g.ctx)
+		log.Println("worker stopped:", name)
+	}()
+}
+
+func (g *group) Shutdown(ctx context.Context) error {
+	g.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -1,65 +1,614 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"flag"
 	"github.com/gin-gonic/gin"
+	grpc_api "github.com/nobbyphala/Brick/adapter/grpc"
 	"github.com/nobbyphala/Brick/adapter/rest_api"
 	"github.com/nobbyphala/Brick/config"
-	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/grpc_server"
 	"github.com/nobbyphala/Brick/external/http_request"
+	"github.com/nobbyphala/Brick/external/http_server"
+	"github.com/nobbyphala/Brick/external/metrics"
+	"github.com/nobbyphala/Brick/external/namematch"
+	"github.com/nobbyphala/Brick/external/pii"
+	"github.com/nobbyphala/Brick/external/worker"
 	"github.com/nobbyphala/Brick/usecase"
 	"github.com/nobbyphala/Brick/usecase/api"
-	"github.com/nobbyphala/Brick/usecase/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"io"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
 func main() {
-	// init driver or framework
-	db, err := database.NewPostgresDB(database.ConnectionOption{
-		Host:     config.DB_HOST,
-		Port:     config.DB_PORT,
-		User:     config.DB_USER,
-		Password: config.DB_PASSWORD,
-		Database: config.DB_DATABASE,
-	})
+	// redact account numbers and names from every log line
+	log.SetOutput(pii.NewRedactingWriter(os.Stderr))
+	gin.DefaultErrorWriter = pii.NewRedactingWriter(gin.DefaultErrorWriter)
+
+	cfg, args, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
 		log.Panicln(err)
 	}
 
-	postgresSql := database.NewPostgresSqlClient(database.PostgresSQLOpts{
-		DB: db,
-	})
+	log.Println("effective config:", cfg)
 
-	// repository
-	disbursementRepository := repository.NewDisbursement(repository.DisbursementDeps{
-		DB: postgresSql,
-	})
-	utilsRepository := repository.NewRepositoryUtils(repository.UtilsOpts{
-		DB: db,
-	})
+	if len(args) > 0 && args[0] == "migrate" {
+		err = runMigrateCommand(context.Background(), cfg.Database, args[1:])
+		if err != nil {
+			log.Panicln(err)
+		}
+		return
+	}
+
+	// init driver or framework and repository
+	encryptor, err := newFieldEncryptor(cfg.Encryption)
+	if err != nil {
+		log.Panicln(err)
+	}
+
+	if len(args) > 0 && args[0] == "apikey" {
+		err = runApiKeyCommand(context.Background(), cfg.Database, encryptor, args[1:])
+		if err != nil {
+			log.Panicln(err)
+		}
+		return
+	}
+
+	repos, err := newRepositories(cfg.Database, encryptor)
+	if err != nil {
+		log.Panicln(err)
+	}
 
 	// api
-	bankApi := api.NewBankApiClient(api.BankApiClientOpts{
-		BaseUrl:     config.BankBaseURL,
+	var bankApi api.Bank = api.NewBankApiClient(api.BankApiClientOpts{
+		BaseUrl:     cfg.Bank.BaseURL,
+		HttpRequest: http_request.NewHttpRequest(),
+		DebugLog:    cfg.Bank.DebugLog,
+	})
+
+	verificationCache := newVerificationCache(cfg.Verification, repos)
+	if verificationCache != nil {
+		bankApi = usecase.NewCachedBank(usecase.CachedBankDeps{
+			Bank:              bankApi,
+			VerificationCache: verificationCache,
+			PositiveTTL:       cfg.Verification.CachePositiveTTL.Duration(),
+			NegativeTTL:       cfg.Verification.CacheNegativeTTL.Duration(),
+		})
+	}
+
+	webhookApi := api.NewWebhookClient(api.WebhookClientOpts{
 		HttpRequest: http_request.NewHttpRequest(),
+		Timeout:     cfg.Webhook.RequestTimeout.Duration(),
 	})
 
+	eventPublisher, eventPublisherCloser, err := newEventPublisher(cfg.Events)
+	if err != nil {
+		log.Panicln(err)
+	}
+	defer eventPublisherCloser.Close()
+
+	verificationTokenSecret, err := newVerificationTokenSecret(cfg.Verification)
+	if err != nil {
+		log.Panicln(err)
+	}
+
 	// usecase
+	nameMatcher := namematch.NewMatcher()
+
+	// the sanctions lists and the internal blocklist are kept in memory, every recipient is screened before transfer
+	screener := usecase.NewScreener(usecase.ScreenerDeps{
+		ScreeningEntryRepository: repos.screeningEntry,
+		NameMatcher:              nameMatcher,
+		NameMatchThreshold:       cfg.Screening.NameMatchThreshold,
+	})
+
+	err = screener.Reload(context.Background())
+	if err != nil {
+		log.Panicln(err)
+	}
+
 	disbursementUsecase := usecase.NewDisbursement(usecase.DisbursementDeps{
-		BankApi:                bankApi,
-		UtilsRepository:        utilsRepository,
-		DisbursementRepository: disbursementRepository,
+		BankApi:                   bankApi,
+		UtilsRepository:           repos.utils,
+		DisbursementRepository:    repos.disbursement,
+		MerchantRepository:        repos.merchant,
+		CallbackReviewRepository:  repos.callbackReview,
+		WebhookEndpointRepository: repos.webhookEndpoint,
+		WebhookDeliveryRepository: repos.webhookDelivery,
+		OutboxRepository:          repos.outbox,
+		VerificationTokenSecret:   verificationTokenSecret,
+		VerificationTokenTTL:      cfg.Verification.TokenTTL.Duration(),
+		NameMatcher:               nameMatcher,
+		BeneficiaryRepository:     repos.beneficiary,
+		BeneficiaryMaxAge:         cfg.Verification.BeneficiaryMaxAge.Duration(),
+		Screener:                  screener,
+		ScreeningRepository:       repos.screening,
+	})
+
+	apiKeyUsecase := usecase.NewApiKey(usecase.ApiKeyDeps{
+		ApiKeyRepository:   repos.apiKey,
+		MerchantRepository: repos.merchant,
+		UtilsRepository:    repos.utils,
+	})
+
+	merchantUsecase := usecase.NewMerchant(usecase.MerchantDeps{
+		MerchantRepository: repos.merchant,
+	})
+
+	auditUsecase := usecase.NewAudit(usecase.AuditDeps{
+		AuditLogRepository: repos.auditLog,
+	})
+
+	disbursementOperationUsecase := usecase.NewDisbursementOperation(usecase.DisbursementOperationDeps{
+		BankApi:                   bankApi,
+		DisbursementRepository:    repos.disbursement,
+		AuditLogRepository:        repos.auditLog,
+		UtilsRepository:           repos.utils,
+		WebhookEndpointRepository: repos.webhookEndpoint,
+		WebhookDeliveryRepository: repos.webhookDelivery,
+		OutboxRepository:          repos.outbox,
+	})
+
+	callbackReviewUsecase := usecase.NewCallbackReview(usecase.CallbackReviewDeps{
+		CallbackReviewRepository:  repos.callbackReview,
+		DisbursementRepository:    repos.disbursement,
+		AuditLogRepository:        repos.auditLog,
+		UtilsRepository:           repos.utils,
+		WebhookEndpointRepository: repos.webhookEndpoint,
+		WebhookDeliveryRepository: repos.webhookDelivery,
+		OutboxRepository:          repos.outbox,
+	})
+
+	webhookUsecase := usecase.NewWebhook(usecase.WebhookDeps{
+		WebhookApi:                webhookApi,
+		WebhookEndpointRepository: repos.webhookEndpoint,
+		WebhookDeliveryRepository: repos.webhookDelivery,
+		MerchantRepository:        repos.merchant,
+		AuditLogRepository:        repos.auditLog,
+		UtilsRepository:           repos.utils,
+		MaxAttempts:               cfg.Webhook.MaxAttempts,
+		RetryBaseDelay:            cfg.Webhook.RetryBaseDelay.Duration(),
+		RetryMaxDelay:             cfg.Webhook.RetryMaxDelay.Duration(),
+		// sending a batch one by one can take up to batch size times the request timeout
+		Lease: time.Duration(cfg.Webhook.DeliveryBatchSize+1) * cfg.Webhook.RequestTimeout.Duration(),
+	})
+
+	verificationCacheUsecase := usecase.NewVerificationCache(usecase.VerificationCacheDeps{
+		VerificationCache:  verificationCache,
+		AuditLogRepository: repos.auditLog,
+	})
+
+	beneficiaryUsecase := usecase.NewBeneficiary(usecase.BeneficiaryDeps{
+		BankApi:               bankApi,
+		NameMatcher:           nameMatcher,
+		BeneficiaryRepository: repos.beneficiary,
+		MerchantRepository:    repos.merchant,
+	})
+
+	screeningUsecase := usecase.NewScreening(usecase.ScreeningDeps{
+		BankApi:                   bankApi,
+		Screener:                  screener,
+		ScreeningRepository:       repos.screening,
+		ScreeningEntryRepository:  repos.screeningEntry,
+		DisbursementRepository:    repos.disbursement,
+		AuditLogRepository:        repos.auditLog,
+		UtilsRepository:           repos.utils,
+		WebhookEndpointRepository: repos.webhookEndpoint,
+		WebhookDeliveryRepository: repos.webhookDelivery,
+		OutboxRepository:          repos.outbox,
+	})
+
+	disbursementStreamUsecase := usecase.NewDisbursementStream(usecase.DisbursementStreamDeps{
+		DisbursementRepository:            repos.disbursement,
+		DisbursementStatusEventRepository: repos.disbursementStatusEvent,
+		DisbursementStatusEventListener:   repos.disbursementStatusEventListener,
+		PollInterval:                      cfg.Stream.PollInterval.Duration(),
+	})
+
+	if cfg.Auth.BootstrapKey != "" {
+		err = apiKeyUsecase.Import(context.Background(), cfg.Auth.BootstrapKey, usecase.IssueApiKeyData{
+			ClientId: cfg.Auth.BootstrapClientId,
+			Name:     "bootstrap",
+			Role:     domain.RoleAdmin,
+		})
+		if err != nil {
+			log.Panicln(err)
+		}
+	}
+
+	// usecase called by adapter check the caller permission
+	disbursementAuthorization := usecase.NewDisbursementAuthorization(usecase.DisbursementAuthorizationDeps{
+		Disbursement: disbursementUsecase,
+		AuditUsecase: auditUsecase,
+	})
+
+	disbursementStreamAuthorization := usecase.NewDisbursementStreamAuthorization(usecase.DisbursementStreamAuthorizationDeps{
+		DisbursementStream: disbursementStreamUsecase,
+		AuditUsecase:       auditUsecase,
+	})
+
+	disbursementOperationAuthorization := usecase.NewDisbursementOperationAuthorization(usecase.DisbursementOperationAuthorizationDeps{
+		DisbursementOperation: disbursementOperationUsecase,
+		AuditUsecase:          auditUsecase,
+	})
+
+	callbackReviewAuthorization := usecase.NewCallbackReviewAuthorization(usecase.CallbackReviewAuthorizationDeps{
+		CallbackReview: callbackReviewUsecase,
+		AuditUsecase:   auditUsecase,
+	})
+
+	webhookAuthorization := usecase.NewWebhookAuthorization(usecase.WebhookAuthorizationDeps{
+		Webhook:      webhookUsecase,
+		AuditUsecase: auditUsecase,
+	})
+
+	verificationCacheAuthorization := usecase.NewVerificationCacheAuthorization(usecase.VerificationCacheAuthorizationDeps{
+		VerificationCache: verificationCacheUsecase,
+		AuditUsecase:      auditUsecase,
+	})
+
+	beneficiaryAuthorization := usecase.NewBeneficiaryAuthorization(usecase.BeneficiaryAuthorizationDeps{
+		Beneficiary:  beneficiaryUsecase,
+		AuditUsecase: auditUsecase,
+	})
+
+	screeningAuthorization := usecase.NewScreeningAuthorization(usecase.ScreeningAuthorizationDeps{
+		Screening:    screeningUsecase,
+		AuditUsecase: auditUsecase,
+	})
+
+	apiKeyAuthorization := usecase.NewApiKeyAuthorization(usecase.ApiKeyAuthorizationDeps{
+		ApiKey:       apiKeyUsecase,
+		AuditUsecase: auditUsecase,
+	})
+
+	merchantAuthorization := usecase.NewMerchantAuthorization(usecase.MerchantAuthorizationDeps{
+		Merchant:     merchantUsecase,
+		AuditUsecase: auditUsecase,
+	})
+
+	auditAuthorization := usecase.NewAuditAuthorization(usecase.AuditAuthorizationDeps{
+		Audit: auditUsecase,
 	})
 
 	// controller
+	maskingPolicy := pii.NewPolicy(cfg.Masking.UnmaskedRoles...)
+
 	disbursementController := rest_api.NewDisbursementController(rest_api.DisbursementControllerDeps{
-		DisbursementUsecase: disbursementUsecase,
+		DisbursementUsecase: disbursementAuthorization,
+		MaskingPolicy:       maskingPolicy,
+	})
+
+	disbursementStreamController := rest_api.NewDisbursementStreamController(rest_api.DisbursementStreamControllerDeps{
+		DisbursementStreamUsecase: disbursementStreamAuthorization,
+		HeartbeatInterval:         cfg.Stream.HeartbeatInterval.Duration(),
+	})
+
+	disbursementOperationController := rest_api.NewDisbursementOperationController(rest_api.DisbursementOperationControllerDeps{
+		DisbursementOperationUsecase: disbursementOperationAuthorization,
+		MaskingPolicy:                maskingPolicy,
+	})
+
+	callbackReviewController := rest_api.NewCallbackReviewController(rest_api.CallbackReviewControllerDeps{
+		CallbackReviewUsecase: callbackReviewAuthorization,
+	})
+
+	webhookController := rest_api.NewWebhookController(rest_api.WebhookControllerDeps{
+		WebhookUsecase: webhookAuthorization,
+	})
+
+	verificationCacheController := rest_api.NewVerificationCacheController(rest_api.VerificationCacheControllerDeps{
+		VerificationCacheUsecase: verificationCacheAuthorization,
+	})
+
+	beneficiaryController := rest_api.NewBeneficiaryController(rest_api.BeneficiaryControllerDeps{
+		BeneficiaryUsecase: beneficiaryAuthorization,
+		MaskingPolicy:      maskingPolicy,
+	})
+
+	screeningController := rest_api.NewScreeningController(rest_api.ScreeningControllerDeps{
+		ScreeningUsecase: screeningAuthorization,
+		MaskingPolicy:    maskingPolicy,
+	})
+
+	metricsRegistry := metrics.NewRegistry()
+
+	metricsController := rest_api.NewMetricsController(rest_api.MetricsControllerDeps{
+		Registry:              metricsRegistry,
+		CallbackReviewUsecase: callbackReviewUsecase,
+	})
+
+	apiKeyController := rest_api.NewApiKeyController(rest_api.ApiKeyControllerDeps{
+		ApiKeyUsecase: apiKeyAuthorization,
+	})
+
+	merchantController := rest_api.NewMerchantController(rest_api.MerchantControllerDeps{
+		MerchantUsecase: merchantAuthorization,
+	})
+
+	auditLogController := rest_api.NewAuditLogController(rest_api.AuditLogControllerDeps{
+		AuditUsecase: auditAuthorization,
+	})
+
+	openApiController, err := rest_api.NewOpenApiController(rest_api.OpenApiControllerDeps{
+		ValidateRequests: cfg.Server.ValidateRequests,
+	})
+	if err != nil {
+		log.Panicln(err)
+	}
+
+	deprecationMiddleware := rest_api.NewDeprecationMiddleware(rest_api.DeprecationMiddlewareDeps{
+		DeprecatedAt: cfg.Api.LegacyDeprecatedAt.Time(),
+		Sunset:       cfg.Api.LegacySunset.Time(),
+		Registry:     metricsRegistry,
+	})
+
+	authMiddleware := rest_api.NewAuthMiddleware(rest_api.AuthMiddlewareDeps{
+		ApiKeyUsecase: apiKeyUsecase,
+		AuditUsecase:  auditUsecase,
 	})
 
 	// init http server
 	r := gin.Default()
 	rest_api.RegisterRouter(r, rest_api.RouteController{
-		DisbursementController: disbursementController,
+		DisbursementController:          disbursementController,
+		DisbursementStreamController:    disbursementStreamController,
+		DisbursementOperationController: disbursementOperationController,
+		CallbackReviewController:        callbackReviewController,
+		WebhookController:               webhookController,
+		VerificationCacheController:     verificationCacheController,
+		BeneficiaryController:           beneficiaryController,
+		ScreeningController:             screeningController,
+		MetricsController:               metricsController,
+		ApiKeyController:                apiKeyController,
+		MerchantController:              merchantController,
+		AuditLogController:              auditLogController,
+		OpenApiController:               openApiController,
+		AuthMiddleware:                  authMiddleware,
+		DeprecationMiddleware:           deprecationMiddleware,
+		LegacyRoutes:                    cfg.Api.LegacyRoutes,
+	})
+
+	// background workers
+	workers := worker.NewGroup()
+
+	workers.Go("listen disbursement status events", disbursementStreamUsecase.Listen)
+
+	workers.Go("deliver webhooks", worker.Periodic(cfg.Webhook.DeliveryInterval.Duration(), func(ctx context.Context) {
+		for ctx.Err() == nil {
+			count, err := webhookUsecase.DeliverDueWebhooks(ctx, cfg.Webhook.DeliveryBatchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Println("error delivering webhooks:", err)
+				}
+				return
+			}
+
+			if count > 0 {
+				log.Println("delivered webhooks:", count)
+			}
+
+			// continue right away while there is a backlog
+			if count < cfg.Webhook.DeliveryBatchSize {
+				return
+			}
+		}
+	}))
+
+	// pick up the lists changed through another instance
+	workers.Go("reload screening lists", worker.Periodic(cfg.Screening.ReloadInterval.Duration(), func(ctx context.Context) {
+		err := screener.Reload(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Println("error reloading screening lists:", err)
+		}
+	}))
+
+	if eventPublisher != nil {
+		eventRelayUsecase := usecase.NewEventRelay(usecase.EventRelayDeps{
+			Publisher:        eventPublisher,
+			OutboxRepository: repos.outbox,
+			RetryBaseDelay:   cfg.Events.RetryBaseDelay.Duration(),
+			RetryMaxDelay:    cfg.Events.RetryMaxDelay.Duration(),
+			// publishing a batch one by one can take up to batch size times the request timeout
+			Lease: time.Duration(cfg.Events.PublishBatchSize+1) * cfg.Events.RequestTimeout.Duration(),
+		})
+
+		workers.Go("publish events", worker.Periodic(cfg.Events.PublishInterval.Duration(), func(ctx context.Context) {
+			for ctx.Err() == nil {
+				count, err := eventRelayUsecase.PublishDueEvents(ctx, cfg.Events.PublishBatchSize)
+				if err != nil {
+					if ctx.Err() == nil {
+						log.Println("error publishing events:", err)
+					}
+					return
+				}
+
+				// a batch has one event per disbursement, continue right away while the next events are waiting
+				if count == 0 {
+					return
+				}
+			}
+		}))
+
+		workers.Go("delete published events", worker.Periodic(time.Hour, func(ctx context.Context) {
+			count, err := eventRelayUsecase.DeletePublishedEvents(ctx, time.Now().Add(-cfg.Events.Retention.Duration()))
+			if err != nil && ctx.Err() == nil {
+				log.Println("error deleting published events:", err)
+			}
+
+			if count > 0 {
+				log.Println("deleted published events:", count)
+			}
+		}))
+	}
+
+	if cfg.Encryption.KeyFile != "" {
+		keyRotationUsecase := usecase.NewKeyRotation(usecase.KeyRotationDeps{
+			DisbursementRepository: repos.disbursement,
+			UtilsRepository:        repos.utils,
+		})
+
+		workers.Go("re-encrypt disbursements", worker.Periodic(cfg.Encryption.ReEncryptInterval.Duration(), func(ctx context.Context) {
+			count, err := keyRotationUsecase.ReEncryptDisbursements(ctx, cfg.Encryption.ReEncryptBatchSize)
+			if err != nil && ctx.Err() == nil {
+				log.Println("error re-encrypting disbursements:", err)
+			}
+
+			if count > 0 {
+				log.Println("re-encrypted disbursements:", count)
+			}
+		}))
+	}
+
+	server := http_server.NewHttpServer(http_server.ServerOpts{
+		Address:        cfg.Server.Address,
+		Handler:        r,
+		ReadTimeout:    cfg.Server.ReadTimeout.Duration(),
+		WriteTimeout:   cfg.Server.WriteTimeout.Duration(),
+		IdleTimeout:    cfg.Server.IdleTimeout.Duration(),
+		MaxHeaderBytes: cfg.Server.MaxHeaderBytes,
+		TLSCertFile:    cfg.Server.TLSCertFile,
+		TLSKeyFile:     cfg.Server.TLSKeyFile,
 	})
 
-	r.Run("127.0.0.1:8080")
+	// streams never finish by themselves, end them so the shutdown does not wait until timeout
+	server.RegisterOnShutdown(disbursementStreamUsecase.Close)
+
+	serverErr := make(chan error, 2)
+	go func() {
+		log.Println("http server listening on", cfg.Server.Address)
+		serverErr <- server.Start()
+	}()
+
+	// the grpc api call the same authorized usecases as the REST api
+	var grpcServer grpc_server.GRPCServer
+	if cfg.Grpc.Address != "" {
+		var opts []grpc.ServerOption
+		if cfg.Grpc.TLSCertFile != "" {
+			creds, err := credentials.NewServerTLSFromFile(cfg.Grpc.TLSCertFile, cfg.Grpc.TLSKeyFile)
+			if err != nil {
+				log.Panicln(err)
+			}
+			opts = append(opts, grpc.Creds(creds))
+		}
+
+		grpcServer = grpc_server.NewGrpcServer(grpc_server.ServerOpts{
+			Address: cfg.Grpc.Address,
+			Server: grpc_api.NewServer(grpc_api.Services{
+				DisbursementServer: grpc_api.NewDisbursementServer(grpc_api.DisbursementServerDeps{
+					DisbursementUsecase:       disbursementAuthorization,
+					DisbursementStreamUsecase: disbursementStreamAuthorization,
+					MaskingPolicy:             maskingPolicy,
+				}),
+				AuthInterceptor: grpc_api.NewAuthInterceptor(grpc_api.AuthInterceptorDeps{
+					ApiKeyUsecase: apiKeyUsecase,
+				}),
+			}, opts...),
+		})
+
+		go func() {
+			log.Println("grpc server listening on", cfg.Grpc.Address)
+			serverErr <- grpcServer.Start()
+		}()
+	}
+
+	// wait until receive termination signal or the server failed to start
+	signalCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	select {
+	case <-signalCtx.Done():
+		log.Println("shutting down")
+	case err = <-serverErr:
+		if err != nil {
+			log.Println("server stopped:", err)
+		}
+	}
+
+	shutdown(cfg.Server.ShutdownTimeout.Duration(), server, grpcServer, workers, repos.closer)
+}
+
+// shutdown stop accepting new request, wait in-flight request and background workers until
+// the shutdown timeout reached then close the database connection. grpcServer is nil when the grpc api is disabled
+func shutdown(timeout time.Duration, server http_server.HTTPServer, grpcServer grpc_server.GRPCServer, workers worker.Group, db io.Closer) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// closing the http server also end the disbursement streams of the grpc api
+	err := server.Shutdown(ctx)
+	if err != nil {
+		log.Println("error shutting down http server:", err)
+	}
+
+	if grpcServer != nil {
+		err = grpcServer.Shutdown(ctx)
+		if err != nil {
+			log.Println("error shutting down grpc server:", err)
+		}
+	}
+
+	err = workers.Shutdown(ctx)
+	if err != nil {
+		log.Println("error waiting background workers:", err)
+	}
+
+	err = db.Close()
+	if err != nil {
+		log.Println("error closing database:", err)
+	}
+
+	log.Println("shutdown complete")
+}
+
+// newEventPublisher return nil publisher when the domain events are only kept in the outbox
+func newEventPublisher(cfg config.EventsConfig) (api.Publisher, io.Closer, error) {
+	switch cfg.Publisher {
+	case config.EventPublisherFile:
+		publisher, err := api.NewFilePublisher(api.FilePublisherOpts{
+			Path: cfg.FilePath,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return publisher, publisher, nil
+	case config.EventPublisherHttp:
+		return api.NewHttpPublisher(api.HttpPublisherOpts{
+			HttpRequest: http_request.NewHttpRequest(),
+			Url:         cfg.HttpUrl,
+			Timeout:     cfg.RequestTimeout.Duration(),
+		}), io.NopCloser(nil), nil
+	}
+
+	return nil, io.NopCloser(nil), nil
+}
+
+// newVerificationTokenSecret generate a random secret when none is configured, tokens are then only accepted by
+// this instance until it restart
+func newVerificationTokenSecret(cfg config.VerificationConfig) ([]byte, error) {
+	if cfg.TokenSecret != "" {
+		return []byte(cfg.TokenSecret), nil
+	}
+
+	log.Println("warning: verification.token_secret is not set, verification tokens are only valid on this instance")
+
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/nobbyphala/Brick/config"
+)
+
+// runMigrateCommand handle `migrate up`, `migrate down [steps]` and `migrate status` subcommands
+func runMigrateCommand(ctx context.Context, cfg config.DatabaseConfig, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate up | down [steps] | status")
+	}
+
+	if cfg.Driver != config.DatabaseDriverPostgres {
+		return fmt.Errorf("migrate is only supported by %s driver", config.DatabaseDriverPostgres)
+	}
+
+	db, err := newPostgresDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator := newMigrator(db)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("%d migration applied", len(applied))
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid steps %q", args[1])
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("%d migration reverted", len(reverted))
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = "applied at " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q", args[0])
+	}
+
+	return nil
+}
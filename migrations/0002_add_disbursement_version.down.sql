@@ -0,0 +1 @@
+ALTER TABLE public.disbursement DROP COLUMN IF EXISTS version;
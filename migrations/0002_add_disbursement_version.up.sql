@@ -0,0 +1,2 @@
+-- version is incremented on every update for optimistic concurrency control
+ALTER TABLE public.disbursement ADD COLUMN IF NOT EXISTS version int8 NOT NULL DEFAULT 1;
@@ -0,0 +1,5 @@
+-- encrypted rows must be decrypted before reverting this migration
+DROP INDEX IF EXISTS disbursement_recipient_account_number_bidx_idx;
+ALTER TABLE public.disbursement DROP COLUMN IF EXISTS recipient_account_number_bidx;
+ALTER TABLE public.disbursement DROP COLUMN IF EXISTS encrypted_data_key;
+ALTER TABLE public.disbursement DROP COLUMN IF EXISTS encryption_key_id;
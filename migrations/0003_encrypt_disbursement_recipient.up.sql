@@ -0,0 +1,6 @@
+-- recipient_name and recipient_account_number are encrypted by the application when encryption_key_id is not null
+ALTER TABLE public.disbursement ADD COLUMN IF NOT EXISTS encryption_key_id varchar NULL;
+ALTER TABLE public.disbursement ADD COLUMN IF NOT EXISTS encrypted_data_key varchar NULL;
+-- keyed hash of the account number for exact match lookup without decrypting
+ALTER TABLE public.disbursement ADD COLUMN IF NOT EXISTS recipient_account_number_bidx varchar NULL;
+CREATE INDEX IF NOT EXISTS disbursement_recipient_account_number_bidx_idx ON public.disbursement (recipient_account_number_bidx);
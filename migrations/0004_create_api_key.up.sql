@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS public.api_key (
+    id uuid DEFAULT uuid_generate_v4() NOT NULL,
+    client_id varchar NOT NULL,
+    name varchar NOT NULL,
+    role varchar NOT NULL,
+    -- public part of the key, used to find the key before comparing the hash
+    prefix varchar NOT NULL,
+    -- sha256 of the secret part, the key itself is never stored
+    key_hash varchar NOT NULL,
+    expires_at timestamp NULL,
+    revoked_at timestamp NULL,
+    last_used_at timestamp NULL,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    CONSTRAINT api_key_pk PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS api_key_prefix_idx ON public.api_key (prefix);
+CREATE INDEX IF NOT EXISTS api_key_client_id_idx ON public.api_key (client_id);
@@ -0,0 +1,4 @@
+ALTER TABLE public.api_key DROP COLUMN IF EXISTS merchant_id;
+DROP INDEX IF EXISTS disbursement_merchant_id_created_at_idx;
+ALTER TABLE public.disbursement DROP COLUMN IF EXISTS merchant_id;
+DROP TABLE IF EXISTS public.merchant;
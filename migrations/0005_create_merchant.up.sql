@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS public.merchant (
+    id uuid DEFAULT uuid_generate_v4() NOT NULL,
+    name varchar NOT NULL,
+    -- allowed bank codes, limits and callback url
+    settings jsonb NOT NULL DEFAULT '{}',
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    CONSTRAINT merchant_pk PRIMARY KEY (id)
+);
+
+-- disbursement created before multi tenancy has no merchant
+ALTER TABLE public.disbursement ADD COLUMN IF NOT EXISTS merchant_id uuid NULL REFERENCES public.merchant (id);
+CREATE INDEX IF NOT EXISTS disbursement_merchant_id_created_at_idx ON public.disbursement (merchant_id, created_at);
+
+-- required for client key, admin and operator keys are not bound to a merchant
+ALTER TABLE public.api_key ADD COLUMN IF NOT EXISTS merchant_id uuid NULL REFERENCES public.merchant (id);
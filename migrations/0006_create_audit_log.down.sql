@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS audit_log_actor_id_created_at_idx;
+DROP INDEX IF EXISTS audit_log_created_at_idx;
+DROP TABLE IF EXISTS public.audit_log;
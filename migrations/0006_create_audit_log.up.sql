@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS public.audit_log (
+    id uuid DEFAULT uuid_generate_v4() NOT NULL,
+    actor_id varchar NOT NULL,
+    actor_role varchar NOT NULL,
+    -- empty for action done without api key
+    api_key_id uuid NULL,
+    merchant_id uuid NULL,
+    action varchar NOT NULL,
+    resource_id varchar NOT NULL,
+    outcome varchar NOT NULL,
+    reason text NOT NULL,
+    created_at timestamp NOT NULL,
+    CONSTRAINT audit_log_pk PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON public.audit_log (created_at);
+CREATE INDEX IF NOT EXISTS audit_log_actor_id_created_at_idx ON public.audit_log (actor_id, created_at);
@@ -0,0 +1,2 @@
+-- reference to the bank document proving the transfer result, attached by operator during manual intervention
+ALTER TABLE public.disbursement ADD COLUMN IF NOT EXISTS bank_evidence_reference varchar NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS callback_review_comment_review_id_created_at_idx;
+DROP TABLE IF EXISTS public.callback_review_comment;
+DROP INDEX IF EXISTS callback_review_open_transaction_id_reason_idx;
+DROP INDEX IF EXISTS callback_review_status_created_at_idx;
+DROP TABLE IF EXISTS public.callback_review;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS public.callback_review (
+    id uuid DEFAULT uuid_generate_v4() NOT NULL,
+    transaction_id varchar NOT NULL,
+    -- empty when the callback transaction id does not match any disbursement
+    disbursement_id uuid NULL REFERENCES public.disbursement (id),
+    reason varchar NOT NULL,
+    detail text NOT NULL,
+    -- the callback as received from the bank
+    payload jsonb NOT NULL,
+    status varchar NOT NULL,
+    assignee_id varchar NULL,
+    resolved_by varchar NULL,
+    resolution_note text NULL,
+    resolved_at timestamp NULL,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    CONSTRAINT callback_review_pk PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS callback_review_status_created_at_idx ON public.callback_review (status, created_at);
+-- the bank retry the callback, keep one open review per transaction and reason
+CREATE UNIQUE INDEX IF NOT EXISTS callback_review_open_transaction_id_reason_idx ON public.callback_review (transaction_id, reason) WHERE status = 'OPEN';
+
+CREATE TABLE IF NOT EXISTS public.callback_review_comment (
+    id uuid DEFAULT uuid_generate_v4() NOT NULL,
+    review_id uuid NOT NULL REFERENCES public.callback_review (id),
+    author_id varchar NOT NULL,
+    body text NOT NULL,
+    created_at timestamp NOT NULL,
+    CONSTRAINT callback_review_comment_pk PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS callback_review_comment_review_id_created_at_idx ON public.callback_review_comment (review_id, created_at);
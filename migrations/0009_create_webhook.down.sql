@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS webhook_delivery_attempt_delivery_id_attempt_idx;
+DROP TABLE IF EXISTS public.webhook_delivery_attempt;
+DROP INDEX IF EXISTS webhook_delivery_disbursement_id_idx;
+DROP INDEX IF EXISTS webhook_delivery_merchant_id_created_at_idx;
+DROP INDEX IF EXISTS webhook_delivery_pending_next_attempt_at_idx;
+DROP TABLE IF EXISTS public.webhook_delivery;
+DROP INDEX IF EXISTS webhook_endpoint_merchant_id_idx;
+DROP TABLE IF EXISTS public.webhook_endpoint;
@@ -0,0 +1,54 @@
+CREATE TABLE IF NOT EXISTS public.webhook_endpoint (
+    id uuid DEFAULT uuid_generate_v4() NOT NULL,
+    merchant_id uuid NOT NULL REFERENCES public.merchant (id),
+    url varchar NOT NULL,
+    -- signing secret, encrypted by the application when encryption_key_id is not null
+    secret varchar NOT NULL,
+    encryption_key_id varchar NULL,
+    encrypted_data_key varchar NULL,
+    -- empty means every event type
+    event_types jsonb NOT NULL DEFAULT '[]',
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    -- deleted endpoint is kept for the delivery logs
+    deleted_at timestamp NULL,
+    CONSTRAINT webhook_endpoint_pk PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS webhook_endpoint_merchant_id_idx ON public.webhook_endpoint (merchant_id) WHERE deleted_at IS NULL;
+
+-- outbox of the events, written in the same transaction as the disbursement change
+CREATE TABLE IF NOT EXISTS public.webhook_delivery (
+    id uuid DEFAULT uuid_generate_v4() NOT NULL,
+    endpoint_id uuid NOT NULL REFERENCES public.webhook_endpoint (id),
+    merchant_id uuid NOT NULL REFERENCES public.merchant (id),
+    event_id varchar NOT NULL,
+    event_type varchar NOT NULL,
+    disbursement_id uuid NOT NULL REFERENCES public.disbursement (id),
+    payload jsonb NOT NULL,
+    status varchar NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    next_attempt_at timestamp NOT NULL,
+    last_status_code int NULL,
+    last_error text NULL,
+    delivered_at timestamp NULL,
+    replay_of uuid NULL REFERENCES public.webhook_delivery (id),
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    CONSTRAINT webhook_delivery_pk PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending_next_attempt_at_idx ON public.webhook_delivery (next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS webhook_delivery_merchant_id_created_at_idx ON public.webhook_delivery (merchant_id, created_at);
+CREATE INDEX IF NOT EXISTS webhook_delivery_disbursement_id_idx ON public.webhook_delivery (disbursement_id);
+
+CREATE TABLE IF NOT EXISTS public.webhook_delivery_attempt (
+    id uuid DEFAULT uuid_generate_v4() NOT NULL,
+    delivery_id uuid NOT NULL REFERENCES public.webhook_delivery (id),
+    attempt int NOT NULL,
+    -- null when no response received
+    status_code int NULL,
+    error text NULL,
+    duration_ms bigint NOT NULL,
+    created_at timestamp NOT NULL,
+    CONSTRAINT webhook_delivery_attempt_pk PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS webhook_delivery_attempt_delivery_id_attempt_idx ON public.webhook_delivery_attempt (delivery_id, attempt);
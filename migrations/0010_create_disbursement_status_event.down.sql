@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS disbursement_status_event_trigger ON public.disbursement;
+DROP FUNCTION IF EXISTS public.record_disbursement_status_event();
+DROP INDEX IF EXISTS disbursement_status_event_disbursement_id_id_idx;
+DROP TABLE IF EXISTS public.disbursement_status_event;
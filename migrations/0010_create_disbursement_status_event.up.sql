@@ -0,0 +1,36 @@
+-- every status change of a disbursement, id is the sequence streaming clients resume from with Last-Event-ID
+CREATE TABLE IF NOT EXISTS public.disbursement_status_event (
+    id bigserial NOT NULL,
+    disbursement_id uuid NOT NULL REFERENCES public.disbursement (id),
+    merchant_id uuid NULL,
+    status int NOT NULL,
+    previous_status int NOT NULL,
+    version bigint NOT NULL,
+    created_at timestamp NOT NULL,
+    CONSTRAINT disbursement_status_event_pk PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS disbursement_status_event_disbursement_id_id_idx ON public.disbursement_status_event (disbursement_id, id);
+
+CREATE OR REPLACE FUNCTION public.record_disbursement_status_event() RETURNS trigger AS $$
+BEGIN
+    -- serialize the writers until commit so a reader never see an id committed after a bigger one,
+    -- otherwise a client resuming after the bigger id would miss the event
+    PERFORM pg_advisory_xact_lock(hashtext('disbursement_status_event'));
+
+    INSERT INTO public.disbursement_status_event
+        (disbursement_id, merchant_id, status, previous_status, version, created_at)
+    VALUES
+        (NEW.id, NEW.merchant_id, NEW.status, OLD.status, NEW.version, CURRENT_TIMESTAMP);
+
+    -- delivered to the listeners when the transaction commits
+    PERFORM pg_notify('disbursement_status_event', NEW.id::text);
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER disbursement_status_event_trigger
+    AFTER UPDATE OF status ON public.disbursement
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status)
+    EXECUTE FUNCTION public.record_disbursement_status_event();
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS outbox_published_at_idx;
+DROP INDEX IF EXISTS outbox_unpublished_aggregate_id_idx;
+DROP INDEX IF EXISTS outbox_unpublished_next_attempt_at_idx;
+DROP TABLE IF EXISTS public.outbox;
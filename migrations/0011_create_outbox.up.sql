@@ -0,0 +1,17 @@
+-- domain events recorded in the transaction of the change, published to other services by the relay
+CREATE TABLE IF NOT EXISTS public.outbox (
+    id bigserial NOT NULL,
+    event_type varchar NOT NULL,
+    -- events of the same aggregate are published in id order
+    aggregate_id varchar NOT NULL,
+    payload jsonb NOT NULL,
+    occurred_at timestamp NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    next_attempt_at timestamp NOT NULL,
+    last_error text NULL,
+    published_at timestamp NULL,
+    CONSTRAINT outbox_pk PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS outbox_unpublished_next_attempt_at_idx ON public.outbox (next_attempt_at) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_unpublished_aggregate_id_idx ON public.outbox (aggregate_id, id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_published_at_idx ON public.outbox (published_at) WHERE published_at IS NOT NULL;
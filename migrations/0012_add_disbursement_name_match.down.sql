@@ -0,0 +1,2 @@
+ALTER TABLE public.disbursement DROP COLUMN IF EXISTS name_match_decision;
+ALTER TABLE public.disbursement DROP COLUMN IF EXISTS name_match_score;
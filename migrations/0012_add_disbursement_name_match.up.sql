@@ -0,0 +1,4 @@
+-- similarity of the recipient name to the account holder name reported by the bank, null for disbursement
+-- created before name matching
+ALTER TABLE public.disbursement ADD COLUMN IF NOT EXISTS name_match_score smallint NULL;
+ALTER TABLE public.disbursement ADD COLUMN IF NOT EXISTS name_match_decision varchar NULL;
@@ -0,0 +1,14 @@
+-- bank account verification results shared by every instance, an expired row is ignored until it is replaced
+CREATE TABLE IF NOT EXISTS public.verification_cache (
+    bank_code varchar NOT NULL,
+    -- blind index of the account number, the account number itself when encryption is not configured
+    account_number_bidx varchar NOT NULL,
+    -- encrypted by the application when encryption_key_id is not null
+    account_holder_name varchar NOT NULL,
+    encryption_key_id varchar NULL,
+    encrypted_data_key varchar NULL,
+    account_status varchar NOT NULL,
+    expires_at timestamp NOT NULL,
+    created_at timestamp NOT NULL,
+    CONSTRAINT verification_cache_pk PRIMARY KEY (bank_code, account_number_bidx)
+);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS public.beneficiary (
+    id uuid DEFAULT uuid_generate_v4() NOT NULL,
+    merchant_id uuid NOT NULL REFERENCES public.merchant (id),
+    -- name, account number and account holder name are encrypted by the application when encryption_key_id is not null
+    name varchar NOT NULL,
+    account_number varchar NOT NULL,
+    bank_code varchar NOT NULL,
+    encryption_key_id varchar NULL,
+    encrypted_data_key varchar NULL,
+    -- last verification of the account by the bank
+    verification_status varchar NOT NULL,
+    account_holder_name varchar NOT NULL,
+    name_match_score smallint NOT NULL,
+    verified_at timestamp NOT NULL,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    deleted_at timestamp NULL,
+    CONSTRAINT beneficiary_pk PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS beneficiary_merchant_id_created_at_idx ON public.beneficiary (merchant_id, created_at) WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.screening;
+DROP TABLE IF EXISTS public.screening_entry;
+DROP INDEX IF EXISTS public.disbursement_bank_transaction_id_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS disbursement_bank_transaction_id_idx ON public.disbursement (bank_transaction_id);
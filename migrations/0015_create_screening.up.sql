@@ -0,0 +1,39 @@
+-- a disbursement on hold has no bank transaction id until compliance release it
+DROP INDEX IF EXISTS public.disbursement_bank_transaction_id_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS disbursement_bank_transaction_id_idx ON public.disbursement (bank_transaction_id) WHERE bank_transaction_id <> '';
+
+CREATE TABLE IF NOT EXISTS public.screening_entry (
+    id uuid DEFAULT uuid_generate_v4() NOT NULL,
+    -- name of the imported sanctions list, or INTERNAL for the blocklist maintained by compliance
+    list_name varchar NOT NULL,
+    -- name and account number are encrypted by the application when encryption_key_id is not null
+    name varchar NOT NULL,
+    account_number varchar NOT NULL,
+    -- the account number is blocked in any bank when empty
+    bank_code varchar NOT NULL,
+    reference varchar NOT NULL,
+    encryption_key_id varchar NULL,
+    encrypted_data_key varchar NULL,
+    created_at timestamp NOT NULL,
+    CONSTRAINT screening_entry_pk PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS screening_entry_list_name_created_at_idx ON public.screening_entry (list_name, created_at);
+
+CREATE TABLE IF NOT EXISTS public.screening (
+    id uuid DEFAULT uuid_generate_v4() NOT NULL,
+    disbursement_id uuid NOT NULL REFERENCES public.disbursement (id),
+    merchant_id uuid NULL REFERENCES public.merchant (id),
+    status varchar NOT NULL,
+    -- the matched entries as json, encrypted by the application when encryption_key_id is not null
+    matches text NOT NULL,
+    encryption_key_id varchar NULL,
+    encrypted_data_key varchar NULL,
+    resolved_by varchar NULL,
+    resolution_note text NULL,
+    resolved_at timestamp NULL,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    CONSTRAINT screening_pk PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS screening_disbursement_id_idx ON public.screening (disbursement_id);
+CREATE INDEX IF NOT EXISTS screening_status_created_at_idx ON public.screening (status, created_at);
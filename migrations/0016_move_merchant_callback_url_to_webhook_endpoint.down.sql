@@ -0,0 +1,9 @@
+UPDATE public.merchant SET settings = settings || jsonb_build_object('callback_url', merchant_callback_url.callback_url)
+FROM public.merchant_callback_url WHERE merchant.id = merchant_callback_url.merchant_id;
+
+-- the endpoint is kept for the delivery logs
+UPDATE public.webhook_endpoint SET deleted_at = now(), updated_at = now()
+FROM public.merchant_callback_url
+WHERE webhook_endpoint.id = merchant_callback_url.endpoint_id AND webhook_endpoint.deleted_at IS NULL;
+
+DROP TABLE IF EXISTS public.merchant_callback_url;
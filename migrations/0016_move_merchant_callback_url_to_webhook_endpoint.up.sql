@@ -0,0 +1,30 @@
+-- callback_url was never used, merchants are notified through their webhook endpoints. The removed setting is kept
+-- with the endpoint created from it so the migration can be reverted
+CREATE TABLE IF NOT EXISTS public.merchant_callback_url (
+    merchant_id uuid NOT NULL REFERENCES public.merchant (id),
+    callback_url jsonb NOT NULL,
+    -- null when the callback url was empty
+    endpoint_id uuid NULL REFERENCES public.webhook_endpoint (id),
+    CONSTRAINT merchant_callback_url_pk PRIMARY KEY (merchant_id)
+);
+
+INSERT INTO public.merchant_callback_url (merchant_id, callback_url)
+SELECT id, settings->'callback_url' FROM public.merchant WHERE settings ? 'callback_url';
+
+-- the endpoint subscribe every event type, its secret is random and not readable, the merchant register a new
+-- endpoint to get a secret
+WITH endpoint AS (
+    INSERT INTO public.webhook_endpoint (merchant_id, url, secret, created_at, updated_at)
+    SELECT merchant_id,
+           callback_url #>> '{}',
+           'whsec_' || rtrim(translate(encode(decode(replace(uuid_generate_v4()::text || uuid_generate_v4()::text, '-', ''), 'hex'), 'base64'), '+/', '-_'), '='),
+           now(),
+           now()
+    FROM public.merchant_callback_url
+    WHERE jsonb_typeof(callback_url) = 'string' AND callback_url #>> '{}' <> ''
+    RETURNING id, merchant_id
+)
+UPDATE public.merchant_callback_url SET endpoint_id = endpoint.id
+FROM endpoint WHERE merchant_callback_url.merchant_id = endpoint.merchant_id;
+
+UPDATE public.merchant SET settings = settings - 'callback_url' WHERE settings ? 'callback_url';
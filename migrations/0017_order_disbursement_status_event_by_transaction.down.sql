@@ -0,0 +1,20 @@
+CREATE OR REPLACE FUNCTION public.record_disbursement_status_event() RETURNS trigger AS $$
+BEGIN
+    -- serialize the writers until commit so a reader never see an id committed after a bigger one,
+    -- otherwise a client resuming after the bigger id would miss the event
+    PERFORM pg_advisory_xact_lock(hashtext('disbursement_status_event'));
+
+    INSERT INTO public.disbursement_status_event
+        (disbursement_id, merchant_id, status, previous_status, version, created_at)
+    VALUES
+        (NEW.id, NEW.merchant_id, NEW.status, OLD.status, NEW.version, CURRENT_TIMESTAMP);
+
+    -- delivered to the listeners when the transaction commits
+    PERFORM pg_notify('disbursement_status_event', NEW.id::text);
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS disbursement_status_event_transaction_id_id_idx;
+ALTER TABLE public.disbursement_status_event DROP COLUMN IF EXISTS transaction_id;
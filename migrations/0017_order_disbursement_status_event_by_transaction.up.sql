@@ -0,0 +1,20 @@
+-- the writers are no longer serialized, the readers instead return only the events of the transactions older than
+-- every transaction in progress, ordered by transaction then id, so a reader never skip an event committed later
+-- with a smaller id. The events recorded before are all committed and ordered by id
+ALTER TABLE public.disbursement_status_event ADD COLUMN IF NOT EXISTS transaction_id bigint NOT NULL DEFAULT 0;
+ALTER TABLE public.disbursement_status_event ALTER COLUMN transaction_id DROP DEFAULT;
+CREATE INDEX IF NOT EXISTS disbursement_status_event_transaction_id_id_idx ON public.disbursement_status_event (transaction_id, id);
+
+CREATE OR REPLACE FUNCTION public.record_disbursement_status_event() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO public.disbursement_status_event
+        (disbursement_id, merchant_id, status, previous_status, version, transaction_id, created_at)
+    VALUES
+        (NEW.id, NEW.merchant_id, NEW.status, OLD.status, NEW.version, txid_current(), CURRENT_TIMESTAMP);
+
+    -- delivered to the listeners when the transaction commits
+    PERFORM pg_notify('disbursement_status_event', NEW.id::text);
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS contains the versioned schema migrations. File name format is <version>_<name>.<up|down>.sql
+//
+//go:embed *.sql
+var FS embed.FS
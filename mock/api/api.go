@@ -40,6 +40,21 @@ func (m *MockBank) EXPECT() *MockBankMockRecorder {
 	return m.recorder
 }
 
+// CheckTransferStatus mocks base method.
+func (m *MockBank) CheckTransferStatus(ctx context.Context, transferStatus api.TransferStatusRequest) (api.TransferStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckTransferStatus", ctx, transferStatus)
+	ret0, _ := ret[0].(api.TransferStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckTransferStatus indicates an expected call of CheckTransferStatus.
+func (mr *MockBankMockRecorder) CheckTransferStatus(ctx, transferStatus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTransferStatus", reflect.TypeOf((*MockBank)(nil).CheckTransferStatus), ctx, transferStatus)
+}
+
 // TransferMoney mocks base method.
 func (m *MockBank) TransferMoney(ctx context.Context, transfer api.TransferRequest) (api.TransferResponse, error) {
 	m.ctrl.T.Helper()
@@ -69,3 +84,115 @@ func (mr *MockBankMockRecorder) VerifyAccount(ctx, account any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAccount", reflect.TypeOf((*MockBank)(nil).VerifyAccount), ctx, account)
 }
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockWebhook) Send(ctx context.Context, request api.WebhookRequest) (api.WebhookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, request)
+	ret0, _ := ret[0].(api.WebhookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockWebhookMockRecorder) Send(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockWebhook)(nil).Send), ctx, request)
+}
+
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockPublisher) Publish(ctx context.Context, event api.EventMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPublisherMockRecorder) Publish(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPublisher)(nil).Publish), ctx, event)
+}
+
+// MockNameMatcher is a mock of NameMatcher interface.
+type MockNameMatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockNameMatcherMockRecorder
+}
+
+// MockNameMatcherMockRecorder is the mock recorder for MockNameMatcher.
+type MockNameMatcherMockRecorder struct {
+	mock *MockNameMatcher
+}
+
+// NewMockNameMatcher creates a new mock instance.
+func NewMockNameMatcher(ctrl *gomock.Controller) *MockNameMatcher {
+	mock := &MockNameMatcher{ctrl: ctrl}
+	mock.recorder = &MockNameMatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNameMatcher) EXPECT() *MockNameMatcherMockRecorder {
+	return m.recorder
+}
+
+// Score mocks base method.
+func (m *MockNameMatcher) Score(name, other string) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Score", name, other)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Score indicates an expected call of Score.
+func (mr *MockNameMatcherMockRecorder) Score(name, other any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Score", reflect.TypeOf((*MockNameMatcher)(nil).Score), name, other)
+}
@@ -12,6 +12,7 @@ package mock_repository
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/nobbyphala/Brick/domain"
 	database "github.com/nobbyphala/Brick/external/database"
@@ -42,6 +43,36 @@ func (m *MockDisbursement) EXPECT() *MockDisbursementMockRecorder {
 	return m.recorder
 }
 
+// GetById mocks base method.
+func (m *MockDisbursement) GetById(ctx context.Context, id string) (*domain.Disbursement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.Disbursement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockDisbursementMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockDisbursement)(nil).GetById), ctx, id)
+}
+
+// GetByRecipientAccountNumber mocks base method.
+func (m *MockDisbursement) GetByRecipientAccountNumber(ctx context.Context, accountNumber string) ([]domain.Disbursement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRecipientAccountNumber", ctx, accountNumber)
+	ret0, _ := ret[0].([]domain.Disbursement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRecipientAccountNumber indicates an expected call of GetByRecipientAccountNumber.
+func (mr *MockDisbursementMockRecorder) GetByRecipientAccountNumber(ctx, accountNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRecipientAccountNumber", reflect.TypeOf((*MockDisbursement)(nil).GetByRecipientAccountNumber), ctx, accountNumber)
+}
+
 // GetByTransactionId mocks base method.
 func (m *MockDisbursement) GetByTransactionId(ctx context.Context, bankTransactionId string) (*domain.Disbursement, error) {
 	m.ctrl.T.Helper()
@@ -72,18 +103,63 @@ func (mr *MockDisbursementMockRecorder) Insert(ctx, disbursement any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDisbursement)(nil).Insert), ctx, disbursement)
 }
 
+// List mocks base method.
+func (m *MockDisbursement) List(ctx context.Context, filter domain.DisbursementFilter) ([]domain.Disbursement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.Disbursement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDisbursementMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDisbursement)(nil).List), ctx, filter)
+}
+
+// ListNeedReEncryption mocks base method.
+func (m *MockDisbursement) ListNeedReEncryption(ctx context.Context, limit int) ([]domain.Disbursement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNeedReEncryption", ctx, limit)
+	ret0, _ := ret[0].([]domain.Disbursement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNeedReEncryption indicates an expected call of ListNeedReEncryption.
+func (mr *MockDisbursementMockRecorder) ListNeedReEncryption(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNeedReEncryption", reflect.TypeOf((*MockDisbursement)(nil).ListNeedReEncryption), ctx, limit)
+}
+
+// SumAmountSince mocks base method.
+func (m *MockDisbursement) SumAmountSince(ctx context.Context, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumAmountSince", ctx, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumAmountSince indicates an expected call of SumAmountSince.
+func (mr *MockDisbursementMockRecorder) SumAmountSince(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumAmountSince", reflect.TypeOf((*MockDisbursement)(nil).SumAmountSince), ctx, since)
+}
+
 // UpdateById mocks base method.
-func (m *MockDisbursement) UpdateById(ctx context.Context, id string, updatedData domain.Disbursement) error {
+func (m *MockDisbursement) UpdateById(ctx context.Context, id string, expectedVersion int64, updatedData domain.Disbursement) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateById", ctx, id, updatedData)
+	ret := m.ctrl.Call(m, "UpdateById", ctx, id, expectedVersion, updatedData)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateById indicates an expected call of UpdateById.
-func (mr *MockDisbursementMockRecorder) UpdateById(ctx, id, updatedData any) *gomock.Call {
+func (mr *MockDisbursementMockRecorder) UpdateById(ctx, id, expectedVersion, updatedData any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockDisbursement)(nil).UpdateById), ctx, id, updatedData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockDisbursement)(nil).UpdateById), ctx, id, expectedVersion, updatedData)
 }
 
 // WithTx mocks base method.
@@ -100,39 +176,1307 @@ func (mr *MockDisbursementMockRecorder) WithTx(Tx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockDisbursement)(nil).WithTx), Tx)
 }
 
-// MockUtils is a mock of Utils interface.
-type MockUtils struct {
+// MockDisbursementStatusEvent is a mock of DisbursementStatusEvent interface.
+type MockDisbursementStatusEvent struct {
 	ctrl     *gomock.Controller
-	recorder *MockUtilsMockRecorder
+	recorder *MockDisbursementStatusEventMockRecorder
 }
 
-// MockUtilsMockRecorder is the mock recorder for MockUtils.
-type MockUtilsMockRecorder struct {
-	mock *MockUtils
+// MockDisbursementStatusEventMockRecorder is the mock recorder for MockDisbursementStatusEvent.
+type MockDisbursementStatusEventMockRecorder struct {
+	mock *MockDisbursementStatusEvent
 }
 
-// NewMockUtils creates a new mock instance.
-func NewMockUtils(ctrl *gomock.Controller) *MockUtils {
-	mock := &MockUtils{ctrl: ctrl}
-	mock.recorder = &MockUtilsMockRecorder{mock}
+// NewMockDisbursementStatusEvent creates a new mock instance.
+func NewMockDisbursementStatusEvent(ctrl *gomock.Controller) *MockDisbursementStatusEvent {
+	mock := &MockDisbursementStatusEvent{ctrl: ctrl}
+	mock.recorder = &MockDisbursementStatusEventMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUtils) EXPECT() *MockUtilsMockRecorder {
+func (m *MockDisbursementStatusEvent) EXPECT() *MockDisbursementStatusEventMockRecorder {
 	return m.recorder
 }
 
-// RunWithTransaction mocks base method.
-func (m *MockUtils) RunWithTransaction(ctx context.Context, handler func(database.SQLDatabase) error) error {
+// LastId mocks base method.
+func (m *MockDisbursementStatusEvent) LastId(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastId", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastId indicates an expected call of LastId.
+func (mr *MockDisbursementStatusEventMockRecorder) LastId(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastId", reflect.TypeOf((*MockDisbursementStatusEvent)(nil).LastId), ctx)
+}
+
+// List mocks base method.
+func (m *MockDisbursementStatusEvent) List(ctx context.Context, filter domain.DisbursementStatusEventFilter) ([]domain.DisbursementStatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.DisbursementStatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDisbursementStatusEventMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDisbursementStatusEvent)(nil).List), ctx, filter)
+}
+
+// MockDisbursementStatusEventListener is a mock of DisbursementStatusEventListener interface.
+type MockDisbursementStatusEventListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockDisbursementStatusEventListenerMockRecorder
+}
+
+// MockDisbursementStatusEventListenerMockRecorder is the mock recorder for MockDisbursementStatusEventListener.
+type MockDisbursementStatusEventListenerMockRecorder struct {
+	mock *MockDisbursementStatusEventListener
+}
+
+// NewMockDisbursementStatusEventListener creates a new mock instance.
+func NewMockDisbursementStatusEventListener(ctrl *gomock.Controller) *MockDisbursementStatusEventListener {
+	mock := &MockDisbursementStatusEventListener{ctrl: ctrl}
+	mock.recorder = &MockDisbursementStatusEventListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDisbursementStatusEventListener) EXPECT() *MockDisbursementStatusEventListenerMockRecorder {
+	return m.recorder
+}
+
+// Listen mocks base method.
+func (m *MockDisbursementStatusEventListener) Listen(ctx context.Context) (<-chan struct{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", ctx)
+	ret0, _ := ret[0].(<-chan struct{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockDisbursementStatusEventListenerMockRecorder) Listen(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockDisbursementStatusEventListener)(nil).Listen), ctx)
+}
+
+// MockMerchant is a mock of Merchant interface.
+type MockMerchant struct {
+	ctrl     *gomock.Controller
+	recorder *MockMerchantMockRecorder
+}
+
+// MockMerchantMockRecorder is the mock recorder for MockMerchant.
+type MockMerchantMockRecorder struct {
+	mock *MockMerchant
+}
+
+// NewMockMerchant creates a new mock instance.
+func NewMockMerchant(ctrl *gomock.Controller) *MockMerchant {
+	mock := &MockMerchant{ctrl: ctrl}
+	mock.recorder = &MockMerchantMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMerchant) EXPECT() *MockMerchantMockRecorder {
+	return m.recorder
+}
+
+// GetById mocks base method.
+func (m *MockMerchant) GetById(ctx context.Context, id string) (*domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockMerchantMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockMerchant)(nil).GetById), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockMerchant) Insert(ctx context.Context, merchant domain.Merchant) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, merchant)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockMerchantMockRecorder) Insert(ctx, merchant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockMerchant)(nil).Insert), ctx, merchant)
+}
+
+// List mocks base method.
+func (m *MockMerchant) List(ctx context.Context) ([]domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMerchantMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMerchant)(nil).List), ctx)
+}
+
+// LockById mocks base method.
+func (m *MockMerchant) LockById(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RunWithTransaction", ctx, handler)
+	ret := m.ctrl.Call(m, "LockById", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RunWithTransaction indicates an expected call of RunWithTransaction.
-func (mr *MockUtilsMockRecorder) RunWithTransaction(ctx, handler any) *gomock.Call {
+// LockById indicates an expected call of LockById.
+func (mr *MockMerchantMockRecorder) LockById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockById", reflect.TypeOf((*MockMerchant)(nil).LockById), ctx, id)
+}
+
+// UpdateById mocks base method.
+func (m *MockMerchant) UpdateById(ctx context.Context, id string, updatedData domain.Merchant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, id, updatedData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockMerchantMockRecorder) UpdateById(ctx, id, updatedData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockMerchant)(nil).UpdateById), ctx, id, updatedData)
+}
+
+// WithTx mocks base method.
+func (m *MockMerchant) WithTx(Tx database.SQLDatabase) repository.Merchant {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", Tx)
+	ret0, _ := ret[0].(repository.Merchant)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockMerchantMockRecorder) WithTx(Tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockMerchant)(nil).WithTx), Tx)
+}
+
+// MockApiKey is a mock of ApiKey interface.
+type MockApiKey struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyMockRecorder
+}
+
+// MockApiKeyMockRecorder is the mock recorder for MockApiKey.
+type MockApiKeyMockRecorder struct {
+	mock *MockApiKey
+}
+
+// NewMockApiKey creates a new mock instance.
+func NewMockApiKey(ctrl *gomock.Controller) *MockApiKey {
+	mock := &MockApiKey{ctrl: ctrl}
+	mock.recorder = &MockApiKeyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKey) EXPECT() *MockApiKeyMockRecorder {
+	return m.recorder
+}
+
+// GetById mocks base method.
+func (m *MockApiKey) GetById(ctx context.Context, id string) (*domain.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockApiKeyMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockApiKey)(nil).GetById), ctx, id)
+}
+
+// GetByPrefix mocks base method.
+func (m *MockApiKey) GetByPrefix(ctx context.Context, prefix string) (*domain.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPrefix", ctx, prefix)
+	ret0, _ := ret[0].(*domain.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPrefix indicates an expected call of GetByPrefix.
+func (mr *MockApiKeyMockRecorder) GetByPrefix(ctx, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPrefix", reflect.TypeOf((*MockApiKey)(nil).GetByPrefix), ctx, prefix)
+}
+
+// Insert mocks base method.
+func (m *MockApiKey) Insert(ctx context.Context, apiKey domain.ApiKey) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, apiKey)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockApiKeyMockRecorder) Insert(ctx, apiKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockApiKey)(nil).Insert), ctx, apiKey)
+}
+
+// ListByClientId mocks base method.
+func (m *MockApiKey) ListByClientId(ctx context.Context, clientId string) ([]domain.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByClientId", ctx, clientId)
+	ret0, _ := ret[0].([]domain.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByClientId indicates an expected call of ListByClientId.
+func (mr *MockApiKeyMockRecorder) ListByClientId(ctx, clientId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByClientId", reflect.TypeOf((*MockApiKey)(nil).ListByClientId), ctx, clientId)
+}
+
+// UpdateById mocks base method.
+func (m *MockApiKey) UpdateById(ctx context.Context, id string, updatedData domain.ApiKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, id, updatedData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockApiKeyMockRecorder) UpdateById(ctx, id, updatedData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockApiKey)(nil).UpdateById), ctx, id, updatedData)
+}
+
+// UpdateLastUsedAt mocks base method.
+func (m *MockApiKey) UpdateLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastUsedAt", ctx, id, lastUsedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastUsedAt indicates an expected call of UpdateLastUsedAt.
+func (mr *MockApiKeyMockRecorder) UpdateLastUsedAt(ctx, id, lastUsedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsedAt", reflect.TypeOf((*MockApiKey)(nil).UpdateLastUsedAt), ctx, id, lastUsedAt)
+}
+
+// WithTx mocks base method.
+func (m *MockApiKey) WithTx(Tx database.SQLDatabase) repository.ApiKey {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", Tx)
+	ret0, _ := ret[0].(repository.ApiKey)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockApiKeyMockRecorder) WithTx(Tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockApiKey)(nil).WithTx), Tx)
+}
+
+// MockAuditLog is a mock of AuditLog interface.
+type MockAuditLog struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogMockRecorder
+}
+
+// MockAuditLogMockRecorder is the mock recorder for MockAuditLog.
+type MockAuditLogMockRecorder struct {
+	mock *MockAuditLog
+}
+
+// NewMockAuditLog creates a new mock instance.
+func NewMockAuditLog(ctrl *gomock.Controller) *MockAuditLog {
+	mock := &MockAuditLog{ctrl: ctrl}
+	mock.recorder = &MockAuditLogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLog) EXPECT() *MockAuditLogMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method.
+func (m *MockAuditLog) Insert(ctx context.Context, auditLog domain.AuditLog) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, auditLog)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockAuditLogMockRecorder) Insert(ctx, auditLog any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAuditLog)(nil).Insert), ctx, auditLog)
+}
+
+// List mocks base method.
+func (m *MockAuditLog) List(ctx context.Context, filter domain.AuditLogFilter) ([]domain.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAuditLogMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuditLog)(nil).List), ctx, filter)
+}
+
+// WithTx mocks base method.
+func (m *MockAuditLog) WithTx(Tx database.SQLDatabase) repository.AuditLog {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", Tx)
+	ret0, _ := ret[0].(repository.AuditLog)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockAuditLogMockRecorder) WithTx(Tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockAuditLog)(nil).WithTx), Tx)
+}
+
+// MockCallbackReview is a mock of CallbackReview interface.
+type MockCallbackReview struct {
+	ctrl     *gomock.Controller
+	recorder *MockCallbackReviewMockRecorder
+}
+
+// MockCallbackReviewMockRecorder is the mock recorder for MockCallbackReview.
+type MockCallbackReviewMockRecorder struct {
+	mock *MockCallbackReview
+}
+
+// NewMockCallbackReview creates a new mock instance.
+func NewMockCallbackReview(ctrl *gomock.Controller) *MockCallbackReview {
+	mock := &MockCallbackReview{ctrl: ctrl}
+	mock.recorder = &MockCallbackReviewMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCallbackReview) EXPECT() *MockCallbackReviewMockRecorder {
+	return m.recorder
+}
+
+// CountOpen mocks base method.
+func (m *MockCallbackReview) CountOpen(ctx context.Context) ([]domain.CallbackReviewCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpen", ctx)
+	ret0, _ := ret[0].([]domain.CallbackReviewCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpen indicates an expected call of CountOpen.
+func (mr *MockCallbackReviewMockRecorder) CountOpen(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpen", reflect.TypeOf((*MockCallbackReview)(nil).CountOpen), ctx)
+}
+
+// GetById mocks base method.
+func (m *MockCallbackReview) GetById(ctx context.Context, id string) (*domain.CallbackReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.CallbackReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockCallbackReviewMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockCallbackReview)(nil).GetById), ctx, id)
+}
+
+// GetOpen mocks base method.
+func (m *MockCallbackReview) GetOpen(ctx context.Context, transactionId string, reason domain.CallbackReviewReason) (*domain.CallbackReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpen", ctx, transactionId, reason)
+	ret0, _ := ret[0].(*domain.CallbackReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpen indicates an expected call of GetOpen.
+func (mr *MockCallbackReviewMockRecorder) GetOpen(ctx, transactionId, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpen", reflect.TypeOf((*MockCallbackReview)(nil).GetOpen), ctx, transactionId, reason)
+}
+
+// Insert mocks base method.
+func (m *MockCallbackReview) Insert(ctx context.Context, review domain.CallbackReview) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, review)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockCallbackReviewMockRecorder) Insert(ctx, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockCallbackReview)(nil).Insert), ctx, review)
+}
+
+// InsertComment mocks base method.
+func (m *MockCallbackReview) InsertComment(ctx context.Context, comment domain.CallbackReviewComment) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertComment", ctx, comment)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertComment indicates an expected call of InsertComment.
+func (mr *MockCallbackReviewMockRecorder) InsertComment(ctx, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertComment", reflect.TypeOf((*MockCallbackReview)(nil).InsertComment), ctx, comment)
+}
+
+// List mocks base method.
+func (m *MockCallbackReview) List(ctx context.Context, filter domain.CallbackReviewFilter) ([]domain.CallbackReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.CallbackReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCallbackReviewMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCallbackReview)(nil).List), ctx, filter)
+}
+
+// ListComments mocks base method.
+func (m *MockCallbackReview) ListComments(ctx context.Context, reviewId string) ([]domain.CallbackReviewComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComments", ctx, reviewId)
+	ret0, _ := ret[0].([]domain.CallbackReviewComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListComments indicates an expected call of ListComments.
+func (mr *MockCallbackReviewMockRecorder) ListComments(ctx, reviewId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComments", reflect.TypeOf((*MockCallbackReview)(nil).ListComments), ctx, reviewId)
+}
+
+// UpdateById mocks base method.
+func (m *MockCallbackReview) UpdateById(ctx context.Context, id string, updatedData domain.CallbackReview) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, id, updatedData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockCallbackReviewMockRecorder) UpdateById(ctx, id, updatedData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockCallbackReview)(nil).UpdateById), ctx, id, updatedData)
+}
+
+// WithTx mocks base method.
+func (m *MockCallbackReview) WithTx(Tx database.SQLDatabase) repository.CallbackReview {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", Tx)
+	ret0, _ := ret[0].(repository.CallbackReview)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockCallbackReviewMockRecorder) WithTx(Tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockCallbackReview)(nil).WithTx), Tx)
+}
+
+// MockWebhookEndpoint is a mock of WebhookEndpoint interface.
+type MockWebhookEndpoint struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookEndpointMockRecorder
+}
+
+// MockWebhookEndpointMockRecorder is the mock recorder for MockWebhookEndpoint.
+type MockWebhookEndpointMockRecorder struct {
+	mock *MockWebhookEndpoint
+}
+
+// NewMockWebhookEndpoint creates a new mock instance.
+func NewMockWebhookEndpoint(ctrl *gomock.Controller) *MockWebhookEndpoint {
+	mock := &MockWebhookEndpoint{ctrl: ctrl}
+	mock.recorder = &MockWebhookEndpointMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookEndpoint) EXPECT() *MockWebhookEndpointMockRecorder {
+	return m.recorder
+}
+
+// DeleteById mocks base method.
+func (m *MockWebhookEndpoint) DeleteById(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockWebhookEndpointMockRecorder) DeleteById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockWebhookEndpoint)(nil).DeleteById), ctx, id)
+}
+
+// GetById mocks base method.
+func (m *MockWebhookEndpoint) GetById(ctx context.Context, id string) (*domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockWebhookEndpointMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockWebhookEndpoint)(nil).GetById), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockWebhookEndpoint) Insert(ctx context.Context, endpoint domain.WebhookEndpoint) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, endpoint)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockWebhookEndpointMockRecorder) Insert(ctx, endpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockWebhookEndpoint)(nil).Insert), ctx, endpoint)
+}
+
+// ListByMerchantId mocks base method.
+func (m *MockWebhookEndpoint) ListByMerchantId(ctx context.Context, merchantId string) ([]domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByMerchantId", ctx, merchantId)
+	ret0, _ := ret[0].([]domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByMerchantId indicates an expected call of ListByMerchantId.
+func (mr *MockWebhookEndpointMockRecorder) ListByMerchantId(ctx, merchantId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByMerchantId", reflect.TypeOf((*MockWebhookEndpoint)(nil).ListByMerchantId), ctx, merchantId)
+}
+
+// UpdateById mocks base method.
+func (m *MockWebhookEndpoint) UpdateById(ctx context.Context, id string, updatedData domain.WebhookEndpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, id, updatedData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockWebhookEndpointMockRecorder) UpdateById(ctx, id, updatedData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockWebhookEndpoint)(nil).UpdateById), ctx, id, updatedData)
+}
+
+// WithTx mocks base method.
+func (m *MockWebhookEndpoint) WithTx(Tx database.SQLDatabase) repository.WebhookEndpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", Tx)
+	ret0, _ := ret[0].(repository.WebhookEndpoint)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockWebhookEndpointMockRecorder) WithTx(Tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockWebhookEndpoint)(nil).WithTx), Tx)
+}
+
+// MockWebhookDelivery is a mock of WebhookDelivery interface.
+type MockWebhookDelivery struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDeliveryMockRecorder
+}
+
+// MockWebhookDeliveryMockRecorder is the mock recorder for MockWebhookDelivery.
+type MockWebhookDeliveryMockRecorder struct {
+	mock *MockWebhookDelivery
+}
+
+// NewMockWebhookDelivery creates a new mock instance.
+func NewMockWebhookDelivery(ctrl *gomock.Controller) *MockWebhookDelivery {
+	mock := &MockWebhookDelivery{ctrl: ctrl}
+	mock.recorder = &MockWebhookDeliveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDelivery) EXPECT() *MockWebhookDeliveryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDue mocks base method.
+func (m *MockWebhookDelivery) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", ctx, now, leaseUntil, limit)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockWebhookDeliveryMockRecorder) ClaimDue(ctx, now, leaseUntil, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockWebhookDelivery)(nil).ClaimDue), ctx, now, leaseUntil, limit)
+}
+
+// GetById mocks base method.
+func (m *MockWebhookDelivery) GetById(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockWebhookDeliveryMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockWebhookDelivery)(nil).GetById), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockWebhookDelivery) Insert(ctx context.Context, delivery domain.WebhookDelivery) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, delivery)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockWebhookDeliveryMockRecorder) Insert(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockWebhookDelivery)(nil).Insert), ctx, delivery)
+}
+
+// InsertAttempt mocks base method.
+func (m *MockWebhookDelivery) InsertAttempt(ctx context.Context, attempt domain.WebhookDeliveryAttempt) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAttempt", ctx, attempt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertAttempt indicates an expected call of InsertAttempt.
+func (mr *MockWebhookDeliveryMockRecorder) InsertAttempt(ctx, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAttempt", reflect.TypeOf((*MockWebhookDelivery)(nil).InsertAttempt), ctx, attempt)
+}
+
+// List mocks base method.
+func (m *MockWebhookDelivery) List(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhookDeliveryMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhookDelivery)(nil).List), ctx, filter)
+}
+
+// ListAttempts mocks base method.
+func (m *MockWebhookDelivery) ListAttempts(ctx context.Context, deliveryId string) ([]domain.WebhookDeliveryAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttempts", ctx, deliveryId)
+	ret0, _ := ret[0].([]domain.WebhookDeliveryAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttempts indicates an expected call of ListAttempts.
+func (mr *MockWebhookDeliveryMockRecorder) ListAttempts(ctx, deliveryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttempts", reflect.TypeOf((*MockWebhookDelivery)(nil).ListAttempts), ctx, deliveryId)
+}
+
+// UpdateById mocks base method.
+func (m *MockWebhookDelivery) UpdateById(ctx context.Context, id string, updatedData domain.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, id, updatedData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockWebhookDeliveryMockRecorder) UpdateById(ctx, id, updatedData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockWebhookDelivery)(nil).UpdateById), ctx, id, updatedData)
+}
+
+// WithTx mocks base method.
+func (m *MockWebhookDelivery) WithTx(Tx database.SQLDatabase) repository.WebhookDelivery {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", Tx)
+	ret0, _ := ret[0].(repository.WebhookDelivery)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockWebhookDeliveryMockRecorder) WithTx(Tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockWebhookDelivery)(nil).WithTx), Tx)
+}
+
+// MockUtils is a mock of Utils interface.
+type MockUtils struct {
+	ctrl     *gomock.Controller
+	recorder *MockUtilsMockRecorder
+}
+
+// MockUtilsMockRecorder is the mock recorder for MockUtils.
+type MockUtilsMockRecorder struct {
+	mock *MockUtils
+}
+
+// NewMockUtils creates a new mock instance.
+func NewMockUtils(ctrl *gomock.Controller) *MockUtils {
+	mock := &MockUtils{ctrl: ctrl}
+	mock.recorder = &MockUtilsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUtils) EXPECT() *MockUtilsMockRecorder {
+	return m.recorder
+}
+
+// RunWithTransaction mocks base method.
+func (m *MockUtils) RunWithTransaction(ctx context.Context, handler func(context.Context, database.SQLDatabase) error, opts ...repository.TxOption) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, handler}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RunWithTransaction", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunWithTransaction indicates an expected call of RunWithTransaction.
+func (mr *MockUtilsMockRecorder) RunWithTransaction(ctx, handler any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, handler}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunWithTransaction", reflect.TypeOf((*MockUtils)(nil).RunWithTransaction), varargs...)
+}
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// ClaimDue mocks base method.
+func (m *MockOutbox) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", ctx, now, leaseUntil, limit)
+	ret0, _ := ret[0].([]domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockOutboxMockRecorder) ClaimDue(ctx, now, leaseUntil, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockOutbox)(nil).ClaimDue), ctx, now, leaseUntil, limit)
+}
+
+// DeletePublishedBefore mocks base method.
+func (m *MockOutbox) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublishedBefore", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePublishedBefore indicates an expected call of DeletePublishedBefore.
+func (mr *MockOutboxMockRecorder) DeletePublishedBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublishedBefore", reflect.TypeOf((*MockOutbox)(nil).DeletePublishedBefore), ctx, before)
+}
+
+// Insert mocks base method.
+func (m *MockOutbox) Insert(ctx context.Context, event domain.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockOutboxMockRecorder) Insert(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockOutbox)(nil).Insert), ctx, event)
+}
+
+// UpdateById mocks base method.
+func (m *MockOutbox) UpdateById(ctx context.Context, id int64, updatedData domain.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, id, updatedData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockOutboxMockRecorder) UpdateById(ctx, id, updatedData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockOutbox)(nil).UpdateById), ctx, id, updatedData)
+}
+
+// WithTx mocks base method.
+func (m *MockOutbox) WithTx(Tx database.SQLDatabase) repository.Outbox {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", Tx)
+	ret0, _ := ret[0].(repository.Outbox)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockOutboxMockRecorder) WithTx(Tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockOutbox)(nil).WithTx), Tx)
+}
+
+// MockBeneficiary is a mock of Beneficiary interface.
+type MockBeneficiary struct {
+	ctrl     *gomock.Controller
+	recorder *MockBeneficiaryMockRecorder
+}
+
+// MockBeneficiaryMockRecorder is the mock recorder for MockBeneficiary.
+type MockBeneficiaryMockRecorder struct {
+	mock *MockBeneficiary
+}
+
+// NewMockBeneficiary creates a new mock instance.
+func NewMockBeneficiary(ctrl *gomock.Controller) *MockBeneficiary {
+	mock := &MockBeneficiary{ctrl: ctrl}
+	mock.recorder = &MockBeneficiaryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBeneficiary) EXPECT() *MockBeneficiaryMockRecorder {
+	return m.recorder
+}
+
+// DeleteById mocks base method.
+func (m *MockBeneficiary) DeleteById(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockBeneficiaryMockRecorder) DeleteById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockBeneficiary)(nil).DeleteById), ctx, id)
+}
+
+// GetById mocks base method.
+func (m *MockBeneficiary) GetById(ctx context.Context, id string) (*domain.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockBeneficiaryMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockBeneficiary)(nil).GetById), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockBeneficiary) Insert(ctx context.Context, beneficiary domain.Beneficiary) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, beneficiary)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockBeneficiaryMockRecorder) Insert(ctx, beneficiary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockBeneficiary)(nil).Insert), ctx, beneficiary)
+}
+
+// List mocks base method.
+func (m *MockBeneficiary) List(ctx context.Context, filter domain.BeneficiaryFilter) ([]domain.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockBeneficiaryMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBeneficiary)(nil).List), ctx, filter)
+}
+
+// UpdateById mocks base method.
+func (m *MockBeneficiary) UpdateById(ctx context.Context, id string, updatedData domain.Beneficiary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, id, updatedData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockBeneficiaryMockRecorder) UpdateById(ctx, id, updatedData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockBeneficiary)(nil).UpdateById), ctx, id, updatedData)
+}
+
+// MockVerificationCache is a mock of VerificationCache interface.
+type MockVerificationCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerificationCacheMockRecorder
+}
+
+// MockVerificationCacheMockRecorder is the mock recorder for MockVerificationCache.
+type MockVerificationCacheMockRecorder struct {
+	mock *MockVerificationCache
+}
+
+// NewMockVerificationCache creates a new mock instance.
+func NewMockVerificationCache(ctrl *gomock.Controller) *MockVerificationCache {
+	mock := &MockVerificationCache{ctrl: ctrl}
+	mock.recorder = &MockVerificationCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVerificationCache) EXPECT() *MockVerificationCacheMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockVerificationCache) Delete(ctx context.Context, bankCode, accountNumber string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, bankCode, accountNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVerificationCacheMockRecorder) Delete(ctx, bankCode, accountNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVerificationCache)(nil).Delete), ctx, bankCode, accountNumber)
+}
+
+// Get mocks base method.
+func (m *MockVerificationCache) Get(ctx context.Context, bankCode, accountNumber string, now time.Time) (*domain.AccountVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, bankCode, accountNumber, now)
+	ret0, _ := ret[0].(*domain.AccountVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockVerificationCacheMockRecorder) Get(ctx, bankCode, accountNumber, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVerificationCache)(nil).Get), ctx, bankCode, accountNumber, now)
+}
+
+// Set mocks base method.
+func (m *MockVerificationCache) Set(ctx context.Context, verification domain.AccountVerification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, verification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockVerificationCacheMockRecorder) Set(ctx, verification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockVerificationCache)(nil).Set), ctx, verification)
+}
+
+// MockScreeningEntry is a mock of ScreeningEntry interface.
+type MockScreeningEntry struct {
+	ctrl     *gomock.Controller
+	recorder *MockScreeningEntryMockRecorder
+}
+
+// MockScreeningEntryMockRecorder is the mock recorder for MockScreeningEntry.
+type MockScreeningEntryMockRecorder struct {
+	mock *MockScreeningEntry
+}
+
+// NewMockScreeningEntry creates a new mock instance.
+func NewMockScreeningEntry(ctrl *gomock.Controller) *MockScreeningEntry {
+	mock := &MockScreeningEntry{ctrl: ctrl}
+	mock.recorder = &MockScreeningEntryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScreeningEntry) EXPECT() *MockScreeningEntryMockRecorder {
+	return m.recorder
+}
+
+// DeleteById mocks base method.
+func (m *MockScreeningEntry) DeleteById(ctx context.Context, listName, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, listName, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockScreeningEntryMockRecorder) DeleteById(ctx, listName, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockScreeningEntry)(nil).DeleteById), ctx, listName, id)
+}
+
+// DeleteByListName mocks base method.
+func (m *MockScreeningEntry) DeleteByListName(ctx context.Context, listName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByListName", ctx, listName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByListName indicates an expected call of DeleteByListName.
+func (mr *MockScreeningEntryMockRecorder) DeleteByListName(ctx, listName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByListName", reflect.TypeOf((*MockScreeningEntry)(nil).DeleteByListName), ctx, listName)
+}
+
+// Insert mocks base method.
+func (m *MockScreeningEntry) Insert(ctx context.Context, entry domain.ScreeningEntry) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, entry)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockScreeningEntryMockRecorder) Insert(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockScreeningEntry)(nil).Insert), ctx, entry)
+}
+
+// List mocks base method.
+func (m *MockScreeningEntry) List(ctx context.Context, filter domain.ScreeningEntryFilter) ([]domain.ScreeningEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.ScreeningEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockScreeningEntryMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockScreeningEntry)(nil).List), ctx, filter)
+}
+
+// ListAll mocks base method.
+func (m *MockScreeningEntry) ListAll(ctx context.Context) ([]domain.ScreeningEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]domain.ScreeningEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockScreeningEntryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockScreeningEntry)(nil).ListAll), ctx)
+}
+
+// WithTx mocks base method.
+func (m *MockScreeningEntry) WithTx(Tx database.SQLDatabase) repository.ScreeningEntry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", Tx)
+	ret0, _ := ret[0].(repository.ScreeningEntry)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockScreeningEntryMockRecorder) WithTx(Tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockScreeningEntry)(nil).WithTx), Tx)
+}
+
+// MockScreening is a mock of Screening interface.
+type MockScreening struct {
+	ctrl     *gomock.Controller
+	recorder *MockScreeningMockRecorder
+}
+
+// MockScreeningMockRecorder is the mock recorder for MockScreening.
+type MockScreeningMockRecorder struct {
+	mock *MockScreening
+}
+
+// NewMockScreening creates a new mock instance.
+func NewMockScreening(ctrl *gomock.Controller) *MockScreening {
+	mock := &MockScreening{ctrl: ctrl}
+	mock.recorder = &MockScreeningMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScreening) EXPECT() *MockScreeningMockRecorder {
+	return m.recorder
+}
+
+// GetById mocks base method.
+func (m *MockScreening) GetById(ctx context.Context, id string) (*domain.Screening, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*domain.Screening)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockScreeningMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockScreening)(nil).GetById), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockScreening) Insert(ctx context.Context, screening domain.Screening) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, screening)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockScreeningMockRecorder) Insert(ctx, screening any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockScreening)(nil).Insert), ctx, screening)
+}
+
+// List mocks base method.
+func (m *MockScreening) List(ctx context.Context, filter domain.ScreeningFilter) ([]domain.Screening, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.Screening)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockScreeningMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockScreening)(nil).List), ctx, filter)
+}
+
+// UpdateById mocks base method.
+func (m *MockScreening) UpdateById(ctx context.Context, id string, updatedData domain.Screening) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, id, updatedData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockScreeningMockRecorder) UpdateById(ctx, id, updatedData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockScreening)(nil).UpdateById), ctx, id, updatedData)
+}
+
+// WithTx mocks base method.
+func (m *MockScreening) WithTx(Tx database.SQLDatabase) repository.Screening {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", Tx)
+	ret0, _ := ret[0].(repository.Screening)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockScreeningMockRecorder) WithTx(Tx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunWithTransaction", reflect.TypeOf((*MockUtils)(nil).RunWithTransaction), ctx, handler)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockScreening)(nil).WithTx), Tx)
 }
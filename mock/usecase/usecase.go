@@ -11,7 +11,9 @@ package mock_usecase
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/nobbyphala/Brick/domain"
 	usecase "github.com/nobbyphala/Brick/usecase"
@@ -42,18 +44,48 @@ func (m *MockDisbursement) EXPECT() *MockDisbursementMockRecorder {
 }
 
 // Disburse mocks base method.
-func (m *MockDisbursement) Disburse(ctx context.Context, disbursement domain.Disbursement) (domain.Disbursement, error) {
+func (m *MockDisbursement) Disburse(ctx context.Context, disbursement domain.Disbursement, opts usecase.DisburseOptions) (domain.Disbursement, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Disburse", ctx, disbursement)
+	ret := m.ctrl.Call(m, "Disburse", ctx, disbursement, opts)
 	ret0, _ := ret[0].(domain.Disbursement)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Disburse indicates an expected call of Disburse.
-func (mr *MockDisbursementMockRecorder) Disburse(ctx, disbursement any) *gomock.Call {
+func (mr *MockDisbursementMockRecorder) Disburse(ctx, disbursement, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disburse", reflect.TypeOf((*MockDisbursement)(nil).Disburse), ctx, disbursement)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disburse", reflect.TypeOf((*MockDisbursement)(nil).Disburse), ctx, disbursement, opts)
+}
+
+// GetDisbursement mocks base method.
+func (m *MockDisbursement) GetDisbursement(ctx context.Context, id string) (domain.Disbursement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisbursement", ctx, id)
+	ret0, _ := ret[0].(domain.Disbursement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDisbursement indicates an expected call of GetDisbursement.
+func (mr *MockDisbursementMockRecorder) GetDisbursement(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisbursement", reflect.TypeOf((*MockDisbursement)(nil).GetDisbursement), ctx, id)
+}
+
+// ListDisbursements mocks base method.
+func (m *MockDisbursement) ListDisbursements(ctx context.Context, filter domain.DisbursementFilter) ([]domain.Disbursement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDisbursements", ctx, filter)
+	ret0, _ := ret[0].([]domain.Disbursement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDisbursements indicates an expected call of ListDisbursements.
+func (mr *MockDisbursementMockRecorder) ListDisbursements(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisbursements", reflect.TypeOf((*MockDisbursement)(nil).ListDisbursements), ctx, filter)
 }
 
 // ProcessBankCallback mocks base method.
@@ -71,11 +103,12 @@ func (mr *MockDisbursementMockRecorder) ProcessBankCallback(ctx, bankCallback an
 }
 
 // VerifyDisbursement mocks base method.
-func (m *MockDisbursement) VerifyDisbursement(ctx context.Context, disbursement domain.Disbursement) error {
+func (m *MockDisbursement) VerifyDisbursement(ctx context.Context, disbursement domain.Disbursement) (usecase.DisbursementVerification, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "VerifyDisbursement", ctx, disbursement)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(usecase.DisbursementVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // VerifyDisbursement indicates an expected call of VerifyDisbursement.
@@ -83,3 +116,1037 @@ func (mr *MockDisbursementMockRecorder) VerifyDisbursement(ctx, disbursement any
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyDisbursement", reflect.TypeOf((*MockDisbursement)(nil).VerifyDisbursement), ctx, disbursement)
 }
+
+// MockKeyRotation is a mock of KeyRotation interface.
+type MockKeyRotation struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyRotationMockRecorder
+}
+
+// MockKeyRotationMockRecorder is the mock recorder for MockKeyRotation.
+type MockKeyRotationMockRecorder struct {
+	mock *MockKeyRotation
+}
+
+// NewMockKeyRotation creates a new mock instance.
+func NewMockKeyRotation(ctrl *gomock.Controller) *MockKeyRotation {
+	mock := &MockKeyRotation{ctrl: ctrl}
+	mock.recorder = &MockKeyRotationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyRotation) EXPECT() *MockKeyRotationMockRecorder {
+	return m.recorder
+}
+
+// ReEncryptDisbursements mocks base method.
+func (m *MockKeyRotation) ReEncryptDisbursements(ctx context.Context, batchSize int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReEncryptDisbursements", ctx, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReEncryptDisbursements indicates an expected call of ReEncryptDisbursements.
+func (mr *MockKeyRotationMockRecorder) ReEncryptDisbursements(ctx, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReEncryptDisbursements", reflect.TypeOf((*MockKeyRotation)(nil).ReEncryptDisbursements), ctx, batchSize)
+}
+
+// MockDisbursementStream is a mock of DisbursementStream interface.
+type MockDisbursementStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockDisbursementStreamMockRecorder
+}
+
+// MockDisbursementStreamMockRecorder is the mock recorder for MockDisbursementStream.
+type MockDisbursementStreamMockRecorder struct {
+	mock *MockDisbursementStream
+}
+
+// NewMockDisbursementStream creates a new mock instance.
+func NewMockDisbursementStream(ctrl *gomock.Controller) *MockDisbursementStream {
+	mock := &MockDisbursementStream{ctrl: ctrl}
+	mock.recorder = &MockDisbursementStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDisbursementStream) EXPECT() *MockDisbursementStreamMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockDisbursementStream) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockDisbursementStreamMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDisbursementStream)(nil).Close))
+}
+
+// Listen mocks base method.
+func (m *MockDisbursementStream) Listen(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Listen", ctx)
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockDisbursementStreamMockRecorder) Listen(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockDisbursementStream)(nil).Listen), ctx)
+}
+
+// Subscribe mocks base method.
+func (m *MockDisbursementStream) Subscribe(ctx context.Context, filter usecase.DisbursementStreamFilter) (<-chan domain.DisbursementStatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, filter)
+	ret0, _ := ret[0].(<-chan domain.DisbursementStatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockDisbursementStreamMockRecorder) Subscribe(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockDisbursementStream)(nil).Subscribe), ctx, filter)
+}
+
+// MockDisbursementOperation is a mock of DisbursementOperation interface.
+type MockDisbursementOperation struct {
+	ctrl     *gomock.Controller
+	recorder *MockDisbursementOperationMockRecorder
+}
+
+// MockDisbursementOperationMockRecorder is the mock recorder for MockDisbursementOperation.
+type MockDisbursementOperationMockRecorder struct {
+	mock *MockDisbursementOperation
+}
+
+// NewMockDisbursementOperation creates a new mock instance.
+func NewMockDisbursementOperation(ctrl *gomock.Controller) *MockDisbursementOperation {
+	mock := &MockDisbursementOperation{ctrl: ctrl}
+	mock.recorder = &MockDisbursementOperationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDisbursementOperation) EXPECT() *MockDisbursementOperationMockRecorder {
+	return m.recorder
+}
+
+// AttachBankEvidence mocks base method.
+func (m *MockDisbursementOperation) AttachBankEvidence(ctx context.Context, id, reference string) (domain.Disbursement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachBankEvidence", ctx, id, reference)
+	ret0, _ := ret[0].(domain.Disbursement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachBankEvidence indicates an expected call of AttachBankEvidence.
+func (mr *MockDisbursementOperationMockRecorder) AttachBankEvidence(ctx, id, reference any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachBankEvidence", reflect.TypeOf((*MockDisbursementOperation)(nil).AttachBankEvidence), ctx, id, reference)
+}
+
+// ForceStatus mocks base method.
+func (m *MockDisbursementOperation) ForceStatus(ctx context.Context, id string, status domain.DisbursementStatus, reason string) (domain.Disbursement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceStatus", ctx, id, status, reason)
+	ret0, _ := ret[0].(domain.Disbursement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceStatus indicates an expected call of ForceStatus.
+func (mr *MockDisbursementOperationMockRecorder) ForceStatus(ctx, id, status, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceStatus", reflect.TypeOf((*MockDisbursementOperation)(nil).ForceStatus), ctx, id, status, reason)
+}
+
+// RecheckBankStatus mocks base method.
+func (m *MockDisbursementOperation) RecheckBankStatus(ctx context.Context, id string) (usecase.BankStatusCheckResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecheckBankStatus", ctx, id)
+	ret0, _ := ret[0].(usecase.BankStatusCheckResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecheckBankStatus indicates an expected call of RecheckBankStatus.
+func (mr *MockDisbursementOperationMockRecorder) RecheckBankStatus(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecheckBankStatus", reflect.TypeOf((*MockDisbursementOperation)(nil).RecheckBankStatus), ctx, id)
+}
+
+// MockBeneficiary is a mock of Beneficiary interface.
+type MockBeneficiary struct {
+	ctrl     *gomock.Controller
+	recorder *MockBeneficiaryMockRecorder
+}
+
+// MockBeneficiaryMockRecorder is the mock recorder for MockBeneficiary.
+type MockBeneficiaryMockRecorder struct {
+	mock *MockBeneficiary
+}
+
+// NewMockBeneficiary creates a new mock instance.
+func NewMockBeneficiary(ctrl *gomock.Controller) *MockBeneficiary {
+	mock := &MockBeneficiary{ctrl: ctrl}
+	mock.recorder = &MockBeneficiaryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBeneficiary) EXPECT() *MockBeneficiaryMockRecorder {
+	return m.recorder
+}
+
+// CreateBeneficiary mocks base method.
+func (m *MockBeneficiary) CreateBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) (domain.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBeneficiary", ctx, beneficiary)
+	ret0, _ := ret[0].(domain.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBeneficiary indicates an expected call of CreateBeneficiary.
+func (mr *MockBeneficiaryMockRecorder) CreateBeneficiary(ctx, beneficiary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBeneficiary", reflect.TypeOf((*MockBeneficiary)(nil).CreateBeneficiary), ctx, beneficiary)
+}
+
+// DeleteBeneficiary mocks base method.
+func (m *MockBeneficiary) DeleteBeneficiary(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBeneficiary", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBeneficiary indicates an expected call of DeleteBeneficiary.
+func (mr *MockBeneficiaryMockRecorder) DeleteBeneficiary(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBeneficiary", reflect.TypeOf((*MockBeneficiary)(nil).DeleteBeneficiary), ctx, id)
+}
+
+// GetBeneficiary mocks base method.
+func (m *MockBeneficiary) GetBeneficiary(ctx context.Context, id string) (domain.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeneficiary", ctx, id)
+	ret0, _ := ret[0].(domain.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeneficiary indicates an expected call of GetBeneficiary.
+func (mr *MockBeneficiaryMockRecorder) GetBeneficiary(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiary", reflect.TypeOf((*MockBeneficiary)(nil).GetBeneficiary), ctx, id)
+}
+
+// ListBeneficiaries mocks base method.
+func (m *MockBeneficiary) ListBeneficiaries(ctx context.Context, filter domain.BeneficiaryFilter) ([]domain.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBeneficiaries", ctx, filter)
+	ret0, _ := ret[0].([]domain.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBeneficiaries indicates an expected call of ListBeneficiaries.
+func (mr *MockBeneficiaryMockRecorder) ListBeneficiaries(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBeneficiaries", reflect.TypeOf((*MockBeneficiary)(nil).ListBeneficiaries), ctx, filter)
+}
+
+// UpdateBeneficiary mocks base method.
+func (m *MockBeneficiary) UpdateBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) (domain.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBeneficiary", ctx, beneficiary)
+	ret0, _ := ret[0].(domain.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBeneficiary indicates an expected call of UpdateBeneficiary.
+func (mr *MockBeneficiaryMockRecorder) UpdateBeneficiary(ctx, beneficiary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBeneficiary", reflect.TypeOf((*MockBeneficiary)(nil).UpdateBeneficiary), ctx, beneficiary)
+}
+
+// MockVerificationCache is a mock of VerificationCache interface.
+type MockVerificationCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerificationCacheMockRecorder
+}
+
+// MockVerificationCacheMockRecorder is the mock recorder for MockVerificationCache.
+type MockVerificationCacheMockRecorder struct {
+	mock *MockVerificationCache
+}
+
+// NewMockVerificationCache creates a new mock instance.
+func NewMockVerificationCache(ctrl *gomock.Controller) *MockVerificationCache {
+	mock := &MockVerificationCache{ctrl: ctrl}
+	mock.recorder = &MockVerificationCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVerificationCache) EXPECT() *MockVerificationCacheMockRecorder {
+	return m.recorder
+}
+
+// EvictVerification mocks base method.
+func (m *MockVerificationCache) EvictVerification(ctx context.Context, bankCode, accountNumber, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvictVerification", ctx, bankCode, accountNumber, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EvictVerification indicates an expected call of EvictVerification.
+func (mr *MockVerificationCacheMockRecorder) EvictVerification(ctx, bankCode, accountNumber, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvictVerification", reflect.TypeOf((*MockVerificationCache)(nil).EvictVerification), ctx, bankCode, accountNumber, reason)
+}
+
+// MockCallbackReview is a mock of CallbackReview interface.
+type MockCallbackReview struct {
+	ctrl     *gomock.Controller
+	recorder *MockCallbackReviewMockRecorder
+}
+
+// MockCallbackReviewMockRecorder is the mock recorder for MockCallbackReview.
+type MockCallbackReviewMockRecorder struct {
+	mock *MockCallbackReview
+}
+
+// NewMockCallbackReview creates a new mock instance.
+func NewMockCallbackReview(ctrl *gomock.Controller) *MockCallbackReview {
+	mock := &MockCallbackReview{ctrl: ctrl}
+	mock.recorder = &MockCallbackReviewMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCallbackReview) EXPECT() *MockCallbackReviewMockRecorder {
+	return m.recorder
+}
+
+// AssignCallbackReview mocks base method.
+func (m *MockCallbackReview) AssignCallbackReview(ctx context.Context, id, assigneeId string) (domain.CallbackReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignCallbackReview", ctx, id, assigneeId)
+	ret0, _ := ret[0].(domain.CallbackReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignCallbackReview indicates an expected call of AssignCallbackReview.
+func (mr *MockCallbackReviewMockRecorder) AssignCallbackReview(ctx, id, assigneeId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignCallbackReview", reflect.TypeOf((*MockCallbackReview)(nil).AssignCallbackReview), ctx, id, assigneeId)
+}
+
+// CommentCallbackReview mocks base method.
+func (m *MockCallbackReview) CommentCallbackReview(ctx context.Context, id, body string) (domain.CallbackReviewComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommentCallbackReview", ctx, id, body)
+	ret0, _ := ret[0].(domain.CallbackReviewComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommentCallbackReview indicates an expected call of CommentCallbackReview.
+func (mr *MockCallbackReviewMockRecorder) CommentCallbackReview(ctx, id, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommentCallbackReview", reflect.TypeOf((*MockCallbackReview)(nil).CommentCallbackReview), ctx, id, body)
+}
+
+// CountOpenCallbackReviews mocks base method.
+func (m *MockCallbackReview) CountOpenCallbackReviews(ctx context.Context) ([]domain.CallbackReviewCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenCallbackReviews", ctx)
+	ret0, _ := ret[0].([]domain.CallbackReviewCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenCallbackReviews indicates an expected call of CountOpenCallbackReviews.
+func (mr *MockCallbackReviewMockRecorder) CountOpenCallbackReviews(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenCallbackReviews", reflect.TypeOf((*MockCallbackReview)(nil).CountOpenCallbackReviews), ctx)
+}
+
+// GetCallbackReview mocks base method.
+func (m *MockCallbackReview) GetCallbackReview(ctx context.Context, id string) (usecase.CallbackReviewDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCallbackReview", ctx, id)
+	ret0, _ := ret[0].(usecase.CallbackReviewDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCallbackReview indicates an expected call of GetCallbackReview.
+func (mr *MockCallbackReviewMockRecorder) GetCallbackReview(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCallbackReview", reflect.TypeOf((*MockCallbackReview)(nil).GetCallbackReview), ctx, id)
+}
+
+// ListCallbackReviews mocks base method.
+func (m *MockCallbackReview) ListCallbackReviews(ctx context.Context, filter domain.CallbackReviewFilter) ([]domain.CallbackReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCallbackReviews", ctx, filter)
+	ret0, _ := ret[0].([]domain.CallbackReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCallbackReviews indicates an expected call of ListCallbackReviews.
+func (mr *MockCallbackReviewMockRecorder) ListCallbackReviews(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCallbackReviews", reflect.TypeOf((*MockCallbackReview)(nil).ListCallbackReviews), ctx, filter)
+}
+
+// ResolveCallbackReview mocks base method.
+func (m *MockCallbackReview) ResolveCallbackReview(ctx context.Context, id string, resolution domain.CallbackReviewResolution, note string) (domain.CallbackReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveCallbackReview", ctx, id, resolution, note)
+	ret0, _ := ret[0].(domain.CallbackReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveCallbackReview indicates an expected call of ResolveCallbackReview.
+func (mr *MockCallbackReviewMockRecorder) ResolveCallbackReview(ctx, id, resolution, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCallbackReview", reflect.TypeOf((*MockCallbackReview)(nil).ResolveCallbackReview), ctx, id, resolution, note)
+}
+
+// MockScreener is a mock of Screener interface.
+type MockScreener struct {
+	ctrl     *gomock.Controller
+	recorder *MockScreenerMockRecorder
+}
+
+// MockScreenerMockRecorder is the mock recorder for MockScreener.
+type MockScreenerMockRecorder struct {
+	mock *MockScreener
+}
+
+// NewMockScreener creates a new mock instance.
+func NewMockScreener(ctrl *gomock.Controller) *MockScreener {
+	mock := &MockScreener{ctrl: ctrl}
+	mock.recorder = &MockScreenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScreener) EXPECT() *MockScreenerMockRecorder {
+	return m.recorder
+}
+
+// Reload mocks base method.
+func (m *MockScreener) Reload(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reload", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reload indicates an expected call of Reload.
+func (mr *MockScreenerMockRecorder) Reload(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reload", reflect.TypeOf((*MockScreener)(nil).Reload), ctx)
+}
+
+// Screen mocks base method.
+func (m *MockScreener) Screen(disbursement domain.Disbursement) []domain.ScreeningMatch {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Screen", disbursement)
+	ret0, _ := ret[0].([]domain.ScreeningMatch)
+	return ret0
+}
+
+// Screen indicates an expected call of Screen.
+func (mr *MockScreenerMockRecorder) Screen(disbursement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Screen", reflect.TypeOf((*MockScreener)(nil).Screen), disbursement)
+}
+
+// MockScreening is a mock of Screening interface.
+type MockScreening struct {
+	ctrl     *gomock.Controller
+	recorder *MockScreeningMockRecorder
+}
+
+// MockScreeningMockRecorder is the mock recorder for MockScreening.
+type MockScreeningMockRecorder struct {
+	mock *MockScreening
+}
+
+// NewMockScreening creates a new mock instance.
+func NewMockScreening(ctrl *gomock.Controller) *MockScreening {
+	mock := &MockScreening{ctrl: ctrl}
+	mock.recorder = &MockScreeningMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScreening) EXPECT() *MockScreeningMockRecorder {
+	return m.recorder
+}
+
+// AddBlocklistEntry mocks base method.
+func (m *MockScreening) AddBlocklistEntry(ctx context.Context, entry domain.ScreeningEntry) (domain.ScreeningEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBlocklistEntry", ctx, entry)
+	ret0, _ := ret[0].(domain.ScreeningEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBlocklistEntry indicates an expected call of AddBlocklistEntry.
+func (mr *MockScreeningMockRecorder) AddBlocklistEntry(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlocklistEntry", reflect.TypeOf((*MockScreening)(nil).AddBlocklistEntry), ctx, entry)
+}
+
+// DeleteBlocklistEntry mocks base method.
+func (m *MockScreening) DeleteBlocklistEntry(ctx context.Context, id, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlocklistEntry", ctx, id, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlocklistEntry indicates an expected call of DeleteBlocklistEntry.
+func (mr *MockScreeningMockRecorder) DeleteBlocklistEntry(ctx, id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlocklistEntry", reflect.TypeOf((*MockScreening)(nil).DeleteBlocklistEntry), ctx, id, reason)
+}
+
+// GetScreening mocks base method.
+func (m *MockScreening) GetScreening(ctx context.Context, id string) (usecase.ScreeningDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScreening", ctx, id)
+	ret0, _ := ret[0].(usecase.ScreeningDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScreening indicates an expected call of GetScreening.
+func (mr *MockScreeningMockRecorder) GetScreening(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScreening", reflect.TypeOf((*MockScreening)(nil).GetScreening), ctx, id)
+}
+
+// ImportScreeningList mocks base method.
+func (m *MockScreening) ImportScreeningList(ctx context.Context, listName string, format domain.ScreeningListFormat, file io.Reader) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportScreeningList", ctx, listName, format, file)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportScreeningList indicates an expected call of ImportScreeningList.
+func (mr *MockScreeningMockRecorder) ImportScreeningList(ctx, listName, format, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportScreeningList", reflect.TypeOf((*MockScreening)(nil).ImportScreeningList), ctx, listName, format, file)
+}
+
+// ListScreeningEntries mocks base method.
+func (m *MockScreening) ListScreeningEntries(ctx context.Context, filter domain.ScreeningEntryFilter) ([]domain.ScreeningEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScreeningEntries", ctx, filter)
+	ret0, _ := ret[0].([]domain.ScreeningEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScreeningEntries indicates an expected call of ListScreeningEntries.
+func (mr *MockScreeningMockRecorder) ListScreeningEntries(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScreeningEntries", reflect.TypeOf((*MockScreening)(nil).ListScreeningEntries), ctx, filter)
+}
+
+// ListScreenings mocks base method.
+func (m *MockScreening) ListScreenings(ctx context.Context, filter domain.ScreeningFilter) ([]domain.Screening, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScreenings", ctx, filter)
+	ret0, _ := ret[0].([]domain.Screening)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScreenings indicates an expected call of ListScreenings.
+func (mr *MockScreeningMockRecorder) ListScreenings(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScreenings", reflect.TypeOf((*MockScreening)(nil).ListScreenings), ctx, filter)
+}
+
+// ResolveScreening mocks base method.
+func (m *MockScreening) ResolveScreening(ctx context.Context, id string, resolution domain.ScreeningResolution, note string) (usecase.ScreeningDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveScreening", ctx, id, resolution, note)
+	ret0, _ := ret[0].(usecase.ScreeningDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveScreening indicates an expected call of ResolveScreening.
+func (mr *MockScreeningMockRecorder) ResolveScreening(ctx, id, resolution, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveScreening", reflect.TypeOf((*MockScreening)(nil).ResolveScreening), ctx, id, resolution, note)
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhookEndpoint mocks base method.
+func (m *MockWebhook) CreateWebhookEndpoint(ctx context.Context, endpoint domain.WebhookEndpoint) (domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookEndpoint", ctx, endpoint)
+	ret0, _ := ret[0].(domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookEndpoint indicates an expected call of CreateWebhookEndpoint.
+func (mr *MockWebhookMockRecorder) CreateWebhookEndpoint(ctx, endpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookEndpoint", reflect.TypeOf((*MockWebhook)(nil).CreateWebhookEndpoint), ctx, endpoint)
+}
+
+// DeleteWebhookEndpoint mocks base method.
+func (m *MockWebhook) DeleteWebhookEndpoint(ctx context.Context, merchantId, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookEndpoint", ctx, merchantId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhookEndpoint indicates an expected call of DeleteWebhookEndpoint.
+func (mr *MockWebhookMockRecorder) DeleteWebhookEndpoint(ctx, merchantId, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookEndpoint", reflect.TypeOf((*MockWebhook)(nil).DeleteWebhookEndpoint), ctx, merchantId, id)
+}
+
+// DeliverDueWebhooks mocks base method.
+func (m *MockWebhook) DeliverDueWebhooks(ctx context.Context, batchSize int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverDueWebhooks", ctx, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverDueWebhooks indicates an expected call of DeliverDueWebhooks.
+func (mr *MockWebhookMockRecorder) DeliverDueWebhooks(ctx, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverDueWebhooks", reflect.TypeOf((*MockWebhook)(nil).DeliverDueWebhooks), ctx, batchSize)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockWebhook) GetWebhookDelivery(ctx context.Context, id string) (usecase.WebhookDeliveryDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", ctx, id)
+	ret0, _ := ret[0].(usecase.WebhookDeliveryDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockWebhookMockRecorder) GetWebhookDelivery(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockWebhook)(nil).GetWebhookDelivery), ctx, id)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockWebhook) ListWebhookDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", ctx, filter)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockWebhookMockRecorder) ListWebhookDeliveries(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockWebhook)(nil).ListWebhookDeliveries), ctx, filter)
+}
+
+// ListWebhookEndpoints mocks base method.
+func (m *MockWebhook) ListWebhookEndpoints(ctx context.Context, merchantId string) ([]domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookEndpoints", ctx, merchantId)
+	ret0, _ := ret[0].([]domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookEndpoints indicates an expected call of ListWebhookEndpoints.
+func (mr *MockWebhookMockRecorder) ListWebhookEndpoints(ctx, merchantId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookEndpoints", reflect.TypeOf((*MockWebhook)(nil).ListWebhookEndpoints), ctx, merchantId)
+}
+
+// ReplayWebhookDelivery mocks base method.
+func (m *MockWebhook) ReplayWebhookDelivery(ctx context.Context, id string) (domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayWebhookDelivery", ctx, id)
+	ret0, _ := ret[0].(domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayWebhookDelivery indicates an expected call of ReplayWebhookDelivery.
+func (mr *MockWebhookMockRecorder) ReplayWebhookDelivery(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayWebhookDelivery", reflect.TypeOf((*MockWebhook)(nil).ReplayWebhookDelivery), ctx, id)
+}
+
+// UpdateWebhookEndpoint mocks base method.
+func (m *MockWebhook) UpdateWebhookEndpoint(ctx context.Context, endpoint domain.WebhookEndpoint) (domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookEndpoint", ctx, endpoint)
+	ret0, _ := ret[0].(domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhookEndpoint indicates an expected call of UpdateWebhookEndpoint.
+func (mr *MockWebhookMockRecorder) UpdateWebhookEndpoint(ctx, endpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookEndpoint", reflect.TypeOf((*MockWebhook)(nil).UpdateWebhookEndpoint), ctx, endpoint)
+}
+
+// MockEventRelay is a mock of EventRelay interface.
+type MockEventRelay struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRelayMockRecorder
+}
+
+// MockEventRelayMockRecorder is the mock recorder for MockEventRelay.
+type MockEventRelayMockRecorder struct {
+	mock *MockEventRelay
+}
+
+// NewMockEventRelay creates a new mock instance.
+func NewMockEventRelay(ctrl *gomock.Controller) *MockEventRelay {
+	mock := &MockEventRelay{ctrl: ctrl}
+	mock.recorder = &MockEventRelayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRelay) EXPECT() *MockEventRelayMockRecorder {
+	return m.recorder
+}
+
+// DeletePublishedEvents mocks base method.
+func (m *MockEventRelay) DeletePublishedEvents(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublishedEvents", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePublishedEvents indicates an expected call of DeletePublishedEvents.
+func (mr *MockEventRelayMockRecorder) DeletePublishedEvents(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublishedEvents", reflect.TypeOf((*MockEventRelay)(nil).DeletePublishedEvents), ctx, before)
+}
+
+// PublishDueEvents mocks base method.
+func (m *MockEventRelay) PublishDueEvents(ctx context.Context, batchSize int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDueEvents", ctx, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishDueEvents indicates an expected call of PublishDueEvents.
+func (mr *MockEventRelayMockRecorder) PublishDueEvents(ctx, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDueEvents", reflect.TypeOf((*MockEventRelay)(nil).PublishDueEvents), ctx, batchSize)
+}
+
+// MockApiKey is a mock of ApiKey interface.
+type MockApiKey struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyMockRecorder
+}
+
+// MockApiKeyMockRecorder is the mock recorder for MockApiKey.
+type MockApiKeyMockRecorder struct {
+	mock *MockApiKey
+}
+
+// NewMockApiKey creates a new mock instance.
+func NewMockApiKey(ctrl *gomock.Controller) *MockApiKey {
+	mock := &MockApiKey{ctrl: ctrl}
+	mock.recorder = &MockApiKeyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKey) EXPECT() *MockApiKeyMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockApiKey) Authenticate(ctx context.Context, key string) (domain.Caller, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, key)
+	ret0, _ := ret[0].(domain.Caller)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockApiKeyMockRecorder) Authenticate(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockApiKey)(nil).Authenticate), ctx, key)
+}
+
+// Import mocks base method.
+func (m *MockApiKey) Import(ctx context.Context, key string, data usecase.IssueApiKeyData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, key, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockApiKeyMockRecorder) Import(ctx, key, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockApiKey)(nil).Import), ctx, key, data)
+}
+
+// Issue mocks base method.
+func (m *MockApiKey) Issue(ctx context.Context, data usecase.IssueApiKeyData) (usecase.IssuedApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", ctx, data)
+	ret0, _ := ret[0].(usecase.IssuedApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockApiKeyMockRecorder) Issue(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockApiKey)(nil).Issue), ctx, data)
+}
+
+// List mocks base method.
+func (m *MockApiKey) List(ctx context.Context, clientId string) ([]domain.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, clientId)
+	ret0, _ := ret[0].([]domain.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockApiKeyMockRecorder) List(ctx, clientId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockApiKey)(nil).List), ctx, clientId)
+}
+
+// Revoke mocks base method.
+func (m *MockApiKey) Revoke(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockApiKeyMockRecorder) Revoke(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockApiKey)(nil).Revoke), ctx, id)
+}
+
+// Rotate mocks base method.
+func (m *MockApiKey) Rotate(ctx context.Context, id string, overlap time.Duration) (usecase.IssuedApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, id, overlap)
+	ret0, _ := ret[0].(usecase.IssuedApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockApiKeyMockRecorder) Rotate(ctx, id, overlap any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockApiKey)(nil).Rotate), ctx, id, overlap)
+}
+
+// MockMerchant is a mock of Merchant interface.
+type MockMerchant struct {
+	ctrl     *gomock.Controller
+	recorder *MockMerchantMockRecorder
+}
+
+// MockMerchantMockRecorder is the mock recorder for MockMerchant.
+type MockMerchantMockRecorder struct {
+	mock *MockMerchant
+}
+
+// NewMockMerchant creates a new mock instance.
+func NewMockMerchant(ctrl *gomock.Controller) *MockMerchant {
+	mock := &MockMerchant{ctrl: ctrl}
+	mock.recorder = &MockMerchantMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMerchant) EXPECT() *MockMerchantMockRecorder {
+	return m.recorder
+}
+
+// CreateMerchant mocks base method.
+func (m *MockMerchant) CreateMerchant(ctx context.Context, merchant domain.Merchant) (domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchant", ctx, merchant)
+	ret0, _ := ret[0].(domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMerchant indicates an expected call of CreateMerchant.
+func (mr *MockMerchantMockRecorder) CreateMerchant(ctx, merchant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchant", reflect.TypeOf((*MockMerchant)(nil).CreateMerchant), ctx, merchant)
+}
+
+// GetMerchant mocks base method.
+func (m *MockMerchant) GetMerchant(ctx context.Context, id string) (domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchant", ctx, id)
+	ret0, _ := ret[0].(domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchant indicates an expected call of GetMerchant.
+func (mr *MockMerchantMockRecorder) GetMerchant(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchant", reflect.TypeOf((*MockMerchant)(nil).GetMerchant), ctx, id)
+}
+
+// ListMerchants mocks base method.
+func (m *MockMerchant) ListMerchants(ctx context.Context) ([]domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchants", ctx)
+	ret0, _ := ret[0].([]domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchants indicates an expected call of ListMerchants.
+func (mr *MockMerchantMockRecorder) ListMerchants(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchants", reflect.TypeOf((*MockMerchant)(nil).ListMerchants), ctx)
+}
+
+// UpdateMerchant mocks base method.
+func (m *MockMerchant) UpdateMerchant(ctx context.Context, merchant domain.Merchant) (domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMerchant", ctx, merchant)
+	ret0, _ := ret[0].(domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMerchant indicates an expected call of UpdateMerchant.
+func (mr *MockMerchantMockRecorder) UpdateMerchant(ctx, merchant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMerchant", reflect.TypeOf((*MockMerchant)(nil).UpdateMerchant), ctx, merchant)
+}
+
+// MockAudit is a mock of Audit interface.
+type MockAudit struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditMockRecorder
+}
+
+// MockAuditMockRecorder is the mock recorder for MockAudit.
+type MockAuditMockRecorder struct {
+	mock *MockAudit
+}
+
+// NewMockAudit creates a new mock instance.
+func NewMockAudit(ctrl *gomock.Controller) *MockAudit {
+	mock := &MockAudit{ctrl: ctrl}
+	mock.recorder = &MockAuditMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAudit) EXPECT() *MockAuditMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockAudit) List(ctx context.Context, filter domain.AuditLogFilter) ([]domain.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAuditMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAudit)(nil).List), ctx, filter)
+}
+
+// Record mocks base method.
+func (m *MockAudit) Record(ctx context.Context, auditLog domain.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, auditLog)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditMockRecorder) Record(ctx, auditLog any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAudit)(nil).Record), ctx, auditLog)
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/nobbyphala/Brick/config"
+	"github.com/nobbyphala/Brick/external/crypto"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/external/migration"
+	"github.com/nobbyphala/Brick/migrations"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"github.com/nobbyphala/Brick/usecase/repository/memory"
+)
+
+type repositories struct {
+	disbursement repository.Disbursement
+	// status changes of the disbursements are recorded by the disbursement repository, listener notify the commits
+	disbursementStatusEvent         repository.DisbursementStatusEvent
+	disbursementStatusEventListener repository.DisbursementStatusEventListener
+	apiKey                          repository.ApiKey
+	merchant                        repository.Merchant
+	auditLog                        repository.AuditLog
+	callbackReview                  repository.CallbackReview
+	webhookEndpoint                 repository.WebhookEndpoint
+	webhookDelivery                 repository.WebhookDelivery
+	outbox                          repository.Outbox
+	beneficiary                     repository.Beneficiary
+	screeningEntry                  repository.ScreeningEntry
+	screening                       repository.Screening
+	// shared verification cache, nil with the memory driver
+	verificationCache repository.VerificationCache
+	utils             repository.Utils
+	// closed when the application shutting down
+	closer io.Closer
+}
+
+func newRepositories(cfg config.DatabaseConfig, encryptor crypto.FieldEncryptor) (repositories, error) {
+	if cfg.Driver == config.DatabaseDriverMemory {
+		return newMemoryRepositories(), nil
+	}
+
+	return newPostgresRepositories(cfg, encryptor)
+}
+
+func newPostgresRepositories(cfg config.DatabaseConfig, encryptor crypto.FieldEncryptor) (repositories, error) {
+	db, err := newPostgresDB(cfg)
+	if err != nil {
+		return repositories{}, err
+	}
+
+	if cfg.AutoMigrate {
+		_, err = newMigrator(db).Up(context.Background())
+		if err != nil {
+			db.Close()
+			return repositories{}, err
+		}
+	}
+
+	postgresSql := database.NewPostgresSqlClient(database.PostgresSQLOpts{
+		DB: db,
+	})
+
+	return repositories{
+		disbursement: repository.NewDisbursement(repository.DisbursementDeps{
+			DB:        postgresSql,
+			Encryptor: encryptor,
+		}),
+		disbursementStatusEvent: repository.NewDisbursementStatusEvent(repository.DisbursementStatusEventDeps{
+			DB: postgresSql,
+		}),
+		// channel notified by disbursement_status_event_trigger
+		disbursementStatusEventListener: database.NewPostgresListener(database.PostgresListenerOpts{
+			DB:      db,
+			Channel: "disbursement_status_event",
+		}),
+		apiKey: repository.NewApiKey(repository.ApiKeyDeps{
+			DB: postgresSql,
+		}),
+		merchant: repository.NewMerchant(repository.MerchantDeps{
+			DB: postgresSql,
+		}),
+		auditLog: repository.NewAuditLog(repository.AuditLogDeps{
+			DB: postgresSql,
+		}),
+		callbackReview: repository.NewCallbackReview(repository.CallbackReviewDeps{
+			DB: postgresSql,
+		}),
+		webhookEndpoint: repository.NewWebhookEndpoint(repository.WebhookEndpointDeps{
+			DB:        postgresSql,
+			Encryptor: encryptor,
+		}),
+		webhookDelivery: repository.NewWebhookDelivery(repository.WebhookDeliveryDeps{
+			DB: postgresSql,
+		}),
+		outbox: repository.NewOutbox(repository.OutboxDeps{
+			DB: postgresSql,
+		}),
+		beneficiary: repository.NewBeneficiary(repository.BeneficiaryDeps{
+			DB:        postgresSql,
+			Encryptor: encryptor,
+		}),
+		screeningEntry: repository.NewScreeningEntry(repository.ScreeningEntryDeps{
+			DB:        postgresSql,
+			Encryptor: encryptor,
+		}),
+		screening: repository.NewScreening(repository.ScreeningDeps{
+			DB:        postgresSql,
+			Encryptor: encryptor,
+		}),
+		verificationCache: repository.NewVerificationCache(repository.VerificationCacheDeps{
+			DB:        postgresSql,
+			Encryptor: encryptor,
+		}),
+		utils: repository.NewRepositoryUtils(repository.UtilsOpts{
+			DB: postgresSql,
+		}),
+		closer: postgresSql,
+	}, nil
+}
+
+func newMemoryRepositories() repositories {
+	store := memory.NewStore()
+
+	return repositories{
+		disbursement: memory.NewDisbursement(memory.DisbursementDeps{
+			Store: store,
+		}),
+		disbursementStatusEvent: memory.NewDisbursementStatusEvent(memory.DisbursementStatusEventDeps{
+			Store: store,
+		}),
+		disbursementStatusEventListener: memory.NewDisbursementStatusEventListener(memory.DisbursementStatusEventListenerDeps{
+			Store: store,
+		}),
+		apiKey: memory.NewApiKey(memory.ApiKeyDeps{
+			Store: store,
+		}),
+		merchant: memory.NewMerchant(memory.MerchantDeps{
+			Store: store,
+		}),
+		auditLog: memory.NewAuditLog(memory.AuditLogDeps{
+			Store: store,
+		}),
+		callbackReview: memory.NewCallbackReview(memory.CallbackReviewDeps{
+			Store: store,
+		}),
+		webhookEndpoint: memory.NewWebhookEndpoint(memory.WebhookEndpointDeps{
+			Store: store,
+		}),
+		webhookDelivery: memory.NewWebhookDelivery(memory.WebhookDeliveryDeps{
+			Store: store,
+		}),
+		outbox: memory.NewOutbox(memory.OutboxDeps{
+			Store: store,
+		}),
+		beneficiary: memory.NewBeneficiary(memory.BeneficiaryDeps{
+			Store: store,
+		}),
+		screeningEntry: memory.NewScreeningEntry(memory.ScreeningEntryDeps{
+			Store: store,
+		}),
+		screening: memory.NewScreening(memory.ScreeningDeps{
+			Store: store,
+		}),
+		utils: memory.NewRepositoryUtils(memory.UtilsOpts{
+			Store: store,
+		}),
+		closer: store,
+	}
+}
+
+// newVerificationCache return nil when the verification results are not cached
+func newVerificationCache(cfg config.VerificationConfig, repos repositories) repository.VerificationCache {
+	switch cfg.Cache {
+	case config.VerificationCacheLRU:
+		return memory.NewVerificationCache(memory.VerificationCacheDeps{
+			Size: cfg.CacheSize,
+		})
+	case config.VerificationCachePostgres:
+		return repos.verificationCache
+	default:
+		return nil
+	}
+}
+
+// newFieldEncryptor return encryptor for sensitive columns, data is kept as plain text when no key file configured
+func newFieldEncryptor(cfg config.EncryptionConfig) (crypto.FieldEncryptor, error) {
+	if cfg.KeyFile == "" {
+		log.Println("warning: encryption.key_file is not set, recipient data will be stored unencrypted")
+		return crypto.NewPlaintextEncryptor(), nil
+	}
+
+	keyProvider, err := crypto.NewLocalFileKeyProvider(crypto.LocalFileKeyProviderOpts{
+		Path: cfg.KeyFile,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.NewEnvelopeEncryptor(crypto.EnvelopeEncryptorOpts{
+		KeyProvider: keyProvider,
+	}), nil
+}
+
+func newPostgresDB(cfg config.DatabaseConfig) (*sqlx.DB, error) {
+	return database.NewPostgresDB(database.ConnectionOption{
+		Host:     cfg.Host,
+		Port:     cfg.Port,
+		User:     cfg.User,
+		Password: cfg.Password,
+		Database: cfg.Database,
+	})
+}
+
+func newMigrator(db *sqlx.DB) migration.Migrator {
+	return migration.NewPostgresMigrator(migration.MigratorOpts{
+		DB:     db,
+		Source: migrations.FS,
+	})
+}
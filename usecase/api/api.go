@@ -5,4 +5,24 @@ import "context"
 type Bank interface {
 	VerifyAccount(ctx context.Context, account VerifyAccountRequest) (VerifyAccountResponse, error)
 	TransferMoney(ctx context.Context, transfer TransferRequest) (TransferResponse, error)
+	// CheckTransferStatus ask the bank the current status of a transfer, used when the callback is missed or disputed
+	CheckTransferStatus(ctx context.Context, transferStatus TransferStatusRequest) (TransferStatusResponse, error)
+}
+
+// Webhook send signed event to the merchant webhook endpoint
+type Webhook interface {
+	// Send return error only when no response received, non 2xx response is returned as is
+	Send(ctx context.Context, request WebhookRequest) (WebhookResponse, error)
+}
+
+// Publisher send domain events to other services. The same event can be published more than once, consumers
+// should ignore event id they have seen
+type Publisher interface {
+	Publish(ctx context.Context, event EventMessage) error
+}
+
+// NameMatcher compare the recipient name written by the merchant with the account holder name reported by the bank
+type NameMatcher interface {
+	// Score return 0 for unrelated names up to 100 for the same name
+	Score(name string, other string) int
 }
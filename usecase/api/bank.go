@@ -2,23 +2,30 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/nobbyphala/Brick/external/http_request"
+	"github.com/nobbyphala/Brick/external/pii"
+	"log"
 )
 
 type bankApiClient struct {
 	baseUrl     string
 	httpRequest http_request.HTTPRequest
+	debugLog    bool
 }
 
 type BankApiClientOpts struct {
 	BaseUrl     string
 	HttpRequest http_request.HTTPRequest
+	// log request and response body, account number and name are redacted
+	DebugLog bool
 }
 
 func NewBankApiClient(opts BankApiClientOpts) *bankApiClient {
 	return &bankApiClient{
 		baseUrl:     opts.BaseUrl,
 		httpRequest: opts.HttpRequest,
+		debugLog:    opts.DebugLog,
 	}
 }
 
@@ -26,10 +33,12 @@ func (cl bankApiClient) VerifyAccount(ctx context.Context, account VerifyAccount
 	url := cl.baseUrl + "/verify"
 	var response VerifyAccountResponse
 
+	cl.logDebug("bank request", url, account)
 	err := cl.httpRequest.Post(ctx, url, nil, account, &response)
 	if err != nil {
 		return response, err
 	}
+	cl.logDebug("bank response", url, response)
 
 	return response, nil
 }
@@ -38,10 +47,40 @@ func (cl bankApiClient) TransferMoney(ctx context.Context, transfer TransferRequ
 	url := cl.baseUrl + "/transfer"
 	var response TransferResponse
 
+	cl.logDebug("bank request", url, transfer)
 	err := cl.httpRequest.Post(ctx, url, nil, transfer, &response)
 	if err != nil {
 		return response, err
 	}
+	cl.logDebug("bank response", url, response)
 
 	return response, nil
 }
+
+func (cl bankApiClient) CheckTransferStatus(ctx context.Context, transferStatus TransferStatusRequest) (TransferStatusResponse, error) {
+	url := cl.baseUrl + "/transfer/status"
+	var response TransferStatusResponse
+
+	cl.logDebug("bank request", url, transferStatus)
+	err := cl.httpRequest.Post(ctx, url, nil, transferStatus, &response)
+	if err != nil {
+		return response, err
+	}
+	cl.logDebug("bank response", url, response)
+
+	return response, nil
+}
+
+func (cl bankApiClient) logDebug(message string, url string, body interface{}) {
+	if !cl.debugLog {
+		return
+	}
+
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		log.Println(message, url, "error marshal body:", err)
+		return
+	}
+
+	log.Println(message, url, pii.Redact(string(jsonBody)))
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/nobbyphala/Brick/external/http_request"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	EventIdHeader   = "X-Brick-Event-Id"
+	EventTypeHeader = "X-Brick-Event-Type"
+)
+
+// filePublisher append every event as a json line to a file, for local development or to be shipped by a log
+// collector
+type filePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+type FilePublisherOpts struct {
+	Path string
+}
+
+func NewFilePublisher(opts FilePublisherOpts) (*filePublisher, error) {
+	file, err := os.OpenFile(opts.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filePublisher{
+		file: file,
+	}, nil
+}
+
+func (fp *filePublisher) Publish(ctx context.Context, event EventMessage) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	_, err = fp.file.Write(append(line, '\n'))
+	if err != nil {
+		return err
+	}
+
+	// the event is marked published after this return, make sure it survive a crash
+	return fp.file.Sync()
+}
+
+func (fp *filePublisher) Close() error {
+	return fp.file.Close()
+}
+
+// httpPublisher POST every event as json to the url, any 2xx response acknowledge the event
+type httpPublisher struct {
+	httpRequest http_request.HTTPRequest
+	url         string
+	timeout     time.Duration
+}
+
+type HttpPublisherOpts struct {
+	HttpRequest http_request.HTTPRequest
+	Url         string
+	// maximum time waiting the response
+	Timeout time.Duration
+}
+
+func NewHttpPublisher(opts HttpPublisherOpts) *httpPublisher {
+	return &httpPublisher{
+		httpRequest: opts.HttpRequest,
+		url:         opts.Url,
+		timeout:     opts.Timeout,
+	}
+}
+
+func (hp httpPublisher) Publish(ctx context.Context, event EventMessage) error {
+	ctx, cancel := context.WithTimeout(ctx, hp.timeout)
+	defer cancel()
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{
+		EventIdHeader:   strconv.FormatInt(event.Id, 10),
+		EventTypeHeader: event.Type,
+	}
+
+	statusCode, err := hp.httpRequest.PostRaw(ctx, hp.url, headers, body)
+	if err != nil {
+		return err
+	}
+
+	if statusCode < 200 || statusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", statusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEventMessage(id int64) EventMessage {
+	return EventMessage{
+		Id:          id,
+		Type:        "DisbursementCreated",
+		AggregateId: "disb-1",
+		OccurredAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Data:        json.RawMessage(`{"disbursement_id":"disb-1"}`),
+	}
+}
+
+func Test_filePublisher_Publish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	publisher, err := NewFilePublisher(FilePublisherOpts{Path: path})
+	assert.NoError(t, err)
+
+	assert.NoError(t, publisher.Publish(context.TODO(), newTestEventMessage(1)))
+	assert.NoError(t, publisher.Publish(context.TODO(), newTestEventMessage(2)))
+	assert.NoError(t, publisher.Close())
+
+	got, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":1,"type":"DisbursementCreated","aggregate_id":"disb-1","occurred_at":"2024-01-01T00:00:00Z","data":{"disbursement_id":"disb-1"}}
+{"id":2,"type":"DisbursementCreated","aggregate_id":"disb-1","occurred_at":"2024-01-01T00:00:00Z","data":{"disbursement_id":"disb-1"}}
+`, string(got))
+}
+
+func Test_httpPublisher_Publish(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		err        error
+		wantErr    error
+	}{
+		{
+			name:       "acknowledged",
+			statusCode: 202,
+		},
+		{
+			name:       "rejected",
+			statusCode: 503,
+			wantErr:    errors.New("unexpected status code 503"),
+		},
+		{
+			name:    "no response",
+			err:     errors.New("connection refused"),
+			wantErr: errors.New("connection refused"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpRequest := &fakeHttpRequest{statusCode: tt.statusCode, err: tt.err}
+			publisher := NewHttpPublisher(HttpPublisherOpts{HttpRequest: httpRequest, Url: "https://events.test/ingest", Timeout: time.Second})
+
+			err := publisher.Publish(context.TODO(), newTestEventMessage(1))
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, "https://events.test/ingest", httpRequest.url)
+			assert.Equal(t, map[string]string{EventIdHeader: "1", EventTypeHeader: "DisbursementCreated"}, httpRequest.headers)
+			assert.JSONEq(t, `{"id":1,"type":"DisbursementCreated","aggregate_id":"disb-1","occurred_at":"2024-01-01T00:00:00Z","data":{"disbursement_id":"disb-1"}}`, string(httpRequest.body))
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package api
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // some of these request and response struct is based on experienced in bank integration
 type VerifyAccountStatus string
 type TransferStatus string
@@ -20,6 +25,7 @@ const (
 type VerifyAccountRequest struct {
 	AccountHolderName   string `json:"account_holder_name"`
 	AccountHolderNumber string `json:"account_holder_number"`
+	BankCode            string `json:"bank_code"`
 }
 
 type VerifyAccountResponse struct {
@@ -43,3 +49,33 @@ type TransferResponse struct {
 	Amount              int            `json:"amount"`
 	TransferStatus      TransferStatus `json:"transfer_status"`
 }
+
+type TransferStatusRequest struct {
+	TransactionId string `json:"transaction_id"`
+}
+
+type TransferStatusResponse struct {
+	TransactionId  string         `json:"transaction_id"`
+	TransferStatus TransferStatus `json:"transfer_status"`
+}
+
+// EventMessage is the envelope of a published domain event, Data is the event as json
+type EventMessage struct {
+	Id          int64           `json:"id"`
+	Type        string          `json:"type"`
+	AggregateId string          `json:"aggregate_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Data        json.RawMessage `json:"data"`
+}
+
+type WebhookRequest struct {
+	Url        string
+	Secret     string
+	DeliveryId string
+	EventType  string
+	Payload    []byte
+}
+
+type WebhookResponse struct {
+	StatusCode int
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/nobbyphala/Brick/external/http_request"
+	"strconv"
+	"time"
+)
+
+const (
+	WebhookEventHeader     = "X-Brick-Event"
+	WebhookDeliveryHeader  = "X-Brick-Delivery"
+	WebhookSignatureHeader = "X-Brick-Signature"
+)
+
+type webhookClient struct {
+	httpRequest http_request.HTTPRequest
+	timeout     time.Duration
+	now         func() time.Time
+}
+
+type WebhookClientOpts struct {
+	HttpRequest http_request.HTTPRequest
+	// maximum time waiting the merchant response
+	Timeout time.Duration
+}
+
+func NewWebhookClient(opts WebhookClientOpts) *webhookClient {
+	return &webhookClient{
+		httpRequest: opts.HttpRequest,
+		timeout:     opts.Timeout,
+		now:         time.Now,
+	}
+}
+
+func (cl webhookClient) Send(ctx context.Context, request WebhookRequest) (WebhookResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, cl.timeout)
+	defer cancel()
+
+	headers := map[string]string{
+		WebhookEventHeader:     request.EventType,
+		WebhookDeliveryHeader:  request.DeliveryId,
+		WebhookSignatureHeader: SignWebhook(request.Secret, cl.now(), request.Payload),
+	}
+
+	statusCode, err := cl.httpRequest.PostRaw(ctx, request.Url, headers, request.Payload)
+	if err != nil {
+		return WebhookResponse{}, err
+	}
+
+	return WebhookResponse{StatusCode: statusCode}, nil
+}
+
+// SignWebhook return the signature header value t=<unix time>,v1=<hex hmac-sha256 of "<unix time>.<payload>">.
+// The merchant recompute v1 with the endpoint secret and reject old t to prevent replay
+func SignWebhook(secret string, timestamp time.Time, payload []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return fmt.Sprintf("t=%s,v1=%s", unix, hex.EncodeToString(mac.Sum(nil)))
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeHttpRequest struct {
+	url        string
+	headers    map[string]string
+	body       []byte
+	statusCode int
+	err        error
+}
+
+func (fake *fakeHttpRequest) Post(ctx context.Context, url string, header map[string]string, body interface{}, response interface{}) error {
+	return errors.New("not implemented")
+}
+
+func (fake *fakeHttpRequest) PostRaw(ctx context.Context, url string, header map[string]string, body []byte) (int, error) {
+	fake.url = url
+	fake.headers = header
+	fake.body = body
+
+	return fake.statusCode, fake.err
+}
+
+func Test_webhookClient_Send(t *testing.T) {
+	httpRequest := &fakeHttpRequest{statusCode: 500}
+	client := NewWebhookClient(WebhookClientOpts{HttpRequest: httpRequest, Timeout: time.Second})
+	client.now = func() time.Time {
+		return time.Unix(1700000000, 0)
+	}
+
+	got, err := client.Send(context.TODO(), WebhookRequest{
+		Url:        "https://merchant.test/webhook",
+		Secret:     "whsec_secret",
+		DeliveryId: "delivery-1",
+		EventType:  "disbursement.completed",
+		Payload:    []byte(`{"id":"disb-1:v2"}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, WebhookResponse{StatusCode: 500}, got)
+	assert.Equal(t, "https://merchant.test/webhook", httpRequest.url)
+	assert.Equal(t, []byte(`{"id":"disb-1:v2"}`), httpRequest.body)
+	assert.Equal(t, map[string]string{
+		"X-Brick-Event":     "disbursement.completed",
+		"X-Brick-Delivery":  "delivery-1",
+		"X-Brick-Signature": SignWebhook("whsec_secret", time.Unix(1700000000, 0), []byte(`{"id":"disb-1:v2"}`)),
+	}, httpRequest.headers)
+}
+
+func Test_SignWebhook(t *testing.T) {
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	got := SignWebhook("secret", time.Unix(1700000000, 0), []byte(`{}`))
+	assert.Equal(t, "t=1700000000,v1=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163", got)
+}
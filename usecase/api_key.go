@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"log"
+	"strings"
+	"time"
+)
+
+// api key format is brk_<prefix>.<secret>, the prefix is stored as is to find the key and the secret is stored hashed
+const (
+	apiKeyScheme     = "brk_"
+	apiKeyPrefixSize = 6
+	apiKeySecretSize = 32
+
+	// last used time is only written when it is older than this, so not every request write to the database
+	apiKeyLastUsedPrecision = time.Minute
+)
+
+var errInvalidApiKeyFormat = errors.New("invalid api key format")
+
+type apiKeyUsecase struct {
+	apiKeyRepository   repository.ApiKey
+	merchantRepository repository.Merchant
+	utilsRepository    repository.Utils
+}
+
+type ApiKeyDeps struct {
+	ApiKeyRepository   repository.ApiKey
+	MerchantRepository repository.Merchant
+	UtilsRepository    repository.Utils
+}
+
+func NewApiKey(deps ApiKeyDeps) *apiKeyUsecase {
+	return &apiKeyUsecase{
+		apiKeyRepository:   deps.ApiKeyRepository,
+		merchantRepository: deps.MerchantRepository,
+		utilsRepository:    deps.UtilsRepository,
+	}
+}
+
+func (ak apiKeyUsecase) Authenticate(ctx context.Context, key string) (domain.Caller, error) {
+	prefix, secret, err := parseApiKey(key)
+	if err != nil {
+		return domain.Caller{}, internal_error.ErrUnauthorized
+	}
+
+	apiKey, err := ak.apiKeyRepository.GetByPrefix(ctx, prefix)
+	if err != nil {
+		log.Println(err)
+		return domain.Caller{}, err
+	}
+
+	if apiKey == nil || subtle.ConstantTimeCompare([]byte(apiKey.Hash), []byte(hashApiKeySecret(secret))) != 1 {
+		return domain.Caller{}, internal_error.ErrUnauthorized
+	}
+
+	now := time.Now().UTC()
+	if !apiKey.IsActive(now) {
+		return domain.Caller{}, internal_error.ErrUnauthorized
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyLastUsedPrecision {
+		// tracking failure should not reject the request
+		err = ak.apiKeyRepository.UpdateLastUsedAt(ctx, apiKey.Id, now)
+		if err != nil {
+			log.Println("error updating api key last used:", err)
+		}
+	}
+
+	return domain.Caller{
+		Id:         apiKey.ClientId,
+		Role:       apiKey.Role,
+		ApiKeyId:   apiKey.Id,
+		MerchantId: apiKey.MerchantId,
+	}, nil
+}
+
+func (ak apiKeyUsecase) Issue(ctx context.Context, data IssueApiKeyData) (IssuedApiKey, error) {
+	err := ak.validateIssueData(ctx, data)
+	if err != nil {
+		return IssuedApiKey{}, err
+	}
+
+	key, err := generateApiKey()
+	if err != nil {
+		return IssuedApiKey{}, err
+	}
+
+	apiKey, err := ak.insert(ctx, ak.apiKeyRepository, key, data)
+	if err != nil {
+		return IssuedApiKey{}, err
+	}
+
+	return IssuedApiKey{
+		ApiKey: apiKey,
+		Key:    key,
+	}, nil
+}
+
+func (ak apiKeyUsecase) Import(ctx context.Context, key string, data IssueApiKeyData) error {
+	err := ak.validateIssueData(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	prefix, _, err := parseApiKey(key)
+	if err != nil {
+		return err
+	}
+
+	existing, err := ak.apiKeyRepository.GetByPrefix(ctx, prefix)
+	if err != nil || existing != nil {
+		return err
+	}
+
+	_, err = ak.insert(ctx, ak.apiKeyRepository, key, data)
+	return err
+}
+
+func (ak apiKeyUsecase) Rotate(ctx context.Context, id string, overlap time.Duration) (IssuedApiKey, error) {
+	var res IssuedApiKey
+
+	err := ak.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+		apiKeyRepo := ak.apiKeyRepository.WithTx(Tx)
+
+		oldKey, err := apiKeyRepo.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if oldKey == nil {
+			return internal_error.ErrApiKeyNotFound
+		}
+
+		if oldKey.RevokedAt != nil {
+			return internal_error.ErrApiKeyRevoked
+		}
+
+		key, err := generateApiKey()
+		if err != nil {
+			return err
+		}
+
+		newKey, err := ak.insert(ctx, apiKeyRepo, key, IssueApiKeyData{
+			ClientId:   oldKey.ClientId,
+			Name:       oldKey.Name,
+			Role:       oldKey.Role,
+			MerchantId: oldKey.MerchantId,
+			ExpiresAt:  oldKey.ExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		// old key keep working during the overlap so the client can roll out the new key
+		overlapEnd := time.Now().UTC().Add(overlap)
+		if oldKey.ExpiresAt == nil || overlapEnd.Before(*oldKey.ExpiresAt) {
+			oldKey.ExpiresAt = &overlapEnd
+		}
+
+		err = apiKeyRepo.UpdateById(ctx, oldKey.Id, *oldKey)
+		if err != nil {
+			return err
+		}
+
+		res = IssuedApiKey{
+			ApiKey: newKey,
+			Key:    key,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return IssuedApiKey{}, err
+	}
+
+	return res, nil
+}
+
+func (ak apiKeyUsecase) Revoke(ctx context.Context, id string) error {
+	return ak.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+		apiKeyRepo := ak.apiKeyRepository.WithTx(Tx)
+
+		apiKey, err := apiKeyRepo.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if apiKey == nil {
+			return internal_error.ErrApiKeyNotFound
+		}
+
+		if apiKey.RevokedAt != nil {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		apiKey.RevokedAt = &now
+
+		return apiKeyRepo.UpdateById(ctx, apiKey.Id, *apiKey)
+	})
+}
+
+func (ak apiKeyUsecase) List(ctx context.Context, clientId string) ([]domain.ApiKey, error) {
+	return ak.apiKeyRepository.ListByClientId(ctx, clientId)
+}
+
+// validateIssueData client key must belong to an existing merchant, it is the tenant of the disbursements made with the key
+func (ak apiKeyUsecase) validateIssueData(ctx context.Context, data IssueApiKeyData) error {
+	if !data.Role.IsValid() {
+		return internal_error.ErrApiKeyInvalidRole
+	}
+
+	if data.MerchantId == "" {
+		if data.Role == domain.RoleClient {
+			return internal_error.ErrApiKeyNoMerchant
+		}
+
+		return nil
+	}
+
+	merchant, err := ak.merchantRepository.GetById(ctx, data.MerchantId)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if merchant == nil {
+		return internal_error.ErrMerchantNotFound
+	}
+
+	return nil
+}
+
+func (ak apiKeyUsecase) insert(ctx context.Context, apiKeyRepo repository.ApiKey, key string, data IssueApiKeyData) (domain.ApiKey, error) {
+	prefix, secret, err := parseApiKey(key)
+	if err != nil {
+		return domain.ApiKey{}, err
+	}
+
+	apiKey := domain.ApiKey{
+		ClientId:   data.ClientId,
+		Name:       data.Name,
+		Role:       data.Role,
+		MerchantId: data.MerchantId,
+		Prefix:     prefix,
+		Hash:       hashApiKeySecret(secret),
+		ExpiresAt:  data.ExpiresAt,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	apiKey.Id, err = apiKeyRepo.Insert(ctx, apiKey)
+	if err != nil {
+		return domain.ApiKey{}, err
+	}
+
+	return apiKey, nil
+}
+
+func generateApiKey() (string, error) {
+	prefix := make([]byte, apiKeyPrefixSize)
+	secret := make([]byte, apiKeySecretSize)
+
+	_, err := rand.Read(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return apiKeyScheme + hex.EncodeToString(prefix) + "." + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func parseApiKey(key string) (string, string, error) {
+	prefix, secret, found := strings.Cut(strings.TrimPrefix(key, apiKeyScheme), ".")
+	if !strings.HasPrefix(key, apiKeyScheme) || !found || prefix == "" || secret == "" {
+		return "", "", errInvalidApiKeyFormat
+	}
+
+	return prefix, secret, nil
+}
+
+// the secret is random with enough entropy so a fast hash is sufficient
+func hashApiKeySecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
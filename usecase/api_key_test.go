@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/mock"
+	mock_repository "github.com/nobbyphala/Brick/mock/repository"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func newTestApiKey(t *testing.T) (string, domain.ApiKey) {
+	key, err := generateApiKey()
+	assert.NoError(t, err)
+
+	prefix, secret, err := parseApiKey(key)
+	assert.NoError(t, err)
+
+	return key, domain.ApiKey{
+		Id:         "key-id-1",
+		ClientId:   "client-1",
+		Name:       "payroll",
+		Role:       domain.RoleClient,
+		MerchantId: "merchant-1",
+		Prefix:     prefix,
+		Hash:       hashApiKeySecret(secret),
+	}
+}
+
+func Test_apiKeyUsecase_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApiKeyRepo := mock_repository.NewMockApiKey(ctrl)
+
+	key, apiKey := newTestApiKey(t)
+	past := time.Now().Add(-time.Hour)
+	recent := time.Now().UTC()
+
+	tests := []struct {
+		name    string
+		key     string
+		want    domain.Caller
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "valid key",
+			key:  key,
+			want: domain.Caller{Id: "client-1", Role: domain.RoleClient, ApiKeyId: "key-id-1", MerchantId: "merchant-1"},
+			mock: func() {
+				mockApiKeyRepo.EXPECT().GetByPrefix(gomock.Any(), apiKey.Prefix).Return(&apiKey, nil)
+				mockApiKeyRepo.EXPECT().UpdateLastUsedAt(gomock.Any(), "key-id-1", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "recently used key does not update last used",
+			key:  key,
+			want: domain.Caller{Id: "client-1", Role: domain.RoleClient, ApiKeyId: "key-id-1", MerchantId: "merchant-1"},
+			mock: func() {
+				recentlyUsed := apiKey
+				recentlyUsed.LastUsedAt = &recent
+				mockApiKeyRepo.EXPECT().GetByPrefix(gomock.Any(), apiKey.Prefix).Return(&recentlyUsed, nil)
+			},
+		},
+		{
+			name: "error update last used is ignored",
+			key:  key,
+			want: domain.Caller{Id: "client-1", Role: domain.RoleClient, ApiKeyId: "key-id-1", MerchantId: "merchant-1"},
+			mock: func() {
+				mockApiKeyRepo.EXPECT().GetByPrefix(gomock.Any(), apiKey.Prefix).Return(&apiKey, nil)
+				mockApiKeyRepo.EXPECT().UpdateLastUsedAt(gomock.Any(), "key-id-1", gomock.Any()).Return(errors.New("sql error"))
+			},
+		},
+		{
+			name:    "invalid format",
+			key:     "not-a-key",
+			wantErr: internal_error.ErrUnauthorized,
+			mock:    func() {},
+		},
+		{
+			name:    "unknown key",
+			key:     key,
+			wantErr: internal_error.ErrUnauthorized,
+			mock: func() {
+				mockApiKeyRepo.EXPECT().GetByPrefix(gomock.Any(), apiKey.Prefix).Return(nil, nil)
+			},
+		},
+		{
+			name:    "wrong secret",
+			key:     apiKeyScheme + apiKey.Prefix + ".wrong",
+			wantErr: internal_error.ErrUnauthorized,
+			mock: func() {
+				mockApiKeyRepo.EXPECT().GetByPrefix(gomock.Any(), apiKey.Prefix).Return(&apiKey, nil)
+			},
+		},
+		{
+			name:    "revoked key",
+			key:     key,
+			wantErr: internal_error.ErrUnauthorized,
+			mock: func() {
+				revoked := apiKey
+				revoked.RevokedAt = &past
+				mockApiKeyRepo.EXPECT().GetByPrefix(gomock.Any(), apiKey.Prefix).Return(&revoked, nil)
+			},
+		},
+		{
+			name:    "expired key",
+			key:     key,
+			wantErr: internal_error.ErrUnauthorized,
+			mock: func() {
+				expired := apiKey
+				expired.ExpiresAt = &past
+				mockApiKeyRepo.EXPECT().GetByPrefix(gomock.Any(), apiKey.Prefix).Return(&expired, nil)
+			},
+		},
+		{
+			name:    "error get key",
+			key:     key,
+			wantErr: errors.New("sql error"),
+			mock: func() {
+				mockApiKeyRepo.EXPECT().GetByPrefix(gomock.Any(), apiKey.Prefix).Return(nil, errors.New("sql error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			ak := NewApiKey(ApiKeyDeps{ApiKeyRepository: mockApiKeyRepo})
+			got, err := ak.Authenticate(context.TODO(), tt.key)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_apiKeyUsecase_Issue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApiKeyRepo := mock_repository.NewMockApiKey(ctrl)
+	mockMerchantRepo := mock_repository.NewMockMerchant(ctrl)
+	ak := NewApiKey(ApiKeyDeps{ApiKeyRepository: mockApiKeyRepo, MerchantRepository: mockMerchantRepo})
+
+	_, err := ak.Issue(context.TODO(), IssueApiKeyData{ClientId: "client-1", Role: "superuser"})
+	assert.Equal(t, internal_error.ErrApiKeyInvalidRole, err)
+
+	_, err = ak.Issue(context.TODO(), IssueApiKeyData{ClientId: "client-1", Role: domain.RoleClient})
+	assert.Equal(t, internal_error.ErrApiKeyNoMerchant, err)
+
+	mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-2").Return(nil, nil)
+	_, err = ak.Issue(context.TODO(), IssueApiKeyData{ClientId: "client-1", Role: domain.RoleClient, MerchantId: "merchant-2"})
+	assert.Equal(t, internal_error.ErrMerchantNotFound, err)
+
+	mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+
+	var inserted domain.ApiKey
+	mockApiKeyRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, apiKey domain.ApiKey) (string, error) {
+		inserted = apiKey
+		return "key-id-1", nil
+	})
+
+	got, err := ak.Issue(context.TODO(), IssueApiKeyData{ClientId: "client-1", Name: "payroll", Role: domain.RoleClient, MerchantId: "merchant-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "key-id-1", got.ApiKey.Id)
+	assert.Equal(t, "merchant-1", inserted.MerchantId)
+	assert.NotContains(t, inserted.Hash, got.Key)
+
+	prefix, secret, err := parseApiKey(got.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, inserted.Prefix, prefix)
+	assert.Equal(t, inserted.Hash, hashApiKeySecret(secret))
+}
+
+func Test_apiKeyUsecase_Rotate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApiKeyRepo := mock_repository.NewMockApiKey(ctrl)
+	mockUtilRepo := mock_repository.NewMockUtils(ctrl)
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+
+	_, apiKey := newTestApiKey(t)
+	revokedAt := time.Now()
+
+	tests := []struct {
+		name    string
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "rotate with overlap",
+			mock: func() {
+				mockApiKeyRepo.EXPECT().GetById(gomock.Any(), "key-id-1").Return(&apiKey, nil)
+				mockApiKeyRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, newKey domain.ApiKey) (string, error) {
+					assert.Equal(t, apiKey.ClientId, newKey.ClientId)
+					assert.Equal(t, apiKey.Role, newKey.Role)
+					assert.Equal(t, apiKey.MerchantId, newKey.MerchantId)
+					assert.NotEqual(t, apiKey.Prefix, newKey.Prefix)
+					return "key-id-2", nil
+				})
+				mockApiKeyRepo.EXPECT().UpdateById(gomock.Any(), "key-id-1", gomock.Any()).DoAndReturn(func(ctx context.Context, id string, oldKey domain.ApiKey) error {
+					assert.WithinDuration(t, time.Now().Add(time.Hour), *oldKey.ExpiresAt, time.Minute)
+					return nil
+				})
+			},
+		},
+		{
+			name:    "key not found",
+			wantErr: internal_error.ErrApiKeyNotFound,
+			mock: func() {
+				mockApiKeyRepo.EXPECT().GetById(gomock.Any(), "key-id-1").Return(nil, nil)
+			},
+		},
+		{
+			name:    "key revoked",
+			wantErr: internal_error.ErrApiKeyRevoked,
+			mock: func() {
+				revoked := apiKey
+				revoked.RevokedAt = &revokedAt
+				mockApiKeyRepo.EXPECT().GetById(gomock.Any(), "key-id-1").Return(&revoked, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+				return handler(ctx, mockSQL)
+			})
+			mockApiKeyRepo.EXPECT().WithTx(mockSQL).Return(mockApiKeyRepo)
+			tt.mock()
+
+			ak := NewApiKey(ApiKeyDeps{ApiKeyRepository: mockApiKeyRepo, UtilsRepository: mockUtilRepo})
+			got, err := ak.Rotate(context.TODO(), "key-id-1", time.Hour)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, "key-id-2", got.ApiKey.Id)
+				assert.NotEmpty(t, got.Key)
+			}
+		})
+	}
+}
+
+func Test_apiKeyUsecase_Revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApiKeyRepo := mock_repository.NewMockApiKey(ctrl)
+	mockUtilRepo := mock_repository.NewMockUtils(ctrl)
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+
+	_, apiKey := newTestApiKey(t)
+
+	mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+		return handler(ctx, mockSQL)
+	})
+	mockApiKeyRepo.EXPECT().WithTx(mockSQL).Return(mockApiKeyRepo)
+	mockApiKeyRepo.EXPECT().GetById(gomock.Any(), "key-id-1").Return(&apiKey, nil)
+	mockApiKeyRepo.EXPECT().UpdateById(gomock.Any(), "key-id-1", gomock.Any()).DoAndReturn(func(ctx context.Context, id string, revoked domain.ApiKey) error {
+		assert.NotNil(t, revoked.RevokedAt)
+		return nil
+	})
+
+	ak := NewApiKey(ApiKeyDeps{ApiKeyRepository: mockApiKeyRepo, UtilsRepository: mockUtilRepo})
+	assert.NoError(t, ak.Revoke(context.TODO(), "key-id-1"))
+}
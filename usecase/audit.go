@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"log"
+)
+
+// actor of audit log recorded for unauthenticated request
+const anonymousActorId = "anonymous"
+
+type auditUsecase struct {
+	auditLogRepository repository.AuditLog
+}
+
+type AuditDeps struct {
+	AuditLogRepository repository.AuditLog
+}
+
+func NewAudit(deps AuditDeps) *auditUsecase {
+	return &auditUsecase{
+		auditLogRepository: deps.AuditLogRepository,
+	}
+}
+
+func (au auditUsecase) Record(ctx context.Context, auditLog domain.AuditLog) error {
+	_, err := au.auditLogRepository.Insert(ctx, withActor(ctx, auditLog))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return nil
+}
+
+func (au auditUsecase) List(ctx context.Context, filter domain.AuditLogFilter) ([]domain.AuditLog, error) {
+	return au.auditLogRepository.List(ctx, filter)
+}
+
+// withActor copy the identity of the caller in ctx to the audit log
+func withActor(ctx context.Context, auditLog domain.AuditLog) domain.AuditLog {
+	auditLog.ActorId = anonymousActorId
+
+	caller, ok := domain.CallerFromContext(ctx)
+	if ok {
+		auditLog.ActorId = caller.Id
+		auditLog.ActorRole = caller.Role
+		auditLog.ApiKeyId = caller.ApiKeyId
+		auditLog.MerchantId = caller.MerchantId
+	}
+
+	return auditLog
+}
+
+// actorId return the id of the caller in ctx, anonymous when there is no caller
+func actorId(ctx context.Context) string {
+	return withActor(ctx, domain.AuditLog{}).ActorId
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	mock_repository "github.com/nobbyphala/Brick/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func Test_auditUsecase_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuditLogRepo := mock_repository.NewMockAuditLog(ctrl)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want domain.AuditLog
+	}{
+		{
+			name: "actor taken from caller",
+			ctx: domain.ContextWithCaller(context.TODO(), domain.Caller{
+				Id:         "client-1",
+				Role:       domain.RoleClient,
+				ApiKeyId:   "key-id-1",
+				MerchantId: "merchant-1",
+			}),
+			want: domain.AuditLog{
+				ActorId:    "client-1",
+				ActorRole:  domain.RoleClient,
+				ApiKeyId:   "key-id-1",
+				MerchantId: "merchant-1",
+				Action:     string(domain.PermissionBankCallback),
+				Outcome:    domain.AuditOutcomeDenied,
+			},
+		},
+		{
+			name: "request without caller",
+			ctx:  context.TODO(),
+			want: domain.AuditLog{
+				ActorId: "anonymous",
+				Action:  string(domain.PermissionBankCallback),
+				Outcome: domain.AuditOutcomeDenied,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuditLogRepo.EXPECT().Insert(gomock.Any(), tt.want).Return("audit-id-1", nil)
+
+			au := NewAudit(AuditDeps{AuditLogRepository: mockAuditLogRepo})
+			err := au.Record(tt.ctx, domain.AuditLog{
+				Action:  string(domain.PermissionBankCallback),
+				Outcome: domain.AuditOutcomeDenied,
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
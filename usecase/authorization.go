@@ -0,0 +1,647 @@
+package usecase
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"io"
+	"log"
+	"time"
+)
+
+// authorizer check the permission of the caller in ctx before the decorated usecase is called. The same check is
+// done by the http middleware, this one protect the usecase when it is called from another adapter
+type authorizer struct {
+	auditUsecase Audit
+}
+
+// authorize return ErrForbidden and record the denied attempt when the caller role is not granted the permission
+func (auth authorizer) authorize(ctx context.Context, permission domain.Permission, resourceId string) error {
+	caller, ok := domain.CallerFromContext(ctx)
+	if ok && caller.Role.Can(permission) {
+		return nil
+	}
+
+	err := auth.auditUsecase.Record(ctx, domain.AuditLog{
+		Action:     string(permission),
+		ResourceId: resourceId,
+		Outcome:    domain.AuditOutcomeDenied,
+		Reason:     "role is not granted the permission",
+	})
+	if err != nil {
+		log.Println("error recording denied access:", err)
+	}
+
+	return internal_error.ErrForbidden
+}
+
+type disbursementAuthorization struct {
+	authorizer
+	next Disbursement
+}
+
+type DisbursementAuthorizationDeps struct {
+	Disbursement Disbursement
+	AuditUsecase Audit
+}
+
+func NewDisbursementAuthorization(deps DisbursementAuthorizationDeps) *disbursementAuthorization {
+	return &disbursementAuthorization{
+		authorizer: authorizer{auditUsecase: deps.AuditUsecase},
+		next:       deps.Disbursement,
+	}
+}
+
+func (da disbursementAuthorization) VerifyDisbursement(ctx context.Context, disbursement domain.Disbursement) (DisbursementVerification, error) {
+	err := da.authorize(ctx, domain.PermissionDisbursementVerify, "")
+	if err != nil {
+		return DisbursementVerification{}, err
+	}
+
+	return da.next.VerifyDisbursement(ctx, disbursement)
+}
+
+func (da disbursementAuthorization) Disburse(ctx context.Context, disbursement domain.Disbursement, opts DisburseOptions) (domain.Disbursement, error) {
+	err := da.authorize(ctx, domain.PermissionDisbursementCreate, "")
+	if err != nil {
+		return domain.Disbursement{}, err
+	}
+
+	return da.next.Disburse(ctx, disbursement, opts)
+}
+
+func (da disbursementAuthorization) GetDisbursement(ctx context.Context, id string) (domain.Disbursement, error) {
+	err := da.authorize(ctx, domain.PermissionDisbursementRead, id)
+	if err != nil {
+		return domain.Disbursement{}, err
+	}
+
+	return da.next.GetDisbursement(ctx, id)
+}
+
+func (da disbursementAuthorization) ListDisbursements(ctx context.Context, filter domain.DisbursementFilter) ([]domain.Disbursement, error) {
+	err := da.authorize(ctx, domain.PermissionDisbursementRead, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return da.next.ListDisbursements(ctx, filter)
+}
+
+func (da disbursementAuthorization) ProcessBankCallback(ctx context.Context, bankCallback BankCallbackData) error {
+	err := da.authorize(ctx, domain.PermissionBankCallback, bankCallback.TransactionId)
+	if err != nil {
+		return err
+	}
+
+	return da.next.ProcessBankCallback(ctx, bankCallback)
+}
+
+type disbursementStreamAuthorization struct {
+	authorizer
+	next DisbursementStream
+}
+
+type DisbursementStreamAuthorizationDeps struct {
+	DisbursementStream DisbursementStream
+	AuditUsecase       Audit
+}
+
+func NewDisbursementStreamAuthorization(deps DisbursementStreamAuthorizationDeps) *disbursementStreamAuthorization {
+	return &disbursementStreamAuthorization{
+		authorizer: authorizer{auditUsecase: deps.AuditUsecase},
+		next:       deps.DisbursementStream,
+	}
+}
+
+func (dsa disbursementStreamAuthorization) Subscribe(ctx context.Context, filter DisbursementStreamFilter) (<-chan domain.DisbursementStatusEvent, error) {
+	err := dsa.authorize(ctx, domain.PermissionDisbursementRead, filter.DisbursementId)
+	if err != nil {
+		return nil, err
+	}
+
+	return dsa.next.Subscribe(ctx, filter)
+}
+
+// Listen is run by the background worker which has no caller
+func (dsa disbursementStreamAuthorization) Listen(ctx context.Context) {
+	dsa.next.Listen(ctx)
+}
+
+func (dsa disbursementStreamAuthorization) Close() {
+	dsa.next.Close()
+}
+
+type disbursementOperationAuthorization struct {
+	authorizer
+	next DisbursementOperation
+}
+
+type DisbursementOperationAuthorizationDeps struct {
+	DisbursementOperation DisbursementOperation
+	AuditUsecase          Audit
+}
+
+func NewDisbursementOperationAuthorization(deps DisbursementOperationAuthorizationDeps) *disbursementOperationAuthorization {
+	return &disbursementOperationAuthorization{
+		authorizer: authorizer{auditUsecase: deps.AuditUsecase},
+		next:       deps.DisbursementOperation,
+	}
+}
+
+func (doa disbursementOperationAuthorization) ForceStatus(ctx context.Context, id string, status domain.DisbursementStatus, reason string) (domain.Disbursement, error) {
+	err := doa.authorize(ctx, domain.PermissionDisbursementOperate, id)
+	if err != nil {
+		return domain.Disbursement{}, err
+	}
+
+	return doa.next.ForceStatus(ctx, id, status, reason)
+}
+
+func (doa disbursementOperationAuthorization) AttachBankEvidence(ctx context.Context, id string, reference string) (domain.Disbursement, error) {
+	err := doa.authorize(ctx, domain.PermissionDisbursementOperate, id)
+	if err != nil {
+		return domain.Disbursement{}, err
+	}
+
+	return doa.next.AttachBankEvidence(ctx, id, reference)
+}
+
+func (doa disbursementOperationAuthorization) RecheckBankStatus(ctx context.Context, id string) (BankStatusCheckResult, error) {
+	err := doa.authorize(ctx, domain.PermissionDisbursementOperate, id)
+	if err != nil {
+		return BankStatusCheckResult{}, err
+	}
+
+	return doa.next.RecheckBankStatus(ctx, id)
+}
+
+type callbackReviewAuthorization struct {
+	authorizer
+	next CallbackReview
+}
+
+type CallbackReviewAuthorizationDeps struct {
+	CallbackReview CallbackReview
+	AuditUsecase   Audit
+}
+
+func NewCallbackReviewAuthorization(deps CallbackReviewAuthorizationDeps) *callbackReviewAuthorization {
+	return &callbackReviewAuthorization{
+		authorizer: authorizer{auditUsecase: deps.AuditUsecase},
+		next:       deps.CallbackReview,
+	}
+}
+
+func (cra callbackReviewAuthorization) ListCallbackReviews(ctx context.Context, filter domain.CallbackReviewFilter) ([]domain.CallbackReview, error) {
+	err := cra.authorize(ctx, domain.PermissionCallbackReviewRead, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return cra.next.ListCallbackReviews(ctx, filter)
+}
+
+func (cra callbackReviewAuthorization) GetCallbackReview(ctx context.Context, id string) (CallbackReviewDetail, error) {
+	err := cra.authorize(ctx, domain.PermissionCallbackReviewRead, id)
+	if err != nil {
+		return CallbackReviewDetail{}, err
+	}
+
+	return cra.next.GetCallbackReview(ctx, id)
+}
+
+func (cra callbackReviewAuthorization) AssignCallbackReview(ctx context.Context, id string, assigneeId string) (domain.CallbackReview, error) {
+	err := cra.authorize(ctx, domain.PermissionCallbackReviewManage, id)
+	if err != nil {
+		return domain.CallbackReview{}, err
+	}
+
+	return cra.next.AssignCallbackReview(ctx, id, assigneeId)
+}
+
+func (cra callbackReviewAuthorization) ResolveCallbackReview(ctx context.Context, id string, resolution domain.CallbackReviewResolution, note string) (domain.CallbackReview, error) {
+	err := cra.authorize(ctx, domain.PermissionCallbackReviewManage, id)
+	if err != nil {
+		return domain.CallbackReview{}, err
+	}
+
+	return cra.next.ResolveCallbackReview(ctx, id, resolution, note)
+}
+
+func (cra callbackReviewAuthorization) CommentCallbackReview(ctx context.Context, id string, body string) (domain.CallbackReviewComment, error) {
+	err := cra.authorize(ctx, domain.PermissionCallbackReviewManage, id)
+	if err != nil {
+		return domain.CallbackReviewComment{}, err
+	}
+
+	return cra.next.CommentCallbackReview(ctx, id, body)
+}
+
+// CountOpenCallbackReviews is collected by the metrics scrape which has no caller
+func (cra callbackReviewAuthorization) CountOpenCallbackReviews(ctx context.Context) ([]domain.CallbackReviewCount, error) {
+	return cra.next.CountOpenCallbackReviews(ctx)
+}
+
+type webhookAuthorization struct {
+	authorizer
+	next Webhook
+}
+
+type WebhookAuthorizationDeps struct {
+	Webhook      Webhook
+	AuditUsecase Audit
+}
+
+func NewWebhookAuthorization(deps WebhookAuthorizationDeps) *webhookAuthorization {
+	return &webhookAuthorization{
+		authorizer: authorizer{auditUsecase: deps.AuditUsecase},
+		next:       deps.Webhook,
+	}
+}
+
+func (wa webhookAuthorization) CreateWebhookEndpoint(ctx context.Context, endpoint domain.WebhookEndpoint) (domain.WebhookEndpoint, error) {
+	err := wa.authorize(ctx, domain.PermissionWebhookManage, endpoint.MerchantId)
+	if err != nil {
+		return domain.WebhookEndpoint{}, err
+	}
+
+	return wa.next.CreateWebhookEndpoint(ctx, endpoint)
+}
+
+func (wa webhookAuthorization) ListWebhookEndpoints(ctx context.Context, merchantId string) ([]domain.WebhookEndpoint, error) {
+	err := wa.authorize(ctx, domain.PermissionWebhookRead, merchantId)
+	if err != nil {
+		return nil, err
+	}
+
+	return wa.next.ListWebhookEndpoints(ctx, merchantId)
+}
+
+func (wa webhookAuthorization) UpdateWebhookEndpoint(ctx context.Context, endpoint domain.WebhookEndpoint) (domain.WebhookEndpoint, error) {
+	err := wa.authorize(ctx, domain.PermissionWebhookManage, endpoint.Id)
+	if err != nil {
+		return domain.WebhookEndpoint{}, err
+	}
+
+	return wa.next.UpdateWebhookEndpoint(ctx, endpoint)
+}
+
+func (wa webhookAuthorization) DeleteWebhookEndpoint(ctx context.Context, merchantId string, id string) error {
+	err := wa.authorize(ctx, domain.PermissionWebhookManage, id)
+	if err != nil {
+		return err
+	}
+
+	return wa.next.DeleteWebhookEndpoint(ctx, merchantId, id)
+}
+
+func (wa webhookAuthorization) ListWebhookDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	err := wa.authorize(ctx, domain.PermissionWebhookRead, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return wa.next.ListWebhookDeliveries(ctx, filter)
+}
+
+func (wa webhookAuthorization) GetWebhookDelivery(ctx context.Context, id string) (WebhookDeliveryDetail, error) {
+	err := wa.authorize(ctx, domain.PermissionWebhookRead, id)
+	if err != nil {
+		return WebhookDeliveryDetail{}, err
+	}
+
+	return wa.next.GetWebhookDelivery(ctx, id)
+}
+
+func (wa webhookAuthorization) ReplayWebhookDelivery(ctx context.Context, id string) (domain.WebhookDelivery, error) {
+	err := wa.authorize(ctx, domain.PermissionWebhookReplay, id)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	return wa.next.ReplayWebhookDelivery(ctx, id)
+}
+
+// DeliverDueWebhooks is run by the background worker which has no caller
+func (wa webhookAuthorization) DeliverDueWebhooks(ctx context.Context, batchSize int) (int, error) {
+	return wa.next.DeliverDueWebhooks(ctx, batchSize)
+}
+
+type apiKeyAuthorization struct {
+	authorizer
+	next ApiKey
+}
+
+type ApiKeyAuthorizationDeps struct {
+	ApiKey       ApiKey
+	AuditUsecase Audit
+}
+
+func NewApiKeyAuthorization(deps ApiKeyAuthorizationDeps) *apiKeyAuthorization {
+	return &apiKeyAuthorization{
+		authorizer: authorizer{auditUsecase: deps.AuditUsecase},
+		next:       deps.ApiKey,
+	}
+}
+
+// Authenticate is called before the caller is known
+func (aa apiKeyAuthorization) Authenticate(ctx context.Context, key string) (domain.Caller, error) {
+	return aa.next.Authenticate(ctx, key)
+}
+
+func (aa apiKeyAuthorization) Issue(ctx context.Context, data IssueApiKeyData) (IssuedApiKey, error) {
+	err := aa.authorize(ctx, domain.PermissionApiKeyManage, data.ClientId)
+	if err != nil {
+		return IssuedApiKey{}, err
+	}
+
+	return aa.next.Issue(ctx, data)
+}
+
+func (aa apiKeyAuthorization) Import(ctx context.Context, key string, data IssueApiKeyData) error {
+	err := aa.authorize(ctx, domain.PermissionApiKeyManage, data.ClientId)
+	if err != nil {
+		return err
+	}
+
+	return aa.next.Import(ctx, key, data)
+}
+
+func (aa apiKeyAuthorization) Rotate(ctx context.Context, id string, overlap time.Duration) (IssuedApiKey, error) {
+	err := aa.authorize(ctx, domain.PermissionApiKeyManage, id)
+	if err != nil {
+		return IssuedApiKey{}, err
+	}
+
+	return aa.next.Rotate(ctx, id, overlap)
+}
+
+func (aa apiKeyAuthorization) Revoke(ctx context.Context, id string) error {
+	err := aa.authorize(ctx, domain.PermissionApiKeyManage, id)
+	if err != nil {
+		return err
+	}
+
+	return aa.next.Revoke(ctx, id)
+}
+
+func (aa apiKeyAuthorization) List(ctx context.Context, clientId string) ([]domain.ApiKey, error) {
+	err := aa.authorize(ctx, domain.PermissionApiKeyManage, clientId)
+	if err != nil {
+		return nil, err
+	}
+
+	return aa.next.List(ctx, clientId)
+}
+
+type merchantAuthorization struct {
+	authorizer
+	next Merchant
+}
+
+type MerchantAuthorizationDeps struct {
+	Merchant     Merchant
+	AuditUsecase Audit
+}
+
+func NewMerchantAuthorization(deps MerchantAuthorizationDeps) *merchantAuthorization {
+	return &merchantAuthorization{
+		authorizer: authorizer{auditUsecase: deps.AuditUsecase},
+		next:       deps.Merchant,
+	}
+}
+
+func (ma merchantAuthorization) CreateMerchant(ctx context.Context, merchant domain.Merchant) (domain.Merchant, error) {
+	err := ma.authorize(ctx, domain.PermissionMerchantManage, "")
+	if err != nil {
+		return domain.Merchant{}, err
+	}
+
+	return ma.next.CreateMerchant(ctx, merchant)
+}
+
+func (ma merchantAuthorization) GetMerchant(ctx context.Context, id string) (domain.Merchant, error) {
+	err := ma.authorize(ctx, domain.PermissionMerchantRead, id)
+	if err != nil {
+		return domain.Merchant{}, err
+	}
+
+	return ma.next.GetMerchant(ctx, id)
+}
+
+func (ma merchantAuthorization) ListMerchants(ctx context.Context) ([]domain.Merchant, error) {
+	err := ma.authorize(ctx, domain.PermissionMerchantRead, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return ma.next.ListMerchants(ctx)
+}
+
+func (ma merchantAuthorization) UpdateMerchant(ctx context.Context, merchant domain.Merchant) (domain.Merchant, error) {
+	err := ma.authorize(ctx, domain.PermissionMerchantManage, merchant.Id)
+	if err != nil {
+		return domain.Merchant{}, err
+	}
+
+	return ma.next.UpdateMerchant(ctx, merchant)
+}
+
+type auditAuthorization struct {
+	authorizer
+	next Audit
+}
+
+type AuditAuthorizationDeps struct {
+	Audit Audit
+}
+
+func NewAuditAuthorization(deps AuditAuthorizationDeps) *auditAuthorization {
+	return &auditAuthorization{
+		authorizer: authorizer{auditUsecase: deps.Audit},
+		next:       deps.Audit,
+	}
+}
+
+// Record is used to audit actions of every caller
+func (aa auditAuthorization) Record(ctx context.Context, auditLog domain.AuditLog) error {
+	return aa.next.Record(ctx, auditLog)
+}
+
+func (aa auditAuthorization) List(ctx context.Context, filter domain.AuditLogFilter) ([]domain.AuditLog, error) {
+	err := aa.authorize(ctx, domain.PermissionAuditRead, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return aa.next.List(ctx, filter)
+}
+
+type verificationCacheAuthorization struct {
+	authorizer
+	next VerificationCache
+}
+
+type VerificationCacheAuthorizationDeps struct {
+	VerificationCache VerificationCache
+	AuditUsecase      Audit
+}
+
+func NewVerificationCacheAuthorization(deps VerificationCacheAuthorizationDeps) *verificationCacheAuthorization {
+	return &verificationCacheAuthorization{
+		authorizer: authorizer{auditUsecase: deps.AuditUsecase},
+		next:       deps.VerificationCache,
+	}
+}
+
+func (vca verificationCacheAuthorization) EvictVerification(ctx context.Context, bankCode string, accountNumber string, reason string) error {
+	err := vca.authorize(ctx, domain.PermissionVerificationCacheManage, bankCode)
+	if err != nil {
+		return err
+	}
+
+	return vca.next.EvictVerification(ctx, bankCode, accountNumber, reason)
+}
+
+type beneficiaryAuthorization struct {
+	authorizer
+	next Beneficiary
+}
+
+type BeneficiaryAuthorizationDeps struct {
+	Beneficiary  Beneficiary
+	AuditUsecase Audit
+}
+
+func NewBeneficiaryAuthorization(deps BeneficiaryAuthorizationDeps) *beneficiaryAuthorization {
+	return &beneficiaryAuthorization{
+		authorizer: authorizer{auditUsecase: deps.AuditUsecase},
+		next:       deps.Beneficiary,
+	}
+}
+
+func (ba beneficiaryAuthorization) CreateBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) (domain.Beneficiary, error) {
+	err := ba.authorize(ctx, domain.PermissionBeneficiaryManage, "")
+	if err != nil {
+		return domain.Beneficiary{}, err
+	}
+
+	return ba.next.CreateBeneficiary(ctx, beneficiary)
+}
+
+func (ba beneficiaryAuthorization) GetBeneficiary(ctx context.Context, id string) (domain.Beneficiary, error) {
+	err := ba.authorize(ctx, domain.PermissionBeneficiaryRead, id)
+	if err != nil {
+		return domain.Beneficiary{}, err
+	}
+
+	return ba.next.GetBeneficiary(ctx, id)
+}
+
+func (ba beneficiaryAuthorization) ListBeneficiaries(ctx context.Context, filter domain.BeneficiaryFilter) ([]domain.Beneficiary, error) {
+	err := ba.authorize(ctx, domain.PermissionBeneficiaryRead, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return ba.next.ListBeneficiaries(ctx, filter)
+}
+
+func (ba beneficiaryAuthorization) UpdateBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) (domain.Beneficiary, error) {
+	err := ba.authorize(ctx, domain.PermissionBeneficiaryManage, beneficiary.Id)
+	if err != nil {
+		return domain.Beneficiary{}, err
+	}
+
+	return ba.next.UpdateBeneficiary(ctx, beneficiary)
+}
+
+func (ba beneficiaryAuthorization) DeleteBeneficiary(ctx context.Context, id string) error {
+	err := ba.authorize(ctx, domain.PermissionBeneficiaryManage, id)
+	if err != nil {
+		return err
+	}
+
+	return ba.next.DeleteBeneficiary(ctx, id)
+}
+
+type screeningAuthorization struct {
+	authorizer
+	next Screening
+}
+
+type ScreeningAuthorizationDeps struct {
+	Screening    Screening
+	AuditUsecase Audit
+}
+
+func NewScreeningAuthorization(deps ScreeningAuthorizationDeps) *screeningAuthorization {
+	return &screeningAuthorization{
+		authorizer: authorizer{auditUsecase: deps.AuditUsecase},
+		next:       deps.Screening,
+	}
+}
+
+func (sa screeningAuthorization) ListScreenings(ctx context.Context, filter domain.ScreeningFilter) ([]domain.Screening, error) {
+	err := sa.authorize(ctx, domain.PermissionScreeningRead, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return sa.next.ListScreenings(ctx, filter)
+}
+
+func (sa screeningAuthorization) GetScreening(ctx context.Context, id string) (ScreeningDetail, error) {
+	err := sa.authorize(ctx, domain.PermissionScreeningRead, id)
+	if err != nil {
+		return ScreeningDetail{}, err
+	}
+
+	return sa.next.GetScreening(ctx, id)
+}
+
+func (sa screeningAuthorization) ResolveScreening(ctx context.Context, id string, resolution domain.ScreeningResolution, note string) (ScreeningDetail, error) {
+	err := sa.authorize(ctx, domain.PermissionScreeningResolve, id)
+	if err != nil {
+		return ScreeningDetail{}, err
+	}
+
+	return sa.next.ResolveScreening(ctx, id, resolution, note)
+}
+
+func (sa screeningAuthorization) ImportScreeningList(ctx context.Context, listName string, format domain.ScreeningListFormat, file io.Reader) (int, error) {
+	err := sa.authorize(ctx, domain.PermissionScreeningListManage, listName)
+	if err != nil {
+		return 0, err
+	}
+
+	return sa.next.ImportScreeningList(ctx, listName, format, file)
+}
+
+func (sa screeningAuthorization) ListScreeningEntries(ctx context.Context, filter domain.ScreeningEntryFilter) ([]domain.ScreeningEntry, error) {
+	err := sa.authorize(ctx, domain.PermissionScreeningRead, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return sa.next.ListScreeningEntries(ctx, filter)
+}
+
+func (sa screeningAuthorization) AddBlocklistEntry(ctx context.Context, entry domain.ScreeningEntry) (domain.ScreeningEntry, error) {
+	err := sa.authorize(ctx, domain.PermissionScreeningListManage, "")
+	if err != nil {
+		return domain.ScreeningEntry{}, err
+	}
+
+	return sa.next.AddBlocklistEntry(ctx, entry)
+}
+
+func (sa screeningAuthorization) DeleteBlocklistEntry(ctx context.Context, id string, reason string) error {
+	err := sa.authorize(ctx, domain.PermissionScreeningListManage, id)
+	if err != nil {
+		return err
+	}
+
+	return sa.next.DeleteBlocklistEntry(ctx, id, reason)
+}
@@ -0,0 +1,345 @@
+package usecase_test
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	mock_usecase "github.com/nobbyphala/Brick/mock/usecase"
+	"github.com/nobbyphala/Brick/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func Test_disbursementAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDisbursementUsecase := mock_usecase.NewMockDisbursement(ctrl)
+	mockAuditUsecase := mock_usecase.NewMockAudit(ctrl)
+
+	clientCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: "merchant-1"})
+	bankCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "bank-1", Role: domain.RoleBank})
+	callback := usecase.BankCallbackData{TransactionId: "txn-id-1", Status: "COMPLETED"}
+
+	tests := []struct {
+		name    string
+		call    func(da usecase.Disbursement) error
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "client create disbursement",
+			call: func(da usecase.Disbursement) error {
+				_, err := da.Disburse(clientCtx, domain.Disbursement{Amount: 60000}, usecase.DisburseOptions{})
+				return err
+			},
+			mock: func() {
+				mockDisbursementUsecase.EXPECT().Disburse(clientCtx, domain.Disbursement{Amount: 60000}, usecase.DisburseOptions{}).Return(domain.Disbursement{Id: "disb-id-1"}, nil)
+			},
+		},
+		{
+			name: "bank process callback",
+			call: func(da usecase.Disbursement) error {
+				return da.ProcessBankCallback(bankCtx, callback)
+			},
+			mock: func() {
+				mockDisbursementUsecase.EXPECT().ProcessBankCallback(bankCtx, callback).Return(nil)
+			},
+		},
+		{
+			name: "client cannot process bank callback",
+			call: func(da usecase.Disbursement) error {
+				return da.ProcessBankCallback(clientCtx, callback)
+			},
+			wantErr: internal_error.ErrForbidden,
+			mock: func() {
+				mockAuditUsecase.EXPECT().Record(clientCtx, domain.AuditLog{
+					Action:     string(domain.PermissionBankCallback),
+					ResourceId: "txn-id-1",
+					Outcome:    domain.AuditOutcomeDenied,
+					Reason:     "role is not granted the permission",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "bank cannot create disbursement",
+			call: func(da usecase.Disbursement) error {
+				_, err := da.Disburse(bankCtx, domain.Disbursement{Amount: 60000}, usecase.DisburseOptions{})
+				return err
+			},
+			wantErr: internal_error.ErrForbidden,
+			mock: func() {
+				mockAuditUsecase.EXPECT().Record(bankCtx, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "client list disbursements",
+			call: func(da usecase.Disbursement) error {
+				_, err := da.ListDisbursements(clientCtx, domain.DisbursementFilter{Limit: 10})
+				return err
+			},
+			mock: func() {
+				mockDisbursementUsecase.EXPECT().ListDisbursements(clientCtx, domain.DisbursementFilter{Limit: 10}).Return([]domain.Disbursement{}, nil)
+			},
+		},
+		{
+			name: "request without caller",
+			call: func(da usecase.Disbursement) error {
+				_, err := da.GetDisbursement(context.TODO(), "disb-id-1")
+				return err
+			},
+			wantErr: internal_error.ErrForbidden,
+			mock: func() {
+				mockAuditUsecase.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			da := usecase.NewDisbursementAuthorization(usecase.DisbursementAuthorizationDeps{
+				Disbursement: mockDisbursementUsecase,
+				AuditUsecase: mockAuditUsecase,
+			})
+			assert.Equal(t, tt.wantErr, tt.call(da))
+		})
+	}
+}
+
+func Test_merchantAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMerchantUsecase := mock_usecase.NewMockMerchant(ctrl)
+	mockAuditUsecase := mock_usecase.NewMockAudit(ctrl)
+
+	ma := usecase.NewMerchantAuthorization(usecase.MerchantAuthorizationDeps{
+		Merchant:     mockMerchantUsecase,
+		AuditUsecase: mockAuditUsecase,
+	})
+	viewerCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsViewer})
+
+	mockMerchantUsecase.EXPECT().ListMerchants(viewerCtx).Return([]domain.Merchant{}, nil)
+	_, err := ma.ListMerchants(viewerCtx)
+	assert.NoError(t, err)
+
+	mockAuditUsecase.EXPECT().Record(viewerCtx, gomock.Any()).Return(nil)
+	_, err = ma.UpdateMerchant(viewerCtx, domain.Merchant{Id: "merchant-1"})
+	assert.Equal(t, internal_error.ErrForbidden, err)
+}
+
+func Test_disbursementOperationAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOperationUsecase := mock_usecase.NewMockDisbursementOperation(ctrl)
+	mockAuditUsecase := mock_usecase.NewMockAudit(ctrl)
+
+	oa := usecase.NewDisbursementOperationAuthorization(usecase.DisbursementOperationAuthorizationDeps{
+		DisbursementOperation: mockOperationUsecase,
+		AuditUsecase:          mockAuditUsecase,
+	})
+	operatorCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsOperator})
+	viewerCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-2", Role: domain.RoleOpsViewer})
+
+	mockOperationUsecase.EXPECT().RecheckBankStatus(operatorCtx, "disb-id-1").Return(usecase.BankStatusCheckResult{}, nil)
+	_, err := oa.RecheckBankStatus(operatorCtx, "disb-id-1")
+	assert.NoError(t, err)
+
+	mockAuditUsecase.EXPECT().Record(viewerCtx, domain.AuditLog{
+		Action:     string(domain.PermissionDisbursementOperate),
+		ResourceId: "disb-id-1",
+		Outcome:    domain.AuditOutcomeDenied,
+		Reason:     "role is not granted the permission",
+	}).Return(nil)
+	_, err = oa.ForceStatus(viewerCtx, "disb-id-1", domain.DisbursementStatusCompleted, "bank confirmed by email")
+	assert.Equal(t, internal_error.ErrForbidden, err)
+}
+
+func Test_callbackReviewAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCallbackReviewUsecase := mock_usecase.NewMockCallbackReview(ctrl)
+	mockAuditUsecase := mock_usecase.NewMockAudit(ctrl)
+
+	cra := usecase.NewCallbackReviewAuthorization(usecase.CallbackReviewAuthorizationDeps{
+		CallbackReview: mockCallbackReviewUsecase,
+		AuditUsecase:   mockAuditUsecase,
+	})
+	viewerCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsViewer})
+
+	mockCallbackReviewUsecase.EXPECT().ListCallbackReviews(viewerCtx, domain.CallbackReviewFilter{}).Return([]domain.CallbackReview{}, nil)
+	_, err := cra.ListCallbackReviews(viewerCtx, domain.CallbackReviewFilter{})
+	assert.NoError(t, err)
+
+	mockAuditUsecase.EXPECT().Record(viewerCtx, gomock.Any()).Return(nil)
+	_, err = cra.ResolveCallbackReview(viewerCtx, "review-1", domain.CallbackReviewResolutionDiscard, "duplicate")
+	assert.Equal(t, internal_error.ErrForbidden, err)
+
+	// collected by metrics scrape without caller
+	mockCallbackReviewUsecase.EXPECT().CountOpenCallbackReviews(context.TODO()).Return(nil, nil)
+	_, err = cra.CountOpenCallbackReviews(context.TODO())
+	assert.NoError(t, err)
+}
+
+func Test_webhookAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookUsecase := mock_usecase.NewMockWebhook(ctrl)
+	mockAuditUsecase := mock_usecase.NewMockAudit(ctrl)
+
+	wa := usecase.NewWebhookAuthorization(usecase.WebhookAuthorizationDeps{
+		Webhook:      mockWebhookUsecase,
+		AuditUsecase: mockAuditUsecase,
+	})
+	operatorCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsOperator})
+
+	mockWebhookUsecase.EXPECT().ReplayWebhookDelivery(operatorCtx, "delivery-1").Return(domain.WebhookDelivery{Id: "delivery-2"}, nil)
+	_, err := wa.ReplayWebhookDelivery(operatorCtx, "delivery-1")
+	assert.NoError(t, err)
+
+	// managing endpoints is reserved to admin
+	mockAuditUsecase.EXPECT().Record(operatorCtx, gomock.Any()).Return(nil)
+	_, err = wa.CreateWebhookEndpoint(operatorCtx, domain.WebhookEndpoint{MerchantId: "merchant-1"})
+	assert.Equal(t, internal_error.ErrForbidden, err)
+
+	// run by the worker without caller
+	mockWebhookUsecase.EXPECT().DeliverDueWebhooks(context.TODO(), 10).Return(0, nil)
+	_, err = wa.DeliverDueWebhooks(context.TODO(), 10)
+	assert.NoError(t, err)
+}
+
+func Test_disbursementStreamAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDisbursementStream := mock_usecase.NewMockDisbursementStream(ctrl)
+	mockAuditUsecase := mock_usecase.NewMockAudit(ctrl)
+
+	dsa := usecase.NewDisbursementStreamAuthorization(usecase.DisbursementStreamAuthorizationDeps{
+		DisbursementStream: mockDisbursementStream,
+		AuditUsecase:       mockAuditUsecase,
+	})
+	viewerCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsViewer})
+	bankCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "bank-1", Role: domain.RoleBank})
+	filter := usecase.DisbursementStreamFilter{DisbursementId: "disb-id-1"}
+
+	mockDisbursementStream.EXPECT().Subscribe(viewerCtx, filter).Return(nil, nil)
+	_, err := dsa.Subscribe(viewerCtx, filter)
+	assert.NoError(t, err)
+
+	mockAuditUsecase.EXPECT().Record(bankCtx, domain.AuditLog{
+		Action:     string(domain.PermissionDisbursementRead),
+		ResourceId: "disb-id-1",
+		Outcome:    domain.AuditOutcomeDenied,
+		Reason:     "role is not granted the permission",
+	}).Return(nil)
+	_, err = dsa.Subscribe(bankCtx, filter)
+	assert.Equal(t, internal_error.ErrForbidden, err)
+
+	// run by the background worker without caller
+	mockDisbursementStream.EXPECT().Listen(context.TODO())
+	dsa.Listen(context.TODO())
+}
+
+func Test_verificationCacheAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVerificationCacheUsecase := mock_usecase.NewMockVerificationCache(ctrl)
+	mockAuditUsecase := mock_usecase.NewMockAudit(ctrl)
+
+	vca := usecase.NewVerificationCacheAuthorization(usecase.VerificationCacheAuthorizationDeps{
+		VerificationCache: mockVerificationCacheUsecase,
+		AuditUsecase:      mockAuditUsecase,
+	})
+	operatorCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsOperator})
+	clientCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: "merchant-1"})
+
+	mockVerificationCacheUsecase.EXPECT().EvictVerification(operatorCtx, "014", "6789567", "account closed").Return(nil)
+	err := vca.EvictVerification(operatorCtx, "014", "6789567", "account closed")
+	assert.NoError(t, err)
+
+	mockAuditUsecase.EXPECT().Record(clientCtx, domain.AuditLog{
+		Action:     string(domain.PermissionVerificationCacheManage),
+		ResourceId: "014",
+		Outcome:    domain.AuditOutcomeDenied,
+		Reason:     "role is not granted the permission",
+	}).Return(nil)
+	err = vca.EvictVerification(clientCtx, "014", "6789567", "account closed")
+	assert.Equal(t, internal_error.ErrForbidden, err)
+}
+
+func Test_beneficiaryAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBeneficiaryUsecase := mock_usecase.NewMockBeneficiary(ctrl)
+	mockAuditUsecase := mock_usecase.NewMockAudit(ctrl)
+
+	ba := usecase.NewBeneficiaryAuthorization(usecase.BeneficiaryAuthorizationDeps{
+		Beneficiary:  mockBeneficiaryUsecase,
+		AuditUsecase: mockAuditUsecase,
+	})
+	clientCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: "merchant-1"})
+	viewerCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsViewer})
+
+	mockBeneficiaryUsecase.EXPECT().DeleteBeneficiary(clientCtx, "beneficiary-1").Return(nil)
+	err := ba.DeleteBeneficiary(clientCtx, "beneficiary-1")
+	assert.NoError(t, err)
+
+	mockBeneficiaryUsecase.EXPECT().GetBeneficiary(viewerCtx, "beneficiary-1").Return(domain.Beneficiary{Id: "beneficiary-1"}, nil)
+	got, err := ba.GetBeneficiary(viewerCtx, "beneficiary-1")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Beneficiary{Id: "beneficiary-1"}, got)
+
+	mockAuditUsecase.EXPECT().Record(viewerCtx, domain.AuditLog{
+		Action:     string(domain.PermissionBeneficiaryManage),
+		ResourceId: "beneficiary-1",
+		Outcome:    domain.AuditOutcomeDenied,
+		Reason:     "role is not granted the permission",
+	}).Return(nil)
+	err = ba.DeleteBeneficiary(viewerCtx, "beneficiary-1")
+	assert.Equal(t, internal_error.ErrForbidden, err)
+}
+
+func Test_screeningAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockScreeningUsecase := mock_usecase.NewMockScreening(ctrl)
+	mockAuditUsecase := mock_usecase.NewMockAudit(ctrl)
+
+	sa := usecase.NewScreeningAuthorization(usecase.ScreeningAuthorizationDeps{
+		Screening:    mockScreeningUsecase,
+		AuditUsecase: mockAuditUsecase,
+	})
+	complianceCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "compliance-1", Role: domain.RoleCompliance})
+	operatorCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsOperator})
+
+	mockScreeningUsecase.EXPECT().ResolveScreening(complianceCtx, "screening-1", domain.ScreeningResolutionRelease, "false positive").Return(usecase.ScreeningDetail{}, nil)
+	_, err := sa.ResolveScreening(complianceCtx, "screening-1", domain.ScreeningResolutionRelease, "false positive")
+	assert.NoError(t, err)
+
+	// the operator can force the status of a disbursement but not release a held one
+	mockAuditUsecase.EXPECT().Record(operatorCtx, domain.AuditLog{
+		Action:     string(domain.PermissionScreeningResolve),
+		ResourceId: "screening-1",
+		Outcome:    domain.AuditOutcomeDenied,
+		Reason:     "role is not granted the permission",
+	}).Return(nil)
+	_, err = sa.ResolveScreening(operatorCtx, "screening-1", domain.ScreeningResolutionRelease, "false positive")
+	assert.Equal(t, internal_error.ErrForbidden, err)
+
+	mockAuditUsecase.EXPECT().Record(operatorCtx, domain.AuditLog{
+		Action:     string(domain.PermissionScreeningListManage),
+		ResourceId: "entry-1",
+		Outcome:    domain.AuditOutcomeDenied,
+		Reason:     "role is not granted the permission",
+	}).Return(nil)
+	err = sa.DeleteBlocklistEntry(operatorCtx, "entry-1", "reported by mistake")
+	assert.Equal(t, internal_error.ErrForbidden, err)
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"log"
+	"time"
+)
+
+// callers page through the beneficiaries, a single page never load more than this
+const maxBeneficiaryListLimit = 100
+
+type beneficiaryUsecase struct {
+	bankApi               api.Bank
+	nameMatcher           api.NameMatcher
+	beneficiaryRepository repository.Beneficiary
+	merchantRepository    repository.Merchant
+	now                   func() time.Time
+}
+
+type BeneficiaryDeps struct {
+	BankApi               api.Bank
+	NameMatcher           api.NameMatcher
+	BeneficiaryRepository repository.Beneficiary
+	MerchantRepository    repository.Merchant
+}
+
+func NewBeneficiary(deps BeneficiaryDeps) *beneficiaryUsecase {
+	return &beneficiaryUsecase{
+		bankApi:               deps.BankApi,
+		nameMatcher:           deps.NameMatcher,
+		beneficiaryRepository: deps.BeneficiaryRepository,
+		merchantRepository:    deps.MerchantRepository,
+		now:                   time.Now,
+	}
+}
+
+func (bu beneficiaryUsecase) CreateBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) (domain.Beneficiary, error) {
+	merchant, err := getCallerMerchant(ctx, bu.merchantRepository)
+	if err != nil {
+		return domain.Beneficiary{}, err
+	}
+
+	beneficiary.MerchantId = merchant.Id
+
+	beneficiary.Verification, err = bu.verify(ctx, merchant, beneficiary)
+	if err != nil {
+		return domain.Beneficiary{}, err
+	}
+
+	beneficiaryId, err := bu.beneficiaryRepository.Insert(ctx, beneficiary)
+	if err != nil {
+		log.Println(err)
+		return domain.Beneficiary{}, err
+	}
+
+	// creation time is set by the database
+	return getBeneficiary(ctx, bu.beneficiaryRepository, beneficiaryId)
+}
+
+func (bu beneficiaryUsecase) GetBeneficiary(ctx context.Context, id string) (domain.Beneficiary, error) {
+	return getBeneficiary(ctx, bu.beneficiaryRepository, id)
+}
+
+func (bu beneficiaryUsecase) ListBeneficiaries(ctx context.Context, filter domain.BeneficiaryFilter) ([]domain.Beneficiary, error) {
+	if filter.Limit < 0 || filter.Limit > maxBeneficiaryListLimit || filter.Offset < 0 {
+		return nil, internal_error.ErrInvalidRequest
+	}
+
+	beneficiaries, err := bu.beneficiaryRepository.List(ctx, filter)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return beneficiaries, nil
+}
+
+func (bu beneficiaryUsecase) UpdateBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) (domain.Beneficiary, error) {
+	merchant, err := getCallerMerchant(ctx, bu.merchantRepository)
+	if err != nil {
+		return domain.Beneficiary{}, err
+	}
+
+	existing, err := getBeneficiary(ctx, bu.beneficiaryRepository, beneficiary.Id)
+	if err != nil {
+		return domain.Beneficiary{}, err
+	}
+
+	existing.Name = beneficiary.Name
+	existing.AccountNumber = beneficiary.AccountNumber
+	existing.BankCode = beneficiary.BankCode
+
+	existing.Verification, err = bu.verify(ctx, merchant, existing)
+	if err != nil {
+		return domain.Beneficiary{}, err
+	}
+
+	err = bu.beneficiaryRepository.UpdateById(ctx, existing.Id, existing)
+	if err != nil {
+		log.Println(err)
+		return domain.Beneficiary{}, err
+	}
+
+	return getBeneficiary(ctx, bu.beneficiaryRepository, existing.Id)
+}
+
+func (bu beneficiaryUsecase) DeleteBeneficiary(ctx context.Context, id string) error {
+	err := bu.beneficiaryRepository.DeleteById(ctx, id)
+	if errors.Is(err, internal_error.ErrNoRowsAffected) {
+		return internal_error.ErrBeneficiaryNotFound
+	}
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return nil
+}
+
+// verify refuse the beneficiary the merchant cannot disburse to, so only a verified account is saved
+func (bu beneficiaryUsecase) verify(ctx context.Context, merchant domain.Merchant, beneficiary domain.Beneficiary) (domain.BeneficiaryVerification, error) {
+	if !merchant.Settings.IsBankCodeAllowed(beneficiary.BankCode) {
+		return domain.BeneficiaryVerification{}, internal_error.ErrBankCodeNotAllowed
+	}
+
+	verification, err := verifyBeneficiary(ctx, bu.bankApi, bu.nameMatcher, beneficiary, bu.now())
+	if err != nil {
+		return domain.BeneficiaryVerification{}, err
+	}
+
+	_, err = checkNameMatch(merchant, verification.NameMatchScore)
+	if err != nil {
+		return domain.BeneficiaryVerification{}, err
+	}
+
+	return verification, nil
+}
+
+// verifyBeneficiary ask the bank the account of the beneficiary. An account that cannot receive money is returned
+// with the error so the result can be recorded, the verification is empty when the bank did not answer
+func verifyBeneficiary(ctx context.Context, bankApi api.Bank, nameMatcher api.NameMatcher, beneficiary domain.Beneficiary, now time.Time) (domain.BeneficiaryVerification, error) {
+	accountHolderName, err := verifyAccount(ctx, bankApi, beneficiary.Recipient(domain.Disbursement{}))
+	switch {
+	case errors.Is(err, internal_error.ErrVerifyAccountNotFound):
+		return domain.BeneficiaryVerification{Status: domain.BeneficiaryAccountNotFound, VerifiedAt: now}, err
+	case errors.Is(err, internal_error.ErrVerifyAccountBlocked):
+		return domain.BeneficiaryVerification{Status: domain.BeneficiaryAccountBlocked, VerifiedAt: now}, err
+	case err != nil:
+		return domain.BeneficiaryVerification{}, err
+	}
+
+	return domain.BeneficiaryVerification{
+		Status:            domain.BeneficiaryVerified,
+		AccountHolderName: accountHolderName,
+		NameMatchScore:    nameMatcher.Score(beneficiary.Name, accountHolderName),
+		VerifiedAt:        now,
+	}, nil
+}
+
+// getBeneficiary return the beneficiary in the merchant scope of the caller
+func getBeneficiary(ctx context.Context, beneficiaryRepository repository.Beneficiary, id string) (domain.Beneficiary, error) {
+	beneficiary, err := beneficiaryRepository.GetById(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return domain.Beneficiary{}, err
+	}
+
+	if beneficiary == nil {
+		return domain.Beneficiary{}, internal_error.ErrBeneficiaryNotFound
+	}
+
+	return *beneficiary, nil
+}
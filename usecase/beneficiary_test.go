@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	mock_api "github.com/nobbyphala/Brick/mock/api"
+	mock_repository "github.com/nobbyphala/Brick/mock/repository"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_beneficiaryUsecase_CreateBeneficiary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBankApi := mock_api.NewMockBank(ctrl)
+	mockNameMatcher := mock_api.NewMockNameMatcher(ctrl)
+	mockBeneficiaryRepo := mock_repository.NewMockBeneficiary(ctrl)
+	mockMerchantRepo := mock_repository.NewMockMerchant(ctrl)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	merchantCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: "merchant-1"})
+	beneficiary := domain.Beneficiary{
+		Name:          "Nobby Phala",
+		AccountNumber: "6789567",
+		BankCode:      "014",
+	}
+	verifyRequest := api.VerifyAccountRequest{
+		AccountHolderName:   "Nobby Phala",
+		AccountHolderNumber: "6789567",
+		BankCode:            "014",
+	}
+	verified := domain.Beneficiary{
+		MerchantId:    "merchant-1",
+		Name:          "Nobby Phala",
+		AccountNumber: "6789567",
+		BankCode:      "014",
+		Verification: domain.BeneficiaryVerification{
+			Status:            domain.BeneficiaryVerified,
+			AccountHolderName: "Nobby Phala Putra",
+			NameMatchScore:    88,
+			VerifiedAt:        now,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    domain.Beneficiary
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "verified beneficiary saved",
+			ctx:  merchantCtx,
+			want: func() domain.Beneficiary {
+				saved := verified
+				saved.Id = "beneficiary-1"
+				saved.CreatedAt = now
+				return saved
+			}(),
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), verifyRequest).Return(api.VerifyAccountResponse{
+					AccountHolderName: "Nobby Phala Putra",
+					AccountStatus:     api.AccountVerifiedStatus,
+				}, nil)
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Nobby Phala Putra").Return(88)
+				mockBeneficiaryRepo.EXPECT().Insert(gomock.Any(), verified).Return("beneficiary-1", nil)
+				mockBeneficiaryRepo.EXPECT().GetById(gomock.Any(), "beneficiary-1").DoAndReturn(func(ctx context.Context, id string) (*domain.Beneficiary, error) {
+					saved := verified
+					saved.Id = id
+					saved.CreatedAt = now
+					return &saved, nil
+				})
+			},
+		},
+		{
+			name:    "blocked account not saved",
+			ctx:     merchantCtx,
+			wantErr: internal_error.ErrVerifyAccountBlocked,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), verifyRequest).Return(api.VerifyAccountResponse{AccountStatus: api.AccountBlockedStatus}, nil)
+			},
+		},
+		{
+			name:    "name not matching the account holder not saved",
+			ctx:     merchantCtx,
+			wantErr: internal_error.ErrRecipientNameMismatch,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{
+					Id:       "merchant-1",
+					Settings: domain.MerchantSettings{NameMatchRejectBelow: 90},
+				}, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), verifyRequest).Return(api.VerifyAccountResponse{
+					AccountHolderName: "Nobby Phala Putra",
+					AccountStatus:     api.AccountVerifiedStatus,
+				}, nil)
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Nobby Phala Putra").Return(88)
+			},
+		},
+		{
+			name:    "bank code not allowed for merchant",
+			ctx:     merchantCtx,
+			wantErr: internal_error.ErrBankCodeNotAllowed,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{
+					Id:       "merchant-1",
+					Settings: domain.MerchantSettings{AllowedBankCodes: []string{"009"}},
+				}, nil)
+			},
+		},
+		{
+			name:    "caller without merchant",
+			ctx:     context.TODO(),
+			wantErr: internal_error.ErrMerchantRequired,
+			mock:    func() {},
+		},
+		{
+			name:    "error insert beneficiary",
+			ctx:     merchantCtx,
+			wantErr: errors.New("sql error"),
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), verifyRequest).Return(api.VerifyAccountResponse{
+					AccountHolderName: "Nobby Phala Putra",
+					AccountStatus:     api.AccountVerifiedStatus,
+				}, nil)
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Nobby Phala Putra").Return(88)
+				mockBeneficiaryRepo.EXPECT().Insert(gomock.Any(), verified).Return("", errors.New("sql error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			bu := NewBeneficiary(BeneficiaryDeps{
+				BankApi:               mockBankApi,
+				NameMatcher:           mockNameMatcher,
+				BeneficiaryRepository: mockBeneficiaryRepo,
+				MerchantRepository:    mockMerchantRepo,
+			})
+			bu.now = func() time.Time { return now }
+
+			got, err := bu.CreateBeneficiary(tt.ctx, beneficiary)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_beneficiaryUsecase_UpdateBeneficiary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBankApi := mock_api.NewMockBank(ctrl)
+	mockNameMatcher := mock_api.NewMockNameMatcher(ctrl)
+	mockBeneficiaryRepo := mock_repository.NewMockBeneficiary(ctrl)
+	mockMerchantRepo := mock_repository.NewMockMerchant(ctrl)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	merchantCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: "merchant-1"})
+	existing := domain.Beneficiary{
+		Id:            "beneficiary-1",
+		MerchantId:    "merchant-1",
+		Name:          "Nobby Phala",
+		AccountNumber: "6789567",
+		BankCode:      "014",
+		Verification: domain.BeneficiaryVerification{
+			Status:            domain.BeneficiaryVerified,
+			AccountHolderName: "Nobby Phala Putra",
+			NameMatchScore:    88,
+			VerifiedAt:        now.Add(-time.Hour),
+		},
+	}
+	updated := domain.Beneficiary{
+		Id:            "beneficiary-1",
+		MerchantId:    "merchant-1",
+		Name:          "Nobby Phala Putra",
+		AccountNumber: "1234567",
+		BankCode:      "009",
+		Verification: domain.BeneficiaryVerification{
+			Status:            domain.BeneficiaryVerified,
+			AccountHolderName: "Nobby Phala Putra",
+			NameMatchScore:    100,
+			VerifiedAt:        now,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		want    domain.Beneficiary
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "changed account verified again",
+			want: updated,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockBeneficiaryRepo.EXPECT().GetById(gomock.Any(), "beneficiary-1").Return(&existing, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), api.VerifyAccountRequest{
+					AccountHolderName:   "Nobby Phala Putra",
+					AccountHolderNumber: "1234567",
+					BankCode:            "009",
+				}).Return(api.VerifyAccountResponse{
+					AccountHolderName: "Nobby Phala Putra",
+					AccountStatus:     api.AccountVerifiedStatus,
+				}, nil)
+				mockNameMatcher.EXPECT().Score("Nobby Phala Putra", "Nobby Phala Putra").Return(100)
+				mockBeneficiaryRepo.EXPECT().UpdateById(gomock.Any(), "beneficiary-1", updated).Return(nil)
+				mockBeneficiaryRepo.EXPECT().GetById(gomock.Any(), "beneficiary-1").Return(&updated, nil)
+			},
+		},
+		{
+			name:    "beneficiary not found",
+			wantErr: internal_error.ErrBeneficiaryNotFound,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockBeneficiaryRepo.EXPECT().GetById(gomock.Any(), "beneficiary-1").Return(nil, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			bu := NewBeneficiary(BeneficiaryDeps{
+				BankApi:               mockBankApi,
+				NameMatcher:           mockNameMatcher,
+				BeneficiaryRepository: mockBeneficiaryRepo,
+				MerchantRepository:    mockMerchantRepo,
+			})
+			bu.now = func() time.Time { return now }
+
+			got, err := bu.UpdateBeneficiary(merchantCtx, domain.Beneficiary{
+				Id:            "beneficiary-1",
+				Name:          "Nobby Phala Putra",
+				AccountNumber: "1234567",
+				BankCode:      "009",
+			})
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_beneficiaryUsecase_DeleteBeneficiary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBeneficiaryRepo := mock_repository.NewMockBeneficiary(ctrl)
+
+	tests := []struct {
+		name    string
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "success delete",
+			mock: func() {
+				mockBeneficiaryRepo.EXPECT().DeleteById(gomock.Any(), "beneficiary-1").Return(nil)
+			},
+		},
+		{
+			name:    "beneficiary not found",
+			wantErr: internal_error.ErrBeneficiaryNotFound,
+			mock: func() {
+				mockBeneficiaryRepo.EXPECT().DeleteById(gomock.Any(), "beneficiary-1").Return(internal_error.ErrNoRowsAffected)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			bu := NewBeneficiary(BeneficiaryDeps{BeneficiaryRepository: mockBeneficiaryRepo})
+
+			err := bu.DeleteBeneficiary(context.TODO(), "beneficiary-1")
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_beneficiaryUsecase_ListBeneficiaries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBeneficiaryRepo := mock_repository.NewMockBeneficiary(ctrl)
+	bu := NewBeneficiary(BeneficiaryDeps{BeneficiaryRepository: mockBeneficiaryRepo})
+
+	mockBeneficiaryRepo.EXPECT().List(gomock.Any(), domain.BeneficiaryFilter{BankCode: "014", Limit: 10}).Return([]domain.Beneficiary{{Id: "beneficiary-1"}}, nil)
+	got, err := bu.ListBeneficiaries(context.TODO(), domain.BeneficiaryFilter{BankCode: "014", Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Beneficiary{{Id: "beneficiary-1"}}, got)
+
+	_, err = bu.ListBeneficiaries(context.TODO(), domain.BeneficiaryFilter{Limit: maxBeneficiaryListLimit + 1})
+	assert.Equal(t, internal_error.ErrInvalidRequest, err)
+}
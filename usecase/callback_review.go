@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"log"
+	"strings"
+	"time"
+)
+
+type callbackReviewUsecase struct {
+	callbackReviewRepository repository.CallbackReview
+	disbursementRepository   repository.Disbursement
+	auditLogRepository       repository.AuditLog
+	utilsRepository          repository.Utils
+	webhookOutbox            webhookOutbox
+	eventOutbox              eventOutbox
+}
+
+type CallbackReviewDeps struct {
+	CallbackReviewRepository repository.CallbackReview
+	DisbursementRepository   repository.Disbursement
+	AuditLogRepository       repository.AuditLog
+	UtilsRepository          repository.Utils
+	// applied callback status is sent to the webhook endpoints of the merchant
+	WebhookEndpointRepository repository.WebhookEndpoint
+	WebhookDeliveryRepository repository.WebhookDelivery
+	// domain events are recorded in the transaction of the change
+	OutboxRepository repository.Outbox
+}
+
+func NewCallbackReview(deps CallbackReviewDeps) *callbackReviewUsecase {
+	return &callbackReviewUsecase{
+		callbackReviewRepository: deps.CallbackReviewRepository,
+		disbursementRepository:   deps.DisbursementRepository,
+		auditLogRepository:       deps.AuditLogRepository,
+		utilsRepository:          deps.UtilsRepository,
+		webhookOutbox: webhookOutbox{
+			endpointRepository: deps.WebhookEndpointRepository,
+			deliveryRepository: deps.WebhookDeliveryRepository,
+		},
+		eventOutbox: eventOutbox{
+			outboxRepository: deps.OutboxRepository,
+		},
+	}
+}
+
+func (cr callbackReviewUsecase) ListCallbackReviews(ctx context.Context, filter domain.CallbackReviewFilter) ([]domain.CallbackReview, error) {
+	return cr.callbackReviewRepository.List(ctx, filter)
+}
+
+func (cr callbackReviewUsecase) GetCallbackReview(ctx context.Context, id string) (CallbackReviewDetail, error) {
+	review, err := cr.getReview(ctx, cr.callbackReviewRepository, id)
+	if err != nil {
+		return CallbackReviewDetail{}, err
+	}
+
+	comments, err := cr.callbackReviewRepository.ListComments(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return CallbackReviewDetail{}, err
+	}
+
+	return CallbackReviewDetail{
+		Review:   review,
+		Comments: comments,
+	}, nil
+}
+
+func (cr callbackReviewUsecase) AssignCallbackReview(ctx context.Context, id string, assigneeId string) (domain.CallbackReview, error) {
+	if assigneeId == "" {
+		assigneeId = actorId(ctx)
+	}
+
+	review, err := cr.getReview(ctx, cr.callbackReviewRepository, id)
+	if err != nil {
+		return domain.CallbackReview{}, err
+	}
+
+	review.AssigneeId = assigneeId
+	review.UpdatedAt = time.Now()
+
+	err = cr.callbackReviewRepository.UpdateById(ctx, id, review)
+	if err != nil {
+		return domain.CallbackReview{}, toCallbackReviewUpdateError(err)
+	}
+
+	return review, nil
+}
+
+func (cr callbackReviewUsecase) ResolveCallbackReview(ctx context.Context, id string, resolution domain.CallbackReviewResolution, note string) (domain.CallbackReview, error) {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return domain.CallbackReview{}, internal_error.ErrOperationReasonRequired
+	}
+
+	if resolution != domain.CallbackReviewResolutionApply && resolution != domain.CallbackReviewResolutionDiscard {
+		return domain.CallbackReview{}, internal_error.ErrCallbackReviewInvalidResolution
+	}
+
+	var res domain.CallbackReview
+
+	err := cr.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+		reviewRepo := cr.callbackReviewRepository.WithTx(Tx)
+
+		review, err := cr.getReview(ctx, reviewRepo, id)
+		if err != nil {
+			return err
+		}
+
+		if !review.IsOpen() {
+			return internal_error.ErrCallbackReviewResolved
+		}
+
+		auditLog := domain.AuditLog{
+			Action:     domain.AuditActionResolveCallback,
+			ResourceId: review.Id,
+			Outcome:    domain.AuditOutcomeAllowed,
+			Reason:     fmt.Sprintf("discard callback of %s: %s", review.TransactionId, note),
+		}
+		auditLog = withActor(ctx, auditLog)
+
+		review.Status = domain.CallbackReviewStatusDiscarded
+		if resolution == domain.CallbackReviewResolutionApply {
+			review.Status = domain.CallbackReviewStatusApplied
+
+			auditLog.Reason, err = cr.applyCallback(ctx, Tx, review)
+			if err != nil {
+				return err
+			}
+
+			auditLog.Reason += ": " + note
+		}
+
+		resolvedAt := time.Now()
+		review.ResolvedBy = auditLog.ActorId
+		review.ResolutionNote = note
+		review.ResolvedAt = &resolvedAt
+		review.UpdatedAt = resolvedAt
+		if review.AssigneeId == "" {
+			review.AssigneeId = auditLog.ActorId
+		}
+
+		err = reviewRepo.UpdateById(ctx, id, review)
+		if err != nil {
+			return toCallbackReviewUpdateError(err)
+		}
+
+		_, err = cr.auditLogRepository.WithTx(Tx).Insert(ctx, auditLog)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		res = review
+		return nil
+	})
+	if err != nil {
+		return domain.CallbackReview{}, err
+	}
+
+	return res, nil
+}
+
+// applyCallback set the disbursement status to the status of the parked callback regardless of the current status,
+// return the description of the change for the audit log
+func (cr callbackReviewUsecase) applyCallback(ctx context.Context, Tx database.SQLDatabase, review domain.CallbackReview) (string, error) {
+	var bankCallback BankCallbackData
+
+	err := json.Unmarshal(review.Payload, &bankCallback)
+	if err != nil {
+		log.Println(err)
+		return "", internal_error.ErrForceStatusInvalid
+	}
+
+	status := toDisbursementStatus(bankCallback.Status)
+	if status == domain.DisbursementStatusUnknown {
+		return "", internal_error.ErrForceStatusInvalid
+	}
+
+	disbursementRepo := cr.disbursementRepository.WithTx(Tx)
+
+	disbursement, err := disbursementRepo.GetByTransactionId(ctx, review.TransactionId)
+	if err != nil {
+		log.Println(err)
+		return "", err
+	}
+
+	// the disbursement should exist by now, for example when the callback arrived before the disbursement committed
+	if disbursement == nil {
+		return "", internal_error.ErrDisbursementNotFound
+	}
+
+	change := fmt.Sprintf("apply callback of %s to disbursement %s, %s -> %s", review.TransactionId, disbursement.Id, disbursement.Status.ToString(), status.ToString())
+
+	if disbursement.Status == status {
+		return change, nil
+	}
+
+	previousVersion := disbursement.Version
+	previousStatus := disbursement.Status
+	disbursement.Status = status
+
+	err = disbursementRepo.UpdateById(ctx, disbursement.Id, previousVersion, *disbursement)
+	if errors.Is(err, internal_error.ErrVersionConflict) {
+		return "", err
+	}
+	if err != nil {
+		log.Println(err)
+		return "", internal_error.ErrUpdateDisbursementStatus
+	}
+
+	disbursement.Version = previousVersion + 1
+
+	err = cr.webhookOutbox.enqueue(ctx, Tx, *disbursement)
+	if err != nil {
+		log.Println(err)
+		return "", internal_error.ErrUpdateDisbursementStatus
+	}
+
+	err = cr.eventOutbox.disbursementStatusChanged(ctx, Tx, *disbursement, previousStatus)
+	if err != nil {
+		log.Println(err)
+		return "", internal_error.ErrUpdateDisbursementStatus
+	}
+
+	return change, nil
+}
+
+func (cr callbackReviewUsecase) CommentCallbackReview(ctx context.Context, id string, body string) (domain.CallbackReviewComment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return domain.CallbackReviewComment{}, internal_error.ErrCallbackReviewCommentRequired
+	}
+
+	_, err := cr.getReview(ctx, cr.callbackReviewRepository, id)
+	if err != nil {
+		return domain.CallbackReviewComment{}, err
+	}
+
+	comment := domain.CallbackReviewComment{
+		ReviewId: id,
+		AuthorId: actorId(ctx),
+		Body:     body,
+	}
+
+	comment.Id, err = cr.callbackReviewRepository.InsertComment(ctx, comment)
+	if err != nil {
+		log.Println(err)
+		return domain.CallbackReviewComment{}, err
+	}
+
+	comment.CreatedAt = time.Now()
+
+	return comment, nil
+}
+
+func (cr callbackReviewUsecase) CountOpenCallbackReviews(ctx context.Context) ([]domain.CallbackReviewCount, error) {
+	return cr.callbackReviewRepository.CountOpen(ctx)
+}
+
+func (cr callbackReviewUsecase) getReview(ctx context.Context, reviewRepo repository.CallbackReview, id string) (domain.CallbackReview, error) {
+	review, err := reviewRepo.GetById(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return domain.CallbackReview{}, err
+	}
+
+	if review == nil {
+		return domain.CallbackReview{}, internal_error.ErrCallbackReviewNotFound
+	}
+
+	return *review, nil
+}
+
+// toCallbackReviewUpdateError map the error of updating review, the update only affect open review
+func toCallbackReviewUpdateError(err error) error {
+	if errors.Is(err, internal_error.ErrNoRowsAffected) {
+		return internal_error.ErrCallbackReviewResolved
+	}
+
+	log.Println(err)
+	return err
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/mock"
+	mock_repository "github.com/nobbyphala/Brick/mock/repository"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func Test_callbackReviewUsecase_ResolveCallbackReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+	mockCallbackReviewRepo := mock_repository.NewMockCallbackReview(ctrl)
+	mockDisbursementRepo := mock_repository.NewMockDisbursement(ctrl)
+	mockAuditLogRepo := mock_repository.NewMockAuditLog(ctrl)
+	mockUtilRepo := mock_repository.NewMockUtils(ctrl)
+	mockOutboxRepo := mock_repository.NewMockOutbox(ctrl)
+
+	operatorCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsOperator})
+	openReview := domain.CallbackReview{
+		Id:             "review-1",
+		TransactionId:  "txn-id-1",
+		DisbursementId: "disb-id-1",
+		Reason:         domain.CallbackReviewReasonInvalidTransition,
+		Payload:        []byte(`{"transaction_id":"txn-id-1","status":"COMPLETED"}`),
+		Status:         domain.CallbackReviewStatusOpen,
+	}
+	runTx := func() {
+		mockCallbackReviewRepo.EXPECT().WithTx(mockSQL).Return(mockCallbackReviewRepo)
+		mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+			return handler(ctx, mockSQL)
+		})
+	}
+	expectResolved := func(status domain.CallbackReviewStatus, auditReason string) {
+		mockCallbackReviewRepo.EXPECT().UpdateById(gomock.Any(), "review-1", gomock.Any()).DoAndReturn(func(ctx context.Context, id string, review domain.CallbackReview) error {
+			assert.Equal(t, status, review.Status)
+			assert.Equal(t, "ops-1", review.ResolvedBy)
+			assert.Equal(t, "ops-1", review.AssigneeId)
+			assert.Equal(t, "bank confirmed by email", review.ResolutionNote)
+			assert.NotNil(t, review.ResolvedAt)
+			return nil
+		})
+		mockAuditLogRepo.EXPECT().WithTx(mockSQL).Return(mockAuditLogRepo)
+		mockAuditLogRepo.EXPECT().Insert(gomock.Any(), domain.AuditLog{
+			ActorId:    "ops-1",
+			ActorRole:  domain.RoleOpsOperator,
+			Action:     domain.AuditActionResolveCallback,
+			ResourceId: "review-1",
+			Outcome:    domain.AuditOutcomeAllowed,
+			Reason:     auditReason,
+		}).Return("audit-id-1", nil)
+	}
+
+	tests := []struct {
+		name       string
+		resolution domain.CallbackReviewResolution
+		note       string
+		wantStatus domain.CallbackReviewStatus
+		wantErr    error
+		mock       func()
+	}{
+		{
+			name:       "apply callback to failed disbursement",
+			resolution: domain.CallbackReviewResolutionApply,
+			note:       "bank confirmed by email",
+			wantStatus: domain.CallbackReviewStatusApplied,
+			mock: func() {
+				runTx()
+				review := openReview
+				mockCallbackReviewRepo.EXPECT().GetById(gomock.Any(), "review-1").Return(&review, nil)
+				mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+				mockDisbursementRepo.EXPECT().GetByTransactionId(gomock.Any(), "txn-id-1").Return(&domain.Disbursement{
+					Id:                "disb-id-1",
+					BankTransactionId: "txn-id-1",
+					Status:            domain.DisbursementStatusFailed,
+					Version:           2,
+				}, nil)
+				mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(2), domain.Disbursement{
+					Id:                "disb-id-1",
+					BankTransactionId: "txn-id-1",
+					Status:            domain.DisbursementStatusCompleted,
+					Version:           2,
+				}).Return(nil)
+				mockOutboxRepo.EXPECT().WithTx(mockSQL).Return(mockOutboxRepo)
+				mockOutboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event domain.OutboxEvent) error {
+					assert.Equal(t, domain.EventTypeDisbursementStatusChanged, event.Type)
+					assert.Equal(t, "disb-id-1", event.AggregateId)
+					var changed domain.DisbursementStatusChanged
+					assert.NoError(t, json.Unmarshal(event.Payload, &changed))
+					assert.Equal(t, "FAILED", changed.PreviousStatus)
+					assert.Equal(t, "COMPLETED", changed.Status)
+					assert.Equal(t, int64(3), changed.Version)
+					return nil
+				})
+				expectResolved(domain.CallbackReviewStatusApplied, "apply callback of txn-id-1 to disbursement disb-id-1, FAILED -> COMPLETED: bank confirmed by email")
+			},
+		},
+		{
+			name:       "discard callback",
+			resolution: domain.CallbackReviewResolutionDiscard,
+			note:       "bank confirmed by email",
+			wantStatus: domain.CallbackReviewStatusDiscarded,
+			mock: func() {
+				runTx()
+				review := openReview
+				mockCallbackReviewRepo.EXPECT().GetById(gomock.Any(), "review-1").Return(&review, nil)
+				expectResolved(domain.CallbackReviewStatusDiscarded, "discard callback of txn-id-1: bank confirmed by email")
+			},
+		},
+		{
+			name:       "apply callback of disbursement still not found",
+			resolution: domain.CallbackReviewResolutionApply,
+			note:       "bank confirmed by email",
+			wantErr:    internal_error.ErrDisbursementNotFound,
+			mock: func() {
+				runTx()
+				review := openReview
+				mockCallbackReviewRepo.EXPECT().GetById(gomock.Any(), "review-1").Return(&review, nil)
+				mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+				mockDisbursementRepo.EXPECT().GetByTransactionId(gomock.Any(), "txn-id-1").Return(nil, nil)
+			},
+		},
+		{
+			name:       "review already resolved",
+			resolution: domain.CallbackReviewResolutionDiscard,
+			note:       "bank confirmed by email",
+			wantErr:    internal_error.ErrCallbackReviewResolved,
+			mock: func() {
+				runTx()
+				review := openReview
+				review.Status = domain.CallbackReviewStatusDiscarded
+				mockCallbackReviewRepo.EXPECT().GetById(gomock.Any(), "review-1").Return(&review, nil)
+			},
+		},
+		{
+			name:       "review not found",
+			resolution: domain.CallbackReviewResolutionDiscard,
+			note:       "bank confirmed by email",
+			wantErr:    internal_error.ErrCallbackReviewNotFound,
+			mock: func() {
+				runTx()
+				mockCallbackReviewRepo.EXPECT().GetById(gomock.Any(), "review-1").Return(nil, nil)
+			},
+		},
+		{
+			name:       "note is mandatory",
+			resolution: domain.CallbackReviewResolutionDiscard,
+			wantErr:    internal_error.ErrOperationReasonRequired,
+			mock:       func() {},
+		},
+		{
+			name:       "unknown resolution",
+			resolution: "ignore",
+			note:       "bank confirmed by email",
+			wantErr:    internal_error.ErrCallbackReviewInvalidResolution,
+			mock:       func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			cr := NewCallbackReview(CallbackReviewDeps{
+				CallbackReviewRepository: mockCallbackReviewRepo,
+				DisbursementRepository:   mockDisbursementRepo,
+				AuditLogRepository:       mockAuditLogRepo,
+				UtilsRepository:          mockUtilRepo,
+				OutboxRepository:         mockOutboxRepo,
+			})
+			got, err := cr.ResolveCallbackReview(operatorCtx, "review-1", tt.resolution, tt.note)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+		})
+	}
+}
+
+func Test_callbackReviewUsecase_AssignCallbackReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCallbackReviewRepo := mock_repository.NewMockCallbackReview(ctrl)
+	cr := NewCallbackReview(CallbackReviewDeps{CallbackReviewRepository: mockCallbackReviewRepo})
+	operatorCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsOperator})
+
+	// assigned to the caller when assignee is empty
+	mockCallbackReviewRepo.EXPECT().GetById(gomock.Any(), "review-1").Return(&domain.CallbackReview{Id: "review-1", Status: domain.CallbackReviewStatusOpen}, nil)
+	mockCallbackReviewRepo.EXPECT().UpdateById(gomock.Any(), "review-1", gomock.Any()).DoAndReturn(func(ctx context.Context, id string, review domain.CallbackReview) error {
+		assert.Equal(t, "ops-1", review.AssigneeId)
+		assert.Equal(t, domain.CallbackReviewStatusOpen, review.Status)
+		return nil
+	})
+
+	got, err := cr.AssignCallbackReview(operatorCtx, "review-1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "ops-1", got.AssigneeId)
+
+	// resolved review cannot be reassigned
+	mockCallbackReviewRepo.EXPECT().GetById(gomock.Any(), "review-1").Return(&domain.CallbackReview{Id: "review-1", Status: domain.CallbackReviewStatusApplied}, nil)
+	mockCallbackReviewRepo.EXPECT().UpdateById(gomock.Any(), "review-1", gomock.Any()).Return(internal_error.ErrNoRowsAffected)
+
+	_, err = cr.AssignCallbackReview(operatorCtx, "review-1", "ops-2")
+	assert.Equal(t, internal_error.ErrCallbackReviewResolved, err)
+}
+
+func Test_callbackReviewUsecase_CommentCallbackReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCallbackReviewRepo := mock_repository.NewMockCallbackReview(ctrl)
+	cr := NewCallbackReview(CallbackReviewDeps{CallbackReviewRepository: mockCallbackReviewRepo})
+	operatorCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsOperator})
+
+	_, err := cr.CommentCallbackReview(operatorCtx, "review-1", " ")
+	assert.Equal(t, internal_error.ErrCallbackReviewCommentRequired, err)
+
+	mockCallbackReviewRepo.EXPECT().GetById(gomock.Any(), "review-1").Return(&domain.CallbackReview{Id: "review-1"}, nil)
+	mockCallbackReviewRepo.EXPECT().InsertComment(gomock.Any(), domain.CallbackReviewComment{
+		ReviewId: "review-1",
+		AuthorId: "ops-1",
+		Body:     "asked the bank for the transfer receipt",
+	}).Return("comment-1", nil)
+
+	got, err := cr.CommentCallbackReview(operatorCtx, "review-1", "asked the bank for the transfer receipt")
+	assert.NoError(t, err)
+	assert.Equal(t, "comment-1", got.Id)
+}
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/nobbyphala/Brick/domain"
 	"github.com/nobbyphala/Brick/domain/internal_error"
@@ -9,57 +11,248 @@ import (
 	"github.com/nobbyphala/Brick/usecase/api"
 	"github.com/nobbyphala/Brick/usecase/repository"
 	"log"
+	"time"
+)
+
+const (
+	maxVersionConflictRetries = 3
+	// callers page through the disbursements, a single page never load more than this
+	maxDisbursementListLimit = 100
 )
 
 type disbursementUsecase struct {
-	bankApi                api.Bank
-	disbursementRepository repository.Disbursement
-	utilsRepository        repository.Utils
+	bankApi                  api.Bank
+	nameMatcher              api.NameMatcher
+	disbursementRepository   repository.Disbursement
+	merchantRepository       repository.Merchant
+	callbackReviewRepository repository.CallbackReview
+	utilsRepository          repository.Utils
+	webhookOutbox            webhookOutbox
+	eventOutbox              eventOutbox
+	verificationToken        verificationToken
+	beneficiaryRepository    repository.Beneficiary
+	beneficiaryMaxAge        time.Duration
+	screener                 Screener
+	screeningRepository      repository.Screening
 }
 
 type DisbursementDeps struct {
-	BankApi                api.Bank
+	BankApi api.Bank
+	// compare the recipient name with the account holder name reported by the bank
+	NameMatcher            api.NameMatcher
 	DisbursementRepository repository.Disbursement
-	UtilsRepository        repository.Utils
+	MerchantRepository     repository.Merchant
+	// bank callback that cannot be processed is parked here for manual review
+	CallbackReviewRepository repository.CallbackReview
+	UtilsRepository          repository.Utils
+	// status change is sent to the webhook endpoints of the merchant
+	WebhookEndpointRepository repository.WebhookEndpoint
+	WebhookDeliveryRepository repository.WebhookDelivery
+	// domain events are recorded in the transaction of the change
+	OutboxRepository repository.Outbox
+	// sign the verification token, no token is issued when empty
+	VerificationTokenSecret []byte
+	VerificationTokenTTL    time.Duration
+	BeneficiaryRepository   repository.Beneficiary
+	// beneficiary verified longer ago than this is verified again before disbursed to, 0 to always verify again
+	BeneficiaryMaxAge time.Duration
+	// recipient matching a sanctions list or the internal blocklist is held for compliance review
+	Screener Screener
+	// every screening decision is recorded with the disbursement
+	ScreeningRepository repository.Screening
 }
 
 func NewDisbursement(deps DisbursementDeps) *disbursementUsecase {
 	return &disbursementUsecase{
-		bankApi:                deps.BankApi,
-		disbursementRepository: deps.DisbursementRepository,
-		utilsRepository:        deps.UtilsRepository,
+		bankApi:                  deps.BankApi,
+		nameMatcher:              deps.NameMatcher,
+		disbursementRepository:   deps.DisbursementRepository,
+		merchantRepository:       deps.MerchantRepository,
+		callbackReviewRepository: deps.CallbackReviewRepository,
+		utilsRepository:          deps.UtilsRepository,
+		webhookOutbox: webhookOutbox{
+			endpointRepository: deps.WebhookEndpointRepository,
+			deliveryRepository: deps.WebhookDeliveryRepository,
+		},
+		eventOutbox: eventOutbox{
+			outboxRepository: deps.OutboxRepository,
+		},
+		verificationToken: verificationToken{
+			secret: deps.VerificationTokenSecret,
+			ttl:    deps.VerificationTokenTTL,
+		},
+		beneficiaryRepository: deps.BeneficiaryRepository,
+		beneficiaryMaxAge:     deps.BeneficiaryMaxAge,
+		screener:              deps.Screener,
+		screeningRepository:   deps.ScreeningRepository,
 	}
 }
 
-func (disb disbursementUsecase) VerifyDisbursement(ctx context.Context, disbursement domain.Disbursement) error {
-	verifyResponse, err := disb.bankApi.VerifyAccount(ctx, api.VerifyAccountRequest{
-		AccountHolderName:   disbursement.RecipientName,
-		AccountHolderNumber: disbursement.RecipientAccountNumber,
-	})
+func (disb disbursementUsecase) VerifyDisbursement(ctx context.Context, disbursement domain.Disbursement) (DisbursementVerification, error) {
+	merchant, err := getCallerMerchant(ctx, disb.merchantRepository)
 	if err != nil {
-		log.Println(err)
-		return internal_error.ErrVerifyDisbursement
+		return DisbursementVerification{}, err
 	}
 
-	switch verifyResponse.AccountStatus {
-	case api.AccountNotFoundStatus:
-		return internal_error.ErrVerifyAccountNotFound
-	case api.AccountBlockedStatus:
-		return internal_error.ErrVerifyAccountBlocked
-	case api.AccountVerifiedStatus:
-		return nil
-	default:
-		return internal_error.ErrVerifyAccountNotFound
+	err = checkMerchantSettings(merchant, disbursement)
+	if err != nil {
+		return DisbursementVerification{}, err
+	}
+
+	accountHolderName, err := verifyAccount(ctx, disb.bankApi, disbursement)
+	if err != nil {
+		return DisbursementVerification{}, err
+	}
+
+	score := disb.nameMatcher.Score(disbursement.RecipientName, accountHolderName)
+
+	decision, err := checkNameMatch(merchant, score)
+	if err != nil {
+		return DisbursementVerification{}, err
 	}
+
+	token, expiresAt := disb.verificationToken.issue(merchant.Id, disbursement, score, time.Now())
+
+	return DisbursementVerification{
+		AccountHolderName: accountHolderName,
+		NameMatchScore:    score,
+		NameMatchDecision: decision,
+		Token:             token,
+		ExpiresAt:         expiresAt,
+	}, nil
 }
 
-func (disb disbursementUsecase) Disburse(ctx context.Context, disbursement domain.Disbursement) (domain.Disbursement, error) {
-	err := disb.VerifyDisbursement(ctx, disbursement)
+func (disb disbursementUsecase) Disburse(ctx context.Context, disbursement domain.Disbursement, opts DisburseOptions) (domain.Disbursement, error) {
+	merchant, err := getCallerMerchant(ctx, disb.merchantRepository)
 	if err != nil {
-		log.Println(err)
 		return domain.Disbursement{}, err
 	}
 
+	var beneficiary *domain.Beneficiary
+	if opts.BeneficiaryId != "" {
+		saved, err := getBeneficiary(ctx, disb.beneficiaryRepository, opts.BeneficiaryId)
+		if err != nil {
+			return domain.Disbursement{}, err
+		}
+
+		beneficiary = &saved
+		disbursement = saved.Recipient(disbursement)
+	}
+
+	err = checkMerchantSettings(merchant, disbursement)
+	if err != nil {
+		return domain.Disbursement{}, err
+	}
+
+	now := time.Now()
+
+	// the recipient verified moments ago by the caller is not sent to the bank again
+	score, verified := disb.verificationToken.nameMatchScore(opts.VerificationToken, merchant.Id, disbursement, now)
+	if !verified && opts.VerificationToken != "" {
+		log.Println("verification token does not match the disbursement, verifying recipient again")
+	}
+
+	if !verified && beneficiary != nil {
+		score, err = disb.beneficiaryNameMatchScore(ctx, *beneficiary, now)
+		if err != nil {
+			return domain.Disbursement{}, err
+		}
+
+		verified = true
+	}
+
+	if !verified {
+		accountHolderName, err := verifyAccount(ctx, disb.bankApi, disbursement)
+		if err != nil {
+			log.Println(err)
+			return domain.Disbursement{}, err
+		}
+
+		score = disb.nameMatcher.Score(disbursement.RecipientName, accountHolderName)
+	}
+
+	// thresholds are applied again, they may be changed after the token was issued
+	decision, err := checkNameMatch(merchant, score)
+	if err != nil {
+		log.Println(err, score)
+		return domain.Disbursement{}, err
+	}
+
+	disbursement.MerchantId = merchant.Id
+	disbursement.NameMatchScore = score
+	disbursement.NameMatchDecision = decision
+
+	screening := domain.Screening{
+		MerchantId: merchant.Id,
+		Status:     domain.ScreeningStatusCleared,
+		Matches:    disb.screener.Screen(disbursement),
+	}
+
+	disbursement.Status = domain.DisbursementStatusPending
+	if len(screening.Matches) > 0 {
+		// the money is not sent until compliance release the disbursement
+		log.Println("recipient matched screening lists, holding disbursement for compliance review")
+		screening.Status = domain.ScreeningStatusHeld
+		disbursement.Status = domain.DisbursementStatusOnHold
+	}
+
+	// the disbursement is recorded before the transfer with the merchant locked, so the concurrent disbursements of
+	// the merchant see its amount when checking the daily limit
+	err = disb.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+		err := disb.merchantRepository.WithTx(Tx).LockById(ctx, merchant.Id)
+		if err != nil {
+			return err
+		}
+
+		disbursementRepo := disb.disbursementRepository.WithTx(Tx)
+
+		err = checkDailyLimit(ctx, disbursementRepo, merchant, disbursement)
+		if err != nil {
+			return err
+		}
+
+		insertedId, err := disbursementRepo.Insert(ctx, domain.Disbursement{
+			MerchantId:             disbursement.MerchantId,
+			RecipientName:          disbursement.RecipientName,
+			RecipientAccountNumber: disbursement.RecipientAccountNumber,
+			RecipientBankCode:      disbursement.RecipientBankCode,
+			Amount:                 disbursement.Amount,
+			Status:                 disbursement.Status,
+			NameMatchScore:         disbursement.NameMatchScore,
+			NameMatchDecision:      disbursement.NameMatchDecision,
+		})
+		if err != nil {
+			return err
+		}
+
+		disbursement.Id = insertedId
+		screening.DisbursementId = insertedId
+
+		_, err = disb.screeningRepository.WithTx(Tx).Insert(ctx, screening)
+		if err != nil {
+			return err
+		}
+
+		return disb.eventOutbox.disbursementCreated(ctx, Tx, disbursement)
+	})
+	if errors.Is(err, internal_error.ErrMerchantDailyLimitExceeded) {
+		return domain.Disbursement{}, err
+	}
+	if err != nil {
+		log.Println(err)
+		return domain.Disbursement{}, internal_error.ErrDisburseDisbursement
+	}
+
+	if disbursement.Status == domain.DisbursementStatusOnHold {
+		return disbursement, nil
+	}
+
+	return disb.transfer(ctx, disbursement)
+}
+
+// transfer send the money of the recorded disbursement and record the bank transaction id. The disbursement is
+// failed only when the bank refuse the transfer, so its amount no longer count toward the daily limit
+func (disb disbursementUsecase) transfer(ctx context.Context, disbursement domain.Disbursement) (domain.Disbursement, error) {
 	transferResponse, err := disb.bankApi.TransferMoney(ctx, api.TransferRequest{
 		AccountHolderNumber: disbursement.RecipientAccountNumber,
 		AccountHolderName:   disbursement.RecipientName,
@@ -67,32 +260,231 @@ func (disb disbursementUsecase) Disburse(ctx context.Context, disbursement domai
 		Amount:              disbursement.Amount,
 	})
 	if err != nil {
-		log.Println(err)
+		// the bank may have moved the money, the disbursement stay pending and keep counting toward the daily limit
+		// until its bank callback, parked for review without the bank transaction id, settle it
+		log.Println("error transferring disbursement", disbursement.Id, err)
 		return domain.Disbursement{}, internal_error.ErrDisburseBankError
 	}
 
-	insertedId, err := disb.disbursementRepository.Insert(ctx, domain.Disbursement{
-		RecipientName:          disbursement.RecipientName,
-		RecipientAccountNumber: disbursement.RecipientAccountNumber,
-		RecipientBankCode:      disbursement.RecipientBankCode,
-		BankTransactionId:      transferResponse.TransactionId,
-		Amount:                 disbursement.Amount,
-		Status:                 domain.DisbursementStatusPending,
+	status := domain.DisbursementStatusPending
+	bankStatus := toDisbursementStatus(transferResponse.TransferStatus)
+	if bankStatus == domain.DisbursementStatusFailed || bankStatus == domain.DisbursementStatusRejected {
+		log.Println("bank refused the transfer of disbursement", disbursement.Id, transferResponse.TransferStatus)
+		status = bankStatus
+	}
+
+	err = disb.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+		disbursementRepo := disb.disbursementRepository.WithTx(Tx)
+
+		recorded, err := disbursementRepo.GetById(ctx, disbursement.Id)
+		if err != nil {
+			return err
+		}
+
+		if recorded == nil {
+			return internal_error.ErrDisbursementNotFound
+		}
+
+		disbursement = *recorded
+		previousStatus := disbursement.Status
+		disbursement.BankTransactionId = transferResponse.TransactionId
+		disbursement.Status = status
+
+		err = disbursementRepo.UpdateById(ctx, disbursement.Id, disbursement.Version, disbursement)
+		if err != nil {
+			return err
+		}
+
+		disbursement.Version++
+
+		if disbursement.Status == previousStatus {
+			return nil
+		}
+
+		err = disb.webhookOutbox.enqueue(ctx, Tx, disbursement)
+		if err != nil {
+			return err
+		}
+
+		return disb.eventOutbox.disbursementStatusChanged(ctx, Tx, disbursement, previousStatus)
 	})
 	if err != nil {
-		log.Println(err)
+		// the disbursement stay pending without the bank transaction id, so the bank callback of the transfer is
+		// parked for review
+		log.Println("error recording transfer of disbursement", disbursement.Id, transferResponse.TransactionId, err)
 		return domain.Disbursement{}, internal_error.ErrDisburseDisbursement
 	}
 
-	disbursement.Id = insertedId
-	disbursement.BankTransactionId = transferResponse.TransactionId
-	disbursement.Status = domain.DisbursementStatusPending
-
 	return disbursement, nil
 }
 
+func (disb disbursementUsecase) GetDisbursement(ctx context.Context, id string) (domain.Disbursement, error) {
+	disbursement, err := disb.disbursementRepository.GetById(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return domain.Disbursement{}, err
+	}
+
+	if disbursement == nil {
+		return domain.Disbursement{}, internal_error.ErrDisbursementNotFound
+	}
+
+	return *disbursement, nil
+}
+
+func (disb disbursementUsecase) ListDisbursements(ctx context.Context, filter domain.DisbursementFilter) ([]domain.Disbursement, error) {
+	if filter.Limit < 0 || filter.Limit > maxDisbursementListLimit || filter.Offset < 0 {
+		return nil, internal_error.ErrInvalidRequest
+	}
+
+	disbursements, err := disb.disbursementRepository.List(ctx, filter)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return disbursements, nil
+}
+
+// beneficiaryNameMatchScore return the score of the last verification of the beneficiary when it is recent enough,
+// otherwise the beneficiary is verified again and the result recorded
+func (disb disbursementUsecase) beneficiaryNameMatchScore(ctx context.Context, beneficiary domain.Beneficiary, now time.Time) (int, error) {
+	if disb.beneficiaryMaxAge > 0 && beneficiary.VerifiedSince(now.Add(-disb.beneficiaryMaxAge)) {
+		return beneficiary.Verification.NameMatchScore, nil
+	}
+
+	verification, verifyErr := verifyBeneficiary(ctx, disb.bankApi, disb.nameMatcher, beneficiary, now)
+	if verification.Status != "" {
+		beneficiary.Verification = verification
+
+		// the disbursement does not depend on the record, it is only verified again next time
+		err := disb.beneficiaryRepository.UpdateById(ctx, beneficiary.Id, beneficiary)
+		if err != nil {
+			log.Println("error recording beneficiary verification:", err)
+		}
+	}
+
+	if verifyErr != nil {
+		log.Println(verifyErr)
+		return 0, verifyErr
+	}
+
+	return verification.NameMatchScore, nil
+}
+
+// verifyAccount return the account holder name reported by the bank when the account can receive money
+func verifyAccount(ctx context.Context, bankApi api.Bank, disbursement domain.Disbursement) (string, error) {
+	verifyResponse, err := bankApi.VerifyAccount(ctx, api.VerifyAccountRequest{
+		AccountHolderName:   disbursement.RecipientName,
+		AccountHolderNumber: disbursement.RecipientAccountNumber,
+		BankCode:            disbursement.RecipientBankCode,
+	})
+	if err != nil {
+		log.Println(err)
+		return "", internal_error.ErrVerifyDisbursement
+	}
+
+	switch verifyResponse.AccountStatus {
+	case api.AccountNotFoundStatus:
+		return "", internal_error.ErrVerifyAccountNotFound
+	case api.AccountBlockedStatus:
+		return "", internal_error.ErrVerifyAccountBlocked
+	case api.AccountVerifiedStatus:
+		return verifyResponse.AccountHolderName, nil
+	default:
+		return "", internal_error.ErrVerifyAccountNotFound
+	}
+}
+
+// getCallerMerchant return merchant of the caller, disbursement can only be made on behalf of a merchant
+func getCallerMerchant(ctx context.Context, merchantRepository repository.Merchant) (domain.Merchant, error) {
+	merchantId, ok := domain.MerchantScopeFromContext(ctx)
+	if !ok {
+		return domain.Merchant{}, internal_error.ErrMerchantRequired
+	}
+
+	merchant, err := merchantRepository.GetById(ctx, merchantId)
+	if err != nil {
+		log.Println(err)
+		return domain.Merchant{}, err
+	}
+
+	if merchant == nil {
+		return domain.Merchant{}, internal_error.ErrMerchantNotFound
+	}
+
+	return *merchant, nil
+}
+
+// checkDailyLimit reject the disbursement exceeding the daily limit of the merchant, the merchant should be locked in
+// the transaction of the repository until the disbursement recorded
+func checkDailyLimit(ctx context.Context, disbursementRepository repository.Disbursement, merchant domain.Merchant, disbursement domain.Disbursement) error {
+	if merchant.Settings.DailyLimit == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	disbursedToday, err := disbursementRepository.SumAmountSince(ctx, startOfDay)
+	if err != nil {
+		log.Println(err)
+		return internal_error.ErrDisburseDisbursement
+	}
+
+	if disbursedToday+disbursement.Amount > merchant.Settings.DailyLimit {
+		return internal_error.ErrMerchantDailyLimitExceeded
+	}
+
+	return nil
+}
+
+// checkNameMatch reject the recipient name scoring below the reject threshold of the merchant, a name flagged for
+// review is still disbursed
+func checkNameMatch(merchant domain.Merchant, score int) (domain.NameMatchDecision, error) {
+	decision := merchant.Settings.NameMatchDecision(score)
+	if decision == domain.NameMatchRejected {
+		return decision, internal_error.ErrRecipientNameMismatch
+	}
+
+	return decision, nil
+}
+
+func checkMerchantSettings(merchant domain.Merchant, disbursement domain.Disbursement) error {
+	// a negative amount would lower the sum checked against the daily limit
+	if disbursement.Amount <= 0 {
+		return internal_error.ErrAmountInvalid
+	}
+
+	if !merchant.Settings.IsBankCodeAllowed(disbursement.RecipientBankCode) {
+		return internal_error.ErrBankCodeNotAllowed
+	}
+
+	if merchant.Settings.MaxAmount > 0 && disbursement.Amount > merchant.Settings.MaxAmount {
+		return internal_error.ErrAmountExceedLimit
+	}
+
+	return nil
+}
+
 func (disb disbursementUsecase) ProcessBankCallback(ctx context.Context, bankCallback BankCallbackData) error {
-	err := disb.utilsRepository.RunWithTransaction(ctx, func(Tx database.SQLDatabase) error {
+	var err error
+
+	// the disbursement can be updated by another process between read and update, in that case read it again
+	for attempt := 0; attempt <= maxVersionConflictRetries; attempt++ {
+		err = disb.processBankCallback(ctx, bankCallback)
+		if !errors.Is(err, internal_error.ErrVersionConflict) {
+			return err
+		}
+
+		log.Println("disbursement modified concurrently, retrying bank callback", bankCallback.TransactionId)
+	}
+
+	return internal_error.ErrUpdateDisbursementStatus
+}
+
+func (disb disbursementUsecase) processBankCallback(ctx context.Context, bankCallback BankCallbackData) error {
+	err := disb.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
 		disbursement, err := disb.disbursementRepository.WithTx(Tx).GetByTransactionId(ctx, bankCallback.TransactionId)
 		if err != nil {
 			log.Println(err)
@@ -100,25 +492,59 @@ func (disb disbursementUsecase) ProcessBankCallback(ctx context.Context, bankCal
 		}
 
 		if disbursement == nil {
-			log.Println("error disbursement not found")
-			return internal_error.ErrDisbursementNotFound
+			log.Println("error disbursement not found, parking callback for review", bankCallback.TransactionId)
+			return disb.parkBankCallback(ctx, Tx, bankCallback, domain.CallbackReview{
+				Reason: domain.CallbackReviewReasonDisbursementNotFound,
+				Detail: "no disbursement has the transaction id",
+			})
 		}
 
+		status := disb.mapTransferStatusToDisbursementStatus(bankCallback.Status)
+
 		if disbursement.Status != domain.DisbursementStatusPending {
+			// the bank resend the callback, nothing to do
+			if disbursement.Status == status {
+				log.Println("duplicate bank callback ignored", bankCallback.TransactionId)
+				return nil
+			}
+
 			// invalid status need manual intervention
-			err = internal_error.ErrDisbursementInvalidStatus
-			log.Println(fmt.Sprintf("error status should be %d but got %d", domain.DisbursementStatusPending, disbursement.Status))
-			return err
+			log.Println(fmt.Sprintf("error status should be %d but got %d, parking callback for review", domain.DisbursementStatusPending, disbursement.Status))
+			return disb.parkBankCallback(ctx, Tx, bankCallback, domain.CallbackReview{
+				DisbursementId: disbursement.Id,
+				Reason:         domain.CallbackReviewReasonInvalidTransition,
+				Detail:         fmt.Sprintf("disbursement status is %s, callback status is %s", disbursement.Status.ToString(), bankCallback.Status),
+			})
 		}
 
-		err = disb.disbursementRepository.WithTx(Tx).UpdateById(ctx, disbursement.Id, domain.Disbursement{
+		err = disb.disbursementRepository.WithTx(Tx).UpdateById(ctx, disbursement.Id, disbursement.Version, domain.Disbursement{
 			RecipientName:          disbursement.RecipientName,
 			RecipientAccountNumber: disbursement.RecipientAccountNumber,
 			RecipientBankCode:      disbursement.RecipientBankCode,
 			BankTransactionId:      disbursement.BankTransactionId,
 			Amount:                 disbursement.Amount,
-			Status:                 disb.mapTransferStatusToDisbursementStatus(bankCallback.Status),
+			Status:                 status,
+			BankEvidenceReference:  disbursement.BankEvidenceReference,
 		})
+		if errors.Is(err, internal_error.ErrVersionConflict) {
+			return err
+		}
+		if err != nil {
+			log.Println(err)
+			return internal_error.ErrUpdateDisbursementStatus
+		}
+
+		previousStatus := disbursement.Status
+		disbursement.Status = status
+		disbursement.Version++
+
+		err = disb.webhookOutbox.enqueue(ctx, Tx, *disbursement)
+		if err != nil {
+			log.Println(err)
+			return internal_error.ErrUpdateDisbursementStatus
+		}
+
+		err = disb.eventOutbox.disbursementStatusChanged(ctx, Tx, *disbursement, previousStatus)
 		if err != nil {
 			log.Println(err)
 			return internal_error.ErrUpdateDisbursementStatus
@@ -130,7 +556,43 @@ func (disb disbursementUsecase) ProcessBankCallback(ctx context.Context, bankCal
 	return err
 }
 
+// parkBankCallback put the callback to the review queue, the same callback resent by the bank is parked once
+func (disb disbursementUsecase) parkBankCallback(ctx context.Context, Tx database.SQLDatabase, bankCallback BankCallbackData, review domain.CallbackReview) error {
+	reviewRepo := disb.callbackReviewRepository.WithTx(Tx)
+
+	parked, err := reviewRepo.GetOpen(ctx, bankCallback.TransactionId, review.Reason)
+	if err != nil {
+		log.Println(err)
+		return internal_error.ErrHandleBankCallback
+	}
+
+	if parked != nil {
+		return nil
+	}
+
+	review.Payload, err = json.Marshal(bankCallback)
+	if err != nil {
+		log.Println(err)
+		return internal_error.ErrHandleBankCallback
+	}
+
+	review.TransactionId = bankCallback.TransactionId
+	review.Status = domain.CallbackReviewStatusOpen
+
+	_, err = reviewRepo.Insert(ctx, review)
+	if err != nil {
+		log.Println(err)
+		return internal_error.ErrHandleBankCallback
+	}
+
+	return nil
+}
+
 func (disb disbursementUsecase) mapTransferStatusToDisbursementStatus(transferStatus api.TransferStatus) domain.DisbursementStatus {
+	return toDisbursementStatus(transferStatus)
+}
+
+func toDisbursementStatus(transferStatus api.TransferStatus) domain.DisbursementStatus {
 	switch transferStatus {
 	case api.TransferStatusCompleted:
 		return domain.DisbursementStatusCompleted
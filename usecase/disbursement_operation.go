@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"log"
+	"strings"
+)
+
+type disbursementOperationUsecase struct {
+	bankApi                api.Bank
+	disbursementRepository repository.Disbursement
+	auditLogRepository     repository.AuditLog
+	utilsRepository        repository.Utils
+	webhookOutbox          webhookOutbox
+	eventOutbox            eventOutbox
+}
+
+type DisbursementOperationDeps struct {
+	BankApi                api.Bank
+	DisbursementRepository repository.Disbursement
+	AuditLogRepository     repository.AuditLog
+	UtilsRepository        repository.Utils
+	// status change is sent to the webhook endpoints of the merchant
+	WebhookEndpointRepository repository.WebhookEndpoint
+	WebhookDeliveryRepository repository.WebhookDelivery
+	// domain events are recorded in the transaction of the change
+	OutboxRepository repository.Outbox
+}
+
+func NewDisbursementOperation(deps DisbursementOperationDeps) *disbursementOperationUsecase {
+	return &disbursementOperationUsecase{
+		bankApi:                deps.BankApi,
+		disbursementRepository: deps.DisbursementRepository,
+		auditLogRepository:     deps.AuditLogRepository,
+		utilsRepository:        deps.UtilsRepository,
+		webhookOutbox: webhookOutbox{
+			endpointRepository: deps.WebhookEndpointRepository,
+			deliveryRepository: deps.WebhookDeliveryRepository,
+		},
+		eventOutbox: eventOutbox{
+			outboxRepository: deps.OutboxRepository,
+		},
+	}
+}
+
+func (op disbursementOperationUsecase) ForceStatus(ctx context.Context, id string, status domain.DisbursementStatus, reason string) (domain.Disbursement, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return domain.Disbursement{}, internal_error.ErrOperationReasonRequired
+	}
+
+	if status.ToString() == domain.DisbursementStatusUnknownStr {
+		return domain.Disbursement{}, internal_error.ErrForceStatusInvalid
+	}
+
+	// only the screening hold a disbursement
+	if status == domain.DisbursementStatusOnHold {
+		return domain.Disbursement{}, internal_error.ErrForceStatusInvalid
+	}
+
+	return op.update(ctx, id, func(disbursement *domain.Disbursement) (domain.AuditLog, error) {
+		// the money was never sent, compliance resolve the screening instead
+		if disbursement.Status == domain.DisbursementStatusOnHold {
+			return domain.AuditLog{}, internal_error.ErrDisbursementOnHold
+		}
+
+		if disbursement.Status == status {
+			return domain.AuditLog{}, internal_error.ErrForceStatusInvalid
+		}
+
+		auditLog := domain.AuditLog{
+			Action: domain.AuditActionForceStatus,
+			Reason: fmt.Sprintf("%s -> %s: %s", disbursement.Status.ToString(), status.ToString(), reason),
+		}
+		disbursement.Status = status
+
+		return auditLog, nil
+	})
+}
+
+func (op disbursementOperationUsecase) AttachBankEvidence(ctx context.Context, id string, reference string) (domain.Disbursement, error) {
+	reference = strings.TrimSpace(reference)
+	if reference == "" {
+		return domain.Disbursement{}, internal_error.ErrBankEvidenceRequired
+	}
+
+	return op.update(ctx, id, func(disbursement *domain.Disbursement) (domain.AuditLog, error) {
+		auditLog := domain.AuditLog{
+			Action: domain.AuditActionAttachBankEvidence,
+			Reason: fmt.Sprintf("bank evidence reference %q replace %q", reference, disbursement.BankEvidenceReference),
+		}
+		disbursement.BankEvidenceReference = reference
+
+		return auditLog, nil
+	})
+}
+
+func (op disbursementOperationUsecase) RecheckBankStatus(ctx context.Context, id string) (BankStatusCheckResult, error) {
+	disbursement, err := op.disbursementRepository.GetById(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return BankStatusCheckResult{}, err
+	}
+
+	if disbursement == nil {
+		return BankStatusCheckResult{}, internal_error.ErrDisbursementNotFound
+	}
+
+	// the bank has no transfer to check until compliance release the disbursement
+	if disbursement.Status == domain.DisbursementStatusOnHold {
+		return BankStatusCheckResult{}, internal_error.ErrDisbursementOnHold
+	}
+
+	statusResponse, err := op.bankApi.CheckTransferStatus(ctx, api.TransferStatusRequest{
+		TransactionId: disbursement.BankTransactionId,
+	})
+	if err != nil {
+		log.Println(err)
+		return BankStatusCheckResult{}, internal_error.ErrBankStatusCheck
+	}
+
+	result := BankStatusCheckResult{
+		BankStatus: statusResponse.TransferStatus,
+	}
+
+	bankStatus := toDisbursementStatus(statusResponse.TransferStatus)
+
+	result.Disbursement, err = op.update(ctx, id, func(disbursement *domain.Disbursement) (domain.AuditLog, error) {
+		auditLog := domain.AuditLog{
+			Action: domain.AuditActionRecheckBankStatus,
+			Reason: fmt.Sprintf("bank status %s, status %s unchanged", statusResponse.TransferStatus, disbursement.Status.ToString()),
+		}
+
+		// same rule as bank callback, only pending disbursement follow the bank
+		if disbursement.Status == domain.DisbursementStatusPending && bankStatus != domain.DisbursementStatusPending && bankStatus != domain.DisbursementStatusUnknown {
+			auditLog.Reason = fmt.Sprintf("bank status %s, %s -> %s", statusResponse.TransferStatus, disbursement.Status.ToString(), bankStatus.ToString())
+			disbursement.Status = bankStatus
+			result.Updated = true
+		}
+
+		return auditLog, nil
+	})
+	if err != nil {
+		return BankStatusCheckResult{}, err
+	}
+
+	return result, nil
+}
+
+// update apply change to the disbursement and record the audit log returned by change in the same transaction
+func (op disbursementOperationUsecase) update(ctx context.Context, id string, change func(disbursement *domain.Disbursement) (domain.AuditLog, error)) (domain.Disbursement, error) {
+	var res domain.Disbursement
+
+	err := op.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+		disbursementRepo := op.disbursementRepository.WithTx(Tx)
+
+		disbursement, err := disbursementRepo.GetById(ctx, id)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		if disbursement == nil {
+			return internal_error.ErrDisbursementNotFound
+		}
+
+		before := *disbursement
+		auditLog, err := change(disbursement)
+		if err != nil {
+			return err
+		}
+
+		if *disbursement != before {
+			err = disbursementRepo.UpdateById(ctx, disbursement.Id, disbursement.Version, *disbursement)
+			if errors.Is(err, internal_error.ErrVersionConflict) {
+				return err
+			}
+			if err != nil {
+				log.Println(err)
+				return internal_error.ErrUpdateDisbursementStatus
+			}
+
+			disbursement.Version++
+
+			if disbursement.Status != before.Status {
+				err = op.webhookOutbox.enqueue(ctx, Tx, *disbursement)
+				if err != nil {
+					log.Println(err)
+					return internal_error.ErrUpdateDisbursementStatus
+				}
+
+				err = op.eventOutbox.disbursementStatusChanged(ctx, Tx, *disbursement, before.Status)
+				if err != nil {
+					log.Println(err)
+					return internal_error.ErrUpdateDisbursementStatus
+				}
+			}
+		}
+
+		auditLog.ResourceId = disbursement.Id
+		auditLog.Outcome = domain.AuditOutcomeAllowed
+
+		_, err = op.auditLogRepository.WithTx(Tx).Insert(ctx, withActor(ctx, auditLog))
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		res = *disbursement
+		return nil
+	})
+	if err != nil {
+		return domain.Disbursement{}, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/mock"
+	mock_api "github.com/nobbyphala/Brick/mock/api"
+	mock_repository "github.com/nobbyphala/Brick/mock/repository"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func Test_disbursementOperationUsecase_ForceStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+	mockDisbursementRepo := mock_repository.NewMockDisbursement(ctrl)
+	mockAuditLogRepo := mock_repository.NewMockAuditLog(ctrl)
+	mockUtilRepo := mock_repository.NewMockUtils(ctrl)
+	mockOutboxRepo := mock_repository.NewMockOutbox(ctrl)
+
+	operatorCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsOperator, ApiKeyId: "key-id-1"})
+	stuck := domain.Disbursement{
+		Id:                "disb-id-1",
+		BankTransactionId: "txn-id-1",
+		Amount:            60000,
+		Status:            domain.DisbursementStatusFailed,
+		Version:           2,
+	}
+	runTx := func() {
+		mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+		mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+			return handler(ctx, mockSQL)
+		})
+	}
+
+	tests := []struct {
+		name    string
+		status  domain.DisbursementStatus
+		reason  string
+		want    domain.Disbursement
+		wantErr error
+		mock    func()
+	}{
+		{
+			name:   "force failed disbursement to completed",
+			status: domain.DisbursementStatusCompleted,
+			reason: "bank confirmed by email",
+			want: domain.Disbursement{
+				Id:                "disb-id-1",
+				BankTransactionId: "txn-id-1",
+				Amount:            60000,
+				Status:            domain.DisbursementStatusCompleted,
+				Version:           3,
+			},
+			mock: func() {
+				runTx()
+				mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(&stuck, nil)
+				mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(2), domain.Disbursement{
+					Id:                "disb-id-1",
+					BankTransactionId: "txn-id-1",
+					Amount:            60000,
+					Status:            domain.DisbursementStatusCompleted,
+					Version:           2,
+				}).Return(nil)
+				mockOutboxRepo.EXPECT().WithTx(mockSQL).Return(mockOutboxRepo)
+				mockOutboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event domain.OutboxEvent) error {
+					var changed domain.DisbursementStatusChanged
+					assert.NoError(t, json.Unmarshal(event.Payload, &changed))
+					assert.Equal(t, domain.EventTypeDisbursementStatusChanged, event.Type)
+					assert.Equal(t, "FAILED", changed.PreviousStatus)
+					assert.Equal(t, "COMPLETED", changed.Status)
+					assert.Equal(t, int64(3), changed.Version)
+					return nil
+				})
+				mockAuditLogRepo.EXPECT().WithTx(mockSQL).Return(mockAuditLogRepo)
+				mockAuditLogRepo.EXPECT().Insert(gomock.Any(), domain.AuditLog{
+					ActorId:    "ops-1",
+					ActorRole:  domain.RoleOpsOperator,
+					ApiKeyId:   "key-id-1",
+					Action:     domain.AuditActionForceStatus,
+					ResourceId: "disb-id-1",
+					Outcome:    domain.AuditOutcomeAllowed,
+					Reason:     "FAILED -> COMPLETED: bank confirmed by email",
+				}).Return("audit-id-1", nil)
+			},
+		},
+		{
+			name:    "reason is mandatory",
+			status:  domain.DisbursementStatusCompleted,
+			reason:  "  ",
+			wantErr: internal_error.ErrOperationReasonRequired,
+			mock:    func() {},
+		},
+		{
+			name:    "unknown status",
+			status:  domain.DisbursementStatus(9),
+			reason:  "bank confirmed by email",
+			wantErr: internal_error.ErrForceStatusInvalid,
+			mock:    func() {},
+		},
+		{
+			name:    "status is already the same",
+			status:  domain.DisbursementStatusFailed,
+			reason:  "bank confirmed by email",
+			wantErr: internal_error.ErrForceStatusInvalid,
+			mock: func() {
+				runTx()
+				mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(&domain.Disbursement{Id: "disb-id-1", Status: domain.DisbursementStatusFailed}, nil)
+			},
+		},
+		{
+			name:    "disbursement cannot be put on hold",
+			status:  domain.DisbursementStatusOnHold,
+			reason:  "suspicious recipient",
+			wantErr: internal_error.ErrForceStatusInvalid,
+			mock:    func() {},
+		},
+		{
+			name:    "disbursement on hold is resolved by compliance",
+			status:  domain.DisbursementStatusRejected,
+			reason:  "recipient is sanctioned",
+			wantErr: internal_error.ErrDisbursementOnHold,
+			mock: func() {
+				runTx()
+				mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(&domain.Disbursement{Id: "disb-id-1", Status: domain.DisbursementStatusOnHold}, nil)
+			},
+		},
+		{
+			name:    "disbursement not found",
+			status:  domain.DisbursementStatusCompleted,
+			reason:  "bank confirmed by email",
+			wantErr: internal_error.ErrDisbursementNotFound,
+			mock: func() {
+				runTx()
+				mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(nil, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			op := NewDisbursementOperation(DisbursementOperationDeps{
+				DisbursementRepository: mockDisbursementRepo,
+				AuditLogRepository:     mockAuditLogRepo,
+				UtilsRepository:        mockUtilRepo,
+				OutboxRepository:       mockOutboxRepo,
+			})
+			got, err := op.ForceStatus(operatorCtx, "disb-id-1", tt.status, tt.reason)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_disbursementOperationUsecase_AttachBankEvidence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+	mockDisbursementRepo := mock_repository.NewMockDisbursement(ctrl)
+	mockAuditLogRepo := mock_repository.NewMockAuditLog(ctrl)
+	mockUtilRepo := mock_repository.NewMockUtils(ctrl)
+	mockOutboxRepo := mock_repository.NewMockOutbox(ctrl)
+
+	op := NewDisbursementOperation(DisbursementOperationDeps{
+		DisbursementRepository: mockDisbursementRepo,
+		AuditLogRepository:     mockAuditLogRepo,
+		UtilsRepository:        mockUtilRepo,
+		OutboxRepository:       mockOutboxRepo,
+	})
+
+	_, err := op.AttachBankEvidence(context.TODO(), "disb-id-1", "")
+	assert.Equal(t, internal_error.ErrBankEvidenceRequired, err)
+
+	mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+		return handler(ctx, mockSQL)
+	})
+	mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+	mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(&domain.Disbursement{Id: "disb-id-1", Status: domain.DisbursementStatusPending, Version: 1}, nil)
+	mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(1), domain.Disbursement{
+		Id:                    "disb-id-1",
+		Status:                domain.DisbursementStatusPending,
+		BankEvidenceReference: "BANK-A/2024/0001",
+		Version:               1,
+	}).Return(nil)
+	mockAuditLogRepo.EXPECT().WithTx(mockSQL).Return(mockAuditLogRepo)
+	mockAuditLogRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog domain.AuditLog) (string, error) {
+		assert.Equal(t, domain.AuditActionAttachBankEvidence, auditLog.Action)
+		assert.Equal(t, "anonymous", auditLog.ActorId)
+		return "audit-id-1", nil
+	})
+
+	got, err := op.AttachBankEvidence(context.TODO(), "disb-id-1", " BANK-A/2024/0001 ")
+	assert.NoError(t, err)
+	assert.Equal(t, "BANK-A/2024/0001", got.BankEvidenceReference)
+}
+
+func Test_disbursementOperationUsecase_RecheckBankStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+	mockBankApi := mock_api.NewMockBank(ctrl)
+	mockDisbursementRepo := mock_repository.NewMockDisbursement(ctrl)
+	mockAuditLogRepo := mock_repository.NewMockAuditLog(ctrl)
+	mockUtilRepo := mock_repository.NewMockUtils(ctrl)
+	mockOutboxRepo := mock_repository.NewMockOutbox(ctrl)
+
+	pending := domain.Disbursement{Id: "disb-id-1", BankTransactionId: "txn-id-1", Status: domain.DisbursementStatusPending, Version: 1}
+	completed := domain.Disbursement{Id: "disb-id-1", BankTransactionId: "txn-id-1", Status: domain.DisbursementStatusCompleted, Version: 2}
+
+	tests := []struct {
+		name       string
+		bankStatus api.TransferStatus
+		stored     domain.Disbursement
+		want       BankStatusCheckResult
+		wantReason string
+		wantErr    error
+		mock       func()
+	}{
+		{
+			name:       "pending disbursement follow the bank",
+			bankStatus: api.TransferStatusCompleted,
+			stored:     pending,
+			want: BankStatusCheckResult{
+				Disbursement: domain.Disbursement{Id: "disb-id-1", BankTransactionId: "txn-id-1", Status: domain.DisbursementStatusCompleted, Version: 2},
+				BankStatus:   api.TransferStatusCompleted,
+				Updated:      true,
+			},
+			wantReason: "bank status COMPLETED, PENDING -> COMPLETED",
+			mock: func() {
+				mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(1), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().WithTx(mockSQL).Return(mockOutboxRepo)
+				mockOutboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:       "final disbursement is not changed",
+			bankStatus: api.TransferStatusFailed,
+			stored:     completed,
+			want: BankStatusCheckResult{
+				Disbursement: completed,
+				BankStatus:   api.TransferStatusFailed,
+			},
+			wantReason: "bank status FAILED, status COMPLETED unchanged",
+			mock:       func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := tt.stored
+			mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(&stored, nil)
+			mockBankApi.EXPECT().CheckTransferStatus(gomock.Any(), api.TransferStatusRequest{TransactionId: "txn-id-1"}).Return(api.TransferStatusResponse{
+				TransactionId:  "txn-id-1",
+				TransferStatus: tt.bankStatus,
+			}, nil)
+			mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+				return handler(ctx, mockSQL)
+			})
+			mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+			lockedStored := tt.stored
+			mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(&lockedStored, nil)
+			tt.mock()
+			mockAuditLogRepo.EXPECT().WithTx(mockSQL).Return(mockAuditLogRepo)
+			mockAuditLogRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog domain.AuditLog) (string, error) {
+				assert.Equal(t, tt.wantReason, auditLog.Reason)
+				return "audit-id-1", nil
+			})
+
+			op := NewDisbursementOperation(DisbursementOperationDeps{
+				BankApi:                mockBankApi,
+				DisbursementRepository: mockDisbursementRepo,
+				AuditLogRepository:     mockAuditLogRepo,
+				UtilsRepository:        mockUtilRepo,
+				OutboxRepository:       mockOutboxRepo,
+			})
+			got, err := op.RecheckBankStatus(context.TODO(), "disb-id-1")
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(&pending, nil)
+	mockBankApi.EXPECT().CheckTransferStatus(gomock.Any(), gomock.Any()).Return(api.TransferStatusResponse{}, errors.New("timeout"))
+
+	op := NewDisbursementOperation(DisbursementOperationDeps{
+		BankApi:                mockBankApi,
+		DisbursementRepository: mockDisbursementRepo,
+	})
+	_, err := op.RecheckBankStatus(context.TODO(), "disb-id-1")
+	assert.Equal(t, internal_error.ErrBankStatusCheck, err)
+
+	// held disbursement was never sent to the bank
+	mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(&domain.Disbursement{Id: "disb-id-1", Status: domain.DisbursementStatusOnHold}, nil)
+
+	_, err = op.RecheckBankStatus(context.TODO(), "disb-id-1")
+	assert.Equal(t, internal_error.ErrDisbursementOnHold, err)
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	disbursementStreamBatchSize    = 100
+	defaultDisbursementStreamPoll  = 5 * time.Second
+	disbursementStreamWakeUpBuffer = 1
+)
+
+// disbursementStreamUsecase read the status events of every subscriber from the repository when the listener
+// notify a commit, the events table is the source of truth so a missed notification only delay the events until
+// the next poll
+type disbursementStreamUsecase struct {
+	disbursementRepository repository.Disbursement
+	eventRepository        repository.DisbursementStatusEvent
+	listener               repository.DisbursementStatusEventListener
+	pollInterval           time.Duration
+
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+type DisbursementStreamDeps struct {
+	DisbursementRepository            repository.Disbursement
+	DisbursementStatusEventRepository repository.DisbursementStatusEvent
+	DisbursementStatusEventListener   repository.DisbursementStatusEventListener
+	// subscribers also read new events at this interval in case a notification is missed
+	PollInterval time.Duration
+}
+
+func NewDisbursementStream(deps DisbursementStreamDeps) *disbursementStreamUsecase {
+	pollInterval := deps.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultDisbursementStreamPoll
+	}
+
+	return &disbursementStreamUsecase{
+		disbursementRepository: deps.DisbursementRepository,
+		eventRepository:        deps.DisbursementStatusEventRepository,
+		listener:               deps.DisbursementStatusEventListener,
+		pollInterval:           pollInterval,
+		subscribers:            make(map[chan struct{}]struct{}),
+		done:                   make(chan struct{}),
+	}
+}
+
+func (uc *disbursementStreamUsecase) Subscribe(ctx context.Context, filter DisbursementStreamFilter) (<-chan domain.DisbursementStatusEvent, error) {
+	if filter.DisbursementId != "" {
+		disbursement, err := uc.disbursementRepository.GetById(ctx, filter.DisbursementId)
+		if err != nil {
+			return nil, err
+		}
+
+		if disbursement == nil {
+			return nil, internal_error.ErrDisbursementNotFound
+		}
+	}
+
+	afterId := filter.AfterEventId
+	if afterId == 0 {
+		lastId, err := uc.eventRepository.LastId(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		afterId = lastId
+	}
+
+	wakeUp := uc.register()
+	events := make(chan domain.DisbursementStatusEvent)
+
+	go func() {
+		defer close(events)
+		defer uc.unregister(wakeUp)
+
+		for {
+			batch, err := uc.eventRepository.List(ctx, domain.DisbursementStatusEventFilter{
+				DisbursementId: filter.DisbursementId,
+				AfterId:        afterId,
+				Limit:          disbursementStreamBatchSize,
+			})
+			if err != nil {
+				// the client resume from the last event it received after reconnecting
+				if ctx.Err() == nil {
+					log.Println("error reading disbursement status events:", err)
+				}
+				return
+			}
+
+			for _, event := range batch {
+				select {
+				case events <- event:
+					afterId = event.Id
+				case <-ctx.Done():
+					return
+				case <-uc.done:
+					return
+				}
+			}
+
+			// continue right away while there is a backlog
+			if len(batch) == disbursementStreamBatchSize {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-uc.done:
+				return
+			case <-wakeUp:
+			case <-time.After(uc.pollInterval):
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func (uc *disbursementStreamUsecase) Listen(ctx context.Context) {
+	for ctx.Err() == nil {
+		notifications, err := uc.listener.Listen(ctx)
+		if err != nil {
+			log.Println("error listening disbursement status events:", err)
+		} else {
+			for range notifications {
+				uc.wakeUpSubscribers()
+			}
+
+			if ctx.Err() == nil {
+				log.Println("disbursement status event listener disconnected")
+			}
+		}
+
+		// subscribers keep polling until the listener reconnected
+		select {
+		case <-ctx.Done():
+		case <-time.After(uc.pollInterval):
+		}
+	}
+}
+
+func (uc *disbursementStreamUsecase) Close() {
+	uc.closeOnce.Do(func() {
+		close(uc.done)
+	})
+}
+
+func (uc *disbursementStreamUsecase) register() chan struct{} {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	wakeUp := make(chan struct{}, disbursementStreamWakeUpBuffer)
+	uc.subscribers[wakeUp] = struct{}{}
+
+	return wakeUp
+}
+
+func (uc *disbursementStreamUsecase) unregister(wakeUp chan struct{}) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	delete(uc.subscribers, wakeUp)
+}
+
+func (uc *disbursementStreamUsecase) wakeUpSubscribers() {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	for wakeUp := range uc.subscribers {
+		// the subscriber read every new event when it wake up, one pending signal is enough
+		select {
+		case wakeUp <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	mock_repository "github.com/nobbyphala/Brick/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_disbursementStreamUsecase_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDisbursementRepo := mock_repository.NewMockDisbursement(ctrl)
+	mockEventRepo := mock_repository.NewMockDisbursementStatusEvent(ctrl)
+
+	completed := domain.DisbursementStatusEvent{
+		Id:             11,
+		DisbursementId: "disb-id-1",
+		Status:         domain.DisbursementStatusCompleted,
+		PreviousStatus: domain.DisbursementStatusPending,
+		Version:        2,
+	}
+
+	tests := []struct {
+		name       string
+		filter     DisbursementStreamFilter
+		wantEvents []domain.DisbursementStatusEvent
+		wantErr    error
+		mock       func()
+	}{
+		{
+			name:   "stream events committed after subscribing",
+			filter: DisbursementStreamFilter{},
+			mock: func() {
+				mockEventRepo.EXPECT().LastId(gomock.Any()).Return(int64(10), nil)
+				mockEventRepo.EXPECT().List(gomock.Any(), domain.DisbursementStatusEventFilter{AfterId: 10, Limit: disbursementStreamBatchSize}).
+					Return([]domain.DisbursementStatusEvent{completed}, nil)
+				mockEventRepo.EXPECT().List(gomock.Any(), domain.DisbursementStatusEventFilter{AfterId: 11, Limit: disbursementStreamBatchSize}).
+					Return(nil, errors.New("connection reset"))
+			},
+			wantEvents: []domain.DisbursementStatusEvent{completed},
+		},
+		{
+			name:   "resume a disbursement after the last event id",
+			filter: DisbursementStreamFilter{DisbursementId: "disb-id-1", AfterEventId: 5},
+			mock: func() {
+				mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(&domain.Disbursement{Id: "disb-id-1"}, nil)
+				mockEventRepo.EXPECT().List(gomock.Any(), domain.DisbursementStatusEventFilter{DisbursementId: "disb-id-1", AfterId: 5, Limit: disbursementStreamBatchSize}).
+					Return([]domain.DisbursementStatusEvent{completed}, nil)
+				mockEventRepo.EXPECT().List(gomock.Any(), domain.DisbursementStatusEventFilter{DisbursementId: "disb-id-1", AfterId: 11, Limit: disbursementStreamBatchSize}).
+					Return(nil, errors.New("connection reset"))
+			},
+			wantEvents: []domain.DisbursementStatusEvent{completed},
+		},
+		{
+			name:   "disbursement not found",
+			filter: DisbursementStreamFilter{DisbursementId: "disb-id-1"},
+			mock: func() {
+				mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(nil, nil)
+			},
+			wantErr: internal_error.ErrDisbursementNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			uc := NewDisbursementStream(DisbursementStreamDeps{
+				DisbursementRepository:            mockDisbursementRepo,
+				DisbursementStatusEventRepository: mockEventRepo,
+				PollInterval:                      time.Millisecond,
+			})
+
+			events, err := uc.Subscribe(context.TODO(), tt.filter)
+			assert.Equal(t, tt.wantErr, err)
+			if err != nil {
+				return
+			}
+
+			// the channel is closed when reading the events failed
+			var got []domain.DisbursementStatusEvent
+			for event := range events {
+				got = append(got, event)
+			}
+			assert.Equal(t, tt.wantEvents, got)
+		})
+	}
+}
+
+func Test_disbursementStreamUsecase_Listen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEventRepo := mock_repository.NewMockDisbursementStatusEvent(ctrl)
+	mockListener := mock_repository.NewMockDisbursementStatusEventListener(ctrl)
+
+	notifications := make(chan struct{}, 1)
+	mockListener.EXPECT().Listen(gomock.Any()).Return(notifications, nil)
+
+	// poll interval longer than the test, the subscriber only read again when woken up by the listener
+	uc := NewDisbursementStream(DisbursementStreamDeps{
+		DisbursementStatusEventRepository: mockEventRepo,
+		DisbursementStatusEventListener:   mockListener,
+		PollInterval:                      time.Hour,
+	})
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	go uc.Listen(ctx)
+
+	completed := domain.DisbursementStatusEvent{Id: 1, DisbursementId: "disb-id-1", Status: domain.DisbursementStatusCompleted}
+
+	listed := make(chan struct{})
+	mockEventRepo.EXPECT().LastId(gomock.Any()).Return(int64(0), nil)
+	gomock.InOrder(
+		mockEventRepo.EXPECT().List(gomock.Any(), domain.DisbursementStatusEventFilter{Limit: disbursementStreamBatchSize}).
+			DoAndReturn(func(ctx context.Context, filter domain.DisbursementStatusEventFilter) ([]domain.DisbursementStatusEvent, error) {
+				close(listed)
+				return nil, nil
+			}),
+		mockEventRepo.EXPECT().List(gomock.Any(), domain.DisbursementStatusEventFilter{Limit: disbursementStreamBatchSize}).
+			Return([]domain.DisbursementStatusEvent{completed}, nil),
+	)
+
+	events, err := uc.Subscribe(ctx, DisbursementStreamFilter{})
+	assert.NoError(t, err)
+
+	<-listed
+	notifications <- struct{}{}
+	assert.Equal(t, completed, <-events)
+
+	uc.Close()
+	for range events {
+	}
+
+	cancel()
+	close(notifications)
+}
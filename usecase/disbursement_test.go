@@ -2,8 +2,10 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
 	"github.com/nobbyphala/Brick/external/database"
 	"github.com/nobbyphala/Brick/mock"
 	mock_api "github.com/nobbyphala/Brick/mock/api"
@@ -13,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"testing"
+	"time"
 )
 
 func TestNewDisbursement(t *testing.T) {
@@ -55,15 +58,80 @@ func Test_disbursementUsecase_Disburse(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockBankApi := mock_api.NewMockBank(ctrl)
+	mockNameMatcher := mock_api.NewMockNameMatcher(ctrl)
 	mockDisbursementRepo := mock_repository.NewMockDisbursement(ctrl)
+	mockMerchantRepo := mock_repository.NewMockMerchant(ctrl)
+	mockUtilRepo := mock_repository.NewMockUtils(ctrl)
+	mockOutboxRepo := mock_repository.NewMockOutbox(ctrl)
+	mockBeneficiaryRepo := mock_repository.NewMockBeneficiary(ctrl)
+	mockScreeningRepo := mock_repository.NewMockScreening(ctrl)
+	mockWebhookEndpointRepo := mock_repository.NewMockWebhookEndpoint(ctrl)
+	mockWebhookDeliveryRepo := mock_repository.NewMockWebhookDelivery(ctrl)
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+
+	runTx := func() {
+		mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+			return handler(ctx, mockSQL)
+		})
+		mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+	}
+	lockMerchant := func() {
+		mockMerchantRepo.EXPECT().WithTx(mockSQL).Return(mockMerchantRepo)
+		mockMerchantRepo.EXPECT().LockById(gomock.Any(), "merchant-1").Return(nil)
+	}
+	recordTransfer := func(reserved domain.Disbursement, transactionId string, status domain.DisbursementStatus) {
+		runTx()
+		reserved.Version = 1
+		mockDisbursementRepo.EXPECT().GetById(gomock.Any(), reserved.Id).Return(&reserved, nil)
+		transferred := reserved
+		transferred.BankTransactionId = transactionId
+		transferred.Status = status
+		mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), reserved.Id, int64(1), transferred).Return(nil)
+	}
+	recordScreening := func() {
+		mockScreeningRepo.EXPECT().WithTx(mockSQL).Return(mockScreeningRepo)
+		mockScreeningRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return("screening-1", nil)
+	}
+	recordCreated := func() {
+		mockOutboxRepo.EXPECT().WithTx(mockSQL).Return(mockOutboxRepo)
+		mockOutboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event domain.OutboxEvent) error {
+			assert.Equal(t, domain.EventTypeDisbursementCreated, event.Type)
+			return nil
+		})
+	}
+	expectStatusChanged := func(previousStatus, status string) {
+		mockWebhookEndpointRepo.EXPECT().WithTx(mockSQL).Return(mockWebhookEndpointRepo)
+		mockWebhookEndpointRepo.EXPECT().ListByMerchantId(gomock.Any(), "merchant-1").Return(nil, nil)
+		mockWebhookDeliveryRepo.EXPECT().WithTx(mockSQL).Return(mockWebhookDeliveryRepo)
+		mockOutboxRepo.EXPECT().WithTx(mockSQL).Return(mockOutboxRepo)
+		mockOutboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event domain.OutboxEvent) error {
+			var changed domain.DisbursementStatusChanged
+			assert.NoError(t, json.Unmarshal(event.Payload, &changed))
+			assert.Equal(t, previousStatus, changed.PreviousStatus)
+			assert.Equal(t, status, changed.Status)
+			return nil
+		})
+	}
+
+	merchantCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: "merchant-1"})
+
+	tokens := verificationToken{secret: []byte("verification-secret"), ttl: time.Minute}
+	verifiedToken, _ := tokens.issue("merchant-1", domain.Disbursement{
+		RecipientName:          "Nobby Phala",
+		RecipientAccountNumber: "6789567",
+		RecipientBankCode:      "Bank A",
+		Amount:                 60000,
+	}, 100, time.Now())
 
 	type fields struct {
 		bankApi                api.Bank
 		disbursementRepository repository.Disbursement
+		merchantRepository     repository.Merchant
 	}
 	type args struct {
 		ctx          context.Context
 		disbursement domain.Disbursement
+		opts         DisburseOptions
 	}
 	tests := []struct {
 		name    string
@@ -78,9 +146,10 @@ func Test_disbursementUsecase_Disburse(t *testing.T) {
 			fields: fields{
 				bankApi:                mockBankApi,
 				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
 			},
 			args: args{
-				ctx: context.TODO(),
+				ctx: merchantCtx,
 				disbursement: domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "6789567",
@@ -90,23 +159,30 @@ func Test_disbursementUsecase_Disburse(t *testing.T) {
 			},
 			want: domain.Disbursement{
 				Id:                     "disb-id-1",
+				MerchantId:             "merchant-1",
 				RecipientName:          "Nobby Phala",
 				RecipientAccountNumber: "6789567",
 				RecipientBankCode:      "Bank A",
 				BankTransactionId:      "txn-id-1",
 				Amount:                 60000,
 				Status:                 1,
+				NameMatchScore:         100,
+				NameMatchDecision:      domain.NameMatchAccepted,
+				Version:                2,
 			},
 			wantErr: nil,
 			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
 				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), api.VerifyAccountRequest{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "6789567",
+					BankCode:            "Bank A",
 				}).Return(api.VerifyAccountResponse{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "6789567",
 					AccountStatus:       "status: account verified",
 				}, nil)
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Nobby Phala").Return(100)
 				mockBankApi.EXPECT().TransferMoney(gomock.Any(), api.TransferRequest{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "6789567",
@@ -120,14 +196,297 @@ func Test_disbursementUsecase_Disburse(t *testing.T) {
 					Amount:              60000,
 					TransferStatus:      "COMPLETED",
 				}, nil)
+				runTx()
+				lockMerchant()
 				mockDisbursementRepo.EXPECT().Insert(gomock.Any(), domain.Disbursement{
+					MerchantId:             "merchant-1",
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+					Status:                 1,
+					NameMatchScore:         100,
+					NameMatchDecision:      domain.NameMatchAccepted,
+				}).Return("disb-id-1", nil)
+				recordTransfer(domain.Disbursement{
+					Id:                     "disb-id-1",
+					MerchantId:             "merchant-1",
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+					Status:                 domain.DisbursementStatusPending,
+					NameMatchScore:         100,
+					NameMatchDecision:      domain.NameMatchAccepted,
+				}, "txn-id-1", domain.DisbursementStatusPending)
+				mockScreeningRepo.EXPECT().WithTx(mockSQL).Return(mockScreeningRepo)
+				mockScreeningRepo.EXPECT().Insert(gomock.Any(), domain.Screening{
+					DisbursementId: "disb-id-1",
+					MerchantId:     "merchant-1",
+					Status:         domain.ScreeningStatusCleared,
+				}).Return("screening-1", nil)
+				mockOutboxRepo.EXPECT().WithTx(mockSQL).Return(mockOutboxRepo)
+				mockOutboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event domain.OutboxEvent) error {
+					var created domain.DisbursementCreated
+					assert.NoError(t, json.Unmarshal(event.Payload, &created))
+					assert.Equal(t, domain.EventTypeDisbursementCreated, event.Type)
+					assert.Equal(t, "disb-id-1", event.AggregateId)
+					assert.Equal(t, "merchant-1", created.MerchantId)
+					assert.Equal(t, "", created.BankTransactionId)
+					assert.Equal(t, "PENDING", created.Status)
+					return nil
+				})
+			},
+		},
+		{
+			name: "recipient on the blocklist held without transfer",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: merchantCtx,
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "1111111",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+				},
+			},
+			want: domain.Disbursement{
+				Id:                     "disb-id-1",
+				MerchantId:             "merchant-1",
+				RecipientName:          "Nobby Phala",
+				RecipientAccountNumber: "1111111",
+				RecipientBankCode:      "Bank A",
+				Amount:                 60000,
+				Status:                 domain.DisbursementStatusOnHold,
+				NameMatchScore:         100,
+				NameMatchDecision:      domain.NameMatchAccepted,
+			},
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), gomock.Any()).Return(api.VerifyAccountResponse{
+					AccountHolderName: "Nobby Phala",
+					AccountStatus:     api.AccountVerifiedStatus,
+				}, nil)
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Nobby Phala").Return(100)
+				runTx()
+				lockMerchant()
+				mockDisbursementRepo.EXPECT().Insert(gomock.Any(), domain.Disbursement{
+					MerchantId:             "merchant-1",
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "1111111",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+					Status:                 domain.DisbursementStatusOnHold,
+					NameMatchScore:         100,
+					NameMatchDecision:      domain.NameMatchAccepted,
+				}).Return("disb-id-1", nil)
+				mockScreeningRepo.EXPECT().WithTx(mockSQL).Return(mockScreeningRepo)
+				mockScreeningRepo.EXPECT().Insert(gomock.Any(), domain.Screening{
+					DisbursementId: "disb-id-1",
+					MerchantId:     "merchant-1",
+					Status:         domain.ScreeningStatusHeld,
+					Matches: []domain.ScreeningMatch{
+						{
+							EntryId:   "entry-1",
+							ListName:  domain.ScreeningListInternal,
+							Reference: "fraud report",
+							Field:     domain.ScreeningMatchFieldAccountNumber,
+							Score:     100,
+						},
+					},
+				}).Return("screening-1", nil)
+				mockOutboxRepo.EXPECT().WithTx(mockSQL).Return(mockOutboxRepo)
+				mockOutboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event domain.OutboxEvent) error {
+					var created domain.DisbursementCreated
+					assert.NoError(t, json.Unmarshal(event.Payload, &created))
+					assert.Equal(t, "", created.BankTransactionId)
+					assert.Equal(t, "ON_HOLD", created.Status)
+					return nil
+				})
+			},
+		},
+		{
+			name: "verification token skip verifying recipient again",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: merchantCtx,
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+				},
+				opts: DisburseOptions{VerificationToken: verifiedToken},
+			},
+			want: domain.Disbursement{
+				Id:                     "disb-id-1",
+				MerchantId:             "merchant-1",
+				RecipientName:          "Nobby Phala",
+				RecipientAccountNumber: "6789567",
+				RecipientBankCode:      "Bank A",
+				BankTransactionId:      "txn-id-1",
+				Amount:                 60000,
+				Status:                 1,
+				NameMatchScore:         100,
+				NameMatchDecision:      domain.NameMatchAccepted,
+				Version:                2,
+			},
+			wantErr: nil,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockBankApi.EXPECT().TransferMoney(gomock.Any(), gomock.Any()).Return(api.TransferResponse{TransactionId: "txn-id-1"}, nil)
+				runTx()
+				lockMerchant()
+				mockDisbursementRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return("disb-id-1", nil)
+				recordTransfer(domain.Disbursement{
+					Id:                     "disb-id-1",
+					MerchantId:             "merchant-1",
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+					Status:                 domain.DisbursementStatusPending,
+					NameMatchScore:         100,
+					NameMatchDecision:      domain.NameMatchAccepted,
+				}, "txn-id-1", domain.DisbursementStatusPending)
+				recordScreening()
+				mockOutboxRepo.EXPECT().WithTx(mockSQL).Return(mockOutboxRepo)
+				mockOutboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "verification token of another amount verify recipient again",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: merchantCtx,
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 90000,
+				},
+				opts: DisburseOptions{VerificationToken: verifiedToken},
+			},
+			want:    domain.Disbursement{},
+			wantErr: internal_error.ErrVerifyAccountBlocked,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), api.VerifyAccountRequest{
+					AccountHolderName:   "Nobby Phala",
+					AccountHolderNumber: "6789567",
+					BankCode:            "Bank A",
+				}).Return(api.VerifyAccountResponse{AccountStatus: api.AccountBlockedStatus}, nil)
+			},
+		},
+		{
+			name: "recipient name flagged for review is disbursed",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: merchantCtx,
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+				},
+			},
+			want: domain.Disbursement{
+				Id:                     "disb-id-1",
+				MerchantId:             "merchant-1",
+				RecipientName:          "Nobby Phala",
+				RecipientAccountNumber: "6789567",
+				RecipientBankCode:      "Bank A",
+				BankTransactionId:      "txn-id-1",
+				Amount:                 60000,
+				Status:                 1,
+				NameMatchScore:         67,
+				NameMatchDecision:      domain.NameMatchReview,
+				Version:                2,
+			},
+			wantErr: nil,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{
+					Id:       "merchant-1",
+					Settings: domain.MerchantSettings{NameMatchRejectBelow: 50, NameMatchReviewBelow: 80},
+				}, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), gomock.Any()).Return(api.VerifyAccountResponse{
+					AccountHolderName: "Nobby Putra",
+					AccountStatus:     api.AccountVerifiedStatus,
+				}, nil)
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Nobby Putra").Return(67)
+				mockBankApi.EXPECT().TransferMoney(gomock.Any(), gomock.Any()).Return(api.TransferResponse{TransactionId: "txn-id-1"}, nil)
+				runTx()
+				lockMerchant()
+				mockDisbursementRepo.EXPECT().Insert(gomock.Any(), domain.Disbursement{
+					MerchantId:             "merchant-1",
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "6789567",
 					RecipientBankCode:      "Bank A",
-					BankTransactionId:      "txn-id-1",
 					Amount:                 60000,
 					Status:                 1,
+					NameMatchScore:         67,
+					NameMatchDecision:      domain.NameMatchReview,
 				}).Return("disb-id-1", nil)
+				recordTransfer(domain.Disbursement{
+					Id:                     "disb-id-1",
+					MerchantId:             "merchant-1",
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+					Status:                 domain.DisbursementStatusPending,
+					NameMatchScore:         67,
+					NameMatchDecision:      domain.NameMatchReview,
+				}, "txn-id-1", domain.DisbursementStatusPending)
+				recordScreening()
+				mockOutboxRepo.EXPECT().WithTx(mockSQL).Return(mockOutboxRepo)
+				mockOutboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "recipient name not matching the account holder",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: merchantCtx,
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+				},
+			},
+			want:    domain.Disbursement{},
+			wantErr: internal_error.ErrRecipientNameMismatch,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{
+					Id:       "merchant-1",
+					Settings: domain.MerchantSettings{NameMatchRejectBelow: 50, NameMatchReviewBelow: 80},
+				}, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), gomock.Any()).Return(api.VerifyAccountResponse{
+					AccountHolderName: "Budi Santoso",
+					AccountStatus:     api.AccountVerifiedStatus,
+				}, nil)
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Budi Santoso").Return(0)
 			},
 		},
 		{
@@ -135,9 +494,10 @@ func Test_disbursementUsecase_Disburse(t *testing.T) {
 			fields: fields{
 				bankApi:                mockBankApi,
 				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
 			},
 			args: args{
-				ctx: context.TODO(),
+				ctx: merchantCtx,
 				disbursement: domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "6789567",
@@ -148,14 +508,61 @@ func Test_disbursementUsecase_Disburse(t *testing.T) {
 			want:    domain.Disbursement{},
 			wantErr: errors.New("error when try to disburse"),
 			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), api.VerifyAccountRequest{
+					AccountHolderName:   "Nobby Phala",
+					AccountHolderNumber: "6789567",
+					BankCode:            "Bank A",
+				}).Return(api.VerifyAccountResponse{
+					AccountHolderName:   "Nobby Phala",
+					AccountHolderNumber: "6789567",
+					AccountStatus:       "status: account verified",
+				}, nil)
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Nobby Phala").Return(100)
+				runTx()
+				lockMerchant()
+				mockDisbursementRepo.EXPECT().Insert(gomock.Any(), domain.Disbursement{
+					MerchantId:             "merchant-1",
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+					Status:                 1,
+					NameMatchScore:         100,
+					NameMatchDecision:      domain.NameMatchAccepted,
+				}).Return("", errors.New("error insert"))
+			},
+		},
+		{
+			name: "unknown outcome of transfer leave the disbursement pending",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: merchantCtx,
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+				},
+			},
+			want:    domain.Disbursement{},
+			wantErr: errors.New("temporary bank network error"),
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
 				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), api.VerifyAccountRequest{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "6789567",
+					BankCode:            "Bank A",
 				}).Return(api.VerifyAccountResponse{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "6789567",
 					AccountStatus:       "status: account verified",
 				}, nil)
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Nobby Phala").Return(100)
 				mockBankApi.EXPECT().TransferMoney(gomock.Any(), api.TransferRequest{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "6789567",
@@ -168,84 +575,479 @@ func Test_disbursementUsecase_Disburse(t *testing.T) {
 					DestinationBankCode: "BANK A",
 					Amount:              60000,
 					TransferStatus:      "COMPLETED",
+				}, errors.New("error api transfer money"))
+				runTx()
+				lockMerchant()
+				mockDisbursementRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return("disb-id-1", nil)
+				recordScreening()
+				recordCreated()
+			},
+		},
+		{
+			name: "transfer refused by the bank fail the disbursement",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: merchantCtx,
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+				},
+			},
+			want: domain.Disbursement{
+				Id:                     "disb-id-1",
+				MerchantId:             "merchant-1",
+				RecipientName:          "Nobby Phala",
+				RecipientAccountNumber: "6789567",
+				RecipientBankCode:      "Bank A",
+				BankTransactionId:      "txn-id-1",
+				Amount:                 60000,
+				Status:                 domain.DisbursementStatusFailed,
+				NameMatchScore:         100,
+				NameMatchDecision:      domain.NameMatchAccepted,
+				Version:                2,
+			},
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), api.VerifyAccountRequest{
+					AccountHolderName:   "Nobby Phala",
+					AccountHolderNumber: "6789567",
+					BankCode:            "Bank A",
+				}).Return(api.VerifyAccountResponse{
+					AccountHolderName:   "Nobby Phala",
+					AccountHolderNumber: "6789567",
+					AccountStatus:       "status: account verified",
 				}, nil)
-				mockDisbursementRepo.EXPECT().Insert(gomock.Any(), domain.Disbursement{
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Nobby Phala").Return(100)
+				mockBankApi.EXPECT().TransferMoney(gomock.Any(), api.TransferRequest{
+					AccountHolderName:   "Nobby Phala",
+					AccountHolderNumber: "6789567",
+					DestinationBankCode: "Bank A",
+					Amount:              60000,
+				}).Return(api.TransferResponse{
+					TransactionId:       "txn-id-1",
+					AccountHolderName:   "Nobby Phala",
+					AccountHolderNumber: "6789567",
+					DestinationBankCode: "BANK A",
+					Amount:              60000,
+					TransferStatus:      "FAILED",
+				}, nil)
+				runTx()
+				lockMerchant()
+				mockDisbursementRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return("disb-id-1", nil)
+				recordScreening()
+				recordTransfer(domain.Disbursement{
+					Id:                     "disb-id-1",
+					MerchantId:             "merchant-1",
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "6789567",
 					RecipientBankCode:      "Bank A",
-					BankTransactionId:      "txn-id-1",
 					Amount:                 60000,
-					Status:                 1,
-				}).Return("", errors.New("error insert"))
+					Status:                 domain.DisbursementStatusPending,
+					NameMatchScore:         100,
+					NameMatchDecision:      domain.NameMatchAccepted,
+				}, "txn-id-1", domain.DisbursementStatusFailed)
+				recordCreated()
+				expectStatusChanged("PENDING", "FAILED")
 			},
 		},
 		{
-			name: "error when transfer money to bank partner",
+			name: "error when recording transfer to database",
 			fields: fields{
 				bankApi:                mockBankApi,
 				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
 			},
 			args: args{
-				ctx: context.TODO(),
+				ctx: merchantCtx,
 				disbursement: domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "6789567",
 					RecipientBankCode:      "Bank A",
 					Amount:                 60000,
 				},
+				opts: DisburseOptions{VerificationToken: verifiedToken},
 			},
 			want:    domain.Disbursement{},
-			wantErr: errors.New("temporary bank network error"),
+			wantErr: internal_error.ErrDisburseDisbursement,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				runTx()
+				lockMerchant()
+				mockDisbursementRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return("disb-id-1", nil)
+				recordScreening()
+				recordCreated()
+				mockBankApi.EXPECT().TransferMoney(gomock.Any(), gomock.Any()).Return(api.TransferResponse{TransactionId: "txn-id-1"}, nil)
+				runTx()
+				mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(nil, errors.New("sql error"))
+			},
+		},
+		{
+			name: "destination account not verified",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: merchantCtx,
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+				},
+			},
+			want:    domain.Disbursement{},
+			wantErr: errors.New("error bank account not found"),
 			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
 				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), api.VerifyAccountRequest{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "6789567",
+					BankCode:            "Bank A",
 				}).Return(api.VerifyAccountResponse{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "6789567",
-					AccountStatus:       "status: account verified",
+					AccountStatus:       "status: account not found",
+				}, nil)
+			},
+		},
+		{
+			name: "caller without merchant",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: context.TODO(),
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+				},
+			},
+			want:    domain.Disbursement{},
+			wantErr: internal_error.ErrMerchantRequired,
+			mock:    func() {},
+		},
+		{
+			name: "bank code not allowed for merchant",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: merchantCtx,
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+				},
+			},
+			want:    domain.Disbursement{},
+			wantErr: internal_error.ErrBankCodeNotAllowed,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{
+					Id:       "merchant-1",
+					Settings: domain.MerchantSettings{AllowedBankCodes: []string{"Bank B"}},
+				}, nil)
+			},
+		},
+		{
+			name: "amount exceed merchant limit",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: merchantCtx,
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+				},
+			},
+			want:    domain.Disbursement{},
+			wantErr: internal_error.ErrAmountExceedLimit,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{
+					Id:       "merchant-1",
+					Settings: domain.MerchantSettings{MaxAmount: 50000},
+				}, nil)
+			},
+		},
+		{
+			name: "amount not positive",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: merchantCtx,
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 0,
+				},
+			},
+			want:    domain.Disbursement{},
+			wantErr: internal_error.ErrAmountInvalid,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{
+					Id:       "merchant-1",
+					Settings: domain.MerchantSettings{DailyLimit: 100000},
+				}, nil)
+			},
+		},
+		{
+			name: "merchant daily limit exceeded",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: merchantCtx,
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+				},
+			},
+			want:    domain.Disbursement{},
+			wantErr: internal_error.ErrMerchantDailyLimitExceeded,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{
+					Id:       "merchant-1",
+					Settings: domain.MerchantSettings{DailyLimit: 100000},
+				}, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), gomock.Any()).Return(api.VerifyAccountResponse{
+					AccountHolderName: "Nobby Phala",
+					AccountStatus:     api.AccountVerifiedStatus,
+				}, nil)
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Nobby Phala").Return(100)
+				runTx()
+				lockMerchant()
+				mockDisbursementRepo.EXPECT().SumAmountSince(gomock.Any(), gomock.Any()).Return(int64(50000), nil)
+			},
+		},
+		{
+			name: "beneficiary verified recently not verified again",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx:          merchantCtx,
+				disbursement: domain.Disbursement{Amount: 60000},
+				opts:         DisburseOptions{BeneficiaryId: "beneficiary-1"},
+			},
+			want: domain.Disbursement{
+				Id:                     "disb-id-1",
+				MerchantId:             "merchant-1",
+				RecipientName:          "Nobby Phala",
+				RecipientAccountNumber: "6789567",
+				RecipientBankCode:      "Bank A",
+				BankTransactionId:      "txn-id-1",
+				Amount:                 60000,
+				Status:                 1,
+				NameMatchScore:         90,
+				NameMatchDecision:      domain.NameMatchAccepted,
+				Version:                2,
+			},
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockBeneficiaryRepo.EXPECT().GetById(gomock.Any(), "beneficiary-1").Return(&domain.Beneficiary{
+					Id:            "beneficiary-1",
+					MerchantId:    "merchant-1",
+					Name:          "Nobby Phala",
+					AccountNumber: "6789567",
+					BankCode:      "Bank A",
+					Verification: domain.BeneficiaryVerification{
+						Status:            domain.BeneficiaryVerified,
+						AccountHolderName: "Nobby Phala Putra",
+						NameMatchScore:    90,
+						VerifiedAt:        time.Now().Add(-time.Hour),
+					},
 				}, nil)
 				mockBankApi.EXPECT().TransferMoney(gomock.Any(), api.TransferRequest{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "6789567",
 					DestinationBankCode: "Bank A",
 					Amount:              60000,
-				}).Return(api.TransferResponse{
-					TransactionId:       "txn-id-1",
+				}).Return(api.TransferResponse{TransactionId: "txn-id-1"}, nil)
+				runTx()
+				lockMerchant()
+				mockDisbursementRepo.EXPECT().Insert(gomock.Any(), domain.Disbursement{
+					MerchantId:             "merchant-1",
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+					Status:                 1,
+					NameMatchScore:         90,
+					NameMatchDecision:      domain.NameMatchAccepted,
+				}).Return("disb-id-1", nil)
+				recordTransfer(domain.Disbursement{
+					Id:                     "disb-id-1",
+					MerchantId:             "merchant-1",
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+					Status:                 domain.DisbursementStatusPending,
+					NameMatchScore:         90,
+					NameMatchDecision:      domain.NameMatchAccepted,
+				}, "txn-id-1", domain.DisbursementStatusPending)
+				recordScreening()
+				mockOutboxRepo.EXPECT().WithTx(mockSQL).Return(mockOutboxRepo)
+				mockOutboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "beneficiary verified long ago verified again",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx:          merchantCtx,
+				disbursement: domain.Disbursement{Amount: 60000},
+				opts:         DisburseOptions{BeneficiaryId: "beneficiary-1"},
+			},
+			want: domain.Disbursement{
+				Id:                     "disb-id-1",
+				MerchantId:             "merchant-1",
+				RecipientName:          "Nobby Phala",
+				RecipientAccountNumber: "6789567",
+				RecipientBankCode:      "Bank A",
+				BankTransactionId:      "txn-id-1",
+				Amount:                 60000,
+				Status:                 1,
+				NameMatchScore:         100,
+				NameMatchDecision:      domain.NameMatchAccepted,
+				Version:                2,
+			},
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockBeneficiaryRepo.EXPECT().GetById(gomock.Any(), "beneficiary-1").Return(&domain.Beneficiary{
+					Id:            "beneficiary-1",
+					MerchantId:    "merchant-1",
+					Name:          "Nobby Phala",
+					AccountNumber: "6789567",
+					BankCode:      "Bank A",
+					Verification: domain.BeneficiaryVerification{
+						Status:            domain.BeneficiaryVerified,
+						AccountHolderName: "Nobby Phala Putra",
+						NameMatchScore:    90,
+						VerifiedAt:        time.Now().Add(-48 * time.Hour),
+					},
+				}, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), api.VerifyAccountRequest{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "6789567",
-					DestinationBankCode: "BANK A",
-					Amount:              60000,
-					TransferStatus:      "COMPLETED",
-				}, errors.New("error api transfer money"))
+					BankCode:            "Bank A",
+				}).Return(api.VerifyAccountResponse{
+					AccountHolderName: "Nobby Phala",
+					AccountStatus:     api.AccountVerifiedStatus,
+				}, nil)
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Nobby Phala").Return(100)
+				mockBeneficiaryRepo.EXPECT().UpdateById(gomock.Any(), "beneficiary-1", gomock.Any()).DoAndReturn(func(ctx context.Context, id string, beneficiary domain.Beneficiary) error {
+					assert.Equal(t, domain.BeneficiaryVerified, beneficiary.Verification.Status)
+					assert.Equal(t, "Nobby Phala", beneficiary.Verification.AccountHolderName)
+					assert.Equal(t, 100, beneficiary.Verification.NameMatchScore)
+					assert.WithinDuration(t, time.Now(), beneficiary.Verification.VerifiedAt, time.Minute)
+					return nil
+				})
+				mockBankApi.EXPECT().TransferMoney(gomock.Any(), gomock.Any()).Return(api.TransferResponse{TransactionId: "txn-id-1"}, nil)
+				runTx()
+				lockMerchant()
+				mockDisbursementRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return("disb-id-1", nil)
+				recordTransfer(domain.Disbursement{
+					Id:                     "disb-id-1",
+					MerchantId:             "merchant-1",
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					Amount:                 60000,
+					Status:                 domain.DisbursementStatusPending,
+					NameMatchScore:         100,
+					NameMatchDecision:      domain.NameMatchAccepted,
+				}, "txn-id-1", domain.DisbursementStatusPending)
+				recordScreening()
+				mockOutboxRepo.EXPECT().WithTx(mockSQL).Return(mockOutboxRepo)
+				mockOutboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil)
 			},
 		},
 		{
-			name: "destination account not verified",
+			name: "beneficiary account blocked since last verification",
 			fields: fields{
 				bankApi:                mockBankApi,
 				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
 			},
 			args: args{
-				ctx: context.TODO(),
-				disbursement: domain.Disbursement{
-					RecipientName:          "Nobby Phala",
-					RecipientAccountNumber: "6789567",
-					RecipientBankCode:      "Bank A",
-					Amount:                 60000,
-				},
+				ctx:          merchantCtx,
+				disbursement: domain.Disbursement{Amount: 60000},
+				opts:         DisburseOptions{BeneficiaryId: "beneficiary-1"},
 			},
 			want:    domain.Disbursement{},
-			wantErr: errors.New("error bank account not found"),
+			wantErr: internal_error.ErrVerifyAccountBlocked,
 			mock: func() {
-				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), api.VerifyAccountRequest{
-					AccountHolderName:   "Nobby Phala",
-					AccountHolderNumber: "6789567",
-				}).Return(api.VerifyAccountResponse{
-					AccountHolderName:   "Nobby Phala",
-					AccountHolderNumber: "6789567",
-					AccountStatus:       "status: account not found",
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockBeneficiaryRepo.EXPECT().GetById(gomock.Any(), "beneficiary-1").Return(&domain.Beneficiary{
+					Id:            "beneficiary-1",
+					MerchantId:    "merchant-1",
+					Name:          "Nobby Phala",
+					AccountNumber: "6789567",
+					BankCode:      "Bank A",
+					Verification: domain.BeneficiaryVerification{
+						Status:         domain.BeneficiaryVerified,
+						NameMatchScore: 90,
+						VerifiedAt:     time.Now().Add(-48 * time.Hour),
+					},
 				}, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), gomock.Any()).Return(api.VerifyAccountResponse{AccountStatus: api.AccountBlockedStatus}, nil)
+				mockBeneficiaryRepo.EXPECT().UpdateById(gomock.Any(), "beneficiary-1", gomock.Any()).DoAndReturn(func(ctx context.Context, id string, beneficiary domain.Beneficiary) error {
+					assert.Equal(t, domain.BeneficiaryAccountBlocked, beneficiary.Verification.Status)
+					return nil
+				})
+			},
+		},
+		{
+			name: "beneficiary of another merchant",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx:          merchantCtx,
+				disbursement: domain.Disbursement{Amount: 60000},
+				opts:         DisburseOptions{BeneficiaryId: "beneficiary-2"},
+			},
+			want:    domain.Disbursement{},
+			wantErr: internal_error.ErrBeneficiaryNotFound,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockBeneficiaryRepo.EXPECT().GetById(gomock.Any(), "beneficiary-2").Return(nil, nil)
 			},
 		},
 	}
@@ -255,8 +1057,27 @@ func Test_disbursementUsecase_Disburse(t *testing.T) {
 			disb := disbursementUsecase{
 				bankApi:                tt.fields.bankApi,
 				disbursementRepository: tt.fields.disbursementRepository,
+				merchantRepository:     tt.fields.merchantRepository,
+				utilsRepository:        mockUtilRepo,
+				webhookOutbox: webhookOutbox{
+					endpointRepository: mockWebhookEndpointRepo,
+					deliveryRepository: mockWebhookDeliveryRepo,
+				},
+				eventOutbox:           eventOutbox{outboxRepository: mockOutboxRepo},
+				verificationToken:     tokens,
+				nameMatcher:           mockNameMatcher,
+				beneficiaryRepository: mockBeneficiaryRepo,
+				beneficiaryMaxAge:     24 * time.Hour,
+				screener: &screener{
+					nameMatcher:        mockNameMatcher,
+					nameMatchThreshold: 80,
+					entries: []domain.ScreeningEntry{
+						{Id: "entry-1", ListName: domain.ScreeningListInternal, AccountNumber: "1111111", Reference: "fraud report"},
+					},
+				},
+				screeningRepository: mockScreeningRepo,
 			}
-			got, err := disb.Disburse(tt.args.ctx, tt.args.disbursement)
+			got, err := disb.Disburse(tt.args.ctx, tt.args.disbursement, tt.args.opts)
 			assert.Equal(t, tt.wantErr, err)
 			assert.Equal(t, tt.want, got)
 		})
@@ -270,12 +1091,29 @@ func Test_disbursementUsecase_ProcessBankCallback(t *testing.T) {
 	mockSQL := mock.NewMockSQLDatabase(ctrl)
 	mockBankApi := mock_api.NewMockBank(ctrl)
 	mockDisbursementRepo := mock_repository.NewMockDisbursement(ctrl)
+	mockCallbackReviewRepo := mock_repository.NewMockCallbackReview(ctrl)
 	mockUtilRepo := mock_repository.NewMockUtils(ctrl)
+	mockWebhookEndpointRepo := mock_repository.NewMockWebhookEndpoint(ctrl)
+	mockWebhookDeliveryRepo := mock_repository.NewMockWebhookDelivery(ctrl)
+	mockOutboxRepo := mock_repository.NewMockOutbox(ctrl)
+	expectStatusChanged := func(previousStatus, status string) {
+		mockOutboxRepo.EXPECT().WithTx(mockSQL).Return(mockOutboxRepo)
+		mockOutboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event domain.OutboxEvent) error {
+			var changed domain.DisbursementStatusChanged
+			assert.NoError(t, json.Unmarshal(event.Payload, &changed))
+			assert.Equal(t, domain.EventTypeDisbursementStatusChanged, event.Type)
+			assert.Equal(t, "disb-id-1", event.AggregateId)
+			assert.Equal(t, previousStatus, changed.PreviousStatus)
+			assert.Equal(t, status, changed.Status)
+			return nil
+		})
+	}
 
 	type fields struct {
-		bankApi                api.Bank
-		disbursementRepository repository.Disbursement
-		utilsRepository        repository.Utils
+		bankApi                  api.Bank
+		disbursementRepository   repository.Disbursement
+		callbackReviewRepository repository.CallbackReview
+		utilsRepository          repository.Utils
 	}
 	type args struct {
 		ctx          context.Context
@@ -313,8 +1151,9 @@ func Test_disbursementUsecase_ProcessBankCallback(t *testing.T) {
 					BankTransactionId:      "txn-id-1",
 					Amount:                 60000,
 					Status:                 1,
+					Version:                1,
 				}, nil)
-				mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", domain.Disbursement{
+				mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(1), domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "6789567",
 					RecipientBankCode:      "Bank A",
@@ -322,8 +1161,50 @@ func Test_disbursementUsecase_ProcessBankCallback(t *testing.T) {
 					Amount:                 60000,
 					Status:                 2,
 				}).Return(nil)
-				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(Tx database.SQLDatabase) error) error {
-					return handler(mockSQL)
+				expectStatusChanged("PENDING", "COMPLETED")
+				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+					return handler(ctx, mockSQL)
+				})
+			},
+		},
+		{
+			name: "status change sent to merchant webhook",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				utilsRepository:        mockUtilRepo,
+			},
+			args: args{
+				ctx: context.TODO(),
+				bankCallback: BankCallbackData{
+					TransactionId: "txn-id-1",
+					Status:        "FAILED",
+				},
+			},
+			wantErr: nil,
+			mock: func() {
+				mockDisbursementRepo.EXPECT().WithTx(gomock.Any()).Return(mockDisbursementRepo).Times(2)
+				mockDisbursementRepo.EXPECT().GetByTransactionId(gomock.Any(), "txn-id-1").Return(&domain.Disbursement{
+					Id:                "disb-id-1",
+					MerchantId:        "merchant-1",
+					RecipientBankCode: "Bank A",
+					BankTransactionId: "txn-id-1",
+					Amount:            60000,
+					Status:            domain.DisbursementStatusPending,
+					Version:           1,
+				}, nil)
+				mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(1), gomock.Any()).Return(nil)
+				expectStatusChanged("PENDING", "FAILED")
+				mockWebhookEndpointRepo.EXPECT().WithTx(mockSQL).Return(mockWebhookEndpointRepo)
+				mockWebhookEndpointRepo.EXPECT().ListByMerchantId(gomock.Any(), "merchant-1").Return([]domain.WebhookEndpoint{{Id: "endpoint-1"}}, nil)
+				mockWebhookDeliveryRepo.EXPECT().WithTx(mockSQL).Return(mockWebhookDeliveryRepo)
+				mockWebhookDeliveryRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, delivery domain.WebhookDelivery) (string, error) {
+					assert.Equal(t, "disb-id-1:v2", delivery.EventId)
+					assert.Equal(t, domain.WebhookEventDisbursementFailed, delivery.EventType)
+					return "delivery-1", nil
+				})
+				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+					return handler(ctx, mockSQL)
 				})
 			},
 		},
@@ -352,8 +1233,9 @@ func Test_disbursementUsecase_ProcessBankCallback(t *testing.T) {
 					BankTransactionId:      "txn-id-1",
 					Amount:                 60000,
 					Status:                 1,
+					Version:                1,
 				}, nil)
-				mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", domain.Disbursement{
+				mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(1), domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "6789567",
 					RecipientBankCode:      "Bank A",
@@ -361,13 +1243,87 @@ func Test_disbursementUsecase_ProcessBankCallback(t *testing.T) {
 					Amount:                 60000,
 					Status:                 2,
 				}).Return(errors.New("error update database"))
-				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(Tx database.SQLDatabase) error) error {
-					return handler(mockSQL)
+				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+					return handler(ctx, mockSQL)
 				})
 			},
 		},
 		{
-			name: "error disbursement status not PENDING",
+			name: "retry when disbursement modified concurrently",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				utilsRepository:        mockUtilRepo,
+			},
+			args: args{
+				ctx: context.TODO(),
+				bankCallback: BankCallbackData{
+					TransactionId: "txn-id-1",
+					Status:        "COMPLETED",
+				},
+			},
+			wantErr: nil,
+			mock: func() {
+				mockDisbursementRepo.EXPECT().WithTx(gomock.Any()).Return(mockDisbursementRepo).Times(4)
+				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+					return handler(ctx, mockSQL)
+				}).Times(2)
+				gomock.InOrder(
+					mockDisbursementRepo.EXPECT().GetByTransactionId(gomock.Any(), "txn-id-1").Return(&domain.Disbursement{
+						Id:                "disb-id-1",
+						BankTransactionId: "txn-id-1",
+						Amount:            60000,
+						Status:            1,
+						Version:           1,
+					}, nil),
+					mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(1), gomock.Any()).Return(internal_error.ErrVersionConflict),
+					mockDisbursementRepo.EXPECT().GetByTransactionId(gomock.Any(), "txn-id-1").Return(&domain.Disbursement{
+						Id:                "disb-id-1",
+						BankTransactionId: "txn-id-1",
+						Amount:            60000,
+						Status:            1,
+						Version:           2,
+					}, nil),
+					mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(2), domain.Disbursement{
+						BankTransactionId: "txn-id-1",
+						Amount:            60000,
+						Status:            2,
+					}).Return(nil),
+				)
+				expectStatusChanged("PENDING", "COMPLETED")
+			},
+		},
+		{
+			name: "give up when disbursement keep modified concurrently",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				utilsRepository:        mockUtilRepo,
+			},
+			args: args{
+				ctx: context.TODO(),
+				bankCallback: BankCallbackData{
+					TransactionId: "txn-id-1",
+					Status:        "COMPLETED",
+				},
+			},
+			wantErr: errors.New("error when updated disbursement status"),
+			mock: func() {
+				mockDisbursementRepo.EXPECT().WithTx(gomock.Any()).Return(mockDisbursementRepo).Times(8)
+				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+					return handler(ctx, mockSQL)
+				}).Times(4)
+				mockDisbursementRepo.EXPECT().GetByTransactionId(gomock.Any(), "txn-id-1").Return(&domain.Disbursement{
+					Id:                "disb-id-1",
+					BankTransactionId: "txn-id-1",
+					Status:            1,
+					Version:           1,
+				}, nil).Times(4)
+				mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(1), gomock.Any()).Return(internal_error.ErrVersionConflict).Times(4)
+			},
+		},
+		{
+			name: "duplicate callback of completed disbursement ignored",
 			fields: fields{
 				bankApi:                mockBankApi,
 				disbursementRepository: mockDisbursementRepo,
@@ -380,11 +1336,11 @@ func Test_disbursementUsecase_ProcessBankCallback(t *testing.T) {
 					Status:        "COMPLETED",
 				},
 			},
-			wantErr: errors.New("error invalid disbursement status"),
+			wantErr: nil,
 			mock: func() {
 				mockDisbursementRepo.EXPECT().WithTx(gomock.Any()).Return(mockDisbursementRepo).Times(1)
-				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(Tx database.SQLDatabase) error) error {
-					return handler(mockSQL)
+				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+					return handler(ctx, mockSQL)
 				})
 				mockDisbursementRepo.EXPECT().GetByTransactionId(gomock.Any(), "txn-id-1").Return(&domain.Disbursement{
 					Id:                     "disb-id-1",
@@ -398,11 +1354,84 @@ func Test_disbursementUsecase_ProcessBankCallback(t *testing.T) {
 			},
 		},
 		{
-			name: "error disbursement not found",
+			name: "callback of failed disbursement parked for review",
 			fields: fields{
-				bankApi:                mockBankApi,
-				disbursementRepository: mockDisbursementRepo,
-				utilsRepository:        mockUtilRepo,
+				bankApi:                  mockBankApi,
+				disbursementRepository:   mockDisbursementRepo,
+				callbackReviewRepository: mockCallbackReviewRepo,
+				utilsRepository:          mockUtilRepo,
+			},
+			args: args{
+				ctx: context.TODO(),
+				bankCallback: BankCallbackData{
+					TransactionId: "txn-id-1",
+					Status:        "COMPLETED",
+				},
+			},
+			wantErr: nil,
+			mock: func() {
+				mockDisbursementRepo.EXPECT().WithTx(gomock.Any()).Return(mockDisbursementRepo).Times(1)
+				mockCallbackReviewRepo.EXPECT().WithTx(mockSQL).Return(mockCallbackReviewRepo)
+				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+					return handler(ctx, mockSQL)
+				})
+				mockDisbursementRepo.EXPECT().GetByTransactionId(gomock.Any(), "txn-id-1").Return(&domain.Disbursement{
+					Id:                "disb-id-1",
+					BankTransactionId: "txn-id-1",
+					Amount:            60000,
+					Status:            3,
+				}, nil)
+				mockCallbackReviewRepo.EXPECT().GetOpen(gomock.Any(), "txn-id-1", domain.CallbackReviewReasonInvalidTransition).Return(nil, nil)
+				mockCallbackReviewRepo.EXPECT().Insert(gomock.Any(), domain.CallbackReview{
+					TransactionId:  "txn-id-1",
+					DisbursementId: "disb-id-1",
+					Reason:         domain.CallbackReviewReasonInvalidTransition,
+					Detail:         "disbursement status is FAILED, callback status is COMPLETED",
+					Payload:        []byte(`{"transaction_id":"txn-id-1","status":"COMPLETED"}`),
+					Status:         domain.CallbackReviewStatusOpen,
+				}).Return("review-1", nil)
+			},
+		},
+		{
+			name: "callback of unknown disbursement parked for review",
+			fields: fields{
+				bankApi:                  mockBankApi,
+				disbursementRepository:   mockDisbursementRepo,
+				callbackReviewRepository: mockCallbackReviewRepo,
+				utilsRepository:          mockUtilRepo,
+			},
+			args: args{
+				ctx: context.TODO(),
+				bankCallback: BankCallbackData{
+					TransactionId: "txn-id-1",
+					Status:        "COMPLETED",
+				},
+			},
+			wantErr: nil,
+			mock: func() {
+				mockDisbursementRepo.EXPECT().WithTx(gomock.Any()).Return(mockDisbursementRepo).Times(1)
+				mockCallbackReviewRepo.EXPECT().WithTx(mockSQL).Return(mockCallbackReviewRepo)
+				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+					return handler(ctx, mockSQL)
+				})
+				mockDisbursementRepo.EXPECT().GetByTransactionId(gomock.Any(), "txn-id-1").Return(nil, nil)
+				mockCallbackReviewRepo.EXPECT().GetOpen(gomock.Any(), "txn-id-1", domain.CallbackReviewReasonDisbursementNotFound).Return(nil, nil)
+				mockCallbackReviewRepo.EXPECT().Insert(gomock.Any(), domain.CallbackReview{
+					TransactionId: "txn-id-1",
+					Reason:        domain.CallbackReviewReasonDisbursementNotFound,
+					Detail:        "no disbursement has the transaction id",
+					Payload:       []byte(`{"transaction_id":"txn-id-1","status":"COMPLETED"}`),
+					Status:        domain.CallbackReviewStatusOpen,
+				}).Return("review-1", nil)
+			},
+		},
+		{
+			name: "resent callback of unknown disbursement parked once",
+			fields: fields{
+				bankApi:                  mockBankApi,
+				disbursementRepository:   mockDisbursementRepo,
+				callbackReviewRepository: mockCallbackReviewRepo,
+				utilsRepository:          mockUtilRepo,
 			},
 			args: args{
 				ctx: context.TODO(),
@@ -411,13 +1440,15 @@ func Test_disbursementUsecase_ProcessBankCallback(t *testing.T) {
 					Status:        "COMPLETED",
 				},
 			},
-			wantErr: errors.New("error disbursement not found"),
+			wantErr: nil,
 			mock: func() {
 				mockDisbursementRepo.EXPECT().WithTx(gomock.Any()).Return(mockDisbursementRepo).Times(1)
-				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(Tx database.SQLDatabase) error) error {
-					return handler(mockSQL)
+				mockCallbackReviewRepo.EXPECT().WithTx(mockSQL).Return(mockCallbackReviewRepo)
+				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+					return handler(ctx, mockSQL)
 				})
 				mockDisbursementRepo.EXPECT().GetByTransactionId(gomock.Any(), "txn-id-1").Return(nil, nil)
+				mockCallbackReviewRepo.EXPECT().GetOpen(gomock.Any(), "txn-id-1", domain.CallbackReviewReasonDisbursementNotFound).Return(&domain.CallbackReview{Id: "review-1"}, nil)
 			},
 		},
 		{
@@ -437,8 +1468,8 @@ func Test_disbursementUsecase_ProcessBankCallback(t *testing.T) {
 			wantErr: errors.New("error when processing bank callback"),
 			mock: func() {
 				mockDisbursementRepo.EXPECT().WithTx(gomock.Any()).Return(mockDisbursementRepo)
-				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(Tx database.SQLDatabase) error) error {
-					return handler(mockSQL)
+				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+					return handler(ctx, mockSQL)
 				})
 				mockDisbursementRepo.EXPECT().GetByTransactionId(gomock.Any(), "txn-id-1").Return(nil, errors.New("error get from database"))
 			},
@@ -448,9 +1479,15 @@ func Test_disbursementUsecase_ProcessBankCallback(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
 			disb := disbursementUsecase{
-				bankApi:                tt.fields.bankApi,
-				disbursementRepository: tt.fields.disbursementRepository,
-				utilsRepository:        tt.fields.utilsRepository,
+				bankApi:                  tt.fields.bankApi,
+				disbursementRepository:   tt.fields.disbursementRepository,
+				callbackReviewRepository: tt.fields.callbackReviewRepository,
+				utilsRepository:          tt.fields.utilsRepository,
+				webhookOutbox: webhookOutbox{
+					endpointRepository: mockWebhookEndpointRepo,
+					deliveryRepository: mockWebhookDeliveryRepo,
+				},
+				eventOutbox: eventOutbox{outboxRepository: mockOutboxRepo},
 			}
 			err := disb.ProcessBankCallback(tt.args.ctx, tt.args.bankCallback)
 			assert.Equal(t, tt.wantErr, err)
@@ -463,11 +1500,16 @@ func Test_disbursementUsecase_VerifyDisbursement(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockBankApi := mock_api.NewMockBank(ctrl)
+	mockNameMatcher := mock_api.NewMockNameMatcher(ctrl)
 	mockDisbursementRepo := mock_repository.NewMockDisbursement(ctrl)
+	mockMerchantRepo := mock_repository.NewMockMerchant(ctrl)
+
+	merchantCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: "merchant-1"})
 
 	type fields struct {
 		bankApi                api.Bank
 		disbursementRepository repository.Disbursement
+		merchantRepository     repository.Merchant
 	}
 	type args struct {
 		ctx          context.Context
@@ -477,6 +1519,7 @@ func Test_disbursementUsecase_VerifyDisbursement(t *testing.T) {
 		name    string
 		fields  fields
 		args    args
+		want    DisbursementVerification
 		wantErr error
 		mock    func()
 	}{
@@ -485,24 +1528,82 @@ func Test_disbursementUsecase_VerifyDisbursement(t *testing.T) {
 			fields: fields{
 				bankApi:                mockBankApi,
 				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
 			},
 			args: args{
-				ctx: context.TODO(),
+				ctx: merchantCtx,
 				disbursement: domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "98765",
+					Amount:                 60000,
 				},
 			},
+			want: DisbursementVerification{
+				AccountHolderName: "Nobby Phala Putra",
+				NameMatchScore:    80,
+				NameMatchDecision: domain.NameMatchAccepted,
+			},
 			wantErr: nil,
 			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
 				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), api.VerifyAccountRequest{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "98765",
 				}).Return(api.VerifyAccountResponse{
-					AccountHolderName:   "Nobby Phala",
+					AccountHolderName:   "Nobby Phala Putra",
 					AccountHolderNumber: "98765",
 					AccountStatus:       "status: account verified",
 				}, nil)
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Nobby Phala Putra").Return(80)
+			},
+		},
+		{
+			name: "amount not positive",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: merchantCtx,
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "98765",
+					Amount:                 -60000,
+				},
+			},
+			want:    DisbursementVerification{},
+			wantErr: internal_error.ErrAmountInvalid,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+			},
+		},
+		{
+			name: "recipient name not matching the account holder",
+			fields: fields{
+				bankApi:                mockBankApi,
+				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
+			},
+			args: args{
+				ctx: merchantCtx,
+				disbursement: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "98765",
+					Amount:                 60000,
+				},
+			},
+			wantErr: internal_error.ErrRecipientNameMismatch,
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{
+					Id:       "merchant-1",
+					Settings: domain.MerchantSettings{NameMatchRejectBelow: 50},
+				}, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), gomock.Any()).Return(api.VerifyAccountResponse{
+					AccountHolderName: "Budi Santoso",
+					AccountStatus:     api.AccountVerifiedStatus,
+				}, nil)
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Budi Santoso").Return(0)
 			},
 		},
 		{
@@ -510,16 +1611,19 @@ func Test_disbursementUsecase_VerifyDisbursement(t *testing.T) {
 			fields: fields{
 				bankApi:                mockBankApi,
 				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
 			},
 			args: args{
-				ctx: context.TODO(),
+				ctx: merchantCtx,
 				disbursement: domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "98765",
+					Amount:                 60000,
 				},
 			},
 			wantErr: errors.New("error bank account not found"),
 			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
 				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), api.VerifyAccountRequest{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "98765",
@@ -535,16 +1639,19 @@ func Test_disbursementUsecase_VerifyDisbursement(t *testing.T) {
 			fields: fields{
 				bankApi:                mockBankApi,
 				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
 			},
 			args: args{
-				ctx: context.TODO(),
+				ctx: merchantCtx,
 				disbursement: domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "98765",
+					Amount:                 60000,
 				},
 			},
 			wantErr: errors.New("error bank account is blocked"),
 			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
 				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), api.VerifyAccountRequest{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "98765",
@@ -560,16 +1667,19 @@ func Test_disbursementUsecase_VerifyDisbursement(t *testing.T) {
 			fields: fields{
 				bankApi:                mockBankApi,
 				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
 			},
 			args: args{
-				ctx: context.TODO(),
+				ctx: merchantCtx,
 				disbursement: domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "98765",
+					Amount:                 60000,
 				},
 			},
 			wantErr: errors.New("error bank account not found"),
 			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
 				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), api.VerifyAccountRequest{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "98765",
@@ -585,16 +1695,19 @@ func Test_disbursementUsecase_VerifyDisbursement(t *testing.T) {
 			fields: fields{
 				bankApi:                mockBankApi,
 				disbursementRepository: mockDisbursementRepo,
+				merchantRepository:     mockMerchantRepo,
 			},
 			args: args{
-				ctx: context.TODO(),
+				ctx: merchantCtx,
 				disbursement: domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "98765",
+					Amount:                 60000,
 				},
 			},
 			wantErr: errors.New("error when trying verify disbursement"),
 			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
 				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), api.VerifyAccountRequest{
 					AccountHolderName:   "Nobby Phala",
 					AccountHolderNumber: "98765",
@@ -608,9 +1721,12 @@ func Test_disbursementUsecase_VerifyDisbursement(t *testing.T) {
 			disb := disbursementUsecase{
 				bankApi:                tt.fields.bankApi,
 				disbursementRepository: tt.fields.disbursementRepository,
+				merchantRepository:     tt.fields.merchantRepository,
+				nameMatcher:            mockNameMatcher,
 			}
-			err := disb.VerifyDisbursement(tt.args.ctx, tt.args.disbursement)
+			got, err := disb.VerifyDisbursement(tt.args.ctx, tt.args.disbursement)
 			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
@@ -702,3 +1818,111 @@ func Test_disbursementUsecase_mapTransferStatusToDisbursementStatus(t *testing.T
 		})
 	}
 }
+
+func Test_disbursementUsecase_GetDisbursement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDisbursementRepo := mock_repository.NewMockDisbursement(ctrl)
+
+	merchantCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: "merchant-1"})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		id      string
+		want    domain.Disbursement
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "disbursement of the merchant",
+			ctx:  merchantCtx,
+			id:   "disb-id-1",
+			want: domain.Disbursement{Id: "disb-id-1", MerchantId: "merchant-1", Amount: 60000},
+			mock: func() {
+				mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(&domain.Disbursement{Id: "disb-id-1", MerchantId: "merchant-1", Amount: 60000}, nil)
+			},
+		},
+		{
+			name:    "disbursement not found or owned by other merchant",
+			ctx:     merchantCtx,
+			id:      "disb-id-2",
+			wantErr: internal_error.ErrDisbursementNotFound,
+			mock: func() {
+				mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-2").Return(nil, nil)
+			},
+		},
+		{
+			name: "operator get disbursement of any merchant",
+			ctx:  domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsViewer}),
+			id:   "disb-id-1",
+			want: domain.Disbursement{Id: "disb-id-1", MerchantId: "merchant-1", Amount: 60000},
+			mock: func() {
+				mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(&domain.Disbursement{Id: "disb-id-1", MerchantId: "merchant-1", Amount: 60000}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			disb := disbursementUsecase{
+				disbursementRepository: mockDisbursementRepo,
+			}
+			got, err := disb.GetDisbursement(tt.ctx, tt.id)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_disbursementUsecase_ListDisbursements(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDisbursementRepo := mock_repository.NewMockDisbursement(ctrl)
+
+	merchantCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: "merchant-1"})
+
+	tests := []struct {
+		name    string
+		filter  domain.DisbursementFilter
+		want    []domain.Disbursement
+		wantErr error
+		mock    func()
+	}{
+		{
+			name:   "disbursements of the merchant",
+			filter: domain.DisbursementFilter{Status: domain.DisbursementStatusPending, Limit: 10},
+			want:   []domain.Disbursement{{Id: "disb-id-1", MerchantId: "merchant-1", Status: domain.DisbursementStatusPending}},
+			mock: func() {
+				mockDisbursementRepo.EXPECT().List(merchantCtx, domain.DisbursementFilter{Status: domain.DisbursementStatusPending, Limit: 10}).Return([]domain.Disbursement{{Id: "disb-id-1", MerchantId: "merchant-1", Status: domain.DisbursementStatusPending}}, nil)
+			},
+		},
+		{
+			name:    "limit too large",
+			filter:  domain.DisbursementFilter{Limit: 1000},
+			wantErr: internal_error.ErrInvalidRequest,
+			mock:    func() {},
+		},
+		{
+			name:    "error list from database",
+			filter:  domain.DisbursementFilter{},
+			wantErr: errors.New("db error"),
+			mock: func() {
+				mockDisbursementRepo.EXPECT().List(merchantCtx, domain.DisbursementFilter{}).Return(nil, errors.New("db error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			disb := disbursementUsecase{
+				disbursementRepository: mockDisbursementRepo,
+			}
+			got, err := disb.ListDisbursements(merchantCtx, tt.filter)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"log"
+	"time"
+)
+
+// eventOutbox record the domain events in the transaction of the change, the relay publish them after commit
+type eventOutbox struct {
+	outboxRepository repository.Outbox
+}
+
+func (outbox eventOutbox) disbursementCreated(ctx context.Context, Tx database.SQLDatabase, disbursement domain.Disbursement) error {
+	now := time.Now()
+
+	return outbox.record(ctx, Tx, now, domain.DisbursementCreated{
+		DisbursementId:    disbursement.Id,
+		MerchantId:        disbursement.MerchantId,
+		Amount:            disbursement.Amount,
+		RecipientBankCode: disbursement.RecipientBankCode,
+		BankTransactionId: disbursement.BankTransactionId,
+		Status:            disbursement.Status.ToString(),
+		CreatedAt:         now.UTC(),
+	})
+}
+
+// disbursementStatusChanged disbursement.Version should be the version after the change
+func (outbox eventOutbox) disbursementStatusChanged(ctx context.Context, Tx database.SQLDatabase, disbursement domain.Disbursement, previousStatus domain.DisbursementStatus) error {
+	now := time.Now()
+
+	return outbox.record(ctx, Tx, now, domain.DisbursementStatusChanged{
+		DisbursementId: disbursement.Id,
+		MerchantId:     disbursement.MerchantId,
+		Status:         disbursement.Status.ToString(),
+		PreviousStatus: previousStatus.ToString(),
+		Version:        disbursement.Version,
+		ChangedAt:      now.UTC(),
+	})
+}
+
+func (outbox eventOutbox) record(ctx context.Context, Tx database.SQLDatabase, occurredAt time.Time, event domain.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return outbox.outboxRepository.WithTx(Tx).Insert(ctx, domain.OutboxEvent{
+		Type:        event.EventType(),
+		AggregateId: event.AggregateId(),
+		Payload:     payload,
+		OccurredAt:  occurredAt,
+	})
+}
+
+type eventRelayUsecase struct {
+	publisher        api.Publisher
+	outboxRepository repository.Outbox
+	retryBaseDelay   time.Duration
+	retryMaxDelay    time.Duration
+	lease            time.Duration
+}
+
+type EventRelayDeps struct {
+	Publisher        api.Publisher
+	OutboxRepository repository.Outbox
+	// delay before the next attempt is doubled after every failed attempt, up to RetryMaxDelay. Events are never
+	// given up, a failing event hold the later events of the same disbursement
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// how long a claimed event is hidden from other relays, should be longer than publishing a batch
+	Lease time.Duration
+}
+
+func NewEventRelay(deps EventRelayDeps) *eventRelayUsecase {
+	return &eventRelayUsecase{
+		publisher:        deps.Publisher,
+		outboxRepository: deps.OutboxRepository,
+		retryBaseDelay:   deps.RetryBaseDelay,
+		retryMaxDelay:    deps.RetryMaxDelay,
+		lease:            deps.Lease,
+	}
+}
+
+func (er eventRelayUsecase) PublishDueEvents(ctx context.Context, batchSize int) (int, error) {
+	now := time.Now()
+
+	events, err := er.outboxRepository.ClaimDue(ctx, now, now.Add(er.lease), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// a batch has at most one event per aggregate, the next event of the aggregate is claimed after it published
+	published := 0
+	for _, event := range events {
+		err := er.publisher.Publish(ctx, api.EventMessage{
+			Id:          event.Id,
+			Type:        string(event.Type),
+			AggregateId: event.AggregateId,
+			OccurredAt:  event.OccurredAt.UTC(),
+			Data:        event.Payload,
+		})
+
+		now := time.Now()
+		event.Attempts++
+
+		if err != nil {
+			event.LastError = err.Error()
+			event.NextAttemptAt = now.Add(exponentialBackoff(er.retryBaseDelay, er.retryMaxDelay, event.Attempts))
+		} else {
+			event.LastError = ""
+			event.PublishedAt = &now
+			published++
+		}
+
+		// when recording failed the lease expire and the event is published again
+		err = er.outboxRepository.UpdateById(ctx, event.Id, event)
+		if err != nil {
+			log.Println("error recording outbox event", event.Id, err)
+		}
+	}
+
+	return published, nil
+}
+
+func (er eventRelayUsecase) DeletePublishedEvents(ctx context.Context, before time.Time) (int64, error) {
+	return er.outboxRepository.DeletePublishedBefore(ctx, before)
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/mock"
+	mock_api "github.com/nobbyphala/Brick/mock/api"
+	mock_repository "github.com/nobbyphala/Brick/mock/repository"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_eventOutbox_disbursementCreated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+	mockOutboxRepo := mock_repository.NewMockOutbox(ctrl)
+
+	mockOutboxRepo.EXPECT().WithTx(mockSQL).Return(mockOutboxRepo)
+	mockOutboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event domain.OutboxEvent) error {
+		assert.Equal(t, domain.EventTypeDisbursementCreated, event.Type)
+		assert.Equal(t, "disb-id-1", event.AggregateId)
+		assert.False(t, event.OccurredAt.IsZero())
+
+		var created map[string]interface{}
+		assert.NoError(t, json.Unmarshal(event.Payload, &created))
+		assert.Equal(t, "disb-id-1", created["disbursement_id"])
+		assert.Equal(t, "merchant-1", created["merchant_id"])
+		assert.Equal(t, float64(60000), created["amount"])
+		assert.Equal(t, "Bank A", created["recipient_bank_code"])
+		assert.Equal(t, "txn-id-1", created["bank_transaction_id"])
+		assert.Equal(t, "PENDING", created["status"])
+		// recipient details are not part of the event
+		assert.NotContains(t, created, "recipient_account_number")
+		return nil
+	})
+
+	outbox := eventOutbox{outboxRepository: mockOutboxRepo}
+	err := outbox.disbursementCreated(context.TODO(), mockSQL, domain.Disbursement{
+		Id:                     "disb-id-1",
+		MerchantId:             "merchant-1",
+		RecipientName:          "Nobby Phala",
+		RecipientAccountNumber: "6789567",
+		RecipientBankCode:      "Bank A",
+		BankTransactionId:      "txn-id-1",
+		Amount:                 60000,
+		Status:                 domain.DisbursementStatusPending,
+	})
+	assert.NoError(t, err)
+}
+
+func Test_eventRelayUsecase_PublishDueEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPublisher := mock_api.NewMockPublisher(ctrl)
+	mockOutboxRepo := mock_repository.NewMockOutbox(ctrl)
+
+	occurredAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	event := domain.OutboxEvent{
+		Id:          7,
+		Type:        domain.EventTypeDisbursementStatusChanged,
+		AggregateId: "disb-id-1",
+		Payload:     []byte(`{"disbursement_id":"disb-id-1"}`),
+		OccurredAt:  occurredAt,
+		Attempts:    2,
+	}
+
+	tests := []struct {
+		name    string
+		mock    func()
+		want    int
+		wantErr error
+	}{
+		{
+			name: "published",
+			mock: func() {
+				mockOutboxRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]domain.OutboxEvent{event}, nil)
+				mockPublisher.EXPECT().Publish(gomock.Any(), api.EventMessage{
+					Id:          7,
+					Type:        "DisbursementStatusChanged",
+					AggregateId: "disb-id-1",
+					OccurredAt:  occurredAt,
+					Data:        []byte(`{"disbursement_id":"disb-id-1"}`),
+				}).Return(nil)
+				mockOutboxRepo.EXPECT().UpdateById(gomock.Any(), int64(7), gomock.Any()).DoAndReturn(func(ctx context.Context, id int64, updated domain.OutboxEvent) error {
+					assert.Equal(t, 3, updated.Attempts)
+					assert.Empty(t, updated.LastError)
+					assert.NotNil(t, updated.PublishedAt)
+					return nil
+				})
+			},
+			want: 1,
+		},
+		{
+			name: "retry with backoff on publish error",
+			mock: func() {
+				mockOutboxRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]domain.OutboxEvent{event}, nil)
+				mockPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(errors.New("broker unavailable"))
+				mockOutboxRepo.EXPECT().UpdateById(gomock.Any(), int64(7), gomock.Any()).DoAndReturn(func(ctx context.Context, id int64, updated domain.OutboxEvent) error {
+					assert.Equal(t, 3, updated.Attempts)
+					assert.Equal(t, "broker unavailable", updated.LastError)
+					assert.Nil(t, updated.PublishedAt)
+					// third failed attempt wait 4x the base delay
+					assert.WithinDuration(t, time.Now().Add(4*time.Second), updated.NextAttemptAt, time.Second)
+					return nil
+				})
+			},
+		},
+		{
+			name: "error claim",
+			mock: func() {
+				mockOutboxRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return(nil, errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			er := NewEventRelay(EventRelayDeps{
+				Publisher:        mockPublisher,
+				OutboxRepository: mockOutboxRepo,
+				RetryBaseDelay:   time.Second,
+				RetryMaxDelay:    time.Minute,
+				Lease:            time.Minute,
+			})
+			got, err := er.PublishDueEvents(context.TODO(), 10)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
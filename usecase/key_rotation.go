@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository"
+)
+
+type keyRotationUsecase struct {
+	disbursementRepository repository.Disbursement
+	utilsRepository        repository.Utils
+}
+
+type KeyRotationDeps struct {
+	DisbursementRepository repository.Disbursement
+	UtilsRepository        repository.Utils
+}
+
+func NewKeyRotation(deps KeyRotationDeps) *keyRotationUsecase {
+	return &keyRotationUsecase{
+		disbursementRepository: deps.DisbursementRepository,
+		utilsRepository:        deps.UtilsRepository,
+	}
+}
+
+func (kr keyRotationUsecase) ReEncryptDisbursements(ctx context.Context, batchSize int) (int, error) {
+	total := 0
+
+	for ctx.Err() == nil {
+		processed := 0
+
+		err := kr.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+			processed = 0
+			disbursementRepo := kr.disbursementRepository.WithTx(Tx)
+
+			disbursements, err := disbursementRepo.ListNeedReEncryption(ctx, batchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, disbursement := range disbursements {
+				// repository always encrypt with the current key when writing
+				err = disbursementRepo.UpdateById(ctx, disbursement.Id, disbursement.Version, disbursement)
+				if err != nil {
+					return err
+				}
+			}
+
+			processed = len(disbursements)
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+
+		total += processed
+		if processed < batchSize {
+			break
+		}
+	}
+
+	return total, ctx.Err()
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/mock"
+	mock_repository "github.com/nobbyphala/Brick/mock/repository"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func Test_keyRotationUsecase_ReEncryptDisbursements(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDisbursementRepo := mock_repository.NewMockDisbursement(ctrl)
+	mockUtilRepo := mock_repository.NewMockUtils(ctrl)
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+
+	runHandler := func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+		return handler(ctx, mockSQL)
+	}
+
+	tests := []struct {
+		name      string
+		batchSize int
+		want      int
+		wantErr   error
+		mock      func()
+	}{
+		{
+			name:      "re-encrypt until no more rows",
+			batchSize: 2,
+			want:      3,
+			wantErr:   nil,
+			mock: func() {
+				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runHandler).Times(2)
+				mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo).Times(2)
+				gomock.InOrder(
+					mockDisbursementRepo.EXPECT().ListNeedReEncryption(gomock.Any(), 2).Return([]domain.Disbursement{
+						{Id: "disb-id-1", Version: 1},
+						{Id: "disb-id-2", Version: 3},
+					}, nil),
+					mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(1), domain.Disbursement{Id: "disb-id-1", Version: 1}).Return(nil),
+					mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-2", int64(3), domain.Disbursement{Id: "disb-id-2", Version: 3}).Return(nil),
+					mockDisbursementRepo.EXPECT().ListNeedReEncryption(gomock.Any(), 2).Return([]domain.Disbursement{
+						{Id: "disb-id-3", Version: 1},
+					}, nil),
+					mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-3", int64(1), domain.Disbursement{Id: "disb-id-3", Version: 1}).Return(nil),
+				)
+			},
+		},
+		{
+			name:      "nothing to re-encrypt",
+			batchSize: 2,
+			want:      0,
+			wantErr:   nil,
+			mock: func() {
+				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runHandler)
+				mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+				mockDisbursementRepo.EXPECT().ListNeedReEncryption(gomock.Any(), 2).Return(nil, nil)
+			},
+		},
+		{
+			name:      "error update",
+			batchSize: 2,
+			want:      0,
+			wantErr:   errors.New("error update"),
+			mock: func() {
+				mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runHandler)
+				mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+				mockDisbursementRepo.EXPECT().ListNeedReEncryption(gomock.Any(), 2).Return([]domain.Disbursement{
+					{Id: "disb-id-1", Version: 1},
+				}, nil)
+				mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(1), gomock.Any()).Return(errors.New("error update"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			kr := NewKeyRotation(KeyRotationDeps{
+				DisbursementRepository: mockDisbursementRepo,
+				UtilsRepository:        mockUtilRepo,
+			})
+			got, err := kr.ReEncryptDisbursements(context.TODO(), tt.batchSize)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"log"
+	"strings"
+)
+
+type merchantUsecase struct {
+	merchantRepository repository.Merchant
+}
+
+type MerchantDeps struct {
+	MerchantRepository repository.Merchant
+}
+
+func NewMerchant(deps MerchantDeps) *merchantUsecase {
+	return &merchantUsecase{
+		merchantRepository: deps.MerchantRepository,
+	}
+}
+
+func (mu merchantUsecase) CreateMerchant(ctx context.Context, merchant domain.Merchant) (domain.Merchant, error) {
+	err := validateMerchant(merchant)
+	if err != nil {
+		return domain.Merchant{}, err
+	}
+
+	merchantId, err := mu.merchantRepository.Insert(ctx, merchant)
+	if err != nil {
+		log.Println(err)
+		return domain.Merchant{}, err
+	}
+
+	// creation time is set by the database
+	return mu.GetMerchant(ctx, merchantId)
+}
+
+func (mu merchantUsecase) GetMerchant(ctx context.Context, id string) (domain.Merchant, error) {
+	merchant, err := mu.merchantRepository.GetById(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return domain.Merchant{}, err
+	}
+
+	if merchant == nil {
+		return domain.Merchant{}, internal_error.ErrMerchantNotFound
+	}
+
+	return *merchant, nil
+}
+
+func (mu merchantUsecase) ListMerchants(ctx context.Context) ([]domain.Merchant, error) {
+	return mu.merchantRepository.List(ctx)
+}
+
+func (mu merchantUsecase) UpdateMerchant(ctx context.Context, merchant domain.Merchant) (domain.Merchant, error) {
+	err := validateMerchant(merchant)
+	if err != nil {
+		return domain.Merchant{}, err
+	}
+
+	existing, err := mu.GetMerchant(ctx, merchant.Id)
+	if err != nil {
+		return domain.Merchant{}, err
+	}
+
+	err = mu.merchantRepository.UpdateById(ctx, merchant.Id, merchant)
+	if err != nil {
+		log.Println(err)
+		return domain.Merchant{}, err
+	}
+
+	merchant.CreatedAt = existing.CreatedAt
+	return merchant, nil
+}
+
+func validateMerchant(merchant domain.Merchant) error {
+	settings := merchant.Settings
+
+	if strings.TrimSpace(merchant.Name) == "" || settings.MaxAmount < 0 || settings.DailyLimit < 0 {
+		return internal_error.ErrMerchantInvalidSettings
+	}
+
+	if !isNameMatchScore(settings.NameMatchRejectBelow) || !isNameMatchScore(settings.NameMatchReviewBelow) {
+		return internal_error.ErrMerchantInvalidSettings
+	}
+
+	// reject threshold above the review threshold would never flag a name for review
+	if settings.NameMatchReviewBelow != 0 && settings.NameMatchRejectBelow > settings.NameMatchReviewBelow {
+		return internal_error.ErrMerchantInvalidSettings
+	}
+
+	return nil
+}
+
+func isNameMatchScore(score int) bool {
+	return score >= 0 && score <= 100
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	mock_repository "github.com/nobbyphala/Brick/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_merchantUsecase_CreateMerchant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMerchantRepo := mock_repository.NewMockMerchant(ctrl)
+
+	tests := []struct {
+		name     string
+		merchant domain.Merchant
+		want     domain.Merchant
+		wantErr  error
+		mock     func()
+	}{
+		{
+			name: "merchant created",
+			merchant: domain.Merchant{
+				Name:     "Toko Nobby",
+				Settings: domain.MerchantSettings{AllowedBankCodes: []string{"BCA"}},
+			},
+			want: domain.Merchant{
+				Id:       "merchant-1",
+				Name:     "Toko Nobby",
+				Settings: domain.MerchantSettings{AllowedBankCodes: []string{"BCA"}},
+			},
+			mock: func() {
+				mockMerchantRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return("merchant-1", nil)
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{
+					Id:       "merchant-1",
+					Name:     "Toko Nobby",
+					Settings: domain.MerchantSettings{AllowedBankCodes: []string{"BCA"}},
+				}, nil)
+			},
+		},
+		{
+			name:     "empty name",
+			merchant: domain.Merchant{Name: " "},
+			wantErr:  internal_error.ErrMerchantInvalidSettings,
+			mock:     func() {},
+		},
+		{
+			name:     "negative limit",
+			merchant: domain.Merchant{Name: "Toko Nobby", Settings: domain.MerchantSettings{DailyLimit: -1}},
+			wantErr:  internal_error.ErrMerchantInvalidSettings,
+			mock:     func() {},
+		},
+		{
+			name:     "name match threshold above 100",
+			merchant: domain.Merchant{Name: "Toko Nobby", Settings: domain.MerchantSettings{NameMatchReviewBelow: 101}},
+			wantErr:  internal_error.ErrMerchantInvalidSettings,
+			mock:     func() {},
+		},
+		{
+			name:     "name match reject threshold above review threshold",
+			merchant: domain.Merchant{Name: "Toko Nobby", Settings: domain.MerchantSettings{NameMatchRejectBelow: 80, NameMatchReviewBelow: 60}},
+			wantErr:  internal_error.ErrMerchantInvalidSettings,
+			mock:     func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			mu := NewMerchant(MerchantDeps{MerchantRepository: mockMerchantRepo})
+			got, err := mu.CreateMerchant(context.TODO(), tt.merchant)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_merchantUsecase_UpdateMerchant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMerchantRepo := mock_repository.NewMockMerchant(ctrl)
+	mu := NewMerchant(MerchantDeps{MerchantRepository: mockMerchantRepo})
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-2").Return(nil, nil)
+	_, err := mu.UpdateMerchant(context.TODO(), domain.Merchant{Id: "merchant-2", Name: "Toko"})
+	assert.Equal(t, internal_error.ErrMerchantNotFound, err)
+
+	updated := domain.Merchant{Id: "merchant-1", Name: "Toko Nobby", Settings: domain.MerchantSettings{MaxAmount: 1000000}}
+	mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1", Name: "Toko", CreatedAt: createdAt}, nil)
+	mockMerchantRepo.EXPECT().UpdateById(gomock.Any(), "merchant-1", updated).Return(nil)
+
+	got, err := mu.UpdateMerchant(context.TODO(), updated)
+	assert.NoError(t, err)
+	assert.Equal(t, createdAt, got.CreatedAt)
+	assert.Equal(t, updated.Settings, got.Settings)
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+	"time"
+)
+
+type apiKeyRepository struct {
+	db database.SQLDatabase
+}
+
+type ApiKeyDeps struct {
+	DB database.SQLDatabase
+}
+
+func NewApiKey(deps ApiKeyDeps) *apiKeyRepository {
+	return &apiKeyRepository{
+		db: deps.DB,
+	}
+}
+
+func (ak apiKeyRepository) WithTx(Tx database.SQLDatabase) ApiKey {
+	return apiKeyRepository{
+		db: Tx,
+	}
+}
+
+func (ak apiKeyRepository) Insert(ctx context.Context, apiKey domain.ApiKey) (string, error) {
+	var apiKeyId string
+
+	err := ak.db.Query(
+		ctx,
+		queryInsertApiKey,
+		apiKey.ClientId,
+		apiKey.Name,
+		string(apiKey.Role),
+		apiKey.Prefix,
+		apiKey.Hash,
+		apiKey.ExpiresAt,
+		nullableString(apiKey.MerchantId),
+	).Scan(&apiKeyId)
+	if err != nil {
+		return "", err
+	}
+
+	return apiKeyId, nil
+}
+
+func (ak apiKeyRepository) GetById(ctx context.Context, id string) (*domain.ApiKey, error) {
+	return ak.get(ctx, querySelectApiKeyById, id)
+}
+
+func (ak apiKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*domain.ApiKey, error) {
+	return ak.get(ctx, querySelectApiKeyByPrefix, prefix)
+}
+
+func (ak apiKeyRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.ApiKey, error) {
+	var res model.ApiKey
+
+	err := ak.db.Get(ctx, &res, query, args...)
+	if err != nil {
+		if errors.Is(err, database.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	apiKey := toDomainApiKey(res)
+	return &apiKey, nil
+}
+
+func (ak apiKeyRepository) ListByClientId(ctx context.Context, clientId string) ([]domain.ApiKey, error) {
+	var rows []model.ApiKey
+
+	err := ak.db.Select(ctx, &rows, querySelectApiKeyByClientId, clientId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.ApiKey, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, toDomainApiKey(row))
+	}
+
+	return res, nil
+}
+
+func (ak apiKeyRepository) UpdateById(ctx context.Context, id string, updatedData domain.ApiKey) error {
+	res, err := ak.db.Exec(
+		ctx,
+		queryUpdateApiKey,
+		updatedData.Name,
+		string(updatedData.Role),
+		updatedData.ExpiresAt,
+		updatedData.RevokedAt,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func (ak apiKeyRepository) UpdateLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) error {
+	res, err := ak.db.Exec(ctx, queryUpdateApiKeyLastUsedAt, lastUsedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func checkRowsAffected(res database.Result) error {
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowAffected == 0 {
+		return internal_error.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func toDomainApiKey(row model.ApiKey) domain.ApiKey {
+	return domain.ApiKey{
+		Id:         row.Id,
+		ClientId:   row.ClientId,
+		MerchantId: stringValue(row.MerchantId),
+		Name:       row.Name,
+		Role:       domain.Role(row.Role),
+		Prefix:     row.Prefix,
+		Hash:       row.KeyHash,
+		ExpiresAt:  row.ExpiresAt,
+		RevokedAt:  row.RevokedAt,
+		LastUsedAt: row.LastUsedAt,
+		CreatedAt:  row.CreatedAt,
+	}
+}
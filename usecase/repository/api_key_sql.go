@@ -0,0 +1,68 @@
+package repository
+
+const (
+	queryInsertApiKey = `
+	INSERT INTO
+		api_key
+		(
+		 client_id,
+		 name,
+		 role,
+		 prefix,
+		 key_hash,
+		 expires_at,
+		 merchant_id,
+		 created_at,
+		 updated_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	RETURNING
+		id`
+
+	queryUpdateApiKey = `
+	UPDATE
+		api_key
+	SET
+		name = $1,
+		role = $2,
+		expires_at = $3,
+		revoked_at = $4,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id = $5`
+
+	queryUpdateApiKeyLastUsedAt = `
+	UPDATE
+		api_key
+	SET
+		last_used_at = $1
+	WHERE
+		id = $2`
+
+	querySelectApiKeyById = `
+	SELECT
+		*
+	FROM
+		api_key
+	WHERE
+		id = $1`
+
+	querySelectApiKeyByPrefix = `
+	SELECT
+		*
+	FROM
+		api_key
+	WHERE
+		prefix = $1`
+
+	querySelectApiKeyByClientId = `
+	SELECT
+		*
+	FROM
+		api_key
+	WHERE
+		client_id = $1
+	ORDER BY
+		created_at`
+)
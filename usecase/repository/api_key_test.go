@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/mock"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_apiKeyRepository_GetByPrefix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		want    *domain.ApiKey
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "get existing api key",
+			want: &domain.ApiKey{
+				Id:        "key-id-1",
+				ClientId:  "client-1",
+				Name:      "payroll",
+				Role:      domain.RoleClient,
+				Prefix:    "abc123",
+				Hash:      "hash",
+				CreatedAt: createdAt,
+			},
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Eq(&model.ApiKey{}), `
+	SELECT
+		*
+	FROM
+		api_key
+	WHERE
+		prefix = $1`, "abc123").DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+					*dest.(*model.ApiKey) = model.ApiKey{
+						Id:        "key-id-1",
+						ClientId:  "client-1",
+						Name:      "payroll",
+						Role:      "client",
+						Prefix:    "abc123",
+						KeyHash:   "hash",
+						CreatedAt: createdAt,
+						UpdatedAt: createdAt,
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "non existing api key",
+			want: nil,
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), "abc123").Return(sql.ErrNoRows)
+			},
+		},
+		{
+			name:    "unknown error from driver",
+			wantErr: errors.New("sql error"),
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), "abc123").Return(errors.New("sql error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			ak := NewApiKey(ApiKeyDeps{DB: mockDB})
+			got, err := ak.GetByPrefix(context.TODO(), "abc123")
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_apiKeyRepository_UpdateLastUsedAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	mockResult := mock.NewMockResult(ctrl)
+	usedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "success update",
+			mock: func() {
+				mockResult.EXPECT().RowsAffected().Return(int64(1), nil)
+				mockDB.EXPECT().Exec(gomock.Any(), `
+	UPDATE
+		api_key
+	SET
+		last_used_at = $1
+	WHERE
+		id = $2`, usedAt, "key-id-1").Return(mockResult, nil)
+			},
+		},
+		{
+			name:    "no row affected",
+			wantErr: internal_error.ErrNoRowsAffected,
+			mock: func() {
+				mockResult.EXPECT().RowsAffected().Return(int64(0), nil)
+				mockDB.EXPECT().Exec(gomock.Any(), gomock.Any(), usedAt, "key-id-1").Return(mockResult, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			ak := NewApiKey(ApiKeyDeps{DB: mockDB})
+			err := ak.UpdateLastUsedAt(context.TODO(), "key-id-1", usedAt)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+)
+
+const defaultAuditLogLimit = 100
+
+type auditLogRepository struct {
+	db database.SQLDatabase
+}
+
+type AuditLogDeps struct {
+	DB database.SQLDatabase
+}
+
+func NewAuditLog(deps AuditLogDeps) *auditLogRepository {
+	return &auditLogRepository{
+		db: deps.DB,
+	}
+}
+
+func (al auditLogRepository) WithTx(Tx database.SQLDatabase) AuditLog {
+	return auditLogRepository{
+		db: Tx,
+	}
+}
+
+func (al auditLogRepository) Insert(ctx context.Context, auditLog domain.AuditLog) (string, error) {
+	var auditLogId string
+
+	err := al.db.Query(
+		ctx,
+		queryInsertAuditLog,
+		auditLog.ActorId,
+		string(auditLog.ActorRole),
+		nullableString(auditLog.ApiKeyId),
+		nullableString(auditLog.MerchantId),
+		auditLog.Action,
+		auditLog.ResourceId,
+		string(auditLog.Outcome),
+		auditLog.Reason,
+	).Scan(&auditLogId)
+	if err != nil {
+		return "", err
+	}
+
+	return auditLogId, nil
+}
+
+func (al auditLogRepository) List(ctx context.Context, filter domain.AuditLogFilter) ([]domain.AuditLog, error) {
+	var rows []model.AuditLog
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLogLimit
+	}
+
+	err := al.db.Select(ctx, &rows, querySelectAuditLogs, filter.ActorId, filter.Action, string(filter.Outcome), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.AuditLog, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, domain.AuditLog{
+			Id:         row.Id,
+			ActorId:    row.ActorId,
+			ActorRole:  domain.Role(row.ActorRole),
+			ApiKeyId:   stringValue(row.ApiKeyId),
+			MerchantId: stringValue(row.MerchantId),
+			Action:     row.Action,
+			ResourceId: row.ResourceId,
+			Outcome:    domain.AuditOutcome(row.Outcome),
+			Reason:     row.Reason,
+			CreatedAt:  row.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
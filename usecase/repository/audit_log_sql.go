@@ -0,0 +1,36 @@
+package repository
+
+const (
+	queryInsertAuditLog = `
+	INSERT INTO
+		audit_log
+		(
+		 actor_id,
+		 actor_role,
+		 api_key_id,
+		 merchant_id,
+		 action,
+		 resource_id,
+		 outcome,
+		 reason,
+		 created_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, $8, CURRENT_TIMESTAMP)
+	RETURNING
+		id`
+
+	// empty filter value match every row
+	querySelectAuditLogs = `
+	SELECT
+		*
+	FROM
+		audit_log
+	WHERE
+		($1 = '' OR actor_id = $1)
+		AND ($2 = '' OR action = $2)
+		AND ($3 = '' OR outcome = $3)
+	ORDER BY
+		created_at DESC
+	LIMIT $4`
+)
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/crypto"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+)
+
+const defaultBeneficiaryLimit = 100
+
+type beneficiaryRepository struct {
+	db        database.SQLDatabase
+	encryptor crypto.FieldEncryptor
+}
+
+type BeneficiaryDeps struct {
+	DB database.SQLDatabase
+	// encrypt name, account number and account holder name before stored
+	Encryptor crypto.FieldEncryptor
+}
+
+func NewBeneficiary(deps BeneficiaryDeps) *beneficiaryRepository {
+	return &beneficiaryRepository{
+		db:        deps.DB,
+		encryptor: deps.Encryptor,
+	}
+}
+
+func (br beneficiaryRepository) Insert(ctx context.Context, beneficiary domain.Beneficiary) (string, error) {
+	var beneficiaryId string
+
+	fields, err := br.encryptor.Encrypt(ctx, beneficiary.Name, beneficiary.AccountNumber, beneficiary.Verification.AccountHolderName)
+	if err != nil {
+		return "", err
+	}
+
+	err = br.db.Query(
+		ctx,
+		queryInsertBeneficiary,
+		beneficiary.MerchantId,
+		fields.Values[0],
+		fields.Values[1],
+		beneficiary.BankCode,
+		nullableString(fields.KeyId),
+		nullableString(fields.DataKey),
+		string(beneficiary.Verification.Status),
+		fields.Values[2],
+		beneficiary.Verification.NameMatchScore,
+		beneficiary.Verification.VerifiedAt,
+	).Scan(&beneficiaryId)
+	if err != nil {
+		return "", err
+	}
+
+	return beneficiaryId, nil
+}
+
+func (br beneficiaryRepository) GetById(ctx context.Context, id string) (*domain.Beneficiary, error) {
+	var res model.Beneficiary
+
+	err := br.db.Get(ctx, &res, querySelectBeneficiaryById, id, merchantScope(ctx))
+	if err != nil {
+		if errors.Is(err, database.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return br.toDomain(ctx, res)
+}
+
+func (br beneficiaryRepository) List(ctx context.Context, filter domain.BeneficiaryFilter) ([]domain.Beneficiary, error) {
+	var rows []model.Beneficiary
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultBeneficiaryLimit
+	}
+
+	err := br.db.Select(ctx, &rows, querySelectBeneficiaries, filter.BankCode, limit, filter.Offset, merchantScope(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Beneficiary, 0, len(rows))
+	for _, row := range rows {
+		beneficiary, err := br.toDomain(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *beneficiary)
+	}
+
+	return res, nil
+}
+
+func (br beneficiaryRepository) UpdateById(ctx context.Context, id string, updatedData domain.Beneficiary) error {
+	fields, err := br.encryptor.Encrypt(ctx, updatedData.Name, updatedData.AccountNumber, updatedData.Verification.AccountHolderName)
+	if err != nil {
+		return err
+	}
+
+	res, err := br.db.Exec(
+		ctx,
+		queryUpdateBeneficiary,
+		fields.Values[0],
+		fields.Values[1],
+		updatedData.BankCode,
+		nullableString(fields.KeyId),
+		nullableString(fields.DataKey),
+		string(updatedData.Verification.Status),
+		fields.Values[2],
+		updatedData.Verification.NameMatchScore,
+		updatedData.Verification.VerifiedAt,
+		id,
+		merchantScope(ctx),
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func (br beneficiaryRepository) DeleteById(ctx context.Context, id string) error {
+	res, err := br.db.Exec(ctx, queryDeleteBeneficiary, id, merchantScope(ctx))
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func (br beneficiaryRepository) toDomain(ctx context.Context, row model.Beneficiary) (*domain.Beneficiary, error) {
+	fields, err := br.encryptor.Decrypt(ctx, crypto.EncryptedFields{
+		KeyId:   stringValue(row.EncryptionKeyId),
+		DataKey: stringValue(row.EncryptedDataKey),
+		Values:  []string{row.Name, row.AccountNumber, row.AccountHolderName},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Beneficiary{
+		Id:            row.Id,
+		MerchantId:    row.MerchantId,
+		Name:          fields[0],
+		AccountNumber: fields[1],
+		BankCode:      row.BankCode,
+		Verification: domain.BeneficiaryVerification{
+			Status:            domain.BeneficiaryVerificationStatus(row.VerificationStatus),
+			AccountHolderName: fields[2],
+			NameMatchScore:    row.NameMatchScore,
+			VerifiedAt:        row.VerifiedAt,
+		},
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+const (
+	queryInsertBeneficiary = `
+	INSERT INTO
+		beneficiary
+		(
+		 merchant_id,
+		 name,
+		 account_number,
+		 bank_code,
+		 encryption_key_id,
+		 encrypted_data_key,
+		 verification_status,
+		 account_holder_name,
+		 name_match_score,
+		 verified_at,
+		 created_at,
+		 updated_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	RETURNING
+		id`
+
+	queryUpdateBeneficiary = `
+	UPDATE
+		beneficiary
+	SET
+		name = $1,
+		account_number = $2,
+		bank_code = $3,
+		encryption_key_id = $4,
+		encrypted_data_key = $5,
+		verification_status = $6,
+		account_holder_name = $7,
+		name_match_score = $8,
+		verified_at = $9,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id = $10
+		AND deleted_at IS NULL
+		AND ($11::uuid IS NULL OR merchant_id = $11)`
+
+	queryDeleteBeneficiary = `
+	UPDATE
+		beneficiary
+	SET
+		deleted_at = CURRENT_TIMESTAMP,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id = $1
+		AND deleted_at IS NULL
+		AND ($2::uuid IS NULL OR merchant_id = $2)`
+
+	querySelectBeneficiaryById = `
+	SELECT
+		*
+	FROM
+		beneficiary
+	WHERE
+		id = $1
+		AND deleted_at IS NULL
+		AND ($2::uuid IS NULL OR merchant_id = $2)`
+
+	querySelectBeneficiaries = `
+	SELECT
+		*
+	FROM
+		beneficiary
+	WHERE
+		deleted_at IS NULL
+		AND ($1 = '' OR bank_code = $1)
+		AND ($4::uuid IS NULL OR merchant_id = $4)
+	ORDER BY
+		created_at DESC,
+		id DESC
+	LIMIT $2
+	OFFSET $3`
+)
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/crypto"
+	"github.com/nobbyphala/Brick/mock"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_beneficiaryRepository_Insert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	mockRow := mock.NewMockRow(ctrl)
+	verifiedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "beneficiary-1"
+		return nil
+	})
+	mockDB.EXPECT().Query(gomock.Any(), `
+	INSERT INTO
+		beneficiary
+		(
+		 merchant_id,
+		 name,
+		 account_number,
+		 bank_code,
+		 encryption_key_id,
+		 encrypted_data_key,
+		 verification_status,
+		 account_holder_name,
+		 name_match_score,
+		 verified_at,
+		 created_at,
+		 updated_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	RETURNING
+		id`, "merchant-1", "Nobby Phala", "6789567", "014", nil, nil, "VERIFIED", "Nobby Phala Putra", 88, verifiedAt).Return(mockRow)
+
+	br := NewBeneficiary(BeneficiaryDeps{DB: mockDB, Encryptor: crypto.NewPlaintextEncryptor()})
+	got, err := br.Insert(context.TODO(), domain.Beneficiary{
+		MerchantId:    "merchant-1",
+		Name:          "Nobby Phala",
+		AccountNumber: "6789567",
+		BankCode:      "014",
+		Verification: domain.BeneficiaryVerification{
+			Status:            domain.BeneficiaryVerified,
+			AccountHolderName: "Nobby Phala Putra",
+			NameMatchScore:    88,
+			VerifiedAt:        verifiedAt,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "beneficiary-1", got)
+}
+
+func Test_beneficiaryRepository_GetById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	verifiedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	merchantId := "merchant-1"
+	merchantCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: merchantId})
+
+	query := `
+	SELECT
+		*
+	FROM
+		beneficiary
+	WHERE
+		id = $1
+		AND deleted_at IS NULL
+		AND ($2::uuid IS NULL OR merchant_id = $2)`
+
+	tests := []struct {
+		name    string
+		want    *domain.Beneficiary
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "beneficiary of the merchant",
+			want: &domain.Beneficiary{
+				Id:            "beneficiary-1",
+				MerchantId:    merchantId,
+				Name:          "Nobby Phala",
+				AccountNumber: "6789567",
+				BankCode:      "014",
+				Verification: domain.BeneficiaryVerification{
+					Status:            domain.BeneficiaryVerified,
+					AccountHolderName: "Nobby Phala Putra",
+					NameMatchScore:    88,
+					VerifiedAt:        verifiedAt,
+				},
+			},
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), query, "beneficiary-1", &merchantId).DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+					*dest.(*model.Beneficiary) = model.Beneficiary{
+						Id:                 "beneficiary-1",
+						MerchantId:         merchantId,
+						Name:               "Nobby Phala",
+						AccountNumber:      "6789567",
+						BankCode:           "014",
+						VerificationStatus: "VERIFIED",
+						AccountHolderName:  "Nobby Phala Putra",
+						NameMatchScore:     88,
+						VerifiedAt:         verifiedAt,
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "beneficiary not found",
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), query, "beneficiary-1", &merchantId).Return(sql.ErrNoRows)
+			},
+		},
+		{
+			name:    "error get",
+			wantErr: errors.New("sql error"),
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), query, "beneficiary-1", &merchantId).Return(errors.New("sql error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			br := NewBeneficiary(BeneficiaryDeps{DB: mockDB, Encryptor: crypto.NewPlaintextEncryptor()})
+			got, err := br.GetById(merchantCtx, "beneficiary-1")
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_beneficiaryRepository_DeleteById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	mockResult := mock.NewMockResult(ctrl)
+
+	query := `
+	UPDATE
+		beneficiary
+	SET
+		deleted_at = CURRENT_TIMESTAMP,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id = $1
+		AND deleted_at IS NULL
+		AND ($2::uuid IS NULL OR merchant_id = $2)`
+
+	tests := []struct {
+		name    string
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "success delete",
+			mock: func() {
+				mockResult.EXPECT().RowsAffected().Return(int64(1), nil)
+				mockDB.EXPECT().Exec(gomock.Any(), query, "beneficiary-1", (*string)(nil)).Return(mockResult, nil)
+			},
+		},
+		{
+			name:    "beneficiary already deleted",
+			wantErr: internal_error.ErrNoRowsAffected,
+			mock: func() {
+				mockResult.EXPECT().RowsAffected().Return(int64(0), nil)
+				mockDB.EXPECT().Exec(gomock.Any(), query, "beneficiary-1", (*string)(nil)).Return(mockResult, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			br := NewBeneficiary(BeneficiaryDeps{DB: mockDB, Encryptor: crypto.NewPlaintextEncryptor()})
+			err := br.DeleteById(context.TODO(), "beneficiary-1")
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
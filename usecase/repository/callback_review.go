@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+)
+
+const defaultCallbackReviewLimit = 100
+
+type callbackReviewRepository struct {
+	db database.SQLDatabase
+}
+
+type CallbackReviewDeps struct {
+	DB database.SQLDatabase
+}
+
+func NewCallbackReview(deps CallbackReviewDeps) *callbackReviewRepository {
+	return &callbackReviewRepository{
+		db: deps.DB,
+	}
+}
+
+func (cr callbackReviewRepository) WithTx(Tx database.SQLDatabase) CallbackReview {
+	return callbackReviewRepository{
+		db: Tx,
+	}
+}
+
+func (cr callbackReviewRepository) Insert(ctx context.Context, review domain.CallbackReview) (string, error) {
+	var reviewId string
+
+	err := cr.db.Query(
+		ctx,
+		queryInsertCallbackReview,
+		review.TransactionId,
+		nullableString(review.DisbursementId),
+		string(review.Reason),
+		review.Detail,
+		string(review.Payload),
+		string(review.Status),
+	).Scan(&reviewId)
+	if err != nil {
+		return "", err
+	}
+
+	return reviewId, nil
+}
+
+func (cr callbackReviewRepository) UpdateById(ctx context.Context, id string, updatedData domain.CallbackReview) error {
+	res, err := cr.db.Exec(
+		ctx,
+		queryUpdateCallbackReview,
+		nullableString(updatedData.AssigneeId),
+		string(updatedData.Status),
+		nullableString(updatedData.ResolvedBy),
+		nullableString(updatedData.ResolutionNote),
+		updatedData.ResolvedAt,
+		id,
+		string(domain.CallbackReviewStatusOpen),
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func (cr callbackReviewRepository) GetById(ctx context.Context, id string) (*domain.CallbackReview, error) {
+	return cr.get(ctx, querySelectCallbackReviewById, id)
+}
+
+func (cr callbackReviewRepository) GetOpen(ctx context.Context, transactionId string, reason domain.CallbackReviewReason) (*domain.CallbackReview, error) {
+	return cr.get(ctx, querySelectOpenCallbackReview, transactionId, string(reason), string(domain.CallbackReviewStatusOpen))
+}
+
+func (cr callbackReviewRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.CallbackReview, error) {
+	var res model.CallbackReview
+
+	err := cr.db.Get(ctx, &res, query, args...)
+	if err != nil {
+		if errors.Is(err, database.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	review := toDomainCallbackReview(res)
+	return &review, nil
+}
+
+func (cr callbackReviewRepository) List(ctx context.Context, filter domain.CallbackReviewFilter) ([]domain.CallbackReview, error) {
+	var rows []model.CallbackReview
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultCallbackReviewLimit
+	}
+
+	err := cr.db.Select(ctx, &rows, querySelectCallbackReviews, string(filter.Status), string(filter.Reason), filter.AssigneeId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.CallbackReview, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, toDomainCallbackReview(row))
+	}
+
+	return res, nil
+}
+
+func (cr callbackReviewRepository) CountOpen(ctx context.Context) ([]domain.CallbackReviewCount, error) {
+	var rows []model.CallbackReviewCount
+
+	err := cr.db.Select(ctx, &rows, queryCountOpenCallbackReviews, string(domain.CallbackReviewStatusOpen))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.CallbackReviewCount, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, domain.CallbackReviewCount{
+			Reason:     domain.CallbackReviewReason(row.Reason),
+			Open:       row.Open,
+			Unassigned: row.Unassigned,
+		})
+	}
+
+	return res, nil
+}
+
+func (cr callbackReviewRepository) InsertComment(ctx context.Context, comment domain.CallbackReviewComment) (string, error) {
+	var commentId string
+
+	err := cr.db.Query(ctx, queryInsertCallbackReviewComment, comment.ReviewId, comment.AuthorId, comment.Body).Scan(&commentId)
+	if err != nil {
+		return "", err
+	}
+
+	return commentId, nil
+}
+
+func (cr callbackReviewRepository) ListComments(ctx context.Context, reviewId string) ([]domain.CallbackReviewComment, error) {
+	var rows []model.CallbackReviewComment
+
+	err := cr.db.Select(ctx, &rows, querySelectCallbackReviewComments, reviewId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.CallbackReviewComment, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, domain.CallbackReviewComment{
+			Id:        row.Id,
+			ReviewId:  row.ReviewId,
+			AuthorId:  row.AuthorId,
+			Body:      row.Body,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+func toDomainCallbackReview(row model.CallbackReview) domain.CallbackReview {
+	return domain.CallbackReview{
+		Id:             row.Id,
+		TransactionId:  row.TransactionId,
+		DisbursementId: stringValue(row.DisbursementId),
+		Reason:         domain.CallbackReviewReason(row.Reason),
+		Detail:         row.Detail,
+		Payload:        row.Payload,
+		Status:         domain.CallbackReviewStatus(row.Status),
+		AssigneeId:     stringValue(row.AssigneeId),
+		ResolvedBy:     stringValue(row.ResolvedBy),
+		ResolutionNote: stringValue(row.ResolutionNote),
+		ResolvedAt:     row.ResolvedAt,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+}
@@ -0,0 +1,106 @@
+package repository
+
+const (
+	queryInsertCallbackReview = `
+	INSERT INTO
+		callback_review
+		(
+		 transaction_id,
+		 disbursement_id,
+		 reason,
+		 detail,
+		 payload,
+		 status,
+		 created_at,
+		 updated_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	RETURNING
+		id`
+
+	// resolved review is final, the update is ignored when the review is not open anymore
+	queryUpdateCallbackReview = `
+	UPDATE
+		callback_review
+	SET
+		assignee_id = $1,
+		status = $2,
+		resolved_by = $3,
+		resolution_note = $4,
+		resolved_at = $5,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id = $6
+		AND status = $7`
+
+	querySelectCallbackReviewById = `
+	SELECT
+		*
+	FROM
+		callback_review
+	WHERE
+		id = $1`
+
+	querySelectOpenCallbackReview = `
+	SELECT
+		*
+	FROM
+		callback_review
+	WHERE
+		transaction_id = $1
+		AND reason = $2
+		AND status = $3`
+
+	// empty filter value match every row
+	querySelectCallbackReviews = `
+	SELECT
+		*
+	FROM
+		callback_review
+	WHERE
+		($1 = '' OR status = $1)
+		AND ($2 = '' OR reason = $2)
+		AND ($3 = '' OR assignee_id = $3)
+	ORDER BY
+		created_at
+	LIMIT $4`
+
+	queryCountOpenCallbackReviews = `
+	SELECT
+		reason,
+		COUNT(*) AS open,
+		COUNT(*) FILTER (WHERE assignee_id IS NULL) AS unassigned
+	FROM
+		callback_review
+	WHERE
+		status = $1
+	GROUP BY
+		reason
+	ORDER BY
+		reason`
+
+	queryInsertCallbackReviewComment = `
+	INSERT INTO
+		callback_review_comment
+		(
+		 review_id,
+		 author_id,
+		 body,
+		 created_at
+		 )
+	VALUES
+		($1, $2, $3, CURRENT_TIMESTAMP)
+	RETURNING
+		id`
+
+	querySelectCallbackReviewComments = `
+	SELECT
+		*
+	FROM
+		callback_review_comment
+	WHERE
+		review_id = $1
+	ORDER BY
+		created_at`
+)
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/mock"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_callbackReviewRepository_UpdateById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	mockResult := mock.NewMockResult(ctrl)
+	resolvedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{
+			name:         "success resolve",
+			rowsAffected: 1,
+		},
+		{
+			name:         "review is not open",
+			rowsAffected: 0,
+			wantErr:      internal_error.ErrNoRowsAffected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockResult.EXPECT().RowsAffected().Return(tt.rowsAffected, nil)
+			mockDB.EXPECT().Exec(gomock.Any(), `
+	UPDATE
+		callback_review
+	SET
+		assignee_id = $1,
+		status = $2,
+		resolved_by = $3,
+		resolution_note = $4,
+		resolved_at = $5,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id = $6
+		AND status = $7`, nullableString("ops-1"), "DISCARDED", nullableString("ops-1"), nullableString("duplicate callback"), &resolvedAt, "review-1", "OPEN").Return(mockResult, nil)
+
+			cr := NewCallbackReview(CallbackReviewDeps{DB: mockDB})
+			err := cr.UpdateById(context.TODO(), "review-1", domain.CallbackReview{
+				AssigneeId:     "ops-1",
+				Status:         domain.CallbackReviewStatusDiscarded,
+				ResolvedBy:     "ops-1",
+				ResolutionNote: "duplicate callback",
+				ResolvedAt:     &resolvedAt,
+			})
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_callbackReviewRepository_CountOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+
+	mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), `
+	SELECT
+		reason,
+		COUNT(*) AS open,
+		COUNT(*) FILTER (WHERE assignee_id IS NULL) AS unassigned
+	FROM
+		callback_review
+	WHERE
+		status = $1
+	GROUP BY
+		reason
+	ORDER BY
+		reason`, "OPEN").DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+		rows := dest.(*[]model.CallbackReviewCount)
+		*rows = []model.CallbackReviewCount{{Reason: "DISBURSEMENT_NOT_FOUND", Open: 3, Unassigned: 2}}
+		return nil
+	})
+
+	cr := NewCallbackReview(CallbackReviewDeps{DB: mockDB})
+	got, err := cr.CountOpen(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.CallbackReviewCount{
+		{Reason: domain.CallbackReviewReasonDisbursementNotFound, Open: 3, Unassigned: 2},
+	}, got)
+}
@@ -2,46 +2,65 @@ package repository
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"github.com/nobbyphala/Brick/domain"
 	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/crypto"
 	"github.com/nobbyphala/Brick/external/database"
 	"github.com/nobbyphala/Brick/usecase/repository/model"
+	"time"
 )
 
+const defaultDisbursementLimit = 100
+
 type disbursementRepository struct {
-	db database.SQLDatabase
+	db        database.SQLDatabase
+	encryptor crypto.FieldEncryptor
 }
 
 type DisbursementDeps struct {
 	DB database.SQLDatabase
+	// encrypt recipient name and account number before stored
+	Encryptor crypto.FieldEncryptor
 }
 
 func NewDisbursement(deps DisbursementDeps) *disbursementRepository {
 	return &disbursementRepository{
-		db: deps.DB,
+		db:        deps.DB,
+		encryptor: deps.Encryptor,
 	}
 }
 
 func (disb disbursementRepository) WithTx(Tx database.SQLDatabase) Disbursement {
 	return disbursementRepository{
-		db: Tx,
+		db:        Tx,
+		encryptor: disb.encryptor,
 	}
 }
 
 func (disb disbursementRepository) Insert(ctx context.Context, disbursement domain.Disbursement) (string, error) {
 	var disbursementId string
 
-	err := disb.db.Query(
+	recipient, err := disb.encryptRecipient(ctx, disbursement)
+	if err != nil {
+		return "", err
+	}
+
+	err = disb.db.Query(
 		ctx,
 		queryInsertDisbursement,
-		disbursement.RecipientName,
-		disbursement.RecipientAccountNumber,
+		recipient.name,
+		recipient.accountNumber,
 		disbursement.RecipientBankCode,
 		disbursement.BankTransactionId,
 		disbursement.Amount,
 		disbursement.Status.ToInt(),
+		recipient.keyId,
+		recipient.dataKey,
+		recipient.accountNumberBidx,
+		nullableString(disbursement.MerchantId),
+		nameMatchScore(disbursement),
+		nullableString(string(disbursement.NameMatchDecision)),
 	).Scan(&disbursementId)
 	if err != nil {
 		return "", err
@@ -50,17 +69,28 @@ func (disb disbursementRepository) Insert(ctx context.Context, disbursement doma
 	return disbursementId, nil
 }
 
-func (disb disbursementRepository) UpdateById(ctx context.Context, id string, updatedData domain.Disbursement) error {
+func (disb disbursementRepository) UpdateById(ctx context.Context, id string, expectedVersion int64, updatedData domain.Disbursement) error {
+	recipient, err := disb.encryptRecipient(ctx, updatedData)
+	if err != nil {
+		return err
+	}
+
 	res, err := disb.db.Exec(
 		ctx,
 		queryUpdateDisbursement,
-		updatedData.RecipientName,
-		updatedData.RecipientAccountNumber,
+		recipient.name,
+		recipient.accountNumber,
 		updatedData.RecipientBankCode,
 		updatedData.BankTransactionId,
 		updatedData.Amount,
 		updatedData.Status,
-		id)
+		id,
+		expectedVersion,
+		recipient.keyId,
+		recipient.dataKey,
+		recipient.accountNumberBidx,
+		merchantScope(ctx),
+		nullableString(updatedData.BankEvidenceReference))
 	if err != nil {
 		return err
 	}
@@ -71,31 +101,219 @@ func (disb disbursementRepository) UpdateById(ctx context.Context, id string, up
 	}
 
 	if rowAffected == 0 {
-		return internal_error.ErrNoRowsAffected
+		return disb.checkVersionConflict(ctx, id)
 	}
 
 	return nil
 }
 
+// checkVersionConflict find out why update by id and version affect no row
+func (disb disbursementRepository) checkVersionConflict(ctx context.Context, id string) error {
+	var version int64
+
+	err := disb.db.Get(ctx, &version, querySelectVersionById, id, merchantScope(ctx))
+	if err != nil {
+		if errors.Is(err, database.ErrNoRows) {
+			return internal_error.ErrNoRowsAffected
+		}
+
+		return err
+	}
+
+	return internal_error.ErrVersionConflict
+}
+
+func (disb disbursementRepository) GetById(ctx context.Context, id string) (*domain.Disbursement, error) {
+	return disb.get(ctx, querySelectDisbursementById, id, merchantScope(ctx))
+}
+
 func (disb disbursementRepository) GetByTransactionId(ctx context.Context, bankTransactionId string) (*domain.Disbursement, error) {
+	return disb.get(ctx, querySelectByBankTransactionId, bankTransactionId, merchantScope(ctx))
+}
+
+func (disb disbursementRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.Disbursement, error) {
 	var res model.Disbursement
 
-	err := disb.db.Get(ctx, &res, querySelectByBankTransactionId, bankTransactionId)
+	err := disb.db.Get(ctx, &res, query, args...)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, database.ErrNoRows) {
 			return nil, nil
 		}
 
 		return nil, err
 	}
 
+	return disb.toDomain(ctx, res)
+}
+
+func (disb disbursementRepository) GetByRecipientAccountNumber(ctx context.Context, accountNumber string) ([]domain.Disbursement, error) {
+	bidx, err := disb.encryptor.BlindIndex(ctx, accountNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []model.Disbursement
+
+	err = disb.db.Select(ctx, &rows, querySelectByRecipientAccountNumber, bidx, accountNumber, merchantScope(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return disb.toDomainList(ctx, rows)
+}
+
+func (disb disbursementRepository) ListNeedReEncryption(ctx context.Context, limit int) ([]domain.Disbursement, error) {
+	keyId, err := disb.encryptor.CurrentKeyId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyId == "" {
+		// encryption not enabled, never decrypt the encrypted rows back to plaintext
+		return nil, nil
+	}
+
+	var rows []model.Disbursement
+
+	err = disb.db.Select(ctx, &rows, querySelectNeedReEncryption, keyId, limit, merchantScope(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return disb.toDomainList(ctx, rows)
+}
+
+func (disb disbursementRepository) SumAmountSince(ctx context.Context, since time.Time) (int64, error) {
+	var total int64
+
+	err := disb.db.Get(
+		ctx,
+		&total,
+		querySumAmountSince,
+		since,
+		domain.DisbursementStatusFailed.ToInt(),
+		domain.DisbursementStatusRejected.ToInt(),
+		merchantScope(ctx),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (disb disbursementRepository) List(ctx context.Context, filter domain.DisbursementFilter) ([]domain.Disbursement, error) {
+	var rows []model.Disbursement
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultDisbursementLimit
+	}
+
+	err := disb.db.Select(
+		ctx,
+		&rows,
+		querySelectDisbursements,
+		filter.Status.ToInt(),
+		filter.RecipientBankCode,
+		limit,
+		filter.Offset,
+		merchantScope(ctx),
+		string(filter.NameMatchDecision),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return disb.toDomainList(ctx, rows)
+}
+
+type encryptedRecipient struct {
+	name              string
+	accountNumber     string
+	accountNumberBidx *string
+	keyId             *string
+	dataKey           *string
+}
+
+func (disb disbursementRepository) encryptRecipient(ctx context.Context, disbursement domain.Disbursement) (encryptedRecipient, error) {
+	fields, err := disb.encryptor.Encrypt(ctx, disbursement.RecipientName, disbursement.RecipientAccountNumber)
+	if err != nil {
+		return encryptedRecipient{}, err
+	}
+
+	res := encryptedRecipient{
+		name:          fields.Values[0],
+		accountNumber: fields.Values[1],
+	}
+
+	if fields.KeyId == "" {
+		return res, nil
+	}
+
+	bidx, err := disb.encryptor.BlindIndex(ctx, disbursement.RecipientAccountNumber)
+	if err != nil {
+		return encryptedRecipient{}, err
+	}
+
+	res.accountNumberBidx = &bidx
+	res.keyId = &fields.KeyId
+	res.dataKey = &fields.DataKey
+
+	return res, nil
+}
+
+func (disb disbursementRepository) toDomain(ctx context.Context, res model.Disbursement) (*domain.Disbursement, error) {
+	fields := crypto.EncryptedFields{
+		Values: []string{res.RecipientName, res.RecipientAccountNumber},
+	}
+
+	if res.EncryptionKeyId != nil && res.EncryptedDataKey != nil {
+		fields.KeyId = *res.EncryptionKeyId
+		fields.DataKey = *res.EncryptedDataKey
+	}
+
+	recipient, err := disb.encryptor.Decrypt(ctx, fields)
+	if err != nil {
+		return nil, err
+	}
+
 	return &domain.Disbursement{
 		Id:                     res.Id,
-		RecipientName:          res.RecipientName,
-		RecipientAccountNumber: res.RecipientAccountNumber,
+		MerchantId:             stringValue(res.MerchantId),
+		RecipientName:          recipient[0],
+		RecipientAccountNumber: recipient[1],
 		RecipientBankCode:      res.RecipientBankCode,
 		BankTransactionId:      res.BankTransactionId,
 		Amount:                 res.Amount,
 		Status:                 domain.DisbursementStatus(res.Status),
+		BankEvidenceReference:  stringValue(res.BankEvidenceReference),
+		Version:                res.Version,
+		NameMatchScore:         intValue(res.NameMatchScore),
+		NameMatchDecision:      domain.NameMatchDecision(stringValue(res.NameMatchDecision)),
 	}, nil
 }
+
+// nameMatchScore is null when the recipient name was not matched
+func nameMatchScore(disbursement domain.Disbursement) *int {
+	if disbursement.NameMatchDecision == "" {
+		return nil
+	}
+
+	return &disbursement.NameMatchScore
+}
+
+func (disb disbursementRepository) toDomainList(ctx context.Context, rows []model.Disbursement) ([]domain.Disbursement, error) {
+	res := make([]domain.Disbursement, 0, len(rows))
+
+	for _, row := range rows {
+		disbursement, err := disb.toDomain(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *disbursement)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+)
+
+const defaultDisbursementStatusEventLimit = 100
+
+// the events are inserted by the disbursement_status_event_trigger when UpdateById change the status
+type disbursementStatusEventRepository struct {
+	db database.SQLDatabase
+}
+
+type DisbursementStatusEventDeps struct {
+	DB database.SQLDatabase
+}
+
+func NewDisbursementStatusEvent(deps DisbursementStatusEventDeps) *disbursementStatusEventRepository {
+	return &disbursementStatusEventRepository{
+		db: deps.DB,
+	}
+}
+
+func (dse disbursementStatusEventRepository) List(ctx context.Context, filter domain.DisbursementStatusEventFilter) ([]domain.DisbursementStatusEvent, error) {
+	var rows []model.DisbursementStatusEvent
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultDisbursementStatusEventLimit
+	}
+
+	err := dse.db.Select(ctx, &rows, querySelectDisbursementStatusEvents, filter.AfterId, nullableString(filter.DisbursementId), merchantScope(ctx), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.DisbursementStatusEvent, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, domain.DisbursementStatusEvent{
+			Id:             row.Id,
+			DisbursementId: row.DisbursementId,
+			MerchantId:     stringValue(row.MerchantId),
+			Status:         domain.DisbursementStatus(row.Status),
+			PreviousStatus: domain.DisbursementStatus(row.PreviousStatus),
+			Version:        row.Version,
+			CreatedAt:      row.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+func (dse disbursementStatusEventRepository) LastId(ctx context.Context) (int64, error) {
+	var lastId int64
+
+	err := dse.db.Query(ctx, querySelectLastDisbursementStatusEventId).Scan(&lastId)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastId, nil
+}
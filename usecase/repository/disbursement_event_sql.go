@@ -0,0 +1,34 @@
+package repository
+
+const (
+	// the events of a transaction in progress are not read until every older transaction ended, so the events are
+	// read in (transaction_id, id) order without skipping an event committed later with a smaller id. Unknown
+	// after id resume from the first event, null disbursement id match every disbursement
+	querySelectDisbursementStatusEvents = `
+	SELECT
+		*
+	FROM
+		disbursement_status_event
+	WHERE
+		(transaction_id, id) > (COALESCE((SELECT transaction_id FROM disbursement_status_event WHERE id = $1), 0), $1)
+		AND transaction_id < txid_snapshot_xmin(txid_current_snapshot())
+		AND ($2::uuid IS NULL OR disbursement_id = $2)
+		AND ($3::uuid IS NULL OR merchant_id = $3)
+	ORDER BY
+		transaction_id, id
+	LIMIT $4`
+
+	querySelectLastDisbursementStatusEventId = `
+	SELECT
+		COALESCE((
+			SELECT
+				id
+			FROM
+				disbursement_status_event
+			WHERE
+				transaction_id < txid_snapshot_xmin(txid_current_snapshot())
+			ORDER BY
+				transaction_id DESC, id DESC
+			LIMIT 1
+		), 0)`
+)
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/mock"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_disbursementStatusEventRepository_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	merchantId := "merchant-1"
+	disbursementId := "disb-1"
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		filter         domain.DisbursementStatusEventFilter
+		disbursementId *string
+		scope          *string
+		limit          int
+	}{
+		{
+			name:   "every merchant with default limit",
+			ctx:    context.TODO(),
+			filter: domain.DisbursementStatusEventFilter{AfterId: 10},
+			limit:  defaultDisbursementStatusEventLimit,
+		},
+		{
+			name:           "scoped to the merchant of the caller",
+			ctx:            domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", MerchantId: merchantId}),
+			filter:         domain.DisbursementStatusEventFilter{DisbursementId: "disb-1", AfterId: 10, Limit: 5},
+			disbursementId: &disbursementId,
+			scope:          &merchantId,
+			limit:          5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), `
+	SELECT
+		*
+	FROM
+		disbursement_status_event
+	WHERE
+		(transaction_id, id) > (COALESCE((SELECT transaction_id FROM disbursement_status_event WHERE id = $1), 0), $1)
+		AND transaction_id < txid_snapshot_xmin(txid_current_snapshot())
+		AND ($2::uuid IS NULL OR disbursement_id = $2)
+		AND ($3::uuid IS NULL OR merchant_id = $3)
+	ORDER BY
+		transaction_id, id
+	LIMIT $4`, int64(10), tt.disbursementId, tt.scope, tt.limit).DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+				*dest.(*[]model.DisbursementStatusEvent) = []model.DisbursementStatusEvent{
+					{
+						Id:             11,
+						DisbursementId: "disb-1",
+						MerchantId:     &merchantId,
+						Status:         domain.DisbursementStatusCompleted.ToInt(),
+						PreviousStatus: domain.DisbursementStatusPending.ToInt(),
+						Version:        2,
+						CreatedAt:      createdAt,
+					},
+				}
+				return nil
+			})
+
+			dse := NewDisbursementStatusEvent(DisbursementStatusEventDeps{DB: mockDB})
+			got, err := dse.List(tt.ctx, tt.filter)
+			assert.Nil(t, err)
+			assert.Equal(t, []domain.DisbursementStatusEvent{
+				{
+					Id:             11,
+					DisbursementId: "disb-1",
+					MerchantId:     merchantId,
+					Status:         domain.DisbursementStatusCompleted,
+					PreviousStatus: domain.DisbursementStatusPending,
+					Version:        2,
+					CreatedAt:      createdAt,
+				},
+			}, got)
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package repository
 
+// queries on disbursement are scoped to the caller merchant by the last parameter, see merchantScope
+
 const (
 	queryInsertDisbursement = `
 	INSERT INTO
@@ -11,11 +13,17 @@ const (
 		 bank_transaction_id, 
 		 amount,
 		 status,
+		 encryption_key_id,
+		 encrypted_data_key,
+		 recipient_account_number_bidx,
+		 merchant_id,
+		 name_match_score,
+		 name_match_decision,
 		 created_at,
 		 updated_at
 		 )
 	VALUES
-		($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 	RETURNING
 		id`
 
@@ -29,9 +37,34 @@ const (
 		bank_transaction_id = $4, 
 		amount = $5,
 		status = $6,
+		encryption_key_id = $9,
+		encrypted_data_key = $10,
+		recipient_account_number_bidx = $11,
+		bank_evidence_reference = $13,
+		version = version + 1,
 		updated_at = CURRENT_TIMESTAMP
 	WHERE
-		id = $7`
+		id = $7
+		AND version = $8
+		AND ($12::uuid IS NULL OR merchant_id = $12)`
+
+	querySelectVersionById = `
+	SELECT
+		version
+	FROM
+		disbursement
+	WHERE
+		id = $1
+		AND ($2::uuid IS NULL OR merchant_id = $2)`
+
+	querySelectDisbursementById = `
+	SELECT
+		*
+	FROM
+		disbursement
+	WHERE
+		id = $1
+		AND ($2::uuid IS NULL OR merchant_id = $2)`
 
 	querySelectByBankTransactionId = `
 	SELECT
@@ -39,5 +72,59 @@ const (
 	FROM
 		disbursement
 	WHERE
-		bank_transaction_id = $1`
+		bank_transaction_id = $1
+		AND ($2::uuid IS NULL OR merchant_id = $2)`
+
+	// row written before encryption enabled has no blind index yet
+	querySelectByRecipientAccountNumber = `
+	SELECT
+		*
+	FROM
+		disbursement
+	WHERE
+		(recipient_account_number_bidx = $1
+			OR (encryption_key_id IS NULL AND recipient_account_number = $2))
+		AND ($3::uuid IS NULL OR merchant_id = $3)
+	ORDER BY
+		created_at`
+
+	querySelectNeedReEncryption = `
+	SELECT
+		*
+	FROM
+		disbursement
+	WHERE
+		(encryption_key_id IS NULL OR encryption_key_id <> $1)
+		AND ($3::uuid IS NULL OR merchant_id = $3)
+	ORDER BY
+		created_at
+	LIMIT $2
+	FOR UPDATE SKIP LOCKED`
+
+	// failed and rejected disbursement did not move any money
+	querySumAmountSince = `
+	SELECT
+		COALESCE(SUM(amount), 0)
+	FROM
+		disbursement
+	WHERE
+		created_at >= $1
+		AND status NOT IN ($2, $3)
+		AND ($4::uuid IS NULL OR merchant_id = $4)`
+
+	querySelectDisbursements = `
+	SELECT
+		*
+	FROM
+		disbursement
+	WHERE
+		($1 = 0 OR status = $1)
+		AND ($2 = '' OR recipient_bank_code = $2)
+		AND ($6 = '' OR name_match_decision = $6)
+		AND ($5::uuid IS NULL OR merchant_id = $5)
+	ORDER BY
+		created_at DESC,
+		id DESC
+	LIMIT $3
+	OFFSET $4`
 )
@@ -5,12 +5,14 @@ import (
 	"database/sql"
 	"errors"
 	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/crypto"
 	"github.com/nobbyphala/Brick/external/database"
 	"github.com/nobbyphala/Brick/mock"
 	"github.com/nobbyphala/Brick/usecase/repository/model"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"testing"
+	"time"
 )
 
 func TestNewDisbursement(t *testing.T) {
@@ -18,6 +20,7 @@ func TestNewDisbursement(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockDB := mock.NewMockSQLDatabase(ctrl)
+	encryptor := crypto.NewPlaintextEncryptor()
 
 	type args struct {
 		deps DisbursementDeps
@@ -30,10 +33,11 @@ func TestNewDisbursement(t *testing.T) {
 		{
 			name: "new disbursement repository",
 			args: args{
-				deps: DisbursementDeps{DB: mockDB},
+				deps: DisbursementDeps{DB: mockDB, Encryptor: encryptor},
 			},
 			want: &disbursementRepository{
-				db: mockDB,
+				db:        mockDB,
+				encryptor: encryptor,
 			},
 		},
 	}
@@ -92,7 +96,8 @@ func Test_disbursementRepository_GetByTransactionId(t *testing.T) {
 	FROM
 		disbursement
 	WHERE
-		bank_transaction_id = $1`, "txn-id-1").DoAndReturn(func(
+		bank_transaction_id = $1
+		AND ($2::uuid IS NULL OR merchant_id = $2)`, "txn-id-1", (*string)(nil)).DoAndReturn(func(
 					ctx context.Context,
 					dest interface{},
 					query string,
@@ -133,7 +138,8 @@ func Test_disbursementRepository_GetByTransactionId(t *testing.T) {
 	FROM
 		disbursement
 	WHERE
-		bank_transaction_id = $1`, "txn-id-1").Return(sql.ErrNoRows)
+		bank_transaction_id = $1
+		AND ($2::uuid IS NULL OR merchant_id = $2)`, "txn-id-1", (*string)(nil)).Return(sql.ErrNoRows)
 			},
 		},
 		{
@@ -154,7 +160,8 @@ func Test_disbursementRepository_GetByTransactionId(t *testing.T) {
 	FROM
 		disbursement
 	WHERE
-		bank_transaction_id = $1`, "txn-id-1").Return(errors.New("sql error"))
+		bank_transaction_id = $1
+		AND ($2::uuid IS NULL OR merchant_id = $2)`, "txn-id-1", (*string)(nil)).Return(errors.New("sql error"))
 			},
 		},
 	}
@@ -162,7 +169,8 @@ func Test_disbursementRepository_GetByTransactionId(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
 			disb := disbursementRepository{
-				db: tt.fields.db,
+				db:        tt.fields.db,
+				encryptor: crypto.NewPlaintextEncryptor(),
 			}
 			got, err := disb.GetByTransactionId(tt.args.ctx, tt.args.bankTransactionId)
 			assert.Equal(t, tt.wantErr, err)
@@ -228,11 +236,17 @@ func Test_disbursementRepository_Insert(t *testing.T) {
 		 bank_transaction_id, 
 		 amount,
 		 status,
+		 encryption_key_id,
+		 encrypted_data_key,
+		 recipient_account_number_bidx,
+		 merchant_id,
+		 name_match_score,
+		 name_match_decision,
 		 created_at,
 		 updated_at
 		 )
 	VALUES
-		($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 	RETURNING
 		id`, gomock.Any()).Return(mockRow)
 			},
@@ -270,11 +284,17 @@ func Test_disbursementRepository_Insert(t *testing.T) {
 		 bank_transaction_id, 
 		 amount,
 		 status,
+		 encryption_key_id,
+		 encrypted_data_key,
+		 recipient_account_number_bidx,
+		 merchant_id,
+		 name_match_score,
+		 name_match_decision,
 		 created_at,
 		 updated_at
 		 )
 	VALUES
-		($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 	RETURNING
 		id`, gomock.Any()).Return(mockRow)
 			},
@@ -284,7 +304,8 @@ func Test_disbursementRepository_Insert(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
 			disb := disbursementRepository{
-				db: tt.fields.db,
+				db:        tt.fields.db,
+				encryptor: crypto.NewPlaintextEncryptor(),
 			}
 			got, err := disb.Insert(tt.args.ctx, tt.args.disbursement)
 			assert.Equal(t, tt.wantErr, err)
@@ -304,9 +325,10 @@ func Test_disbursementRepository_UpdateById(t *testing.T) {
 		db database.SQLDatabase
 	}
 	type args struct {
-		ctx         context.Context
-		id          string
-		updatedData domain.Disbursement
+		ctx             context.Context
+		id              string
+		expectedVersion int64
+		updatedData     domain.Disbursement
 	}
 	tests := []struct {
 		name    string
@@ -321,8 +343,9 @@ func Test_disbursementRepository_UpdateById(t *testing.T) {
 				db: mockDB,
 			},
 			args: args{
-				ctx: context.TODO(),
-				id:  "disb-id-1",
+				ctx:             context.TODO(),
+				id:              "disb-id-1",
+				expectedVersion: 1,
 				updatedData: domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "6789567",
@@ -345,9 +368,16 @@ func Test_disbursementRepository_UpdateById(t *testing.T) {
 		bank_transaction_id = $4, 
 		amount = $5,
 		status = $6,
+		encryption_key_id = $9,
+		encrypted_data_key = $10,
+		recipient_account_number_bidx = $11,
+		bank_evidence_reference = $13,
+		version = version + 1,
 		updated_at = CURRENT_TIMESTAMP
 	WHERE
-		id = $7`, gomock.Any()).Return(mockResult, nil)
+		id = $7
+		AND version = $8
+		AND ($12::uuid IS NULL OR merchant_id = $12)`, gomock.Any()).Return(mockResult, nil)
 			},
 		},
 		{
@@ -356,8 +386,9 @@ func Test_disbursementRepository_UpdateById(t *testing.T) {
 				db: mockDB,
 			},
 			args: args{
-				ctx: context.TODO(),
-				id:  "disb-id-1",
+				ctx:             context.TODO(),
+				id:              "disb-id-1",
+				expectedVersion: 1,
 				updatedData: domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "6789567",
@@ -370,6 +401,14 @@ func Test_disbursementRepository_UpdateById(t *testing.T) {
 			wantErr: errors.New("error expected there row be affected but got none"),
 			mock: func() {
 				mockResult.EXPECT().RowsAffected().Return(int64(0), nil)
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), `
+	SELECT
+		version
+	FROM
+		disbursement
+	WHERE
+		id = $1
+		AND ($2::uuid IS NULL OR merchant_id = $2)`, "disb-id-1", (*string)(nil)).Return(sql.ErrNoRows)
 				mockDB.EXPECT().Exec(gomock.Any(), `
 	UPDATE
 		disbursement
@@ -380,9 +419,49 @@ func Test_disbursementRepository_UpdateById(t *testing.T) {
 		bank_transaction_id = $4, 
 		amount = $5,
 		status = $6,
+		encryption_key_id = $9,
+		encrypted_data_key = $10,
+		recipient_account_number_bidx = $11,
+		bank_evidence_reference = $13,
+		version = version + 1,
 		updated_at = CURRENT_TIMESTAMP
 	WHERE
-		id = $7`, gomock.Any()).Return(mockResult, nil)
+		id = $7
+		AND version = $8
+		AND ($12::uuid IS NULL OR merchant_id = $12)`, gomock.Any()).Return(mockResult, nil)
+			},
+		},
+		{
+			name: "stale version",
+			fields: fields{
+				db: mockDB,
+			},
+			args: args{
+				ctx:             context.TODO(),
+				id:              "disb-id-1",
+				expectedVersion: 1,
+				updatedData: domain.Disbursement{
+					RecipientName:          "Nobby Phala",
+					RecipientAccountNumber: "6789567",
+					RecipientBankCode:      "Bank A",
+					BankTransactionId:      "45678",
+					Amount:                 60000,
+					Status:                 2,
+				},
+			},
+			wantErr: errors.New("error record has been modified by another process"),
+			mock: func() {
+				mockResult.EXPECT().RowsAffected().Return(int64(0), nil)
+				mockDB.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResult, nil)
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), "disb-id-1").DoAndReturn(func(
+					ctx context.Context,
+					dest interface{},
+					query string,
+					args ...interface{},
+				) error {
+					*dest.(*int64) = 2
+					return nil
+				})
 			},
 		},
 		{
@@ -391,8 +470,9 @@ func Test_disbursementRepository_UpdateById(t *testing.T) {
 				db: mockDB,
 			},
 			args: args{
-				ctx: context.TODO(),
-				id:  "disb-id-1",
+				ctx:             context.TODO(),
+				id:              "disb-id-1",
+				expectedVersion: 1,
 				updatedData: domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "6789567",
@@ -415,9 +495,16 @@ func Test_disbursementRepository_UpdateById(t *testing.T) {
 		bank_transaction_id = $4, 
 		amount = $5,
 		status = $6,
+		encryption_key_id = $9,
+		encrypted_data_key = $10,
+		recipient_account_number_bidx = $11,
+		bank_evidence_reference = $13,
+		version = version + 1,
 		updated_at = CURRENT_TIMESTAMP
 	WHERE
-		id = $7`, gomock.Any()).Return(mockResult, nil)
+		id = $7
+		AND version = $8
+		AND ($12::uuid IS NULL OR merchant_id = $12)`, gomock.Any()).Return(mockResult, nil)
 			},
 		},
 		{
@@ -426,8 +513,9 @@ func Test_disbursementRepository_UpdateById(t *testing.T) {
 				db: mockDB,
 			},
 			args: args{
-				ctx: context.TODO(),
-				id:  "disb-id-1",
+				ctx:             context.TODO(),
+				id:              "disb-id-1",
+				expectedVersion: 1,
 				updatedData: domain.Disbursement{
 					RecipientName:          "Nobby Phala",
 					RecipientAccountNumber: "6789567",
@@ -449,9 +537,16 @@ func Test_disbursementRepository_UpdateById(t *testing.T) {
 		bank_transaction_id = $4, 
 		amount = $5,
 		status = $6,
+		encryption_key_id = $9,
+		encrypted_data_key = $10,
+		recipient_account_number_bidx = $11,
+		bank_evidence_reference = $13,
+		version = version + 1,
 		updated_at = CURRENT_TIMESTAMP
 	WHERE
-		id = $7`, gomock.Any()).Return(mockResult, errors.New("error exec query"))
+		id = $7
+		AND version = $8
+		AND ($12::uuid IS NULL OR merchant_id = $12)`, gomock.Any()).Return(mockResult, errors.New("error exec query"))
 			},
 		},
 	}
@@ -459,10 +554,106 @@ func Test_disbursementRepository_UpdateById(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
 			disb := disbursementRepository{
-				db: tt.fields.db,
+				db:        tt.fields.db,
+				encryptor: crypto.NewPlaintextEncryptor(),
 			}
-			err := disb.UpdateById(tt.args.ctx, tt.args.id, tt.args.updatedData)
+			err := disb.UpdateById(tt.args.ctx, tt.args.id, tt.args.expectedVersion, tt.args.updatedData)
 			assert.Equal(t, tt.wantErr, err)
 		})
 	}
 }
+
+func Test_disbursementRepository_SumAmountSince(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	merchantId := "merchant-1"
+	ctx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: merchantId})
+
+	mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), `
+	SELECT
+		COALESCE(SUM(amount), 0)
+	FROM
+		disbursement
+	WHERE
+		created_at >= $1
+		AND status NOT IN ($2, $3)
+		AND ($4::uuid IS NULL OR merchant_id = $4)`, since, 3, 4, &merchantId).DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+		*dest.(*int64) = 150000
+		return nil
+	})
+
+	disb := disbursementRepository{
+		db:        mockDB,
+		encryptor: crypto.NewPlaintextEncryptor(),
+	}
+	got, err := disb.SumAmountSince(ctx, since)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(150000), got)
+}
+
+func Test_disbursementRepository_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	merchantId := "merchant-1"
+	ctx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: merchantId})
+	nameMatchScore, nameMatchDecision := 72, "REVIEW"
+
+	mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), `
+	SELECT
+		*
+	FROM
+		disbursement
+	WHERE
+		($1 = 0 OR status = $1)
+		AND ($2 = '' OR recipient_bank_code = $2)
+		AND ($6 = '' OR name_match_decision = $6)
+		AND ($5::uuid IS NULL OR merchant_id = $5)
+	ORDER BY
+		created_at DESC,
+		id DESC
+	LIMIT $3
+	OFFSET $4`, 2, "014", 100, 20, &merchantId, "REVIEW").DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+		*dest.(*[]model.Disbursement) = []model.Disbursement{{
+			Id:                     "disb-id-1",
+			MerchantId:             &merchantId,
+			RecipientName:          "Nobby Phala",
+			RecipientAccountNumber: "6789567",
+			RecipientBankCode:      "014",
+			Amount:                 60000,
+			Status:                 2,
+			Version:                2,
+			NameMatchScore:         &nameMatchScore,
+			NameMatchDecision:      &nameMatchDecision,
+		}}
+		return nil
+	})
+
+	disb := disbursementRepository{
+		db:        mockDB,
+		encryptor: crypto.NewPlaintextEncryptor(),
+	}
+	got, err := disb.List(ctx, domain.DisbursementFilter{
+		Status:            domain.DisbursementStatusCompleted,
+		RecipientBankCode: "014",
+		NameMatchDecision: domain.NameMatchReview,
+		Offset:            20,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Disbursement{{
+		Id:                     "disb-id-1",
+		MerchantId:             "merchant-1",
+		RecipientName:          "Nobby Phala",
+		RecipientAccountNumber: "6789567",
+		RecipientBankCode:      "014",
+		Amount:                 60000,
+		Status:                 domain.DisbursementStatusCompleted,
+		Version:                2,
+		NameMatchScore:         72,
+		NameMatchDecision:      domain.NameMatchReview,
+	}}, got)
+}
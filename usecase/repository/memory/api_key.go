@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository"
+)
+
+const tableApiKey = "api_key"
+
+type apiKeyRepository struct {
+	store *Store
+	tx    *transaction
+}
+
+type ApiKeyDeps struct {
+	Store *Store
+}
+
+func NewApiKey(deps ApiKeyDeps) *apiKeyRepository {
+	// same as api_key_prefix_idx in the postgres schema
+	deps.Store.RegisterUniqueIndex(tableApiKey, UniqueIndex{
+		Name: "api_key_prefix_idx",
+		Key: func(value interface{}) string {
+			return value.(domain.ApiKey).Prefix
+		},
+	})
+
+	return &apiKeyRepository{
+		store: deps.Store,
+	}
+}
+
+func (ak apiKeyRepository) WithTx(Tx database.SQLDatabase) repository.ApiKey {
+	return apiKeyRepository{
+		store: ak.store,
+		tx:    txFrom(Tx),
+	}
+}
+
+func (ak apiKeyRepository) Insert(ctx context.Context, apiKey domain.ApiKey) (string, error) {
+	apiKey.Id = newId()
+	if apiKey.CreatedAt.IsZero() {
+		apiKey.CreatedAt = time.Now()
+	}
+	apiKey.LastUsedAt = nil
+	apiKey.RevokedAt = nil
+
+	err := run(ak.store, ak.tx, func(tx *transaction) error {
+		return tx.put(tableApiKey, apiKey.Id, apiKey)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return apiKey.Id, nil
+}
+
+func (ak apiKeyRepository) GetById(ctx context.Context, id string) (*domain.ApiKey, error) {
+	var res *domain.ApiKey
+
+	err := run(ak.store, ak.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableApiKey, id)
+		if exists {
+			apiKey := value.(domain.ApiKey)
+			res = &apiKey
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ak apiKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*domain.ApiKey, error) {
+	var res *domain.ApiKey
+
+	err := run(ak.store, ak.tx, func(tx *transaction) error {
+		tx.scan(tableApiKey, func(key string, value interface{}) bool {
+			apiKey := value.(domain.ApiKey)
+			if apiKey.Prefix != prefix {
+				return true
+			}
+
+			res = &apiKey
+			return false
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ak apiKeyRepository) ListByClientId(ctx context.Context, clientId string) ([]domain.ApiKey, error) {
+	res := []domain.ApiKey{}
+
+	err := run(ak.store, ak.tx, func(tx *transaction) error {
+		tx.scan(tableApiKey, func(key string, value interface{}) bool {
+			apiKey := value.(domain.ApiKey)
+			if apiKey.ClientId == clientId {
+				res = append(res, apiKey)
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].CreatedAt.Before(res[j].CreatedAt)
+	})
+
+	return res, nil
+}
+
+func (ak apiKeyRepository) UpdateById(ctx context.Context, id string, updatedData domain.ApiKey) error {
+	return ak.update(id, func(apiKey *domain.ApiKey) {
+		apiKey.Name = updatedData.Name
+		apiKey.Role = updatedData.Role
+		apiKey.ExpiresAt = updatedData.ExpiresAt
+		apiKey.RevokedAt = updatedData.RevokedAt
+	})
+}
+
+func (ak apiKeyRepository) UpdateLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) error {
+	return ak.update(id, func(apiKey *domain.ApiKey) {
+		apiKey.LastUsedAt = &lastUsedAt
+	})
+}
+
+func (ak apiKeyRepository) update(id string, fn func(apiKey *domain.ApiKey)) error {
+	return run(ak.store, ak.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableApiKey, id)
+		if !exists {
+			return internal_error.ErrNoRowsAffected
+		}
+
+		apiKey := value.(domain.ApiKey)
+		fn(&apiKey)
+
+		return tx.put(tableApiKey, id, apiKey)
+	})
+}
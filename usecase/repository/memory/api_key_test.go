@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_apiKeyRepository(t *testing.T) {
+	ctx := context.TODO()
+	ak := NewApiKey(ApiKeyDeps{Store: NewStore()})
+
+	id, err := ak.Insert(ctx, domain.ApiKey{
+		ClientId: "client-1",
+		Name:     "payroll",
+		Role:     domain.RoleClient,
+		Prefix:   "prefix-1",
+		Hash:     "hash-1",
+	})
+	assert.NoError(t, err)
+
+	_, err = ak.Insert(ctx, domain.ApiKey{ClientId: "client-2", Prefix: "prefix-1"})
+	assert.True(t, database.IsUniqueViolation(err))
+
+	got, err := ak.GetByPrefix(ctx, "prefix-1")
+	assert.NoError(t, err)
+	assert.Equal(t, id, got.Id)
+	assert.Equal(t, "hash-1", got.Hash)
+
+	got, err = ak.GetByPrefix(ctx, "prefix-unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	revokedAt := time.Now()
+	updated := domain.ApiKey{Name: "payroll", Role: domain.RoleClient, RevokedAt: &revokedAt}
+	assert.NoError(t, ak.UpdateById(ctx, id, updated))
+	assert.NoError(t, ak.UpdateLastUsedAt(ctx, id, revokedAt))
+	assert.Equal(t, internal_error.ErrNoRowsAffected, ak.UpdateLastUsedAt(ctx, "unknown", revokedAt))
+
+	got, err = ak.GetById(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, &revokedAt, got.RevokedAt)
+	assert.Equal(t, &revokedAt, got.LastUsedAt)
+
+	list, err := ak.ListByClientId(ctx, "client-1")
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository"
+)
+
+const (
+	tableAuditLog = "audit_log"
+
+	// same as the postgres repository
+	defaultAuditLogLimit = 100
+)
+
+type auditLogRepository struct {
+	store *Store
+	tx    *transaction
+}
+
+type AuditLogDeps struct {
+	Store *Store
+}
+
+func NewAuditLog(deps AuditLogDeps) *auditLogRepository {
+	return &auditLogRepository{
+		store: deps.Store,
+	}
+}
+
+func (al auditLogRepository) WithTx(Tx database.SQLDatabase) repository.AuditLog {
+	return auditLogRepository{
+		store: al.store,
+		tx:    txFrom(Tx),
+	}
+}
+
+func (al auditLogRepository) Insert(ctx context.Context, auditLog domain.AuditLog) (string, error) {
+	auditLog.Id = newId()
+	auditLog.CreatedAt = time.Now()
+
+	err := run(al.store, al.tx, func(tx *transaction) error {
+		return tx.put(tableAuditLog, auditLog.Id, auditLog)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return auditLog.Id, nil
+}
+
+func (al auditLogRepository) List(ctx context.Context, filter domain.AuditLogFilter) ([]domain.AuditLog, error) {
+	res := []domain.AuditLog{}
+
+	err := run(al.store, al.tx, func(tx *transaction) error {
+		tx.scan(tableAuditLog, func(key string, value interface{}) bool {
+			auditLog := value.(domain.AuditLog)
+			if (filter.ActorId == "" || auditLog.ActorId == filter.ActorId) &&
+				(filter.Action == "" || auditLog.Action == filter.Action) &&
+				(filter.Outcome == "" || auditLog.Outcome == filter.Outcome) {
+				res = append(res, auditLog)
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].CreatedAt.After(res[j].CreatedAt)
+	})
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLogLimit
+	}
+
+	if len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_auditLogRepository_List(t *testing.T) {
+	ctx := context.TODO()
+	al := NewAuditLog(AuditLogDeps{Store: NewStore()})
+
+	for _, outcome := range []domain.AuditOutcome{domain.AuditOutcomeAllowed, domain.AuditOutcomeDenied, domain.AuditOutcomeDenied} {
+		_, err := al.Insert(ctx, domain.AuditLog{ActorId: "client-1", Action: "disbursement.create", Outcome: outcome})
+		assert.NoError(t, err)
+	}
+
+	got, err := al.List(ctx, domain.AuditLogFilter{Outcome: domain.AuditOutcomeDenied})
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	got, err = al.List(ctx, domain.AuditLogFilter{ActorId: "client-1", Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	got, err = al.List(ctx, domain.AuditLogFilter{ActorId: "client-2"})
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+)
+
+const (
+	tableBeneficiary = "beneficiary"
+
+	// same as the postgres repository
+	defaultBeneficiaryLimit = 100
+)
+
+type beneficiaryRepository struct {
+	store *Store
+}
+
+type BeneficiaryDeps struct {
+	Store *Store
+}
+
+func NewBeneficiary(deps BeneficiaryDeps) *beneficiaryRepository {
+	return &beneficiaryRepository{
+		store: deps.Store,
+	}
+}
+
+func (br beneficiaryRepository) Insert(ctx context.Context, beneficiary domain.Beneficiary) (string, error) {
+	beneficiary.Id = newId()
+	beneficiary.CreatedAt = time.Now()
+	beneficiary.UpdatedAt = beneficiary.CreatedAt
+
+	err := run(br.store, nil, func(tx *transaction) error {
+		return tx.put(tableBeneficiary, beneficiary.Id, beneficiary)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return beneficiary.Id, nil
+}
+
+func (br beneficiaryRepository) GetById(ctx context.Context, id string) (*domain.Beneficiary, error) {
+	var res *domain.Beneficiary
+
+	err := run(br.store, nil, func(tx *transaction) error {
+		value, exists := tx.get(tableBeneficiary, id)
+		if exists && beneficiaryInMerchantScope(ctx, value.(domain.Beneficiary)) {
+			beneficiary := value.(domain.Beneficiary)
+			res = &beneficiary
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (br beneficiaryRepository) List(ctx context.Context, filter domain.BeneficiaryFilter) ([]domain.Beneficiary, error) {
+	var rows []domain.Beneficiary
+
+	err := run(br.store, nil, func(tx *transaction) error {
+		tx.scan(tableBeneficiary, func(key string, value interface{}) bool {
+			beneficiary := value.(domain.Beneficiary)
+			if beneficiaryInMerchantScope(ctx, beneficiary) && (filter.BankCode == "" || beneficiary.BankCode == filter.BankCode) {
+				rows = append(rows, beneficiary)
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].CreatedAt.After(rows[j].CreatedAt)
+	})
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultBeneficiaryLimit
+	}
+
+	res := []domain.Beneficiary{}
+	for i := filter.Offset; i < len(rows) && len(res) < limit; i++ {
+		res = append(res, rows[i])
+	}
+
+	return res, nil
+}
+
+func (br beneficiaryRepository) UpdateById(ctx context.Context, id string, updatedData domain.Beneficiary) error {
+	return run(br.store, nil, func(tx *transaction) error {
+		value, exists := tx.get(tableBeneficiary, id)
+		if !exists || !beneficiaryInMerchantScope(ctx, value.(domain.Beneficiary)) {
+			return internal_error.ErrNoRowsAffected
+		}
+
+		beneficiary := value.(domain.Beneficiary)
+		beneficiary.Name = updatedData.Name
+		beneficiary.AccountNumber = updatedData.AccountNumber
+		beneficiary.BankCode = updatedData.BankCode
+		beneficiary.Verification = updatedData.Verification
+		beneficiary.UpdatedAt = time.Now()
+
+		return tx.put(tableBeneficiary, id, beneficiary)
+	})
+}
+
+func (br beneficiaryRepository) DeleteById(ctx context.Context, id string) error {
+	return run(br.store, nil, func(tx *transaction) error {
+		value, exists := tx.get(tableBeneficiary, id)
+		if !exists || !beneficiaryInMerchantScope(ctx, value.(domain.Beneficiary)) {
+			return internal_error.ErrNoRowsAffected
+		}
+
+		return tx.delete(tableBeneficiary, id)
+	})
+}
+
+// beneficiaryInMerchantScope return false when the caller in ctx belong to another merchant
+func beneficiaryInMerchantScope(ctx context.Context, beneficiary domain.Beneficiary) bool {
+	merchantId, scoped := domain.MerchantScopeFromContext(ctx)
+	return !scoped || beneficiary.MerchantId == merchantId
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_beneficiaryRepository(t *testing.T) {
+	br := NewBeneficiary(BeneficiaryDeps{Store: NewStore()})
+	merchantCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: "merchant-1"})
+	otherMerchantCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-2", Role: domain.RoleClient, MerchantId: "merchant-2"})
+
+	beneficiaryId, err := br.Insert(merchantCtx, domain.Beneficiary{MerchantId: "merchant-1", Name: "Nobby Phala", AccountNumber: "6789567", BankCode: "014"})
+	assert.NoError(t, err)
+
+	_, err = br.Insert(otherMerchantCtx, domain.Beneficiary{MerchantId: "merchant-2", Name: "John Doe", AccountNumber: "1234567", BankCode: "009"})
+	assert.NoError(t, err)
+
+	err = br.UpdateById(merchantCtx, beneficiaryId, domain.Beneficiary{
+		Name:          "Nobby Phala Putra",
+		AccountNumber: "6789567",
+		BankCode:      "014",
+		Verification:  domain.BeneficiaryVerification{Status: domain.BeneficiaryAccountBlocked},
+	})
+	assert.NoError(t, err)
+
+	beneficiaries, err := br.List(merchantCtx, domain.BeneficiaryFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, beneficiaries, 1)
+	assert.Equal(t, "Nobby Phala Putra", beneficiaries[0].Name)
+	assert.Equal(t, domain.BeneficiaryAccountBlocked, beneficiaries[0].Verification.Status)
+
+	// caller without merchant see every merchant
+	beneficiaries, err = br.List(context.TODO(), domain.BeneficiaryFilter{BankCode: "009"})
+	assert.NoError(t, err)
+	assert.Len(t, beneficiaries, 1)
+
+	got, err := br.GetById(otherMerchantCtx, beneficiaryId)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	err = br.DeleteById(otherMerchantCtx, beneficiaryId)
+	assert.Equal(t, internal_error.ErrNoRowsAffected, err)
+
+	err = br.DeleteById(merchantCtx, beneficiaryId)
+	assert.NoError(t, err)
+
+	got, err = br.GetById(merchantCtx, beneficiaryId)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
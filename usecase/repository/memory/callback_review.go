@@ -0,0 +1,251 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository"
+)
+
+const (
+	tableCallbackReview        = "callback_review"
+	tableCallbackReviewComment = "callback_review_comment"
+
+	// same as the postgres repository
+	defaultCallbackReviewLimit = 100
+)
+
+type callbackReviewRepository struct {
+	store *Store
+	tx    *transaction
+}
+
+type CallbackReviewDeps struct {
+	Store *Store
+}
+
+func NewCallbackReview(deps CallbackReviewDeps) *callbackReviewRepository {
+	// same as callback_review_open_transaction_id_reason_idx in the postgres schema
+	deps.Store.RegisterUniqueIndex(tableCallbackReview, UniqueIndex{
+		Name: "callback_review_open_transaction_id_reason_idx",
+		Key: func(value interface{}) string {
+			review := value.(domain.CallbackReview)
+			if !review.IsOpen() {
+				return ""
+			}
+
+			return review.TransactionId + "/" + string(review.Reason)
+		},
+	})
+
+	return &callbackReviewRepository{
+		store: deps.Store,
+	}
+}
+
+func (cr callbackReviewRepository) WithTx(Tx database.SQLDatabase) repository.CallbackReview {
+	return callbackReviewRepository{
+		store: cr.store,
+		tx:    txFrom(Tx),
+	}
+}
+
+func (cr callbackReviewRepository) Insert(ctx context.Context, review domain.CallbackReview) (string, error) {
+	review.Id = newId()
+	review.Payload = append([]byte(nil), review.Payload...)
+	review.CreatedAt = time.Now()
+	review.UpdatedAt = review.CreatedAt
+
+	err := run(cr.store, cr.tx, func(tx *transaction) error {
+		return tx.put(tableCallbackReview, review.Id, review)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return review.Id, nil
+}
+
+func (cr callbackReviewRepository) UpdateById(ctx context.Context, id string, updatedData domain.CallbackReview) error {
+	return run(cr.store, cr.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableCallbackReview, id)
+		if !exists || !value.(domain.CallbackReview).IsOpen() {
+			return internal_error.ErrNoRowsAffected
+		}
+
+		review := value.(domain.CallbackReview)
+		review.AssigneeId = updatedData.AssigneeId
+		review.Status = updatedData.Status
+		review.ResolvedBy = updatedData.ResolvedBy
+		review.ResolutionNote = updatedData.ResolutionNote
+		review.ResolvedAt = updatedData.ResolvedAt
+		review.UpdatedAt = time.Now()
+
+		return tx.put(tableCallbackReview, id, review)
+	})
+}
+
+func (cr callbackReviewRepository) GetById(ctx context.Context, id string) (*domain.CallbackReview, error) {
+	var res *domain.CallbackReview
+
+	err := run(cr.store, cr.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableCallbackReview, id)
+		if exists {
+			review := value.(domain.CallbackReview)
+			res = &review
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (cr callbackReviewRepository) GetOpen(ctx context.Context, transactionId string, reason domain.CallbackReviewReason) (*domain.CallbackReview, error) {
+	var res *domain.CallbackReview
+
+	err := run(cr.store, cr.tx, func(tx *transaction) error {
+		tx.scan(tableCallbackReview, func(key string, value interface{}) bool {
+			review := value.(domain.CallbackReview)
+			if review.IsOpen() && review.TransactionId == transactionId && review.Reason == reason {
+				res = &review
+				return false
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (cr callbackReviewRepository) List(ctx context.Context, filter domain.CallbackReviewFilter) ([]domain.CallbackReview, error) {
+	res := []domain.CallbackReview{}
+
+	err := run(cr.store, cr.tx, func(tx *transaction) error {
+		tx.scan(tableCallbackReview, func(key string, value interface{}) bool {
+			review := value.(domain.CallbackReview)
+			if (filter.Status == "" || review.Status == filter.Status) &&
+				(filter.Reason == "" || review.Reason == filter.Reason) &&
+				(filter.AssigneeId == "" || review.AssigneeId == filter.AssigneeId) {
+				res = append(res, review)
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].CreatedAt.Before(res[j].CreatedAt)
+	})
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultCallbackReviewLimit
+	}
+
+	if len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+func (cr callbackReviewRepository) CountOpen(ctx context.Context) ([]domain.CallbackReviewCount, error) {
+	counts := make(map[domain.CallbackReviewReason]*domain.CallbackReviewCount)
+
+	err := run(cr.store, cr.tx, func(tx *transaction) error {
+		tx.scan(tableCallbackReview, func(key string, value interface{}) bool {
+			review := value.(domain.CallbackReview)
+			if !review.IsOpen() {
+				return true
+			}
+
+			count, exists := counts[review.Reason]
+			if !exists {
+				count = &domain.CallbackReviewCount{Reason: review.Reason}
+				counts[review.Reason] = count
+			}
+
+			count.Open++
+			if review.AssigneeId == "" {
+				count.Unassigned++
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.CallbackReviewCount, 0, len(counts))
+	for _, count := range counts {
+		res = append(res, *count)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Reason < res[j].Reason
+	})
+
+	return res, nil
+}
+
+func (cr callbackReviewRepository) InsertComment(ctx context.Context, comment domain.CallbackReviewComment) (string, error) {
+	comment.Id = newId()
+	comment.CreatedAt = time.Now()
+
+	err := run(cr.store, cr.tx, func(tx *transaction) error {
+		return tx.put(tableCallbackReviewComment, comment.Id, comment)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return comment.Id, nil
+}
+
+func (cr callbackReviewRepository) ListComments(ctx context.Context, reviewId string) ([]domain.CallbackReviewComment, error) {
+	res := []domain.CallbackReviewComment{}
+
+	err := run(cr.store, cr.tx, func(tx *transaction) error {
+		tx.scan(tableCallbackReviewComment, func(key string, value interface{}) bool {
+			comment := value.(domain.CallbackReviewComment)
+			if comment.ReviewId == reviewId {
+				res = append(res, comment)
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].CreatedAt.Before(res[j].CreatedAt)
+	})
+
+	return res, nil
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_callbackReviewRepository(t *testing.T) {
+	ctx := context.TODO()
+	cr := NewCallbackReview(CallbackReviewDeps{Store: NewStore()})
+
+	notFoundId, err := cr.Insert(ctx, domain.CallbackReview{
+		TransactionId: "txn-id-1",
+		Reason:        domain.CallbackReviewReasonDisbursementNotFound,
+		Payload:       []byte(`{"transaction_id":"txn-id-1","status":"COMPLETED"}`),
+		Status:        domain.CallbackReviewStatusOpen,
+	})
+	assert.NoError(t, err)
+
+	_, err = cr.Insert(ctx, domain.CallbackReview{
+		TransactionId: "txn-id-2",
+		Reason:        domain.CallbackReviewReasonInvalidTransition,
+		Status:        domain.CallbackReviewStatusOpen,
+		AssigneeId:    "ops-1",
+	})
+	assert.NoError(t, err)
+
+	// one open review per transaction and reason
+	_, err = cr.Insert(ctx, domain.CallbackReview{
+		TransactionId: "txn-id-1",
+		Reason:        domain.CallbackReviewReasonDisbursementNotFound,
+		Status:        domain.CallbackReviewStatusOpen,
+	})
+	assert.ErrorIs(t, err, database.ErrUniqueViolation)
+
+	open, err := cr.GetOpen(ctx, "txn-id-1", domain.CallbackReviewReasonDisbursementNotFound)
+	assert.NoError(t, err)
+	assert.Equal(t, notFoundId, open.Id)
+
+	counts, err := cr.CountOpen(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.CallbackReviewCount{
+		{Reason: domain.CallbackReviewReasonDisbursementNotFound, Open: 1, Unassigned: 1},
+		{Reason: domain.CallbackReviewReasonInvalidTransition, Open: 1, Unassigned: 0},
+	}, counts)
+
+	err = cr.UpdateById(ctx, notFoundId, domain.CallbackReview{Status: domain.CallbackReviewStatusDiscarded, ResolvedBy: "ops-1"})
+	assert.NoError(t, err)
+
+	// resolved review cannot be changed
+	err = cr.UpdateById(ctx, notFoundId, domain.CallbackReview{Status: domain.CallbackReviewStatusOpen})
+	assert.Equal(t, internal_error.ErrNoRowsAffected, err)
+
+	open, err = cr.GetOpen(ctx, "txn-id-1", domain.CallbackReviewReasonDisbursementNotFound)
+	assert.NoError(t, err)
+	assert.Nil(t, open)
+
+	got, err := cr.List(ctx, domain.CallbackReviewFilter{Status: domain.CallbackReviewStatusOpen})
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, "txn-id-2", got[0].TransactionId)
+
+	_, err = cr.InsertComment(ctx, domain.CallbackReviewComment{ReviewId: notFoundId, AuthorId: "ops-1", Body: "duplicate callback"})
+	assert.NoError(t, err)
+
+	comments, err := cr.ListComments(ctx, notFoundId)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "duplicate callback", comments[0].Body)
+}
@@ -0,0 +1,308 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+)
+
+const (
+	tableDisbursement = "disbursement"
+
+	defaultDisbursementLimit = 100
+)
+
+type disbursementRepository struct {
+	store *Store
+	tx    *transaction
+}
+
+type DisbursementDeps struct {
+	Store *Store
+}
+
+func NewDisbursement(deps DisbursementDeps) *disbursementRepository {
+	// same as disbursement_bank_transaction_id_idx in the postgres schema
+	deps.Store.RegisterUniqueIndex(tableDisbursement, UniqueIndex{
+		Name: "disbursement_bank_transaction_id_idx",
+		Key: func(value interface{}) string {
+			return value.(model.Disbursement).BankTransactionId
+		},
+	})
+
+	return &disbursementRepository{
+		store: deps.Store,
+	}
+}
+
+func (disb disbursementRepository) WithTx(Tx database.SQLDatabase) repository.Disbursement {
+	return disbursementRepository{
+		store: disb.store,
+		tx:    txFrom(Tx),
+	}
+}
+
+func (disb disbursementRepository) Insert(ctx context.Context, disbursement domain.Disbursement) (string, error) {
+	id := newId()
+	now := time.Now()
+
+	var nameMatchScore *int
+	if disbursement.NameMatchDecision != "" {
+		nameMatchScore = &disbursement.NameMatchScore
+	}
+
+	err := run(disb.store, disb.tx, func(tx *transaction) error {
+		return tx.put(tableDisbursement, id, model.Disbursement{
+			Id:                     id,
+			MerchantId:             nullableString(disbursement.MerchantId),
+			RecipientName:          disbursement.RecipientName,
+			RecipientAccountNumber: disbursement.RecipientAccountNumber,
+			RecipientBankCode:      disbursement.RecipientBankCode,
+			BankTransactionId:      disbursement.BankTransactionId,
+			Amount:                 disbursement.Amount,
+			Status:                 disbursement.Status.ToInt(),
+			NameMatchScore:         nameMatchScore,
+			NameMatchDecision:      nullableString(string(disbursement.NameMatchDecision)),
+			Version:                1,
+			CreatedAt:              now,
+			UpdatedAt:              now,
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (disb disbursementRepository) UpdateById(ctx context.Context, id string, expectedVersion int64, updatedData domain.Disbursement) error {
+	return run(disb.store, disb.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableDisbursement, id)
+		if !exists || !inMerchantScope(ctx, value.(model.Disbursement)) {
+			return internal_error.ErrNoRowsAffected
+		}
+
+		existing := value.(model.Disbursement)
+		if existing.Version != expectedVersion {
+			return internal_error.ErrVersionConflict
+		}
+
+		previousStatus := existing.Status
+
+		existing.RecipientName = updatedData.RecipientName
+		existing.RecipientAccountNumber = updatedData.RecipientAccountNumber
+		existing.RecipientBankCode = updatedData.RecipientBankCode
+		existing.BankTransactionId = updatedData.BankTransactionId
+		existing.Amount = updatedData.Amount
+		existing.Status = updatedData.Status.ToInt()
+		existing.BankEvidenceReference = nullableString(updatedData.BankEvidenceReference)
+		existing.Version++
+		existing.UpdatedAt = time.Now()
+
+		err := tx.put(tableDisbursement, id, existing)
+		if err != nil {
+			return err
+		}
+
+		// same as disbursement_status_event_trigger in the postgres schema
+		if existing.Status == previousStatus {
+			return nil
+		}
+
+		return tx.insertSequenced(tableDisbursementStatusEvent, func(sequence int64) interface{} {
+			return model.DisbursementStatusEvent{
+				Id:             sequence,
+				DisbursementId: existing.Id,
+				MerchantId:     existing.MerchantId,
+				Status:         existing.Status,
+				PreviousStatus: previousStatus,
+				Version:        existing.Version,
+				CreatedAt:      existing.UpdatedAt,
+			}
+		})
+	})
+}
+
+func (disb disbursementRepository) GetById(ctx context.Context, id string) (*domain.Disbursement, error) {
+	var res *domain.Disbursement
+
+	err := run(disb.store, disb.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableDisbursement, id)
+		if exists && inMerchantScope(ctx, value.(model.Disbursement)) {
+			res = toDomainDisbursement(value.(model.Disbursement))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (disb disbursementRepository) GetByTransactionId(ctx context.Context, bankTransactionId string) (*domain.Disbursement, error) {
+	var res *domain.Disbursement
+
+	err := run(disb.store, disb.tx, func(tx *transaction) error {
+		tx.scan(tableDisbursement, func(key string, value interface{}) bool {
+			row := value.(model.Disbursement)
+			if row.BankTransactionId != bankTransactionId || !inMerchantScope(ctx, row) {
+				return true
+			}
+
+			res = toDomainDisbursement(row)
+			return false
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (disb disbursementRepository) GetByRecipientAccountNumber(ctx context.Context, accountNumber string) ([]domain.Disbursement, error) {
+	res := []domain.Disbursement{}
+
+	err := run(disb.store, disb.tx, func(tx *transaction) error {
+		tx.scan(tableDisbursement, func(key string, value interface{}) bool {
+			row := value.(model.Disbursement)
+			if row.RecipientAccountNumber == accountNumber && inMerchantScope(ctx, row) {
+				res = append(res, *toDomainDisbursement(row))
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ListNeedReEncryption always return nothing, the in-memory store never persist data so it is not encrypted
+func (disb disbursementRepository) ListNeedReEncryption(ctx context.Context, limit int) ([]domain.Disbursement, error) {
+	return nil, nil
+}
+
+func (disb disbursementRepository) SumAmountSince(ctx context.Context, since time.Time) (int64, error) {
+	var total int64
+
+	err := run(disb.store, disb.tx, func(tx *transaction) error {
+		tx.scan(tableDisbursement, func(key string, value interface{}) bool {
+			row := value.(model.Disbursement)
+			status := domain.DisbursementStatus(row.Status)
+
+			if inMerchantScope(ctx, row) && !row.CreatedAt.Before(since) &&
+				status != domain.DisbursementStatusFailed && status != domain.DisbursementStatusRejected {
+				total += row.Amount
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (disb disbursementRepository) List(ctx context.Context, filter domain.DisbursementFilter) ([]domain.Disbursement, error) {
+	var rows []model.Disbursement
+
+	err := run(disb.store, disb.tx, func(tx *transaction) error {
+		tx.scan(tableDisbursement, func(key string, value interface{}) bool {
+			row := value.(model.Disbursement)
+			if inMerchantScope(ctx, row) &&
+				(filter.Status == domain.DisbursementStatusUnknown || row.Status == filter.Status.ToInt()) &&
+				(filter.RecipientBankCode == "" || row.RecipientBankCode == filter.RecipientBankCode) &&
+				(filter.NameMatchDecision == "" || (row.NameMatchDecision != nil && *row.NameMatchDecision == string(filter.NameMatchDecision))) {
+				rows = append(rows, row)
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if !rows[i].CreatedAt.Equal(rows[j].CreatedAt) {
+			return rows[i].CreatedAt.After(rows[j].CreatedAt)
+		}
+
+		return rows[i].Id > rows[j].Id
+	})
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultDisbursementLimit
+	}
+
+	res := []domain.Disbursement{}
+	for i := filter.Offset; i < len(rows) && len(res) < limit; i++ {
+		res = append(res, *toDomainDisbursement(rows[i]))
+	}
+
+	return res, nil
+}
+
+// inMerchantScope return false when the caller in ctx belong to another merchant
+func inMerchantScope(ctx context.Context, row model.Disbursement) bool {
+	merchantId, scoped := domain.MerchantScopeFromContext(ctx)
+	return !scoped || (row.MerchantId != nil && *row.MerchantId == merchantId)
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func toDomainDisbursement(row model.Disbursement) *domain.Disbursement {
+	disbursement := &domain.Disbursement{
+		Id:                     row.Id,
+		RecipientName:          row.RecipientName,
+		RecipientAccountNumber: row.RecipientAccountNumber,
+		RecipientBankCode:      row.RecipientBankCode,
+		BankTransactionId:      row.BankTransactionId,
+		Amount:                 row.Amount,
+		Status:                 domain.DisbursementStatus(row.Status),
+		Version:                row.Version,
+	}
+
+	if row.MerchantId != nil {
+		disbursement.MerchantId = *row.MerchantId
+	}
+
+	if row.BankEvidenceReference != nil {
+		disbursement.BankEvidenceReference = *row.BankEvidenceReference
+	}
+
+	if row.NameMatchScore != nil && row.NameMatchDecision != nil {
+		disbursement.NameMatchScore = *row.NameMatchScore
+		disbursement.NameMatchDecision = domain.NameMatchDecision(*row.NameMatchDecision)
+	}
+
+	return disbursement
+}
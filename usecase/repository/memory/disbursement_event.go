@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+)
+
+// the events are inserted by the disbursement repository when UpdateById change the status
+const tableDisbursementStatusEvent = "disbursement_status_event"
+
+const defaultDisbursementStatusEventLimit = 100
+
+type disbursementStatusEventRepository struct {
+	store *Store
+}
+
+type DisbursementStatusEventDeps struct {
+	Store *Store
+}
+
+func NewDisbursementStatusEvent(deps DisbursementStatusEventDeps) *disbursementStatusEventRepository {
+	return &disbursementStatusEventRepository{
+		store: deps.Store,
+	}
+}
+
+func (dse disbursementStatusEventRepository) List(ctx context.Context, filter domain.DisbursementStatusEventFilter) ([]domain.DisbursementStatusEvent, error) {
+	res := []domain.DisbursementStatusEvent{}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultDisbursementStatusEventLimit
+	}
+
+	merchantId, scoped := domain.MerchantScopeFromContext(ctx)
+
+	err := run(dse.store, nil, func(tx *transaction) error {
+		tx.scan(tableDisbursementStatusEvent, func(key string, value interface{}) bool {
+			row := value.(model.DisbursementStatusEvent)
+			if row.Id <= filter.AfterId ||
+				(filter.DisbursementId != "" && row.DisbursementId != filter.DisbursementId) ||
+				(scoped && (row.MerchantId == nil || *row.MerchantId != merchantId)) {
+				return true
+			}
+
+			res = append(res, toDomainDisbursementStatusEvent(row))
+			return len(res) < limit
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (dse disbursementStatusEventRepository) LastId(ctx context.Context) (int64, error) {
+	return dse.store.lastSequence(tableDisbursementStatusEvent), nil
+}
+
+type disbursementStatusEventListener struct {
+	store *Store
+}
+
+type DisbursementStatusEventListenerDeps struct {
+	Store *Store
+}
+
+func NewDisbursementStatusEventListener(deps DisbursementStatusEventListenerDeps) *disbursementStatusEventListener {
+	return &disbursementStatusEventListener{
+		store: deps.Store,
+	}
+}
+
+func (dsl disbursementStatusEventListener) Listen(ctx context.Context) (<-chan struct{}, error) {
+	committed, stop := dsl.store.watch(tableDisbursementStatusEvent)
+
+	notifications := make(chan struct{}, 1)
+	go func() {
+		defer close(notifications)
+		defer stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-committed:
+				select {
+				case notifications <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return notifications, nil
+}
+
+func toDomainDisbursementStatusEvent(row model.DisbursementStatusEvent) domain.DisbursementStatusEvent {
+	event := domain.DisbursementStatusEvent{
+		Id:             row.Id,
+		DisbursementId: row.DisbursementId,
+		Status:         domain.DisbursementStatus(row.Status),
+		PreviousStatus: domain.DisbursementStatus(row.PreviousStatus),
+		Version:        row.Version,
+		CreatedAt:      row.CreatedAt,
+	}
+
+	if row.MerchantId != nil {
+		event.MerchantId = *row.MerchantId
+	}
+
+	return event
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_disbursementStatusEventRepository_List(t *testing.T) {
+	ctx := context.TODO()
+	store := NewStore()
+	disb := NewDisbursement(DisbursementDeps{Store: store})
+	events := NewDisbursementStatusEvent(DisbursementStatusEventDeps{Store: store})
+	ut := NewRepositoryUtils(UtilsOpts{Store: store})
+
+	disbursement := newTestDisbursement()
+	disbursement.MerchantId = "merchant-1"
+	id, err := disb.Insert(ctx, disbursement)
+	assert.NoError(t, err)
+
+	// status not changed
+	disbursement.BankEvidenceReference = "evidence-1"
+	assert.NoError(t, disb.UpdateById(ctx, id, 1, disbursement))
+
+	// rolled back
+	err = ut.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+		disbursement.Status = domain.DisbursementStatusFailed
+		assert.NoError(t, disb.WithTx(Tx).UpdateById(ctx, id, 2, disbursement))
+		return errors.New("handler error")
+	})
+	assert.Error(t, err)
+
+	disbursement.Status = domain.DisbursementStatusCompleted
+	assert.NoError(t, disb.UpdateById(ctx, id, 2, disbursement))
+
+	lastId, err := events.LastId(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), lastId)
+
+	got, err := events.List(ctx, domain.DisbursementStatusEventFilter{})
+	assert.NoError(t, err)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, int64(1), got[0].Id)
+		assert.Equal(t, id, got[0].DisbursementId)
+		assert.Equal(t, "merchant-1", got[0].MerchantId)
+		assert.Equal(t, domain.DisbursementStatusCompleted, got[0].Status)
+		assert.Equal(t, domain.DisbursementStatusPending, got[0].PreviousStatus)
+		assert.Equal(t, int64(3), got[0].Version)
+	}
+
+	got, err = events.List(ctx, domain.DisbursementStatusEventFilter{AfterId: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	otherMerchant := domain.ContextWithCaller(ctx, domain.Caller{Id: "client-2", MerchantId: "merchant-2"})
+	got, err = events.List(otherMerchant, domain.DisbursementStatusEventFilter{})
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func Test_disbursementStatusEventRepository_CommitOrder(t *testing.T) {
+	ctx := context.TODO()
+	store := NewStore()
+	disb := NewDisbursement(DisbursementDeps{Store: store})
+	events := NewDisbursementStatusEvent(DisbursementStatusEventDeps{Store: store})
+
+	first, err := disb.Insert(ctx, newTestDisbursement())
+	assert.NoError(t, err)
+
+	second := newTestDisbursement()
+	second.BankTransactionId = "txn-id-2"
+	secondId, err := disb.Insert(ctx, second)
+	assert.NoError(t, err)
+
+	// the transaction writing first commit last, its event must come last
+	firstTx := store.begin(false)
+	completed := newTestDisbursement()
+	completed.Status = domain.DisbursementStatusCompleted
+	assert.NoError(t, disb.WithTx(firstTx).UpdateById(ctx, first, 1, completed))
+
+	second.Status = domain.DisbursementStatusFailed
+	assert.NoError(t, disb.UpdateById(ctx, secondId, 1, second))
+	assert.NoError(t, firstTx.commit())
+
+	got, err := events.List(ctx, domain.DisbursementStatusEventFilter{})
+	assert.NoError(t, err)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, secondId, got[0].DisbursementId)
+		assert.Equal(t, first, got[1].DisbursementId)
+		assert.Equal(t, []int64{1, 2}, []int64{got[0].Id, got[1].Id})
+	}
+
+	got, err = events.List(ctx, domain.DisbursementStatusEventFilter{DisbursementId: first})
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+}
+
+func Test_disbursementStatusEventListener_Listen(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	store := NewStore()
+	disb := NewDisbursement(DisbursementDeps{Store: store})
+	listener := NewDisbursementStatusEventListener(DisbursementStatusEventListenerDeps{Store: store})
+
+	notifications, err := listener.Listen(ctx)
+	assert.NoError(t, err)
+
+	disbursement := newTestDisbursement()
+	id, err := disb.Insert(ctx, disbursement)
+	assert.NoError(t, err)
+
+	disbursement.Status = domain.DisbursementStatusCompleted
+	assert.NoError(t, disb.UpdateById(ctx, id, 1, disbursement))
+
+	_, ok := <-notifications
+	assert.True(t, ok, "should be notified after the status change committed")
+
+	cancel()
+	for range notifications {
+	}
+}
@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDisbursement() domain.Disbursement {
+	return domain.Disbursement{
+		RecipientName:          "Nobby Phala",
+		RecipientAccountNumber: "79823469",
+		RecipientBankCode:      "Bank A",
+		BankTransactionId:      "txn-id-1",
+		Amount:                 1000000,
+		Status:                 domain.DisbursementStatusPending,
+		NameMatchScore:         100,
+		NameMatchDecision:      domain.NameMatchAccepted,
+	}
+}
+
+func Test_disbursementRepository_InsertAndGet(t *testing.T) {
+	ctx := context.TODO()
+	disb := NewDisbursement(DisbursementDeps{Store: NewStore()})
+
+	id, err := disb.Insert(ctx, newTestDisbursement())
+	assert.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	got, err := disb.GetByTransactionId(ctx, "txn-id-1")
+	assert.NoError(t, err)
+
+	want := newTestDisbursement()
+	want.Id = id
+	want.Version = 1
+	assert.Equal(t, &want, got)
+
+	got, err = disb.GetByTransactionId(ctx, "txn-id-unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func Test_disbursementRepository_UniqueBankTransactionId(t *testing.T) {
+	ctx := context.TODO()
+	disb := NewDisbursement(DisbursementDeps{Store: NewStore()})
+
+	_, err := disb.Insert(ctx, newTestDisbursement())
+	assert.NoError(t, err)
+
+	_, err = disb.Insert(ctx, newTestDisbursement())
+	assert.True(t, database.IsUniqueViolation(err))
+}
+
+func Test_disbursementRepository_UpdateById(t *testing.T) {
+	ctx := context.TODO()
+	disb := NewDisbursement(DisbursementDeps{Store: NewStore()})
+
+	id, err := disb.Insert(ctx, newTestDisbursement())
+	assert.NoError(t, err)
+
+	updated := newTestDisbursement()
+	updated.Status = domain.DisbursementStatusCompleted
+
+	err = disb.UpdateById(ctx, id, 1, updated)
+	assert.NoError(t, err)
+
+	got, _ := disb.GetByTransactionId(ctx, "txn-id-1")
+	assert.Equal(t, domain.DisbursementStatusCompleted, got.Status)
+	assert.Equal(t, int64(2), got.Version)
+
+	err = disb.UpdateById(ctx, id, 1, updated)
+	assert.Equal(t, internal_error.ErrVersionConflict, err)
+
+	err = disb.UpdateById(ctx, "unknown-id", 1, updated)
+	assert.Equal(t, internal_error.ErrNoRowsAffected, err)
+}
+
+func Test_disbursementRepository_MerchantScope(t *testing.T) {
+	disb := NewDisbursement(DisbursementDeps{Store: NewStore()})
+	merchantCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-1", Role: domain.RoleClient, MerchantId: "merchant-1"})
+	otherMerchantCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "client-2", Role: domain.RoleClient, MerchantId: "merchant-2"})
+
+	disbursement := newTestDisbursement()
+	disbursement.MerchantId = "merchant-1"
+	id, err := disb.Insert(context.TODO(), disbursement)
+	assert.NoError(t, err)
+
+	failed := newTestDisbursement()
+	failed.MerchantId = "merchant-1"
+	failed.BankTransactionId = "txn-id-2"
+	failed.Status = domain.DisbursementStatusFailed
+	_, err = disb.Insert(context.TODO(), failed)
+	assert.NoError(t, err)
+
+	got, err := disb.GetById(merchantCtx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, "merchant-1", got.MerchantId)
+
+	got, err = disb.GetById(otherMerchantCtx, id)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	err = disb.UpdateById(otherMerchantCtx, id, 1, disbursement)
+	assert.Equal(t, internal_error.ErrNoRowsAffected, err)
+
+	total, err := disb.SumAmountSince(merchantCtx, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000000), total)
+
+	total, err = disb.SumAmountSince(otherMerchantCtx, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
+
+func Test_disbursementRepository_List(t *testing.T) {
+	ctx := context.TODO()
+	disb := NewDisbursement(DisbursementDeps{Store: NewStore()})
+
+	var ids []string
+	for i, bankCode := range []string{"Bank A", "Bank B", "Bank A"} {
+		disbursement := newTestDisbursement()
+		disbursement.BankTransactionId = "txn-id-" + string(rune('1'+i))
+		disbursement.RecipientBankCode = bankCode
+		if i == 1 {
+			disbursement.NameMatchScore = 70
+			disbursement.NameMatchDecision = domain.NameMatchReview
+		}
+
+		id, err := disb.Insert(ctx, disbursement)
+		assert.NoError(t, err)
+		ids = append(ids, id)
+
+		// created_at decide the order
+		time.Sleep(time.Millisecond)
+	}
+
+	got, err := disb.List(ctx, domain.DisbursementFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, got, 3)
+	assert.Equal(t, ids[2], got[0].Id)
+	assert.Equal(t, ids[0], got[2].Id)
+
+	got, err = disb.List(ctx, domain.DisbursementFilter{RecipientBankCode: "Bank A", Limit: 1, Offset: 1})
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, ids[0], got[0].Id)
+
+	got, err = disb.List(ctx, domain.DisbursementFilter{NameMatchDecision: domain.NameMatchReview})
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, ids[1], got[0].Id)
+	assert.Equal(t, 70, got[0].NameMatchScore)
+
+	got, err = disb.List(ctx, domain.DisbursementFilter{Status: domain.DisbursementStatusCompleted})
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository"
+)
+
+const tableMerchant = "merchant"
+
+type merchantRepository struct {
+	store *Store
+	tx    *transaction
+}
+
+type MerchantDeps struct {
+	Store *Store
+}
+
+func NewMerchant(deps MerchantDeps) *merchantRepository {
+	return &merchantRepository{
+		store: deps.Store,
+	}
+}
+
+func (mr merchantRepository) WithTx(Tx database.SQLDatabase) repository.Merchant {
+	return merchantRepository{
+		store: mr.store,
+		tx:    txFrom(Tx),
+	}
+}
+
+func (mr merchantRepository) Insert(ctx context.Context, merchant domain.Merchant) (string, error) {
+	merchant.Id = newId()
+	merchant.CreatedAt = time.Now()
+	merchant.Settings.AllowedBankCodes = append([]string(nil), merchant.Settings.AllowedBankCodes...)
+
+	err := run(mr.store, mr.tx, func(tx *transaction) error {
+		return tx.put(tableMerchant, merchant.Id, merchant)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return merchant.Id, nil
+}
+
+func (mr merchantRepository) GetById(ctx context.Context, id string) (*domain.Merchant, error) {
+	var res *domain.Merchant
+
+	err := run(mr.store, mr.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableMerchant, id)
+		if exists {
+			merchant := value.(domain.Merchant)
+			res = &merchant
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (mr merchantRepository) LockById(ctx context.Context, id string) error {
+	return run(mr.store, mr.tx, func(tx *transaction) error {
+		err := tx.lock(tableMerchant, id)
+		if err != nil {
+			return err
+		}
+
+		if _, exists := tx.get(tableMerchant, id); !exists {
+			return internal_error.ErrMerchantNotFound
+		}
+
+		return nil
+	})
+}
+
+func (mr merchantRepository) List(ctx context.Context) ([]domain.Merchant, error) {
+	res := []domain.Merchant{}
+
+	err := run(mr.store, mr.tx, func(tx *transaction) error {
+		tx.scan(tableMerchant, func(key string, value interface{}) bool {
+			res = append(res, value.(domain.Merchant))
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].CreatedAt.Before(res[j].CreatedAt)
+	})
+
+	return res, nil
+}
+
+func (mr merchantRepository) UpdateById(ctx context.Context, id string, updatedData domain.Merchant) error {
+	return run(mr.store, mr.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableMerchant, id)
+		if !exists {
+			return internal_error.ErrNoRowsAffected
+		}
+
+		merchant := value.(domain.Merchant)
+		merchant.Name = updatedData.Name
+		merchant.Settings = updatedData.Settings
+		merchant.Settings.AllowedBankCodes = append([]string(nil), updatedData.Settings.AllowedBankCodes...)
+
+		return tx.put(tableMerchant, id, merchant)
+	})
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_merchantRepository_LockById(t *testing.T) {
+	ctx := context.TODO()
+	store := NewStore()
+	mr := NewMerchant(MerchantDeps{Store: store})
+	ut := NewRepositoryUtils(UtilsOpts{Store: store})
+
+	merchantId, err := mr.Insert(ctx, domain.Merchant{Name: "Toko Nobby"})
+	assert.NoError(t, err)
+
+	t.Run("non existing merchant", func(t *testing.T) {
+		err := ut.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+			return mr.WithTx(Tx).LockById(ctx, "merchant-x")
+		})
+		assert.Equal(t, internal_error.ErrMerchantNotFound, err)
+	})
+
+	t.Run("second transaction wait for the lock and see the change of the first", func(t *testing.T) {
+		locked := make(chan struct{})
+		firstDone := make(chan error)
+
+		go func() {
+			firstDone <- ut.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+				err := mr.WithTx(Tx).LockById(ctx, merchantId)
+				if err != nil {
+					return err
+				}
+
+				close(locked)
+				time.Sleep(20 * time.Millisecond)
+				return mr.WithTx(Tx).UpdateById(ctx, merchantId, domain.Merchant{Name: "Toko Nobby Baru"})
+			})
+		}()
+
+		<-locked
+
+		var seen []string
+		err := ut.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+			err := mr.WithTx(Tx).LockById(ctx, merchantId)
+			if err != nil {
+				return err
+			}
+
+			merchant, _ := mr.WithTx(Tx).GetById(ctx, merchantId)
+			seen = append(seen, merchant.Name)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, <-firstDone)
+		assert.Equal(t, "Toko Nobby Baru", seen[len(seen)-1], "transaction should be retried with a snapshot after the first committed")
+	})
+}
@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+)
+
+const tableOutbox = "outbox"
+
+type outboxRepository struct {
+	store *Store
+	tx    *transaction
+}
+
+type OutboxDeps struct {
+	Store *Store
+}
+
+func NewOutbox(deps OutboxDeps) *outboxRepository {
+	return &outboxRepository{
+		store: deps.Store,
+	}
+}
+
+func (ob outboxRepository) WithTx(Tx database.SQLDatabase) repository.Outbox {
+	return outboxRepository{
+		store: ob.store,
+		tx:    txFrom(Tx),
+	}
+}
+
+func (ob outboxRepository) Insert(ctx context.Context, event domain.OutboxEvent) error {
+	return run(ob.store, ob.tx, func(tx *transaction) error {
+		// keyed by id like the bigserial column of the postgres schema
+		return tx.insertSequenced(tableOutbox, func(sequence int64) interface{} {
+			return model.OutboxEvent{
+				Id:            sequence,
+				EventType:     string(event.Type),
+				AggregateId:   event.AggregateId,
+				Payload:       event.Payload,
+				OccurredAt:    event.OccurredAt,
+				NextAttemptAt: event.OccurredAt,
+			}
+		})
+	})
+}
+
+func (ob outboxRepository) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]domain.OutboxEvent, error) {
+	res := []domain.OutboxEvent{}
+
+	err := run(ob.store, ob.tx, func(tx *transaction) error {
+		// aggregates having an older unpublished event
+		waiting := make(map[string]bool)
+
+		var claimed []model.OutboxEvent
+		tx.scan(tableOutbox, func(key string, value interface{}) bool {
+			row := value.(model.OutboxEvent)
+			if row.PublishedAt != nil {
+				return true
+			}
+
+			if !waiting[row.AggregateId] && !row.NextAttemptAt.After(now) {
+				row.NextAttemptAt = leaseUntil
+				claimed = append(claimed, row)
+			}
+
+			waiting[row.AggregateId] = true
+			return len(claimed) < limit
+		})
+
+		for _, row := range claimed {
+			err := tx.put(tableOutbox, sequenceKey(row.Id), row)
+			if err != nil {
+				return err
+			}
+
+			res = append(res, toDomainOutboxEvent(row))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ob outboxRepository) UpdateById(ctx context.Context, id int64, updatedData domain.OutboxEvent) error {
+	return run(ob.store, ob.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableOutbox, sequenceKey(id))
+		if !exists {
+			return internal_error.ErrNoRowsAffected
+		}
+
+		row := value.(model.OutboxEvent)
+		row.Attempts = updatedData.Attempts
+		row.NextAttemptAt = updatedData.NextAttemptAt
+		row.LastError = nullableString(updatedData.LastError)
+		row.PublishedAt = updatedData.PublishedAt
+
+		return tx.put(tableOutbox, sequenceKey(id), row)
+	})
+}
+
+func (ob outboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+
+	err := run(ob.store, ob.tx, func(tx *transaction) error {
+		var keys []string
+		tx.scan(tableOutbox, func(key string, value interface{}) bool {
+			row := value.(model.OutboxEvent)
+			if row.PublishedAt != nil && row.PublishedAt.Before(before) {
+				keys = append(keys, key)
+			}
+
+			return true
+		})
+
+		for _, key := range keys {
+			err := tx.delete(tableOutbox, key)
+			if err != nil {
+				return err
+			}
+		}
+
+		deleted = int64(len(keys))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+func toDomainOutboxEvent(row model.OutboxEvent) domain.OutboxEvent {
+	event := domain.OutboxEvent{
+		Id:            row.Id,
+		Type:          domain.EventType(row.EventType),
+		AggregateId:   row.AggregateId,
+		Payload:       row.Payload,
+		OccurredAt:    row.OccurredAt,
+		Attempts:      row.Attempts,
+		NextAttemptAt: row.NextAttemptAt,
+		PublishedAt:   row.PublishedAt,
+	}
+
+	if row.LastError != nil {
+		event.LastError = *row.LastError
+	}
+
+	return event
+}
@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_outboxRepository_ClaimDue(t *testing.T) {
+	ctx := context.TODO()
+	ob := NewOutbox(OutboxDeps{Store: NewStore()})
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+
+	for _, aggregateId := range []string{"disb-1", "disb-2", "disb-1"} {
+		err := ob.Insert(ctx, domain.OutboxEvent{
+			Type:        domain.EventTypeDisbursementStatusChanged,
+			AggregateId: aggregateId,
+			Payload:     []byte(`{}`),
+			OccurredAt:  now,
+		})
+		assert.NoError(t, err)
+	}
+
+	got, err := ob.ClaimDue(ctx, now, leaseUntil, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, got, 2, "the second event of disb-1 wait for the first") {
+		assert.Equal(t, []int64{1, 2}, []int64{got[0].Id, got[1].Id})
+		assert.Equal(t, leaseUntil, got[0].NextAttemptAt)
+	}
+
+	got, err = ob.ClaimDue(ctx, now, leaseUntil, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, got, "leased events should not be claimed again")
+
+	publishedAt := now
+	assert.NoError(t, ob.UpdateById(ctx, 1, domain.OutboxEvent{Attempts: 1, NextAttemptAt: now, PublishedAt: &publishedAt}))
+
+	got, err = ob.ClaimDue(ctx, now, leaseUntil, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, int64(3), got[0].Id)
+	}
+
+	deleted, err := ob.DeletePublishedBefore(ctx, now.Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
@@ -0,0 +1,264 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository"
+)
+
+const (
+	tableScreeningEntry = "screening_entry"
+	tableScreening      = "screening"
+
+	// same as the postgres repository
+	defaultScreeningEntryLimit = 100
+	defaultScreeningLimit      = 100
+)
+
+type screeningEntryRepository struct {
+	store *Store
+	tx    *transaction
+}
+
+type ScreeningEntryDeps struct {
+	Store *Store
+}
+
+func NewScreeningEntry(deps ScreeningEntryDeps) *screeningEntryRepository {
+	return &screeningEntryRepository{
+		store: deps.Store,
+	}
+}
+
+func (sr screeningEntryRepository) WithTx(Tx database.SQLDatabase) repository.ScreeningEntry {
+	return screeningEntryRepository{
+		store: sr.store,
+		tx:    txFrom(Tx),
+	}
+}
+
+func (sr screeningEntryRepository) Insert(ctx context.Context, entry domain.ScreeningEntry) (string, error) {
+	entry.Id = newId()
+	entry.CreatedAt = time.Now()
+
+	err := run(sr.store, sr.tx, func(tx *transaction) error {
+		return tx.put(tableScreeningEntry, entry.Id, entry)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return entry.Id, nil
+}
+
+func (sr screeningEntryRepository) DeleteById(ctx context.Context, listName string, id string) error {
+	return run(sr.store, sr.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableScreeningEntry, id)
+		if !exists || value.(domain.ScreeningEntry).ListName != listName {
+			return internal_error.ErrNoRowsAffected
+		}
+
+		return tx.delete(tableScreeningEntry, id)
+	})
+}
+
+func (sr screeningEntryRepository) DeleteByListName(ctx context.Context, listName string) error {
+	return run(sr.store, sr.tx, func(tx *transaction) error {
+		var ids []string
+		tx.scan(tableScreeningEntry, func(key string, value interface{}) bool {
+			if value.(domain.ScreeningEntry).ListName == listName {
+				ids = append(ids, key)
+			}
+
+			return true
+		})
+
+		for _, id := range ids {
+			err := tx.delete(tableScreeningEntry, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (sr screeningEntryRepository) List(ctx context.Context, filter domain.ScreeningEntryFilter) ([]domain.ScreeningEntry, error) {
+	var rows []domain.ScreeningEntry
+
+	err := run(sr.store, sr.tx, func(tx *transaction) error {
+		tx.scan(tableScreeningEntry, func(key string, value interface{}) bool {
+			entry := value.(domain.ScreeningEntry)
+			if filter.ListName == "" || entry.ListName == filter.ListName {
+				rows = append(rows, entry)
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].CreatedAt.After(rows[j].CreatedAt)
+	})
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultScreeningEntryLimit
+	}
+
+	res := []domain.ScreeningEntry{}
+	for i := filter.Offset; i < len(rows) && len(res) < limit; i++ {
+		res = append(res, rows[i])
+	}
+
+	return res, nil
+}
+
+func (sr screeningEntryRepository) ListAll(ctx context.Context) ([]domain.ScreeningEntry, error) {
+	res := []domain.ScreeningEntry{}
+
+	err := run(sr.store, sr.tx, func(tx *transaction) error {
+		tx.scan(tableScreeningEntry, func(key string, value interface{}) bool {
+			res = append(res, value.(domain.ScreeningEntry))
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+type screeningRepository struct {
+	store *Store
+	tx    *transaction
+}
+
+type ScreeningDeps struct {
+	Store *Store
+}
+
+func NewScreening(deps ScreeningDeps) *screeningRepository {
+	// same as screening_disbursement_id_idx in the postgres schema
+	deps.Store.RegisterUniqueIndex(tableScreening, UniqueIndex{
+		Name: "screening_disbursement_id_idx",
+		Key: func(value interface{}) string {
+			return value.(domain.Screening).DisbursementId
+		},
+	})
+
+	return &screeningRepository{
+		store: deps.Store,
+	}
+}
+
+func (sr screeningRepository) WithTx(Tx database.SQLDatabase) repository.Screening {
+	return screeningRepository{
+		store: sr.store,
+		tx:    txFrom(Tx),
+	}
+}
+
+func (sr screeningRepository) Insert(ctx context.Context, screening domain.Screening) (string, error) {
+	screening.Id = newId()
+	screening.Matches = append([]domain.ScreeningMatch(nil), screening.Matches...)
+	screening.CreatedAt = time.Now()
+	screening.UpdatedAt = screening.CreatedAt
+
+	err := run(sr.store, sr.tx, func(tx *transaction) error {
+		return tx.put(tableScreening, screening.Id, screening)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return screening.Id, nil
+}
+
+func (sr screeningRepository) UpdateById(ctx context.Context, id string, updatedData domain.Screening) error {
+	return run(sr.store, sr.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableScreening, id)
+		if !exists || !value.(domain.Screening).IsHeld() {
+			return internal_error.ErrNoRowsAffected
+		}
+
+		screening := value.(domain.Screening)
+		screening.Status = updatedData.Status
+		screening.ResolvedBy = updatedData.ResolvedBy
+		screening.ResolutionNote = updatedData.ResolutionNote
+		screening.ResolvedAt = updatedData.ResolvedAt
+		screening.UpdatedAt = time.Now()
+
+		return tx.put(tableScreening, id, screening)
+	})
+}
+
+func (sr screeningRepository) GetById(ctx context.Context, id string) (*domain.Screening, error) {
+	var res *domain.Screening
+
+	err := run(sr.store, sr.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableScreening, id)
+		if exists {
+			screening := value.(domain.Screening)
+			res = &screening
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (sr screeningRepository) List(ctx context.Context, filter domain.ScreeningFilter) ([]domain.Screening, error) {
+	var rows []domain.Screening
+
+	err := run(sr.store, sr.tx, func(tx *transaction) error {
+		tx.scan(tableScreening, func(key string, value interface{}) bool {
+			screening := value.(domain.Screening)
+			if (filter.Status == "" || screening.Status == filter.Status) &&
+				(filter.DisbursementId == "" || screening.DisbursementId == filter.DisbursementId) {
+				rows = append(rows, screening)
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].CreatedAt.Before(rows[j].CreatedAt)
+	})
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultScreeningLimit
+	}
+
+	res := []domain.Screening{}
+	for i := filter.Offset; i < len(rows) && len(res) < limit; i++ {
+		res = append(res, rows[i])
+	}
+
+	return res, nil
+}
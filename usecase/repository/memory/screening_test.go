@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_screeningEntryRepository(t *testing.T) {
+	ctx := context.TODO()
+	sr := NewScreeningEntry(ScreeningEntryDeps{Store: NewStore()})
+
+	_, err := sr.Insert(ctx, domain.ScreeningEntry{ListName: "OFAC", Name: "Nobby Phala", Reference: "SDN-1"})
+	assert.NoError(t, err)
+	_, err = sr.Insert(ctx, domain.ScreeningEntry{ListName: "OFAC", Name: "Budi Santoso", Reference: "SDN-2"})
+	assert.NoError(t, err)
+	blockedId, err := sr.Insert(ctx, domain.ScreeningEntry{ListName: domain.ScreeningListInternal, AccountNumber: "6789567", Reference: "fraud report"})
+	assert.NoError(t, err)
+
+	// an entry is deleted only from its own list
+	err = sr.DeleteById(ctx, "OFAC", blockedId)
+	assert.Equal(t, internal_error.ErrNoRowsAffected, err)
+
+	internal, err := sr.List(ctx, domain.ScreeningEntryFilter{ListName: domain.ScreeningListInternal})
+	assert.NoError(t, err)
+	assert.Len(t, internal, 1)
+	assert.Equal(t, "6789567", internal[0].AccountNumber)
+
+	err = sr.DeleteByListName(ctx, "OFAC")
+	assert.NoError(t, err)
+
+	all, err := sr.ListAll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+	assert.Equal(t, blockedId, all[0].Id)
+
+	err = sr.DeleteById(ctx, domain.ScreeningListInternal, blockedId)
+	assert.NoError(t, err)
+
+	all, err = sr.ListAll(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func Test_screeningRepository(t *testing.T) {
+	ctx := context.TODO()
+	sr := NewScreening(ScreeningDeps{Store: NewStore()})
+
+	heldId, err := sr.Insert(ctx, domain.Screening{
+		DisbursementId: "disbursement-1",
+		Status:         domain.ScreeningStatusHeld,
+		Matches:        []domain.ScreeningMatch{{EntryId: "entry-1", Field: domain.ScreeningMatchFieldName, Score: 90}},
+	})
+	assert.NoError(t, err)
+	_, err = sr.Insert(ctx, domain.Screening{DisbursementId: "disbursement-2", Status: domain.ScreeningStatusCleared})
+	assert.NoError(t, err)
+
+	// one screening per disbursement
+	_, err = sr.Insert(ctx, domain.Screening{DisbursementId: "disbursement-1", Status: domain.ScreeningStatusCleared})
+	assert.ErrorIs(t, err, database.ErrUniqueViolation)
+
+	held, err := sr.List(ctx, domain.ScreeningFilter{Status: domain.ScreeningStatusHeld})
+	assert.NoError(t, err)
+	assert.Len(t, held, 1)
+	assert.Equal(t, heldId, held[0].Id)
+
+	err = sr.UpdateById(ctx, heldId, domain.Screening{Status: domain.ScreeningStatusReleased, ResolvedBy: "compliance-1"})
+	assert.NoError(t, err)
+
+	// resolved screening cannot be changed
+	err = sr.UpdateById(ctx, heldId, domain.Screening{Status: domain.ScreeningStatusRejected})
+	assert.Equal(t, internal_error.ErrNoRowsAffected, err)
+
+	screening, err := sr.GetById(ctx, heldId)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ScreeningStatusReleased, screening.Status)
+	assert.Equal(t, "compliance-1", screening.ResolvedBy)
+	assert.Len(t, screening.Matches, 1)
+}
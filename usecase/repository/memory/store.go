@@ -0,0 +1,224 @@
+package memory
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nobbyphala/Brick/external/database"
+)
+
+// in-memory database for local development and tests. Every transaction read from a snapshot of the committed
+// data taken when the transaction begin, and the writes only visible to other transactions after commit.
+// Commit fail with database.ErrSerializationFailure when another transaction committed a change to the same
+// record after the snapshot taken, so the transaction can be retried like postgres serialization failure.
+
+var ErrReadOnlyTransaction = errors.New("cannot execute write in a read-only transaction")
+
+type record struct {
+	value   interface{}
+	version uint64
+}
+
+// UniqueIndex reject two records in the same table having the same key. Empty key is not indexed like NULL
+type UniqueIndex struct {
+	Name string
+	Key  func(value interface{}) string
+}
+
+type Store struct {
+	mu sync.Mutex
+	// committed tables are never modified in place, commit replace the changed table with a modified copy
+	tables  map[string]map[string]record
+	uniques map[string][]UniqueIndex
+	clock   uint64
+	// last value of the sequence of the tables having sequenced records
+	sequences map[string]int64
+	// notified after a commit changed the table, like postgres NOTIFY
+	watchers map[string]map[chan struct{}]struct{}
+	// record locks held until the end of the transaction, like postgres SELECT ... FOR UPDATE
+	locks map[string]*sync.Mutex
+}
+
+func NewStore() *Store {
+	return &Store{
+		tables:    make(map[string]map[string]record),
+		uniques:   make(map[string][]UniqueIndex),
+		sequences: make(map[string]int64),
+		watchers:  make(map[string]map[chan struct{}]struct{}),
+		locks:     make(map[string]*sync.Mutex),
+	}
+}
+
+func (s *Store) RegisterUniqueIndex(table string, index UniqueIndex) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.uniques[table] {
+		if existing.Name == index.Name {
+			return
+		}
+	}
+
+	s.uniques[table] = append(s.uniques[table], index)
+}
+
+// watch return a channel receiving a value after a commit changed the table, several commits may be notified once.
+// Call stop to unregister the channel
+func (s *Store) watch(table string) (notifications <-chan struct{}, stop func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	if s.watchers[table] == nil {
+		s.watchers[table] = make(map[chan struct{}]struct{})
+	}
+	s.watchers[table][ch] = struct{}{}
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		delete(s.watchers[table], ch)
+	}
+}
+
+// lastSequence return the last committed value of the table sequence, 0 when nothing committed
+func (s *Store) lastSequence(table string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sequences[table]
+}
+
+// recordLock return the lock of the record, created on first use
+func (s *Store) recordLock(table string, key string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := table + "/" + key
+	if s.locks[name] == nil {
+		s.locks[name] = &sync.Mutex{}
+	}
+
+	return s.locks[name]
+}
+
+func (s *Store) Close() error {
+	return nil
+}
+
+func (s *Store) begin(readOnly bool) *transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return &transaction{
+		store:    s,
+		snapshot: s.tables,
+		writes:   make(map[string]map[string]write),
+		readOnly: readOnly,
+	}
+}
+
+func (s *Store) commit(writes map[string]map[string]write) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for table, entries := range writes {
+		for key, entry := range entries {
+			if s.tables[table][key].version != entry.baseVersion {
+				return database.ErrSerializationFailure
+			}
+		}
+	}
+
+	tables := make(map[string]map[string]record, len(s.tables)+len(writes))
+	for table, rows := range s.tables {
+		tables[table] = rows
+	}
+
+	for table, entries := range writes {
+		rows := make(map[string]record, len(tables[table])+len(entries))
+		for key, row := range tables[table] {
+			rows[key] = row
+		}
+
+		for key, entry := range entries {
+			if entry.deleted {
+				delete(rows, key)
+				continue
+			}
+
+			if entry.sequenced != nil {
+				s.sequences[table]++
+				key = sequenceKey(s.sequences[table])
+				entry.value = entry.sequenced(s.sequences[table])
+			}
+
+			s.clock++
+			rows[key] = record{value: entry.value, version: s.clock}
+		}
+
+		err := checkUnique(s.uniques[table], rows)
+		if err != nil {
+			return err
+		}
+
+		tables[table] = rows
+	}
+
+	s.tables = tables
+
+	for table := range writes {
+		for ch := range s.watchers[table] {
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}
+
+	return nil
+}
+
+// sequenceKey is zero padded so the records are scanned in sequence order
+func sequenceKey(sequence int64) string {
+	return fmt.Sprintf("%020d", sequence)
+}
+
+func checkUnique(indexes []UniqueIndex, rows map[string]record) error {
+	for _, index := range indexes {
+		seen := make(map[string]bool, len(rows))
+
+		for _, row := range rows {
+			key := index.Key(row.value)
+			if key == "" {
+				continue
+			}
+
+			if seen[key] {
+				return fmt.Errorf("%w %q", database.ErrUniqueViolation, index.Name)
+			}
+
+			seen[key] = true
+		}
+	}
+
+	return nil
+}
+
+// newId generate random uuid v4 like postgres uuid_generate_v4
+func newId() string {
+	var b [16]byte
+
+	_, err := rand.Read(b[:])
+	if err != nil {
+		panic(err)
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,236 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/nobbyphala/Brick/external/database"
+)
+
+var ErrNotSupported = errors.New("sql is not supported by in-memory database")
+
+type write struct {
+	value       interface{}
+	deleted     bool
+	baseVersion uint64 // version of the record in the snapshot when first written
+	// set for the records keyed by the table sequence, called with the sequence value on commit
+	sequenced func(sequence int64) interface{}
+}
+
+// transaction implement database.SQLDatabase so it can be passed around as the transaction handle,
+// but the in-memory repositories access the data through its methods instead of sql
+type transaction struct {
+	store    *Store
+	snapshot map[string]map[string]record
+	writes   map[string]map[string]write
+	readOnly bool
+	done     bool
+	locks    map[string]*sync.Mutex
+}
+
+func (tx *transaction) get(table string, key string) (interface{}, bool) {
+	if entry, exists := tx.writes[table][key]; exists {
+		return entry.value, !entry.deleted
+	}
+
+	row, exists := tx.snapshot[table][key]
+	return row.value, exists
+}
+
+// scan call fn for every visible record in the table ordered by key until fn return false
+func (tx *transaction) scan(table string, fn func(key string, value interface{}) bool) {
+	keys := make([]string, 0, len(tx.snapshot[table])+len(tx.writes[table]))
+	for key := range tx.snapshot[table] {
+		if _, written := tx.writes[table][key]; !written {
+			keys = append(keys, key)
+		}
+	}
+	for key, entry := range tx.writes[table] {
+		if !entry.deleted && entry.sequenced == nil {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, _ := tx.get(table, key)
+		if !fn(key, value) {
+			return
+		}
+	}
+}
+
+func (tx *transaction) put(table string, key string, value interface{}) error {
+	if tx.readOnly {
+		return ErrReadOnlyTransaction
+	}
+
+	for _, index := range tx.store.uniques[table] {
+		indexKey := index.Key(value)
+		if indexKey == "" {
+			continue
+		}
+
+		var duplicate bool
+		tx.scan(table, func(otherKey string, other interface{}) bool {
+			duplicate = otherKey != key && index.Key(other) == indexKey
+			return !duplicate
+		})
+
+		if duplicate {
+			return fmt.Errorf("%w %q", database.ErrUniqueViolation, index.Name)
+		}
+	}
+
+	tx.setWrite(table, key, write{value: value})
+	return nil
+}
+
+// insertSequenced add a record keyed by the next value of the table sequence like a bigserial column. The sequence
+// is assigned on commit so the keys are increasing in commit order, the record is not visible inside the transaction
+func (tx *transaction) insertSequenced(table string, value func(sequence int64) interface{}) error {
+	if tx.readOnly {
+		return ErrReadOnlyTransaction
+	}
+
+	// placeholder key replaced on commit
+	tx.setWrite(table, newId(), write{sequenced: value})
+	return nil
+}
+
+func (tx *transaction) delete(table string, key string) error {
+	if tx.readOnly {
+		return ErrReadOnlyTransaction
+	}
+
+	tx.setWrite(table, key, write{deleted: true})
+	return nil
+}
+
+// lock wait until no other transaction hold the lock of the record and hold it until the transaction end. The
+// snapshot may be older than the change committed by the previous holder, so the record is also written unchanged
+// to fail the commit and retry the transaction in that case
+func (tx *transaction) lock(table string, key string) error {
+	if tx.readOnly {
+		return ErrReadOnlyTransaction
+	}
+
+	name := table + "/" + key
+	if _, held := tx.locks[name]; !held {
+		lock := tx.store.recordLock(table, key)
+		lock.Lock()
+
+		if tx.locks == nil {
+			tx.locks = make(map[string]*sync.Mutex)
+		}
+		tx.locks[name] = lock
+	}
+
+	if value, exists := tx.get(table, key); exists {
+		tx.setWrite(table, key, write{value: value})
+	}
+
+	return nil
+}
+
+func (tx *transaction) unlock() {
+	for _, lock := range tx.locks {
+		lock.Unlock()
+	}
+
+	tx.locks = nil
+}
+
+func (tx *transaction) setWrite(table string, key string, entry write) {
+	if tx.writes[table] == nil {
+		tx.writes[table] = make(map[string]write)
+	}
+
+	if previous, exists := tx.writes[table][key]; exists {
+		entry.baseVersion = previous.baseVersion
+	} else {
+		entry.baseVersion = tx.snapshot[table][key].version
+	}
+
+	tx.writes[table][key] = entry
+}
+
+func (tx *transaction) commit() error {
+	if tx.done {
+		return database.ErrTxDone
+	}
+
+	tx.done = true
+	defer tx.unlock()
+
+	return tx.store.commit(tx.writes)
+}
+
+func (tx *transaction) rollback() {
+	tx.done = true
+	tx.writes = nil
+	tx.unlock()
+}
+
+// savepoint return copy of the current writes that can be restored with rollbackTo
+func (tx *transaction) savepoint() map[string]map[string]write {
+	res := make(map[string]map[string]write, len(tx.writes))
+	for table, entries := range tx.writes {
+		res[table] = make(map[string]write, len(entries))
+		for key, entry := range entries {
+			res[table][key] = entry
+		}
+	}
+
+	return res
+}
+
+func (tx *transaction) rollbackTo(savepoint map[string]map[string]write) {
+	tx.writes = savepoint
+}
+
+func (tx *transaction) Get(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return ErrNotSupported
+}
+
+func (tx *transaction) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return ErrNotSupported
+}
+
+func (tx *transaction) Exec(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+	return nil, ErrNotSupported
+}
+
+func (tx *transaction) Query(ctx context.Context, query string, args ...interface{}) database.Row {
+	return unsupportedRow{}
+}
+
+func (tx *transaction) QueryRows(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+	return nil, ErrNotSupported
+}
+
+func (tx *transaction) NamedExec(ctx context.Context, query string, arg interface{}) (database.Result, error) {
+	return nil, ErrNotSupported
+}
+
+func (tx *transaction) NamedQuery(ctx context.Context, query string, arg interface{}) (database.Rows, error) {
+	return nil, ErrNotSupported
+}
+
+func (tx *transaction) Prepare(ctx context.Context, query string) (database.Stmt, error) {
+	return nil, ErrNotSupported
+}
+
+type unsupportedRow struct{}
+
+func (row unsupportedRow) Scan(dest ...any) error {
+	return ErrNotSupported
+}
+
+func (row unsupportedRow) Err() error {
+	return ErrNotSupported
+}
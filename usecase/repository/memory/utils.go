@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository"
+)
+
+const txRetryBaseDelay = 5 * time.Millisecond
+
+type txContextKey struct{}
+
+type utils struct {
+	store *Store
+}
+
+type UtilsOpts struct {
+	Store *Store
+}
+
+func NewRepositoryUtils(opts UtilsOpts) *utils {
+	return &utils{
+		store: opts.Store,
+	}
+}
+
+func (ut utils) RunWithTransaction(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+	if tx, ok := ctx.Value(txContextKey{}).(*transaction); ok {
+		return ut.runWithSavepoint(ctx, tx, handler)
+	}
+
+	txOpts := repository.BuildTxOptions(opts)
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = ut.runOnce(ctx, txOpts, handler)
+		if err == nil || !database.IsRetryableError(err) || attempt >= txOpts.MaxRetries {
+			return err
+		}
+
+		log.Println("retrying transaction after conflict:", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(txRetryBaseDelay << attempt):
+		}
+	}
+}
+
+func (ut utils) runOnce(ctx context.Context, txOpts repository.TxOptions, handler func(ctx context.Context, Tx database.SQLDatabase) error) error {
+	tx := ut.store.begin(txOpts.ReadOnly)
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			tx.rollback()
+			panic(recovered)
+		}
+	}()
+
+	err := handler(context.WithValue(ctx, txContextKey{}, tx), tx)
+	if err != nil {
+		tx.rollback()
+		return err
+	}
+
+	return tx.commit()
+}
+
+func (ut utils) runWithSavepoint(ctx context.Context, tx *transaction, handler func(ctx context.Context, Tx database.SQLDatabase) error) error {
+	savepoint := tx.savepoint()
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			tx.rollbackTo(savepoint)
+			panic(recovered)
+		}
+	}()
+
+	err := handler(ctx, tx)
+	if err != nil {
+		tx.rollbackTo(savepoint)
+		return err
+	}
+
+	return nil
+}
+
+// run fn in tx when the repository bound to a transaction, otherwise run it in its own transaction
+func run(store *Store, tx *transaction, fn func(tx *transaction) error) error {
+	if tx != nil {
+		return fn(tx)
+	}
+
+	tx = store.begin(false)
+
+	err := fn(tx)
+	if err != nil {
+		tx.rollback()
+		return err
+	}
+
+	return tx.commit()
+}
+
+// txFrom return the in-memory transaction. Transaction from other database is ignored so the
+// repository run in its own transaction
+func txFrom(Tx database.SQLDatabase) *transaction {
+	tx, _ := Tx.(*transaction)
+	return tx
+}
@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_utils_RunWithTransaction(t *testing.T) {
+	ctx := context.TODO()
+	store := NewStore()
+	disb := NewDisbursement(DisbursementDeps{Store: store})
+	ut := NewRepositoryUtils(UtilsOpts{Store: store})
+
+	t.Run("rollback discard the writes", func(t *testing.T) {
+		err := ut.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+			_, err := disb.WithTx(Tx).Insert(ctx, newTestDisbursement())
+			assert.NoError(t, err)
+
+			got, _ := disb.WithTx(Tx).GetByTransactionId(ctx, "txn-id-1")
+			assert.NotNil(t, got, "write should be visible inside the transaction")
+
+			got, _ = disb.GetByTransactionId(ctx, "txn-id-1")
+			assert.Nil(t, got, "write should not be visible outside the transaction before commit")
+
+			return errors.New("handler error")
+		})
+		assert.Equal(t, errors.New("handler error"), err)
+
+		got, _ := disb.GetByTransactionId(ctx, "txn-id-1")
+		assert.Nil(t, got)
+	})
+
+	t.Run("commit make the writes visible", func(t *testing.T) {
+		err := ut.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+			_, err := disb.WithTx(Tx).Insert(ctx, newTestDisbursement())
+			return err
+		})
+		assert.NoError(t, err)
+
+		got, _ := disb.GetByTransactionId(ctx, "txn-id-1")
+		assert.NotNil(t, got)
+	})
+
+	t.Run("nested call rollback only the savepoint", func(t *testing.T) {
+		err := ut.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+			second := newTestDisbursement()
+			second.BankTransactionId = "txn-id-2"
+			_, err := disb.WithTx(Tx).Insert(ctx, second)
+			assert.NoError(t, err)
+
+			return ut.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+				third := newTestDisbursement()
+				third.BankTransactionId = "txn-id-3"
+				disb.WithTx(Tx).Insert(ctx, third)
+				return errors.New("nested error")
+			})
+		})
+		assert.Equal(t, errors.New("nested error"), err)
+
+		err = ut.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+			second := newTestDisbursement()
+			second.BankTransactionId = "txn-id-2"
+			_, err := disb.WithTx(Tx).Insert(ctx, second)
+			assert.NoError(t, err)
+
+			ut.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+				third := newTestDisbursement()
+				third.BankTransactionId = "txn-id-3"
+				disb.WithTx(Tx).Insert(ctx, third)
+				return errors.New("nested error")
+			})
+
+			return nil
+		})
+		assert.NoError(t, err)
+
+		got, _ := disb.GetByTransactionId(ctx, "txn-id-2")
+		assert.NotNil(t, got)
+		got, _ = disb.GetByTransactionId(ctx, "txn-id-3")
+		assert.Nil(t, got)
+	})
+
+	t.Run("concurrent update retried", func(t *testing.T) {
+		existing, _ := disb.GetByTransactionId(ctx, "txn-id-1")
+		attempt := 0
+
+		err := ut.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+			attempt++
+
+			got, _ := disb.WithTx(Tx).GetByTransactionId(ctx, "txn-id-1")
+			got.Amount++
+
+			if attempt == 1 {
+				// another writer commit the same record after the snapshot taken
+				concurrent := *existing
+				concurrent.Amount = 1
+				assert.NoError(t, disb.UpdateById(ctx, existing.Id, existing.Version, concurrent))
+			}
+
+			return disb.WithTx(Tx).UpdateById(ctx, got.Id, got.Version, *got)
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, attempt)
+
+		got, _ := disb.GetByTransactionId(ctx, "txn-id-1")
+		assert.Equal(t, int64(2), got.Amount)
+	})
+
+	t.Run("read only transaction reject writes", func(t *testing.T) {
+		err := ut.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+			second := newTestDisbursement()
+			second.BankTransactionId = "txn-id-read-only"
+			_, err := disb.WithTx(Tx).Insert(ctx, second)
+			return err
+		}, repository.WithReadOnly())
+		assert.Equal(t, ErrReadOnlyTransaction, err)
+	})
+}
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+)
+
+// verificationCacheRepository keep the verification results in the process, not shared with other instances and
+// not transactional, so it does not use the Store. The least recently used result is dropped when full
+type verificationCacheRepository struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	// front is the most recently used
+	recency *list.List
+}
+
+type VerificationCacheDeps struct {
+	// maximum number of accounts kept
+	Size int
+}
+
+func NewVerificationCache(deps VerificationCacheDeps) *verificationCacheRepository {
+	return &verificationCacheRepository{
+		size:    deps.Size,
+		entries: make(map[string]*list.Element),
+		recency: list.New(),
+	}
+}
+
+func (vc *verificationCacheRepository) Get(ctx context.Context, bankCode string, accountNumber string, now time.Time) (*domain.AccountVerification, error) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	element, ok := vc.entries[verificationCacheKey(bankCode, accountNumber)]
+	if !ok {
+		return nil, nil
+	}
+
+	verification := element.Value.(domain.AccountVerification)
+	if !now.Before(verification.ExpiresAt) {
+		vc.remove(element)
+		return nil, nil
+	}
+
+	vc.recency.MoveToFront(element)
+
+	return &verification, nil
+}
+
+func (vc *verificationCacheRepository) Set(ctx context.Context, verification domain.AccountVerification) error {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	key := verificationCacheKey(verification.BankCode, verification.AccountNumber)
+
+	element, ok := vc.entries[key]
+	if ok {
+		element.Value = verification
+		vc.recency.MoveToFront(element)
+		return nil
+	}
+
+	vc.entries[key] = vc.recency.PushFront(verification)
+
+	for vc.recency.Len() > vc.size {
+		vc.remove(vc.recency.Back())
+	}
+
+	return nil
+}
+
+func (vc *verificationCacheRepository) Delete(ctx context.Context, bankCode string, accountNumber string) error {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	element, ok := vc.entries[verificationCacheKey(bankCode, accountNumber)]
+	if ok {
+		vc.remove(element)
+	}
+
+	return nil
+}
+
+func (vc *verificationCacheRepository) remove(element *list.Element) {
+	verification := vc.recency.Remove(element).(domain.AccountVerification)
+	delete(vc.entries, verificationCacheKey(verification.BankCode, verification.AccountNumber))
+}
+
+// NUL is not part of a bank code, so two different accounts never have the same key
+func verificationCacheKey(bankCode string, accountNumber string) string {
+	return bankCode + "\x00" + accountNumber
+}
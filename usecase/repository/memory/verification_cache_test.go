@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_verificationCacheRepository(t *testing.T) {
+	ctx := context.TODO()
+	vc := NewVerificationCache(VerificationCacheDeps{Size: 2})
+	now := time.Now()
+
+	verification := func(accountNumber string, ttl time.Duration) domain.AccountVerification {
+		return domain.AccountVerification{
+			BankCode:          "014",
+			AccountNumber:     accountNumber,
+			AccountHolderName: "Nobby Phala",
+			AccountStatus:     "status: account verified",
+			ExpiresAt:         now.Add(ttl),
+		}
+	}
+
+	assert.NoError(t, vc.Set(ctx, verification("111", time.Minute)))
+	assert.NoError(t, vc.Set(ctx, verification("222", time.Minute)))
+
+	got, err := vc.Get(ctx, "014", "111", now)
+	assert.NoError(t, err)
+	assert.Equal(t, verification("111", time.Minute), *got)
+
+	got, err = vc.Get(ctx, "008", "111", now)
+	assert.NoError(t, err)
+	assert.Nil(t, got, "the same account number of another bank is another account")
+
+	// 222 is the least recently used since 111 was read
+	assert.NoError(t, vc.Set(ctx, verification("333", time.Minute)))
+
+	got, err = vc.Get(ctx, "014", "222", now)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = vc.Get(ctx, "014", "333", now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Nil(t, got, "expired result should not be returned")
+
+	assert.NoError(t, vc.Delete(ctx, "014", "111"))
+	assert.NoError(t, vc.Delete(ctx, "014", "111"))
+
+	got, err = vc.Get(ctx, "014", "111", now)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+	assert.Equal(t, 0, vc.recency.Len())
+}
@@ -0,0 +1,325 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository"
+)
+
+const (
+	tableWebhookEndpoint        = "webhook_endpoint"
+	tableWebhookDelivery        = "webhook_delivery"
+	tableWebhookDeliveryAttempt = "webhook_delivery_attempt"
+
+	// same as the postgres repository
+	defaultWebhookDeliveryLimit = 100
+)
+
+type webhookEndpointRepository struct {
+	store *Store
+	tx    *transaction
+}
+
+type WebhookEndpointDeps struct {
+	Store *Store
+}
+
+func NewWebhookEndpoint(deps WebhookEndpointDeps) *webhookEndpointRepository {
+	return &webhookEndpointRepository{
+		store: deps.Store,
+	}
+}
+
+func (wr webhookEndpointRepository) WithTx(Tx database.SQLDatabase) repository.WebhookEndpoint {
+	return webhookEndpointRepository{
+		store: wr.store,
+		tx:    txFrom(Tx),
+	}
+}
+
+func (wr webhookEndpointRepository) Insert(ctx context.Context, endpoint domain.WebhookEndpoint) (string, error) {
+	endpoint.Id = newId()
+	endpoint.EventTypes = append([]domain.WebhookEventType(nil), endpoint.EventTypes...)
+	endpoint.CreatedAt = time.Now()
+
+	err := run(wr.store, wr.tx, func(tx *transaction) error {
+		return tx.put(tableWebhookEndpoint, endpoint.Id, endpoint)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint.Id, nil
+}
+
+func (wr webhookEndpointRepository) GetById(ctx context.Context, id string) (*domain.WebhookEndpoint, error) {
+	var res *domain.WebhookEndpoint
+
+	err := run(wr.store, wr.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableWebhookEndpoint, id)
+		if exists {
+			endpoint := value.(domain.WebhookEndpoint)
+			res = &endpoint
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (wr webhookEndpointRepository) ListByMerchantId(ctx context.Context, merchantId string) ([]domain.WebhookEndpoint, error) {
+	res := []domain.WebhookEndpoint{}
+
+	err := run(wr.store, wr.tx, func(tx *transaction) error {
+		tx.scan(tableWebhookEndpoint, func(key string, value interface{}) bool {
+			endpoint := value.(domain.WebhookEndpoint)
+			if endpoint.MerchantId == merchantId {
+				res = append(res, endpoint)
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].CreatedAt.Before(res[j].CreatedAt)
+	})
+
+	return res, nil
+}
+
+func (wr webhookEndpointRepository) UpdateById(ctx context.Context, id string, updatedData domain.WebhookEndpoint) error {
+	return run(wr.store, wr.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableWebhookEndpoint, id)
+		if !exists {
+			return internal_error.ErrNoRowsAffected
+		}
+
+		endpoint := value.(domain.WebhookEndpoint)
+		endpoint.Url = updatedData.Url
+		endpoint.EventTypes = append([]domain.WebhookEventType(nil), updatedData.EventTypes...)
+
+		return tx.put(tableWebhookEndpoint, id, endpoint)
+	})
+}
+
+// DeleteById remove the endpoint, unlike postgres there is no delivery log to keep it for
+func (wr webhookEndpointRepository) DeleteById(ctx context.Context, id string) error {
+	return run(wr.store, wr.tx, func(tx *transaction) error {
+		if _, exists := tx.get(tableWebhookEndpoint, id); !exists {
+			return internal_error.ErrNoRowsAffected
+		}
+
+		return tx.delete(tableWebhookEndpoint, id)
+	})
+}
+
+type webhookDeliveryRepository struct {
+	store *Store
+	tx    *transaction
+}
+
+type WebhookDeliveryDeps struct {
+	Store *Store
+}
+
+func NewWebhookDelivery(deps WebhookDeliveryDeps) *webhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		store: deps.Store,
+	}
+}
+
+func (wr webhookDeliveryRepository) WithTx(Tx database.SQLDatabase) repository.WebhookDelivery {
+	return webhookDeliveryRepository{
+		store: wr.store,
+		tx:    txFrom(Tx),
+	}
+}
+
+func (wr webhookDeliveryRepository) Insert(ctx context.Context, delivery domain.WebhookDelivery) (string, error) {
+	delivery.Id = newId()
+	delivery.Payload = append([]byte(nil), delivery.Payload...)
+	delivery.Attempts = 0
+	delivery.CreatedAt = time.Now()
+	delivery.UpdatedAt = delivery.CreatedAt
+
+	err := run(wr.store, wr.tx, func(tx *transaction) error {
+		return tx.put(tableWebhookDelivery, delivery.Id, delivery)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return delivery.Id, nil
+}
+
+func (wr webhookDeliveryRepository) UpdateById(ctx context.Context, id string, updatedData domain.WebhookDelivery) error {
+	return run(wr.store, wr.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableWebhookDelivery, id)
+		if !exists {
+			return internal_error.ErrNoRowsAffected
+		}
+
+		delivery := value.(domain.WebhookDelivery)
+		delivery.Status = updatedData.Status
+		delivery.Attempts = updatedData.Attempts
+		delivery.NextAttemptAt = updatedData.NextAttemptAt
+		delivery.LastStatusCode = updatedData.LastStatusCode
+		delivery.LastError = updatedData.LastError
+		delivery.DeliveredAt = updatedData.DeliveredAt
+		delivery.UpdatedAt = time.Now()
+
+		return tx.put(tableWebhookDelivery, id, delivery)
+	})
+}
+
+func (wr webhookDeliveryRepository) GetById(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	var res *domain.WebhookDelivery
+
+	err := run(wr.store, wr.tx, func(tx *transaction) error {
+		value, exists := tx.get(tableWebhookDelivery, id)
+		if exists {
+			delivery := value.(domain.WebhookDelivery)
+			res = &delivery
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (wr webhookDeliveryRepository) List(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	res := []domain.WebhookDelivery{}
+
+	err := run(wr.store, wr.tx, func(tx *transaction) error {
+		tx.scan(tableWebhookDelivery, func(key string, value interface{}) bool {
+			delivery := value.(domain.WebhookDelivery)
+			if (filter.MerchantId == "" || delivery.MerchantId == filter.MerchantId) &&
+				(filter.EndpointId == "" || delivery.EndpointId == filter.EndpointId) &&
+				(filter.DisbursementId == "" || delivery.DisbursementId == filter.DisbursementId) &&
+				(filter.Status == "" || delivery.Status == filter.Status) {
+				res = append(res, delivery)
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].CreatedAt.After(res[j].CreatedAt)
+	})
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultWebhookDeliveryLimit
+	}
+
+	if len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+func (wr webhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	res := []domain.WebhookDelivery{}
+
+	err := run(wr.store, wr.tx, func(tx *transaction) error {
+		tx.scan(tableWebhookDelivery, func(key string, value interface{}) bool {
+			delivery := value.(domain.WebhookDelivery)
+			if delivery.Status == domain.WebhookDeliveryStatusPending && !delivery.NextAttemptAt.After(now) {
+				res = append(res, delivery)
+			}
+
+			return true
+		})
+
+		sort.SliceStable(res, func(i, j int) bool {
+			return res[i].NextAttemptAt.Before(res[j].NextAttemptAt)
+		})
+
+		if len(res) > limit {
+			res = res[:limit]
+		}
+
+		for i := range res {
+			res[i].NextAttemptAt = leaseUntil
+			res[i].UpdatedAt = time.Now()
+
+			err := tx.put(tableWebhookDelivery, res[i].Id, res[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (wr webhookDeliveryRepository) InsertAttempt(ctx context.Context, attempt domain.WebhookDeliveryAttempt) (string, error) {
+	attempt.Id = newId()
+	attempt.CreatedAt = time.Now()
+
+	err := run(wr.store, wr.tx, func(tx *transaction) error {
+		return tx.put(tableWebhookDeliveryAttempt, attempt.Id, attempt)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return attempt.Id, nil
+}
+
+func (wr webhookDeliveryRepository) ListAttempts(ctx context.Context, deliveryId string) ([]domain.WebhookDeliveryAttempt, error) {
+	res := []domain.WebhookDeliveryAttempt{}
+
+	err := run(wr.store, wr.tx, func(tx *transaction) error {
+		tx.scan(tableWebhookDeliveryAttempt, func(key string, value interface{}) bool {
+			attempt := value.(domain.WebhookDeliveryAttempt)
+			if attempt.DeliveryId == deliveryId {
+				res = append(res, attempt)
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Attempt < res[j].Attempt
+	})
+
+	return res, nil
+}
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_webhookEndpointRepository(t *testing.T) {
+	ctx := context.TODO()
+	wr := NewWebhookEndpoint(WebhookEndpointDeps{Store: NewStore()})
+
+	endpointId, err := wr.Insert(ctx, domain.WebhookEndpoint{MerchantId: "merchant-1", Url: "https://merchant.test/a", Secret: "whsec_a"})
+	assert.NoError(t, err)
+
+	_, err = wr.Insert(ctx, domain.WebhookEndpoint{MerchantId: "merchant-2", Url: "https://merchant.test/b", Secret: "whsec_b"})
+	assert.NoError(t, err)
+
+	err = wr.UpdateById(ctx, endpointId, domain.WebhookEndpoint{
+		Url:        "https://merchant.test/c",
+		Secret:     "ignored",
+		EventTypes: []domain.WebhookEventType{domain.WebhookEventDisbursementFailed},
+	})
+	assert.NoError(t, err)
+
+	endpoints, err := wr.ListByMerchantId(ctx, "merchant-1")
+	assert.NoError(t, err)
+	assert.Len(t, endpoints, 1)
+	assert.Equal(t, "https://merchant.test/c", endpoints[0].Url)
+	assert.Equal(t, "whsec_a", endpoints[0].Secret)
+	assert.Equal(t, []domain.WebhookEventType{domain.WebhookEventDisbursementFailed}, endpoints[0].EventTypes)
+
+	err = wr.DeleteById(ctx, endpointId)
+	assert.NoError(t, err)
+
+	got, err := wr.GetById(ctx, endpointId)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	err = wr.DeleteById(ctx, endpointId)
+	assert.Equal(t, internal_error.ErrNoRowsAffected, err)
+}
+
+func Test_webhookDeliveryRepository_ClaimDue(t *testing.T) {
+	ctx := context.TODO()
+	wr := NewWebhookDelivery(WebhookDeliveryDeps{Store: NewStore()})
+	now := time.Now()
+
+	dueId, err := wr.Insert(ctx, domain.WebhookDelivery{
+		EndpointId:    "endpoint-1",
+		EventId:       "disb-1:v2",
+		Status:        domain.WebhookDeliveryStatusPending,
+		NextAttemptAt: now.Add(-time.Second),
+	})
+	assert.NoError(t, err)
+
+	_, err = wr.Insert(ctx, domain.WebhookDelivery{
+		EndpointId:    "endpoint-1",
+		EventId:       "disb-2:v2",
+		Status:        domain.WebhookDeliveryStatusPending,
+		NextAttemptAt: now.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+
+	_, err = wr.Insert(ctx, domain.WebhookDelivery{
+		EndpointId:    "endpoint-1",
+		EventId:       "disb-3:v2",
+		Status:        domain.WebhookDeliveryStatusDelivered,
+		NextAttemptAt: now.Add(-time.Second),
+	})
+	assert.NoError(t, err)
+
+	claimed, err := wr.ClaimDue(ctx, now, now.Add(time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, dueId, claimed[0].Id)
+
+	// leased until the send finish
+	claimed, err = wr.ClaimDue(ctx, now, now.Add(time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	_, err = wr.InsertAttempt(ctx, domain.WebhookDeliveryAttempt{DeliveryId: dueId, Attempt: 2, StatusCode: 200})
+	assert.NoError(t, err)
+	_, err = wr.InsertAttempt(ctx, domain.WebhookDeliveryAttempt{DeliveryId: dueId, Attempt: 1, Error: "timeout"})
+	assert.NoError(t, err)
+
+	attempts, err := wr.ListAttempts(ctx, dueId)
+	assert.NoError(t, err)
+	assert.Len(t, attempts, 2)
+	assert.Equal(t, 1, attempts[0].Attempt)
+	assert.Equal(t, 2, attempts[1].Attempt)
+}
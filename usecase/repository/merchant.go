@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+)
+
+type merchantRepository struct {
+	db database.SQLDatabase
+}
+
+type MerchantDeps struct {
+	DB database.SQLDatabase
+}
+
+func NewMerchant(deps MerchantDeps) *merchantRepository {
+	return &merchantRepository{
+		db: deps.DB,
+	}
+}
+
+func (mr merchantRepository) WithTx(Tx database.SQLDatabase) Merchant {
+	return merchantRepository{
+		db: Tx,
+	}
+}
+
+func (mr merchantRepository) Insert(ctx context.Context, merchant domain.Merchant) (string, error) {
+	var merchantId string
+
+	err := mr.db.Query(ctx, queryInsertMerchant, merchant.Name, toModelMerchantSettings(merchant.Settings)).Scan(&merchantId)
+	if err != nil {
+		return "", err
+	}
+
+	return merchantId, nil
+}
+
+func (mr merchantRepository) GetById(ctx context.Context, id string) (*domain.Merchant, error) {
+	var res model.Merchant
+
+	err := mr.db.Get(ctx, &res, querySelectMerchantById, id)
+	if err != nil {
+		if errors.Is(err, database.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	merchant := toDomainMerchant(res)
+	return &merchant, nil
+}
+
+func (mr merchantRepository) LockById(ctx context.Context, id string) error {
+	var merchantId string
+
+	err := mr.db.Get(ctx, &merchantId, queryLockMerchantById, id)
+	if err != nil {
+		if errors.Is(err, database.ErrNoRows) {
+			return internal_error.ErrMerchantNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (mr merchantRepository) List(ctx context.Context) ([]domain.Merchant, error) {
+	var rows []model.Merchant
+
+	err := mr.db.Select(ctx, &rows, querySelectMerchants)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Merchant, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, toDomainMerchant(row))
+	}
+
+	return res, nil
+}
+
+func (mr merchantRepository) UpdateById(ctx context.Context, id string, updatedData domain.Merchant) error {
+	res, err := mr.db.Exec(ctx, queryUpdateMerchant, updatedData.Name, toModelMerchantSettings(updatedData.Settings), id)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func toModelMerchantSettings(settings domain.MerchantSettings) model.MerchantSettings {
+	return model.MerchantSettings{
+		AllowedBankCodes:     settings.AllowedBankCodes,
+		MaxAmount:            settings.MaxAmount,
+		DailyLimit:           settings.DailyLimit,
+		NameMatchRejectBelow: settings.NameMatchRejectBelow,
+		NameMatchReviewBelow: settings.NameMatchReviewBelow,
+	}
+}
+
+func toDomainMerchant(row model.Merchant) domain.Merchant {
+	return domain.Merchant{
+		Id:   row.Id,
+		Name: row.Name,
+		Settings: domain.MerchantSettings{
+			AllowedBankCodes:     row.Settings.AllowedBankCodes,
+			MaxAmount:            row.Settings.MaxAmount,
+			DailyLimit:           row.Settings.DailyLimit,
+			NameMatchRejectBelow: row.Settings.NameMatchRejectBelow,
+			NameMatchReviewBelow: row.Settings.NameMatchReviewBelow,
+		},
+		CreatedAt: row.CreatedAt,
+	}
+}
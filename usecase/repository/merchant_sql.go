@@ -0,0 +1,52 @@
+package repository
+
+const (
+	queryInsertMerchant = `
+	INSERT INTO
+		merchant
+		(
+		 name,
+		 settings,
+		 created_at,
+		 updated_at
+		 )
+	VALUES
+		($1, $2, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	RETURNING
+		id`
+
+	queryUpdateMerchant = `
+	UPDATE
+		merchant
+	SET
+		name = $1,
+		settings = $2,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id = $3`
+
+	querySelectMerchantById = `
+	SELECT
+		*
+	FROM
+		merchant
+	WHERE
+		id = $1`
+
+	queryLockMerchantById = `
+	SELECT
+		id
+	FROM
+		merchant
+	WHERE
+		id = $1
+	FOR UPDATE`
+
+	querySelectMerchants = `
+	SELECT
+		*
+	FROM
+		merchant
+	ORDER BY
+		created_at`
+)
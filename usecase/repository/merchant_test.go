@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/mock"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_merchantRepository_GetById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		want    *domain.Merchant
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "get existing merchant",
+			want: &domain.Merchant{
+				Id:        "merchant-1",
+				Name:      "Toko Nobby",
+				Settings:  domain.MerchantSettings{AllowedBankCodes: []string{"BCA"}, DailyLimit: 1000000, NameMatchRejectBelow: 50, NameMatchReviewBelow: 80},
+				CreatedAt: createdAt,
+			},
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Eq(&model.Merchant{}), `
+	SELECT
+		*
+	FROM
+		merchant
+	WHERE
+		id = $1`, "merchant-1").DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+					res := dest.(*model.Merchant)
+					res.Id = "merchant-1"
+					res.Name = "Toko Nobby"
+					res.CreatedAt = createdAt
+					return res.Settings.Scan([]byte(`{"allowed_bank_codes":["BCA"],"daily_limit":1000000,"name_match_reject_below":50,"name_match_review_below":80}`))
+				})
+			},
+		},
+		{
+			name: "non existing merchant",
+			want: nil,
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), "merchant-1").Return(sql.ErrNoRows)
+			},
+		},
+		{
+			name:    "unknown error from driver",
+			wantErr: errors.New("sql error"),
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), "merchant-1").Return(errors.New("sql error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			mr := NewMerchant(MerchantDeps{DB: mockDB})
+			got, err := mr.GetById(context.TODO(), "merchant-1")
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_merchantRepository_LockById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+
+	tests := []struct {
+		name    string
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "lock existing merchant",
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), `
+	SELECT
+		id
+	FROM
+		merchant
+	WHERE
+		id = $1
+	FOR UPDATE`, "merchant-1").Return(nil)
+			},
+		},
+		{
+			name:    "non existing merchant",
+			wantErr: internal_error.ErrMerchantNotFound,
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), "merchant-1").Return(sql.ErrNoRows)
+			},
+		},
+		{
+			name:    "unknown error from driver",
+			wantErr: errors.New("sql error"),
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), "merchant-1").Return(errors.New("sql error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			mr := NewMerchant(MerchantDeps{DB: mockDB})
+			err := mr.LockById(context.TODO(), "merchant-1")
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_merchantRepository_UpdateById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	mockResult := mock.NewMockResult(ctrl)
+
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{
+			name:         "success update",
+			rowsAffected: 1,
+		},
+		{
+			name:         "merchant does not exist",
+			rowsAffected: 0,
+			wantErr:      internal_error.ErrNoRowsAffected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockResult.EXPECT().RowsAffected().Return(tt.rowsAffected, nil)
+			mockDB.EXPECT().Exec(gomock.Any(), `
+	UPDATE
+		merchant
+	SET
+		name = $1,
+		settings = $2,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id = $3`, "Toko Nobby", model.MerchantSettings{MaxAmount: 500000}, "merchant-1").Return(mockResult, nil)
+
+			mr := NewMerchant(MerchantDeps{DB: mockDB})
+			err := mr.UpdateById(context.TODO(), "merchant-1", domain.Merchant{
+				Name:     "Toko Nobby",
+				Settings: domain.MerchantSettings{MaxAmount: 500000},
+			})
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
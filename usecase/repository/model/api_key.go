@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type ApiKey struct {
+	Id         string     `db:"id"`
+	ClientId   string     `db:"client_id"`
+	MerchantId *string    `db:"merchant_id"`
+	Name       string     `db:"name"`
+	Role       string     `db:"role"`
+	Prefix     string     `db:"prefix"`
+	KeyHash    string     `db:"key_hash"`
+	ExpiresAt  *time.Time `db:"expires_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type AuditLog struct {
+	Id         string    `db:"id"`
+	ActorId    string    `db:"actor_id"`
+	ActorRole  string    `db:"actor_role"`
+	ApiKeyId   *string   `db:"api_key_id"`
+	MerchantId *string   `db:"merchant_id"`
+	Action     string    `db:"action"`
+	ResourceId string    `db:"resource_id"`
+	Outcome    string    `db:"outcome"`
+	Reason     string    `db:"reason"`
+	CreatedAt  time.Time `db:"created_at"`
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+type Beneficiary struct {
+	Id                 string     `db:"id"`
+	MerchantId         string     `db:"merchant_id"`
+	Name               string     `db:"name"`
+	AccountNumber      string     `db:"account_number"`
+	BankCode           string     `db:"bank_code"`
+	EncryptionKeyId    *string    `db:"encryption_key_id"`
+	EncryptedDataKey   *string    `db:"encrypted_data_key"`
+	VerificationStatus string     `db:"verification_status"`
+	AccountHolderName  string     `db:"account_holder_name"`
+	NameMatchScore     int        `db:"name_match_score"`
+	VerifiedAt         time.Time  `db:"verified_at"`
+	CreatedAt          time.Time  `db:"created_at"`
+	UpdatedAt          time.Time  `db:"updated_at"`
+	DeletedAt          *time.Time `db:"deleted_at"`
+}
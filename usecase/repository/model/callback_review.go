@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+type CallbackReview struct {
+	Id             string     `db:"id"`
+	TransactionId  string     `db:"transaction_id"`
+	DisbursementId *string    `db:"disbursement_id"`
+	Reason         string     `db:"reason"`
+	Detail         string     `db:"detail"`
+	Payload        []byte     `db:"payload"`
+	Status         string     `db:"status"`
+	AssigneeId     *string    `db:"assignee_id"`
+	ResolvedBy     *string    `db:"resolved_by"`
+	ResolutionNote *string    `db:"resolution_note"`
+	ResolvedAt     *time.Time `db:"resolved_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+type CallbackReviewComment struct {
+	Id        string    `db:"id"`
+	ReviewId  string    `db:"review_id"`
+	AuthorId  string    `db:"author_id"`
+	Body      string    `db:"body"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type CallbackReviewCount struct {
+	Reason     string `db:"reason"`
+	Open       int64  `db:"open"`
+	Unassigned int64  `db:"unassigned"`
+}
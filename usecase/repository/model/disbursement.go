@@ -3,13 +3,21 @@ package model
 import "time"
 
 type Disbursement struct {
-	Id                     string    `db:"id"`
-	RecipientName          string    `db:"recipient_name"`
-	RecipientAccountNumber string    `db:"recipient_account_number"`
-	RecipientBankCode      string    `db:"recipient_bank_code"`
-	BankTransactionId      string    `db:"bank_transaction_id"`
-	Amount                 int64     `db:"amount"`
-	Status                 int       `db:"status"`
-	CreatedAt              time.Time `db:"created_at"`
-	UpdatedAt              time.Time `db:"updated_at"`
+	Id                         string    `db:"id"`
+	MerchantId                 *string   `db:"merchant_id"`
+	RecipientName              string    `db:"recipient_name"`
+	RecipientAccountNumber     string    `db:"recipient_account_number"`
+	RecipientAccountNumberBidx *string   `db:"recipient_account_number_bidx"`
+	RecipientBankCode          string    `db:"recipient_bank_code"`
+	BankTransactionId          string    `db:"bank_transaction_id"`
+	Amount                     int64     `db:"amount"`
+	Status                     int       `db:"status"`
+	BankEvidenceReference      *string   `db:"bank_evidence_reference"`
+	Version                    int64     `db:"version"`
+	EncryptionKeyId            *string   `db:"encryption_key_id"`
+	EncryptedDataKey           *string   `db:"encrypted_data_key"`
+	NameMatchScore             *int      `db:"name_match_score"`
+	NameMatchDecision          *string   `db:"name_match_decision"`
+	CreatedAt                  time.Time `db:"created_at"`
+	UpdatedAt                  time.Time `db:"updated_at"`
 }
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type DisbursementStatusEvent struct {
+	Id             int64     `db:"id"`
+	DisbursementId string    `db:"disbursement_id"`
+	MerchantId     *string   `db:"merchant_id"`
+	Status         int       `db:"status"`
+	PreviousStatus int       `db:"previous_status"`
+	Version        int64     `db:"version"`
+	TransactionId  int64     `db:"transaction_id"`
+	CreatedAt      time.Time `db:"created_at"`
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type Merchant struct {
+	Id        string           `db:"id"`
+	Name      string           `db:"name"`
+	Settings  MerchantSettings `db:"settings"`
+	CreatedAt time.Time        `db:"created_at"`
+	UpdatedAt time.Time        `db:"updated_at"`
+}
+
+// MerchantSettings is stored as jsonb
+type MerchantSettings struct {
+	AllowedBankCodes []string `json:"allowed_bank_codes,omitempty"`
+	MaxAmount        int64    `json:"max_amount,omitempty"`
+	DailyLimit       int64    `json:"daily_limit,omitempty"`
+	// absent in settings written before name matching, 0 disable the threshold
+	NameMatchRejectBelow int `json:"name_match_reject_below,omitempty"`
+	NameMatchReviewBelow int `json:"name_match_review_below,omitempty"`
+}
+
+func (settings MerchantSettings) Value() (driver.Value, error) {
+	res, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(res), nil
+}
+
+func (settings *MerchantSettings) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case []byte:
+		return json.Unmarshal(value, settings)
+	case string:
+		return json.Unmarshal([]byte(value), settings)
+	case nil:
+		*settings = MerchantSettings{}
+		return nil
+	default:
+		return errors.New("unsupported merchant settings type")
+	}
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type OutboxEvent struct {
+	Id            int64      `db:"id"`
+	EventType     string     `db:"event_type"`
+	AggregateId   string     `db:"aggregate_id"`
+	Payload       []byte     `db:"payload"`
+	OccurredAt    time.Time  `db:"occurred_at"`
+	Attempts      int        `db:"attempts"`
+	NextAttemptAt time.Time  `db:"next_attempt_at"`
+	LastError     *string    `db:"last_error"`
+	PublishedAt   *time.Time `db:"published_at"`
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+type ScreeningEntry struct {
+	Id               string    `db:"id"`
+	ListName         string    `db:"list_name"`
+	Name             string    `db:"name"`
+	AccountNumber    string    `db:"account_number"`
+	BankCode         string    `db:"bank_code"`
+	Reference        string    `db:"reference"`
+	EncryptionKeyId  *string   `db:"encryption_key_id"`
+	EncryptedDataKey *string   `db:"encrypted_data_key"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+type Screening struct {
+	Id               string     `db:"id"`
+	DisbursementId   string     `db:"disbursement_id"`
+	MerchantId       *string    `db:"merchant_id"`
+	Status           string     `db:"status"`
+	Matches          string     `db:"matches"`
+	EncryptionKeyId  *string    `db:"encryption_key_id"`
+	EncryptedDataKey *string    `db:"encrypted_data_key"`
+	ResolvedBy       *string    `db:"resolved_by"`
+	ResolutionNote   *string    `db:"resolution_note"`
+	ResolvedAt       *time.Time `db:"resolved_at"`
+	CreatedAt        time.Time  `db:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at"`
+}
+
+// ScreeningMatch is stored as json in Screening.Matches
+type ScreeningMatch struct {
+	EntryId   string `json:"entry_id"`
+	ListName  string `json:"list_name"`
+	EntryName string `json:"entry_name"`
+	Reference string `json:"reference"`
+	Field     string `json:"field"`
+	Score     int    `json:"score"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type VerificationCache struct {
+	BankCode          string    `db:"bank_code"`
+	AccountNumberBidx string    `db:"account_number_bidx"`
+	AccountHolderName string    `db:"account_holder_name"`
+	EncryptionKeyId   *string   `db:"encryption_key_id"`
+	EncryptedDataKey  *string   `db:"encrypted_data_key"`
+	AccountStatus     string    `db:"account_status"`
+	ExpiresAt         time.Time `db:"expires_at"`
+	CreatedAt         time.Time `db:"created_at"`
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type WebhookEndpoint struct {
+	Id               string            `db:"id"`
+	MerchantId       string            `db:"merchant_id"`
+	Url              string            `db:"url"`
+	Secret           string            `db:"secret"`
+	EncryptionKeyId  *string           `db:"encryption_key_id"`
+	EncryptedDataKey *string           `db:"encrypted_data_key"`
+	EventTypes       WebhookEventTypes `db:"event_types"`
+	CreatedAt        time.Time         `db:"created_at"`
+	UpdatedAt        time.Time         `db:"updated_at"`
+	DeletedAt        *time.Time        `db:"deleted_at"`
+}
+
+// WebhookEventTypes is stored as jsonb array
+type WebhookEventTypes []string
+
+func (eventTypes WebhookEventTypes) Value() (driver.Value, error) {
+	if eventTypes == nil {
+		eventTypes = WebhookEventTypes{}
+	}
+
+	res, err := json.Marshal([]string(eventTypes))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(res), nil
+}
+
+func (eventTypes *WebhookEventTypes) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case []byte:
+		return json.Unmarshal(value, (*[]string)(eventTypes))
+	case string:
+		return json.Unmarshal([]byte(value), (*[]string)(eventTypes))
+	case nil:
+		*eventTypes = nil
+		return nil
+	default:
+		return errors.New("unsupported webhook event types type")
+	}
+}
+
+type WebhookDelivery struct {
+	Id             string     `db:"id"`
+	EndpointId     string     `db:"endpoint_id"`
+	MerchantId     string     `db:"merchant_id"`
+	EventId        string     `db:"event_id"`
+	EventType      string     `db:"event_type"`
+	DisbursementId string     `db:"disbursement_id"`
+	Payload        []byte     `db:"payload"`
+	Status         string     `db:"status"`
+	Attempts       int        `db:"attempts"`
+	NextAttemptAt  time.Time  `db:"next_attempt_at"`
+	LastStatusCode *int       `db:"last_status_code"`
+	LastError      *string    `db:"last_error"`
+	DeliveredAt    *time.Time `db:"delivered_at"`
+	ReplayOf       *string    `db:"replay_of"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+type WebhookDeliveryAttempt struct {
+	Id         string    `db:"id"`
+	DeliveryId string    `db:"delivery_id"`
+	Attempt    int       `db:"attempt"`
+	StatusCode *int      `db:"status_code"`
+	Error      *string   `db:"error"`
+	DurationMs int64     `db:"duration_ms"`
+	CreatedAt  time.Time `db:"created_at"`
+}
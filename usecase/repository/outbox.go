@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+	"sort"
+	"time"
+)
+
+type outboxRepository struct {
+	db database.SQLDatabase
+}
+
+type OutboxDeps struct {
+	DB database.SQLDatabase
+}
+
+func NewOutbox(deps OutboxDeps) *outboxRepository {
+	return &outboxRepository{
+		db: deps.DB,
+	}
+}
+
+func (ob outboxRepository) WithTx(Tx database.SQLDatabase) Outbox {
+	return outboxRepository{
+		db: Tx,
+	}
+}
+
+func (ob outboxRepository) Insert(ctx context.Context, event domain.OutboxEvent) error {
+	_, err := ob.db.Exec(
+		ctx,
+		queryInsertOutboxEvent,
+		string(event.Type),
+		event.AggregateId,
+		string(event.Payload),
+		event.OccurredAt,
+	)
+
+	return err
+}
+
+func (ob outboxRepository) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]domain.OutboxEvent, error) {
+	var rows []model.OutboxEvent
+
+	err := ob.db.Select(ctx, &rows, queryClaimDueOutboxEvents, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// RETURNING does not keep the order of the subquery
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Id < rows[j].Id
+	})
+
+	res := make([]domain.OutboxEvent, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, domain.OutboxEvent{
+			Id:            row.Id,
+			Type:          domain.EventType(row.EventType),
+			AggregateId:   row.AggregateId,
+			Payload:       row.Payload,
+			OccurredAt:    row.OccurredAt,
+			Attempts:      row.Attempts,
+			NextAttemptAt: row.NextAttemptAt,
+			LastError:     stringValue(row.LastError),
+			PublishedAt:   row.PublishedAt,
+		})
+	}
+
+	return res, nil
+}
+
+func (ob outboxRepository) UpdateById(ctx context.Context, id int64, updatedData domain.OutboxEvent) error {
+	res, err := ob.db.Exec(
+		ctx,
+		queryUpdateOutboxEvent,
+		updatedData.Attempts,
+		updatedData.NextAttemptAt,
+		nullableString(updatedData.LastError),
+		updatedData.PublishedAt,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func (ob outboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := ob.db.Exec(ctx, queryDeletePublishedOutboxEvents, before)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,67 @@
+package repository
+
+const (
+	queryInsertOutboxEvent = `
+	INSERT INTO
+		outbox
+		(
+		 event_type,
+		 aggregate_id,
+		 payload,
+		 occurred_at,
+		 next_attempt_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $4)`
+
+	// an event waiting for an older unpublished event of the same aggregate is skipped, including an event being
+	// published by another relay, so the events of an aggregate are published one at a time in order
+	queryClaimDueOutboxEvents = `
+	UPDATE
+		outbox
+	SET
+		next_attempt_at = $2
+	WHERE
+		id IN (
+			SELECT
+				id
+			FROM
+				outbox o
+			WHERE
+				published_at IS NULL
+				AND next_attempt_at <= $1
+				AND NOT EXISTS (
+					SELECT
+						1
+					FROM
+						outbox older
+					WHERE
+						older.aggregate_id = o.aggregate_id
+						AND older.id < o.id
+						AND older.published_at IS NULL
+				)
+			ORDER BY
+				id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+	RETURNING
+		*`
+
+	queryUpdateOutboxEvent = `
+	UPDATE
+		outbox
+	SET
+		attempts = $1,
+		next_attempt_at = $2,
+		last_error = $3,
+		published_at = $4
+	WHERE
+		id = $5`
+
+	queryDeletePublishedOutboxEvents = `
+	DELETE FROM
+		outbox
+	WHERE
+		published_at < $1`
+)
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/mock"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_outboxRepository_ClaimDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	leaseUntil := now.Add(time.Minute)
+	lastError := "connection refused"
+
+	mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), `
+	UPDATE
+		outbox
+	SET
+		next_attempt_at = $2
+	WHERE
+		id IN (
+			SELECT
+				id
+			FROM
+				outbox o
+			WHERE
+				published_at IS NULL
+				AND next_attempt_at <= $1
+				AND NOT EXISTS (
+					SELECT
+						1
+					FROM
+						outbox older
+					WHERE
+						older.aggregate_id = o.aggregate_id
+						AND older.id < o.id
+						AND older.published_at IS NULL
+				)
+			ORDER BY
+				id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+	RETURNING
+		*`, now, leaseUntil, 10).DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+		rows := dest.(*[]model.OutboxEvent)
+		*rows = []model.OutboxEvent{
+			{Id: 7, EventType: "DisbursementStatusChanged", AggregateId: "disb-2", Payload: []byte(`{}`), OccurredAt: now, NextAttemptAt: leaseUntil},
+			{Id: 5, EventType: "DisbursementCreated", AggregateId: "disb-1", Payload: []byte(`{}`), OccurredAt: now, Attempts: 2, NextAttemptAt: leaseUntil, LastError: &lastError},
+		}
+		return nil
+	})
+
+	ob := NewOutbox(OutboxDeps{DB: mockDB})
+	got, err := ob.ClaimDue(context.TODO(), now, leaseUntil, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.OutboxEvent{
+		{Id: 5, Type: domain.EventTypeDisbursementCreated, AggregateId: "disb-1", Payload: []byte(`{}`), OccurredAt: now, Attempts: 2, NextAttemptAt: leaseUntil, LastError: lastError},
+		{Id: 7, Type: domain.EventTypeDisbursementStatusChanged, AggregateId: "disb-2", Payload: []byte(`{}`), OccurredAt: now, NextAttemptAt: leaseUntil},
+	}, got, "should be sorted by id")
+}
+
+func Test_outboxRepository_UpdateById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	mockResult := mock.NewMockResult(ctrl)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{
+			name:         "mark published",
+			rowsAffected: 1,
+		},
+		{
+			name:         "event deleted",
+			rowsAffected: 0,
+			wantErr:      internal_error.ErrNoRowsAffected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockResult.EXPECT().RowsAffected().Return(tt.rowsAffected, nil)
+			mockDB.EXPECT().Exec(gomock.Any(), `
+	UPDATE
+		outbox
+	SET
+		attempts = $1,
+		next_attempt_at = $2,
+		last_error = $3,
+		published_at = $4
+	WHERE
+		id = $5`, 1, now, (*string)(nil), &now, int64(5)).Return(mockResult, nil)
+
+			ob := NewOutbox(OutboxDeps{DB: mockDB})
+			err := ob.UpdateById(context.TODO(), 5, domain.OutboxEvent{
+				Attempts:      1,
+				NextAttemptAt: now,
+				PublishedAt:   &now,
+			})
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
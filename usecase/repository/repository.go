@@ -4,15 +4,183 @@ import (
 	"context"
 	"github.com/nobbyphala/Brick/domain"
 	"github.com/nobbyphala/Brick/external/database"
+	"time"
 )
 
+// Disbursement queries are scoped to the merchant of the caller in ctx, see domain.MerchantScopeFromContext
 type Disbursement interface {
 	WithTx(Tx database.SQLDatabase) Disbursement
 	Insert(ctx context.Context, disbursement domain.Disbursement) (string, error)
-	UpdateById(ctx context.Context, id string, updatedData domain.Disbursement) error
+	// UpdateById return internal_error.ErrVersionConflict when the stored version is not expectedVersion
+	UpdateById(ctx context.Context, id string, expectedVersion int64, updatedData domain.Disbursement) error
+	GetById(ctx context.Context, id string) (*domain.Disbursement, error)
 	GetByTransactionId(ctx context.Context, bankTransactionId string) (*domain.Disbursement, error)
+	// GetByRecipientAccountNumber find by exact account number using the blind index, without decrypting every row
+	GetByRecipientAccountNumber(ctx context.Context, accountNumber string) ([]domain.Disbursement, error)
+	// ListNeedReEncryption return up to limit disbursements not encrypted with the current key.
+	// Updating them re-encrypt with the current key
+	ListNeedReEncryption(ctx context.Context, limit int) ([]domain.Disbursement, error)
+	// SumAmountSince return total amount of disbursements created since the given time, excluding failed and rejected
+	SumAmountSince(ctx context.Context, since time.Time) (int64, error)
+	List(ctx context.Context, filter domain.DisbursementFilter) ([]domain.Disbursement, error)
+}
+
+// DisbursementStatusEvent read the status changes recorded when the status of a disbursement is updated,
+// queries are scoped to the merchant of the caller in ctx
+type DisbursementStatusEvent interface {
+	// List return the events following the event filter.AfterId in commit order, oldest first
+	List(ctx context.Context, filter domain.DisbursementStatusEventFilter) ([]domain.DisbursementStatusEvent, error)
+	// LastId return id of the newest readable event of every merchant, 0 when there is no event
+	LastId(ctx context.Context) (int64, error)
+}
+
+// DisbursementStatusEventListener notify when new disbursement status events are committed
+type DisbursementStatusEventListener interface {
+	// Listen return a channel receiving a value after new events committed, several commits may be notified once.
+	// The channel is closed when ctx done or the connection lost
+	Listen(ctx context.Context) (<-chan struct{}, error)
+}
+
+type Merchant interface {
+	WithTx(Tx database.SQLDatabase) Merchant
+	Insert(ctx context.Context, merchant domain.Merchant) (string, error)
+	GetById(ctx context.Context, id string) (*domain.Merchant, error)
+	// LockById lock the merchant until the transaction end, return internal_error.ErrMerchantNotFound when the
+	// merchant does not exist
+	LockById(ctx context.Context, id string) error
+	List(ctx context.Context) ([]domain.Merchant, error)
+	// UpdateById update name and settings of the merchant
+	UpdateById(ctx context.Context, id string, updatedData domain.Merchant) error
+}
+
+type ApiKey interface {
+	WithTx(Tx database.SQLDatabase) ApiKey
+	Insert(ctx context.Context, apiKey domain.ApiKey) (string, error)
+	GetById(ctx context.Context, id string) (*domain.ApiKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*domain.ApiKey, error)
+	ListByClientId(ctx context.Context, clientId string) ([]domain.ApiKey, error)
+	// UpdateById update name, role, expiry and revocation of the key
+	UpdateById(ctx context.Context, id string, updatedData domain.ApiKey) error
+	UpdateLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) error
+}
+
+// AuditLog is append only, audit logs are never updated or deleted
+type AuditLog interface {
+	WithTx(Tx database.SQLDatabase) AuditLog
+	Insert(ctx context.Context, auditLog domain.AuditLog) (string, error)
+	// List return the newest logs matching the filter first
+	List(ctx context.Context, filter domain.AuditLogFilter) ([]domain.AuditLog, error)
+}
+
+// CallbackReview store bank callbacks parked for manual review
+type CallbackReview interface {
+	WithTx(Tx database.SQLDatabase) CallbackReview
+	Insert(ctx context.Context, review domain.CallbackReview) (string, error)
+	// UpdateById update assignee and resolution of an open review, return internal_error.ErrNoRowsAffected
+	// when the review is not open anymore
+	UpdateById(ctx context.Context, id string, updatedData domain.CallbackReview) error
+	GetById(ctx context.Context, id string) (*domain.CallbackReview, error)
+	// GetOpen return the open review of the transaction parked for the reason
+	GetOpen(ctx context.Context, transactionId string, reason domain.CallbackReviewReason) (*domain.CallbackReview, error)
+	// List return the oldest reviews matching the filter first
+	List(ctx context.Context, filter domain.CallbackReviewFilter) ([]domain.CallbackReview, error)
+	// CountOpen return the number of open reviews per reason, reason without open review is omitted
+	CountOpen(ctx context.Context) ([]domain.CallbackReviewCount, error)
+	InsertComment(ctx context.Context, comment domain.CallbackReviewComment) (string, error)
+	// ListComments return the comments of the review, oldest first
+	ListComments(ctx context.Context, reviewId string) ([]domain.CallbackReviewComment, error)
+}
+
+// WebhookEndpoint store the webhook endpoints of the merchants, deleted endpoints are not returned
+type WebhookEndpoint interface {
+	WithTx(Tx database.SQLDatabase) WebhookEndpoint
+	Insert(ctx context.Context, endpoint domain.WebhookEndpoint) (string, error)
+	GetById(ctx context.Context, id string) (*domain.WebhookEndpoint, error)
+	ListByMerchantId(ctx context.Context, merchantId string) ([]domain.WebhookEndpoint, error)
+	// UpdateById update url and event types of the endpoint
+	UpdateById(ctx context.Context, id string, updatedData domain.WebhookEndpoint) error
+	DeleteById(ctx context.Context, id string) error
+}
+
+// WebhookDelivery is the outbox of the webhook events and their delivery logs
+type WebhookDelivery interface {
+	WithTx(Tx database.SQLDatabase) WebhookDelivery
+	Insert(ctx context.Context, delivery domain.WebhookDelivery) (string, error)
+	// UpdateById update status, attempts and the result of the last attempt
+	UpdateById(ctx context.Context, id string, updatedData domain.WebhookDelivery) error
+	GetById(ctx context.Context, id string) (*domain.WebhookDelivery, error)
+	// List return the newest deliveries matching the filter first
+	List(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error)
+	// ClaimDue return up to limit pending deliveries due at now and move their next attempt to leaseUntil, so
+	// other workers skip them while they are being sent
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error)
+	InsertAttempt(ctx context.Context, attempt domain.WebhookDeliveryAttempt) (string, error)
+	// ListAttempts return the attempts of the delivery, oldest first
+	ListAttempts(ctx context.Context, deliveryId string) ([]domain.WebhookDeliveryAttempt, error)
 }
 
 type Utils interface {
-	RunWithTransaction(ctx context.Context, handler func(Tx database.SQLDatabase) error) error
+	// RunWithTransaction run handler inside a transaction. The ctx passed to handler carry the transaction, so
+	// calling RunWithTransaction again with that ctx create a savepoint instead of a new transaction.
+	RunWithTransaction(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...TxOption) error
+}
+
+// Outbox store the domain events until they are published by the relay
+type Outbox interface {
+	WithTx(Tx database.SQLDatabase) Outbox
+	Insert(ctx context.Context, event domain.OutboxEvent) error
+	// ClaimDue return up to limit unpublished events due at now, oldest first, and hide them from other relays until
+	// leaseUntil. An event is not claimed while an older event of the same aggregate is unpublished
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]domain.OutboxEvent, error)
+	// UpdateById update attempts, next attempt, last error and published time of the event
+	UpdateById(ctx context.Context, id int64, updatedData domain.OutboxEvent) error
+	// DeletePublishedBefore delete events published before the given time, return the number of deleted events
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Beneficiary store the saved recipients of the merchants, restricted to the merchant scope of ctx. Deleted
+// beneficiaries are not returned
+type Beneficiary interface {
+	Insert(ctx context.Context, beneficiary domain.Beneficiary) (string, error)
+	GetById(ctx context.Context, id string) (*domain.Beneficiary, error)
+	List(ctx context.Context, filter domain.BeneficiaryFilter) ([]domain.Beneficiary, error)
+	// UpdateById update the recipient and the last verification of the beneficiary
+	UpdateById(ctx context.Context, id string, updatedData domain.Beneficiary) error
+	DeleteById(ctx context.Context, id string) error
+}
+
+// VerificationCache store the bank account verification results by bank code and account number until they expire
+type VerificationCache interface {
+	// Get return nil when there is no result or it expired at now
+	Get(ctx context.Context, bankCode string, accountNumber string, now time.Time) (*domain.AccountVerification, error)
+	// Set replace the result of the same bank code and account number
+	Set(ctx context.Context, verification domain.AccountVerification) error
+	// Delete remove the result, deleting an account without result is not an error
+	Delete(ctx context.Context, bankCode string, accountNumber string) error
+}
+
+// ScreeningEntry store the entries of the imported sanctions lists and of the internal blocklist
+type ScreeningEntry interface {
+	WithTx(Tx database.SQLDatabase) ScreeningEntry
+	Insert(ctx context.Context, entry domain.ScreeningEntry) (string, error)
+	// DeleteById return internal_error.ErrNoRowsAffected when the list has no entry with the id
+	DeleteById(ctx context.Context, listName string, id string) error
+	// DeleteByListName remove every entry of the list, so an import replace the previous one
+	DeleteByListName(ctx context.Context, listName string) error
+	// List return the newest entries matching the filter first
+	List(ctx context.Context, filter domain.ScreeningEntryFilter) ([]domain.ScreeningEntry, error)
+	// ListAll return the entries of every list, loaded by the screener
+	ListAll(ctx context.Context) ([]domain.ScreeningEntry, error)
+}
+
+// Screening store the screening decision of every disbursement
+type Screening interface {
+	WithTx(Tx database.SQLDatabase) Screening
+	Insert(ctx context.Context, screening domain.Screening) (string, error)
+	// UpdateById update the resolution of a held screening, return internal_error.ErrNoRowsAffected when the
+	// screening is not held anymore
+	UpdateById(ctx context.Context, id string, updatedData domain.Screening) error
+	GetById(ctx context.Context, id string) (*domain.Screening, error)
+	// List return the oldest screenings matching the filter first
+	List(ctx context.Context, filter domain.ScreeningFilter) ([]domain.Screening, error)
 }
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+)
+
+// merchantScope return the merchant id tenant scoped queries are restricted to, nil when the caller can access
+// every merchant. Queries compare it with ($n::uuid IS NULL OR merchant_id = $n)
+func merchantScope(ctx context.Context) *string {
+	merchantId, ok := domain.MerchantScopeFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	return &merchantId
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
+func nullableInt(value int) *int {
+	if value == 0 {
+		return nil
+	}
+
+	return &value
+}
+
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+
+	return *value
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/crypto"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+)
+
+const defaultScreeningLimit = 100
+
+type screeningRepository struct {
+	db        database.SQLDatabase
+	encryptor crypto.FieldEncryptor
+}
+
+type ScreeningDeps struct {
+	DB database.SQLDatabase
+	// encrypt the matches before stored, they contain the names of the matched entries
+	Encryptor crypto.FieldEncryptor
+}
+
+func NewScreening(deps ScreeningDeps) *screeningRepository {
+	return &screeningRepository{
+		db:        deps.DB,
+		encryptor: deps.Encryptor,
+	}
+}
+
+func (sr screeningRepository) WithTx(Tx database.SQLDatabase) Screening {
+	return screeningRepository{
+		db:        Tx,
+		encryptor: sr.encryptor,
+	}
+}
+
+func (sr screeningRepository) Insert(ctx context.Context, screening domain.Screening) (string, error) {
+	var screeningId string
+
+	matches := make([]model.ScreeningMatch, 0, len(screening.Matches))
+	for _, match := range screening.Matches {
+		matches = append(matches, model.ScreeningMatch{
+			EntryId:   match.EntryId,
+			ListName:  match.ListName,
+			EntryName: match.EntryName,
+			Reference: match.Reference,
+			Field:     string(match.Field),
+			Score:     match.Score,
+		})
+	}
+
+	matchesJSON, err := json.Marshal(matches)
+	if err != nil {
+		return "", err
+	}
+
+	fields, err := sr.encryptor.Encrypt(ctx, string(matchesJSON))
+	if err != nil {
+		return "", err
+	}
+
+	err = sr.db.Query(
+		ctx,
+		queryInsertScreening,
+		screening.DisbursementId,
+		nullableString(screening.MerchantId),
+		string(screening.Status),
+		fields.Values[0],
+		nullableString(fields.KeyId),
+		nullableString(fields.DataKey),
+	).Scan(&screeningId)
+	if err != nil {
+		return "", err
+	}
+
+	return screeningId, nil
+}
+
+func (sr screeningRepository) UpdateById(ctx context.Context, id string, updatedData domain.Screening) error {
+	res, err := sr.db.Exec(
+		ctx,
+		queryUpdateScreening,
+		string(updatedData.Status),
+		nullableString(updatedData.ResolvedBy),
+		nullableString(updatedData.ResolutionNote),
+		updatedData.ResolvedAt,
+		id,
+		string(domain.ScreeningStatusHeld),
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func (sr screeningRepository) GetById(ctx context.Context, id string) (*domain.Screening, error) {
+	var res model.Screening
+
+	err := sr.db.Get(ctx, &res, querySelectScreeningById, id)
+	if err != nil {
+		if errors.Is(err, database.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return sr.toDomain(ctx, res)
+}
+
+func (sr screeningRepository) List(ctx context.Context, filter domain.ScreeningFilter) ([]domain.Screening, error) {
+	var rows []model.Screening
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultScreeningLimit
+	}
+
+	err := sr.db.Select(ctx, &rows, querySelectScreenings, string(filter.Status), filter.DisbursementId, limit, filter.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Screening, 0, len(rows))
+	for _, row := range rows {
+		screening, err := sr.toDomain(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *screening)
+	}
+
+	return res, nil
+}
+
+func (sr screeningRepository) toDomain(ctx context.Context, row model.Screening) (*domain.Screening, error) {
+	fields, err := sr.encryptor.Decrypt(ctx, crypto.EncryptedFields{
+		KeyId:   stringValue(row.EncryptionKeyId),
+		DataKey: stringValue(row.EncryptedDataKey),
+		Values:  []string{row.Matches},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []model.ScreeningMatch
+	err = json.Unmarshal([]byte(fields[0]), &matches)
+	if err != nil {
+		return nil, err
+	}
+
+	screening := &domain.Screening{
+		Id:             row.Id,
+		DisbursementId: row.DisbursementId,
+		MerchantId:     stringValue(row.MerchantId),
+		Status:         domain.ScreeningStatus(row.Status),
+		ResolvedBy:     stringValue(row.ResolvedBy),
+		ResolutionNote: stringValue(row.ResolutionNote),
+		ResolvedAt:     row.ResolvedAt,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+
+	for _, match := range matches {
+		screening.Matches = append(screening.Matches, domain.ScreeningMatch{
+			EntryId:   match.EntryId,
+			ListName:  match.ListName,
+			EntryName: match.EntryName,
+			Reference: match.Reference,
+			Field:     domain.ScreeningMatchField(match.Field),
+			Score:     match.Score,
+		})
+	}
+
+	return screening, nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/crypto"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+)
+
+const defaultScreeningEntryLimit = 100
+
+type screeningEntryRepository struct {
+	db        database.SQLDatabase
+	encryptor crypto.FieldEncryptor
+}
+
+type ScreeningEntryDeps struct {
+	DB database.SQLDatabase
+	// encrypt name and account number before stored
+	Encryptor crypto.FieldEncryptor
+}
+
+func NewScreeningEntry(deps ScreeningEntryDeps) *screeningEntryRepository {
+	return &screeningEntryRepository{
+		db:        deps.DB,
+		encryptor: deps.Encryptor,
+	}
+}
+
+func (sr screeningEntryRepository) WithTx(Tx database.SQLDatabase) ScreeningEntry {
+	return screeningEntryRepository{
+		db:        Tx,
+		encryptor: sr.encryptor,
+	}
+}
+
+func (sr screeningEntryRepository) Insert(ctx context.Context, entry domain.ScreeningEntry) (string, error) {
+	var entryId string
+
+	fields, err := sr.encryptor.Encrypt(ctx, entry.Name, entry.AccountNumber)
+	if err != nil {
+		return "", err
+	}
+
+	err = sr.db.Query(
+		ctx,
+		queryInsertScreeningEntry,
+		entry.ListName,
+		fields.Values[0],
+		fields.Values[1],
+		entry.BankCode,
+		entry.Reference,
+		nullableString(fields.KeyId),
+		nullableString(fields.DataKey),
+	).Scan(&entryId)
+	if err != nil {
+		return "", err
+	}
+
+	return entryId, nil
+}
+
+func (sr screeningEntryRepository) DeleteById(ctx context.Context, listName string, id string) error {
+	res, err := sr.db.Exec(ctx, queryDeleteScreeningEntry, id, listName)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func (sr screeningEntryRepository) DeleteByListName(ctx context.Context, listName string) error {
+	_, err := sr.db.Exec(ctx, queryDeleteScreeningEntriesByListName, listName)
+	return err
+}
+
+func (sr screeningEntryRepository) List(ctx context.Context, filter domain.ScreeningEntryFilter) ([]domain.ScreeningEntry, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultScreeningEntryLimit
+	}
+
+	return sr.list(ctx, querySelectScreeningEntries, filter.ListName, limit, filter.Offset)
+}
+
+func (sr screeningEntryRepository) ListAll(ctx context.Context) ([]domain.ScreeningEntry, error) {
+	return sr.list(ctx, querySelectAllScreeningEntries)
+}
+
+func (sr screeningEntryRepository) list(ctx context.Context, query string, args ...interface{}) ([]domain.ScreeningEntry, error) {
+	var rows []model.ScreeningEntry
+
+	err := sr.db.Select(ctx, &rows, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.ScreeningEntry, 0, len(rows))
+	for _, row := range rows {
+		fields, err := sr.encryptor.Decrypt(ctx, crypto.EncryptedFields{
+			KeyId:   stringValue(row.EncryptionKeyId),
+			DataKey: stringValue(row.EncryptedDataKey),
+			Values:  []string{row.Name, row.AccountNumber},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, domain.ScreeningEntry{
+			Id:            row.Id,
+			ListName:      row.ListName,
+			Name:          fields[0],
+			AccountNumber: fields[1],
+			BankCode:      row.BankCode,
+			Reference:     row.Reference,
+			CreatedAt:     row.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
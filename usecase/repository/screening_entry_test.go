@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/crypto"
+	"github.com/nobbyphala/Brick/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func Test_screeningEntryRepository_Insert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	mockRow := mock.NewMockRow(ctrl)
+
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "entry-1"
+		return nil
+	})
+	mockDB.EXPECT().Query(gomock.Any(), `
+	INSERT INTO
+		screening_entry
+		(
+		 list_name,
+		 name,
+		 account_number,
+		 bank_code,
+		 reference,
+		 encryption_key_id,
+		 encrypted_data_key,
+		 created_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
+	RETURNING
+		id`, "OFAC", "Nobby Phala", "", "", "SDN-1", nil, nil).Return(mockRow)
+
+	sr := NewScreeningEntry(ScreeningEntryDeps{DB: mockDB, Encryptor: crypto.NewPlaintextEncryptor()})
+	got, err := sr.Insert(context.TODO(), domain.ScreeningEntry{
+		ListName:  "OFAC",
+		Name:      "Nobby Phala",
+		Reference: "SDN-1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "entry-1", got)
+}
+
+func Test_screeningEntryRepository_DeleteById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	mockResult := mock.NewMockResult(ctrl)
+
+	query := `
+	DELETE FROM
+		screening_entry
+	WHERE
+		id = $1
+		AND list_name = $2`
+
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{
+			name:         "success delete",
+			rowsAffected: 1,
+		},
+		{
+			name:         "entry not in the list",
+			rowsAffected: 0,
+			wantErr:      internal_error.ErrNoRowsAffected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockResult.EXPECT().RowsAffected().Return(tt.rowsAffected, nil)
+			mockDB.EXPECT().Exec(gomock.Any(), query, "entry-1", domain.ScreeningListInternal).Return(mockResult, nil)
+
+			sr := NewScreeningEntry(ScreeningEntryDeps{DB: mockDB, Encryptor: crypto.NewPlaintextEncryptor()})
+			err := sr.DeleteById(context.TODO(), domain.ScreeningListInternal, "entry-1")
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package repository
+
+const (
+	queryInsertScreeningEntry = `
+	INSERT INTO
+		screening_entry
+		(
+		 list_name,
+		 name,
+		 account_number,
+		 bank_code,
+		 reference,
+		 encryption_key_id,
+		 encrypted_data_key,
+		 created_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
+	RETURNING
+		id`
+
+	queryDeleteScreeningEntry = `
+	DELETE FROM
+		screening_entry
+	WHERE
+		id = $1
+		AND list_name = $2`
+
+	queryDeleteScreeningEntriesByListName = `
+	DELETE FROM
+		screening_entry
+	WHERE
+		list_name = $1`
+
+	// empty filter value match every row
+	querySelectScreeningEntries = `
+	SELECT
+		*
+	FROM
+		screening_entry
+	WHERE
+		($1 = '' OR list_name = $1)
+	ORDER BY
+		created_at DESC,
+		id DESC
+	LIMIT $2
+	OFFSET $3`
+
+	querySelectAllScreeningEntries = `
+	SELECT
+		*
+	FROM
+		screening_entry`
+
+	queryInsertScreening = `
+	INSERT INTO
+		screening
+		(
+		 disbursement_id,
+		 merchant_id,
+		 status,
+		 matches,
+		 encryption_key_id,
+		 encrypted_data_key,
+		 created_at,
+		 updated_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	RETURNING
+		id`
+
+	// resolved screening is final, the update is ignored when the screening is not held anymore
+	queryUpdateScreening = `
+	UPDATE
+		screening
+	SET
+		status = $1,
+		resolved_by = $2,
+		resolution_note = $3,
+		resolved_at = $4,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id = $5
+		AND status = $6`
+
+	querySelectScreeningById = `
+	SELECT
+		*
+	FROM
+		screening
+	WHERE
+		id = $1`
+
+	// empty filter value match every row
+	querySelectScreenings = `
+	SELECT
+		*
+	FROM
+		screening
+	WHERE
+		($1 = '' OR status = $1)
+		AND ($2 = '' OR disbursement_id::text = $2)
+	ORDER BY
+		created_at,
+		id
+	LIMIT $3
+	OFFSET $4`
+)
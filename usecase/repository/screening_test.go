@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/crypto"
+	"github.com/nobbyphala/Brick/mock"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_screeningRepository_Insert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	mockRow := mock.NewMockRow(ctrl)
+
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "screening-1"
+		return nil
+	})
+	mockDB.EXPECT().Query(gomock.Any(), `
+	INSERT INTO
+		screening
+		(
+		 disbursement_id,
+		 merchant_id,
+		 status,
+		 matches,
+		 encryption_key_id,
+		 encrypted_data_key,
+		 created_at,
+		 updated_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	RETURNING
+		id`, "disbursement-1", nullableString("merchant-1"), "HELD",
+		`[{"entry_id":"entry-1","list_name":"OFAC","entry_name":"Nobby Phala","reference":"SDN-1","field":"NAME","score":90}]`,
+		nil, nil).Return(mockRow)
+
+	sr := NewScreening(ScreeningDeps{DB: mockDB, Encryptor: crypto.NewPlaintextEncryptor()})
+	got, err := sr.Insert(context.TODO(), domain.Screening{
+		DisbursementId: "disbursement-1",
+		MerchantId:     "merchant-1",
+		Status:         domain.ScreeningStatusHeld,
+		Matches: []domain.ScreeningMatch{
+			{
+				EntryId:   "entry-1",
+				ListName:  "OFAC",
+				EntryName: "Nobby Phala",
+				Reference: "SDN-1",
+				Field:     domain.ScreeningMatchFieldName,
+				Score:     90,
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "screening-1", got)
+}
+
+func Test_screeningRepository_UpdateById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	mockResult := mock.NewMockResult(ctrl)
+	resolvedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{
+			name:         "success resolve",
+			rowsAffected: 1,
+		},
+		{
+			name:         "screening is not held",
+			rowsAffected: 0,
+			wantErr:      internal_error.ErrNoRowsAffected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockResult.EXPECT().RowsAffected().Return(tt.rowsAffected, nil)
+			mockDB.EXPECT().Exec(gomock.Any(), `
+	UPDATE
+		screening
+	SET
+		status = $1,
+		resolved_by = $2,
+		resolution_note = $3,
+		resolved_at = $4,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id = $5
+		AND status = $6`, "RELEASED", nullableString("compliance-1"), nullableString("different birth date"), &resolvedAt, "screening-1", "HELD").Return(mockResult, nil)
+
+			sr := NewScreening(ScreeningDeps{DB: mockDB, Encryptor: crypto.NewPlaintextEncryptor()})
+			err := sr.UpdateById(context.TODO(), "screening-1", domain.Screening{
+				Status:         domain.ScreeningStatusReleased,
+				ResolvedBy:     "compliance-1",
+				ResolutionNote: "different birth date",
+				ResolvedAt:     &resolvedAt,
+			})
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_screeningRepository_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	query := `
+	SELECT
+		*
+	FROM
+		screening
+	WHERE
+		($1 = '' OR status = $1)
+		AND ($2 = '' OR disbursement_id::text = $2)
+	ORDER BY
+		created_at,
+		id
+	LIMIT $3
+	OFFSET $4`
+
+	tests := []struct {
+		name    string
+		filter  domain.ScreeningFilter
+		want    []domain.Screening
+		wantErr error
+		mock    func()
+	}{
+		{
+			name:   "held screenings with default limit",
+			filter: domain.ScreeningFilter{Status: domain.ScreeningStatusHeld},
+			want: []domain.Screening{
+				{
+					Id:             "screening-1",
+					DisbursementId: "disbursement-1",
+					Status:         domain.ScreeningStatusHeld,
+					Matches: []domain.ScreeningMatch{
+						{
+							EntryId:   "entry-1",
+							ListName:  domain.ScreeningListInternal,
+							Reference: "fraud report",
+							Field:     domain.ScreeningMatchFieldAccountNumber,
+							Score:     100,
+						},
+					},
+					CreatedAt: createdAt,
+					UpdatedAt: createdAt,
+				},
+				{
+					Id:             "screening-2",
+					DisbursementId: "disbursement-2",
+					Status:         domain.ScreeningStatusHeld,
+					CreatedAt:      createdAt,
+					UpdatedAt:      createdAt,
+				},
+			},
+			mock: func() {
+				mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), query, "HELD", "", 100, 0).DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+					*dest.(*[]model.Screening) = []model.Screening{
+						{
+							Id:             "screening-1",
+							DisbursementId: "disbursement-1",
+							Status:         "HELD",
+							Matches:        `[{"entry_id":"entry-1","list_name":"INTERNAL","entry_name":"","reference":"fraud report","field":"ACCOUNT_NUMBER","score":100}]`,
+							CreatedAt:      createdAt,
+							UpdatedAt:      createdAt,
+						},
+						{
+							Id:             "screening-2",
+							DisbursementId: "disbursement-2",
+							Status:         "HELD",
+							Matches:        `[]`,
+							CreatedAt:      createdAt,
+							UpdatedAt:      createdAt,
+						},
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name:    "error select",
+			filter:  domain.ScreeningFilter{DisbursementId: "disbursement-1", Limit: 10, Offset: 20},
+			wantErr: errors.New("sql error"),
+			mock: func() {
+				mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), query, "", "disbursement-1", 10, 20).Return(errors.New("sql error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			sr := NewScreening(ScreeningDeps{DB: mockDB, Encryptor: crypto.NewPlaintextEncryptor()})
+			got, err := sr.List(context.TODO(), tt.filter)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package repository
+
+import "github.com/nobbyphala/Brick/external/database"
+
+const defaultTxMaxRetries = 3
+
+type TxOptions struct {
+	Isolation database.IsolationLevel
+	ReadOnly  bool
+	// number of retry when the transaction failed because of serialization failure or deadlock
+	MaxRetries int
+}
+
+type TxOption func(opts *TxOptions)
+
+func WithIsolationLevel(level database.IsolationLevel) TxOption {
+	return func(opts *TxOptions) {
+		opts.Isolation = level
+	}
+}
+
+func WithReadOnly() TxOption {
+	return func(opts *TxOptions) {
+		opts.ReadOnly = true
+	}
+}
+
+func WithMaxRetries(maxRetries int) TxOption {
+	return func(opts *TxOptions) {
+		opts.MaxRetries = maxRetries
+	}
+}
+
+// BuildTxOptions apply opts on top of the default transaction options
+func BuildTxOptions(opts []TxOption) TxOptions {
+	res := TxOptions{
+		Isolation:  database.LevelDefault,
+		MaxRetries: defaultTxMaxRetries,
+	}
+
+	for _, opt := range opts {
+		opt(&res)
+	}
+
+	return res
+}
@@ -2,16 +2,30 @@ package repository
 
 import (
 	"context"
-	"github.com/jmoiron/sqlx"
+	"errors"
+	"fmt"
 	"github.com/nobbyphala/Brick/external/database"
+	"log"
+	"time"
 )
 
+const txRetryBaseDelay = 20 * time.Millisecond
+
+type txContextKey struct{}
+
+// txState is the running transaction shared with the nested RunWithTransaction call through context
+type txState struct {
+	tx            database.Tx
+	sqlDatabase   *retryableErrorRecorder
+	savepointSeed int
+}
+
 type utils struct {
-	db *sqlx.DB // Because sqlx not fully wrapped yet for now we will use sqlx directly
+	db database.Transactor
 }
 
 type UtilsOpts struct {
-	DB *sqlx.DB
+	DB database.Transactor
 }
 
 func NewRepositoryUtils(opts UtilsOpts) *utils {
@@ -20,27 +34,174 @@ func NewRepositoryUtils(opts UtilsOpts) *utils {
 	}
 }
 
-func (ut utils) RunWithTransaction(ctx context.Context, handler func(Tx database.SQLDatabase) error) error {
-	tx, err := ut.db.Beginx()
-	if err != nil {
-		return err
+func (ut utils) RunWithTransaction(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...TxOption) error {
+	if state, ok := ctx.Value(txContextKey{}).(*txState); ok {
+		return ut.runWithSavepoint(ctx, state, handler)
 	}
 
-	sqlDatabaseTx := database.NewPostgresSqlTx(database.PostgresSqlTxOpts{
-		Tx: tx,
+	txOpts := BuildTxOptions(opts)
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		var retryable bool
+
+		retryable, err = ut.runOnce(ctx, txOpts, handler)
+		if err == nil || !retryable || attempt >= txOpts.MaxRetries {
+			return err
+		}
+
+		log.Println("retrying transaction after conflict:", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(txRetryBaseDelay << attempt):
+		}
+	}
+}
+
+// runOnce run the handler inside a new transaction and report whether the returned error is retryable
+func (ut utils) runOnce(ctx context.Context, txOpts TxOptions, handler func(ctx context.Context, Tx database.SQLDatabase) error) (retryable bool, err error) {
+	tx, err := ut.db.BeginTx(ctx, database.TxOptions{
+		Isolation: txOpts.Isolation,
+		ReadOnly:  txOpts.ReadOnly,
 	})
+	if err != nil {
+		return database.IsRetryableError(err), err
+	}
+
+	state := &txState{
+		tx:          tx,
+		sqlDatabase: &retryableErrorRecorder{db: tx},
+	}
 
-	err = handler(sqlDatabaseTx)
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			rollback(tx)
+			panic(recovered)
+		}
+	}()
+
+	err = handler(context.WithValue(ctx, txContextKey{}, state), state.sqlDatabase)
 	if err != nil {
-		tx.Rollback()
-		return err
+		rollback(tx)
+		return state.sqlDatabase.retryable || database.IsRetryableError(err), err
 	}
 
 	err = tx.Commit()
 	if err != nil {
-		tx.Rollback()
+		rollback(tx)
+		return database.IsRetryableError(err), err
+	}
+
+	return false, nil
+}
+
+func (ut utils) runWithSavepoint(ctx context.Context, state *txState, handler func(ctx context.Context, Tx database.SQLDatabase) error) (err error) {
+	state.savepointSeed++
+	savepoint := fmt.Sprintf("sp_%d", state.savepointSeed)
+
+	_, err = state.tx.Exec(ctx, "SAVEPOINT "+savepoint)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			rollbackToSavepoint(state.tx, savepoint)
+			panic(recovered)
+		}
+	}()
+
+	err = handler(ctx, state.sqlDatabase)
+	if err != nil {
+		rollbackToSavepoint(state.tx, savepoint)
+		return err
+	}
+
+	_, err = state.tx.Exec(ctx, "RELEASE SAVEPOINT "+savepoint)
+	return err
+}
+
+func rollback(tx database.Tx) {
+	err := tx.Rollback()
+	if err != nil && !errors.Is(err, database.ErrTxDone) {
+		log.Println("error rollback transaction:", err)
+	}
+}
+
+func rollbackToSavepoint(tx database.Tx, savepoint string) {
+	// use new context so the savepoint still rolled back when ctx cancelled
+	_, err := tx.Exec(context.Background(), "ROLLBACK TO SAVEPOINT "+savepoint)
+	if err != nil {
+		log.Println("error rollback to savepoint:", err)
+	}
+}
+
+// retryableErrorRecorder remember whether any query failed because of serialization failure or deadlock,
+// because the handler usually replace the driver error with its own error
+type retryableErrorRecorder struct {
+	db        database.SQLDatabase
+	retryable bool
+}
+
+func (rec *retryableErrorRecorder) record(err error) error {
+	if database.IsRetryableError(err) {
+		rec.retryable = true
+	}
+
+	return err
+}
+
+func (rec *retryableErrorRecorder) Get(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return rec.record(rec.db.Get(ctx, dest, query, args...))
+}
+
+func (rec *retryableErrorRecorder) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return rec.record(rec.db.Select(ctx, dest, query, args...))
+}
+
+func (rec *retryableErrorRecorder) Exec(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+	res, err := rec.db.Exec(ctx, query, args...)
+	return res, rec.record(err)
+}
+
+func (rec *retryableErrorRecorder) Query(ctx context.Context, query string, args ...interface{}) database.Row {
+	return recordedRow{
+		row:      rec.db.Query(ctx, query, args...),
+		recorder: rec,
+	}
+}
+
+func (rec *retryableErrorRecorder) QueryRows(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+	rows, err := rec.db.QueryRows(ctx, query, args...)
+	return rows, rec.record(err)
+}
+
+func (rec *retryableErrorRecorder) NamedExec(ctx context.Context, query string, arg interface{}) (database.Result, error) {
+	res, err := rec.db.NamedExec(ctx, query, arg)
+	return res, rec.record(err)
+}
+
+func (rec *retryableErrorRecorder) NamedQuery(ctx context.Context, query string, arg interface{}) (database.Rows, error) {
+	rows, err := rec.db.NamedQuery(ctx, query, arg)
+	return rows, rec.record(err)
+}
+
+func (rec *retryableErrorRecorder) Prepare(ctx context.Context, query string) (database.Stmt, error) {
+	stmt, err := rec.db.Prepare(ctx, query)
+	return stmt, rec.record(err)
+}
+
+type recordedRow struct {
+	row      database.Row
+	recorder *retryableErrorRecorder
+}
+
+func (row recordedRow) Scan(dest ...any) error {
+	return row.recorder.record(row.row.Scan(dest...))
+}
+
+func (row recordedRow) Err() error {
+	return row.recorder.record(row.row.Err())
 }
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestNewRepositoryUtils(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactor := mock.NewMockTransactor(ctrl)
+
+	got := NewRepositoryUtils(UtilsOpts{DB: mockTransactor})
+	assert.Equal(t, &utils{db: mockTransactor}, got)
+}
+
+func Test_utils_RunWithTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	mockResult := mock.NewMockResult(ctrl)
+
+	serializationErr := &pgconn.PgError{Code: "40001"}
+
+	type args struct {
+		handler func(ctx context.Context, Tx database.SQLDatabase) error
+		opts    []TxOption
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "commit when handler success",
+			args: args{
+				handler: func(ctx context.Context, Tx database.SQLDatabase) error {
+					_, err := Tx.Exec(ctx, "UPDATE")
+					return err
+				},
+				opts: []TxOption{WithIsolationLevel(database.LevelSerializable), WithReadOnly()},
+			},
+			wantErr: nil,
+			mock: func() {
+				mockTransactor.EXPECT().BeginTx(gomock.Any(), database.TxOptions{
+					Isolation: database.LevelSerializable,
+					ReadOnly:  true,
+				}).Return(mockTx, nil)
+				mockTx.EXPECT().Exec(gomock.Any(), "UPDATE").Return(mockResult, nil)
+				mockTx.EXPECT().Commit().Return(nil)
+			},
+		},
+		{
+			name: "rollback when handler failed",
+			args: args{
+				handler: func(ctx context.Context, Tx database.SQLDatabase) error {
+					return errors.New("handler error")
+				},
+			},
+			wantErr: errors.New("handler error"),
+			mock: func() {
+				mockTransactor.EXPECT().BeginTx(gomock.Any(), database.TxOptions{}).Return(mockTx, nil)
+				mockTx.EXPECT().Rollback().Return(nil)
+			},
+		},
+		{
+			name: "error begin transaction",
+			args: args{
+				handler: func(ctx context.Context, Tx database.SQLDatabase) error {
+					return nil
+				},
+			},
+			wantErr: errors.New("begin error"),
+			mock: func() {
+				mockTransactor.EXPECT().BeginTx(gomock.Any(), database.TxOptions{}).Return(nil, errors.New("begin error"))
+			},
+		},
+		{
+			name: "retry when query failed with serialization failure",
+			args: args{
+				handler: func() func(ctx context.Context, Tx database.SQLDatabase) error {
+					attempt := 0
+					return func(ctx context.Context, Tx database.SQLDatabase) error {
+						attempt++
+						if attempt == 1 {
+							_, err := Tx.Exec(ctx, "UPDATE")
+							if err != nil {
+								// handler usually replace the driver error
+								return errors.New("error update")
+							}
+						}
+						return nil
+					}
+				}(),
+			},
+			wantErr: nil,
+			mock: func() {
+				mockTransactor.EXPECT().BeginTx(gomock.Any(), database.TxOptions{}).Return(mockTx, nil).Times(2)
+				mockTx.EXPECT().Exec(gomock.Any(), "UPDATE").Return(nil, serializationErr)
+				mockTx.EXPECT().Rollback().Return(nil)
+				mockTx.EXPECT().Commit().Return(nil)
+			},
+		},
+		{
+			name: "give up after max retries when commit conflicted",
+			args: args{
+				handler: func(ctx context.Context, Tx database.SQLDatabase) error {
+					return nil
+				},
+				opts: []TxOption{WithMaxRetries(1)},
+			},
+			wantErr: serializationErr,
+			mock: func() {
+				mockTransactor.EXPECT().BeginTx(gomock.Any(), database.TxOptions{}).Return(mockTx, nil).Times(2)
+				mockTx.EXPECT().Commit().Return(serializationErr).Times(2)
+				mockTx.EXPECT().Rollback().Return(database.ErrTxDone).Times(2)
+			},
+		},
+		{
+			name: "nested call use savepoint",
+			args: args{
+				handler: func(ctx context.Context, Tx database.SQLDatabase) error {
+					ut := utils{db: mockTransactor}
+
+					err := ut.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+						return errors.New("nested error")
+					})
+					assert.Equal(t, errors.New("nested error"), err)
+
+					return ut.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+						return nil
+					})
+				},
+			},
+			wantErr: nil,
+			mock: func() {
+				mockTransactor.EXPECT().BeginTx(gomock.Any(), database.TxOptions{}).Return(mockTx, nil)
+				gomock.InOrder(
+					mockTx.EXPECT().Exec(gomock.Any(), "SAVEPOINT sp_1").Return(mockResult, nil),
+					mockTx.EXPECT().Exec(gomock.Any(), "ROLLBACK TO SAVEPOINT sp_1").Return(mockResult, nil),
+					mockTx.EXPECT().Exec(gomock.Any(), "SAVEPOINT sp_2").Return(mockResult, nil),
+					mockTx.EXPECT().Exec(gomock.Any(), "RELEASE SAVEPOINT sp_2").Return(mockResult, nil),
+				)
+				mockTx.EXPECT().Commit().Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			ut := utils{
+				db: mockTransactor,
+			}
+			err := ut.RunWithTransaction(context.TODO(), tt.args.handler, tt.args.opts...)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_utils_RunWithTransaction_panic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactor := mock.NewMockTransactor(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+
+	mockTransactor.EXPECT().BeginTx(gomock.Any(), database.TxOptions{}).Return(mockTx, nil)
+	mockTx.EXPECT().Rollback().Return(nil)
+
+	ut := utils{db: mockTransactor}
+
+	assert.PanicsWithValue(t, "handler panic", func() {
+		ut.RunWithTransaction(context.TODO(), func(ctx context.Context, Tx database.SQLDatabase) error {
+			panic("handler panic")
+		})
+	})
+}
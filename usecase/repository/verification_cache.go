@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/crypto"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+	"time"
+)
+
+type verificationCacheRepository struct {
+	db        database.SQLDatabase
+	encryptor crypto.FieldEncryptor
+}
+
+type VerificationCacheDeps struct {
+	DB database.SQLDatabase
+	// encrypt the account holder name before stored, the account number is only stored as blind index
+	Encryptor crypto.FieldEncryptor
+}
+
+func NewVerificationCache(deps VerificationCacheDeps) *verificationCacheRepository {
+	return &verificationCacheRepository{
+		db:        deps.DB,
+		encryptor: deps.Encryptor,
+	}
+}
+
+func (vc verificationCacheRepository) Get(ctx context.Context, bankCode string, accountNumber string, now time.Time) (*domain.AccountVerification, error) {
+	bidx, err := vc.encryptor.BlindIndex(ctx, accountNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var res model.VerificationCache
+
+	err = vc.db.Get(ctx, &res, querySelectVerificationCache, bankCode, bidx, now)
+	if err != nil {
+		if errors.Is(err, database.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	holderName, err := vc.encryptor.Decrypt(ctx, crypto.EncryptedFields{
+		KeyId:   stringValue(res.EncryptionKeyId),
+		DataKey: stringValue(res.EncryptedDataKey),
+		Values:  []string{res.AccountHolderName},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AccountVerification{
+		BankCode:          res.BankCode,
+		AccountNumber:     accountNumber,
+		AccountHolderName: holderName[0],
+		AccountStatus:     res.AccountStatus,
+		ExpiresAt:         res.ExpiresAt,
+	}, nil
+}
+
+func (vc verificationCacheRepository) Set(ctx context.Context, verification domain.AccountVerification) error {
+	bidx, err := vc.encryptor.BlindIndex(ctx, verification.AccountNumber)
+	if err != nil {
+		return err
+	}
+
+	holderName, err := vc.encryptor.Encrypt(ctx, verification.AccountHolderName)
+	if err != nil {
+		return err
+	}
+
+	_, err = vc.db.Exec(
+		ctx,
+		queryUpsertVerificationCache,
+		verification.BankCode,
+		bidx,
+		holderName.Values[0],
+		nullableString(holderName.KeyId),
+		nullableString(holderName.DataKey),
+		verification.AccountStatus,
+		verification.ExpiresAt,
+	)
+
+	return err
+}
+
+func (vc verificationCacheRepository) Delete(ctx context.Context, bankCode string, accountNumber string) error {
+	bidx, err := vc.encryptor.BlindIndex(ctx, accountNumber)
+	if err != nil {
+		return err
+	}
+
+	_, err = vc.db.Exec(ctx, queryDeleteVerificationCache, bankCode, bidx)
+
+	return err
+}
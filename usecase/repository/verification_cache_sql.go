@@ -0,0 +1,43 @@
+package repository
+
+const (
+	queryUpsertVerificationCache = `
+	INSERT INTO
+		verification_cache
+		(
+		 bank_code,
+		 account_number_bidx,
+		 account_holder_name,
+		 encryption_key_id,
+		 encrypted_data_key,
+		 account_status,
+		 expires_at,
+		 created_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
+	ON CONFLICT (bank_code, account_number_bidx) DO UPDATE SET
+		account_holder_name = EXCLUDED.account_holder_name,
+		encryption_key_id = EXCLUDED.encryption_key_id,
+		encrypted_data_key = EXCLUDED.encrypted_data_key,
+		account_status = EXCLUDED.account_status,
+		expires_at = EXCLUDED.expires_at,
+		created_at = EXCLUDED.created_at`
+
+	querySelectVerificationCache = `
+	SELECT
+		*
+	FROM
+		verification_cache
+	WHERE
+		bank_code = $1
+		AND account_number_bidx = $2
+		AND expires_at > $3`
+
+	queryDeleteVerificationCache = `
+	DELETE FROM
+		verification_cache
+	WHERE
+		bank_code = $1
+		AND account_number_bidx = $2`
+)
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/crypto"
+	"github.com/nobbyphala/Brick/mock"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_verificationCacheRepository_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		want    *domain.AccountVerification
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "cached verification",
+			want: &domain.AccountVerification{
+				BankCode:          "014",
+				AccountNumber:     "6789567",
+				AccountHolderName: "Nobby Phala",
+				AccountStatus:     "status: account verified",
+				ExpiresAt:         expiresAt,
+			},
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Eq(&model.VerificationCache{}), `
+	SELECT
+		*
+	FROM
+		verification_cache
+	WHERE
+		bank_code = $1
+		AND account_number_bidx = $2
+		AND expires_at > $3`, "014", "6789567", now).DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+					res := dest.(*model.VerificationCache)
+					res.BankCode = "014"
+					res.AccountNumberBidx = "6789567"
+					res.AccountHolderName = "Nobby Phala"
+					res.AccountStatus = "status: account verified"
+					res.ExpiresAt = expiresAt
+					return nil
+				})
+			},
+		},
+		{
+			name: "not cached or expired",
+			want: nil,
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), "014", "6789567", now).Return(sql.ErrNoRows)
+			},
+		},
+		{
+			name:    "unknown error from driver",
+			wantErr: errors.New("sql error"),
+			mock: func() {
+				mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), "014", "6789567", now).Return(errors.New("sql error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			vc := NewVerificationCache(VerificationCacheDeps{DB: mockDB, Encryptor: crypto.NewPlaintextEncryptor()})
+			got, err := vc.Get(context.TODO(), "014", "6789567", now)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_verificationCacheRepository_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	mockResult := mock.NewMockResult(ctrl)
+	expiresAt := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	mockDB.EXPECT().Exec(gomock.Any(), `
+	INSERT INTO
+		verification_cache
+		(
+		 bank_code,
+		 account_number_bidx,
+		 account_holder_name,
+		 encryption_key_id,
+		 encrypted_data_key,
+		 account_status,
+		 expires_at,
+		 created_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
+	ON CONFLICT (bank_code, account_number_bidx) DO UPDATE SET
+		account_holder_name = EXCLUDED.account_holder_name,
+		encryption_key_id = EXCLUDED.encryption_key_id,
+		encrypted_data_key = EXCLUDED.encrypted_data_key,
+		account_status = EXCLUDED.account_status,
+		expires_at = EXCLUDED.expires_at,
+		created_at = EXCLUDED.created_at`, "014", "6789567", "Nobby Phala", nil, nil, "status: account verified", expiresAt).Return(mockResult, nil)
+
+	vc := NewVerificationCache(VerificationCacheDeps{DB: mockDB, Encryptor: crypto.NewPlaintextEncryptor()})
+	err := vc.Set(context.TODO(), domain.AccountVerification{
+		BankCode:          "014",
+		AccountNumber:     "6789567",
+		AccountHolderName: "Nobby Phala",
+		AccountStatus:     "status: account verified",
+		ExpiresAt:         expiresAt,
+	})
+	assert.NoError(t, err)
+}
+
+func Test_verificationCacheRepository_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	mockResult := mock.NewMockResult(ctrl)
+
+	mockDB.EXPECT().Exec(gomock.Any(), `
+	DELETE FROM
+		verification_cache
+	WHERE
+		bank_code = $1
+		AND account_number_bidx = $2`, "014", "6789567").Return(mockResult, nil)
+
+	vc := NewVerificationCache(VerificationCacheDeps{DB: mockDB, Encryptor: crypto.NewPlaintextEncryptor()})
+	err := vc.Delete(context.TODO(), "014", "6789567")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+	"time"
+)
+
+const defaultWebhookDeliveryLimit = 100
+
+type webhookDeliveryRepository struct {
+	db database.SQLDatabase
+}
+
+type WebhookDeliveryDeps struct {
+	DB database.SQLDatabase
+}
+
+func NewWebhookDelivery(deps WebhookDeliveryDeps) *webhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		db: deps.DB,
+	}
+}
+
+func (wr webhookDeliveryRepository) WithTx(Tx database.SQLDatabase) WebhookDelivery {
+	return webhookDeliveryRepository{
+		db: Tx,
+	}
+}
+
+func (wr webhookDeliveryRepository) Insert(ctx context.Context, delivery domain.WebhookDelivery) (string, error) {
+	var deliveryId string
+
+	err := wr.db.Query(
+		ctx,
+		queryInsertWebhookDelivery,
+		delivery.EndpointId,
+		delivery.MerchantId,
+		delivery.EventId,
+		string(delivery.EventType),
+		delivery.DisbursementId,
+		string(delivery.Payload),
+		string(delivery.Status),
+		delivery.NextAttemptAt,
+		nullableString(delivery.ReplayOf),
+	).Scan(&deliveryId)
+	if err != nil {
+		return "", err
+	}
+
+	return deliveryId, nil
+}
+
+func (wr webhookDeliveryRepository) UpdateById(ctx context.Context, id string, updatedData domain.WebhookDelivery) error {
+	res, err := wr.db.Exec(
+		ctx,
+		queryUpdateWebhookDelivery,
+		string(updatedData.Status),
+		updatedData.Attempts,
+		updatedData.NextAttemptAt,
+		nullableInt(updatedData.LastStatusCode),
+		nullableString(updatedData.LastError),
+		updatedData.DeliveredAt,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func (wr webhookDeliveryRepository) GetById(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	var res model.WebhookDelivery
+
+	err := wr.db.Get(ctx, &res, querySelectWebhookDeliveryById, id)
+	if err != nil {
+		if errors.Is(err, database.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	delivery := toDomainWebhookDelivery(res)
+	return &delivery, nil
+}
+
+func (wr webhookDeliveryRepository) List(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	var rows []model.WebhookDelivery
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultWebhookDeliveryLimit
+	}
+
+	err := wr.db.Select(
+		ctx,
+		&rows,
+		querySelectWebhookDeliveries,
+		filter.MerchantId,
+		filter.EndpointId,
+		filter.DisbursementId,
+		string(filter.Status),
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainWebhookDeliveries(rows), nil
+}
+
+func (wr webhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	var rows []model.WebhookDelivery
+
+	err := wr.db.Select(ctx, &rows, queryClaimDueWebhookDeliveries, now, leaseUntil, string(domain.WebhookDeliveryStatusPending), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainWebhookDeliveries(rows), nil
+}
+
+func (wr webhookDeliveryRepository) InsertAttempt(ctx context.Context, attempt domain.WebhookDeliveryAttempt) (string, error) {
+	var attemptId string
+
+	err := wr.db.Query(
+		ctx,
+		queryInsertWebhookDeliveryAttempt,
+		attempt.DeliveryId,
+		attempt.Attempt,
+		nullableInt(attempt.StatusCode),
+		nullableString(attempt.Error),
+		attempt.Duration.Milliseconds(),
+	).Scan(&attemptId)
+	if err != nil {
+		return "", err
+	}
+
+	return attemptId, nil
+}
+
+func (wr webhookDeliveryRepository) ListAttempts(ctx context.Context, deliveryId string) ([]domain.WebhookDeliveryAttempt, error) {
+	var rows []model.WebhookDeliveryAttempt
+
+	err := wr.db.Select(ctx, &rows, querySelectWebhookDeliveryAttempts, deliveryId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.WebhookDeliveryAttempt, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, domain.WebhookDeliveryAttempt{
+			Id:         row.Id,
+			DeliveryId: row.DeliveryId,
+			Attempt:    row.Attempt,
+			StatusCode: intValue(row.StatusCode),
+			Error:      stringValue(row.Error),
+			Duration:   time.Duration(row.DurationMs) * time.Millisecond,
+			CreatedAt:  row.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+func toDomainWebhookDeliveries(rows []model.WebhookDelivery) []domain.WebhookDelivery {
+	res := make([]domain.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, toDomainWebhookDelivery(row))
+	}
+
+	return res
+}
+
+func toDomainWebhookDelivery(row model.WebhookDelivery) domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		Id:             row.Id,
+		EndpointId:     row.EndpointId,
+		MerchantId:     row.MerchantId,
+		EventId:        row.EventId,
+		EventType:      domain.WebhookEventType(row.EventType),
+		DisbursementId: row.DisbursementId,
+		Payload:        row.Payload,
+		Status:         domain.WebhookDeliveryStatus(row.Status),
+		Attempts:       row.Attempts,
+		NextAttemptAt:  row.NextAttemptAt,
+		LastStatusCode: intValue(row.LastStatusCode),
+		LastError:      stringValue(row.LastError),
+		DeliveredAt:    row.DeliveredAt,
+		ReplayOf:       stringValue(row.ReplayOf),
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+}
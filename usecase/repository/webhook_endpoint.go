@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/crypto"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+)
+
+type webhookEndpointRepository struct {
+	db        database.SQLDatabase
+	encryptor crypto.FieldEncryptor
+}
+
+type WebhookEndpointDeps struct {
+	DB database.SQLDatabase
+	// encrypt the signing secret before stored
+	Encryptor crypto.FieldEncryptor
+}
+
+func NewWebhookEndpoint(deps WebhookEndpointDeps) *webhookEndpointRepository {
+	return &webhookEndpointRepository{
+		db:        deps.DB,
+		encryptor: deps.Encryptor,
+	}
+}
+
+func (wr webhookEndpointRepository) WithTx(Tx database.SQLDatabase) WebhookEndpoint {
+	return webhookEndpointRepository{
+		db:        Tx,
+		encryptor: wr.encryptor,
+	}
+}
+
+func (wr webhookEndpointRepository) Insert(ctx context.Context, endpoint domain.WebhookEndpoint) (string, error) {
+	var endpointId string
+
+	secret, err := wr.encryptor.Encrypt(ctx, endpoint.Secret)
+	if err != nil {
+		return "", err
+	}
+
+	err = wr.db.Query(
+		ctx,
+		queryInsertWebhookEndpoint,
+		endpoint.MerchantId,
+		endpoint.Url,
+		secret.Values[0],
+		nullableString(secret.KeyId),
+		nullableString(secret.DataKey),
+		toModelWebhookEventTypes(endpoint.EventTypes),
+	).Scan(&endpointId)
+	if err != nil {
+		return "", err
+	}
+
+	return endpointId, nil
+}
+
+func (wr webhookEndpointRepository) GetById(ctx context.Context, id string) (*domain.WebhookEndpoint, error) {
+	var res model.WebhookEndpoint
+
+	err := wr.db.Get(ctx, &res, querySelectWebhookEndpointById, id)
+	if err != nil {
+		if errors.Is(err, database.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return wr.toDomain(ctx, res)
+}
+
+func (wr webhookEndpointRepository) ListByMerchantId(ctx context.Context, merchantId string) ([]domain.WebhookEndpoint, error) {
+	var rows []model.WebhookEndpoint
+
+	err := wr.db.Select(ctx, &rows, querySelectWebhookEndpointsByMerchantId, merchantId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.WebhookEndpoint, 0, len(rows))
+	for _, row := range rows {
+		endpoint, err := wr.toDomain(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *endpoint)
+	}
+
+	return res, nil
+}
+
+func (wr webhookEndpointRepository) UpdateById(ctx context.Context, id string, updatedData domain.WebhookEndpoint) error {
+	res, err := wr.db.Exec(ctx, queryUpdateWebhookEndpoint, updatedData.Url, toModelWebhookEventTypes(updatedData.EventTypes), id)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func (wr webhookEndpointRepository) DeleteById(ctx context.Context, id string) error {
+	res, err := wr.db.Exec(ctx, queryDeleteWebhookEndpoint, id)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func (wr webhookEndpointRepository) toDomain(ctx context.Context, row model.WebhookEndpoint) (*domain.WebhookEndpoint, error) {
+	secret, err := wr.encryptor.Decrypt(ctx, crypto.EncryptedFields{
+		KeyId:   stringValue(row.EncryptionKeyId),
+		DataKey: stringValue(row.EncryptedDataKey),
+		Values:  []string{row.Secret},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var eventTypes []domain.WebhookEventType
+	for _, eventType := range row.EventTypes {
+		eventTypes = append(eventTypes, domain.WebhookEventType(eventType))
+	}
+
+	return &domain.WebhookEndpoint{
+		Id:         row.Id,
+		MerchantId: row.MerchantId,
+		Url:        row.Url,
+		Secret:     secret[0],
+		EventTypes: eventTypes,
+		CreatedAt:  row.CreatedAt,
+	}, nil
+}
+
+func toModelWebhookEventTypes(eventTypes []domain.WebhookEventType) model.WebhookEventTypes {
+	res := make(model.WebhookEventTypes, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		res = append(res, string(eventType))
+	}
+
+	return res
+}
@@ -0,0 +1,170 @@
+package repository
+
+const (
+	queryInsertWebhookEndpoint = `
+	INSERT INTO
+		webhook_endpoint
+		(
+		 merchant_id,
+		 url,
+		 secret,
+		 encryption_key_id,
+		 encrypted_data_key,
+		 event_types,
+		 created_at,
+		 updated_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	RETURNING
+		id`
+
+	queryUpdateWebhookEndpoint = `
+	UPDATE
+		webhook_endpoint
+	SET
+		url = $1,
+		event_types = $2,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id = $3
+		AND deleted_at IS NULL`
+
+	queryDeleteWebhookEndpoint = `
+	UPDATE
+		webhook_endpoint
+	SET
+		deleted_at = CURRENT_TIMESTAMP,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id = $1
+		AND deleted_at IS NULL`
+
+	querySelectWebhookEndpointById = `
+	SELECT
+		*
+	FROM
+		webhook_endpoint
+	WHERE
+		id = $1
+		AND deleted_at IS NULL`
+
+	querySelectWebhookEndpointsByMerchantId = `
+	SELECT
+		*
+	FROM
+		webhook_endpoint
+	WHERE
+		merchant_id = $1
+		AND deleted_at IS NULL
+	ORDER BY
+		created_at`
+
+	queryInsertWebhookDelivery = `
+	INSERT INTO
+		webhook_delivery
+		(
+		 endpoint_id,
+		 merchant_id,
+		 event_id,
+		 event_type,
+		 disbursement_id,
+		 payload,
+		 status,
+		 next_attempt_at,
+		 replay_of,
+		 created_at,
+		 updated_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	RETURNING
+		id`
+
+	queryUpdateWebhookDelivery = `
+	UPDATE
+		webhook_delivery
+	SET
+		status = $1,
+		attempts = $2,
+		next_attempt_at = $3,
+		last_status_code = $4,
+		last_error = $5,
+		delivered_at = $6,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id = $7`
+
+	querySelectWebhookDeliveryById = `
+	SELECT
+		*
+	FROM
+		webhook_delivery
+	WHERE
+		id = $1`
+
+	// empty filter value match every row
+	querySelectWebhookDeliveries = `
+	SELECT
+		*
+	FROM
+		webhook_delivery
+	WHERE
+		($1 = '' OR merchant_id::text = $1)
+		AND ($2 = '' OR endpoint_id::text = $2)
+		AND ($3 = '' OR disbursement_id::text = $3)
+		AND ($4 = '' OR status = $4)
+	ORDER BY
+		created_at DESC
+	LIMIT $5`
+
+	// skip locked let several instances claim different deliveries at the same time
+	queryClaimDueWebhookDeliveries = `
+	UPDATE
+		webhook_delivery
+	SET
+		next_attempt_at = $2,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id IN (
+			SELECT
+				id
+			FROM
+				webhook_delivery
+			WHERE
+				status = $3
+				AND next_attempt_at <= $1
+			ORDER BY
+				next_attempt_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+	RETURNING
+		*`
+
+	queryInsertWebhookDeliveryAttempt = `
+	INSERT INTO
+		webhook_delivery_attempt
+		(
+		 delivery_id,
+		 attempt,
+		 status_code,
+		 error,
+		 duration_ms,
+		 created_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+	RETURNING
+		id`
+
+	querySelectWebhookDeliveryAttempts = `
+	SELECT
+		*
+	FROM
+		webhook_delivery_attempt
+	WHERE
+		delivery_id = $1
+	ORDER BY
+		attempt`
+)
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/external/crypto"
+	"github.com/nobbyphala/Brick/mock"
+	"github.com/nobbyphala/Brick/usecase/repository/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_webhookEndpointRepository_Insert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	mockRow := mock.NewMockRow(ctrl)
+
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "endpoint-1"
+		return nil
+	})
+	mockDB.EXPECT().Query(gomock.Any(), `
+	INSERT INTO
+		webhook_endpoint
+		(
+		 merchant_id,
+		 url,
+		 secret,
+		 encryption_key_id,
+		 encrypted_data_key,
+		 event_types,
+		 created_at,
+		 updated_at
+		 )
+	VALUES
+		($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	RETURNING
+		id`, "merchant-1", "https://merchant.test/webhook", "whsec_secret", nil, nil,
+		model.WebhookEventTypes{"disbursement.completed"}).Return(mockRow)
+
+	wr := NewWebhookEndpoint(WebhookEndpointDeps{DB: mockDB, Encryptor: crypto.NewPlaintextEncryptor()})
+	got, err := wr.Insert(context.TODO(), domain.WebhookEndpoint{
+		MerchantId: "merchant-1",
+		Url:        "https://merchant.test/webhook",
+		Secret:     "whsec_secret",
+		EventTypes: []domain.WebhookEventType{domain.WebhookEventDisbursementCompleted},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "endpoint-1", got)
+}
+
+func Test_webhookDeliveryRepository_ClaimDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	leaseUntil := now.Add(time.Minute)
+	statusCode := 500
+
+	mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), `
+	UPDATE
+		webhook_delivery
+	SET
+		next_attempt_at = $2,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id IN (
+			SELECT
+				id
+			FROM
+				webhook_delivery
+			WHERE
+				status = $3
+				AND next_attempt_at <= $1
+			ORDER BY
+				next_attempt_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+	RETURNING
+		*`, now, leaseUntil, "PENDING", 10).DoAndReturn(func(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+		rows := dest.(*[]model.WebhookDelivery)
+		*rows = []model.WebhookDelivery{{
+			Id:             "delivery-1",
+			EndpointId:     "endpoint-1",
+			MerchantId:     "merchant-1",
+			EventId:        "disb-1:v2",
+			EventType:      "disbursement.completed",
+			DisbursementId: "disb-1",
+			Payload:        []byte(`{}`),
+			Status:         "PENDING",
+			Attempts:       1,
+			NextAttemptAt:  leaseUntil,
+			LastStatusCode: &statusCode,
+		}}
+		return nil
+	})
+
+	wr := NewWebhookDelivery(WebhookDeliveryDeps{DB: mockDB})
+	got, err := wr.ClaimDue(context.TODO(), now, leaseUntil, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{{
+		Id:             "delivery-1",
+		EndpointId:     "endpoint-1",
+		MerchantId:     "merchant-1",
+		EventId:        "disb-1:v2",
+		EventType:      domain.WebhookEventDisbursementCompleted,
+		DisbursementId: "disb-1",
+		Payload:        []byte(`{}`),
+		Status:         domain.WebhookDeliveryStatusPending,
+		Attempts:       1,
+		NextAttemptAt:  leaseUntil,
+		LastStatusCode: 500,
+	}}, got)
+}
+
+func Test_webhookDeliveryRepository_UpdateById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mock.NewMockSQLDatabase(ctrl)
+	mockResult := mock.NewMockResult(ctrl)
+	deliveredAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockResult.EXPECT().RowsAffected().Return(int64(1), nil)
+	mockDB.EXPECT().Exec(gomock.Any(), `
+	UPDATE
+		webhook_delivery
+	SET
+		status = $1,
+		attempts = $2,
+		next_attempt_at = $3,
+		last_status_code = $4,
+		last_error = $5,
+		delivered_at = $6,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE
+		id = $7`, "DELIVERED", 2, deliveredAt, nullableInt(200), nullableString(""), &deliveredAt, "delivery-1").Return(mockResult, nil)
+
+	wr := NewWebhookDelivery(WebhookDeliveryDeps{DB: mockDB})
+	err := wr.UpdateById(context.TODO(), "delivery-1", domain.WebhookDelivery{
+		Status:         domain.WebhookDeliveryStatusDelivered,
+		Attempts:       2,
+		NextAttemptAt:  deliveredAt,
+		LastStatusCode: 200,
+		DeliveredAt:    &deliveredAt,
+	})
+	assert.NoError(t, err)
+}
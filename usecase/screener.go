@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"sync"
+)
+
+// screener keep the entries of every list in memory so a disbursement is screened without reading the database
+type screener struct {
+	screeningEntryRepository repository.ScreeningEntry
+	nameMatcher              api.NameMatcher
+	nameMatchThreshold       int
+
+	mu      sync.RWMutex
+	entries []domain.ScreeningEntry
+}
+
+type ScreenerDeps struct {
+	ScreeningEntryRepository repository.ScreeningEntry
+	// compare the recipient name with the listed names, the same matcher as the bank account holder name
+	NameMatcher api.NameMatcher
+	// recipient name scoring at or above the threshold against a listed name is a match
+	NameMatchThreshold int
+}
+
+// NewScreener return a screener without entries, Reload before screening
+func NewScreener(deps ScreenerDeps) *screener {
+	return &screener{
+		screeningEntryRepository: deps.ScreeningEntryRepository,
+		nameMatcher:              deps.NameMatcher,
+		nameMatchThreshold:       deps.NameMatchThreshold,
+	}
+}
+
+func (s *screener) Screen(disbursement domain.Disbursement) []domain.ScreeningMatch {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matches []domain.ScreeningMatch
+	for _, entry := range s.entries {
+		if entry.AccountNumber != "" && entry.AccountNumber == disbursement.RecipientAccountNumber &&
+			(entry.BankCode == "" || entry.BankCode == disbursement.RecipientBankCode) {
+			matches = append(matches, toScreeningMatch(entry, domain.ScreeningMatchFieldAccountNumber, 100))
+			continue
+		}
+
+		if entry.Name == "" {
+			continue
+		}
+
+		score := s.nameMatcher.Score(disbursement.RecipientName, entry.Name)
+		if score >= s.nameMatchThreshold {
+			matches = append(matches, toScreeningMatch(entry, domain.ScreeningMatchFieldName, score))
+		}
+	}
+
+	return matches
+}
+
+func (s *screener) Reload(ctx context.Context) error {
+	entries, err := s.screeningEntryRepository.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = entries
+	return nil
+}
+
+func toScreeningMatch(entry domain.ScreeningEntry, field domain.ScreeningMatchField, score int) domain.ScreeningMatch {
+	return domain.ScreeningMatch{
+		EntryId:   entry.Id,
+		ListName:  entry.ListName,
+		EntryName: entry.Name,
+		Reference: entry.Reference,
+		Field:     field,
+		Score:     score,
+	}
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	mock_api "github.com/nobbyphala/Brick/mock/api"
+	mock_repository "github.com/nobbyphala/Brick/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func Test_screener_Screen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNameMatcher := mock_api.NewMockNameMatcher(ctrl)
+	mockScreeningEntryRepo := mock_repository.NewMockScreeningEntry(ctrl)
+
+	sanctioned := domain.ScreeningEntry{Id: "entry-1", ListName: "OFAC", Name: "Budi Santoso Wibowo", Reference: "SDN-1"}
+	blockedAccount := domain.ScreeningEntry{Id: "entry-2", ListName: domain.ScreeningListInternal, AccountNumber: "1111111", BankCode: "014", Reference: "fraud report"}
+	blockedEverywhere := domain.ScreeningEntry{Id: "entry-3", ListName: domain.ScreeningListInternal, AccountNumber: "2222222", Reference: "mule account"}
+
+	mockScreeningEntryRepo.EXPECT().ListAll(gomock.Any()).Return([]domain.ScreeningEntry{sanctioned, blockedAccount, blockedEverywhere}, nil)
+
+	s := NewScreener(ScreenerDeps{
+		ScreeningEntryRepository: mockScreeningEntryRepo,
+		NameMatcher:              mockNameMatcher,
+		NameMatchThreshold:       80,
+	})
+	assert.NoError(t, s.Reload(context.TODO()))
+
+	tests := []struct {
+		name         string
+		disbursement domain.Disbursement
+		want         []domain.ScreeningMatch
+		mock         func()
+	}{
+		{
+			name:         "cleared recipient",
+			disbursement: domain.Disbursement{RecipientName: "Nobby Phala", RecipientAccountNumber: "6789567", RecipientBankCode: "014"},
+			mock: func() {
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Budi Santoso Wibowo").Return(0)
+			},
+		},
+		{
+			name:         "similar name match",
+			disbursement: domain.Disbursement{RecipientName: "Budi Santoso", RecipientAccountNumber: "6789567", RecipientBankCode: "014"},
+			want: []domain.ScreeningMatch{
+				{EntryId: "entry-1", ListName: "OFAC", EntryName: "Budi Santoso Wibowo", Reference: "SDN-1", Field: domain.ScreeningMatchFieldName, Score: 80},
+			},
+			mock: func() {
+				mockNameMatcher.EXPECT().Score("Budi Santoso", "Budi Santoso Wibowo").Return(80)
+			},
+		},
+		{
+			name:         "blocked account in the same bank",
+			disbursement: domain.Disbursement{RecipientName: "Nobby Phala", RecipientAccountNumber: "1111111", RecipientBankCode: "014"},
+			want: []domain.ScreeningMatch{
+				{EntryId: "entry-2", ListName: domain.ScreeningListInternal, Reference: "fraud report", Field: domain.ScreeningMatchFieldAccountNumber, Score: 100},
+			},
+			mock: func() {
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Budi Santoso Wibowo").Return(0)
+			},
+		},
+		{
+			name:         "same account number in another bank",
+			disbursement: domain.Disbursement{RecipientName: "Nobby Phala", RecipientAccountNumber: "1111111", RecipientBankCode: "008"},
+			mock: func() {
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Budi Santoso Wibowo").Return(0)
+			},
+		},
+		{
+			name:         "account blocked in every bank",
+			disbursement: domain.Disbursement{RecipientName: "Nobby Phala", RecipientAccountNumber: "2222222", RecipientBankCode: "008"},
+			want: []domain.ScreeningMatch{
+				{EntryId: "entry-3", ListName: domain.ScreeningListInternal, Reference: "mule account", Field: domain.ScreeningMatchFieldAccountNumber, Score: 100},
+			},
+			mock: func() {
+				mockNameMatcher.EXPECT().Score("Nobby Phala", "Budi Santoso Wibowo").Return(0)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			assert.Equal(t, tt.want, s.Screen(tt.disbursement))
+		})
+	}
+
+	// the entries loaded before are kept when the lists cannot be read
+	mockScreeningEntryRepo.EXPECT().ListAll(gomock.Any()).Return(nil, errors.New("sql error"))
+	assert.Equal(t, errors.New("sql error"), s.Reload(context.TODO()))
+	assert.Len(t, s.entries, 3)
+}
@@ -0,0 +1,562 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"io"
+	"log"
+	"strings"
+	"time"
+)
+
+// callers page through the screenings and the entries, a single page never load more than this
+const maxScreeningListLimit = 100
+
+type screeningUsecase struct {
+	bankApi                  api.Bank
+	screener                 Screener
+	screeningRepository      repository.Screening
+	screeningEntryRepository repository.ScreeningEntry
+	disbursementRepository   repository.Disbursement
+	auditLogRepository       repository.AuditLog
+	utilsRepository          repository.Utils
+	webhookOutbox            webhookOutbox
+	eventOutbox              eventOutbox
+}
+
+type ScreeningDeps struct {
+	// released disbursement is sent to the bank
+	BankApi api.Bank
+	// reloaded after the lists changed
+	Screener                 Screener
+	ScreeningRepository      repository.Screening
+	ScreeningEntryRepository repository.ScreeningEntry
+	DisbursementRepository   repository.Disbursement
+	AuditLogRepository       repository.AuditLog
+	UtilsRepository          repository.Utils
+	// status change is sent to the webhook endpoints of the merchant
+	WebhookEndpointRepository repository.WebhookEndpoint
+	WebhookDeliveryRepository repository.WebhookDelivery
+	// domain events are recorded in the transaction of the change
+	OutboxRepository repository.Outbox
+}
+
+func NewScreening(deps ScreeningDeps) *screeningUsecase {
+	return &screeningUsecase{
+		bankApi:                  deps.BankApi,
+		screener:                 deps.Screener,
+		screeningRepository:      deps.ScreeningRepository,
+		screeningEntryRepository: deps.ScreeningEntryRepository,
+		disbursementRepository:   deps.DisbursementRepository,
+		auditLogRepository:       deps.AuditLogRepository,
+		utilsRepository:          deps.UtilsRepository,
+		webhookOutbox: webhookOutbox{
+			endpointRepository: deps.WebhookEndpointRepository,
+			deliveryRepository: deps.WebhookDeliveryRepository,
+		},
+		eventOutbox: eventOutbox{
+			outboxRepository: deps.OutboxRepository,
+		},
+	}
+}
+
+func (su screeningUsecase) ListScreenings(ctx context.Context, filter domain.ScreeningFilter) ([]domain.Screening, error) {
+	if filter.Limit < 0 || filter.Limit > maxScreeningListLimit || filter.Offset < 0 {
+		return nil, internal_error.ErrInvalidRequest
+	}
+
+	screenings, err := su.screeningRepository.List(ctx, filter)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return screenings, nil
+}
+
+func (su screeningUsecase) GetScreening(ctx context.Context, id string) (ScreeningDetail, error) {
+	screening, err := getScreening(ctx, su.screeningRepository, id)
+	if err != nil {
+		return ScreeningDetail{}, err
+	}
+
+	disbursement, err := getScreenedDisbursement(ctx, su.disbursementRepository, screening)
+	if err != nil {
+		return ScreeningDetail{}, err
+	}
+
+	return ScreeningDetail{
+		Screening:    screening,
+		Disbursement: disbursement,
+	}, nil
+}
+
+func (su screeningUsecase) ResolveScreening(ctx context.Context, id string, resolution domain.ScreeningResolution, note string) (ScreeningDetail, error) {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return ScreeningDetail{}, internal_error.ErrOperationReasonRequired
+	}
+
+	if resolution != domain.ScreeningResolutionRelease && resolution != domain.ScreeningResolutionReject {
+		return ScreeningDetail{}, internal_error.ErrScreeningInvalidResolution
+	}
+
+	var res ScreeningDetail
+
+	// the screening is resolved before the transfer, so the disbursement cannot be released twice
+	err := su.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+		screeningRepo := su.screeningRepository.WithTx(Tx)
+
+		screening, err := getScreening(ctx, screeningRepo, id)
+		if err != nil {
+			return err
+		}
+
+		if !screening.IsHeld() {
+			return internal_error.ErrScreeningResolved
+		}
+
+		disbursement, err := getScreenedDisbursement(ctx, su.disbursementRepository.WithTx(Tx), screening)
+		if err != nil {
+			return err
+		}
+
+		if disbursement.Status != domain.DisbursementStatusOnHold {
+			return internal_error.ErrScreeningResolved
+		}
+
+		auditLog := withActor(ctx, domain.AuditLog{
+			Action:     domain.AuditActionResolveScreening,
+			ResourceId: screening.Id,
+			Outcome:    domain.AuditOutcomeAllowed,
+			Reason:     fmt.Sprintf("%s disbursement %s: %s", resolution, disbursement.Id, note),
+		})
+
+		resolvedAt := time.Now()
+		screening.Status = domain.ScreeningStatusReleased
+		screening.ResolvedBy = auditLog.ActorId
+		screening.ResolutionNote = note
+		screening.ResolvedAt = &resolvedAt
+		screening.UpdatedAt = resolvedAt
+		if resolution == domain.ScreeningResolutionReject {
+			screening.Status = domain.ScreeningStatusRejected
+		}
+
+		err = screeningRepo.UpdateById(ctx, id, screening)
+		if errors.Is(err, internal_error.ErrNoRowsAffected) {
+			return internal_error.ErrScreeningResolved
+		}
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		// the released disbursement is pending from now on, without bank transaction id until the transfer is
+		// recorded, so it does not stay on hold when the process stop before
+		status := domain.DisbursementStatusPending
+		if resolution == domain.ScreeningResolutionReject {
+			status = domain.DisbursementStatusRejected
+		}
+
+		err = su.changeStatus(ctx, Tx, &disbursement, status)
+		if err != nil {
+			return err
+		}
+
+		_, err = su.auditLogRepository.WithTx(Tx).Insert(ctx, auditLog)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		res = ScreeningDetail{
+			Screening:    screening,
+			Disbursement: disbursement,
+		}
+		return nil
+	})
+	if err != nil {
+		return ScreeningDetail{}, err
+	}
+
+	if resolution == domain.ScreeningResolutionRelease {
+		res.Disbursement, err = su.transfer(ctx, res.Disbursement)
+		if err != nil {
+			return ScreeningDetail{}, err
+		}
+	}
+
+	return res, nil
+}
+
+// transfer send the released disbursement to the bank, the disbursement fail only when the bank refuse the transfer
+func (su screeningUsecase) transfer(ctx context.Context, disbursement domain.Disbursement) (domain.Disbursement, error) {
+	transferResponse, err := su.bankApi.TransferMoney(ctx, api.TransferRequest{
+		AccountHolderNumber: disbursement.RecipientAccountNumber,
+		AccountHolderName:   disbursement.RecipientName,
+		DestinationBankCode: disbursement.RecipientBankCode,
+		Amount:              disbursement.Amount,
+	})
+	if err != nil {
+		// the bank may have moved the money, the disbursement stay pending until its bank callback, parked for
+		// review without the bank transaction id, settle it
+		log.Println("error transferring released disbursement", disbursement.Id, err)
+		return disbursement, nil
+	}
+
+	var res domain.Disbursement
+
+	err = su.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+		res = disbursement
+		res.BankTransactionId = transferResponse.TransactionId
+
+		bankStatus := toDisbursementStatus(transferResponse.TransferStatus)
+		if bankStatus == domain.DisbursementStatusFailed || bankStatus == domain.DisbursementStatusRejected {
+			log.Println("bank refused the transfer of released disbursement", disbursement.Id, transferResponse.TransferStatus)
+			return su.changeStatus(ctx, Tx, &res, bankStatus)
+		}
+
+		err := su.disbursementRepository.WithTx(Tx).UpdateById(ctx, res.Id, res.Version, res)
+		if err != nil {
+			log.Println(err)
+			return internal_error.ErrUpdateDisbursementStatus
+		}
+
+		res.Version++
+		return nil
+	})
+	if err != nil {
+		// the disbursement stay pending without the bank transaction id, so the bank callback of the transfer is
+		// parked for review
+		log.Println("error recording transfer of released disbursement", disbursement.Id, transferResponse.TransactionId, err)
+		return domain.Disbursement{}, internal_error.ErrUpdateDisbursementStatus
+	}
+
+	return res, nil
+}
+
+// changeStatus update the status of the disbursement and send the change to the merchant
+func (su screeningUsecase) changeStatus(ctx context.Context, Tx database.SQLDatabase, disbursement *domain.Disbursement, status domain.DisbursementStatus) error {
+	previousStatus := disbursement.Status
+	disbursement.Status = status
+
+	err := su.disbursementRepository.WithTx(Tx).UpdateById(ctx, disbursement.Id, disbursement.Version, *disbursement)
+	if errors.Is(err, internal_error.ErrVersionConflict) {
+		return err
+	}
+	if err != nil {
+		log.Println(err)
+		return internal_error.ErrUpdateDisbursementStatus
+	}
+
+	disbursement.Version++
+
+	err = su.webhookOutbox.enqueue(ctx, Tx, *disbursement)
+	if err != nil {
+		log.Println(err)
+		return internal_error.ErrUpdateDisbursementStatus
+	}
+
+	err = su.eventOutbox.disbursementStatusChanged(ctx, Tx, *disbursement, previousStatus)
+	if err != nil {
+		log.Println(err)
+		return internal_error.ErrUpdateDisbursementStatus
+	}
+
+	return nil
+}
+
+func (su screeningUsecase) ImportScreeningList(ctx context.Context, listName string, format domain.ScreeningListFormat, file io.Reader) (int, error) {
+	listName = strings.ToUpper(strings.TrimSpace(listName))
+	if listName == "" {
+		return 0, internal_error.ErrScreeningListInvalid
+	}
+
+	if listName == domain.ScreeningListInternal {
+		return 0, internal_error.ErrScreeningListReserved
+	}
+
+	entries, err := parseScreeningList(format, file)
+	if err != nil {
+		log.Println(err)
+		return 0, internal_error.ErrScreeningListInvalid
+	}
+
+	// an empty file would silently remove the list
+	if len(entries) == 0 {
+		return 0, internal_error.ErrScreeningListInvalid
+	}
+
+	for _, entry := range entries {
+		if entry.Name == "" && entry.AccountNumber == "" {
+			return 0, internal_error.ErrScreeningListInvalid
+		}
+	}
+
+	err = su.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+		entryRepo := su.screeningEntryRepository.WithTx(Tx)
+
+		err := entryRepo.DeleteByListName(ctx, listName)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		for _, entry := range entries {
+			entry.ListName = listName
+
+			_, err = entryRepo.Insert(ctx, entry)
+			if err != nil {
+				log.Println(err)
+				return err
+			}
+		}
+
+		_, err = su.auditLogRepository.WithTx(Tx).Insert(ctx, withActor(ctx, domain.AuditLog{
+			Action:     domain.AuditActionImportScreening,
+			ResourceId: listName,
+			Outcome:    domain.AuditOutcomeAllowed,
+			Reason:     fmt.Sprintf("%d entries imported from %s", len(entries), format),
+		}))
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	su.reload(ctx)
+
+	return len(entries), nil
+}
+
+func (su screeningUsecase) ListScreeningEntries(ctx context.Context, filter domain.ScreeningEntryFilter) ([]domain.ScreeningEntry, error) {
+	if filter.Limit < 0 || filter.Limit > maxScreeningListLimit || filter.Offset < 0 {
+		return nil, internal_error.ErrInvalidRequest
+	}
+
+	// list names are stored upper cased by the import
+	filter.ListName = strings.ToUpper(strings.TrimSpace(filter.ListName))
+
+	entries, err := su.screeningEntryRepository.List(ctx, filter)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (su screeningUsecase) AddBlocklistEntry(ctx context.Context, entry domain.ScreeningEntry) (domain.ScreeningEntry, error) {
+	entry.Reference = strings.TrimSpace(entry.Reference)
+	if entry.Reference == "" {
+		return domain.ScreeningEntry{}, internal_error.ErrOperationReasonRequired
+	}
+
+	entry.Name = strings.TrimSpace(entry.Name)
+	entry.AccountNumber = strings.TrimSpace(entry.AccountNumber)
+	if entry.Name == "" && entry.AccountNumber == "" {
+		return domain.ScreeningEntry{}, internal_error.ErrBlocklistEntryInvalid
+	}
+
+	entry.ListName = domain.ScreeningListInternal
+
+	err := su.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+		var err error
+
+		entry.Id, err = su.screeningEntryRepository.WithTx(Tx).Insert(ctx, entry)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		_, err = su.auditLogRepository.WithTx(Tx).Insert(ctx, withActor(ctx, domain.AuditLog{
+			Action:     domain.AuditActionAddBlocklist,
+			ResourceId: entry.Id,
+			Outcome:    domain.AuditOutcomeAllowed,
+			Reason:     entry.Reference,
+		}))
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.ScreeningEntry{}, err
+	}
+
+	// the stored creation time is set by the database a moment earlier
+	entry.CreatedAt = time.Now()
+
+	su.reload(ctx)
+
+	return entry, nil
+}
+
+func (su screeningUsecase) DeleteBlocklistEntry(ctx context.Context, id string, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return internal_error.ErrOperationReasonRequired
+	}
+
+	err := su.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+		err := su.screeningEntryRepository.WithTx(Tx).DeleteById(ctx, domain.ScreeningListInternal, id)
+		if errors.Is(err, internal_error.ErrNoRowsAffected) {
+			return internal_error.ErrBlocklistEntryNotFound
+		}
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		_, err = su.auditLogRepository.WithTx(Tx).Insert(ctx, withActor(ctx, domain.AuditLog{
+			Action:     domain.AuditActionDeleteBlocklist,
+			ResourceId: id,
+			Outcome:    domain.AuditOutcomeAllowed,
+			Reason:     reason,
+		}))
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	su.reload(ctx)
+
+	return nil
+}
+
+// reload apply the changed lists to the screener right away, the periodic reload retry when it fails
+func (su screeningUsecase) reload(ctx context.Context) {
+	err := su.screener.Reload(ctx)
+	if err != nil {
+		log.Println("error reloading screening lists:", err)
+	}
+}
+
+// screeningListXML is the xml format of the imported lists
+type screeningListXML struct {
+	XMLName xml.Name `xml:"screening_list"`
+	Entries []struct {
+		Name          string `xml:"name"`
+		AccountNumber string `xml:"account_number"`
+		BankCode      string `xml:"bank_code"`
+		Reference     string `xml:"reference"`
+	} `xml:"entry"`
+}
+
+// parseScreeningList read the entries of a list file. Csv file has a header row naming the columns name,
+// account_number, bank_code and reference in any order, missing columns are empty
+func parseScreeningList(format domain.ScreeningListFormat, file io.Reader) ([]domain.ScreeningEntry, error) {
+	var entries []domain.ScreeningEntry
+
+	switch format {
+	case domain.ScreeningListFormatCSV:
+		reader := csv.NewReader(file)
+		reader.TrimLeadingSpace = true
+
+		header, err := reader.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		columns := make(map[string]int)
+		for i, column := range header {
+			columns[strings.ToLower(strings.TrimSpace(column))] = i
+		}
+
+		value := func(record []string, column string) string {
+			i, exists := columns[column]
+			if !exists {
+				return ""
+			}
+
+			return strings.TrimSpace(record[i])
+		}
+
+		for {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			entries = append(entries, domain.ScreeningEntry{
+				Name:          value(record, "name"),
+				AccountNumber: value(record, "account_number"),
+				BankCode:      value(record, "bank_code"),
+				Reference:     value(record, "reference"),
+			})
+		}
+	case domain.ScreeningListFormatXML:
+		var list screeningListXML
+
+		err := xml.NewDecoder(file).Decode(&list)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range list.Entries {
+			entries = append(entries, domain.ScreeningEntry{
+				Name:          strings.TrimSpace(entry.Name),
+				AccountNumber: strings.TrimSpace(entry.AccountNumber),
+				BankCode:      strings.TrimSpace(entry.BankCode),
+				Reference:     strings.TrimSpace(entry.Reference),
+			})
+		}
+	default:
+		return nil, fmt.Errorf("unknown screening list format %q", format)
+	}
+
+	return entries, nil
+}
+
+// getScreening return the screening or internal_error.ErrScreeningNotFound
+func getScreening(ctx context.Context, screeningRepository repository.Screening, id string) (domain.Screening, error) {
+	screening, err := screeningRepository.GetById(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return domain.Screening{}, err
+	}
+
+	if screening == nil {
+		return domain.Screening{}, internal_error.ErrScreeningNotFound
+	}
+
+	return *screening, nil
+}
+
+func getScreenedDisbursement(ctx context.Context, disbursementRepository repository.Disbursement, screening domain.Screening) (domain.Disbursement, error) {
+	disbursement, err := disbursementRepository.GetById(ctx, screening.DisbursementId)
+	if err != nil {
+		log.Println(err)
+		return domain.Disbursement{}, err
+	}
+
+	if disbursement == nil {
+		return domain.Disbursement{}, internal_error.ErrDisbursementNotFound
+	}
+
+	return *disbursement, nil
+}
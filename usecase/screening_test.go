@@ -0,0 +1,509 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/mock"
+	mock_api "github.com/nobbyphala/Brick/mock/api"
+	mock_repository "github.com/nobbyphala/Brick/mock/repository"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+)
+
+func Test_screeningUsecase_ResolveScreening(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+	mockBankApi := mock_api.NewMockBank(ctrl)
+	mockScreeningRepo := mock_repository.NewMockScreening(ctrl)
+	mockDisbursementRepo := mock_repository.NewMockDisbursement(ctrl)
+	mockAuditLogRepo := mock_repository.NewMockAuditLog(ctrl)
+	mockUtilRepo := mock_repository.NewMockUtils(ctrl)
+	mockOutboxRepo := mock_repository.NewMockOutbox(ctrl)
+	mockWebhookEndpointRepo := mock_repository.NewMockWebhookEndpoint(ctrl)
+	mockWebhookDeliveryRepo := mock_repository.NewMockWebhookDelivery(ctrl)
+
+	complianceCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "compliance-1", Role: domain.RoleCompliance, ApiKeyId: "key-id-1"})
+
+	held := domain.Screening{
+		Id:             "screening-1",
+		DisbursementId: "disb-id-1",
+		MerchantId:     "merchant-1",
+		Status:         domain.ScreeningStatusHeld,
+	}
+	onHold := domain.Disbursement{
+		Id:                     "disb-id-1",
+		MerchantId:             "merchant-1",
+		RecipientName:          "Nobby Phala",
+		RecipientAccountNumber: "6789567",
+		RecipientBankCode:      "014",
+		Amount:                 60000,
+		Status:                 domain.DisbursementStatusOnHold,
+		Version:                1,
+	}
+
+	runTx := func() {
+		mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+			return handler(ctx, mockSQL)
+		})
+	}
+	claim := func(status domain.ScreeningStatus) {
+		runTx()
+		stored := held
+		storedDisbursement := onHold
+		mockScreeningRepo.EXPECT().WithTx(mockSQL).Return(mockScreeningRepo)
+		mockScreeningRepo.EXPECT().GetById(gomock.Any(), "screening-1").Return(&stored, nil)
+		mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+		mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(&storedDisbursement, nil)
+		mockScreeningRepo.EXPECT().UpdateById(gomock.Any(), "screening-1", gomock.Any()).DoAndReturn(func(ctx context.Context, id string, screening domain.Screening) error {
+			assert.Equal(t, status, screening.Status)
+			assert.Equal(t, "compliance-1", screening.ResolvedBy)
+			assert.NotNil(t, screening.ResolvedAt)
+			return nil
+		})
+	}
+	expectStatusChanged := func(previousStatus, status string) {
+		mockWebhookEndpointRepo.EXPECT().WithTx(mockSQL).Return(mockWebhookEndpointRepo)
+		mockWebhookEndpointRepo.EXPECT().ListByMerchantId(gomock.Any(), "merchant-1").Return(nil, nil)
+		mockWebhookDeliveryRepo.EXPECT().WithTx(mockSQL).Return(mockWebhookDeliveryRepo)
+		mockOutboxRepo.EXPECT().WithTx(mockSQL).Return(mockOutboxRepo)
+		mockOutboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event domain.OutboxEvent) error {
+			var changed domain.DisbursementStatusChanged
+			assert.NoError(t, json.Unmarshal(event.Payload, &changed))
+			assert.Equal(t, previousStatus, changed.PreviousStatus)
+			assert.Equal(t, status, changed.Status)
+			return nil
+		})
+	}
+	// the release move the disbursement out of hold in the transaction resolving the screening
+	release := func() {
+		mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+		mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(1), gomock.Any()).DoAndReturn(func(ctx context.Context, id string, version int64, disbursement domain.Disbursement) error {
+			assert.Equal(t, "", disbursement.BankTransactionId)
+			assert.Equal(t, domain.DisbursementStatusPending, disbursement.Status)
+			return nil
+		})
+		expectStatusChanged("ON_HOLD", "PENDING")
+	}
+	expectAudit := func(reason string) {
+		mockAuditLogRepo.EXPECT().WithTx(mockSQL).Return(mockAuditLogRepo)
+		mockAuditLogRepo.EXPECT().Insert(gomock.Any(), domain.AuditLog{
+			ActorId:    "compliance-1",
+			ActorRole:  domain.RoleCompliance,
+			ApiKeyId:   "key-id-1",
+			Action:     domain.AuditActionResolveScreening,
+			ResourceId: "screening-1",
+			Outcome:    domain.AuditOutcomeAllowed,
+			Reason:     reason,
+		}).Return("audit-id-1", nil)
+	}
+
+	tests := []struct {
+		name             string
+		resolution       domain.ScreeningResolution
+		note             string
+		wantStatus       domain.ScreeningStatus
+		wantDisbursement domain.Disbursement
+		wantErr          error
+		mock             func()
+	}{
+		{
+			name:       "released disbursement sent to the bank",
+			resolution: domain.ScreeningResolutionRelease,
+			note:       "different date of birth",
+			wantStatus: domain.ScreeningStatusReleased,
+			wantDisbursement: domain.Disbursement{
+				Id:                     "disb-id-1",
+				MerchantId:             "merchant-1",
+				RecipientName:          "Nobby Phala",
+				RecipientAccountNumber: "6789567",
+				RecipientBankCode:      "014",
+				BankTransactionId:      "txn-id-1",
+				Amount:                 60000,
+				Status:                 domain.DisbursementStatusPending,
+				Version:                3,
+			},
+			mock: func() {
+				claim(domain.ScreeningStatusReleased)
+				release()
+				expectAudit("release disbursement disb-id-1: different date of birth")
+				mockBankApi.EXPECT().TransferMoney(gomock.Any(), api.TransferRequest{
+					AccountHolderNumber: "6789567",
+					AccountHolderName:   "Nobby Phala",
+					DestinationBankCode: "014",
+					Amount:              60000,
+				}).Return(api.TransferResponse{TransactionId: "txn-id-1"}, nil)
+				runTx()
+				mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+				mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(2), gomock.Any()).DoAndReturn(func(ctx context.Context, id string, version int64, disbursement domain.Disbursement) error {
+					assert.Equal(t, "txn-id-1", disbursement.BankTransactionId)
+					assert.Equal(t, domain.DisbursementStatusPending, disbursement.Status)
+					return nil
+				})
+			},
+		},
+		{
+			name:       "transfer not recorded leave the released disbursement pending",
+			resolution: domain.ScreeningResolutionRelease,
+			note:       "different date of birth",
+			wantErr:    internal_error.ErrUpdateDisbursementStatus,
+			mock: func() {
+				claim(domain.ScreeningStatusReleased)
+				release()
+				expectAudit("release disbursement disb-id-1: different date of birth")
+				mockBankApi.EXPECT().TransferMoney(gomock.Any(), gomock.Any()).Return(api.TransferResponse{TransactionId: "txn-id-1"}, nil)
+				runTx()
+				mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+				mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(2), gomock.Any()).Return(errors.New("sql error"))
+			},
+		},
+		{
+			name:       "released disbursement refused by the bank fail",
+			resolution: domain.ScreeningResolutionRelease,
+			note:       "different date of birth",
+			wantStatus: domain.ScreeningStatusReleased,
+			wantDisbursement: domain.Disbursement{
+				Id:                     "disb-id-1",
+				MerchantId:             "merchant-1",
+				RecipientName:          "Nobby Phala",
+				RecipientAccountNumber: "6789567",
+				RecipientBankCode:      "014",
+				Amount:                 60000,
+				BankTransactionId:      "txn-id-1",
+				Status:                 domain.DisbursementStatusFailed,
+				Version:                3,
+			},
+			mock: func() {
+				claim(domain.ScreeningStatusReleased)
+				release()
+				expectAudit("release disbursement disb-id-1: different date of birth")
+				mockBankApi.EXPECT().TransferMoney(gomock.Any(), gomock.Any()).Return(api.TransferResponse{TransactionId: "txn-id-1", TransferStatus: api.TransferStatusFailed}, nil)
+				runTx()
+				mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+				mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(2), gomock.Any()).Return(nil)
+				expectStatusChanged("PENDING", "FAILED")
+			},
+		},
+		{
+			name:       "released disbursement with unknown transfer outcome stay pending",
+			resolution: domain.ScreeningResolutionRelease,
+			note:       "different date of birth",
+			wantStatus: domain.ScreeningStatusReleased,
+			wantDisbursement: domain.Disbursement{
+				Id:                     "disb-id-1",
+				MerchantId:             "merchant-1",
+				RecipientName:          "Nobby Phala",
+				RecipientAccountNumber: "6789567",
+				RecipientBankCode:      "014",
+				Amount:                 60000,
+				Status:                 domain.DisbursementStatusPending,
+				Version:                2,
+			},
+			mock: func() {
+				claim(domain.ScreeningStatusReleased)
+				release()
+				expectAudit("release disbursement disb-id-1: different date of birth")
+				mockBankApi.EXPECT().TransferMoney(gomock.Any(), gomock.Any()).Return(api.TransferResponse{}, errors.New("timeout"))
+			},
+		},
+		{
+			name:       "rejected disbursement not sent to the bank",
+			resolution: domain.ScreeningResolutionReject,
+			note:       "confirmed sanctioned party",
+			wantStatus: domain.ScreeningStatusRejected,
+			wantDisbursement: domain.Disbursement{
+				Id:                     "disb-id-1",
+				MerchantId:             "merchant-1",
+				RecipientName:          "Nobby Phala",
+				RecipientAccountNumber: "6789567",
+				RecipientBankCode:      "014",
+				Amount:                 60000,
+				Status:                 domain.DisbursementStatusRejected,
+				Version:                2,
+			},
+			mock: func() {
+				claim(domain.ScreeningStatusRejected)
+				mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+				mockDisbursementRepo.EXPECT().UpdateById(gomock.Any(), "disb-id-1", int64(1), gomock.Any()).Return(nil)
+				expectStatusChanged("ON_HOLD", "REJECTED")
+				expectAudit("reject disbursement disb-id-1: confirmed sanctioned party")
+			},
+		},
+		{
+			name:       "screening resolved concurrently",
+			resolution: domain.ScreeningResolutionRelease,
+			note:       "different date of birth",
+			wantErr:    internal_error.ErrScreeningResolved,
+			mock: func() {
+				runTx()
+				stored := held
+				storedDisbursement := onHold
+				mockScreeningRepo.EXPECT().WithTx(mockSQL).Return(mockScreeningRepo)
+				mockScreeningRepo.EXPECT().GetById(gomock.Any(), "screening-1").Return(&stored, nil)
+				mockDisbursementRepo.EXPECT().WithTx(mockSQL).Return(mockDisbursementRepo)
+				mockDisbursementRepo.EXPECT().GetById(gomock.Any(), "disb-id-1").Return(&storedDisbursement, nil)
+				mockScreeningRepo.EXPECT().UpdateById(gomock.Any(), "screening-1", gomock.Any()).Return(internal_error.ErrNoRowsAffected)
+			},
+		},
+		{
+			name:       "cleared screening cannot be resolved",
+			resolution: domain.ScreeningResolutionReject,
+			note:       "confirmed sanctioned party",
+			wantErr:    internal_error.ErrScreeningResolved,
+			mock: func() {
+				runTx()
+				mockScreeningRepo.EXPECT().WithTx(mockSQL).Return(mockScreeningRepo)
+				mockScreeningRepo.EXPECT().GetById(gomock.Any(), "screening-1").Return(&domain.Screening{Id: "screening-1", Status: domain.ScreeningStatusCleared}, nil)
+			},
+		},
+		{
+			name:       "screening not found",
+			resolution: domain.ScreeningResolutionReject,
+			note:       "confirmed sanctioned party",
+			wantErr:    internal_error.ErrScreeningNotFound,
+			mock: func() {
+				runTx()
+				mockScreeningRepo.EXPECT().WithTx(mockSQL).Return(mockScreeningRepo)
+				mockScreeningRepo.EXPECT().GetById(gomock.Any(), "screening-1").Return(nil, nil)
+			},
+		},
+		{
+			name:       "note is mandatory",
+			resolution: domain.ScreeningResolutionRelease,
+			note:       " ",
+			wantErr:    internal_error.ErrOperationReasonRequired,
+			mock:       func() {},
+		},
+		{
+			name:       "unknown resolution",
+			resolution: "escalate",
+			note:       "need a second opinion",
+			wantErr:    internal_error.ErrScreeningInvalidResolution,
+			mock:       func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			su := NewScreening(ScreeningDeps{
+				BankApi:                   mockBankApi,
+				ScreeningRepository:       mockScreeningRepo,
+				DisbursementRepository:    mockDisbursementRepo,
+				AuditLogRepository:        mockAuditLogRepo,
+				UtilsRepository:           mockUtilRepo,
+				OutboxRepository:          mockOutboxRepo,
+				WebhookEndpointRepository: mockWebhookEndpointRepo,
+				WebhookDeliveryRepository: mockWebhookDeliveryRepo,
+			})
+
+			got, err := su.ResolveScreening(complianceCtx, "screening-1", tt.resolution, tt.note)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantStatus, got.Screening.Status)
+			assert.Equal(t, tt.wantDisbursement, got.Disbursement)
+		})
+	}
+}
+
+func Test_screeningUsecase_ImportScreeningList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+	mockScreeningEntryRepo := mock_repository.NewMockScreeningEntry(ctrl)
+	mockAuditLogRepo := mock_repository.NewMockAuditLog(ctrl)
+	mockUtilRepo := mock_repository.NewMockUtils(ctrl)
+
+	complianceCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "compliance-1", Role: domain.RoleCompliance, ApiKeyId: "key-id-1"})
+
+	imported := func(reason string, entries ...domain.ScreeningEntry) {
+		mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+			return handler(ctx, mockSQL)
+		})
+		mockScreeningEntryRepo.EXPECT().WithTx(mockSQL).Return(mockScreeningEntryRepo)
+		mockScreeningEntryRepo.EXPECT().DeleteByListName(gomock.Any(), "OFAC").Return(nil)
+		for _, entry := range entries {
+			mockScreeningEntryRepo.EXPECT().Insert(gomock.Any(), entry).Return("entry-id", nil)
+		}
+		mockAuditLogRepo.EXPECT().WithTx(mockSQL).Return(mockAuditLogRepo)
+		mockAuditLogRepo.EXPECT().Insert(gomock.Any(), domain.AuditLog{
+			ActorId:    "compliance-1",
+			ActorRole:  domain.RoleCompliance,
+			ApiKeyId:   "key-id-1",
+			Action:     domain.AuditActionImportScreening,
+			ResourceId: "OFAC",
+			Outcome:    domain.AuditOutcomeAllowed,
+			Reason:     reason,
+		}).Return("audit-id-1", nil)
+		// the screener read the imported list
+		mockScreeningEntryRepo.EXPECT().ListAll(gomock.Any()).Return(entries, nil)
+	}
+
+	tests := []struct {
+		name     string
+		listName string
+		format   domain.ScreeningListFormat
+		file     string
+		want     int
+		wantErr  error
+		mock     func()
+	}{
+		{
+			name:     "import csv with columns in any order",
+			listName: "ofac",
+			format:   domain.ScreeningListFormatCSV,
+			file:     "reference,name,account_number\nSDN-1,Budi Santoso Wibowo,\nSDN-2, ,1111111\n",
+			want:     2,
+			mock: func() {
+				imported("2 entries imported from csv",
+					domain.ScreeningEntry{ListName: "OFAC", Name: "Budi Santoso Wibowo", Reference: "SDN-1"},
+					domain.ScreeningEntry{ListName: "OFAC", AccountNumber: "1111111", Reference: "SDN-2"},
+				)
+			},
+		},
+		{
+			name:     "import xml",
+			listName: "OFAC",
+			format:   domain.ScreeningListFormatXML,
+			file: `<screening_list>
+	<entry><name>Budi Santoso Wibowo</name><reference>SDN-1</reference></entry>
+	<entry><account_number>1111111</account_number><bank_code>014</bank_code><reference>SDN-2</reference></entry>
+</screening_list>`,
+			want: 2,
+			mock: func() {
+				imported("2 entries imported from xml",
+					domain.ScreeningEntry{ListName: "OFAC", Name: "Budi Santoso Wibowo", Reference: "SDN-1"},
+					domain.ScreeningEntry{ListName: "OFAC", AccountNumber: "1111111", BankCode: "014", Reference: "SDN-2"},
+				)
+			},
+		},
+		{
+			name:     "internal blocklist cannot be imported",
+			listName: "internal",
+			format:   domain.ScreeningListFormatCSV,
+			file:     "name\nBudi Santoso Wibowo\n",
+			wantErr:  internal_error.ErrScreeningListReserved,
+			mock:     func() {},
+		},
+		{
+			name:     "entry without name and account number",
+			listName: "OFAC",
+			format:   domain.ScreeningListFormatCSV,
+			file:     "name,reference\nBudi Santoso Wibowo,SDN-1\n,SDN-2\n",
+			wantErr:  internal_error.ErrScreeningListInvalid,
+			mock:     func() {},
+		},
+		{
+			name:     "empty list does not remove the entries",
+			listName: "OFAC",
+			format:   domain.ScreeningListFormatCSV,
+			file:     "name,reference\n",
+			wantErr:  internal_error.ErrScreeningListInvalid,
+			mock:     func() {},
+		},
+		{
+			name:     "malformed xml",
+			listName: "OFAC",
+			format:   domain.ScreeningListFormatXML,
+			file:     "<sanctions><entry>",
+			wantErr:  internal_error.ErrScreeningListInvalid,
+			mock:     func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			su := NewScreening(ScreeningDeps{
+				Screener:                 NewScreener(ScreenerDeps{ScreeningEntryRepository: mockScreeningEntryRepo}),
+				ScreeningEntryRepository: mockScreeningEntryRepo,
+				AuditLogRepository:       mockAuditLogRepo,
+				UtilsRepository:          mockUtilRepo,
+			})
+
+			got, err := su.ImportScreeningList(complianceCtx, tt.listName, tt.format, strings.NewReader(tt.file))
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_screeningUsecase_Blocklist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+	mockScreeningEntryRepo := mock_repository.NewMockScreeningEntry(ctrl)
+	mockAuditLogRepo := mock_repository.NewMockAuditLog(ctrl)
+	mockUtilRepo := mock_repository.NewMockUtils(ctrl)
+
+	complianceCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "compliance-1", Role: domain.RoleCompliance, ApiKeyId: "key-id-1"})
+	runTx := func() {
+		mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+			return handler(ctx, mockSQL)
+		})
+		mockScreeningEntryRepo.EXPECT().WithTx(mockSQL).Return(mockScreeningEntryRepo)
+	}
+
+	su := NewScreening(ScreeningDeps{
+		Screener:                 NewScreener(ScreenerDeps{ScreeningEntryRepository: mockScreeningEntryRepo}),
+		ScreeningEntryRepository: mockScreeningEntryRepo,
+		AuditLogRepository:       mockAuditLogRepo,
+		UtilsRepository:          mockUtilRepo,
+	})
+
+	// the reason is the reference of the entry
+	runTx()
+	mockScreeningEntryRepo.EXPECT().Insert(gomock.Any(), domain.ScreeningEntry{
+		ListName:      domain.ScreeningListInternal,
+		AccountNumber: "1111111",
+		BankCode:      "014",
+		Reference:     "fraud report",
+	}).Return("entry-1", nil)
+	mockAuditLogRepo.EXPECT().WithTx(mockSQL).Return(mockAuditLogRepo)
+	mockAuditLogRepo.EXPECT().Insert(gomock.Any(), domain.AuditLog{
+		ActorId:    "compliance-1",
+		ActorRole:  domain.RoleCompliance,
+		ApiKeyId:   "key-id-1",
+		Action:     domain.AuditActionAddBlocklist,
+		ResourceId: "entry-1",
+		Outcome:    domain.AuditOutcomeAllowed,
+		Reason:     "fraud report",
+	}).Return("audit-id-1", nil)
+	mockScreeningEntryRepo.EXPECT().ListAll(gomock.Any()).Return(nil, nil)
+
+	entry, err := su.AddBlocklistEntry(complianceCtx, domain.ScreeningEntry{AccountNumber: " 1111111 ", BankCode: "014", Reference: "fraud report "})
+	assert.NoError(t, err)
+	assert.Equal(t, "entry-1", entry.Id)
+	assert.Equal(t, domain.ScreeningListInternal, entry.ListName)
+
+	_, err = su.AddBlocklistEntry(complianceCtx, domain.ScreeningEntry{Reference: "fraud report"})
+	assert.Equal(t, internal_error.ErrBlocklistEntryInvalid, err)
+
+	_, err = su.AddBlocklistEntry(complianceCtx, domain.ScreeningEntry{AccountNumber: "1111111"})
+	assert.Equal(t, internal_error.ErrOperationReasonRequired, err)
+
+	// only the internal blocklist entries can be deleted
+	runTx()
+	mockScreeningEntryRepo.EXPECT().DeleteById(gomock.Any(), domain.ScreeningListInternal, "entry-2").Return(internal_error.ErrNoRowsAffected)
+
+	err = su.DeleteBlocklistEntry(complianceCtx, "entry-2", "reported by mistake")
+	assert.Equal(t, internal_error.ErrBlocklistEntryNotFound, err)
+
+	runTx()
+	mockScreeningEntryRepo.EXPECT().DeleteById(gomock.Any(), domain.ScreeningListInternal, "entry-1").Return(nil)
+	mockAuditLogRepo.EXPECT().WithTx(mockSQL).Return(mockAuditLogRepo)
+	mockAuditLogRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return("audit-id-2", nil)
+	mockScreeningEntryRepo.EXPECT().ListAll(gomock.Any()).Return(nil, errors.New("sql error"))
+
+	// the periodic reload catch up when the screener cannot be reloaded
+	err = su.DeleteBlocklistEntry(complianceCtx, "entry-1", "reported by mistake")
+	assert.NoError(t, err)
+}
@@ -1,8 +1,94 @@
 package usecase
 
-import "github.com/nobbyphala/Brick/usecase/api"
+import (
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"time"
+)
 
+// BankCallbackData is stored as the payload of the callback review when the callback cannot be processed
 type BankCallbackData struct {
-	TransactionId string
-	Status        api.TransferStatus
+	TransactionId string             `json:"transaction_id"`
+	Status        api.TransferStatus `json:"status"`
+}
+
+type DisbursementVerification struct {
+	// AccountHolderName is the name of the account owner reported by the bank
+	AccountHolderName string
+	// similarity of the recipient name to AccountHolderName from 0 to 100, never rejected here
+	NameMatchScore    int
+	NameMatchDecision domain.NameMatchDecision
+	// Token let the disbursement of the same recipient and amount skip verification until ExpiresAt,
+	// empty when verification token is disabled
+	Token     string
+	ExpiresAt time.Time
+}
+
+type DisburseOptions struct {
+	// VerificationToken returned by VerifyDisbursement, the recipient is verified again when it does not match
+	VerificationToken string
+	// BeneficiaryId disburse to the saved recipient instead of the recipient of the disbursement
+	BeneficiaryId string
+}
+
+type DisbursementStreamFilter struct {
+	// empty to stream every disbursement
+	DisbursementId string
+	// resume after the event, 0 to stream only the events committed after subscribing
+	AfterEventId int64
+}
+
+type IssueApiKeyData struct {
+	ClientId string
+	Name     string
+	Role     domain.Role
+	// required for client role, the caller is restricted to disbursements of this merchant
+	MerchantId string
+	// key never expire when nil
+	ExpiresAt *time.Time
+}
+
+type IssuedApiKey struct {
+	ApiKey domain.ApiKey
+	// Key is the plain api key, it is not stored and only returned once
+	Key string
+}
+
+type BankStatusCheckResult struct {
+	Disbursement domain.Disbursement
+	// BankStatus is the transfer status returned by the bank
+	BankStatus api.TransferStatus
+	// Updated is true when the disbursement status is changed to follow the bank
+	Updated bool
+}
+
+type CallbackReviewDetail struct {
+	Review   domain.CallbackReview
+	Comments []domain.CallbackReviewComment
+}
+
+type ScreeningDetail struct {
+	Screening    domain.Screening
+	Disbursement domain.Disbursement
+}
+
+type WebhookDeliveryDetail struct {
+	Delivery domain.WebhookDelivery
+	Attempts []domain.WebhookDeliveryAttempt
+}
+
+// WebhookEvent is the payload sent to the merchant, it does not contain the recipient name and account number
+type WebhookEvent struct {
+	// Id is <disbursement id>:v<version>, the same event may be delivered more than once
+	Id        string                  `json:"id"`
+	Type      domain.WebhookEventType `json:"type"`
+	CreatedAt time.Time               `json:"created_at"`
+	Data      WebhookEventData        `json:"data"`
+}
+
+type WebhookEventData struct {
+	Id                string `json:"id"`
+	Amount            int64  `json:"amount"`
+	RecipientBankCode string `json:"recipient_bank_code"`
+	Status            string `json:"status"`
 }
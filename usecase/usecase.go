@@ -3,10 +3,152 @@ package usecase
 import (
 	"context"
 	"github.com/nobbyphala/Brick/domain"
+	"io"
+	"time"
 )
 
+// Disbursement VerifyDisbursement and Disburse are done on behalf of the merchant of the caller in ctx
 type Disbursement interface {
-	VerifyDisbursement(ctx context.Context, disbursement domain.Disbursement) error
-	Disburse(ctx context.Context, disbursement domain.Disbursement) (domain.Disbursement, error)
+	VerifyDisbursement(ctx context.Context, disbursement domain.Disbursement) (DisbursementVerification, error)
+	Disburse(ctx context.Context, disbursement domain.Disbursement, opts DisburseOptions) (domain.Disbursement, error)
+	// GetDisbursement only return disbursement owned by the merchant of the caller, caller without merchant such as
+	// operator can get every disbursement
+	GetDisbursement(ctx context.Context, id string) (domain.Disbursement, error)
+	// ListDisbursements is scoped the same as GetDisbursement, newest first
+	ListDisbursements(ctx context.Context, filter domain.DisbursementFilter) ([]domain.Disbursement, error)
 	ProcessBankCallback(ctx context.Context, bankCallback BankCallbackData) error
 }
+
+type KeyRotation interface {
+	// ReEncryptDisbursements re-encrypt disbursements written with an old key in batches of batchSize,
+	// return the number of disbursements re-encrypted
+	ReEncryptDisbursements(ctx context.Context, batchSize int) (int, error)
+}
+
+// DisbursementStream push the status changes of the disbursements as they are committed
+type DisbursementStream interface {
+	// Subscribe send the status events of the disbursements visible to the caller in ctx to the returned channel.
+	// The channel is closed when ctx done, the stream closed or reading the events failed
+	Subscribe(ctx context.Context, filter DisbursementStreamFilter) (<-chan domain.DisbursementStatusEvent, error)
+	// Listen wake up the subscribers when new events committed until ctx done
+	Listen(ctx context.Context)
+	// Close end every subscription, called before the server shutdown wait the in-flight requests
+	Close()
+}
+
+// DisbursementOperation is manual intervention on stuck disbursement, every action is audited with the operator identity
+type DisbursementOperation interface {
+	// ForceStatus set the status regardless of the current status, reason is mandatory
+	ForceStatus(ctx context.Context, id string, status domain.DisbursementStatus, reason string) (domain.Disbursement, error)
+	AttachBankEvidence(ctx context.Context, id string, reference string) (domain.Disbursement, error)
+	// RecheckBankStatus ask the bank the transfer status and apply it when the disbursement is still pending
+	RecheckBankStatus(ctx context.Context, id string) (BankStatusCheckResult, error)
+}
+
+// Beneficiary manage the saved recipients of the merchant of the caller in ctx
+type Beneficiary interface {
+	// CreateBeneficiary verify the account with the bank, a recipient the merchant cannot disburse to is not saved
+	CreateBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) (domain.Beneficiary, error)
+	GetBeneficiary(ctx context.Context, id string) (domain.Beneficiary, error)
+	// ListBeneficiaries return the newest beneficiaries first, caller without merchant can list every merchant
+	ListBeneficiaries(ctx context.Context, filter domain.BeneficiaryFilter) ([]domain.Beneficiary, error)
+	// UpdateBeneficiary replace name, account number and bank code, the account is verified again
+	UpdateBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) (domain.Beneficiary, error)
+	DeleteBeneficiary(ctx context.Context, id string) error
+}
+
+// VerificationCache manage the bank account verification results cached to spare the bank inquiries
+type VerificationCache interface {
+	// EvictVerification remove the result of the account so the next verification ask the bank again, reason is
+	// mandatory and audited
+	EvictVerification(ctx context.Context, bankCode string, accountNumber string, reason string) error
+}
+
+// CallbackReview is the queue of bank callbacks parked because they cannot be processed automatically
+type CallbackReview interface {
+	ListCallbackReviews(ctx context.Context, filter domain.CallbackReviewFilter) ([]domain.CallbackReview, error)
+	GetCallbackReview(ctx context.Context, id string) (CallbackReviewDetail, error)
+	// AssignCallbackReview assign the open review to assigneeId, or to the caller when assigneeId is empty
+	AssignCallbackReview(ctx context.Context, id string, assigneeId string) (domain.CallbackReview, error)
+	// ResolveCallbackReview apply the callback status to the disbursement or discard the callback, note is mandatory
+	ResolveCallbackReview(ctx context.Context, id string, resolution domain.CallbackReviewResolution, note string) (domain.CallbackReview, error)
+	CommentCallbackReview(ctx context.Context, id string, body string) (domain.CallbackReviewComment, error)
+	CountOpenCallbackReviews(ctx context.Context) ([]domain.CallbackReviewCount, error)
+}
+
+// Screener match the recipient of a disbursement against the sanctions lists and the internal blocklist kept in memory
+type Screener interface {
+	// Screen return the entries matched by the recipient, empty when the recipient is cleared
+	Screen(disbursement domain.Disbursement) []domain.ScreeningMatch
+	// Reload replace the entries in memory with the stored lists
+	Reload(ctx context.Context) error
+}
+
+// Screening is the compliance review of the disbursements held by the screening and the management of the lists
+type Screening interface {
+	// ListScreenings return the oldest screenings first
+	ListScreenings(ctx context.Context, filter domain.ScreeningFilter) ([]domain.Screening, error)
+	GetScreening(ctx context.Context, id string) (ScreeningDetail, error)
+	// ResolveScreening send the held disbursement to the bank or reject it, note is mandatory
+	ResolveScreening(ctx context.Context, id string, resolution domain.ScreeningResolution, note string) (ScreeningDetail, error)
+	// ImportScreeningList replace the entries of the sanctions list with the entries read from file, return the
+	// number of entries imported
+	ImportScreeningList(ctx context.Context, listName string, format domain.ScreeningListFormat, file io.Reader) (int, error)
+	// ListScreeningEntries return the newest entries of the sanctions lists and the internal blocklist first
+	ListScreeningEntries(ctx context.Context, filter domain.ScreeningEntryFilter) ([]domain.ScreeningEntry, error)
+	// AddBlocklistEntry add the entry to the internal blocklist, the reason is the entry reference and is mandatory
+	AddBlocklistEntry(ctx context.Context, entry domain.ScreeningEntry) (domain.ScreeningEntry, error)
+	// DeleteBlocklistEntry remove the entry from the internal blocklist, reason is mandatory
+	DeleteBlocklistEntry(ctx context.Context, id string, reason string) error
+}
+
+// Webhook manage the webhook endpoints of merchants and send the disbursement status changes to them
+type Webhook interface {
+	// CreateWebhookEndpoint generate the signing secret of the endpoint, the secret is only returned here
+	CreateWebhookEndpoint(ctx context.Context, endpoint domain.WebhookEndpoint) (domain.WebhookEndpoint, error)
+	ListWebhookEndpoints(ctx context.Context, merchantId string) ([]domain.WebhookEndpoint, error)
+	// UpdateWebhookEndpoint replace url and event types of the endpoint
+	UpdateWebhookEndpoint(ctx context.Context, endpoint domain.WebhookEndpoint) (domain.WebhookEndpoint, error)
+	DeleteWebhookEndpoint(ctx context.Context, merchantId string, id string) error
+	ListWebhookDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error)
+	GetWebhookDelivery(ctx context.Context, id string) (WebhookDeliveryDetail, error)
+	// ReplayWebhookDelivery send the event of the delivery again as a new delivery
+	ReplayWebhookDelivery(ctx context.Context, id string) (domain.WebhookDelivery, error)
+	// DeliverDueWebhooks send up to batchSize due deliveries, return the number of deliveries accepted by the merchants
+	DeliverDueWebhooks(ctx context.Context, batchSize int) (int, error)
+}
+
+// EventRelay publish the domain events recorded in the outbox, at least once and in order per disbursement
+type EventRelay interface {
+	// PublishDueEvents publish up to batchSize due events, return the number of events published
+	PublishDueEvents(ctx context.Context, batchSize int) (int, error)
+	// DeletePublishedEvents delete events published before the given time, return the number of deleted events
+	DeletePublishedEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+type ApiKey interface {
+	// Authenticate return the caller owning the key, internal_error.ErrUnauthorized when the key is unknown,
+	// revoked or expired
+	Authenticate(ctx context.Context, key string) (domain.Caller, error)
+	Issue(ctx context.Context, data IssueApiKeyData) (IssuedApiKey, error)
+	// Import register a key generated outside the application, it does nothing when the key already registered
+	Import(ctx context.Context, key string, data IssueApiKeyData) error
+	// Rotate issue a new key for the same client, the old key keep working until overlap passed
+	Rotate(ctx context.Context, id string, overlap time.Duration) (IssuedApiKey, error)
+	Revoke(ctx context.Context, id string) error
+	List(ctx context.Context, clientId string) ([]domain.ApiKey, error)
+}
+
+type Merchant interface {
+	CreateMerchant(ctx context.Context, merchant domain.Merchant) (domain.Merchant, error)
+	GetMerchant(ctx context.Context, id string) (domain.Merchant, error)
+	ListMerchants(ctx context.Context) ([]domain.Merchant, error)
+	// UpdateMerchant replace name and settings of the merchant
+	UpdateMerchant(ctx context.Context, merchant domain.Merchant) (domain.Merchant, error)
+}
+
+type Audit interface {
+	// Record store the audit log, actor fields are taken from the caller in ctx
+	Record(ctx context.Context, auditLog domain.AuditLog) error
+	List(ctx context.Context, filter domain.AuditLogFilter) ([]domain.AuditLog, error)
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/pii"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"log"
+	"strings"
+	"time"
+)
+
+// cachedBank decorate the bank api, an account verified before is answered from the cache until its result expire.
+// Transfers are always sent to the bank
+type cachedBank struct {
+	api.Bank
+	verificationCache repository.VerificationCache
+	positiveTTL       time.Duration
+	negativeTTL       time.Duration
+	now               func() time.Time
+}
+
+type CachedBankDeps struct {
+	Bank              api.Bank
+	VerificationCache repository.VerificationCache
+	// how long a verified account is cached
+	PositiveTTL time.Duration
+	// how long a not found or blocked account is cached, 0 to always ask the bank again
+	NegativeTTL time.Duration
+}
+
+func NewCachedBank(deps CachedBankDeps) *cachedBank {
+	return &cachedBank{
+		Bank:              deps.Bank,
+		verificationCache: deps.VerificationCache,
+		positiveTTL:       deps.PositiveTTL,
+		negativeTTL:       deps.NegativeTTL,
+		now:               time.Now,
+	}
+}
+
+// VerifyAccount ask the bank when the account is not cached, the cache being unavailable only cost a bank inquiry
+func (cb cachedBank) VerifyAccount(ctx context.Context, account api.VerifyAccountRequest) (api.VerifyAccountResponse, error) {
+	cached, err := cb.verificationCache.Get(ctx, account.BankCode, account.AccountHolderNumber, cb.now())
+	if err != nil {
+		log.Println("error reading cached verification:", err)
+	}
+
+	if cached != nil {
+		return api.VerifyAccountResponse{
+			AccountHolderName:   cached.AccountHolderName,
+			AccountHolderNumber: cached.AccountNumber,
+			AccountStatus:       api.VerifyAccountStatus(cached.AccountStatus),
+		}, nil
+	}
+
+	response, err := cb.Bank.VerifyAccount(ctx, account)
+	if err != nil {
+		return response, err
+	}
+
+	ttl := cb.ttl(response.AccountStatus)
+	if ttl <= 0 {
+		return response, nil
+	}
+
+	err = cb.verificationCache.Set(ctx, domain.AccountVerification{
+		BankCode:          account.BankCode,
+		AccountNumber:     account.AccountHolderNumber,
+		AccountHolderName: response.AccountHolderName,
+		AccountStatus:     string(response.AccountStatus),
+		ExpiresAt:         cb.now().Add(ttl),
+	})
+	if err != nil {
+		log.Println("error caching verification:", err)
+	}
+
+	return response, nil
+}
+
+// ttl return 0 for the status that should not be cached
+func (cb cachedBank) ttl(status api.VerifyAccountStatus) time.Duration {
+	switch status {
+	case api.AccountVerifiedStatus:
+		return cb.positiveTTL
+	case api.AccountNotFoundStatus, api.AccountBlockedStatus:
+		return cb.negativeTTL
+	default:
+		return 0
+	}
+}
+
+type verificationCacheUsecase struct {
+	verificationCache  repository.VerificationCache
+	auditLogRepository repository.AuditLog
+}
+
+type VerificationCacheDeps struct {
+	// nil when the verification cache is disabled
+	VerificationCache  repository.VerificationCache
+	AuditLogRepository repository.AuditLog
+}
+
+func NewVerificationCache(deps VerificationCacheDeps) *verificationCacheUsecase {
+	return &verificationCacheUsecase{
+		verificationCache:  deps.VerificationCache,
+		auditLogRepository: deps.AuditLogRepository,
+	}
+}
+
+func (vc verificationCacheUsecase) EvictVerification(ctx context.Context, bankCode string, accountNumber string, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return internal_error.ErrOperationReasonRequired
+	}
+
+	if vc.verificationCache == nil {
+		return internal_error.ErrVerificationCacheDisabled
+	}
+
+	err := vc.verificationCache.Delete(ctx, bankCode, accountNumber)
+	if err != nil {
+		log.Println(err)
+		return internal_error.ErrEvictVerification
+	}
+
+	_, err = vc.auditLogRepository.Insert(ctx, withActor(ctx, domain.AuditLog{
+		Action:     domain.AuditActionEvictVerification,
+		ResourceId: bankCode + "/" + pii.MaskAccountNumber(accountNumber),
+		Outcome:    domain.AuditOutcomeAllowed,
+		Reason:     reason,
+	}))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return nil
+}
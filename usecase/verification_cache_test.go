@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	mock_api "github.com/nobbyphala/Brick/mock/api"
+	mock_repository "github.com/nobbyphala/Brick/mock/repository"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_cachedBank_VerifyAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBankApi := mock_api.NewMockBank(ctrl)
+	mockVerificationCache := mock_repository.NewMockVerificationCache(ctrl)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	request := api.VerifyAccountRequest{
+		AccountHolderName:   "Nobby Phala",
+		AccountHolderNumber: "6789567",
+		BankCode:            "014",
+	}
+	verified := api.VerifyAccountResponse{
+		AccountHolderName:   "Nobby Phala Putra",
+		AccountHolderNumber: "6789567",
+		AccountStatus:       api.AccountVerifiedStatus,
+	}
+
+	tests := []struct {
+		name        string
+		negativeTTL time.Duration
+		want        api.VerifyAccountResponse
+		wantErr     error
+		mock        func()
+	}{
+		{
+			name: "cached account not sent to the bank",
+			want: verified,
+			mock: func() {
+				mockVerificationCache.EXPECT().Get(gomock.Any(), "014", "6789567", now).Return(&domain.AccountVerification{
+					BankCode:          "014",
+					AccountNumber:     "6789567",
+					AccountHolderName: "Nobby Phala Putra",
+					AccountStatus:     string(api.AccountVerifiedStatus),
+					ExpiresAt:         now.Add(time.Minute),
+				}, nil)
+			},
+		},
+		{
+			name: "verified account cached for the positive ttl",
+			want: verified,
+			mock: func() {
+				mockVerificationCache.EXPECT().Get(gomock.Any(), "014", "6789567", now).Return(nil, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), request).Return(verified, nil)
+				mockVerificationCache.EXPECT().Set(gomock.Any(), domain.AccountVerification{
+					BankCode:          "014",
+					AccountNumber:     "6789567",
+					AccountHolderName: "Nobby Phala Putra",
+					AccountStatus:     string(api.AccountVerifiedStatus),
+					ExpiresAt:         now.Add(time.Hour),
+				}).Return(nil)
+			},
+		},
+		{
+			name:        "blocked account cached for the negative ttl",
+			negativeTTL: time.Minute,
+			want:        api.VerifyAccountResponse{AccountStatus: api.AccountBlockedStatus},
+			mock: func() {
+				mockVerificationCache.EXPECT().Get(gomock.Any(), "014", "6789567", now).Return(nil, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), request).Return(api.VerifyAccountResponse{AccountStatus: api.AccountBlockedStatus}, nil)
+				mockVerificationCache.EXPECT().Set(gomock.Any(), domain.AccountVerification{
+					BankCode:      "014",
+					AccountNumber: "6789567",
+					AccountStatus: string(api.AccountBlockedStatus),
+					ExpiresAt:     now.Add(time.Minute),
+				}).Return(nil)
+			},
+		},
+		{
+			name: "not found account not cached without negative ttl",
+			want: api.VerifyAccountResponse{AccountStatus: api.AccountNotFoundStatus},
+			mock: func() {
+				mockVerificationCache.EXPECT().Get(gomock.Any(), "014", "6789567", now).Return(nil, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), request).Return(api.VerifyAccountResponse{AccountStatus: api.AccountNotFoundStatus}, nil)
+			},
+		},
+		{
+			name:        "unknown status not cached",
+			negativeTTL: time.Minute,
+			want:        api.VerifyAccountResponse{AccountStatus: "status: unknown"},
+			mock: func() {
+				mockVerificationCache.EXPECT().Get(gomock.Any(), "014", "6789567", now).Return(nil, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), request).Return(api.VerifyAccountResponse{AccountStatus: "status: unknown"}, nil)
+			},
+		},
+		{
+			name:    "error from bank partner not cached",
+			wantErr: errors.New("error api"),
+			mock: func() {
+				mockVerificationCache.EXPECT().Get(gomock.Any(), "014", "6789567", now).Return(nil, nil)
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), request).Return(api.VerifyAccountResponse{}, errors.New("error api"))
+			},
+		},
+		{
+			name: "cache unavailable ask the bank",
+			want: verified,
+			mock: func() {
+				mockVerificationCache.EXPECT().Get(gomock.Any(), "014", "6789567", now).Return(nil, errors.New("sql error"))
+				mockBankApi.EXPECT().VerifyAccount(gomock.Any(), request).Return(verified, nil)
+				mockVerificationCache.EXPECT().Set(gomock.Any(), gomock.Any()).Return(errors.New("sql error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			cb := NewCachedBank(CachedBankDeps{
+				Bank:              mockBankApi,
+				VerificationCache: mockVerificationCache,
+				PositiveTTL:       time.Hour,
+				NegativeTTL:       tt.negativeTTL,
+			})
+			cb.now = func() time.Time { return now }
+
+			got, err := cb.VerifyAccount(context.TODO(), request)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_verificationCacheUsecase_EvictVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVerificationCache := mock_repository.NewMockVerificationCache(ctrl)
+	mockAuditLogRepo := mock_repository.NewMockAuditLog(ctrl)
+
+	operatorCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsOperator, ApiKeyId: "key-id-1"})
+
+	tests := []struct {
+		name     string
+		reason   string
+		disabled bool
+		wantErr  error
+		mock     func()
+	}{
+		{
+			name:   "evict cached verification",
+			reason: "account closed by the holder",
+			mock: func() {
+				mockVerificationCache.EXPECT().Delete(gomock.Any(), "014", "6789567").Return(nil)
+				mockAuditLogRepo.EXPECT().Insert(gomock.Any(), domain.AuditLog{
+					ActorId:    "ops-1",
+					ActorRole:  domain.RoleOpsOperator,
+					ApiKeyId:   "key-id-1",
+					Action:     domain.AuditActionEvictVerification,
+					ResourceId: "014/***9567",
+					Outcome:    domain.AuditOutcomeAllowed,
+					Reason:     "account closed by the holder",
+				}).Return("audit-1", nil)
+			},
+		},
+		{
+			name:    "reason is mandatory",
+			reason:  " ",
+			wantErr: internal_error.ErrOperationReasonRequired,
+			mock:    func() {},
+		},
+		{
+			name:     "verification cache disabled",
+			reason:   "account closed by the holder",
+			disabled: true,
+			wantErr:  internal_error.ErrVerificationCacheDisabled,
+			mock:     func() {},
+		},
+		{
+			name:    "error delete from cache",
+			reason:  "account closed by the holder",
+			wantErr: internal_error.ErrEvictVerification,
+			mock: func() {
+				mockVerificationCache.EXPECT().Delete(gomock.Any(), "014", "6789567").Return(errors.New("sql error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			deps := VerificationCacheDeps{
+				VerificationCache:  mockVerificationCache,
+				AuditLogRepository: mockAuditLogRepo,
+			}
+			if tt.disabled {
+				deps.VerificationCache = nil
+			}
+
+			err := NewVerificationCache(deps).EvictVerification(operatorCtx, "014", "6789567", tt.reason)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
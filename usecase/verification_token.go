@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/nobbyphala/Brick/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const verificationTokenVersion = "v1"
+
+// verificationToken is a stateless proof that the recipient was verified by the bank. The token is
+// v1.<expiry unix>.<name match score>.<hex hmac-sha256 of the merchant, recipient, amount, expiry and score>, it
+// does not contain the recipient data so it only match the disbursement it was issued for
+type verificationToken struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// issue return empty token when no secret is configured
+func (vt verificationToken) issue(merchantId string, disbursement domain.Disbursement, nameMatchScore int, now time.Time) (string, time.Time) {
+	if len(vt.secret) == 0 {
+		return "", time.Time{}
+	}
+
+	expiresAt := now.Add(vt.ttl).Truncate(time.Second)
+	expiry := strconv.FormatInt(expiresAt.Unix(), 10)
+	score := strconv.Itoa(nameMatchScore)
+
+	return fmt.Sprintf("%s.%s.%s.%s", verificationTokenVersion, expiry, score, vt.sign(merchantId, disbursement, expiry, score)), expiresAt
+}
+
+// nameMatchScore return the score of the recipient name when the token was issued for the same merchant, recipient
+// and amount and has not expired
+func (vt verificationToken) nameMatchScore(token string, merchantId string, disbursement domain.Disbursement, now time.Time) (int, bool) {
+	if len(vt.secret) == 0 || token == "" {
+		return 0, false
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 4 || parts[0] != verificationTokenVersion {
+		return 0, false
+	}
+
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || !now.Before(time.Unix(expiry, 0)) {
+		return 0, false
+	}
+
+	score, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return 0, false
+	}
+
+	signature, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return 0, false
+	}
+
+	expected, _ := hex.DecodeString(vt.sign(merchantId, disbursement, parts[1], parts[2]))
+	if !hmac.Equal(signature, expected) {
+		return 0, false
+	}
+
+	return score, true
+}
+
+func (vt verificationToken) sign(merchantId string, disbursement domain.Disbursement, expiry string, score string) string {
+	mac := hmac.New(sha256.New, vt.secret)
+	// fields are quoted so a value cannot be shifted to the next field
+	fmt.Fprintf(mac, "%q.%q.%q.%q.%d.%s.%s",
+		merchantId,
+		disbursement.RecipientBankCode,
+		disbursement.RecipientAccountNumber,
+		disbursement.RecipientName,
+		disbursement.Amount,
+		expiry,
+		score,
+	)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_verificationToken(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	tokens := verificationToken{secret: []byte("verification-secret"), ttl: 5 * time.Minute}
+	verified := domain.Disbursement{
+		RecipientName:          "Nobby Phala",
+		RecipientAccountNumber: "6789567",
+		RecipientBankCode:      "014",
+		Amount:                 60000,
+	}
+
+	token, expiresAt := tokens.issue("merchant-1", verified, 85, now)
+	assert.Equal(t, now.Add(5*time.Minute), expiresAt)
+
+	withAccount := verified
+	withAccount.RecipientAccountNumber = "6789568"
+
+	type args struct {
+		token        string
+		merchantId   string
+		disbursement domain.Disbursement
+		now          time.Time
+	}
+	tests := []struct {
+		name      string
+		tokens    verificationToken
+		args      args
+		want      bool
+		wantScore int
+	}{
+		{
+			name:      "same merchant recipient and amount",
+			tokens:    tokens,
+			args:      args{token: token, merchantId: "merchant-1", disbursement: verified, now: now.Add(time.Minute)},
+			want:      true,
+			wantScore: 85,
+		},
+		{
+			name:   "expired",
+			tokens: tokens,
+			args:   args{token: token, merchantId: "merchant-1", disbursement: verified, now: expiresAt},
+			want:   false,
+		},
+		{
+			name:   "another merchant",
+			tokens: tokens,
+			args:   args{token: token, merchantId: "merchant-2", disbursement: verified, now: now},
+			want:   false,
+		},
+		{
+			name:   "another account",
+			tokens: tokens,
+			args:   args{token: token, merchantId: "merchant-1", disbursement: withAccount, now: now},
+			want:   false,
+		},
+		{
+			name:   "expiry extended",
+			tokens: tokens,
+			args:   args{token: strings.Replace(token, strings.Split(token, ".")[1], "9999999999", 1), merchantId: "merchant-1", disbursement: verified, now: now},
+			want:   false,
+		},
+		{
+			name:   "score raised",
+			tokens: tokens,
+			args:   args{token: strings.Replace(token, ".85.", ".100.", 1), merchantId: "merchant-1", disbursement: verified, now: now},
+			want:   false,
+		},
+		{
+			name:   "signed with another secret",
+			tokens: verificationToken{secret: []byte("another-secret"), ttl: 5 * time.Minute},
+			args:   args{token: token, merchantId: "merchant-1", disbursement: verified, now: now},
+			want:   false,
+		},
+		{
+			name:   "malformed",
+			tokens: tokens,
+			args:   args{token: "not a token", merchantId: "merchant-1", disbursement: verified, now: now},
+			want:   false,
+		},
+		{
+			name:   "no secret",
+			tokens: verificationToken{},
+			args:   args{token: token, merchantId: "merchant-1", disbursement: verified, now: now},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, got := tt.tokens.nameMatchScore(tt.args.token, tt.args.merchantId, tt.args.disbursement, tt.args.now)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantScore, score)
+		})
+	}
+
+	t.Run("no token issued without secret", func(t *testing.T) {
+		got, _ := verificationToken{}.issue("merchant-1", verified, 85, now)
+		assert.Empty(t, got)
+	})
+}
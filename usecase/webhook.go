@@ -0,0 +1,463 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"log"
+	"net/url"
+	"time"
+)
+
+const (
+	webhookSecretScheme = "whsec_"
+	webhookSecretSize   = 32
+)
+
+// webhookOutbox write the webhook deliveries of a disbursement change in the transaction of the change, so the
+// event is sent if and only if the change is committed
+type webhookOutbox struct {
+	endpointRepository repository.WebhookEndpoint
+	deliveryRepository repository.WebhookDelivery
+}
+
+// enqueue add a delivery of the disbursement current status to every endpoint of the merchant subscribing the
+// event, disbursement.Version should be the version after the change
+func (outbox webhookOutbox) enqueue(ctx context.Context, Tx database.SQLDatabase, disbursement domain.Disbursement) error {
+	// disbursement created before multi tenancy has no merchant to notify
+	if disbursement.MerchantId == "" {
+		return nil
+	}
+
+	eventType, ok := domain.WebhookEventTypeOf(disbursement.Status)
+	if !ok {
+		return nil
+	}
+
+	endpoints, err := outbox.endpointRepository.WithTx(Tx).ListByMerchantId(ctx, disbursement.MerchantId)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	event := WebhookEvent{
+		Id:        fmt.Sprintf("%s:v%d", disbursement.Id, disbursement.Version),
+		Type:      eventType,
+		CreatedAt: now.UTC(),
+		Data: WebhookEventData{
+			Id:                disbursement.Id,
+			Amount:            disbursement.Amount,
+			RecipientBankCode: disbursement.RecipientBankCode,
+			Status:            disbursement.Status.ToString(),
+		},
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	deliveryRepo := outbox.deliveryRepository.WithTx(Tx)
+
+	for _, endpoint := range endpoints {
+		if !endpoint.Subscribes(eventType) {
+			continue
+		}
+
+		_, err = deliveryRepo.Insert(ctx, domain.WebhookDelivery{
+			EndpointId:     endpoint.Id,
+			MerchantId:     disbursement.MerchantId,
+			EventId:        event.Id,
+			EventType:      eventType,
+			DisbursementId: disbursement.Id,
+			Payload:        payload,
+			Status:         domain.WebhookDeliveryStatusPending,
+			NextAttemptAt:  now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type webhookUsecase struct {
+	webhookApi         api.Webhook
+	endpointRepository repository.WebhookEndpoint
+	deliveryRepository repository.WebhookDelivery
+	merchantRepository repository.Merchant
+	auditLogRepository repository.AuditLog
+	utilsRepository    repository.Utils
+	maxAttempts        int
+	retryBaseDelay     time.Duration
+	retryMaxDelay      time.Duration
+	lease              time.Duration
+}
+
+type WebhookDeps struct {
+	WebhookApi                api.Webhook
+	WebhookEndpointRepository repository.WebhookEndpoint
+	WebhookDeliveryRepository repository.WebhookDelivery
+	MerchantRepository        repository.Merchant
+	AuditLogRepository        repository.AuditLog
+	UtilsRepository           repository.Utils
+	// delivery is failed after this many attempts, it can still be replayed manually
+	MaxAttempts int
+	// delay before the next attempt is doubled after every failed attempt, up to RetryMaxDelay
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// how long a claimed delivery is hidden from other workers, should be longer than the request timeout
+	Lease time.Duration
+}
+
+func NewWebhook(deps WebhookDeps) *webhookUsecase {
+	return &webhookUsecase{
+		webhookApi:         deps.WebhookApi,
+		endpointRepository: deps.WebhookEndpointRepository,
+		deliveryRepository: deps.WebhookDeliveryRepository,
+		merchantRepository: deps.MerchantRepository,
+		auditLogRepository: deps.AuditLogRepository,
+		utilsRepository:    deps.UtilsRepository,
+		maxAttempts:        deps.MaxAttempts,
+		retryBaseDelay:     deps.RetryBaseDelay,
+		retryMaxDelay:      deps.RetryMaxDelay,
+		lease:              deps.Lease,
+	}
+}
+
+func (wu webhookUsecase) CreateWebhookEndpoint(ctx context.Context, endpoint domain.WebhookEndpoint) (domain.WebhookEndpoint, error) {
+	err := validateWebhookEndpoint(endpoint)
+	if err != nil {
+		return domain.WebhookEndpoint{}, err
+	}
+
+	merchant, err := wu.merchantRepository.GetById(ctx, endpoint.MerchantId)
+	if err != nil {
+		log.Println(err)
+		return domain.WebhookEndpoint{}, err
+	}
+
+	if merchant == nil {
+		return domain.WebhookEndpoint{}, internal_error.ErrMerchantNotFound
+	}
+
+	endpoint.Secret, err = generateWebhookSecret()
+	if err != nil {
+		log.Println(err)
+		return domain.WebhookEndpoint{}, err
+	}
+
+	endpointId, err := wu.endpointRepository.Insert(ctx, endpoint)
+	if err != nil {
+		log.Println(err)
+		return domain.WebhookEndpoint{}, err
+	}
+
+	// creation time is set by the database
+	return wu.getEndpoint(ctx, endpoint.MerchantId, endpointId)
+}
+
+func (wu webhookUsecase) ListWebhookEndpoints(ctx context.Context, merchantId string) ([]domain.WebhookEndpoint, error) {
+	return wu.endpointRepository.ListByMerchantId(ctx, merchantId)
+}
+
+func (wu webhookUsecase) UpdateWebhookEndpoint(ctx context.Context, endpoint domain.WebhookEndpoint) (domain.WebhookEndpoint, error) {
+	err := validateWebhookEndpoint(endpoint)
+	if err != nil {
+		return domain.WebhookEndpoint{}, err
+	}
+
+	existing, err := wu.getEndpoint(ctx, endpoint.MerchantId, endpoint.Id)
+	if err != nil {
+		return domain.WebhookEndpoint{}, err
+	}
+
+	err = wu.endpointRepository.UpdateById(ctx, endpoint.Id, endpoint)
+	if err != nil {
+		log.Println(err)
+		return domain.WebhookEndpoint{}, err
+	}
+
+	existing.Url = endpoint.Url
+	existing.EventTypes = endpoint.EventTypes
+
+	return existing, nil
+}
+
+func (wu webhookUsecase) DeleteWebhookEndpoint(ctx context.Context, merchantId string, id string) error {
+	_, err := wu.getEndpoint(ctx, merchantId, id)
+	if err != nil {
+		return err
+	}
+
+	err = wu.endpointRepository.DeleteById(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return nil
+}
+
+func (wu webhookUsecase) getEndpoint(ctx context.Context, merchantId string, id string) (domain.WebhookEndpoint, error) {
+	endpoint, err := wu.endpointRepository.GetById(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return domain.WebhookEndpoint{}, err
+	}
+
+	if endpoint == nil || endpoint.MerchantId != merchantId {
+		return domain.WebhookEndpoint{}, internal_error.ErrWebhookEndpointNotFound
+	}
+
+	return *endpoint, nil
+}
+
+func (wu webhookUsecase) ListWebhookDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	return wu.deliveryRepository.List(ctx, filter)
+}
+
+func (wu webhookUsecase) GetWebhookDelivery(ctx context.Context, id string) (WebhookDeliveryDetail, error) {
+	delivery, err := wu.getDelivery(ctx, wu.deliveryRepository, id)
+	if err != nil {
+		return WebhookDeliveryDetail{}, err
+	}
+
+	attempts, err := wu.deliveryRepository.ListAttempts(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return WebhookDeliveryDetail{}, err
+	}
+
+	return WebhookDeliveryDetail{
+		Delivery: delivery,
+		Attempts: attempts,
+	}, nil
+}
+
+func (wu webhookUsecase) ReplayWebhookDelivery(ctx context.Context, id string) (domain.WebhookDelivery, error) {
+	var res domain.WebhookDelivery
+
+	err := wu.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+		deliveryRepo := wu.deliveryRepository.WithTx(Tx)
+
+		delivery, err := wu.getDelivery(ctx, deliveryRepo, id)
+		if err != nil {
+			return err
+		}
+
+		endpoint, err := wu.endpointRepository.WithTx(Tx).GetById(ctx, delivery.EndpointId)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		if endpoint == nil {
+			return internal_error.ErrWebhookEndpointNotFound
+		}
+
+		// same event id so the merchant can tell it is the same event
+		res = domain.WebhookDelivery{
+			EndpointId:     delivery.EndpointId,
+			MerchantId:     delivery.MerchantId,
+			EventId:        delivery.EventId,
+			EventType:      delivery.EventType,
+			DisbursementId: delivery.DisbursementId,
+			Payload:        delivery.Payload,
+			Status:         domain.WebhookDeliveryStatusPending,
+			NextAttemptAt:  time.Now(),
+			ReplayOf:       delivery.Id,
+		}
+
+		replayId, err := deliveryRepo.Insert(ctx, res)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		// creation time is set by the database
+		res, err = wu.getDelivery(ctx, deliveryRepo, replayId)
+		if err != nil {
+			return err
+		}
+
+		_, err = wu.auditLogRepository.WithTx(Tx).Insert(ctx, withActor(ctx, domain.AuditLog{
+			Action:     domain.AuditActionReplayWebhook,
+			ResourceId: delivery.Id,
+			Outcome:    domain.AuditOutcomeAllowed,
+			Reason:     fmt.Sprintf("replay %s of %s as %s", delivery.EventId, delivery.Status, res.Id),
+		}))
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	return res, nil
+}
+
+func (wu webhookUsecase) getDelivery(ctx context.Context, deliveryRepo repository.WebhookDelivery, id string) (domain.WebhookDelivery, error) {
+	delivery, err := deliveryRepo.GetById(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return domain.WebhookDelivery{}, err
+	}
+
+	if delivery == nil {
+		return domain.WebhookDelivery{}, internal_error.ErrWebhookDeliveryNotFound
+	}
+
+	return *delivery, nil
+}
+
+func (wu webhookUsecase) DeliverDueWebhooks(ctx context.Context, batchSize int) (int, error) {
+	now := time.Now()
+
+	deliveries, err := wu.deliveryRepository.ClaimDue(ctx, now, now.Add(wu.lease), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		ok, err := wu.deliver(ctx, delivery)
+		if err != nil {
+			// the lease expire and the delivery is claimed again
+			log.Println("error recording webhook delivery", delivery.Id, err)
+			continue
+		}
+
+		if ok {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+// deliver send the delivery once and record the attempt, return true when the merchant accepted it
+func (wu webhookUsecase) deliver(ctx context.Context, delivery domain.WebhookDelivery) (bool, error) {
+	endpoint, err := wu.endpointRepository.GetById(ctx, delivery.EndpointId)
+	if err != nil {
+		return false, err
+	}
+
+	attempt := domain.WebhookDeliveryAttempt{
+		DeliveryId: delivery.Id,
+		Attempt:    delivery.Attempts + 1,
+	}
+
+	if endpoint == nil {
+		attempt.Error = "endpoint deleted"
+	} else {
+		start := time.Now()
+		response, err := wu.webhookApi.Send(ctx, api.WebhookRequest{
+			Url:        endpoint.Url,
+			Secret:     endpoint.Secret,
+			DeliveryId: delivery.Id,
+			EventType:  string(delivery.EventType),
+			Payload:    delivery.Payload,
+		})
+		attempt.Duration = time.Since(start)
+		attempt.StatusCode = response.StatusCode
+
+		if err != nil {
+			attempt.Error = err.Error()
+		} else if !isSuccessStatusCode(response.StatusCode) {
+			attempt.Error = fmt.Sprintf("unexpected status code %d", response.StatusCode)
+		}
+	}
+
+	now := time.Now()
+	delivery.Attempts = attempt.Attempt
+	delivery.LastStatusCode = attempt.StatusCode
+	delivery.LastError = attempt.Error
+
+	switch {
+	case attempt.Error == "":
+		delivery.Status = domain.WebhookDeliveryStatusDelivered
+		delivery.DeliveredAt = &now
+	case endpoint == nil || delivery.Attempts >= wu.maxAttempts:
+		delivery.Status = domain.WebhookDeliveryStatusFailed
+	default:
+		delivery.NextAttemptAt = now.Add(wu.retryDelay(delivery.Attempts))
+	}
+
+	err = wu.utilsRepository.RunWithTransaction(ctx, func(ctx context.Context, Tx database.SQLDatabase) error {
+		deliveryRepo := wu.deliveryRepository.WithTx(Tx)
+
+		_, err := deliveryRepo.InsertAttempt(ctx, attempt)
+		if err != nil {
+			return err
+		}
+
+		return deliveryRepo.UpdateById(ctx, delivery.Id, delivery)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return delivery.Status == domain.WebhookDeliveryStatusDelivered, nil
+}
+
+// retryDelay return the delay after the given number of failed attempts
+func (wu webhookUsecase) retryDelay(attempts int) time.Duration {
+	return exponentialBackoff(wu.retryBaseDelay, wu.retryMaxDelay, attempts)
+}
+
+// exponentialBackoff return baseDelay doubled for every failed attempt after the first, up to maxDelay
+func exponentialBackoff(baseDelay time.Duration, maxDelay time.Duration, attempts int) time.Duration {
+	delay := baseDelay
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxDelay {
+		return maxDelay
+	}
+
+	return delay
+}
+
+func isSuccessStatusCode(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 300
+}
+
+func validateWebhookEndpoint(endpoint domain.WebhookEndpoint) error {
+	endpointUrl, err := url.Parse(endpoint.Url)
+	if err != nil || (endpointUrl.Scheme != "http" && endpointUrl.Scheme != "https") || endpointUrl.Host == "" {
+		return internal_error.ErrWebhookEndpointInvalid
+	}
+
+	for _, eventType := range endpoint.EventTypes {
+		if !eventType.IsValid() {
+			return internal_error.ErrWebhookEndpointInvalid
+		}
+	}
+
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretSize)
+
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return webhookSecretScheme + base64.RawURLEncoding.EncodeToString(secret), nil
+}
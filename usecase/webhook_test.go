@@ -0,0 +1,420 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/nobbyphala/Brick/domain"
+	"github.com/nobbyphala/Brick/domain/internal_error"
+	"github.com/nobbyphala/Brick/external/database"
+	"github.com/nobbyphala/Brick/mock"
+	mock_api "github.com/nobbyphala/Brick/mock/api"
+	mock_repository "github.com/nobbyphala/Brick/mock/repository"
+	"github.com/nobbyphala/Brick/usecase/api"
+	"github.com/nobbyphala/Brick/usecase/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_webhookOutbox_enqueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+	mockEndpointRepo := mock_repository.NewMockWebhookEndpoint(ctrl)
+	mockDeliveryRepo := mock_repository.NewMockWebhookDelivery(ctrl)
+
+	completed := domain.Disbursement{
+		Id:                     "disb-id-1",
+		MerchantId:             "merchant-1",
+		RecipientName:          "John Doe",
+		RecipientAccountNumber: "1234567890",
+		RecipientBankCode:      "014",
+		Amount:                 60000,
+		Status:                 domain.DisbursementStatusCompleted,
+		Version:                2,
+	}
+
+	tests := []struct {
+		name         string
+		disbursement domain.Disbursement
+		mock         func()
+		wantErr      error
+	}{
+		{
+			name:         "enqueue to subscribed endpoints",
+			disbursement: completed,
+			mock: func() {
+				mockEndpointRepo.EXPECT().WithTx(mockSQL).Return(mockEndpointRepo)
+				mockEndpointRepo.EXPECT().ListByMerchantId(gomock.Any(), "merchant-1").Return([]domain.WebhookEndpoint{
+					{Id: "endpoint-1", MerchantId: "merchant-1"},
+					{Id: "endpoint-2", MerchantId: "merchant-1", EventTypes: []domain.WebhookEventType{domain.WebhookEventDisbursementFailed}},
+				}, nil)
+				mockDeliveryRepo.EXPECT().WithTx(mockSQL).Return(mockDeliveryRepo)
+				mockDeliveryRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, delivery domain.WebhookDelivery) (string, error) {
+					assert.Equal(t, "endpoint-1", delivery.EndpointId)
+					assert.Equal(t, "merchant-1", delivery.MerchantId)
+					assert.Equal(t, "disb-id-1:v2", delivery.EventId)
+					assert.Equal(t, domain.WebhookEventDisbursementCompleted, delivery.EventType)
+					assert.Equal(t, domain.WebhookDeliveryStatusPending, delivery.Status)
+					assert.Contains(t, string(delivery.Payload), `"data":{"id":"disb-id-1","amount":60000,"recipient_bank_code":"014","status":"COMPLETED"}`)
+					// recipient is not sent
+					assert.NotContains(t, string(delivery.Payload), "John Doe")
+					assert.NotContains(t, string(delivery.Payload), "1234567890")
+					return "delivery-1", nil
+				})
+			},
+		},
+		{
+			name:         "disbursement without merchant",
+			disbursement: domain.Disbursement{Id: "disb-id-1", Status: domain.DisbursementStatusCompleted},
+			mock:         func() {},
+		},
+		{
+			name:         "error list endpoints",
+			disbursement: completed,
+			mock: func() {
+				mockEndpointRepo.EXPECT().WithTx(mockSQL).Return(mockEndpointRepo)
+				mockEndpointRepo.EXPECT().ListByMerchantId(gomock.Any(), "merchant-1").Return(nil, errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			outbox := webhookOutbox{
+				endpointRepository: mockEndpointRepo,
+				deliveryRepository: mockDeliveryRepo,
+			}
+			err := outbox.enqueue(context.TODO(), mockSQL, tt.disbursement)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_webhookUsecase_DeliverDueWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+	mockWebhookApi := mock_api.NewMockWebhook(ctrl)
+	mockEndpointRepo := mock_repository.NewMockWebhookEndpoint(ctrl)
+	mockDeliveryRepo := mock_repository.NewMockWebhookDelivery(ctrl)
+	mockUtilRepo := mock_repository.NewMockUtils(ctrl)
+
+	endpoint := domain.WebhookEndpoint{Id: "endpoint-1", MerchantId: "merchant-1", Url: "https://merchant.test/webhook", Secret: "whsec_secret"}
+	delivery := domain.WebhookDelivery{
+		Id:         "delivery-1",
+		EndpointId: "endpoint-1",
+		EventId:    "disb-id-1:v2",
+		EventType:  domain.WebhookEventDisbursementCompleted,
+		Payload:    []byte(`{}`),
+		Status:     domain.WebhookDeliveryStatusPending,
+		Attempts:   2,
+	}
+	expectRecorded := func(check func(attempt domain.WebhookDeliveryAttempt, delivery domain.WebhookDelivery)) {
+		var recordedAttempt domain.WebhookDeliveryAttempt
+
+		mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+			return handler(ctx, mockSQL)
+		})
+		mockDeliveryRepo.EXPECT().WithTx(mockSQL).Return(mockDeliveryRepo)
+		mockDeliveryRepo.EXPECT().InsertAttempt(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, attempt domain.WebhookDeliveryAttempt) (string, error) {
+			recordedAttempt = attempt
+			return "attempt-1", nil
+		})
+		mockDeliveryRepo.EXPECT().UpdateById(gomock.Any(), "delivery-1", gomock.Any()).DoAndReturn(func(ctx context.Context, id string, updated domain.WebhookDelivery) error {
+			check(recordedAttempt, updated)
+			return nil
+		})
+	}
+
+	tests := []struct {
+		name    string
+		mock    func()
+		want    int
+		wantErr error
+	}{
+		{
+			name: "delivered",
+			mock: func() {
+				mockDeliveryRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]domain.WebhookDelivery{delivery}, nil)
+				mockEndpointRepo.EXPECT().GetById(gomock.Any(), "endpoint-1").Return(&endpoint, nil)
+				mockWebhookApi.EXPECT().Send(gomock.Any(), api.WebhookRequest{
+					Url:        "https://merchant.test/webhook",
+					Secret:     "whsec_secret",
+					DeliveryId: "delivery-1",
+					EventType:  "disbursement.completed",
+					Payload:    []byte(`{}`),
+				}).Return(api.WebhookResponse{StatusCode: 204}, nil)
+				expectRecorded(func(attempt domain.WebhookDeliveryAttempt, updated domain.WebhookDelivery) {
+					assert.Equal(t, 3, attempt.Attempt)
+					assert.Equal(t, 204, attempt.StatusCode)
+					assert.Empty(t, attempt.Error)
+					assert.Equal(t, domain.WebhookDeliveryStatusDelivered, updated.Status)
+					assert.Equal(t, 3, updated.Attempts)
+					assert.NotNil(t, updated.DeliveredAt)
+				})
+			},
+			want: 1,
+		},
+		{
+			name: "retry with backoff on error response",
+			mock: func() {
+				mockDeliveryRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]domain.WebhookDelivery{delivery}, nil)
+				mockEndpointRepo.EXPECT().GetById(gomock.Any(), "endpoint-1").Return(&endpoint, nil)
+				mockWebhookApi.EXPECT().Send(gomock.Any(), gomock.Any()).Return(api.WebhookResponse{StatusCode: 503}, nil)
+				expectRecorded(func(attempt domain.WebhookDeliveryAttempt, updated domain.WebhookDelivery) {
+					assert.Equal(t, "unexpected status code 503", attempt.Error)
+					assert.Equal(t, domain.WebhookDeliveryStatusPending, updated.Status)
+					assert.Equal(t, 503, updated.LastStatusCode)
+					// third failed attempt wait 4x the base delay
+					assert.WithinDuration(t, time.Now().Add(4*time.Minute), updated.NextAttemptAt, 5*time.Second)
+				})
+			},
+		},
+		{
+			name: "failed after max attempts",
+			mock: func() {
+				lastAttempt := delivery
+				lastAttempt.Attempts = 4
+				mockDeliveryRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]domain.WebhookDelivery{lastAttempt}, nil)
+				mockEndpointRepo.EXPECT().GetById(gomock.Any(), "endpoint-1").Return(&endpoint, nil)
+				mockWebhookApi.EXPECT().Send(gomock.Any(), gomock.Any()).Return(api.WebhookResponse{}, errors.New("connection refused"))
+				expectRecorded(func(attempt domain.WebhookDeliveryAttempt, updated domain.WebhookDelivery) {
+					assert.Equal(t, "connection refused", attempt.Error)
+					assert.Equal(t, domain.WebhookDeliveryStatusFailed, updated.Status)
+					assert.Equal(t, 5, updated.Attempts)
+				})
+			},
+		},
+		{
+			name: "endpoint deleted",
+			mock: func() {
+				mockDeliveryRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]domain.WebhookDelivery{delivery}, nil)
+				mockEndpointRepo.EXPECT().GetById(gomock.Any(), "endpoint-1").Return(nil, nil)
+				expectRecorded(func(attempt domain.WebhookDeliveryAttempt, updated domain.WebhookDelivery) {
+					assert.Equal(t, "endpoint deleted", attempt.Error)
+					assert.Equal(t, domain.WebhookDeliveryStatusFailed, updated.Status)
+				})
+			},
+		},
+		{
+			name: "error claim",
+			mock: func() {
+				mockDeliveryRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return(nil, errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			wu := NewWebhook(WebhookDeps{
+				WebhookApi:                mockWebhookApi,
+				WebhookEndpointRepository: mockEndpointRepo,
+				WebhookDeliveryRepository: mockDeliveryRepo,
+				UtilsRepository:           mockUtilRepo,
+				MaxAttempts:               5,
+				RetryBaseDelay:            time.Minute,
+				RetryMaxDelay:             time.Hour,
+				Lease:                     time.Minute,
+			})
+			got, err := wu.DeliverDueWebhooks(context.TODO(), 10)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_webhookUsecase_retryDelay(t *testing.T) {
+	wu := NewWebhook(WebhookDeps{RetryBaseDelay: 30 * time.Second, RetryMaxDelay: 6 * time.Hour})
+
+	assert.Equal(t, 30*time.Second, wu.retryDelay(1))
+	assert.Equal(t, time.Minute, wu.retryDelay(2))
+	assert.Equal(t, 8*time.Minute, wu.retryDelay(5))
+	assert.Equal(t, 6*time.Hour, wu.retryDelay(30))
+}
+
+func Test_webhookUsecase_ReplayWebhookDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQL := mock.NewMockSQLDatabase(ctrl)
+	mockEndpointRepo := mock_repository.NewMockWebhookEndpoint(ctrl)
+	mockDeliveryRepo := mock_repository.NewMockWebhookDelivery(ctrl)
+	mockAuditLogRepo := mock_repository.NewMockAuditLog(ctrl)
+	mockUtilRepo := mock_repository.NewMockUtils(ctrl)
+
+	operatorCtx := domain.ContextWithCaller(context.TODO(), domain.Caller{Id: "ops-1", Role: domain.RoleOpsOperator})
+	failed := &domain.WebhookDelivery{
+		Id:             "delivery-1",
+		EndpointId:     "endpoint-1",
+		MerchantId:     "merchant-1",
+		EventId:        "disb-id-1:v2",
+		EventType:      domain.WebhookEventDisbursementCompleted,
+		DisbursementId: "disb-id-1",
+		Payload:        []byte(`{}`),
+		Status:         domain.WebhookDeliveryStatusFailed,
+		Attempts:       10,
+	}
+	runTx := func() {
+		mockUtilRepo.EXPECT().RunWithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handler func(ctx context.Context, Tx database.SQLDatabase) error, opts ...repository.TxOption) error {
+			return handler(ctx, mockSQL)
+		})
+		mockDeliveryRepo.EXPECT().WithTx(mockSQL).Return(mockDeliveryRepo)
+	}
+
+	tests := []struct {
+		name    string
+		mock    func()
+		want    domain.WebhookDelivery
+		wantErr error
+	}{
+		{
+			name: "success replay",
+			mock: func() {
+				runTx()
+				mockDeliveryRepo.EXPECT().GetById(gomock.Any(), "delivery-1").Return(failed, nil)
+				mockEndpointRepo.EXPECT().WithTx(mockSQL).Return(mockEndpointRepo)
+				mockEndpointRepo.EXPECT().GetById(gomock.Any(), "endpoint-1").Return(&domain.WebhookEndpoint{Id: "endpoint-1"}, nil)
+				mockDeliveryRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, delivery domain.WebhookDelivery) (string, error) {
+					assert.Equal(t, "disb-id-1:v2", delivery.EventId)
+					assert.Equal(t, "delivery-1", delivery.ReplayOf)
+					assert.Equal(t, domain.WebhookDeliveryStatusPending, delivery.Status)
+					return "delivery-2", nil
+				})
+				mockDeliveryRepo.EXPECT().GetById(gomock.Any(), "delivery-2").Return(&domain.WebhookDelivery{
+					Id:             "delivery-2",
+					EndpointId:     "endpoint-1",
+					MerchantId:     "merchant-1",
+					EventId:        "disb-id-1:v2",
+					EventType:      domain.WebhookEventDisbursementCompleted,
+					DisbursementId: "disb-id-1",
+					Payload:        []byte(`{}`),
+					Status:         domain.WebhookDeliveryStatusPending,
+					ReplayOf:       "delivery-1",
+				}, nil)
+				mockAuditLogRepo.EXPECT().WithTx(mockSQL).Return(mockAuditLogRepo)
+				mockAuditLogRepo.EXPECT().Insert(gomock.Any(), domain.AuditLog{
+					ActorId:    "ops-1",
+					ActorRole:  domain.RoleOpsOperator,
+					Action:     domain.AuditActionReplayWebhook,
+					ResourceId: "delivery-1",
+					Outcome:    domain.AuditOutcomeAllowed,
+					Reason:     "replay disb-id-1:v2 of FAILED as delivery-2",
+				}).Return("audit-id-1", nil)
+			},
+			want: domain.WebhookDelivery{
+				Id:             "delivery-2",
+				EndpointId:     "endpoint-1",
+				MerchantId:     "merchant-1",
+				EventId:        "disb-id-1:v2",
+				EventType:      domain.WebhookEventDisbursementCompleted,
+				DisbursementId: "disb-id-1",
+				Payload:        []byte(`{}`),
+				Status:         domain.WebhookDeliveryStatusPending,
+				ReplayOf:       "delivery-1",
+			},
+		},
+		{
+			name: "delivery not found",
+			mock: func() {
+				runTx()
+				mockDeliveryRepo.EXPECT().GetById(gomock.Any(), "delivery-1").Return(nil, nil)
+			},
+			wantErr: internal_error.ErrWebhookDeliveryNotFound,
+		},
+		{
+			name: "endpoint deleted",
+			mock: func() {
+				runTx()
+				mockDeliveryRepo.EXPECT().GetById(gomock.Any(), "delivery-1").Return(failed, nil)
+				mockEndpointRepo.EXPECT().WithTx(mockSQL).Return(mockEndpointRepo)
+				mockEndpointRepo.EXPECT().GetById(gomock.Any(), "endpoint-1").Return(nil, nil)
+			},
+			wantErr: internal_error.ErrWebhookEndpointNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			wu := NewWebhook(WebhookDeps{
+				WebhookEndpointRepository: mockEndpointRepo,
+				WebhookDeliveryRepository: mockDeliveryRepo,
+				AuditLogRepository:        mockAuditLogRepo,
+				UtilsRepository:           mockUtilRepo,
+			})
+			got, err := wu.ReplayWebhookDelivery(operatorCtx, "delivery-1")
+			assert.Equal(t, tt.wantErr, err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_webhookUsecase_CreateWebhookEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEndpointRepo := mock_repository.NewMockWebhookEndpoint(ctrl)
+	mockMerchantRepo := mock_repository.NewMockMerchant(ctrl)
+
+	tests := []struct {
+		name     string
+		endpoint domain.WebhookEndpoint
+		mock     func()
+		wantErr  error
+	}{
+		{
+			name:     "success create",
+			endpoint: domain.WebhookEndpoint{MerchantId: "merchant-1", Url: "https://merchant.test/webhook"},
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(&domain.Merchant{Id: "merchant-1"}, nil)
+				mockEndpointRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, endpoint domain.WebhookEndpoint) (string, error) {
+					assert.True(t, strings.HasPrefix(endpoint.Secret, "whsec_"))
+					return "endpoint-1", nil
+				})
+				mockEndpointRepo.EXPECT().GetById(gomock.Any(), "endpoint-1").Return(&domain.WebhookEndpoint{Id: "endpoint-1", MerchantId: "merchant-1", Secret: "whsec_secret"}, nil)
+			},
+		},
+		{
+			name:     "invalid url",
+			endpoint: domain.WebhookEndpoint{MerchantId: "merchant-1", Url: "ftp://merchant.test/webhook"},
+			mock:     func() {},
+			wantErr:  internal_error.ErrWebhookEndpointInvalid,
+		},
+		{
+			name:     "unknown event type",
+			endpoint: domain.WebhookEndpoint{MerchantId: "merchant-1", Url: "https://merchant.test/webhook", EventTypes: []domain.WebhookEventType{"disbursement.unknown"}},
+			mock:     func() {},
+			wantErr:  internal_error.ErrWebhookEndpointInvalid,
+		},
+		{
+			name:     "merchant not found",
+			endpoint: domain.WebhookEndpoint{MerchantId: "merchant-1", Url: "https://merchant.test/webhook"},
+			mock: func() {
+				mockMerchantRepo.EXPECT().GetById(gomock.Any(), "merchant-1").Return(nil, nil)
+			},
+			wantErr: internal_error.ErrMerchantNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			wu := NewWebhook(WebhookDeps{
+				WebhookEndpointRepository: mockEndpointRepo,
+				MerchantRepository:        mockMerchantRepo,
+			})
+			_, err := wu.CreateWebhookEndpoint(context.TODO(), tt.endpoint)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}